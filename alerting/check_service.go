@@ -0,0 +1,122 @@
+// Package alerting runs checks as tasks and reports the status of the series they monitor,
+// notifying endpoints of the changes of their levels.
+package alerting
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+// CheckService is a platform.CheckService managing the tasks running the checks
+// stored by the underlying service.
+type CheckService struct {
+	platform.CheckService
+	TaskService platform.TaskService
+}
+
+var _ platform.CheckService = (*CheckService)(nil)
+
+// NewCheckService returns a CheckService storing checks in s and running them as tasks of ts.
+func NewCheckService(s platform.CheckService, ts platform.TaskService) *CheckService {
+	return &CheckService{
+		CheckService: s,
+		TaskService:  ts,
+	}
+}
+
+// CreateCheck creates the task running c, then stores c.
+func (s *CheckService) CreateCheck(ctx context.Context, c *platform.Check) error {
+	op := "alerting/create check"
+	if c.Status == "" {
+		c.Status = platform.CheckActive
+	}
+	script, err := c.GenerateFlux()
+	if err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Err:  err,
+		}
+	}
+
+	t := &platform.Task{
+		Organization: c.OrganizationID,
+		Owner:        platform.User{ID: c.OwnerID},
+		Flux:         script,
+	}
+	if err := s.TaskService.CreateTask(ctx, t); err != nil {
+		return &platform.Error{
+			Op:  op,
+			Err: err,
+		}
+	}
+	if c.Status != platform.CheckActive {
+		if _, err := s.TaskService.UpdateTask(ctx, t.ID, platform.TaskUpdate{Status: &c.Status}); err != nil {
+			_ = s.TaskService.DeleteTask(ctx, t.ID)
+			return &platform.Error{
+				Op:  op,
+				Err: err,
+			}
+		}
+	}
+
+	c.TaskID = t.ID
+	if err := s.CheckService.CreateCheck(ctx, c); err != nil {
+		_ = s.TaskService.DeleteTask(ctx, t.ID)
+		return err
+	}
+	return nil
+}
+
+// UpdateCheck updates the task running the check, then stores the updated check.
+func (s *CheckService) UpdateCheck(ctx context.Context, id platform.ID, upd platform.CheckUpdate) (*platform.Check, error) {
+	op := "alerting/update check"
+	if err := upd.Valid(); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Err:  err,
+		}
+	}
+
+	c, err := s.CheckService.FindCheckByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	upd.Apply(c)
+	script, err := c.GenerateFlux()
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Err:  err,
+		}
+	}
+
+	if _, err := s.TaskService.UpdateTask(ctx, c.TaskID, platform.TaskUpdate{Flux: &script, Status: &c.Status}); err != nil {
+		return nil, &platform.Error{
+			Op:  op,
+			Err: err,
+		}
+	}
+	return s.CheckService.UpdateCheck(ctx, id, upd)
+}
+
+// DeleteCheck deletes the check and its task.
+func (s *CheckService) DeleteCheck(ctx context.Context, id platform.ID) error {
+	c, err := s.CheckService.FindCheckByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.CheckService.DeleteCheck(ctx, id); err != nil {
+		return err
+	}
+	if err := s.TaskService.DeleteTask(ctx, c.TaskID); err != nil {
+		return &platform.Error{
+			Op:  "alerting/delete check",
+			Err: err,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package alerting_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/alerting"
+	"github.com/influxdata/platform/inmem"
+	_ "github.com/influxdata/platform/query/builtin"
+	"github.com/influxdata/platform/task"
+	"github.com/influxdata/platform/task/backend"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func newTestCheck() *platform.Check {
+	return &platform.Check{
+		OrganizationID: platformtesting.MustIDBase16("aaaaaaaaaaaaaaaa"),
+		OwnerID:        platformtesting.MustIDBase16("bbbbbbbbbbbbbbbb"),
+		Name:           "high cpu",
+		Type:           platform.ThresholdCheckType,
+		Every:          "1m",
+		Query: platform.CheckQuery{
+			Bucket:      "telegraf",
+			Measurement: "cpu",
+			Field:       "usage_user",
+		},
+		Thresholds: []platform.Threshold{
+			{Level: platform.CheckLevelWarn, Value: 70},
+			{Level: platform.CheckLevelCrit, Value: 90},
+		},
+	}
+}
+
+func TestCheckService(t *testing.T) {
+	ctx := context.Background()
+	ts := task.PlatformAdapter(backend.NewInMemStore(), backend.NopLogReader{})
+	s := alerting.NewCheckService(inmem.NewService(), ts)
+
+	c := newTestCheck()
+	if err := s.CreateCheck(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Status != platform.CheckActive {
+		t.Fatalf("expected new check to be active, got %q", c.Status)
+	}
+
+	tk, err := ts.FindTaskByID(ctx, c.TaskID)
+	if err != nil {
+		t.Fatalf("failed to find task of check: %v", err)
+	}
+	script, err := c.GenerateFlux()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.Flux != script || tk.Status != string(backend.TaskActive) || tk.Owner.ID != c.OwnerID {
+		t.Fatalf("unexpected task of check: %+v", tk)
+	}
+
+	every := "5m"
+	inactive := platform.CheckInactive
+	c, err = s.UpdateCheck(ctx, c.ID, platform.CheckUpdate{Every: &every, Status: &inactive})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tk, err = ts.FindTaskByID(ctx, c.TaskID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(tk.Flux, "every: 5m") || tk.Status != string(backend.TaskInactive) {
+		t.Fatalf("expected task to be updated with the check, got %+v", tk)
+	}
+
+	invalid := "never"
+	if _, err := s.UpdateCheck(ctx, c.ID, platform.CheckUpdate{Every: &invalid}); platform.ErrorCode(err) != platform.EInvalid {
+		t.Fatalf("expected invalid update to fail with %q, got %v", platform.EInvalid, err)
+	}
+
+	if err := s.DeleteCheck(ctx, c.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.FindCheckByID(ctx, c.ID); platform.ErrorCode(err) != platform.ENotFound {
+		t.Fatalf("expected check to be deleted, got %v", err)
+	}
+	if _, err := ts.FindTaskByID(ctx, c.TaskID); err == nil {
+		t.Fatal("expected task of check to be deleted")
+	}
+}
+
+func TestCheckService_CreateInvalid(t *testing.T) {
+	ctx := context.Background()
+	ts := task.PlatformAdapter(backend.NewInMemStore(), backend.NopLogReader{})
+	s := alerting.NewCheckService(inmem.NewService(), ts)
+
+	c := newTestCheck()
+	c.Thresholds = nil
+	if err := s.CreateCheck(ctx, c); platform.ErrorCode(err) != platform.EInvalid {
+		t.Fatalf("expected error %q, got %v", platform.EInvalid, err)
+	}
+	if tasks, _, err := ts.FindTasks(ctx, platform.TaskFilter{}); err != nil || len(tasks) != 0 {
+		t.Fatalf("expected no task to be created, got %v, %v", tasks, err)
+	}
+}
@@ -0,0 +1,377 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/backend/executor"
+	"github.com/influxdata/platform/tsdb"
+	"go.uber.org/zap"
+)
+
+const (
+	// MonitoringBucketName is the name of the bucket of each organization the statuses of its checks are written to.
+	MonitoringBucketName = "_monitoring"
+	// MonitoringRetentionPeriod is the retention period of the monitoring buckets the Monitor creates.
+	MonitoringRetentionPeriod = 7 * 24 * time.Hour
+
+	// StatusMeasurement is the measurement of the statuses written by the Monitor.
+	StatusMeasurement = "statuses"
+
+	// MaxConcurrentNotifications is the maximum number of notifications a Monitor sends at once.
+	MaxConcurrentNotifications = 10
+)
+
+// Tag and field keys of the statuses written by the Monitor.
+const (
+	CheckIDTag   = "_check_id"
+	CheckNameTag = "_check_name"
+	LevelTag     = "_level"
+	TypeTag      = "_type"
+	MessageField = "_message"
+	ValueField   = "_value"
+)
+
+// ignoredKeyColumns are the columns of the group keys of results that do not identify series.
+var ignoredKeyColumns = map[string]bool{
+	"_start":       true,
+	"_stop":        true,
+	"_measurement": true,
+	"_field":       true,
+}
+
+// Monitor evaluates the results of the runs of the tasks of checks.
+// It writes the status of each series of a check to the monitoring bucket of the organization of the check,
+// and notifies the endpoints of the check of the changes of the levels of its series.
+// The last level of each series is kept in the CheckStatusService, so that it survives restarts.
+type Monitor struct {
+	CheckService                platform.CheckService
+	CheckStatusService          platform.CheckStatusService
+	NotificationEndpointService platform.NotificationEndpointService
+	BucketService               platform.BucketService
+	PointsWriter                storage.PointsWriter
+	Notifier                    *Notifier
+
+	logger *zap.Logger
+
+	notifying chan struct{} // Semaphore bounding the notifications sent at once.
+}
+
+var _ executor.ResultHandler = (*Monitor)(nil)
+
+// NewMonitor returns a Monitor writing statuses with pw.
+// The CheckService, CheckStatusService, NotificationEndpointService and BucketService must be set before it handles results.
+func NewMonitor(logger *zap.Logger, pw storage.PointsWriter) *Monitor {
+	return &Monitor{
+		PointsWriter: pw,
+		Notifier:     NewNotifier(),
+		logger:       logger,
+		notifying:    make(chan struct{}, MaxConcurrentNotifications),
+	}
+}
+
+// seriesStatus is the status of a series of a check.
+type seriesStatus struct {
+	key      string
+	tags     map[string]string
+	level    platform.CheckLevel
+	value    float64
+	hasValue bool
+}
+
+// HandleResults evaluates the results of a run, if the run belongs to the task of a check.
+// It returns an error if the statuses cannot be written; failed notifications are only logged.
+// The runs of the task of a check do not overlap, so the statuses of a check are not updated concurrently.
+func (m *Monitor) HandleResults(ctx context.Context, run backend.QueuedRun, results flux.ResultIterator) error {
+	checks, err := m.CheckService.FindChecks(ctx, platform.CheckFilter{TaskID: &run.TaskID})
+	if err != nil {
+		return err
+	}
+	if len(checks) == 0 {
+		return nil
+	}
+	c := checks[0]
+
+	series, err := readSeries(results)
+	if err != nil {
+		return err
+	}
+
+	prev, err := m.lastStatuses(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+
+	statuses, changes, next := evaluate(c, prev, series)
+	now := time.Unix(run.Now, 0).UTC()
+	if err := m.writeStatuses(ctx, c, statuses, now); err != nil {
+		return err
+	}
+	if err := m.putLastStatuses(ctx, c.ID, next); err != nil {
+		return err
+	}
+	m.notify(ctx, c, changes, now)
+	return nil
+}
+
+// lastStatuses returns the statuses of the series of the check stored by the previous run, by series key.
+func (m *Monitor) lastStatuses(ctx context.Context, checkID platform.ID) (map[string]seriesStatus, error) {
+	stored, err := m.CheckStatusService.FindCheckStatuses(ctx, checkID)
+	if err != nil {
+		return nil, err
+	}
+
+	prev := make(map[string]seriesStatus, len(stored))
+	for _, st := range stored {
+		s := seriesStatus{tags: st.Tags, level: st.Level}
+		if s.tags == nil {
+			s.tags = map[string]string{}
+		}
+		s.key = seriesKey(s.tags)
+		prev[s.key] = s
+	}
+	return prev, nil
+}
+
+// putLastStatuses stores the statuses of the series of the check for the next run.
+func (m *Monitor) putLastStatuses(ctx context.Context, checkID platform.ID, next map[string]seriesStatus) error {
+	stored := make([]*platform.CheckStatus, 0, len(next))
+	for _, s := range next {
+		stored = append(stored, &platform.CheckStatus{Tags: s.tags, Level: s.level})
+	}
+	sort.Slice(stored, func(i, j int) bool { return seriesKey(stored[i].Tags) < seriesKey(stored[j].Tags) })
+	return m.CheckStatusService.PutCheckStatuses(ctx, checkID, stored)
+}
+
+// readSeries returns the last value of each series in results, by series key.
+func readSeries(results flux.ResultIterator) (map[string]seriesStatus, error) {
+	series := make(map[string]seriesStatus)
+	for results.More() {
+		res := results.Next()
+		if err := res.Tables().Do(func(tbl flux.Table) error {
+			s := seriesStatus{tags: make(map[string]string)}
+			key := tbl.Key()
+			for j, col := range key.Cols() {
+				if col.Type == flux.TString && !ignoredKeyColumns[col.Label] {
+					s.tags[col.Label] = key.ValueString(j)
+				}
+			}
+			s.key = seriesKey(s.tags)
+
+			valueIdx := execute.ColIdx(execute.DefaultValueColLabel, tbl.Cols())
+			if err := tbl.Do(func(cr flux.ColReader) error {
+				if valueIdx < 0 || cr.Len() == 0 {
+					return nil
+				}
+				last := cr.Len() - 1
+				switch cr.Cols()[valueIdx].Type {
+				case flux.TFloat:
+					s.value, s.hasValue = cr.Floats(valueIdx)[last], true
+				case flux.TInt:
+					s.value, s.hasValue = float64(cr.Ints(valueIdx)[last]), true
+				case flux.TUInt:
+					s.value, s.hasValue = float64(cr.UInts(valueIdx)[last]), true
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			if s.hasValue {
+				series[s.key] = s
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return series, results.Err()
+}
+
+// seriesKey returns the canonical key of the series with the given tags.
+func seriesKey(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// change is a change of the level of a series.
+type change struct {
+	status seriesStatus
+	from   platform.CheckLevel
+}
+
+// evaluate returns the statuses of the series of c given the series read by a run and the statuses of the previous run,
+// the changes of their levels since the previous run, and the statuses to keep for the next run.
+func evaluate(c *platform.Check, prev, series map[string]seriesStatus) ([]seriesStatus, []change, map[string]seriesStatus) {
+	next := make(map[string]seriesStatus, len(series))
+	switch c.Type {
+	case platform.ThresholdCheckType:
+		// Series without data in a run keep their level.
+		for k, s := range prev {
+			next[k] = s
+		}
+		for k, s := range series {
+			s.level = c.ThresholdLevel(s.value)
+			next[k] = s
+		}
+	case platform.DeadmanCheckType:
+		for k, s := range series {
+			s.level = platform.CheckLevelOK
+			next[k] = s
+		}
+		for k, s := range prev {
+			if _, ok := series[k]; ok {
+				continue
+			}
+			if k == "" && len(series) > 0 {
+				// The data of the check is reporting again, under its own series.
+				s.level = platform.CheckLevelOK
+			} else {
+				s.level = c.DeadmanLevel()
+			}
+			s.hasValue = false
+			next[k] = s
+		}
+		if len(next) == 0 {
+			// No series of the check has ever reported data.
+			next[""] = seriesStatus{tags: map[string]string{}, level: c.DeadmanLevel()}
+		}
+	}
+
+	statuses := make([]seriesStatus, 0, len(next))
+	var changes []change
+	for k, s := range next {
+		// Deadman checks evaluate all their series in every run, threshold checks only those with data.
+		if _, ok := series[k]; ok || c.Type == platform.DeadmanCheckType {
+			statuses = append(statuses, s)
+		}
+		from := platform.CheckLevelOK
+		if p, ok := prev[k]; ok {
+			from = p.level
+		}
+		if s.level != from {
+			changes = append(changes, change{status: s, from: from})
+		}
+	}
+	if s, ok := next[""]; ok && s.level == platform.CheckLevelOK {
+		delete(next, "")
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].key < statuses[j].key })
+	sort.Slice(changes, func(i, j int) bool { return changes[i].status.key < changes[j].status.key })
+	return statuses, changes, next
+}
+
+// message returns the human readable description of the status of a series of c.
+func message(c *platform.Check, s seriesStatus) string {
+	series := c.Query.Measurement + "." + c.Query.Field
+	if s.key != "" {
+		series += "{" + s.key + "}"
+	}
+	switch {
+	case s.hasValue:
+		return fmt.Sprintf("%s: %s is %s, with value %v", c.Name, series, s.level, s.value)
+	case c.Type == platform.DeadmanCheckType && s.level != platform.CheckLevelOK:
+		return fmt.Sprintf("%s: %s is %s, with no data for %s", c.Name, series, s.level, c.TimeSince)
+	default:
+		return fmt.Sprintf("%s: %s is %s", c.Name, series, s.level)
+	}
+}
+
+// writeStatuses writes statuses to the monitoring bucket of the organization of c.
+func (m *Monitor) writeStatuses(ctx context.Context, c *platform.Check, statuses []seriesStatus, now time.Time) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	points := make([]models.Point, 0, len(statuses))
+	for _, s := range statuses {
+		tags := map[string]string{
+			CheckIDTag:   c.ID.String(),
+			CheckNameTag: c.Name,
+			LevelTag:     string(s.level),
+			TypeTag:      string(c.Type),
+		}
+		for k, v := range s.tags {
+			tags[k] = v
+		}
+		fields := models.Fields{MessageField: message(c, s)}
+		if s.hasValue {
+			fields[ValueField] = s.value
+		}
+		pt, err := models.NewPoint(StatusMeasurement, models.NewTags(tags), fields, now)
+		if err != nil {
+			return err
+		}
+		points = append(points, pt)
+	}
+
+	b, err := platform.FindOrCreateOrgBucket(ctx, m.BucketService, c.OrganizationID, MonitoringBucketName, MonitoringRetentionPeriod)
+	if err != nil {
+		return err
+	}
+	exploded, err := tsdb.ExplodePoints(c.OrganizationID, b.ID, points)
+	if err != nil {
+		return err
+	}
+	return m.PointsWriter.WritePoints(exploded)
+}
+
+// notify notifies the endpoints of c of the changes of the levels of its series.
+// The notifications are sent concurrently, up to MaxConcurrentNotifications at once across all checks,
+// and notify returns once they are all sent.
+func (m *Monitor) notify(ctx context.Context, c *platform.Check, changes []change, now time.Time) {
+	if len(changes) == 0 || len(c.EndpointIDs) == 0 {
+		return
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for _, id := range c.EndpointIDs {
+		e, err := m.NotificationEndpointService.FindNotificationEndpointByID(ctx, id)
+		if err != nil {
+			m.logger.Info("Failed to find notification endpoint", zap.String("check_id", c.ID.String()), zap.String("endpoint_id", id.String()), zap.Error(err))
+			continue
+		}
+		for _, ch := range changes {
+			n := Notification{
+				CheckID:       c.ID,
+				CheckName:     c.Name,
+				Level:         ch.status.level,
+				PreviousLevel: ch.from,
+				Tags:          ch.status.tags,
+				Message:       message(c, ch.status),
+				Time:          now,
+			}
+			if ch.status.hasValue {
+				v := ch.status.value
+				n.Value = &v
+			}
+
+			m.notifying <- struct{}{}
+			wg.Add(1)
+			go func(id platform.ID) {
+				defer func() {
+					<-m.notifying
+					wg.Done()
+				}()
+				if err := m.Notifier.Notify(ctx, e, n); err != nil {
+					m.logger.Info("Failed to notify endpoint", zap.String("check_id", c.ID.String()), zap.String("endpoint_id", id.String()), zap.Error(err))
+				}
+			}(id)
+		}
+	}
+}
@@ -0,0 +1,441 @@
+package alerting_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/alerting"
+	"github.com/influxdata/platform/inmem"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/query"
+	pcontrol "github.com/influxdata/platform/query/control"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/storage/readservice"
+	"github.com/influxdata/platform/task"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/backend/executor"
+	"github.com/influxdata/platform/tsdb"
+	"go.uber.org/zap"
+)
+
+// pointsWriter records the points written to it.
+type pointsWriter struct {
+	mu     sync.Mutex
+	points []models.Point
+}
+
+func (w *pointsWriter) WritePoints(points []models.Point) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.points = append(w.points, points...)
+	return nil
+}
+
+// statuses returns the sorted "series:level" of the status messages written since the last call.
+func (w *pointsWriter) statuses(t *testing.T) []string {
+	t.Helper()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var statuses []string
+	for _, pt := range w.points {
+		tags := pt.Tags()
+		if string(tags.Get([]byte(tsdb.FieldKeyTagKey))) != alerting.MessageField {
+			continue
+		}
+		if m := string(tags.Get([]byte(tsdb.MeasurementTagKey))); m != alerting.StatusMeasurement {
+			t.Fatalf("unexpected measurement %q", m)
+		}
+		statuses = append(statuses, string(tags.Get([]byte("host")))+":"+string(tags.Get([]byte(alerting.LevelTag))))
+	}
+	w.points = nil
+	sort.Strings(statuses)
+	return statuses
+}
+
+// endpointServer records the requests sent to notification endpoints.
+type endpointServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []endpointRequest
+}
+
+type endpointRequest struct {
+	method, path, auth string
+	body               map[string]interface{}
+}
+
+func newEndpointServer() *endpointServer {
+	s := &endpointServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		req := endpointRequest{method: r.Method, path: r.URL.Path, auth: r.Header.Get("Authorization")}
+		if err := json.Unmarshal(b, &req.body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.requests = append(s.requests, req)
+		s.mu.Unlock()
+	}))
+	return s
+}
+
+// received returns the requests received since the last call.
+func (s *endpointServer) received() []endpointRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reqs := s.requests
+	s.requests = nil
+	return reqs
+}
+
+type monitorSystem struct {
+	svc     *inmem.Service
+	monitor *alerting.Monitor
+	writer  *pointsWriter
+	server  *endpointServer
+}
+
+func newMonitorSystem(t *testing.T) *monitorSystem {
+	svc := inmem.NewService()
+	w := &pointsWriter{}
+	s := &monitorSystem{svc: svc, writer: w, server: newEndpointServer()}
+	s.restart()
+
+	if err := svc.CreateOrganization(context.Background(), &platform.Organization{Name: "org"}); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// restart replaces the monitor with a new one, sharing the services of the previous one.
+func (s *monitorSystem) restart() {
+	m := alerting.NewMonitor(zap.NewNop(), s.writer)
+	m.CheckService = s.svc
+	m.CheckStatusService = s.svc
+	m.NotificationEndpointService = s.svc
+	m.BucketService = s.svc
+	s.monitor = m
+}
+
+func (s *monitorSystem) createCheck(t *testing.T, c *platform.Check) {
+	t.Helper()
+	ctx := context.Background()
+	orgs, _, err := s.svc.FindOrganizations(ctx, platform.OrganizationFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.OrganizationID = orgs[0].ID
+	c.TaskID = s.svc.IDGenerator.ID()
+
+	for _, e := range []*platform.NotificationEndpoint{
+		{
+			Name:    "webhook",
+			Type:    platform.HTTPEndpointType,
+			URL:     s.server.URL + "/hook",
+			Method:  http.MethodPut,
+			Headers: map[string]string{"Authorization": "Bearer secret"},
+		},
+		{
+			Name:    "slack",
+			Type:    platform.SlackEndpointType,
+			URL:     s.server.URL + "/slack",
+			Channel: "#alerts",
+		},
+	} {
+		e.OrganizationID = c.OrganizationID
+		if err := s.svc.CreateNotificationEndpoint(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+		c.EndpointIDs = append(c.EndpointIDs, e.ID)
+	}
+	if err := s.svc.CreateCheck(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// run handles a run of the task of c with the given last value of each host, by host.
+func (s *monitorSystem) run(t *testing.T, c *platform.Check, now int64, values map[string]float64) {
+	t.Helper()
+	var tables []*executetest.Table
+	for host, v := range values {
+		tables = append(tables, &executetest.Table{
+			KeyCols: []string{"_measurement", "_field", "host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+				{Label: "_measurement", Type: flux.TString},
+				{Label: "_field", Type: flux.TString},
+				{Label: "host", Type: flux.TString},
+			},
+			Data: [][]interface{}{
+				{execute.Time(now), v, "cpu", "usage_user", host},
+			},
+		})
+	}
+	res := executetest.NewResult(tables)
+	res.Nm = "_result"
+	results := flux.NewSliceResultIterator([]flux.Result{res})
+
+	qr := backend.QueuedRun{TaskID: c.TaskID, RunID: platform.ID(now), Now: now}
+	if err := s.monitor.HandleResults(context.Background(), qr, results); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// notifications returns the sorted "series:previous->level" of the notifications received by the http endpoint,
+// and checks that the slack endpoint received the same number of messages.
+func (s *monitorSystem) notifications(t *testing.T) []string {
+	t.Helper()
+	var notifications []string
+	var slack int
+	for _, r := range s.server.received() {
+		switch r.path {
+		case "/hook":
+			if r.method != http.MethodPut || r.auth != "Bearer secret" {
+				t.Fatalf("unexpected request to http endpoint: %+v", r)
+			}
+			var host string
+			if tags, ok := r.body["tags"].(map[string]interface{}); ok {
+				host, _ = tags["host"].(string)
+			}
+			notifications = append(notifications, host+":"+r.body["previousLevel"].(string)+"->"+r.body["level"].(string))
+		case "/slack":
+			if r.method != http.MethodPost || r.body["channel"] != "#alerts" || r.body["text"] == "" {
+				t.Fatalf("unexpected request to slack endpoint: %+v", r)
+			}
+			slack++
+		}
+	}
+	if slack != len(notifications) {
+		t.Fatalf("expected %d slack messages, got %d", len(notifications), slack)
+	}
+	sort.Strings(notifications)
+	return notifications
+}
+
+func TestMonitor_Threshold(t *testing.T) {
+	s := newMonitorSystem(t)
+	defer s.server.Close()
+	c := newTestCheck()
+	s.createCheck(t, c)
+
+	for _, step := range []struct {
+		values        map[string]float64
+		statuses      []string
+		notifications []string
+	}{
+		{
+			values:        map[string]float64{"a": 95, "b": 50},
+			statuses:      []string{"a:crit", "b:ok"},
+			notifications: []string{"a:ok->crit"},
+		},
+		{
+			// The level of a is unchanged, so its endpoints are not notified again.
+			values:        map[string]float64{"a": 92, "b": 80},
+			statuses:      []string{"a:crit", "b:warn"},
+			notifications: []string{"b:ok->warn"},
+		},
+		{
+			values:        map[string]float64{"a": 10},
+			statuses:      []string{"a:ok"},
+			notifications: []string{"a:crit->ok"},
+		},
+		{
+			values:   map[string]float64{"a": 20, "b": 85},
+			statuses: []string{"a:ok", "b:warn"},
+		},
+	} {
+		s.run(t, c, 60, step.values)
+		if got := s.writer.statuses(t); !reflect.DeepEqual(got, step.statuses) {
+			t.Fatalf("after values %v, expected statuses %v, got %v", step.values, step.statuses, got)
+		}
+		if got := s.notifications(t); !reflect.DeepEqual(got, step.notifications) {
+			t.Fatalf("after values %v, expected notifications %v, got %v", step.values, step.notifications, got)
+		}
+	}
+
+	name := alerting.MonitoringBucketName
+	bs, _, err := s.svc.FindBuckets(context.Background(), platform.BucketFilter{OrganizationID: &c.OrganizationID, Name: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bs) != 1 || bs[0].RetentionPeriod != alerting.MonitoringRetentionPeriod {
+		t.Fatalf("expected one monitoring bucket, got %+v", bs)
+	}
+}
+
+func TestMonitor_Deadman(t *testing.T) {
+	s := newMonitorSystem(t)
+	defer s.server.Close()
+	c := newTestCheck()
+	c.Type = platform.DeadmanCheckType
+	c.Thresholds = nil
+	c.TimeSince = "5m"
+	c.Level = platform.CheckLevelWarn
+	s.createCheck(t, c)
+
+	for _, step := range []struct {
+		values        map[string]float64
+		statuses      []string
+		notifications []string
+	}{
+		{
+			// Without any data, the check itself is reported dead.
+			statuses:      []string{":warn"},
+			notifications: []string{":ok->warn"},
+		},
+		{
+			statuses: []string{":warn"},
+		},
+		{
+			values:        map[string]float64{"a": 1, "b": 1},
+			statuses:      []string{":ok", "a:ok", "b:ok"},
+			notifications: []string{":warn->ok"},
+		},
+		{
+			values:        map[string]float64{"a": 1},
+			statuses:      []string{"a:ok", "b:warn"},
+			notifications: []string{"b:ok->warn"},
+		},
+		{
+			values:        map[string]float64{"b": 1},
+			statuses:      []string{"a:warn", "b:ok"},
+			notifications: []string{"a:ok->warn", "b:warn->ok"},
+		},
+	} {
+		s.run(t, c, 60, step.values)
+		if got := s.writer.statuses(t); !reflect.DeepEqual(got, step.statuses) {
+			t.Fatalf("after values %v, expected statuses %v, got %v", step.values, step.statuses, got)
+		}
+		if got := s.notifications(t); !reflect.DeepEqual(got, step.notifications) {
+			t.Fatalf("after values %v, expected notifications %v, got %v", step.values, step.notifications, got)
+		}
+	}
+}
+
+func TestMonitor_Restart(t *testing.T) {
+	s := newMonitorSystem(t)
+	defer s.server.Close()
+	c := newTestCheck()
+	s.createCheck(t, c)
+
+	s.run(t, c, 60, map[string]float64{"a": 95})
+	if got, want := s.notifications(t), []string{"a:ok->crit"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected notifications %v, got %v", want, got)
+	}
+
+	// The levels of the series survive a restart, so unchanged levels are not notified again.
+	s.restart()
+	s.run(t, c, 120, map[string]float64{"a": 96})
+	if got := s.notifications(t); len(got) != 0 {
+		t.Fatalf("expected no notifications after a restart, got %v", got)
+	}
+	s.run(t, c, 180, map[string]float64{"a": 10})
+	if got, want := s.notifications(t), []string{"a:crit->ok"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected notifications %v, got %v", want, got)
+	}
+}
+
+func TestMonitor_UnknownTask(t *testing.T) {
+	s := newMonitorSystem(t)
+	defer s.server.Close()
+
+	results := flux.NewSliceResultIterator(nil)
+	if err := s.monitor.HandleResults(context.Background(), backend.QueuedRun{TaskID: 1, Now: 60}, results); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.writer.statuses(t); len(got) != 0 {
+		t.Fatalf("expected no statuses for a task without check, got %v", got)
+	}
+}
+
+// TestMonitor_TaskOnStorage runs the task of a check with the executor on the query
+// controller of a storage engine, as influxd does.
+func TestMonitor_TaskOnStorage(t *testing.T) {
+	ctx := context.Background()
+	s := newMonitorSystem(t)
+	defer s.server.Close()
+
+	orgs, _, err := s.svc.FindOrganizations(ctx, platform.OrganizationFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	org := orgs[0]
+	bucket := &platform.Bucket{Name: "telegraf", OrganizationID: org.ID}
+	if err := s.svc.CreateBucket(ctx, bucket); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "alerting-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	engine := storage.NewEngine(dir, storage.NewConfig())
+	if err := engine.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Close()
+
+	now := time.Unix(1538352000, 0)
+	var pts []models.Point
+	for host, v := range map[string]float64{"a": 95, "b": 50} {
+		pts = append(pts, models.MustNewPoint(
+			"cpu",
+			models.NewTags(map[string]string{"host": host}),
+			map[string]interface{}{"usage_user": v},
+			now.Add(-30*time.Second),
+		))
+	}
+	pts, err = tsdb.ExplodePoints(org.ID, bucket.ID, pts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.WritePoints(pts); err != nil {
+		t.Fatal(err)
+	}
+
+	ctrl, err := readservice.NewController(pcontrol.Config{}, engine, s.svc, s.svc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctrl.Shutdown(ctx)
+
+	store := backend.NewInMemStore()
+	c := newTestCheck()
+	c.OrganizationID = org.ID
+	if err := alerting.NewCheckService(s.svc, task.PlatformAdapter(store, backend.NopLogReader{})).CreateCheck(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+
+	ex := executor.NewQueryServiceExecutor(zap.NewNop(), query.QueryServiceBridge{AsyncQueryService: ctrl}, store, executor.WithResultHandler(s.monitor))
+	rp, err := ex.Execute(ctx, backend.QueuedRun{TaskID: c.TaskID, RunID: 1, Now: now.Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := rp.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := res.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.writer.statuses(t), []string{"a:crit", "b:ok"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected statuses %v, got %v", want, got)
+	}
+}
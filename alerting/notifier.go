@@ -0,0 +1,86 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+// Notification is the body of the requests sent to http notification endpoints.
+type Notification struct {
+	CheckID       platform.ID         `json:"checkID"`
+	CheckName     string              `json:"checkName"`
+	Level         platform.CheckLevel `json:"level"`
+	PreviousLevel platform.CheckLevel `json:"previousLevel"`
+	Tags          map[string]string   `json:"tags,omitempty"`
+	Value         *float64            `json:"value,omitempty"`
+	Message       string              `json:"message"`
+	Time          time.Time           `json:"time"`
+}
+
+// slackMessage is the body of the requests sent to slack notification endpoints.
+type slackMessage struct {
+	Text    string `json:"text"`
+	Channel string `json:"channel,omitempty"`
+}
+
+// Notifier sends notifications to notification endpoints.
+type Notifier struct {
+	Client *http.Client
+}
+
+// NewNotifier returns a Notifier sending requests with a client timing out after 10 seconds.
+func NewNotifier() *Notifier {
+	return &Notifier{
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Notify sends notification to e.
+func (n *Notifier) Notify(ctx context.Context, e *platform.NotificationEndpoint, notification Notification) error {
+	var body interface{}
+	method := http.MethodPost
+	switch e.Type {
+	case platform.HTTPEndpointType:
+		body = notification
+		if e.Method != "" {
+			method = e.Method
+		}
+	case platform.SlackEndpointType:
+		body = slackMessage{Text: notification.Message, Channel: e.Channel}
+	default:
+		return fmt.Errorf("invalid notification endpoint type %q", e.Type)
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(method, e.URL, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("notification endpoint %s responded with status %s", e.ID, resp.Status)
+	}
+	return nil
+}
@@ -2,12 +2,14 @@ package bolt
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/coreos/bbolt"
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/check"
 	"github.com/influxdata/platform/rand"
 	"github.com/influxdata/platform/snowflake"
 	"go.uber.org/zap"
@@ -75,6 +77,17 @@ func (c *Client) Open(ctx context.Context) error {
 	return nil
 }
 
+// Check reports whether the bolt database is open and can be read.
+func (c *Client) Check(ctx context.Context) check.Response {
+	if c.db == nil {
+		return check.Error(errors.New("bolt database is not open"))
+	}
+	if err := c.db.View(func(*bolt.Tx) error { return nil }); err != nil {
+		return check.Error(err)
+	}
+	return check.Pass()
+}
+
 // initialize creates Buckets that are missing
 func (c *Client) initialize(ctx context.Context) error {
 	if err := c.db.Update(func(tx *bolt.Tx) error {
@@ -143,6 +156,35 @@ func (c *Client) initialize(ctx context.Context) error {
 			return err
 		}
 
+		// Always create Organization Settings bucket.
+		if err := c.initializeOrganizationSettings(ctx, tx); err != nil {
+			return err
+		}
+
+		// Always create Checks bucket.
+		if err := c.initializeChecks(ctx, tx); err != nil {
+			return err
+		}
+
+		// Always create Notification Endpoints bucket.
+		if err := c.initializeNotificationEndpoints(ctx, tx); err != nil {
+			return err
+		}
+
+		// Always create User Identities bucket.
+		if err := c.initializeUserIdentities(ctx, tx); err != nil {
+			return err
+		}
+
+		// Always create DBRP Mappings bucket.
+		if err := c.initializeDBRPMappings(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := c.migrate(ctx, tx); err != nil {
+			return fmt.Errorf(ErrUnableToMigrate, err)
+		}
+
 		return nil
 	}); err != nil {
 		return err
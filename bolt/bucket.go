@@ -372,6 +372,10 @@ func (c *Client) updateBucket(ctx context.Context, tx *bolt.Tx, id platform.ID,
 		b.RetentionPeriod = *upd.RetentionPeriod
 	}
 
+	if upd.MaxSeries != nil {
+		b.MaxSeries = *upd.MaxSeries
+	}
+
 	if upd.Name != nil {
 		key, err := bucketIndexKey(b)
 		if err != nil {
@@ -0,0 +1,374 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+)
+
+var (
+	checkBucket       = []byte("checksv1")
+	checkTaskIndex    = []byte("checktaskindexv1")
+	checkStatusBucket = []byte("checkstatusesv1")
+)
+
+var (
+	_ platform.CheckService       = (*Client)(nil)
+	_ platform.CheckStatusService = (*Client)(nil)
+)
+
+func (c *Client) initializeChecks(ctx context.Context, tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(checkBucket); err != nil {
+		return err
+	}
+	if _, err := tx.CreateBucketIfNotExists(checkStatusBucket); err != nil {
+		return err
+	}
+	if tx.Bucket(checkTaskIndex) != nil {
+		return nil
+	}
+
+	// Index the checks stored before the index existed.
+	idx, err := tx.CreateBucket(checkTaskIndex)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(checkBucket).ForEach(func(k, v []byte) error {
+		check := &platform.Check{}
+		if err := json.Unmarshal(v, check); err != nil {
+			return err
+		}
+		key, err := checkTaskIndexKey(check)
+		if err != nil {
+			return err
+		}
+		return idx.Put(key, nil)
+	})
+}
+
+// checkTaskIndexKey is the key indexing a check by its task, so that the checks of a task are found
+// by the prefix of the encoded task ID.
+func checkTaskIndexKey(ch *platform.Check) ([]byte, error) {
+	taskID, err := ch.TaskID.Encode()
+	if err != nil {
+		return nil, err
+	}
+	checkID, err := ch.ID.Encode()
+	if err != nil {
+		return nil, err
+	}
+	k := make([]byte, 2*platform.IDLength)
+	copy(k, taskID)
+	copy(k[platform.IDLength:], checkID)
+	return k, nil
+}
+
+func filterChecksFn(filter platform.CheckFilter) func(c *platform.Check) bool {
+	return func(c *platform.Check) bool {
+		return (filter.ID == nil || c.ID == *filter.ID) &&
+			(filter.OrganizationID == nil || c.OrganizationID == *filter.OrganizationID) &&
+			(filter.TaskID == nil || c.TaskID == *filter.TaskID)
+	}
+}
+
+// FindCheckByID returns a single check by ID.
+func (c *Client) FindCheckByID(ctx context.Context, id platform.ID) (*platform.Check, error) {
+	op := "bolt/find check by id"
+	var check *platform.Check
+	err := c.db.View(func(tx *bolt.Tx) error {
+		ch, pErr := c.findCheckByID(ctx, tx, id)
+		if pErr != nil {
+			pErr.Op = op
+			return pErr
+		}
+		check = ch
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return check, nil
+}
+
+func (c *Client) findCheckByID(ctx context.Context, tx *bolt.Tx, id platform.ID) (*platform.Check, *platform.Error) {
+	encID, err := id.Encode()
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EEmptyValue,
+			Err:  err,
+		}
+	}
+
+	d := tx.Bucket(checkBucket).Get(encID)
+	if d == nil {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("check with ID %v not found", id),
+		}
+	}
+
+	check := &platform.Check{}
+	if err := json.Unmarshal(d, check); err != nil {
+		return nil, &platform.Error{
+			Err: err,
+		}
+	}
+	return check, nil
+}
+
+// FindChecks returns all checks that match filter.
+// The checks of a task are found through the task index, without scanning all checks.
+func (c *Client) FindChecks(ctx context.Context, filter platform.CheckFilter) ([]*platform.Check, error) {
+	checks := []*platform.Check{}
+	filterFn := filterChecksFn(filter)
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		if filter.TaskID != nil {
+			prefix, err := filter.TaskID.Encode()
+			if err != nil {
+				return err
+			}
+			cur := tx.Bucket(checkTaskIndex).Cursor()
+			for k, _ := cur.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cur.Next() {
+				var id platform.ID
+				if err := id.Decode(k[platform.IDLength:]); err != nil {
+					return err
+				}
+				check, pErr := c.findCheckByID(ctx, tx, id)
+				if pErr != nil {
+					return pErr
+				}
+				if filterFn(check) {
+					checks = append(checks, check)
+				}
+			}
+			return nil
+		}
+
+		cur := tx.Bucket(checkBucket).Cursor()
+		for k, v := cur.First(); k != nil; k, v = cur.Next() {
+			check := &platform.Check{}
+			if err := json.Unmarshal(v, check); err != nil {
+				return err
+			}
+			if filterFn(check) {
+				checks = append(checks, check)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Op:  "bolt/find checks",
+			Err: err,
+		}
+	}
+	return checks, nil
+}
+
+// CreateCheck creates a new check and sets ch.ID with the new identifier.
+func (c *Client) CreateCheck(ctx context.Context, ch *platform.Check) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		ch.ID = c.IDGenerator.ID()
+		if pErr := c.putCheck(ctx, tx, ch); pErr != nil {
+			pErr.Op = "bolt/create check"
+			return pErr
+		}
+		return nil
+	})
+}
+
+// PutCheck puts a check in the store, as is.
+func (c *Client) PutCheck(ctx context.Context, ch *platform.Check) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		if pErr := c.putCheck(ctx, tx, ch); pErr != nil {
+			pErr.Op = "bolt/put check"
+			return pErr
+		}
+		return nil
+	})
+}
+
+func (c *Client) putCheck(ctx context.Context, tx *bolt.Tx, ch *platform.Check) *platform.Error {
+	v, err := json.Marshal(ch)
+	if err != nil {
+		return &platform.Error{
+			Err: err,
+		}
+	}
+	encID, err := ch.ID.Encode()
+	if err != nil {
+		return &platform.Error{
+			Code: platform.EEmptyValue,
+			Err:  err,
+		}
+	}
+	key, err := checkTaskIndexKey(ch)
+	if err != nil {
+		return &platform.Error{
+			Code: platform.EEmptyValue,
+			Err:  err,
+		}
+	}
+
+	if prev, pErr := c.findCheckByID(ctx, tx, ch.ID); pErr == nil && prev.TaskID != ch.TaskID {
+		if pErr := c.deleteCheckTaskIndex(ctx, tx, prev); pErr != nil {
+			return pErr
+		}
+	}
+	if err := tx.Bucket(checkBucket).Put(encID, v); err != nil {
+		return &platform.Error{
+			Err: err,
+		}
+	}
+	if err := tx.Bucket(checkTaskIndex).Put(key, nil); err != nil {
+		return &platform.Error{
+			Err: err,
+		}
+	}
+	return nil
+}
+
+func (c *Client) deleteCheckTaskIndex(ctx context.Context, tx *bolt.Tx, ch *platform.Check) *platform.Error {
+	key, err := checkTaskIndexKey(ch)
+	if err != nil {
+		return &platform.Error{
+			Code: platform.EEmptyValue,
+			Err:  err,
+		}
+	}
+	if err := tx.Bucket(checkTaskIndex).Delete(key); err != nil {
+		return &platform.Error{
+			Err: err,
+		}
+	}
+	return nil
+}
+
+// UpdateCheck updates a single check with changeset.
+// Returns the new check state after update.
+func (c *Client) UpdateCheck(ctx context.Context, id platform.ID, upd platform.CheckUpdate) (*platform.Check, error) {
+	op := "bolt/update check"
+	var check *platform.Check
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		ch, pErr := c.findCheckByID(ctx, tx, id)
+		if pErr != nil {
+			pErr.Op = op
+			return pErr
+		}
+
+		upd.Apply(ch)
+		if pErr := c.putCheck(ctx, tx, ch); pErr != nil {
+			pErr.Op = op
+			return pErr
+		}
+		check = ch
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return check, nil
+}
+
+// DeleteCheck removes a check by ID.
+func (c *Client) DeleteCheck(ctx context.Context, id platform.ID) error {
+	op := "bolt/delete check"
+	return c.db.Update(func(tx *bolt.Tx) error {
+		ch, pErr := c.findCheckByID(ctx, tx, id)
+		if pErr != nil {
+			pErr.Op = op
+			return pErr
+		}
+		if pErr := c.deleteCheckTaskIndex(ctx, tx, ch); pErr != nil {
+			pErr.Op = op
+			return pErr
+		}
+
+		encID, err := id.Encode()
+		if err != nil {
+			return &platform.Error{
+				Code: platform.EEmptyValue,
+				Op:   op,
+				Err:  err,
+			}
+		}
+		if err := tx.Bucket(checkBucket).Delete(encID); err != nil {
+			return &platform.Error{
+				Op:  op,
+				Err: err,
+			}
+		}
+		if err := tx.Bucket(checkStatusBucket).Delete(encID); err != nil {
+			return &platform.Error{
+				Op:  op,
+				Err: err,
+			}
+		}
+		return nil
+	})
+}
+
+// FindCheckStatuses returns the last status of each series of the check.
+func (c *Client) FindCheckStatuses(ctx context.Context, checkID platform.ID) ([]*platform.CheckStatus, error) {
+	op := "bolt/find check statuses"
+	encID, err := checkID.Encode()
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EEmptyValue,
+			Op:   op,
+			Err:  err,
+		}
+	}
+
+	statuses := []*platform.CheckStatus{}
+	err = c.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(checkStatusBucket).Get(encID)
+		if v == nil {
+			return nil
+		}
+		return json.Unmarshal(v, &statuses)
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Op:  op,
+			Err: err,
+		}
+	}
+	return statuses, nil
+}
+
+// PutCheckStatuses replaces the statuses of the series of the check.
+func (c *Client) PutCheckStatuses(ctx context.Context, checkID platform.ID, statuses []*platform.CheckStatus) error {
+	op := "bolt/put check statuses"
+	encID, err := checkID.Encode()
+	if err != nil {
+		return &platform.Error{
+			Code: platform.EEmptyValue,
+			Op:   op,
+			Err:  err,
+		}
+	}
+	v, err := json.Marshal(statuses)
+	if err != nil {
+		return &platform.Error{
+			Op:  op,
+			Err: err,
+		}
+	}
+
+	err = c.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(checkStatusBucket).Put(encID, v)
+	})
+	if err != nil {
+		return &platform.Error{
+			Op:  op,
+			Err: err,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initCheckService(f platformtesting.CheckFields, t *testing.T) (platform.CheckService, func()) {
+	return initCheckNStatusService(f, t)
+}
+
+func initCheckNStatusService(f platformtesting.CheckFields, t *testing.T) (platformtesting.CheckNStatusService, func()) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	c.IDGenerator = f.IDGenerator
+	ctx := context.TODO()
+	for _, check := range f.Checks {
+		if err := c.PutCheck(ctx, check); err != nil {
+			t.Fatalf("failed to populate checks: %v", err)
+		}
+	}
+	return c, func() {
+		defer closeFn()
+		for _, check := range f.Checks {
+			if err := c.DeleteCheck(ctx, check.ID); err != nil {
+				t.Logf("failed to remove check: %v", err)
+			}
+		}
+	}
+}
+
+func TestCheckService(t *testing.T) {
+	platformtesting.CheckService(initCheckService, t)
+}
+
+func TestCheckStatusService(t *testing.T) {
+	platformtesting.CheckStatuses(initCheckNStatusService, t)
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"sync"
+	"strings"
 
 	bolt "github.com/coreos/bbolt"
 	"github.com/influxdata/platform"
@@ -64,59 +64,37 @@ func (c *Client) findDashboardByID(ctx context.Context, tx *bolt.Tx, id platform
 
 // FindDashboard retrieves a dashboard using an arbitrary dashboard filter.
 func (c *Client) FindDashboard(ctx context.Context, filter platform.DashboardFilter) (*platform.Dashboard, error) {
-	if len(filter.IDs) == 1 {
-		return c.FindDashboardByID(ctx, *filter.IDs[0])
-	}
-
-	var d *platform.Dashboard
-	err := c.db.View(func(tx *bolt.Tx) error {
-		filterFn := filterDashboardsFn(filter)
-		return c.forEachDashboard(ctx, tx, func(dash *platform.Dashboard) bool {
-			if filterFn(dash) {
-				d = dash
-				return false
-			}
-			return true
-		})
-	})
-
+	ds, _, err := c.FindDashboards(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	if d == nil {
+	if len(ds) == 0 {
 		return nil, platform.ErrDashboardNotFound
 	}
 
-	return d, nil
+	return ds[0], nil
 }
 
-func filterDashboardsFn(filter platform.DashboardFilter) func(d *platform.Dashboard) bool {
+func filterDashboardsFn(filter platform.DashboardFilter, owned map[platform.ID]bool) func(d *platform.Dashboard) bool {
+	var ids map[platform.ID]bool
 	if len(filter.IDs) > 0 {
-		var sm sync.Map
+		ids = make(map[platform.ID]bool, len(filter.IDs))
 		for _, id := range filter.IDs {
-			sm.Store(id.String(), true)
-		}
-		return func(d *platform.Dashboard) bool {
-			_, ok := sm.Load(d.ID.String())
-			return ok
+			ids[*id] = true
 		}
 	}
 
-	return func(d *platform.Dashboard) bool { return true }
+	return func(d *platform.Dashboard) bool {
+		return (ids == nil || ids[d.ID]) &&
+			(filter.OrganizationID == nil || d.OrganizationID == *filter.OrganizationID) &&
+			(filter.NamePrefix == nil || strings.HasPrefix(d.Name, *filter.NamePrefix)) &&
+			(filter.Owner == nil || owned[d.ID])
+	}
 }
 
 // FindDashboards retrives all dashboards that match an arbitrary dashboard filter.
 func (c *Client) FindDashboards(ctx context.Context, filter platform.DashboardFilter) ([]*platform.Dashboard, int, error) {
-	if len(filter.IDs) == 1 {
-		d, err := c.FindDashboardByID(ctx, *filter.IDs[0])
-		if err != nil {
-			return nil, 0, err
-		}
-
-		return []*platform.Dashboard{d}, 1, nil
-	}
-
 	ds := []*platform.Dashboard{}
 	err := c.db.View(func(tx *bolt.Tx) error {
 		dashs, err := c.findDashboards(ctx, tx, filter)
@@ -135,9 +113,28 @@ func (c *Client) FindDashboards(ctx context.Context, filter platform.DashboardFi
 }
 
 func (c *Client) findDashboards(ctx context.Context, tx *bolt.Tx, filter platform.DashboardFilter) ([]*platform.Dashboard, error) {
-	ds := []*platform.Dashboard{}
+	var owned map[platform.ID]bool
+	if filter.Owner != nil {
+		ids, err := c.ownedResourceIDs(ctx, tx, *filter.Owner, platform.DashboardResourceType)
+		if err != nil {
+			return nil, err
+		}
+		owned = ids
+	}
+	filterFn := filterDashboardsFn(filter, owned)
 
-	filterFn := filterDashboardsFn(filter)
+	if len(filter.IDs) == 1 {
+		d, err := c.findDashboardByID(ctx, tx, *filter.IDs[0])
+		if err != nil {
+			return nil, err
+		}
+		if !filterFn(d) {
+			return []*platform.Dashboard{}, nil
+		}
+		return []*platform.Dashboard{d}, nil
+	}
+
+	ds := []*platform.Dashboard{}
 	err := c.forEachDashboard(ctx, tx, func(d *platform.Dashboard) bool {
 		if filterFn(d) {
 			ds = append(ds, d)
@@ -160,7 +157,7 @@ func (c *Client) CreateDashboard(ctx context.Context, d *platform.Dashboard) err
 		for _, cell := range d.Cells {
 			cell.ID = c.IDGenerator.ID()
 
-			if err := c.createViewIfNotExists(ctx, tx, cell, platform.AddDashboardCellOptions{}); err != nil {
+			if err := c.createViewIfNotExists(ctx, tx, d.OrganizationID, cell, platform.AddDashboardCellOptions{}); err != nil {
 				return err
 			}
 		}
@@ -169,14 +166,26 @@ func (c *Client) CreateDashboard(ctx context.Context, d *platform.Dashboard) err
 	})
 }
 
-func (c *Client) createViewIfNotExists(ctx context.Context, tx *bolt.Tx, cell *platform.Cell, opts platform.AddDashboardCellOptions) error {
+// viewOrganizationError is returned when a cell of a dashboard is to show a view of another organization.
+func viewOrganizationError(viewID, orgID platform.ID) error {
+	return &platform.Error{
+		Code: platform.EInvalid,
+		Op:   "bolt/add dashboard cell",
+		Msg:  fmt.Sprintf("view %s does not belong to organization %s", viewID, orgID),
+	}
+}
+
+func (c *Client) createViewIfNotExists(ctx context.Context, tx *bolt.Tx, orgID platform.ID, cell *platform.Cell, opts platform.AddDashboardCellOptions) error {
 	if opts.UsingView.Valid() {
 		// Creates a hard copy of a view
 		v, err := c.findViewByID(ctx, tx, opts.UsingView)
 		if err != nil {
 			return err
 		}
-		view, err := c.copyView(ctx, tx, v.ID)
+		if v.OrganizationID != orgID {
+			return viewOrganizationError(v.ID, orgID)
+		}
+		view, err := c.copyView(ctx, tx, v.ID, orgID)
 		if err != nil {
 			return err
 		}
@@ -184,15 +193,22 @@ func (c *Client) createViewIfNotExists(ctx context.Context, tx *bolt.Tx, cell *p
 		return nil
 	} else if cell.ViewID.Valid() {
 		// Creates a soft copy of a view
-		_, err := c.findViewByID(ctx, tx, cell.ViewID)
+		v, err := c.findViewByID(ctx, tx, cell.ViewID)
 		if err != nil {
 			return err
 		}
+		if v.OrganizationID != orgID {
+			return viewOrganizationError(v.ID, orgID)
+		}
 		return nil
 	}
 
 	// If not view exists create the view
-	view := &platform.View{}
+	view := &platform.View{
+		ViewContents: platform.ViewContents{
+			OrganizationID: orgID,
+		},
+	}
 	if err := c.createView(ctx, tx, view); err != nil {
 		return err
 	}
@@ -243,7 +259,7 @@ func (c *Client) AddDashboardCell(ctx context.Context, id platform.ID, cell *pla
 			return err
 		}
 		cell.ID = c.IDGenerator.ID()
-		if err := c.createViewIfNotExists(ctx, tx, cell, opts); err != nil {
+		if err := c.createViewIfNotExists(ctx, tx, d.OrganizationID, cell, opts); err != nil {
 			return err
 		}
 
@@ -25,6 +25,11 @@ func initDashboardService(f platformtesting.DashboardFields, t *testing.T) (plat
 			t.Fatalf("failed to populate views")
 		}
 	}
+	for _, m := range f.UserResourceMappings {
+		if err := c.CreateUserResourceMapping(ctx, m); err != nil {
+			t.Fatalf("failed to populate user resource mappings")
+		}
+	}
 	return c, func() {
 		defer closeFn()
 		for _, b := range f.Dashboards {
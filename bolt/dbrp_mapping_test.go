@@ -0,0 +1,46 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initDBRPMappingService(f platformtesting.DBRPMappingFields, t *testing.T) (platform.DBRPMappingService, func()) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	ctx := context.TODO()
+	if err := f.Populate(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+	return c, func() {
+		defer closeFn()
+		if err := platformtesting.CleanupDBRPMappings(ctx, c); err != nil {
+			t.Logf("failed to remove dbrp mappings: %v", err)
+		}
+	}
+}
+
+func TestDBRPMappingService_CreateDBRPMapping(t *testing.T) {
+	platformtesting.CreateDBRPMapping(initDBRPMappingService, t)
+}
+
+func TestDBRPMappingService_FindDBRPMappingByKey(t *testing.T) {
+	platformtesting.FindDBRPMappingByKey(initDBRPMappingService, t)
+}
+
+func TestDBRPMappingService_FindDBRPMappings(t *testing.T) {
+	platformtesting.FindDBRPMappings(initDBRPMappingService, t)
+}
+
+func TestDBRPMappingService_DeleteDBRPMapping(t *testing.T) {
+	platformtesting.DeleteDBRPMapping(initDBRPMappingService, t)
+}
+
+func TestDBRPMappingService_FindDBRPMapping(t *testing.T) {
+	platformtesting.FindDBRPMapping(initDBRPMappingService, t)
+}
@@ -3,6 +3,7 @@ package bolt
 import (
 	"context"
 	"encoding/json"
+	"strings"
 
 	bolt "github.com/coreos/bbolt"
 	"github.com/influxdata/platform"
@@ -20,11 +21,30 @@ func (c *Client) initializeMacros(ctx context.Context, tx *bolt.Tx) error {
 	return nil
 }
 
-// FindMacros returns all macros in the store
-func (c *Client) FindMacros(ctx context.Context) ([]*platform.Macro, error) {
+func filterMacrosFn(filter platform.MacroFilter, owned map[platform.ID]bool) func(m *platform.Macro) bool {
+	return func(m *platform.Macro) bool {
+		return (filter.ID == nil || m.ID == *filter.ID) &&
+			(filter.OrganizationID == nil || m.OrganizationID == *filter.OrganizationID) &&
+			(filter.NamePrefix == nil || strings.HasPrefix(m.Name, *filter.NamePrefix)) &&
+			(filter.Owner == nil || owned[m.ID])
+	}
+}
+
+// FindMacros returns all macros in the store that match filter
+func (c *Client) FindMacros(ctx context.Context, filter platform.MacroFilter) ([]*platform.Macro, error) {
 	macros := []*platform.Macro{}
 
 	err := c.db.View(func(tx *bolt.Tx) error {
+		var owned map[platform.ID]bool
+		if filter.Owner != nil {
+			ids, err := c.ownedResourceIDs(ctx, tx, *filter.Owner, platform.MacroResourceType)
+			if err != nil {
+				return err
+			}
+			owned = ids
+		}
+		filterFn := filterMacrosFn(filter, owned)
+
 		b := tx.Bucket(macroBucket)
 		c := b.Cursor()
 
@@ -36,7 +56,9 @@ func (c *Client) FindMacros(ctx context.Context) ([]*platform.Macro, error) {
 				return err
 			}
 
-			macros = append(macros, &macro)
+			if filterFn(&macro) {
+				macros = append(macros, &macro)
+			}
 		}
 
 		return nil
@@ -23,6 +23,12 @@ func initMacroService(f platformtesting.MacroFields, t *testing.T) (platform.Mac
 		}
 	}
 
+	for _, m := range f.UserResourceMappings {
+		if err := c.CreateUserResourceMapping(ctx, m); err != nil {
+			t.Fatalf("failed to populate user resource mappings: %v", err)
+		}
+	}
+
 	done := func() {
 		defer closeFn()
 
@@ -44,6 +50,10 @@ func TestMacroService_FindMacroByID(t *testing.T) {
 	platformtesting.FindMacroByID(initMacroService, t)
 }
 
+func TestMacroService_FindMacros(t *testing.T) {
+	platformtesting.FindMacros(initMacroService, t)
+}
+
 func TestMacroService_UpdateMacro(t *testing.T) {
 	platformtesting.UpdateMacro(initMacroService, t)
 }
@@ -0,0 +1,129 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+	"go.uber.org/zap"
+)
+
+// migrate brings data stored by previous versions up to date.
+func (c *Client) migrate(ctx context.Context, tx *bolt.Tx) error {
+	for _, r := range []struct {
+		bucket []byte
+		typ    platform.ResourceType
+	}{
+		{bucket: dashboardBucket, typ: platform.DashboardResourceType},
+		{bucket: viewBucket, typ: platform.ViewResourceType},
+		{bucket: macroBucket, typ: platform.MacroResourceType},
+	} {
+		if err := c.migrateResourceOrganizations(ctx, tx, r.bucket, r.typ); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateResourceOrganizations assigns an organization to the resources of
+// bucket stored before they were scoped to organizations. A resource takes
+// the organization of its owners when they belong to exactly one, or the
+// only organization of the store. Resources for which neither is known are
+// left without an organization and reported.
+func (c *Client) migrateResourceOrganizations(ctx context.Context, tx *bolt.Tx, bucket []byte, typ platform.ResourceType) error {
+	var orgs []platform.ID
+	if err := forEachOrganization(ctx, tx, func(o *platform.Organization) bool {
+		orgs = append(orgs, o.ID)
+		return true
+	}); err != nil {
+		return err
+	}
+
+	updates := map[string][]byte{}
+	cur := tx.Bucket(bucket).Cursor()
+	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(v, &fields); err != nil {
+			return err
+		}
+		if _, ok := fields["organizationID"]; ok {
+			continue
+		}
+
+		var id platform.ID
+		if err := id.Decode(k); err != nil {
+			return err
+		}
+
+		orgID, err := c.resourceOwnersOrganization(ctx, tx, id, typ)
+		if err != nil {
+			return err
+		}
+		if !orgID.Valid() && len(orgs) == 1 {
+			orgID = orgs[0]
+		}
+		if !orgID.Valid() {
+			c.Logger.Warn("Unable to determine the organization of resource",
+				zap.String("resource_type", string(typ)),
+				zap.Stringer("resource_id", id))
+			continue
+		}
+
+		if fields["organizationID"], err = json.Marshal(orgID); err != nil {
+			return err
+		}
+		b, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+		updates[string(k)] = b
+	}
+
+	for k, v := range updates {
+		if err := tx.Bucket(bucket).Put([]byte(k), v); err != nil {
+			return err
+		}
+	}
+	if len(updates) > 0 {
+		c.Logger.Info("Assigned organizations to resources",
+			zap.String("resource_type", string(typ)),
+			zap.Int("count", len(updates)))
+	}
+	return nil
+}
+
+// resourceOwnersOrganization returns the organization the owners of the
+// resource belong to, or an invalid ID unless there is exactly one.
+func (c *Client) resourceOwnersOrganization(ctx context.Context, tx *bolt.Tx, id platform.ID, typ platform.ResourceType) (platform.ID, error) {
+	owners, err := c.findUserResourceMappings(ctx, tx, platform.UserResourceMappingFilter{
+		ResourceID:   id,
+		ResourceType: typ,
+		UserType:     platform.Owner,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	orgs := map[platform.ID]bool{}
+	for _, o := range owners {
+		ms, err := c.findUserResourceMappings(ctx, tx, platform.UserResourceMappingFilter{
+			UserID:       o.UserID,
+			ResourceType: platform.OrgResourceType,
+		})
+		if err != nil {
+			return 0, err
+		}
+		for _, m := range ms {
+			orgs[m.ResourceID] = true
+		}
+	}
+
+	if len(orgs) != 1 {
+		return 0, nil
+	}
+	for orgID := range orgs {
+		return orgID, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,80 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func TestClient_MigrateResourceOrganizations(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	ctx := context.Background()
+	orgA := &platform.Organization{Name: "a"}
+	orgB := &platform.Organization{Name: "b"}
+	for _, o := range []*platform.Organization{orgA, orgB} {
+		if err := c.CreateOrganization(ctx, o); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	userID := platformtesting.MustIDBase16("020f755c3c082000")
+	owned := platformtesting.MustIDBase16("020f755c3c082001")
+	unowned := platformtesting.MustIDBase16("020f755c3c082002")
+	for _, m := range []*platform.UserResourceMapping{
+		{ResourceID: orgB.ID, ResourceType: platform.OrgResourceType, UserID: userID, UserType: platform.Member},
+		{ResourceID: owned, ResourceType: platform.DashboardResourceType, UserID: userID, UserType: platform.Owner},
+	} {
+		if err := c.CreateUserResourceMapping(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Store dashboards as they were before they had an organization.
+	if err := c.DB().Update(func(tx *bolt.Tx) error {
+		for _, id := range []platform.ID{owned, unowned} {
+			k, err := id.Encode()
+			if err != nil {
+				return err
+			}
+			v := []byte(`{"id":"` + id.String() + `","name":"legacy","cells":[]}`)
+			if err := tx.Bucket([]byte("dashboardsv2")).Put(k, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reopening the store migrates it.
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := c.FindDashboardByID(ctx, owned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.OrganizationID != orgB.ID {
+		t.Errorf("expected the owned dashboard to take the organization of its owner %s, got %s", orgB.ID, d.OrganizationID)
+	}
+
+	d, err = c.FindDashboardByID(ctx, unowned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.OrganizationID.Valid() {
+		t.Errorf("expected the unowned dashboard to be left without an organization, got %s", d.OrganizationID)
+	}
+}
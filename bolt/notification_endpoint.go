@@ -0,0 +1,200 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+)
+
+var (
+	notificationEndpointBucket = []byte("notificationendpointsv1")
+)
+
+var _ platform.NotificationEndpointService = (*Client)(nil)
+
+func (c *Client) initializeNotificationEndpoints(ctx context.Context, tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(notificationEndpointBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+func filterNotificationEndpointsFn(filter platform.NotificationEndpointFilter) func(e *platform.NotificationEndpoint) bool {
+	return func(e *platform.NotificationEndpoint) bool {
+		return (filter.ID == nil || e.ID == *filter.ID) &&
+			(filter.OrganizationID == nil || e.OrganizationID == *filter.OrganizationID)
+	}
+}
+
+// FindNotificationEndpointByID returns a single notification endpoint by ID.
+func (c *Client) FindNotificationEndpointByID(ctx context.Context, id platform.ID) (*platform.NotificationEndpoint, error) {
+	op := "bolt/find notification endpoint by id"
+	var endpoint *platform.NotificationEndpoint
+	err := c.db.View(func(tx *bolt.Tx) error {
+		e, pErr := c.findNotificationEndpointByID(ctx, tx, id)
+		if pErr != nil {
+			pErr.Op = op
+			return pErr
+		}
+		endpoint = e
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+func (c *Client) findNotificationEndpointByID(ctx context.Context, tx *bolt.Tx, id platform.ID) (*platform.NotificationEndpoint, *platform.Error) {
+	encID, err := id.Encode()
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EEmptyValue,
+			Err:  err,
+		}
+	}
+
+	d := tx.Bucket(notificationEndpointBucket).Get(encID)
+	if d == nil {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("notification endpoint with ID %v not found", id),
+		}
+	}
+
+	e := &platform.NotificationEndpoint{}
+	if err := json.Unmarshal(d, e); err != nil {
+		return nil, &platform.Error{
+			Err: err,
+		}
+	}
+	return e, nil
+}
+
+// FindNotificationEndpoints returns all notification endpoints that match filter.
+func (c *Client) FindNotificationEndpoints(ctx context.Context, filter platform.NotificationEndpointFilter) ([]*platform.NotificationEndpoint, error) {
+	endpoints := []*platform.NotificationEndpoint{}
+	filterFn := filterNotificationEndpointsFn(filter)
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		cur := tx.Bucket(notificationEndpointBucket).Cursor()
+		for k, v := cur.First(); k != nil; k, v = cur.Next() {
+			e := &platform.NotificationEndpoint{}
+			if err := json.Unmarshal(v, e); err != nil {
+				return err
+			}
+			if filterFn(e) {
+				endpoints = append(endpoints, e)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Op:  "bolt/find notification endpoints",
+			Err: err,
+		}
+	}
+	return endpoints, nil
+}
+
+// CreateNotificationEndpoint creates a new notification endpoint and sets e.ID with the new identifier.
+func (c *Client) CreateNotificationEndpoint(ctx context.Context, e *platform.NotificationEndpoint) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		e.ID = c.IDGenerator.ID()
+		if pErr := c.putNotificationEndpoint(ctx, tx, e); pErr != nil {
+			pErr.Op = "bolt/create notification endpoint"
+			return pErr
+		}
+		return nil
+	})
+}
+
+// PutNotificationEndpoint puts a notification endpoint in the store, as is.
+func (c *Client) PutNotificationEndpoint(ctx context.Context, e *platform.NotificationEndpoint) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		if pErr := c.putNotificationEndpoint(ctx, tx, e); pErr != nil {
+			pErr.Op = "bolt/put notification endpoint"
+			return pErr
+		}
+		return nil
+	})
+}
+
+func (c *Client) putNotificationEndpoint(ctx context.Context, tx *bolt.Tx, e *platform.NotificationEndpoint) *platform.Error {
+	v, err := json.Marshal(e)
+	if err != nil {
+		return &platform.Error{
+			Err: err,
+		}
+	}
+	encID, err := e.ID.Encode()
+	if err != nil {
+		return &platform.Error{
+			Code: platform.EEmptyValue,
+			Err:  err,
+		}
+	}
+	if err := tx.Bucket(notificationEndpointBucket).Put(encID, v); err != nil {
+		return &platform.Error{
+			Err: err,
+		}
+	}
+	return nil
+}
+
+// UpdateNotificationEndpoint updates a single notification endpoint with changeset.
+// Returns the new notification endpoint state after update.
+func (c *Client) UpdateNotificationEndpoint(ctx context.Context, id platform.ID, upd platform.NotificationEndpointUpdate) (*platform.NotificationEndpoint, error) {
+	op := "bolt/update notification endpoint"
+	var endpoint *platform.NotificationEndpoint
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		e, pErr := c.findNotificationEndpointByID(ctx, tx, id)
+		if pErr != nil {
+			pErr.Op = op
+			return pErr
+		}
+
+		upd.Apply(e)
+		if pErr := c.putNotificationEndpoint(ctx, tx, e); pErr != nil {
+			pErr.Op = op
+			return pErr
+		}
+		endpoint = e
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// DeleteNotificationEndpoint removes a notification endpoint by ID.
+func (c *Client) DeleteNotificationEndpoint(ctx context.Context, id platform.ID) error {
+	op := "bolt/delete notification endpoint"
+	return c.db.Update(func(tx *bolt.Tx) error {
+		if _, pErr := c.findNotificationEndpointByID(ctx, tx, id); pErr != nil {
+			pErr.Op = op
+			return pErr
+		}
+
+		encID, err := id.Encode()
+		if err != nil {
+			return &platform.Error{
+				Code: platform.EEmptyValue,
+				Op:   op,
+				Err:  err,
+			}
+		}
+		if err := tx.Bucket(notificationEndpointBucket).Delete(encID); err != nil {
+			return &platform.Error{
+				Op:  op,
+				Err: err,
+			}
+		}
+		return nil
+	})
+}
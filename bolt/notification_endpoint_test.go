@@ -0,0 +1,35 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initNotificationEndpointService(f platformtesting.NotificationEndpointFields, t *testing.T) (platform.NotificationEndpointService, func()) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	c.IDGenerator = f.IDGenerator
+	ctx := context.TODO()
+	for _, e := range f.NotificationEndpoints {
+		if err := c.PutNotificationEndpoint(ctx, e); err != nil {
+			t.Fatalf("failed to populate notification endpoints: %v", err)
+		}
+	}
+	return c, func() {
+		defer closeFn()
+		for _, e := range f.NotificationEndpoints {
+			if err := c.DeleteNotificationEndpoint(ctx, e.ID); err != nil {
+				t.Logf("failed to remove notification endpoint: %v", err)
+			}
+		}
+	}
+}
+
+func TestNotificationEndpointService(t *testing.T) {
+	platformtesting.NotificationEndpointService(initNotificationEndpointService, t)
+}
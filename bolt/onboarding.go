@@ -99,6 +99,14 @@ func (c *Client) Generate(ctx context.Context, req *platform.OnboardingRequest)
 	if err = c.CreateOrganization(ctx, o); err != nil {
 		return nil, err
 	}
+	if err = c.CreateUserResourceMapping(ctx, &platform.UserResourceMapping{
+		ResourceID:   o.ID,
+		ResourceType: platform.OrgResourceType,
+		UserID:       u.ID,
+		UserType:     platform.Owner,
+	}); err != nil {
+		return nil, err
+	}
 	bucket := &platform.Bucket{
 		Name:            req.Bucket,
 		Organization:    o.Name,
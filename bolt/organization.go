@@ -294,6 +294,9 @@ func (c *Client) DeleteOrganization(ctx context.Context, id platform.ID) error {
 		if err := c.deleteOrganizationsBuckets(ctx, tx, id); err != nil {
 			return err
 		}
+		if err := c.deleteOrganizationSettings(ctx, tx, id); err != nil {
+			return err
+		}
 		return c.deleteOrganization(ctx, tx, id)
 	})
 }
@@ -0,0 +1,113 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+)
+
+var (
+	organizationSettingsBucket = []byte("organizationsettingsv1")
+)
+
+var _ platform.OrganizationSettingsService = (*Client)(nil)
+
+func (c *Client) initializeOrganizationSettings(ctx context.Context, tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists([]byte(organizationSettingsBucket)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindOrganizationSettings retrieves the settings of an organization.
+func (c *Client) FindOrganizationSettings(ctx context.Context, orgID platform.ID) (*platform.OrganizationSettings, error) {
+	var s *platform.OrganizationSettings
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		settings, err := c.findOrganizationSettings(ctx, tx, orgID)
+		if err != nil {
+			return err
+		}
+		s = settings
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (c *Client) findOrganizationSettings(ctx context.Context, tx *bolt.Tx, orgID platform.ID) (*platform.OrganizationSettings, error) {
+	if _, err := c.findOrganizationByID(ctx, tx, orgID); err != nil {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Op:   "bolt/find organization settings",
+			Err:  err,
+		}
+	}
+
+	encodedID, err := orgID.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var s platform.OrganizationSettings
+	v := tx.Bucket(organizationSettingsBucket).Get(encodedID)
+	if len(v) == 0 {
+		return &s, nil
+	}
+
+	if err := json.Unmarshal(v, &s); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// UpdateOrganizationSettings updates the settings of an organization according the parameters set on upd.
+func (c *Client) UpdateOrganizationSettings(ctx context.Context, orgID platform.ID, upd platform.OrganizationSettingsUpdate) (*platform.OrganizationSettings, error) {
+	if err := upd.Valid(); err != nil {
+		return nil, err
+	}
+
+	var s *platform.OrganizationSettings
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		settings, err := c.findOrganizationSettings(ctx, tx, orgID)
+		if err != nil {
+			return err
+		}
+		upd.Apply(settings)
+
+		if err := c.putOrganizationSettings(ctx, tx, orgID, settings); err != nil {
+			return err
+		}
+		s = settings
+		return nil
+	})
+
+	return s, err
+}
+
+func (c *Client) putOrganizationSettings(ctx context.Context, tx *bolt.Tx, orgID platform.ID, s *platform.OrganizationSettings) error {
+	v, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	encodedID, err := orgID.Encode()
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(organizationSettingsBucket).Put(encodedID, v)
+}
+
+func (c *Client) deleteOrganizationSettings(ctx context.Context, tx *bolt.Tx, orgID platform.ID) error {
+	encodedID, err := orgID.Encode()
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(organizationSettingsBucket).Delete(encodedID)
+}
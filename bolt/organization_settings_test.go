@@ -0,0 +1,72 @@
+package bolt_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+func TestOrganizationSettingsService(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	ctx := context.Background()
+	org := &platform.Organization{Name: "o1"}
+	if err := c.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := c.FindOrganizationSettings(ctx, org.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s, &platform.OrganizationSettings{}) {
+		t.Fatalf("expected zero settings, got %+v", s)
+	}
+
+	concurrency, timeout := 2, 5*time.Second
+	s, err = c.UpdateOrganizationSettings(ctx, org.ID, platform.OrganizationSettingsUpdate{
+		QueryConcurrency:  &concurrency,
+		QueryQueueTimeout: &timeout,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	memory := int64(1024)
+	if _, err := c.UpdateOrganizationSettings(ctx, org.ID, platform.OrganizationSettingsUpdate{
+		QueryMemoryBytes: &memory,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &platform.OrganizationSettings{
+		QueryConcurrency:  2,
+		QueryQueueTimeout: 5 * time.Second,
+		QueryMemoryBytes:  1024,
+	}
+	if s, err = c.FindOrganizationSettings(ctx, org.ID); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(s, want) {
+		t.Fatalf("unexpected settings: got %+v, want %+v", s, want)
+	}
+
+	negative := -1
+	if _, err := c.UpdateOrganizationSettings(ctx, org.ID, platform.OrganizationSettingsUpdate{
+		QueryQueueSize: &negative,
+	}); err == nil {
+		t.Fatal("expected error updating settings with a negative limit")
+	}
+
+	if err := c.DeleteOrganization(ctx, org.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.FindOrganizationSettings(ctx, org.ID); platform.ErrorCode(err) != platform.ENotFound {
+		t.Fatalf("expected not found error finding the settings of a deleted organization, got %v", err)
+	}
+}
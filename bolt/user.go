@@ -318,6 +318,9 @@ func (c *Client) deleteUser(ctx context.Context, tx *bolt.Tx, id platform.ID) er
 	if err := tx.Bucket(userIndex).Delete(userIndexKey(u.Name)); err != nil {
 		return err
 	}
+	if err := c.deleteUserIdentities(ctx, tx, id); err != nil {
+		return err
+	}
 	return tx.Bucket(userBucket).Delete(encodedID)
 }
 
@@ -0,0 +1,86 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+)
+
+var (
+	userIdentityBucket = []byte("useridentitiesv1")
+)
+
+var _ platform.UserIdentityService = (*Client)(nil)
+
+func (c *Client) initializeUserIdentities(ctx context.Context, tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists([]byte(userIdentityBucket)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// userIdentityKey separates the provider from the subject with a byte neither can contain.
+func userIdentityKey(provider, subject string) []byte {
+	return []byte(provider + "\x00" + subject)
+}
+
+// FindUserIdentity retrieves the identity of the subject at the provider.
+func (c *Client) FindUserIdentity(ctx context.Context, provider, subject string) (*platform.UserIdentity, error) {
+	var i platform.UserIdentity
+	err := c.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(userIdentityBucket).Get(userIdentityKey(provider, subject))
+		if len(v) == 0 {
+			return &platform.Error{
+				Code: platform.ENotFound,
+				Op:   "bolt/find user identity",
+				Msg:  fmt.Sprintf("identity %s of provider %s not found", subject, provider),
+			}
+		}
+		return json.Unmarshal(v, &i)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &i, nil
+}
+
+// PutUserIdentity links the identity to its user, replacing any previous link.
+func (c *Client) PutUserIdentity(ctx context.Context, i *platform.UserIdentity) error {
+	v, err := json.Marshal(i)
+	if err != nil {
+		return err
+	}
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(userIdentityBucket).Put(userIdentityKey(i.Provider, i.Subject), v)
+	})
+}
+
+// deleteUserIdentities unlinks the identities of the user, so that they sign in a new user.
+func (c *Client) deleteUserIdentities(ctx context.Context, tx *bolt.Tx, userID platform.ID) error {
+	var keys [][]byte
+	err := tx.Bucket(userIdentityBucket).ForEach(func(k, v []byte) error {
+		var i platform.UserIdentity
+		if err := json.Unmarshal(v, &i); err != nil {
+			return err
+		}
+		if i.UserID == userID {
+			keys = append(keys, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		if err := tx.Bucket(userIdentityBucket).Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package bolt_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/platform"
+)
+
+func TestUserIdentityService(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	ctx := context.Background()
+	u := &platform.User{Name: "jane@example.com"}
+	if err := c.CreateUser(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.FindUserIdentity(ctx, "github", u.Name); platform.ErrorCode(err) != platform.ENotFound {
+		t.Fatalf("expected unknown identity not to be found, got %v", err)
+	}
+
+	want := &platform.UserIdentity{Provider: "github", Subject: u.Name, UserID: u.ID}
+	if err := c.PutUserIdentity(ctx, want); err != nil {
+		t.Fatal(err)
+	}
+	if i, err := c.FindUserIdentity(ctx, "github", u.Name); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(i, want) {
+		t.Fatalf("unexpected identity: got %+v, want %+v", i, want)
+	}
+	if _, err := c.FindUserIdentity(ctx, "google", u.Name); platform.ErrorCode(err) != platform.ENotFound {
+		t.Fatalf("expected identity of another provider not to be found, got %v", err)
+	}
+
+	if err := c.DeleteUser(ctx, u.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.FindUserIdentity(ctx, "github", u.Name); platform.ErrorCode(err) != platform.ENotFound {
+		t.Fatalf("expected identity of deleted user not to be found, got %v", err)
+	}
+}
@@ -64,6 +64,24 @@ func (c *Client) findUserResourceMappings(ctx context.Context, tx *bolt.Tx, filt
 	return ms, nil
 }
 
+// ownedResourceIDs returns the IDs of the resources of type typ owned by the user.
+func (c *Client) ownedResourceIDs(ctx context.Context, tx *bolt.Tx, userID platform.ID, typ platform.ResourceType) (map[platform.ID]bool, error) {
+	ms, err := c.findUserResourceMappings(ctx, tx, platform.UserResourceMappingFilter{
+		UserID:       userID,
+		UserType:     platform.Owner,
+		ResourceType: typ,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[platform.ID]bool, len(ms))
+	for _, m := range ms {
+		ids[m.ResourceID] = true
+	}
+	return ids, nil
+}
+
 func (c *Client) findUserResourceMapping(ctx context.Context, tx *bolt.Tx, resourceID platform.ID, userID platform.ID) (*platform.UserResourceMapping, error) {
 	var m platform.UserResourceMapping
 
@@ -3,6 +3,7 @@ package bolt
 import (
 	"context"
 	"encoding/json"
+	"strings"
 
 	bolt "github.com/coreos/bbolt"
 	"github.com/influxdata/platform"
@@ -59,7 +60,7 @@ func (c *Client) findViewByID(ctx context.Context, tx *bolt.Tx, id platform.ID)
 	return &d, nil
 }
 
-func (c *Client) copyView(ctx context.Context, tx *bolt.Tx, id platform.ID) (*platform.View, error) {
+func (c *Client) copyView(ctx context.Context, tx *bolt.Tx, id, orgID platform.ID) (*platform.View, error) {
 	v, err := c.findViewByID(ctx, tx, id)
 	if err != nil {
 		return nil, err
@@ -67,7 +68,8 @@ func (c *Client) copyView(ctx context.Context, tx *bolt.Tx, id platform.ID) (*pl
 
 	view := &platform.View{
 		ViewContents: platform.ViewContents{
-			Name: v.Name,
+			OrganizationID: orgID,
+			Name:           v.Name,
 		},
 		Properties: v.Properties,
 	}
@@ -81,54 +83,29 @@ func (c *Client) copyView(ctx context.Context, tx *bolt.Tx, id platform.ID) (*pl
 
 // FindView retrieves a view using an arbitrary view filter.
 func (c *Client) FindView(ctx context.Context, filter platform.ViewFilter) (*platform.View, error) {
-	if filter.ID != nil {
-		return c.FindViewByID(ctx, *filter.ID)
-	}
-
-	var d *platform.View
-	err := c.db.View(func(tx *bolt.Tx) error {
-		filterFn := filterViewsFn(filter)
-		return c.forEachView(ctx, tx, func(dash *platform.View) bool {
-			if filterFn(dash) {
-				d = dash
-				return false
-			}
-			return true
-		})
-	})
-
+	vs, _, err := c.FindViews(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	if d == nil {
+	if len(vs) == 0 {
 		return nil, platform.ErrViewNotFound
 	}
 
-	return d, nil
+	return vs[0], nil
 }
 
-func filterViewsFn(filter platform.ViewFilter) func(d *platform.View) bool {
-	if filter.ID != nil {
-		return func(d *platform.View) bool {
-			return d.ID == *filter.ID
-		}
+func filterViewsFn(filter platform.ViewFilter, owned map[platform.ID]bool) func(d *platform.View) bool {
+	return func(d *platform.View) bool {
+		return (filter.ID == nil || d.ID == *filter.ID) &&
+			(filter.OrganizationID == nil || d.OrganizationID == *filter.OrganizationID) &&
+			(filter.NamePrefix == nil || strings.HasPrefix(d.Name, *filter.NamePrefix)) &&
+			(filter.Owner == nil || owned[d.ID])
 	}
-
-	return func(d *platform.View) bool { return true }
 }
 
 // FindViews retrives all views that match an arbitrary view filter.
 func (c *Client) FindViews(ctx context.Context, filter platform.ViewFilter) ([]*platform.View, int, error) {
-	if filter.ID != nil {
-		d, err := c.FindViewByID(ctx, *filter.ID)
-		if err != nil {
-			return nil, 0, err
-		}
-
-		return []*platform.View{d}, 1, nil
-	}
-
 	ds := []*platform.View{}
 	err := c.db.View(func(tx *bolt.Tx) error {
 		dashs, err := c.findViews(ctx, tx, filter)
@@ -147,9 +124,28 @@ func (c *Client) FindViews(ctx context.Context, filter platform.ViewFilter) ([]*
 }
 
 func (c *Client) findViews(ctx context.Context, tx *bolt.Tx, filter platform.ViewFilter) ([]*platform.View, error) {
-	ds := []*platform.View{}
+	var owned map[platform.ID]bool
+	if filter.Owner != nil {
+		ids, err := c.ownedResourceIDs(ctx, tx, *filter.Owner, platform.ViewResourceType)
+		if err != nil {
+			return nil, err
+		}
+		owned = ids
+	}
+	filterFn := filterViewsFn(filter, owned)
 
-	filterFn := filterViewsFn(filter)
+	if filter.ID != nil {
+		d, err := c.findViewByID(ctx, tx, *filter.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !filterFn(d) {
+			return []*platform.View{}, nil
+		}
+		return []*platform.View{d}, nil
+	}
+
+	ds := []*platform.View{}
 	err := c.forEachView(ctx, tx, func(d *platform.View) bool {
 		if filterFn(d) {
 			ds = append(ds, d)
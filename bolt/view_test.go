@@ -20,6 +20,11 @@ func initViewService(f platformtesting.ViewFields, t *testing.T) (platform.ViewS
 			t.Fatalf("failed to populate cells")
 		}
 	}
+	for _, m := range f.UserResourceMappings {
+		if err := c.CreateUserResourceMapping(ctx, m); err != nil {
+			t.Fatalf("failed to populate user resource mappings")
+		}
+	}
 	return c, func() {
 		defer closeFn()
 		for _, b := range f.Views {
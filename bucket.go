@@ -25,6 +25,9 @@ type Bucket struct {
 	Name                string        `json:"name"`
 	RetentionPolicyName string        `json:"rp,omitempty"` // This to support v1 sources
 	RetentionPeriod     time.Duration `json:"retentionPeriod"`
+	// MaxSeries is the number of series the bucket may hold; writes creating more series are rejected.
+	// Zero is unlimited.
+	MaxSeries int `json:"maxSeries,omitempty"`
 }
 
 // BucketService represents a service for managing bucket data.
@@ -55,6 +58,7 @@ type BucketService interface {
 type BucketUpdate struct {
 	Name            *string        `json:"name,omitempty"`
 	RetentionPeriod *time.Duration `json:"retentionPeriod,omitempty"`
+	MaxSeries       *int           `json:"maxSeries,omitempty"`
 }
 
 // BucketFilter represents a set of filter that restrict the returned results.
@@ -65,6 +69,30 @@ type BucketFilter struct {
 	Organization   *string
 }
 
+// FindOrCreateOrgBucket returns the bucket of the organization with the given name,
+// creating it with the retention period if it does not exist. The system uses it for
+// the buckets it writes to on behalf of each organization, which are looked up on every
+// write rather than cached, since users may delete them.
+func FindOrCreateOrgBucket(ctx context.Context, bs BucketService, orgID ID, name string, retentionPeriod time.Duration) (*Bucket, error) {
+	buckets, _, err := bs.FindBuckets(ctx, BucketFilter{OrganizationID: &orgID, Name: &name})
+	if err != nil {
+		return nil, err
+	}
+	if len(buckets) > 0 {
+		return buckets[0], nil
+	}
+
+	b := &Bucket{
+		OrganizationID:  orgID,
+		Name:            name,
+		RetentionPeriod: retentionPeriod,
+	}
+	if err := bs.CreateBucket(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
 // FindOptions represents options passed to all find methods with multiple results.
 type FindOptions struct {
 	Limit      int
@@ -77,3 +105,35 @@ type FindOptions struct {
 func InternalBucketID(t BucketType) (*ID, error) {
 	return IDFromString(fmt.Sprintf("%d", t))
 }
+
+// BucketStats describes the data stored in a bucket.
+type BucketStats struct {
+	BucketID ID `json:"bucketID"`
+	// SeriesN is the number of series in the bucket.
+	SeriesN int `json:"seriesCount"`
+	// DiskBytes is the size of the data of the bucket in TSM files.
+	DiskBytes int64 `json:"diskBytes"`
+	// CacheBytes is the size of the data of the bucket not yet written to TSM files.
+	CacheBytes int64 `json:"cacheBytes"`
+	// Earliest and Latest are the timestamps of the oldest and newest points of the bucket.
+	// They are nil when the bucket holds no points.
+	Earliest     *time.Time         `json:"earliest,omitempty"`
+	Latest       *time.Time         `json:"latest,omitempty"`
+	Measurements []MeasurementStats `json:"measurements"`
+}
+
+// MeasurementStats describes the data stored for a measurement of a bucket.
+type MeasurementStats struct {
+	Name       string     `json:"name"`
+	SeriesN    int        `json:"seriesCount"`
+	DiskBytes  int64      `json:"diskBytes"`
+	CacheBytes int64      `json:"cacheBytes"`
+	Earliest   *time.Time `json:"earliest,omitempty"`
+	Latest     *time.Time `json:"latest,omitempty"`
+}
+
+// BucketStatsService represents a service for inspecting the data stored in buckets.
+type BucketStatsService interface {
+	// FindBucketStats returns the stats of a bucket by ID.
+	FindBucketStats(ctx context.Context, id ID) (*BucketStats, error)
+}
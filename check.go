@@ -0,0 +1,365 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CheckService represents a service for managing checks, which monitor the data of a bucket
+// and report its status.
+type CheckService interface {
+	// FindCheckByID returns a single check by ID.
+	FindCheckByID(ctx context.Context, id ID) (*Check, error)
+
+	// FindChecks returns all checks that match filter.
+	FindChecks(ctx context.Context, filter CheckFilter) ([]*Check, error)
+
+	// CreateCheck creates a new check and sets c.ID with the new identifier.
+	CreateCheck(ctx context.Context, c *Check) error
+
+	// UpdateCheck updates a single check with changeset.
+	// Returns the new check state after update.
+	UpdateCheck(ctx context.Context, id ID, upd CheckUpdate) (*Check, error)
+
+	// DeleteCheck removes a check by ID, along with its statuses.
+	DeleteCheck(ctx context.Context, id ID) error
+}
+
+// CheckStatusService persists the last status of each series of the checks,
+// so that the changes of their levels are detected across restarts.
+type CheckStatusService interface {
+	// FindCheckStatuses returns the last status of each series of the check.
+	FindCheckStatuses(ctx context.Context, checkID ID) ([]*CheckStatus, error)
+
+	// PutCheckStatuses replaces the statuses of the series of the check.
+	PutCheckStatuses(ctx context.Context, checkID ID, statuses []*CheckStatus) error
+}
+
+// CheckStatus is the last level a check reported for one of its series.
+type CheckStatus struct {
+	// Tags identify the series; they are empty for the status of a deadman check without any series.
+	Tags  map[string]string `json:"tags,omitempty"`
+	Level CheckLevel        `json:"level"`
+}
+
+// CheckType is the kind of condition a check monitors.
+type CheckType string
+
+const (
+	// ThresholdCheckType checks the aggregated value of each series against thresholds.
+	ThresholdCheckType CheckType = "threshold"
+	// DeadmanCheckType checks that each series keeps reporting data.
+	DeadmanCheckType CheckType = "deadman"
+)
+
+// CheckLevel is the status level a check reports for a series.
+type CheckLevel string
+
+// Check levels, from the least to the most severe.
+const (
+	CheckLevelOK   CheckLevel = "ok"
+	CheckLevelInfo CheckLevel = "info"
+	CheckLevelWarn CheckLevel = "warn"
+	CheckLevelCrit CheckLevel = "crit"
+)
+
+// Severity returns the rank of the level, from 0 for ok to 3 for crit, or -1 if the level is unknown.
+func (l CheckLevel) Severity() int {
+	switch l {
+	case CheckLevelOK:
+		return 0
+	case CheckLevelInfo:
+		return 1
+	case CheckLevelWarn:
+		return 2
+	case CheckLevelCrit:
+		return 3
+	default:
+		return -1
+	}
+}
+
+// Check statuses, mirroring the status of the check's task.
+const (
+	CheckActive   = "active"
+	CheckInactive = "inactive"
+)
+
+// Check monitors the data of a bucket on a schedule.
+// Each check is run by a task, which it manages.
+type Check struct {
+	ID             ID        `json:"id,omitempty"`
+	OrganizationID ID        `json:"organizationID"`
+	Name           string    `json:"name"`
+	Type           CheckType `json:"type"`
+	// Status is either "active" or "inactive"; inactive checks are not run.
+	Status string `json:"status"`
+	// Every is how often the check runs, as a duration such as "1m".
+	Every string     `json:"every"`
+	Query CheckQuery `json:"query"`
+
+	// Thresholds are the levels of a threshold check.
+	Thresholds []Threshold `json:"thresholds,omitempty"`
+
+	// TimeSince is the duration, such as "5m", without data after which a deadman check reports its level.
+	TimeSince string `json:"timeSince,omitempty"`
+	// Level is the level a deadman check reports for series without data.
+	// If empty, the level is crit.
+	Level CheckLevel `json:"level,omitempty"`
+
+	// EndpointIDs are the notification endpoints notified when the level of a series changes.
+	EndpointIDs []ID `json:"endpointIDs,omitempty"`
+
+	// OwnerID is the user owning the task of the check.
+	OwnerID ID `json:"ownerID,omitempty"`
+	// TaskID is the task running the check.
+	TaskID ID `json:"taskID,omitempty"`
+}
+
+// CheckQuery selects the series a check monitors.
+type CheckQuery struct {
+	Bucket      string            `json:"bucket"`
+	Measurement string            `json:"measurement"`
+	Field       string            `json:"field"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	// Aggregate is the function reducing the values of each series in a run of a threshold check,
+	// to the value that is compared to the thresholds: "last", "first", "mean", "min", "max", "sum" or "count".
+	// If empty, the last value is used.
+	Aggregate string `json:"aggregate,omitempty"`
+}
+
+var checkAggregates = map[string]bool{
+	"last":  true,
+	"first": true,
+	"mean":  true,
+	"min":   true,
+	"max":   true,
+	"sum":   true,
+	"count": true,
+}
+
+// Threshold is a level of a threshold check, reported when a value is above the threshold,
+// or below it if Below is set.
+type Threshold struct {
+	Level CheckLevel `json:"level"`
+	Value float64    `json:"value"`
+	Below bool       `json:"below,omitempty"`
+}
+
+// Matches reports whether v crosses the threshold.
+func (t Threshold) Matches(v float64) bool {
+	if t.Below {
+		return v < t.Value
+	}
+	return v > t.Value
+}
+
+// CheckFilter represents a set of filters that restrict the returned checks.
+type CheckFilter struct {
+	ID             *ID
+	OrganizationID *ID
+	TaskID         *ID
+}
+
+// CheckUpdate is the set of changes that can be applied to a check.
+// Nil fields are left unchanged.
+type CheckUpdate struct {
+	Name        *string     `json:"name,omitempty"`
+	Status      *string     `json:"status,omitempty"`
+	Every       *string     `json:"every,omitempty"`
+	Query       *CheckQuery `json:"query,omitempty"`
+	Thresholds  []Threshold `json:"thresholds,omitempty"`
+	TimeSince   *string     `json:"timeSince,omitempty"`
+	Level       *CheckLevel `json:"level,omitempty"`
+	EndpointIDs []ID        `json:"endpointIDs,omitempty"`
+}
+
+// Valid returns an error if the changeset is empty.
+func (u *CheckUpdate) Valid() error {
+	if u.Name == nil && u.Status == nil && u.Every == nil && u.Query == nil && u.Thresholds == nil &&
+		u.TimeSince == nil && u.Level == nil && u.EndpointIDs == nil {
+		return fmt.Errorf("no fields supplied in update")
+	}
+	return nil
+}
+
+// Apply applies the non-nil fields of the changeset to c.
+func (u *CheckUpdate) Apply(c *Check) {
+	if u.Name != nil {
+		c.Name = *u.Name
+	}
+	if u.Status != nil {
+		c.Status = *u.Status
+	}
+	if u.Every != nil {
+		c.Every = *u.Every
+	}
+	if u.Query != nil {
+		c.Query = *u.Query
+	}
+	if u.Thresholds != nil {
+		c.Thresholds = u.Thresholds
+	}
+	if u.TimeSince != nil {
+		c.TimeSince = *u.TimeSince
+	}
+	if u.Level != nil {
+		c.Level = *u.Level
+	}
+	if u.EndpointIDs != nil {
+		c.EndpointIDs = u.EndpointIDs
+	}
+}
+
+// Valid returns an error if the check contains invalid data.
+func (c *Check) Valid() error {
+	if c.Name == "" {
+		return fmt.Errorf("name empty")
+	}
+	if c.Status != "" && c.Status != CheckActive && c.Status != CheckInactive {
+		return fmt.Errorf("invalid status %q", c.Status)
+	}
+	if _, err := parsePositiveDuration(c.Every); err != nil {
+		return fmt.Errorf("invalid every: %v", err)
+	}
+	if c.Query.Bucket == "" {
+		return fmt.Errorf("query bucket empty")
+	}
+	if c.Query.Measurement == "" {
+		return fmt.Errorf("query measurement empty")
+	}
+	if c.Query.Field == "" {
+		return fmt.Errorf("query field empty")
+	}
+
+	switch c.Type {
+	case ThresholdCheckType:
+		if len(c.Thresholds) == 0 {
+			return fmt.Errorf("threshold check requires thresholds")
+		}
+		for _, t := range c.Thresholds {
+			if t.Level.Severity() <= 0 {
+				return fmt.Errorf("invalid threshold level %q", t.Level)
+			}
+		}
+		if c.Query.Aggregate != "" && !checkAggregates[c.Query.Aggregate] {
+			return fmt.Errorf("invalid query aggregate %q", c.Query.Aggregate)
+		}
+	case DeadmanCheckType:
+		if _, err := parsePositiveDuration(c.TimeSince); err != nil {
+			return fmt.Errorf("invalid timeSince: %v", err)
+		}
+		if c.Level != "" && c.Level.Severity() <= 0 {
+			return fmt.Errorf("invalid level %q", c.Level)
+		}
+	default:
+		return fmt.Errorf("invalid check type %q", c.Type)
+	}
+
+	return nil
+}
+
+// ThresholdLevel returns the level of the most severe threshold crossed by v, or ok if none is.
+func (c *Check) ThresholdLevel(v float64) CheckLevel {
+	level := CheckLevelOK
+	for _, t := range c.Thresholds {
+		if t.Matches(v) && t.Level.Severity() > level.Severity() {
+			level = t.Level
+		}
+	}
+	return level
+}
+
+// DeadmanLevel returns the level a deadman check reports for series without data.
+func (c *Check) DeadmanLevel() CheckLevel {
+	if c.Level == "" {
+		return CheckLevelCrit
+	}
+	return c.Level
+}
+
+// GenerateFlux returns the script of the task running the check.
+// The script queries the series of the check over the last run interval,
+// or, for a deadman check, over the TimeSince interval.
+func (c *Check) GenerateFlux() (string, error) {
+	if err := c.Valid(); err != nil {
+		return "", err
+	}
+
+	every, _ := parsePositiveDuration(c.Every)
+	window := every
+	if c.Type == DeadmanCheckType {
+		window, _ = parsePositiveDuration(c.TimeSince)
+	}
+
+	preds := []string{
+		"r._measurement == " + fluxString(c.Query.Measurement),
+		"r._field == " + fluxString(c.Query.Field),
+	}
+	keys := make([]string, 0, len(c.Query.Tags))
+	for k := range c.Query.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		preds = append(preds, fmt.Sprintf("r[%s] == %s", fluxString(k), fluxString(c.Query.Tags[k])))
+	}
+
+	aggregate := "last"
+	if c.Type == ThresholdCheckType && c.Query.Aggregate != "" {
+		aggregate = c.Query.Aggregate
+	}
+	// Sorting by time keeps last() and first() from being pushed down into storage,
+	// which fails to plan after a range.
+	call := aggregate + "()"
+	if aggregate == "last" || aggregate == "first" {
+		call = `sort(cols: ["_time"])` + "\n\t|> " + call
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "option task = {\n\tname: %s,\n\tevery: %s,\n}\n\n", fluxString(c.Name), fluxDuration(every))
+	fmt.Fprintf(&b, "from(bucket: %s)\n", fluxString(c.Query.Bucket))
+	fmt.Fprintf(&b, "\t|> range(start: -%s)\n", fluxDuration(window))
+	fmt.Fprintf(&b, "\t|> filter(fn: (r) => %s)\n", strings.Join(preds, " and "))
+	fmt.Fprintf(&b, "\t|> %s\n", call)
+	return b.String(), nil
+}
+
+func parsePositiveDuration(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration %q must be positive", s)
+	}
+	return d, nil
+}
+
+// fluxString returns s as a Flux string literal.
+func fluxString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// fluxDuration returns d as a Flux duration literal, in the largest unit that divides it.
+func fluxDuration(d time.Duration) string {
+	units := []struct {
+		d    time.Duration
+		unit string
+	}{
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+		{time.Millisecond, "ms"},
+		{time.Microsecond, "us"},
+	}
+	for _, u := range units {
+		if d%u.d == 0 {
+			return fmt.Sprintf("%d%s", d/u.d, u.unit)
+		}
+	}
+	return fmt.Sprintf("%dns", d)
+}
@@ -0,0 +1,192 @@
+package platform_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/platform"
+	_ "github.com/influxdata/platform/query/builtin"
+)
+
+func TestCheck_Valid(t *testing.T) {
+	valid := func() *platform.Check {
+		return &platform.Check{
+			Name:  "cpu",
+			Type:  platform.ThresholdCheckType,
+			Every: "1m",
+			Query: platform.CheckQuery{
+				Bucket:      "telegraf",
+				Measurement: "cpu",
+				Field:       "usage_user",
+			},
+			Thresholds: []platform.Threshold{
+				{Level: platform.CheckLevelCrit, Value: 90},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *platform.Check)
+		wantErr bool
+	}{
+		{
+			name:   "valid threshold check",
+			modify: func(c *platform.Check) {},
+		},
+		{
+			name: "valid deadman check",
+			modify: func(c *platform.Check) {
+				c.Type = platform.DeadmanCheckType
+				c.Thresholds = nil
+				c.TimeSince = "5m"
+			},
+		},
+		{
+			name:    "missing name",
+			modify:  func(c *platform.Check) { c.Name = "" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid every",
+			modify:  func(c *platform.Check) { c.Every = "-1m" },
+			wantErr: true,
+		},
+		{
+			name:    "threshold check without thresholds",
+			modify:  func(c *platform.Check) { c.Thresholds = nil },
+			wantErr: true,
+		},
+		{
+			name:    "threshold at ok level",
+			modify:  func(c *platform.Check) { c.Thresholds[0].Level = platform.CheckLevelOK },
+			wantErr: true,
+		},
+		{
+			name:    "invalid aggregate",
+			modify:  func(c *platform.Check) { c.Query.Aggregate = "median" },
+			wantErr: true,
+		},
+		{
+			name: "deadman check without timeSince",
+			modify: func(c *platform.Check) {
+				c.Type = platform.DeadmanCheckType
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid type",
+			modify:  func(c *platform.Check) { c.Type = "relative" },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid()
+			tt.modify(c)
+			if err := c.Valid(); (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCheck_ThresholdLevel(t *testing.T) {
+	c := &platform.Check{
+		Thresholds: []platform.Threshold{
+			{Level: platform.CheckLevelInfo, Value: 50},
+			{Level: platform.CheckLevelCrit, Value: 90},
+			{Level: platform.CheckLevelWarn, Value: 70},
+			{Level: platform.CheckLevelWarn, Value: 10, Below: true},
+		},
+	}
+	for v, want := range map[float64]platform.CheckLevel{
+		5:  platform.CheckLevelWarn,
+		30: platform.CheckLevelOK,
+		60: platform.CheckLevelInfo,
+		80: platform.CheckLevelWarn,
+		95: platform.CheckLevelCrit,
+	} {
+		if got := c.ThresholdLevel(v); got != want {
+			t.Errorf("expected level %s for value %v, got %s", want, v, got)
+		}
+	}
+}
+
+func TestCheck_GenerateFlux(t *testing.T) {
+	tests := []struct {
+		name  string
+		check platform.Check
+		want  string
+	}{
+		{
+			name: "threshold",
+			check: platform.Check{
+				Name:  "high cpu",
+				Type:  platform.ThresholdCheckType,
+				Every: "90s",
+				Query: platform.CheckQuery{
+					Bucket:      "telegraf",
+					Measurement: "cpu",
+					Field:       "usage_user",
+					Tags:        map[string]string{"region": "west", "cpu": "cpu-total"},
+					Aggregate:   "mean",
+				},
+				Thresholds: []platform.Threshold{
+					{Level: platform.CheckLevelCrit, Value: 90},
+				},
+			},
+			want: `option task = {
+	name: "high cpu",
+	every: 90s,
+}
+
+from(bucket: "telegraf")
+	|> range(start: -90s)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_user" and r["cpu"] == "cpu-total" and r["region"] == "west")
+	|> mean()
+`,
+		},
+		{
+			name: "deadman",
+			check: platform.Check{
+				Name:  `no "data"`,
+				Type:  platform.DeadmanCheckType,
+				Every: "1m",
+				Query: platform.CheckQuery{
+					Bucket:      "telegraf",
+					Measurement: "mem",
+					Field:       "used",
+				},
+				TimeSince: "2h",
+			},
+			want: `option task = {
+	name: "no \"data\"",
+	every: 1m,
+}
+
+from(bucket: "telegraf")
+	|> range(start: -2h)
+	|> filter(fn: (r) => r._measurement == "mem" and r._field == "used")
+	|> sort(cols: ["_time"])
+	|> last()
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.check.GenerateFlux()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("unexpected script:\n%s\nwant:\n%s", got, tt.want)
+			}
+			if _, err := flux.Compile(context.Background(), got, time.Now()); err != nil {
+				t.Fatalf("generated script does not compile: %v", err)
+			}
+		})
+	}
+}
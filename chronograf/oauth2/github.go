@@ -23,6 +23,7 @@ var _ Provider = &Github{}
 type Github struct {
 	ClientID     string
 	ClientSecret string
+	RedirectURL  string
 	Orgs         []string // Optional github organization checking
 	Logger       chronograf.Logger
 }
@@ -59,6 +60,7 @@ func (g *Github) Config() *oauth2.Config {
 		ClientSecret: g.Secret(),
 		Scopes:       g.Scopes(),
 		Endpoint:     ogh.Endpoint,
+		RedirectURL:  g.RedirectURL,
 	}
 }
 
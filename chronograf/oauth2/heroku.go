@@ -25,6 +25,7 @@ type Heroku struct {
 	// OAuth2 Secrets
 	ClientID     string
 	ClientSecret string
+	RedirectURL  string
 
 	Organizations []string // set of organizations permitted to access the protected resource. Empty means "all"
 
@@ -38,6 +39,7 @@ func (h *Heroku) Config() *oauth2.Config {
 		ClientSecret: h.Secret(),
 		Scopes:       h.Scopes(),
 		Endpoint:     hrk.Endpoint,
+		RedirectURL:  h.RedirectURL,
 	}
 }
 
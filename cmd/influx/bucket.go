@@ -29,6 +29,7 @@ type BucketCreateFlags struct {
 	org       string
 	orgID     string
 	retention time.Duration
+	maxSeries int
 }
 
 var bucketCreateFlags BucketCreateFlags
@@ -42,6 +43,7 @@ func init() {
 
 	bucketCreateCmd.Flags().StringVarP(&bucketCreateFlags.name, "name", "n", "", "name of bucket that will be created")
 	bucketCreateCmd.Flags().DurationVarP(&bucketCreateFlags.retention, "retention", "r", 0, "duration in nanoseconds data will live in bucket")
+	bucketCreateCmd.Flags().IntVarP(&bucketCreateFlags.maxSeries, "max-series", "", 0, "number of series the bucket may hold; 0 is unlimited")
 	bucketCreateCmd.Flags().StringVarP(&bucketCreateFlags.org, "org", "o", "", "name of the organization that owns the bucket")
 	bucketCreateCmd.Flags().StringVarP(&bucketCreateFlags.orgID, "org-id", "", "", "id of the organization that owns the bucket")
 	bucketCreateCmd.MarkFlagRequired("name")
@@ -64,6 +66,7 @@ func bucketCreateF(cmd *cobra.Command, args []string) {
 	b := &platform.Bucket{
 		Name:            bucketCreateFlags.name,
 		RetentionPeriod: bucketCreateFlags.retention,
+		MaxSeries:       bucketCreateFlags.maxSeries,
 	}
 
 	if bucketCreateFlags.org != "" {
@@ -89,6 +92,7 @@ func bucketCreateF(cmd *cobra.Command, args []string) {
 		"ID",
 		"Name",
 		"Retention",
+		"MaxSeries",
 		"Organization",
 		"OrganizationID",
 	)
@@ -96,6 +100,7 @@ func bucketCreateF(cmd *cobra.Command, args []string) {
 		"ID":             b.ID.String(),
 		"Name":           b.Name,
 		"Retention":      b.RetentionPeriod,
+		"MaxSeries":      b.MaxSeries,
 		"Organization":   b.Organization,
 		"OrganizationID": b.OrganizationID.String(),
 	})
@@ -177,6 +182,7 @@ func bucketFindF(cmd *cobra.Command, args []string) {
 		"ID",
 		"Name",
 		"Retention",
+		"MaxSeries",
 		"Organization",
 		"OrganizationID",
 	)
@@ -185,6 +191,7 @@ func bucketFindF(cmd *cobra.Command, args []string) {
 			"ID":             b.ID.String(),
 			"Name":           b.Name,
 			"Retention":      b.RetentionPeriod,
+			"MaxSeries":      b.MaxSeries,
 			"Organization":   b.Organization,
 			"OrganizationID": b.OrganizationID.String(),
 		})
@@ -197,6 +204,7 @@ type BucketUpdateFlags struct {
 	id        string
 	name      string
 	retention time.Duration
+	maxSeries int
 }
 
 var bucketUpdateFlags BucketUpdateFlags
@@ -211,6 +219,7 @@ func init() {
 	bucketUpdateCmd.Flags().StringVarP(&bucketUpdateFlags.id, "id", "i", "", "bucket ID (required)")
 	bucketUpdateCmd.Flags().StringVarP(&bucketUpdateFlags.name, "name", "n", "", "new bucket name")
 	bucketUpdateCmd.Flags().DurationVarP(&bucketUpdateFlags.retention, "retention", "r", 0, "new duration data will live in bucket")
+	bucketUpdateCmd.Flags().IntVarP(&bucketUpdateFlags.maxSeries, "max-series", "", 0, "new number of series the bucket may hold; 0 is unlimited")
 	bucketUpdateCmd.MarkFlagRequired("id")
 
 	bucketCmd.AddCommand(bucketUpdateCmd)
@@ -235,6 +244,9 @@ func bucketUpdateF(cmd *cobra.Command, args []string) {
 	if bucketUpdateFlags.retention != 0 {
 		update.RetentionPeriod = &bucketUpdateFlags.retention
 	}
+	if cmd.Flags().Changed("max-series") {
+		update.MaxSeries = &bucketUpdateFlags.maxSeries
+	}
 
 	b, err := s.UpdateBucket(context.Background(), id, update)
 	if err != nil {
@@ -247,6 +259,7 @@ func bucketUpdateF(cmd *cobra.Command, args []string) {
 		"ID",
 		"Name",
 		"Retention",
+		"MaxSeries",
 		"Organization",
 		"OrganizationID",
 	)
@@ -254,6 +267,7 @@ func bucketUpdateF(cmd *cobra.Command, args []string) {
 		"ID":             b.ID.String(),
 		"Name":           b.Name,
 		"Retention":      b.RetentionPeriod,
+		"MaxSeries":      b.MaxSeries,
 		"Organization":   b.Organization,
 		"OrganizationID": b.OrganizationID.String(),
 	})
@@ -297,6 +311,7 @@ func bucketDeleteF(cmd *cobra.Command, args []string) {
 		"ID",
 		"Name",
 		"Retention",
+		"MaxSeries",
 		"Organization",
 		"OrganizationID",
 		"Deleted",
@@ -305,6 +320,7 @@ func bucketDeleteF(cmd *cobra.Command, args []string) {
 		"ID":             b.ID.String(),
 		"Name":           b.Name,
 		"Retention":      b.RetentionPeriod,
+		"MaxSeries":      b.MaxSeries,
 		"Organization":   b.Organization,
 		"OrganizationID": b.OrganizationID.String(),
 		"Deleted":        true,
@@ -324,3 +340,79 @@ func init() {
 
 	bucketCmd.AddCommand(bucketDeleteCmd)
 }
+
+// BucketStatsFlags define the Stats Command
+type BucketStatsFlags struct {
+	id string
+}
+
+var bucketStatsFlags BucketStatsFlags
+
+func bucketStatsF(cmd *cobra.Command, args []string) {
+	s := &http.BucketService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var id platform.ID
+	if err := id.DecodeFromString(bucketStatsFlags.id); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	stats, err := s.FindBucketStats(context.Background(), id)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"Measurement",
+		"Series",
+		"DiskBytes",
+		"CacheBytes",
+		"Earliest",
+		"Latest",
+	)
+	// The first row holds the totals of the bucket.
+	w.Write(map[string]interface{}{
+		"Measurement": "*",
+		"Series":      stats.SeriesN,
+		"DiskBytes":   stats.DiskBytes,
+		"CacheBytes":  stats.CacheBytes,
+		"Earliest":    formatStatsTime(stats.Earliest),
+		"Latest":      formatStatsTime(stats.Latest),
+	})
+	for _, m := range stats.Measurements {
+		w.Write(map[string]interface{}{
+			"Measurement": m.Name,
+			"Series":      m.SeriesN,
+			"DiskBytes":   m.DiskBytes,
+			"CacheBytes":  m.CacheBytes,
+			"Earliest":    formatStatsTime(m.Earliest),
+			"Latest":      formatStatsTime(m.Latest),
+		})
+	}
+	w.Flush()
+}
+
+func formatStatsTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func init() {
+	bucketStatsCmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show the series, size and time range of the data in a bucket",
+		Run:   bucketStatsF,
+	}
+
+	bucketStatsCmd.Flags().StringVarP(&bucketStatsFlags.id, "id", "i", "", "bucket id (required)")
+	bucketStatsCmd.MarkFlagRequired("id")
+
+	bucketCmd.AddCommand(bucketStatsCmd)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/cmd/influx/internal"
+	"github.com/influxdata/platform/http"
+	"github.com/spf13/cobra"
+)
+
+// Dashboard Command
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "dashboard related commands",
+	Run:   dashboardF,
+}
+
+func dashboardF(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+}
+
+// DashboardExportFlags define the Export Command
+type DashboardExportFlags struct {
+	id   string
+	file string
+}
+
+var dashboardExportFlags DashboardExportFlags
+
+func init() {
+	dashboardExportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a dashboard with its views and macros as JSON",
+		Run:   dashboardExportF,
+	}
+
+	dashboardExportCmd.Flags().StringVarP(&dashboardExportFlags.id, "id", "i", "", "dashboard id (required)")
+	dashboardExportCmd.Flags().StringVarP(&dashboardExportFlags.file, "file", "f", "", "file to write the export to; defaults to stdout")
+	dashboardExportCmd.MarkFlagRequired("id")
+
+	dashboardCmd.AddCommand(dashboardExportCmd)
+}
+
+func dashboardExportF(cmd *cobra.Command, args []string) {
+	s := &http.DashboardService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var id platform.ID
+	if err := id.DecodeFromString(dashboardExportFlags.id); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	e, err := s.ExportDashboard(context.Background(), id)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var out io.Writer = os.Stdout
+	if dashboardExportFlags.file != "" {
+		f, err := os.Create(dashboardExportFlags.file)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(e); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// DashboardImportFlags define the Import Command
+type DashboardImportFlags struct {
+	file  string
+	orgID string
+}
+
+var dashboardImportFlags DashboardImportFlags
+
+func init() {
+	dashboardImportCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import a dashboard exported as JSON",
+		Run:   dashboardImportF,
+	}
+
+	dashboardImportCmd.Flags().StringVarP(&dashboardImportFlags.file, "file", "f", "", "file to read the export from (required)")
+	dashboardImportCmd.Flags().StringVarP(&dashboardImportFlags.orgID, "org-id", "", "", "id of the organization to import the dashboard into (required)")
+	dashboardImportCmd.MarkFlagRequired("file")
+	dashboardImportCmd.MarkFlagRequired("org-id")
+
+	dashboardCmd.AddCommand(dashboardImportCmd)
+}
+
+func dashboardImportF(cmd *cobra.Command, args []string) {
+	s := &http.DashboardService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var orgID platform.ID
+	if err := orgID.DecodeFromString(dashboardImportFlags.orgID); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	f, err := os.Open(dashboardImportFlags.file)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	var e platform.DashboardExport
+	if err := json.NewDecoder(f).Decode(&e); err != nil {
+		fmt.Printf("error reading dashboard export: %v\n", err)
+		os.Exit(1)
+	}
+
+	d, err := s.ImportDashboard(context.Background(), orgID, &e)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"Name",
+		"Cells",
+	)
+	w.Write(map[string]interface{}{
+		"ID":    d.ID.String(),
+		"Name":  d.Name,
+		"Cells": len(d.Cells),
+	})
+	w.Flush()
+}
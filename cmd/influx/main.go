@@ -21,6 +21,7 @@ var influxCmd = &cobra.Command{
 func init() {
 	influxCmd.AddCommand(authorizationCmd)
 	influxCmd.AddCommand(bucketCmd)
+	influxCmd.AddCommand(dashboardCmd)
 	influxCmd.AddCommand(replCmd)
 	influxCmd.AddCommand(queryCmd)
 	influxCmd.AddCommand(organizationCmd)
@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/influxdata/flux/repl"
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/cmd/influx/internal"
+	"github.com/influxdata/platform/http"
+	"github.com/influxdata/platform/query"
 	_ "github.com/influxdata/platform/query/builtin"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -57,3 +62,82 @@ func fluxQueryF(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 }
+
+func init() {
+	queryListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the queries in flight of an organization",
+		Args:  cobra.NoArgs,
+		Run:   queryListF,
+	}
+	queryCmd.AddCommand(queryListCmd)
+
+	queryKillCmd := &cobra.Command{
+		Use:   "kill [query ID]",
+		Short: "Cancel a query in flight",
+		Args:  cobra.ExactArgs(1),
+		Run:   queryKillF,
+	}
+	queryCmd.AddCommand(queryKillCmd)
+}
+
+func queryListF(cmd *cobra.Command, args []string) {
+	var orgID platform.ID
+	if err := orgID.DecodeFromString(queryFlags.OrgID); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	s := &http.ActiveQueryService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	qs, err := s.FindActiveQueries(context.Background(), query.ActiveQueryFilter{
+		OrganizationID: &orgID,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"UserID",
+		"Type",
+		"State",
+		"Duration",
+		"Memory",
+	)
+	for _, q := range qs {
+		w.Write(map[string]interface{}{
+			"ID":       q.ID.String(),
+			"UserID":   q.UserID.String(),
+			"Type":     q.CompilerType,
+			"State":    q.State,
+			"Duration": time.Since(q.StartTime).Round(time.Millisecond),
+			"Memory":   q.MemoryBytes,
+		})
+	}
+	w.Flush()
+}
+
+func queryKillF(cmd *cobra.Command, args []string) {
+	var id platform.ID
+	if err := id.DecodeFromString(args[0]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	s := &http.ActiveQueryService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	if err := s.CancelQuery(context.Background(), id); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Query %s cancelled\n", id)
+}
@@ -9,23 +9,34 @@ import (
 	"os/signal"
 	"os/user"
 	"path/filepath"
-	"runtime"
 	"syscall"
 	"time"
 
 	"github.com/influxdata/flux/control"
-	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/alerting"
 	"github.com/influxdata/platform/bolt"
+	"github.com/influxdata/platform/chronograf"
+	"github.com/influxdata/platform/chronograf/canned"
+	"github.com/influxdata/platform/chronograf/filestore"
+	"github.com/influxdata/platform/chronograf/id"
+	clog "github.com/influxdata/platform/chronograf/log"
+	"github.com/influxdata/platform/chronograf/multistore"
+	"github.com/influxdata/platform/chronograf/oauth2"
 	"github.com/influxdata/platform/chronograf/server"
 	"github.com/influxdata/platform/gather"
 	"github.com/influxdata/platform/http"
+	"github.com/influxdata/platform/kit/check"
+	"github.com/influxdata/platform/kit/lifecycle"
 	"github.com/influxdata/platform/kit/prom"
+	"github.com/influxdata/platform/layouts"
 	influxlogger "github.com/influxdata/platform/logger"
 	"github.com/influxdata/platform/nats"
 	"github.com/influxdata/platform/query"
 	_ "github.com/influxdata/platform/query/builtin"
 	pcontrol "github.com/influxdata/platform/query/control"
+	"github.com/influxdata/platform/query/influxql"
+	"github.com/influxdata/platform/query/querylog"
 	"github.com/influxdata/platform/source"
 	"github.com/influxdata/platform/storage"
 	"github.com/influxdata/platform/storage/readservice"
@@ -60,6 +71,21 @@ var (
 	natsPath          string
 	developerMode     bool
 	enginePath        string
+	tlsCertPath       string
+	tlsKeyPath        string
+	cannedPath        string
+
+	shutdownTimeout time.Duration
+
+	slowQueryThreshold time.Duration
+
+	queryConcurrency    int
+	queryQueueSize      int
+	queryQueueTimeout   time.Duration
+	queryMemoryBytes    int64
+	orgQueryMemoryBytes int64
+
+	taskLeaseDuration time.Duration
 )
 
 func influxDir() (string, error) {
@@ -127,6 +153,72 @@ func init() {
 	if h := viper.GetString("ENGINE_PATH"); h != "" {
 		enginePath = h
 	}
+
+	platformCmd.Flags().StringVar(&tlsCertPath, "tls-cert", "", "path to a TLS certificate to serve https; reloaded on SIGHUP")
+	viper.BindEnv("TLS_CERT")
+	if h := viper.GetString("TLS_CERT"); h != "" {
+		tlsCertPath = h
+	}
+
+	platformCmd.Flags().StringVar(&tlsKeyPath, "tls-key", "", "path to the private key of the TLS certificate; reloaded on SIGHUP")
+	viper.BindEnv("TLS_KEY")
+	if h := viper.GetString("TLS_KEY"); h != "" {
+		tlsKeyPath = h
+	}
+
+	platformCmd.Flags().StringVar(&cannedPath, "canned-path", "", "path to a directory of chronograf canned layouts offered as dashboard templates")
+	viper.BindEnv("CANNED_PATH")
+	if h := viper.GetString("CANNED_PATH"); h != "" {
+		cannedPath = h
+	}
+
+	platformCmd.Flags().DurationVar(&shutdownTimeout, "shutdown-timeout", 30*time.Second, "duration to wait for requests to drain and components to close on shutdown")
+	viper.BindEnv("SHUTDOWN_TIMEOUT")
+	if h := viper.GetDuration("SHUTDOWN_TIMEOUT"); h != 0 {
+		shutdownTimeout = h
+	}
+
+	platformCmd.Flags().DurationVar(&slowQueryThreshold, "slow-query-threshold", 10*time.Second, "duration above which queries are logged as slow; 0 disables slow query logging")
+	viper.BindEnv("SLOW_QUERY_THRESHOLD")
+	if h := viper.GetDuration("SLOW_QUERY_THRESHOLD"); h != 0 {
+		slowQueryThreshold = h
+	}
+
+	platformCmd.Flags().IntVar(&queryConcurrency, "query-concurrency", 10, "default number of queries of an organization that may execute at once; 0 is unlimited")
+	viper.BindEnv("QUERY_CONCURRENCY")
+	if h := viper.GetInt("QUERY_CONCURRENCY"); h != 0 {
+		queryConcurrency = h
+	}
+
+	platformCmd.Flags().IntVar(&queryQueueSize, "query-queue-size", 10, "default number of queries of an organization that may wait to execute; 0 is unlimited")
+	viper.BindEnv("QUERY_QUEUE_SIZE")
+	if h := viper.GetInt("QUERY_QUEUE_SIZE"); h != 0 {
+		queryQueueSize = h
+	}
+
+	platformCmd.Flags().DurationVar(&queryQueueTimeout, "query-queue-timeout", 30*time.Second, "default duration a query of an organization may wait to execute; 0 waits forever")
+	viper.BindEnv("QUERY_QUEUE_TIMEOUT")
+	if h := viper.GetDuration("QUERY_QUEUE_TIMEOUT"); h != 0 {
+		queryQueueTimeout = h
+	}
+
+	platformCmd.Flags().Int64Var(&queryMemoryBytes, "query-memory-bytes", 0, "default number of bytes a single query of an organization may allocate; 0 divides the memory of the organization by its query concurrency, or is unlimited")
+	viper.BindEnv("QUERY_MEMORY_BYTES")
+	if h := viper.GetInt64("QUERY_MEMORY_BYTES"); h != 0 {
+		queryMemoryBytes = h
+	}
+
+	platformCmd.Flags().Int64Var(&orgQueryMemoryBytes, "org-query-memory-bytes", 0, "default number of bytes all the executing queries of an organization may allocate; 0 is unlimited")
+	viper.BindEnv("ORG_QUERY_MEMORY_BYTES")
+	if h := viper.GetInt64("ORG_QUERY_MEMORY_BYTES"); h != 0 {
+		orgQueryMemoryBytes = h
+	}
+
+	platformCmd.Flags().DurationVar(&taskLeaseDuration, "task-lease-duration", 30*time.Second, "duration of the leases of the tasks this process schedules; other processes sharing the task store take over tasks whose leases expire")
+	viper.BindEnv("TASK_LEASE_DURATION")
+	if h := viper.GetDuration("TASK_LEASE_DURATION"); h != 0 {
+		taskLeaseDuration = h
+	}
 }
 
 var platformCmd = &cobra.Command{
@@ -148,11 +240,36 @@ func platformF(cmd *cobra.Command, args []string) {
 	c.Path = boltPath
 	c.WithLogger(logger)
 
+	if (tlsCertPath == "") != (tlsKeyPath == "") {
+		logger.Error("both --tls-cert and --tls-key must be set to serve https")
+		os.Exit(1)
+	}
+
 	if err := c.Open(ctx); err != nil {
 		logger.Error("failed opening bolt", zap.Error(err))
 		os.Exit(1)
 	}
-	defer c.Close()
+
+	// Components are stopped in the reverse order they are added on shutdown,
+	// so that a component stops before the components it depends on.
+	lm := lifecycle.NewManager(logger)
+	lm.Add("bolt", func(context.Context) error { return c.Close() })
+
+	// Components running in the background report their failure with fail,
+	// which shuts the process down. Only the first failure is reported, so
+	// that the components failing after it do not block.
+	errc := make(chan error, 1)
+	fail := func(err error) {
+		select {
+		case errc <- err:
+		default:
+			logger.Error("component failed while shutting down", zap.Error(err))
+		}
+	}
+
+	// Components register their checks to report the health and readiness of the process.
+	chk := check.NewCheck()
+	chk.AddHealthCheck("bolt", c)
 
 	var authSvc platform.AuthorizationService
 	{
@@ -174,6 +291,11 @@ func platformF(cmd *cobra.Command, args []string) {
 		userSvc = c
 	}
 
+	var userIdentitySvc platform.UserIdentityService
+	{
+		userIdentitySvc = c
+	}
+
 	var userResourceSvc platform.UserResourceMappingService
 	{
 		userResourceSvc = c
@@ -189,6 +311,22 @@ func platformF(cmd *cobra.Command, args []string) {
 		viewSvc = c
 	}
 
+	var dashboardTemplateSvc platform.DashboardTemplateService
+	{
+		clogger := clog.New(clog.InfoLevel)
+		// Layouts in the canned path take precedence over those compiled into the binary.
+		stores := []chronograf.LayoutsStore{&canned.BinLayoutsStore{Logger: clogger}}
+		if cannedPath != "" {
+			stores = append([]chronograf.LayoutsStore{filestore.NewApps(cannedPath, &id.UUID{}, clogger)}, stores...)
+		}
+		dashboardTemplateSvc = &layouts.Service{
+			LayoutsStore:     &multistore.Layouts{Stores: stores},
+			DashboardService: dashboardSvc,
+			ViewService:      viewSvc,
+			BucketService:    bucketSvc,
+		}
+	}
+
 	var sourceSvc platform.SourceService
 	{
 		sourceSvc = c
@@ -211,49 +349,122 @@ func platformF(cmd *cobra.Command, args []string) {
 
 	var onboardingSvc platform.OnboardingService = c
 
+	var dbrpMappingSvc platform.DBRPMappingService = c
+
+	var orgSettingsSvc platform.OrganizationSettingsService = c
+
+	orgQueryLimits := platform.OrganizationSettings{
+		QueryConcurrency:      queryConcurrency,
+		QueryQueueSize:        queryQueueSize,
+		QueryQueueTimeout:     queryQueueTimeout,
+		QueryMemoryBytes:      queryMemoryBytes,
+		TotalQueryMemoryBytes: orgQueryMemoryBytes,
+	}
+
 	var storageQueryService query.ProxyQueryService
+	var queryService query.QueryService
+	var queryLogSvc query.LogService
+	var activeQuerySvc query.ActiveQueryService
+	var schemaQuerier influxql.SchemaQuerier
 	var pointsWriter storage.PointsWriter
+	var bucketStatsSvc platform.BucketStatsService
 	{
 		config := storage.NewConfig()
 		config.EngineOptions.WALEnabled = true // Enable a disk-based WAL.
 		config.EngineOptions.Config = config.Config
 
-		engine := storage.NewEngine(enginePath, config, storage.WithRetentionEnforcer(bucketSvc))
+		engine := storage.NewEngine(enginePath, config,
+			storage.WithRetentionEnforcer(bucketSvc),
+			storage.WithSeriesLimits(bucketSvc),
+		)
 		engine.WithLogger(logger)
 		reg.MustRegister(engine.PrometheusCollectors()...)
 
-		if err := engine.Open(); err != nil {
-			logger.Error("failed to open engine", zap.Error(err))
-			os.Exit(1)
-		}
+		chk.AddHealthCheck("storage", engine)
+		chk.AddReadyCheck("storage", check.CheckerFunc(engine.CheckReady))
+
+		// The engine loads its TSM files and replays its WAL in the background
+		// so that the process reports it is not ready until it is done.
+		go func() {
+			if err := engine.Open(); err != nil {
+				fail(fmt.Errorf("failed to open engine: %v", err))
+			}
+		}()
+		lm.Add("storage", func(context.Context) error {
+			// Write the cache to disk so the WAL need not be replayed on start.
+			if err := engine.WriteSnapshot(); err != nil && err != storage.ErrEngineClosed {
+				logger.Error("failed to snapshot engine cache", zap.Error(err))
+			}
+			return engine.Close()
+		})
 
 		pointsWriter = engine
+		bucketStatsSvc = engine
+
+		ctrlConfig := pcontrol.Config{
+			Config: control.Config{
+				ConcurrencyQuota: readservice.DefaultConcurrencyQuota,
+				MemoryBytesQuota: readservice.DefaultMemoryBytesQuota,
+				Logger:           logger.With(zap.String("service", "storage-reads")),
+				Verbose:          false,
+			},
+			OrganizationSettingsService: orgSettingsSvc,
+			DefaultOrganizationSettings: orgQueryLimits,
+		}
 
-		service, err := readservice.NewProxyQueryService(
-			engine, bucketSvc, orgSvc, logger.With(zap.String("service", "storage-reads")))
+		ctrl, err := readservice.NewController(ctrlConfig, engine, bucketSvc, orgSvc)
 		if err != nil {
 			logger.Error("failed to create query service", zap.Error(err))
 			os.Exit(1)
 		}
+		reg.MustRegister(ctrl.PrometheusCollectors()...)
+		lm.Add("storage-queries", ctrl.Shutdown)
+		activeQuerySvc = ctrl
 
-		storageQueryService = service
-	}
-
-	var queryService query.QueryService
-	{
-		// TODO(lh): this is temporary until query endpoint is added here.
-		config := control.Config{
-			ExecutorDependencies: make(execute.Dependencies),
-			ConcurrencyQuota:     runtime.NumCPU() * 2,
-			MemoryBytesQuota:     0,
-			Verbose:              false,
+		service := query.QueryServiceBridge{
+			AsyncQueryService: ctrl,
 		}
+		// The tasks, and so the checks, read from storage.
+		queryService = service
 
-		queryService = query.QueryServiceBridge{
-			AsyncQueryService: pcontrol.New(config),
+		queryLogger := querylog.NewLogger(engine, bucketSvc, logger.With(zap.String("service", "query-log")))
+		queryLogger.SlowQueryThreshold = slowQueryThreshold
+
+		storageQueryService = &query.LoggingServiceBridge{
+			QueryService: service,
+			QueryLogger:  queryLogger,
+		}
+		queryLogSvc = querylog.NewService(service, bucketSvc)
+
+		// InfluxQL queries read the schema of measurements from storage while they are
+		// compiled. The query being compiled already holds its organization's quota, so
+		// the schema queries run on their own controller without organization limits.
+		schemaCtrl, err := readservice.NewController(pcontrol.Config{
+			Config: control.Config{
+				ConcurrencyQuota: readservice.DefaultConcurrencyQuota,
+				MemoryBytesQuota: readservice.DefaultMemoryBytesQuota,
+				Logger:           logger.With(zap.String("service", "storage-schema-reads")),
+				Verbose:          false,
+			},
+		}, engine, bucketSvc, orgSvc)
+		if err != nil {
+			logger.Error("failed to create schema query service", zap.Error(err))
+			os.Exit(1)
+		}
+		lm.Add("storage-schema-queries", schemaCtrl.Shutdown)
+		schemaQuerier = query.QueryServiceBridge{
+			AsyncQueryService: schemaCtrl,
 		}
 	}
 
+	// The monitor evaluates the results of the tasks of checks.
+	var notificationEndpointSvc platform.NotificationEndpointService = c
+	monitor := alerting.NewMonitor(logger.With(zap.String("service", "monitor")), pointsWriter)
+	monitor.CheckService = c
+	monitor.CheckStatusService = c
+	monitor.NotificationEndpointService = notificationEndpointSvc
+	monitor.BucketService = bucketSvc
+
 	var taskSvc platform.TaskService
 	{
 		boltStore, err := taskbolt.New(c.DB(), "tasks")
@@ -261,28 +472,63 @@ func platformF(cmd *cobra.Command, args []string) {
 			logger.Fatal("failed opening task bolt", zap.Error(err))
 		}
 
-		executor := taskexecutor.NewQueryServiceExecutor(logger, queryService, boltStore)
+		executor := taskexecutor.NewQueryServiceExecutor(logger, queryService, boltStore, taskexecutor.WithResultHandler(monitor))
 
 		// TODO(lh): Replace NopLogWriter with real log writer
-		scheduler := taskbackend.NewScheduler(boltStore, executor, taskbackend.NopLogWriter{}, time.Now().UTC().Unix())
-		scheduler.Start(context.Background())
+		leaseOwner := taskLeaseOwner()
+		scheduler := taskbackend.NewScheduler(taskbackend.LeasedDesiredState(boltStore, leaseOwner), executor, taskbackend.NopLogWriter{}, time.Now().UTC().Unix())
+		leaseScheduler := taskbackend.NewLeaseScheduler(scheduler, boltStore, leaseOwner, taskLeaseDuration, taskbackend.WithLeaseLogger(logger))
+		leaseScheduler.Start(context.Background())
+		chk.AddHealthCheck("task-scheduler", scheduler)
+		lm.Add("task-scheduler", func(context.Context) error {
+			leaseScheduler.Stop()
+			return nil
+		})
 
 		// TODO(lh): Replace NopLogReader with real log reader
-		taskSvc = task.PlatformAdapter(coordinator.New(scheduler, boltStore), taskbackend.NopLogReader{})
+		taskSvc = task.PlatformAdapter(coordinator.New(leaseScheduler, boltStore), taskbackend.NopLogReader{})
 		// TODO(lh): Add in `taskSvc = task.NewValidator(taskSvc)` once we have Authentication coming in the context.
 		// see issue #563
 	}
 
+	var checkSvc platform.CheckService = alerting.NewCheckService(c, taskSvc)
+
 	var scraperTargetSvc platform.ScraperTargetStoreService = c
 
+	var oauthMuxesByName map[string]oauth2.Mux
+	{
+		groupOrgs, err := http.ParseGroupOrganizations(oauthGroupOrgPairs)
+		if err != nil {
+			logger.Error("invalid oauth group organizations", zap.Error(err))
+			os.Exit(1)
+		}
+
+		auth := &http.OAuthAuthenticator{
+			Logger:                     logger.With(zap.String("service", "oauth")),
+			UserService:                userSvc,
+			UserIdentityService:        userIdentitySvc,
+			SessionService:             sessionSvc,
+			OrganizationService:        orgSvc,
+			UserResourceMappingService: userResourceSvc,
+			GroupOrganizations:         groupOrgs,
+			SecureCookie:               oauthSecureCookie(),
+		}
+		oauthMuxesByName, err = oauthMuxes(clog.New(clog.InfoLevel), auth)
+		if err != nil {
+			logger.Error("failed to configure oauth providers", zap.Error(err))
+			os.Exit(1)
+		}
+		for name := range oauthMuxesByName {
+			logger.Info("signing in with oauth provider", zap.String("provider", name))
+		}
+	}
+
 	chronografSvc, err := server.NewServiceV2(ctx, c.DB())
 	if err != nil {
 		logger.Error("failed creating chronograf service", zap.Error(err))
 		os.Exit(1)
 	}
 
-	errc := make(chan error)
-
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGTERM, os.Interrupt)
 
@@ -292,12 +538,15 @@ func platformF(cmd *cobra.Command, args []string) {
 		logger.Error("failed to start nats streaming server", zap.Error(err))
 		os.Exit(1)
 	}
+	chk.AddHealthCheck("nats", natsServer)
+	lm.Add("nats", func(context.Context) error { return natsServer.Close() })
 
 	publisher := nats.NewAsyncPublisher("nats-publisher")
 	if err := publisher.Open(); err != nil {
 		logger.Error("failed to connect to streaming server", zap.Error(err))
 		os.Exit(1)
 	}
+	lm.Add("nats-publisher", func(context.Context) error { return publisher.Close() })
 
 	// TODO(jm): this is an example of using a subscriber to consume from the channel. It should be removed.
 	subscriber := nats.NewQueueSubscriber("nats-subscriber")
@@ -305,41 +554,93 @@ func platformF(cmd *cobra.Command, args []string) {
 		logger.Error("failed to connect to streaming server", zap.Error(err))
 		os.Exit(1)
 	}
+	lm.Add("nats-subscriber", func(context.Context) error { return subscriber.Close() })
 
 	scraperScheduler, err := gather.NewScheduler(10, logger, scraperTargetSvc, publisher, subscriber, 0, 0)
 	if err != nil {
 		logger.Error("failed to create scraper subscriber", zap.Error(err))
 		os.Exit(1)
 	}
+	chk.AddHealthCheck("scraper", scraperScheduler)
+	scraperCtx, stopScraper := context.WithCancel(ctx)
+	scraperDone := make(chan struct{})
 	go func() {
-		errc <- scraperScheduler.Run(ctx)
+		defer close(scraperDone)
+		if err := scraperScheduler.Run(scraperCtx); err != nil {
+			fail(err)
+		}
 	}()
+	lm.Add("scraper", func(ctx context.Context) error {
+		stopScraper()
+		select {
+		case <-scraperDone:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
 
 	httpServer := &nethttp.Server{
 		Addr: httpBindAddress,
 	}
 
+	var certLoader *http.CertificateLoader
+	if tlsCertPath != "" {
+		certLoader, err = http.NewCertificateLoader(tlsCertPath, tlsKeyPath)
+		if err != nil {
+			logger.Error("failed to load TLS certificate", zap.Error(err))
+			os.Exit(1)
+		}
+		httpServer.TLSConfig = certLoader.TLSConfig()
+
+		// Reload the certificate on SIGHUP so that it can be rotated without a restart.
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		go func() {
+			for range hup {
+				if err := certLoader.Reload(); err != nil {
+					logger.Error("failed to reload TLS certificate, keeping the previous one", zap.Error(err))
+					continue
+				}
+				logger.Info("reloaded TLS certificate", zap.String("path", tlsCertPath))
+			}
+		}()
+	}
+	// The HTTP server stops first so that requests drain before the components
+	// serving them are stopped.
+	lm.Add("http", httpServer.Shutdown)
+
 	handlerConfig := &http.APIBackend{
-		Logger:                     logger,
-		NewBucketService:           source.NewBucketService,
-		NewQueryService:            source.NewQueryService,
-		PointsWriter:               pointsWriter,
-		AuthorizationService:       authSvc,
-		BucketService:              bucketSvc,
-		SessionService:             sessionSvc,
-		UserService:                userSvc,
-		OrganizationService:        orgSvc,
-		UserResourceMappingService: userResourceSvc,
-		DashboardService:           dashboardSvc,
-		ViewService:                viewSvc,
-		SourceService:              sourceSvc,
-		MacroService:               macroSvc,
-		BasicAuthService:           basicAuthSvc,
-		OnboardingService:          onboardingSvc,
-		ProxyQueryService:          storageQueryService,
-		TaskService:                taskSvc,
-		ScraperTargetStoreService:  scraperTargetSvc,
-		ChronografService:          chronografSvc,
+		Logger:                      logger,
+		NewBucketService:            source.NewBucketService,
+		NewQueryService:             source.NewQueryService,
+		PointsWriter:                pointsWriter,
+		AuthorizationService:        authSvc,
+		BucketService:               bucketSvc,
+		BucketStatsService:          bucketStatsSvc,
+		SessionService:              sessionSvc,
+		UserService:                 userSvc,
+		OrganizationService:         orgSvc,
+		OrganizationSettingsService: orgSettingsSvc,
+		UserResourceMappingService:  userResourceSvc,
+		DashboardService:            dashboardSvc,
+		DashboardTemplateService:    dashboardTemplateSvc,
+		ViewService:                 viewSvc,
+		SourceService:               sourceSvc,
+		MacroService:                macroSvc,
+		DBRPMappingService:          dbrpMappingSvc,
+		SchemaQuerier:               schemaQuerier,
+		CheckService:                checkSvc,
+		NotificationEndpointService: notificationEndpointSvc,
+		BasicAuthService:            basicAuthSvc,
+		OnboardingService:           onboardingSvc,
+		ProxyQueryService:           storageQueryService,
+		QueryLogService:             queryLogSvc,
+		ActiveQueryService:          activeQuerySvc,
+		TaskService:                 taskSvc,
+		ScraperTargetStoreService:   scraperTargetSvc,
+		ChronografService:           chronografSvc,
+		OAuthMuxes:                  oauthMuxesByName,
 	}
 
 	// HTTP server
@@ -349,21 +650,41 @@ func platformF(cmd *cobra.Command, args []string) {
 
 		h := http.NewHandlerFromRegistry("platform", reg)
 		h.Handler = platformHandler
+		h.HealthHandler = chk.HealthHandler()
+		h.ReadyHandler = chk.ReadyHandler()
 
 		httpServer.Handler = h
-		logger.Info("listening", zap.String("transport", "http"), zap.String("addr", httpBindAddress))
-		errc <- httpServer.ListenAndServe()
+
+		var err error
+		if certLoader != nil {
+			logger.Info("listening", zap.String("transport", "https"), zap.String("addr", httpBindAddress))
+			err = httpServer.ListenAndServeTLS("", "")
+		} else {
+			logger.Info("listening", zap.String("transport", "http"), zap.String("addr", httpBindAddress))
+			err = httpServer.ListenAndServe()
+		}
+		if err != nethttp.ErrServerClosed {
+			fail(err)
+		}
 	}()
 
+	exitCode := 0
 	select {
-	case <-sigs:
+	case sig := <-sigs:
+		logger.Info("shutting down", zap.Stringer("signal", sig), zap.Duration("timeout", shutdownTimeout))
 	case err := <-errc:
-		logger.Fatal("unable to start platform", zap.Error(err))
+		logger.Error("unable to run platform, shutting down", zap.Error(err))
+		exitCode = 1
 	}
 
-	cctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	cctx, cancel := context.WithTimeout(ctx, shutdownTimeout)
 	defer cancel()
-	httpServer.Shutdown(cctx)
+	if err := lm.Shutdown(cctx); err != nil {
+		exitCode = 1
+	}
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
 }
 
 // Execute executes the idped command
@@ -373,3 +694,13 @@ func Execute() {
 		os.Exit(1)
 	}
 }
+
+// taskLeaseOwner returns the owner of the task leases of this process,
+// unique among the processes sharing the task store.
+func taskLeaseOwner() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "influxd"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
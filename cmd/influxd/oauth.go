@@ -0,0 +1,326 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/influxdata/platform/chronograf"
+	"github.com/influxdata/platform/chronograf/oauth2"
+	"github.com/spf13/viper"
+)
+
+var (
+	tokenSecret        string
+	jwksURL            string
+	publicURL          string
+	useIDToken         bool
+	oauthGroupOrgPairs []string
+
+	githubClientID     string
+	githubClientSecret string
+	githubOrgs         []string
+
+	googleClientID     string
+	googleClientSecret string
+	googleDomains      []string
+
+	herokuClientID      string
+	herokuClientSecret  string
+	herokuOrganizations []string
+
+	auth0Domain        string
+	auth0ClientID      string
+	auth0ClientSecret  string
+	auth0Organizations []string
+
+	genericName         string
+	genericClientID     string
+	genericClientSecret string
+	genericScopes       []string
+	genericDomains      []string
+	genericAuthURL      string
+	genericTokenURL     string
+	genericAPIURL       string
+	genericAPIKey       string
+)
+
+func init() {
+	platformCmd.Flags().StringVar(&tokenSecret, "token-secret", "", "secret to sign the oauth2 state; required to sign in with oauth2")
+	viper.BindEnv("TOKEN_SECRET")
+	if h := viper.GetString("TOKEN_SECRET"); h != "" {
+		tokenSecret = h
+	}
+
+	platformCmd.Flags().StringVar(&jwksURL, "jwks-url", "", "url of the JSON web key set to validate RS256 signed oauth2 id tokens")
+	viper.BindEnv("JWKS_URL")
+	if h := viper.GetString("JWKS_URL"); h != "" {
+		jwksURL = h
+	}
+
+	platformCmd.Flags().StringVar(&publicURL, "public-url", "", "url at which influxd is reached by browsers, used to build oauth2 callback urls")
+	viper.BindEnv("PUBLIC_URL")
+	if h := viper.GetString("PUBLIC_URL"); h != "" {
+		publicURL = h
+	}
+
+	platformCmd.Flags().BoolVar(&useIDToken, "use-id-token", false, "read the user and its groups from the claims of the oauth2 id token")
+	viper.BindEnv("USE_ID_TOKEN")
+	if h := viper.GetBool("USE_ID_TOKEN"); h {
+		useIDToken = h
+	}
+
+	platformCmd.Flags().StringSliceVar(&oauthGroupOrgPairs, "oauth-group-org", nil, "group=organization pairs making the users of an oauth2 group members of an organization")
+	viper.BindEnv("OAUTH_GROUP_ORG")
+	if h := viper.GetStringSlice("OAUTH_GROUP_ORG"); len(h) != 0 {
+		oauthGroupOrgPairs = h
+	}
+
+	platformCmd.Flags().StringVar(&githubClientID, "github-client-id", "", "github client id for oauth2")
+	viper.BindEnv("GH_CLIENT_ID")
+	if h := viper.GetString("GH_CLIENT_ID"); h != "" {
+		githubClientID = h
+	}
+
+	platformCmd.Flags().StringVar(&githubClientSecret, "github-client-secret", "", "github client secret for oauth2")
+	viper.BindEnv("GH_CLIENT_SECRET")
+	if h := viper.GetString("GH_CLIENT_SECRET"); h != "" {
+		githubClientSecret = h
+	}
+
+	platformCmd.Flags().StringSliceVar(&githubOrgs, "github-organization", nil, "github organizations users must belong to")
+	viper.BindEnv("GH_ORGS")
+	if h := viper.GetStringSlice("GH_ORGS"); len(h) != 0 {
+		githubOrgs = h
+	}
+
+	platformCmd.Flags().StringVar(&googleClientID, "google-client-id", "", "google client id for oauth2")
+	viper.BindEnv("GOOGLE_CLIENT_ID")
+	if h := viper.GetString("GOOGLE_CLIENT_ID"); h != "" {
+		googleClientID = h
+	}
+
+	platformCmd.Flags().StringVar(&googleClientSecret, "google-client-secret", "", "google client secret for oauth2")
+	viper.BindEnv("GOOGLE_CLIENT_SECRET")
+	if h := viper.GetString("GOOGLE_CLIENT_SECRET"); h != "" {
+		googleClientSecret = h
+	}
+
+	platformCmd.Flags().StringSliceVar(&googleDomains, "google-domains", nil, "google email domains users must belong to")
+	viper.BindEnv("GOOGLE_DOMAINS")
+	if h := viper.GetStringSlice("GOOGLE_DOMAINS"); len(h) != 0 {
+		googleDomains = h
+	}
+
+	platformCmd.Flags().StringVar(&herokuClientID, "heroku-client-id", "", "heroku client id for oauth2")
+	viper.BindEnv("HEROKU_CLIENT_ID")
+	if h := viper.GetString("HEROKU_CLIENT_ID"); h != "" {
+		herokuClientID = h
+	}
+
+	platformCmd.Flags().StringVar(&herokuClientSecret, "heroku-secret", "", "heroku client secret for oauth2")
+	viper.BindEnv("HEROKU_SECRET")
+	if h := viper.GetString("HEROKU_SECRET"); h != "" {
+		herokuClientSecret = h
+	}
+
+	platformCmd.Flags().StringSliceVar(&herokuOrganizations, "heroku-organization", nil, "heroku organizations users must belong to")
+	viper.BindEnv("HEROKU_ORGS")
+	if h := viper.GetStringSlice("HEROKU_ORGS"); len(h) != 0 {
+		herokuOrganizations = h
+	}
+
+	platformCmd.Flags().StringVar(&auth0Domain, "auth0-domain", "", "subdomain of auth0.com used for auth0 oauth2")
+	viper.BindEnv("AUTH0_DOMAIN")
+	if h := viper.GetString("AUTH0_DOMAIN"); h != "" {
+		auth0Domain = h
+	}
+
+	platformCmd.Flags().StringVar(&auth0ClientID, "auth0-client-id", "", "auth0 client id for oauth2")
+	viper.BindEnv("AUTH0_CLIENT_ID")
+	if h := viper.GetString("AUTH0_CLIENT_ID"); h != "" {
+		auth0ClientID = h
+	}
+
+	platformCmd.Flags().StringVar(&auth0ClientSecret, "auth0-client-secret", "", "auth0 client secret for oauth2")
+	viper.BindEnv("AUTH0_CLIENT_SECRET")
+	if h := viper.GetString("AUTH0_CLIENT_SECRET"); h != "" {
+		auth0ClientSecret = h
+	}
+
+	platformCmd.Flags().StringSliceVar(&auth0Organizations, "auth0-organizations", nil, "auth0 organizations users must belong to")
+	viper.BindEnv("AUTH0_ORGS")
+	if h := viper.GetStringSlice("AUTH0_ORGS"); len(h) != 0 {
+		auth0Organizations = h
+	}
+
+	platformCmd.Flags().StringVar(&genericName, "generic-name", "", "name of the generic oauth2 provider")
+	viper.BindEnv("GENERIC_NAME")
+	if h := viper.GetString("GENERIC_NAME"); h != "" {
+		genericName = h
+	}
+
+	platformCmd.Flags().StringVar(&genericClientID, "generic-client-id", "", "generic oauth2 client id")
+	viper.BindEnv("GENERIC_CLIENT_ID")
+	if h := viper.GetString("GENERIC_CLIENT_ID"); h != "" {
+		genericClientID = h
+	}
+
+	platformCmd.Flags().StringVar(&genericClientSecret, "generic-client-secret", "", "generic oauth2 client secret")
+	viper.BindEnv("GENERIC_CLIENT_SECRET")
+	if h := viper.GetString("GENERIC_CLIENT_SECRET"); h != "" {
+		genericClientSecret = h
+	}
+
+	platformCmd.Flags().StringSliceVar(&genericScopes, "generic-scopes", []string{"user:email"}, "scopes requested by the generic oauth2 provider")
+	viper.BindEnv("GENERIC_SCOPES")
+	if h := viper.GetStringSlice("GENERIC_SCOPES"); len(h) != 0 {
+		genericScopes = h
+	}
+
+	platformCmd.Flags().StringSliceVar(&genericDomains, "generic-domains", nil, "email domains users of the generic oauth2 provider must belong to")
+	viper.BindEnv("GENERIC_DOMAINS")
+	if h := viper.GetStringSlice("GENERIC_DOMAINS"); len(h) != 0 {
+		genericDomains = h
+	}
+
+	platformCmd.Flags().StringVar(&genericAuthURL, "generic-auth-url", "", "authorization url of the generic oauth2 provider")
+	viper.BindEnv("GENERIC_AUTH_URL")
+	if h := viper.GetString("GENERIC_AUTH_URL"); h != "" {
+		genericAuthURL = h
+	}
+
+	platformCmd.Flags().StringVar(&genericTokenURL, "generic-token-url", "", "token url of the generic oauth2 provider")
+	viper.BindEnv("GENERIC_TOKEN_URL")
+	if h := viper.GetString("GENERIC_TOKEN_URL"); h != "" {
+		genericTokenURL = h
+	}
+
+	platformCmd.Flags().StringVar(&genericAPIURL, "generic-api-url", "", "url returning the OpenID userinfo of the generic oauth2 provider")
+	viper.BindEnv("GENERIC_API_URL")
+	if h := viper.GetString("GENERIC_API_URL"); h != "" {
+		genericAPIURL = h
+	}
+
+	platformCmd.Flags().StringVar(&genericAPIKey, "generic-api-key", "email", "key of the email address in the userinfo of the generic oauth2 provider")
+	viper.BindEnv("GENERIC_API_KEY")
+	if h := viper.GetString("GENERIC_API_KEY"); h != "" {
+		genericAPIKey = h
+	}
+}
+
+// oauthCallbackURL returns the url the provider called name redirects browsers to once they signed in.
+func oauthCallbackURL(name string) (string, error) {
+	u, err := url.Parse(publicURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid public url: %v", err)
+	}
+	u.Path = path.Join(u.Path, "/api/v2/oauth", name, "callback")
+	return u.String(), nil
+}
+
+// oauthSecureCookie reports whether browsers reach influxd over HTTPS.
+func oauthSecureCookie() bool {
+	u, err := url.Parse(publicURL)
+	return err == nil && u.Scheme == "https"
+}
+
+// oauthProviders returns the oauth2 providers configured by the flags by name.
+func oauthProviders(logger chronograf.Logger) (map[string]oauth2.Provider, error) {
+	providers := make(map[string]oauth2.Provider)
+	// The state of the sign in is signed with the token secret to prevent CSRF.
+	if tokenSecret == "" {
+		return providers, nil
+	}
+
+	if githubClientID != "" && githubClientSecret != "" {
+		redirectURL, err := oauthCallbackURL("github")
+		if err != nil {
+			return nil, err
+		}
+		providers["github"] = &oauth2.Github{
+			ClientID:     githubClientID,
+			ClientSecret: githubClientSecret,
+			RedirectURL:  redirectURL,
+			Orgs:         githubOrgs,
+			Logger:       logger,
+		}
+	}
+
+	if googleClientID != "" && googleClientSecret != "" {
+		redirectURL, err := oauthCallbackURL("google")
+		if err != nil {
+			return nil, err
+		}
+		providers["google"] = &oauth2.Google{
+			ClientID:     googleClientID,
+			ClientSecret: googleClientSecret,
+			Domains:      googleDomains,
+			RedirectURL:  redirectURL,
+			Logger:       logger,
+		}
+	}
+
+	if herokuClientID != "" && herokuClientSecret != "" {
+		redirectURL, err := oauthCallbackURL("heroku")
+		if err != nil {
+			return nil, err
+		}
+		providers["heroku"] = &oauth2.Heroku{
+			ClientID:      herokuClientID,
+			ClientSecret:  herokuClientSecret,
+			RedirectURL:   redirectURL,
+			Organizations: herokuOrganizations,
+			Logger:        logger,
+		}
+	}
+
+	if auth0ClientID != "" && auth0ClientSecret != "" {
+		redirectURL, err := oauthCallbackURL("auth0")
+		if err != nil {
+			return nil, err
+		}
+		auth0, err := oauth2.NewAuth0(auth0Domain, auth0ClientID, auth0ClientSecret, redirectURL, auth0Organizations, logger)
+		if err != nil {
+			return nil, fmt.Errorf("invalid auth0 domain: %v", err)
+		}
+		providers["auth0"] = &auth0
+	}
+
+	if genericClientID != "" && genericClientSecret != "" && genericAuthURL != "" && genericTokenURL != "" {
+		gen := &oauth2.Generic{
+			PageName:       genericName,
+			ClientID:       genericClientID,
+			ClientSecret:   genericClientSecret,
+			RequiredScopes: genericScopes,
+			Domains:        genericDomains,
+			AuthURL:        genericAuthURL,
+			TokenURL:       genericTokenURL,
+			APIURL:         genericAPIURL,
+			APIKey:         genericAPIKey,
+			Logger:         logger,
+		}
+		redirectURL, err := oauthCallbackURL(gen.Name())
+		if err != nil {
+			return nil, err
+		}
+		gen.RedirectURL = redirectURL
+		providers[gen.Name()] = gen
+	}
+
+	return providers, nil
+}
+
+// oauthMuxes returns the muxes signing in with the configured oauth2 providers by name.
+func oauthMuxes(logger chronograf.Logger, auth oauth2.Authenticator) (map[string]oauth2.Mux, error) {
+	providers, err := oauthProviders(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	muxes := make(map[string]oauth2.Mux, len(providers))
+	for name, p := range providers {
+		muxes[name] = oauth2.NewAuthMux(p, auth, oauth2.NewJWT(tokenSecret, jwksURL), "", logger, useIDToken)
+	}
+	return muxes, nil
+}
@@ -45,9 +45,10 @@ type DashboardService interface {
 
 // Dashboard represents all visual and query data for a dashboard
 type Dashboard struct {
-	ID    ID      `json:"id,omitempty"`
-	Name  string  `json:"name"`
-	Cells []*Cell `json:"cells"`
+	ID             ID      `json:"id,omitempty"`
+	OrganizationID ID      `json:"organizationID,omitempty"`
+	Name           string  `json:"name"`
+	Cells          []*Cell `json:"cells"`
 }
 
 // Cell holds positional information about a cell on dashboard and a reference to a cell.
@@ -62,7 +63,12 @@ type Cell struct {
 
 // DashboardFilter is a filter for dashboards.
 type DashboardFilter struct {
-	IDs []*ID
+	IDs            []*ID
+	OrganizationID *ID
+	// NamePrefix matches dashboards whose name starts with the prefix.
+	NamePrefix *string
+	// Owner matches dashboards owned by the user.
+	Owner *ID
 }
 
 // DashboardUpdate is the patch structure for a dashboard.
@@ -0,0 +1,57 @@
+package platform
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DashboardExportVersion is the version of the dashboard export format.
+const DashboardExportVersion = 1
+
+// DashboardExport is a self-contained document of a dashboard, the views of
+// its cells and the macros used by their queries.
+type DashboardExport struct {
+	Version   int        `json:"version"`
+	Dashboard *Dashboard `json:"dashboard"`
+	Views     []*View    `json:"views"`
+	Macros    []*Macro   `json:"macros"`
+}
+
+// Valid returns an error if the export is of another version or a cell
+// references a view missing from the export.
+func (e *DashboardExport) Valid() error {
+	if e.Version != DashboardExportVersion {
+		return fmt.Errorf("unsupported dashboard export version %d, expected %d", e.Version, DashboardExportVersion)
+	}
+	if e.Dashboard == nil {
+		return fmt.Errorf("dashboard export is missing the dashboard")
+	}
+
+	views := make(map[ID]bool, len(e.Views))
+	for _, v := range e.Views {
+		views[v.ID] = true
+	}
+	for _, c := range e.Dashboard.Cells {
+		if !views[c.ViewID] {
+			return fmt.Errorf("cell %s references view %s missing from the export", c.ID, c.ViewID)
+		}
+	}
+	return nil
+}
+
+// UsesMacro reports whether the query references the macro, either as
+// v.name in Flux or as :name: in InfluxQL.
+func (q DashboardQuery) UsesMacro(name string) bool {
+	return queryUsesMacro(q.Text, name)
+}
+
+func queryUsesMacro(text, name string) bool {
+	return MacroReferenceRegexp(name).MatchString(text)
+}
+
+// MacroReferenceRegexp returns the regular expression matching the references to
+// the macro with the name in queries. It is meant to be compiled once per macro
+// when matching many queries.
+func MacroReferenceRegexp(name string) *regexp.Regexp {
+	return regexp.MustCompile(`(^|[^\w.])v\.` + regexp.QuoteMeta(name) + `\b|:` + regexp.QuoteMeta(name) + `:`)
+}
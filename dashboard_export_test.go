@@ -0,0 +1,26 @@
+package platform_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/platform"
+)
+
+func TestDashboardQuery_UsesMacro(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{text: `from(bucket: "b") |> filter(fn: (r) => r.host == v.host)`, want: true},
+		{text: `SELECT mean("usage") FROM "cpu" WHERE "host" = :host:`, want: true},
+		{text: `from(bucket: "b") |> filter(fn: (r) => r.host == v.hostname)`, want: false},
+		{text: `from(bucket: "b") |> filter(fn: (r) => r.host == "host")`, want: false},
+		{text: `from(bucket: "b") |> filter(fn: (r) => r.host == dev.host)`, want: false},
+	}
+	for _, tt := range tests {
+		q := platform.DashboardQuery{Text: tt.text}
+		if got := q.UsesMacro("host"); got != tt.want {
+			t.Errorf("UsesMacro(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
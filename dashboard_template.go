@@ -0,0 +1,52 @@
+package platform
+
+import (
+	"context"
+)
+
+// ErrDashboardTemplateNotFound is the error for a missing dashboard template.
+const ErrDashboardTemplateNotFound = ChronografError("dashboard template not found")
+
+// DashboardTemplateService represents a service for creating dashboards from templates.
+type DashboardTemplateService interface {
+	// FindDashboardTemplates returns the templates that match filter.
+	FindDashboardTemplates(ctx context.Context, filter DashboardTemplateFilter) ([]*DashboardTemplate, error)
+
+	// FindDashboardTemplateByID returns a single template by ID.
+	FindDashboardTemplateByID(ctx context.Context, id string) (*DashboardTemplate, error)
+
+	// CreateDashboardFromTemplate creates a dashboard and its views from a
+	// template, querying the bucket of the request.
+	CreateDashboardFromTemplate(ctx context.Context, id string, req DashboardTemplateRequest) (*Dashboard, error)
+}
+
+// DashboardTemplate describes a dashboard of data collected by a Telegraf plugin.
+type DashboardTemplate struct {
+	ID          string                   `json:"id"`
+	Name        string                   `json:"name"`
+	Application string                   `json:"application"`
+	Measurement string                   `json:"measurement"`
+	Cells       []*DashboardTemplateCell `json:"cells"`
+}
+
+// DashboardTemplateCell is a cell of a dashboard template.
+type DashboardTemplateCell struct {
+	Name string `json:"name"`
+	X    int32  `json:"x"`
+	Y    int32  `json:"y"`
+	W    int32  `json:"w"`
+	H    int32  `json:"h"`
+}
+
+// DashboardTemplateFilter is a filter for dashboard templates.
+type DashboardTemplateFilter struct {
+	// Plugin is the name of the Telegraf input plugin whose data the templates show.
+	Plugin *string
+}
+
+// DashboardTemplateRequest holds the parameters of a dashboard created from a template.
+type DashboardTemplateRequest struct {
+	OrganizationID ID     `json:"orgID"`
+	BucketID       ID     `json:"bucketID"`
+	Name           string `json:"name,omitempty"`
+}
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/check"
 	"github.com/influxdata/platform/nats"
 	"go.uber.org/zap"
 )
@@ -31,7 +34,8 @@ type Scheduler struct {
 
 	Logger *zap.Logger
 
-	gather chan struct{}
+	gather  chan struct{}
+	running int32 // running is 1 while Run has not returned.
 }
 
 // NewScheduler creates a new Scheduler and subscriptions for scraper jobs.
@@ -76,17 +80,34 @@ func NewScheduler(
 // Run will retrieve scraper targets from the target storage,
 // and publish them to nats job queue for gather.
 func (s *Scheduler) Run(ctx context.Context) error {
+	atomic.StoreInt32(&s.running, 1)
+	defer atomic.StoreInt32(&s.running, 0)
+
 	go func(s *Scheduler) {
 		for {
 			select {
+			case <-ctx.Done():
+				return
 			case <-time.After(s.Interval):
-				s.gather <- struct{}{}
+				select {
+				case s.gather <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
 			}
 		}
 	}(s)
 	return s.run(ctx)
 }
 
+// Check reports whether the scheduler is running.
+func (s *Scheduler) Check(ctx context.Context) check.Response {
+	if atomic.LoadInt32(&s.running) == 0 {
+		return check.Error(errors.New("scraper scheduler is not running"))
+	}
+	return check.Pass()
+}
+
 func (s *Scheduler) run(ctx context.Context) error {
 	for {
 		select {
@@ -5,8 +5,10 @@ import (
 	"strings"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/chronograf/oauth2"
 	"github.com/influxdata/platform/chronograf/server"
 	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/influxql"
 	"github.com/influxdata/platform/storage"
 	"go.uber.org/zap"
 )
@@ -18,13 +20,18 @@ type APIHandler struct {
 	OrgHandler           *OrgHandler
 	AuthorizationHandler *AuthorizationHandler
 	DashboardHandler     *DashboardHandler
+	TemplateHandler      *DashboardTemplateHandler
 	AssetHandler         *AssetHandler
 	ChronografHandler    *ChronografHandler
 	ViewHandler          *ViewHandler
 	SourceHandler        *SourceHandler
 	MacroHandler         *MacroHandler
+	CheckHandler         *CheckHandler
+	EndpointHandler      *NotificationEndpointHandler
 	TaskHandler          *TaskHandler
 	QueryHandler         *FluxHandler
+	QueriesHandler       *QueriesHandler
+	PrometheusHandler    *PrometheusHandler
 	WriteHandler         *WriteHandler
 	SetupHandler         *SetupHandler
 	SessionHandler       *SessionHandler
@@ -38,23 +45,33 @@ type APIBackend struct {
 	NewBucketService func(*platform.Source) (platform.BucketService, error)
 	NewQueryService  func(*platform.Source) (query.ProxyQueryService, error)
 
-	PointsWriter               storage.PointsWriter
-	AuthorizationService       platform.AuthorizationService
-	BucketService              platform.BucketService
-	SessionService             platform.SessionService
-	UserService                platform.UserService
-	OrganizationService        platform.OrganizationService
-	UserResourceMappingService platform.UserResourceMappingService
-	DashboardService           platform.DashboardService
-	ViewService                platform.ViewService
-	SourceService              platform.SourceService
-	MacroService               platform.MacroService
-	BasicAuthService           platform.BasicAuthService
-	OnboardingService          platform.OnboardingService
-	ProxyQueryService          query.ProxyQueryService
-	TaskService                platform.TaskService
-	ScraperTargetStoreService  platform.ScraperTargetStoreService
-	ChronografService          *server.Service
+	PointsWriter                storage.PointsWriter
+	AuthorizationService        platform.AuthorizationService
+	BucketService               platform.BucketService
+	BucketStatsService          platform.BucketStatsService
+	SessionService              platform.SessionService
+	UserService                 platform.UserService
+	OrganizationService         platform.OrganizationService
+	OrganizationSettingsService platform.OrganizationSettingsService
+	UserResourceMappingService  platform.UserResourceMappingService
+	DashboardService            platform.DashboardService
+	DashboardTemplateService    platform.DashboardTemplateService
+	ViewService                 platform.ViewService
+	SourceService               platform.SourceService
+	MacroService                platform.MacroService
+	CheckService                platform.CheckService
+	NotificationEndpointService platform.NotificationEndpointService
+	DBRPMappingService          platform.DBRPMappingService
+	SchemaQuerier               influxql.SchemaQuerier
+	BasicAuthService            platform.BasicAuthService
+	OnboardingService           platform.OnboardingService
+	ProxyQueryService           query.ProxyQueryService
+	QueryLogService             query.LogService
+	ActiveQueryService          query.ActiveQueryService
+	TaskService                 platform.TaskService
+	ScraperTargetStoreService   platform.ScraperTargetStoreService
+	ChronografService           *server.Service
+	OAuthMuxes                  map[string]oauth2.Mux
 }
 
 // NewAPIHandler constructs all api handlers beneath it and returns an APIHandler
@@ -63,13 +80,16 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 	h.SessionHandler = NewSessionHandler()
 	h.SessionHandler.BasicAuthService = b.BasicAuthService
 	h.SessionHandler.SessionService = b.SessionService
+	h.SessionHandler.OAuthMuxes = b.OAuthMuxes
 
 	h.BucketHandler = NewBucketHandler()
 	h.BucketHandler.BucketService = b.BucketService
+	h.BucketHandler.BucketStatsService = b.BucketStatsService
 	h.BucketHandler.UserResourceMappingService = b.UserResourceMappingService
 
 	h.OrgHandler = NewOrgHandler()
 	h.OrgHandler.OrganizationService = b.OrganizationService
+	h.OrgHandler.OrganizationSettingsService = b.OrganizationSettingsService
 	h.OrgHandler.BucketService = b.BucketService
 	h.OrgHandler.UserResourceMappingService = b.UserResourceMappingService
 
@@ -78,14 +98,35 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 
 	h.DashboardHandler = NewDashboardHandler()
 	h.DashboardHandler.DashboardService = b.DashboardService
+	h.DashboardHandler.ViewService = b.ViewService
+	h.DashboardHandler.MacroService = b.MacroService
 	h.DashboardHandler.UserResourceMappingService = b.UserResourceMappingService
 
+	h.TemplateHandler = NewDashboardTemplateHandler()
+	h.TemplateHandler.DashboardTemplateService = b.DashboardTemplateService
+	h.TemplateHandler.UserResourceMappingService = b.UserResourceMappingService
+
 	h.ViewHandler = NewViewHandler()
 	h.ViewHandler.ViewService = b.ViewService
 	h.ViewHandler.UserResourceMappingService = b.UserResourceMappingService
 
 	h.MacroHandler = NewMacroHandler()
 	h.MacroHandler.MacroService = b.MacroService
+	h.MacroHandler.UserResourceMappingService = b.UserResourceMappingService
+	h.MacroHandler.AuthorizationService = b.AuthorizationService
+	h.MacroHandler.ProxyQueryService = b.ProxyQueryService
+	h.MacroHandler.DBRPMappingService = b.DBRPMappingService
+	h.MacroHandler.BucketService = b.BucketService
+	h.MacroHandler.SchemaQuerier = b.SchemaQuerier
+
+	h.CheckHandler = NewCheckHandler()
+	h.CheckHandler.CheckService = b.CheckService
+	h.CheckHandler.NotificationEndpointService = b.NotificationEndpointService
+	h.CheckHandler.UserResourceMappingService = b.UserResourceMappingService
+
+	h.EndpointHandler = NewNotificationEndpointHandler()
+	h.EndpointHandler.NotificationEndpointService = b.NotificationEndpointService
+	h.EndpointHandler.UserResourceMappingService = b.UserResourceMappingService
 
 	h.AuthorizationHandler = NewAuthorizationHandler()
 	h.AuthorizationHandler.AuthorizationService = b.AuthorizationService
@@ -114,6 +155,23 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 	h.QueryHandler.OrganizationService = b.OrganizationService
 	h.QueryHandler.Logger = b.Logger.With(zap.String("handler", "query"))
 	h.QueryHandler.ProxyQueryService = b.ProxyQueryService
+	h.QueryHandler.MacroService = b.MacroService
+	h.QueryHandler.DBRPMappingService = b.DBRPMappingService
+	h.QueryHandler.BucketService = b.BucketService
+	h.QueryHandler.SchemaQuerier = b.SchemaQuerier
+
+	h.QueriesHandler = NewQueriesHandler()
+	h.QueriesHandler.OrganizationService = b.OrganizationService
+	h.QueriesHandler.UserResourceMappingService = b.UserResourceMappingService
+	h.QueriesHandler.QueryLogService = b.QueryLogService
+	h.QueriesHandler.ActiveQueryService = b.ActiveQueryService
+	h.QueriesHandler.Logger = b.Logger.With(zap.String("handler", "queries"))
+
+	h.PrometheusHandler = NewPrometheusHandler()
+	h.PrometheusHandler.AuthorizationService = b.AuthorizationService
+	h.PrometheusHandler.BucketService = b.BucketService
+	h.PrometheusHandler.ProxyQueryService = b.ProxyQueryService
+	h.PrometheusHandler.Logger = b.Logger.With(zap.String("handler", "prometheus"))
 
 	h.ChronografHandler = NewChronografHandler(b.ChronografService)
 
@@ -123,24 +181,34 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 var apiLinks = map[string]interface{}{
 	"signin":     "/api/v2/signin",
 	"signout":    "/api/v2/signout",
+	"oauth":      "/api/v2/oauth",
 	"setup":      "/api/v2/setup",
 	"sources":    "/api/v2/sources",
 	"dashboards": "/api/v2/dashboards",
 	"views":      "/api/v2/views",
-	"write":      "/api/v2/write",
-	"orgs":       "/api/v2/orgs",
-	"auths":      "/api/v2/authorizations",
-	"buckets":    "/api/v2/buckets",
-	"users":      "/api/v2/users",
-	"me":         "/api/v2/me",
-	"tasks":      "/api/v2/tasks",
-	"macros":     "/api/v2/macros",
+	"templates": map[string]string{
+		"dashboards": "/api/v2/templates/dashboards",
+	},
+	"write":                 "/api/v2/write",
+	"orgs":                  "/api/v2/orgs",
+	"auths":                 "/api/v2/authorizations",
+	"buckets":               "/api/v2/buckets",
+	"users":                 "/api/v2/users",
+	"me":                    "/api/v2/me",
+	"tasks":                 "/api/v2/tasks",
+	"macros":                "/api/v2/macros",
+	"checks":                "/api/v2/checks",
+	"notificationEndpoints": "/api/v2/notificationEndpoints",
 	"query": map[string]string{
 		"self":        "/api/v2/query",
 		"ast":         "/api/v2/query/ast",
 		"spec":        "/api/v2/query/spec",
 		"suggestions": "/api/v2/query/suggestions",
 	},
+	"queries": map[string]string{
+		"self": "/api/v2/queries",
+		"log":  "/api/v2/queries/log",
+	},
 	"external": map[string]string{
 		"statusFeed": "https://www.influxdata.com/feed/json",
 	},
@@ -148,6 +216,7 @@ var apiLinks = map[string]interface{}{
 		"metrics": "/metrics",
 		"debug":   "/debug/pprof",
 		"health":  "/health",
+		"ready":   "/ready",
 	},
 }
 
@@ -172,7 +241,7 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if r.URL.Path == "/api/v2/signin" || r.URL.Path == "/api/v2/signout" {
+	if r.URL.Path == "/api/v2/signin" || r.URL.Path == "/api/v2/signout" || strings.HasPrefix(r.URL.Path, "/api/v2/oauth") {
 		h.SessionHandler.ServeHTTP(w, r)
 		return
 	}
@@ -187,11 +256,22 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Must be matched before /api/v2/query as it shares its prefix.
+	if strings.HasPrefix(r.URL.Path, "/api/v2/queries") {
+		h.QueriesHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/query") {
 		h.QueryHandler.ServeHTTP(w, r)
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/prometheus") {
+		h.PrometheusHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/buckets") {
 		h.BucketHandler.ServeHTTP(w, r)
 		return
@@ -222,6 +302,11 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/templates/dashboards") {
+		h.TemplateHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/sources") {
 		h.SourceHandler.ServeHTTP(w, r)
 		return
@@ -242,6 +327,16 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/checks") {
+		h.CheckHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/notificationEndpoints") {
+		h.EndpointHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/chronograf/") {
 		h.ChronografHandler.ServeHTTP(w, r)
 		return
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/kit/errors"
+)
+
+// authorizerUserID returns the ID of the user on whose behalf the request is made.
+func authorizerUserID(ctx context.Context) (platform.ID, error) {
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	switch s := a.(type) {
+	case *platform.Session:
+		return s.UserID, nil
+	case *platform.Authorization:
+		if !s.IsActive() {
+			return 0, errors.Forbiddenf("authorization is inactive")
+		}
+		return s.UserID, nil
+	}
+	return 0, errors.Forbiddenf("unsupported authorizer %q", a.Kind())
+}
+
+// authorizeOrganization returns a forbidden error unless the user making the request
+// is a member or an owner of the organization.
+func authorizeOrganization(ctx context.Context, svc platform.UserResourceMappingService, orgID platform.ID) error {
+	userID, err := authorizerUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	ms, err := findOrganizationMappings(ctx, svc, orgID, userID, "")
+	if err != nil {
+		return err
+	}
+	if len(ms) == 0 {
+		return errors.Forbiddenf("user %s is not a member of organization %s", userID, orgID)
+	}
+	return nil
+}
+
+// authorizeOrganizationOwner returns a forbidden error unless the user making the request
+// is an owner of the organization.
+func authorizeOrganizationOwner(ctx context.Context, svc platform.UserResourceMappingService, orgID platform.ID) error {
+	userID, err := authorizerUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	ms, err := findOrganizationMappings(ctx, svc, orgID, userID, platform.Owner)
+	if err != nil {
+		return err
+	}
+	if len(ms) == 0 {
+		return errors.Forbiddenf("user %s is not an owner of organization %s", userID, orgID)
+	}
+	return nil
+}
+
+func findOrganizationMappings(ctx context.Context, svc platform.UserResourceMappingService, orgID, userID platform.ID, userType platform.UserType) ([]*platform.UserResourceMapping, error) {
+	ms, _, err := svc.FindUserResourceMappings(ctx, platform.UserResourceMappingFilter{
+		ResourceID:   orgID,
+		ResourceType: platform.OrgResourceType,
+		UserID:       userID,
+		UserType:     userType,
+	})
+	return ms, err
+}
+
+// authorizeReadBucket returns a forbidden error unless the request may read the bucket.
+// An authorization must grant the permission to read the bucket, while the user of a
+// session must be a member of the organization of the bucket.
+func authorizeReadBucket(ctx context.Context, svc platform.UserResourceMappingService, b *platform.Bucket) error {
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := a.(*platform.Session); ok {
+		return authorizeOrganization(ctx, svc, b.OrganizationID)
+	}
+	if !a.Allowed(platform.ReadBucketPermission(b.ID)) {
+		return errors.Forbiddenf("insufficient permissions to read bucket %s", b.ID)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
+)
+
+// testUserID is the user on whose behalf authorized test requests are made.
+const testUserID = platform.ID(0x020f755c3c0820ff)
+
+// withTestAuthorizer returns r with an active authorization for testUserID.
+func withTestAuthorizer(r *http.Request) *http.Request {
+	return r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{
+		UserID: testUserID,
+		Status: platform.Active,
+	}))
+}
+
+// authorizedHandler serves requests with an active authorization for testUserID.
+func authorizedHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, withTestAuthorizer(r))
+	})
+}
+
+// memberMappingService makes testUserID a member of every organization.
+func memberMappingService() *mock.UserResourceMappingService {
+	return &mock.UserResourceMappingService{
+		FindMappingsF: func(ctx context.Context, filter platform.UserResourceMappingFilter) ([]*platform.UserResourceMapping, int, error) {
+			if filter.UserID != testUserID || filter.ResourceType != platform.OrgResourceType {
+				return nil, 0, nil
+			}
+			return []*platform.UserResourceMapping{{
+				ResourceID:   filter.ResourceID,
+				ResourceType: platform.OrgResourceType,
+				UserID:       testUserID,
+				UserType:     platform.Member,
+			}}, 1, nil
+		},
+	}
+}
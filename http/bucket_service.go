@@ -19,6 +19,7 @@ type BucketHandler struct {
 	*httprouter.Router
 
 	BucketService              platform.BucketService
+	BucketStatsService         platform.BucketStatsService
 	UserResourceMappingService platform.UserResourceMappingService
 }
 
@@ -29,6 +30,7 @@ const (
 	bucketsIDMembersIDPath = "/api/v2/buckets/:id/members/:userID"
 	bucketsIDOwnersPath    = "/api/v2/buckets/:id/owners"
 	bucketsIDOwnersIDPath  = "/api/v2/buckets/:id/owners/:userID"
+	bucketsIDStatsPath     = "/api/v2/buckets/:id/stats"
 )
 
 // NewBucketHandler returns a new instance of BucketHandler.
@@ -51,6 +53,8 @@ func NewBucketHandler() *BucketHandler {
 	h.HandlerFunc("GET", bucketsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, platform.Owner))
 	h.HandlerFunc("DELETE", bucketsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
 
+	h.HandlerFunc("GET", bucketsIDStatsPath, h.handleGetBucketStats)
+
 	return h
 }
 
@@ -62,6 +66,7 @@ type bucket struct {
 	Name                string      `json:"name"`
 	RetentionPolicyName string      `json:"rp,omitempty"` // This to support v1 sources
 	RetentionPeriod     string      `json:"retentionPeriod"`
+	MaxSeries           int         `json:"maxSeries,omitempty"`
 }
 
 func (b *bucket) toPlatform() (*platform.Bucket, error) {
@@ -74,6 +79,10 @@ func (b *bucket) toPlatform() (*platform.Bucket, error) {
 		return nil, err
 	}
 
+	if b.MaxSeries < 0 {
+		return nil, errors.InvalidDataf("max series must not be negative")
+	}
+
 	return &platform.Bucket{
 		ID:                  b.ID,
 		OrganizationID:      b.OrganizationID,
@@ -81,6 +90,7 @@ func (b *bucket) toPlatform() (*platform.Bucket, error) {
 		Name:                b.Name,
 		RetentionPolicyName: b.RetentionPolicyName,
 		RetentionPeriod:     d,
+		MaxSeries:           b.MaxSeries,
 	}, nil
 }
 
@@ -96,6 +106,7 @@ func newBucket(pb *platform.Bucket) *bucket {
 		Name:                pb.Name,
 		RetentionPolicyName: pb.RetentionPolicyName,
 		RetentionPeriod:     FormatDuration(pb.RetentionPeriod),
+		MaxSeries:           pb.MaxSeries,
 	}
 }
 
@@ -103,6 +114,7 @@ func newBucket(pb *platform.Bucket) *bucket {
 type bucketUpdate struct {
 	Name            *string `json:"name,omitempty"`
 	RetentionPeriod *string `json:"retentionPeriod,omitempty"`
+	MaxSeries       *int    `json:"maxSeries,omitempty"`
 }
 
 func (b *bucketUpdate) toPlatform() (*platform.BucketUpdate, error) {
@@ -110,8 +122,13 @@ func (b *bucketUpdate) toPlatform() (*platform.BucketUpdate, error) {
 		return nil, nil
 	}
 
+	if b.MaxSeries != nil && *b.MaxSeries < 0 {
+		return nil, errors.InvalidDataf("max series must not be negative")
+	}
+
 	up := &platform.BucketUpdate{
-		Name: b.Name,
+		Name:      b.Name,
+		MaxSeries: b.MaxSeries,
 	}
 	if b.RetentionPeriod != nil {
 		d, err := ParseDuration(*b.RetentionPeriod)
@@ -130,7 +147,8 @@ func newBucketUpdate(pb *platform.BucketUpdate) *bucketUpdate {
 	}
 
 	up := &bucketUpdate{
-		Name: pb.Name,
+		Name:      pb.Name,
+		MaxSeries: pb.MaxSeries,
 	}
 	if pb.RetentionPeriod != nil {
 		d := FormatDuration(*pb.RetentionPeriod)
@@ -147,8 +165,9 @@ type bucketResponse struct {
 func newBucketResponse(b *platform.Bucket) *bucketResponse {
 	return &bucketResponse{
 		Links: map[string]string{
-			"self": fmt.Sprintf("/api/v2/buckets/%s", b.ID),
-			"org":  fmt.Sprintf("/api/v2/orgs/%s", b.OrganizationID),
+			"self":  fmt.Sprintf("/api/v2/buckets/%s", b.ID),
+			"org":   fmt.Sprintf("/api/v2/orgs/%s", b.OrganizationID),
+			"stats": fmt.Sprintf("/api/v2/buckets/%s/stats", b.ID),
 		},
 		bucket: *newBucket(b),
 	}
@@ -271,6 +290,55 @@ func decodeGetBucketRequest(ctx context.Context, r *http.Request) (*getBucketReq
 	return req, nil
 }
 
+type bucketStatsResponse struct {
+	Links map[string]string `json:"links"`
+	*platform.BucketStats
+}
+
+// handleGetBucketStats is the HTTP handler for the GET /api/v2/buckets/:id/stats route.
+func (h *BucketHandler) handleGetBucketStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetBucketRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	b, err := h.BucketService.FindBucketByID(ctx, req.BucketID)
+	if err != nil {
+		// TODO(desa): fix this when using real errors library
+		if strings.Contains(err.Error(), "not found") {
+			err = errors.New(err.Error(), errors.NotFound)
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorizeReadBucket(ctx, h.UserResourceMappingService, b); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	stats, err := h.BucketStatsService.FindBucketStats(ctx, b.ID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	res := bucketStatsResponse{
+		Links: map[string]string{
+			"self":   fmt.Sprintf("/api/v2/buckets/%s/stats", b.ID),
+			"bucket": fmt.Sprintf("/api/v2/buckets/%s", b.ID),
+		},
+		BucketStats: stats,
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
 // handleDeleteBucket is the HTTP handler for the DELETE /api/v2/buckets/:id route.
 func (h *BucketHandler) handleDeleteBucket(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -654,6 +722,37 @@ func (s *BucketService) DeleteBucket(ctx context.Context, id platform.ID) error
 	return CheckError(resp)
 }
 
+// FindBucketStats returns the stats of a bucket by ID.
+func (s *BucketService) FindBucketStats(ctx context.Context, id platform.ID) (*platform.BucketStats, error) {
+	u, err := newURL(s.Addr, path.Join(bucketIDPath(id), "stats"))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var stats platform.BucketStats
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
 func bucketIDPath(id platform.ID) string {
 	return path.Join(bucketPath, id.String())
 }
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
 	"github.com/influxdata/platform/inmem"
 	"github.com/influxdata/platform/mock"
 	platformtesting "github.com/influxdata/platform/testing"
@@ -72,7 +73,8 @@ func TestService_handleGetBuckets(t *testing.T) {
     {
       "links": {
         "org": "/api/v2/orgs/50f7ba1150f7ba11",
-        "self": "/api/v2/buckets/0b501e7e557ab1ed"
+        "self": "/api/v2/buckets/0b501e7e557ab1ed",
+        "stats": "/api/v2/buckets/0b501e7e557ab1ed/stats"
       },
       "id": "0b501e7e557ab1ed",
       "organizationID": "50f7ba1150f7ba11",
@@ -82,7 +84,8 @@ func TestService_handleGetBuckets(t *testing.T) {
     {
       "links": {
         "org": "/api/v2/orgs/7e55e118dbabb1ed",
-        "self": "/api/v2/buckets/c0175f0077a77005"
+        "self": "/api/v2/buckets/c0175f0077a77005",
+        "stats": "/api/v2/buckets/c0175f0077a77005/stats"
       },
       "id": "c0175f0077a77005",
       "organizationID": "7e55e118dbabb1ed",
@@ -202,7 +205,8 @@ func TestService_handleGetBucket(t *testing.T) {
 {
   "links": {
     "org": "/api/v2/orgs/020f755c3c082000",
-    "self": "/api/v2/buckets/020f755c3c082000"
+    "self": "/api/v2/buckets/020f755c3c082000",
+    "stats": "/api/v2/buckets/020f755c3c082000/stats"
   },
   "id": "020f755c3c082000",
   "organizationID": "020f755c3c082000",
@@ -269,6 +273,124 @@ func TestService_handleGetBucket(t *testing.T) {
 	}
 }
 
+func TestService_handleGetBucketStats(t *testing.T) {
+	bucketID := platformtesting.MustIDBase16("020f755c3c082000")
+	orgID := platformtesting.MustIDBase16("020f755c3c082010")
+	reader := &platform.Authorization{
+		UserID:      testUserID,
+		Status:      platform.Active,
+		Permissions: []platform.Permission{platform.ReadBucketPermission(bucketID)},
+	}
+	earliest := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	latest := time.Date(2018, 10, 2, 0, 0, 0, 0, time.UTC)
+
+	h := NewBucketHandler()
+	h.BucketService = &mock.BucketService{
+		FindBucketByIDFn: func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+			if id != bucketID {
+				return nil, fmt.Errorf("bucket not found")
+			}
+			return &platform.Bucket{ID: bucketID, OrganizationID: orgID, Name: "hello"}, nil
+		},
+	}
+	h.UserResourceMappingService = memberMappingService()
+	h.BucketStatsService = &mock.BucketStatsService{
+		FindBucketStatsFn: func(ctx context.Context, id platform.ID) (*platform.BucketStats, error) {
+			return &platform.BucketStats{
+				BucketID:   id,
+				SeriesN:    2,
+				DiskBytes:  100,
+				CacheBytes: 10,
+				Earliest:   &earliest,
+				Latest:     &latest,
+				Measurements: []platform.MeasurementStats{
+					{Name: "cpu", SeriesN: 2, DiskBytes: 100, CacheBytes: 10, Earliest: &earliest, Latest: &latest},
+				},
+			}, nil
+		},
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		authorizer platform.Authorizer
+		statusCode int
+		body       string
+	}{
+		{
+			name:       "get the stats of a bucket",
+			id:         "020f755c3c082000",
+			authorizer: reader,
+			statusCode: http.StatusOK,
+			body: `
+{
+  "links": {
+    "self": "/api/v2/buckets/020f755c3c082000/stats",
+    "bucket": "/api/v2/buckets/020f755c3c082000"
+  },
+  "bucketID": "020f755c3c082000",
+  "seriesCount": 2,
+  "diskBytes": 100,
+  "cacheBytes": 10,
+  "earliest": "2018-10-01T00:00:00Z",
+  "latest": "2018-10-02T00:00:00Z",
+  "measurements": [
+    {
+      "name": "cpu",
+      "seriesCount": 2,
+      "diskBytes": 100,
+      "cacheBytes": 10,
+      "earliest": "2018-10-01T00:00:00Z",
+      "latest": "2018-10-02T00:00:00Z"
+    }
+  ]
+}
+`,
+		},
+		{
+			name:       "get the stats of a bucket of the organization of a session",
+			id:         "020f755c3c082000",
+			authorizer: &platform.Session{UserID: testUserID, ExpiresAt: time.Now().Add(time.Hour)},
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "authorization that cannot read the bucket",
+			id:   "020f755c3c082000",
+			authorizer: &platform.Authorization{
+				UserID:      testUserID,
+				Status:      platform.Active,
+				Permissions: []platform.Permission{platform.WriteBucketPermission(bucketID)},
+			},
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "bucket not found",
+			id:         "020f755c3c082001",
+			authorizer: reader,
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://any.url/api/v2/buckets/"+tt.id+"/stats", nil)
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), tt.authorizer))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.statusCode {
+				t.Errorf("handleGetBucketStats() = %v, want %v: %s", res.StatusCode, tt.statusCode, body)
+			}
+			if eq, _ := jsonEqual(string(body), tt.body); tt.body != "" && !eq {
+				t.Errorf("handleGetBucketStats() = \n***%v***\n,\nwant\n***%v***", string(body), tt.body)
+			}
+		})
+	}
+}
+
 func TestService_handlePostBucket(t *testing.T) {
 	type fields struct {
 		BucketService platform.BucketService
@@ -311,7 +433,8 @@ func TestService_handlePostBucket(t *testing.T) {
 {
   "links": {
     "org": "/api/v2/orgs/6f626f7274697320",
-    "self": "/api/v2/buckets/020f755c3c082000"
+    "self": "/api/v2/buckets/020f755c3c082000",
+    "stats": "/api/v2/buckets/020f755c3c082000/stats"
   },
   "id": "020f755c3c082000",
   "organizationID": "6f626f7274697320",
@@ -511,7 +634,8 @@ func TestService_handlePatchBucket(t *testing.T) {
 {
   "links": {
     "org": "/api/v2/orgs/020f755c3c082000",
-    "self": "/api/v2/buckets/020f755c3c082000"
+    "self": "/api/v2/buckets/020f755c3c082000",
+    "stats": "/api/v2/buckets/020f755c3c082000/stats"
   },
   "id": "020f755c3c082000",
   "organizationID": "020f755c3c082000",
@@ -0,0 +1,280 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	checksPath   = "/api/v2/checks"
+	checksIDPath = "/api/v2/checks/:id"
+)
+
+// CheckHandler is the handler for the check service.
+type CheckHandler struct {
+	*httprouter.Router
+
+	CheckService                platform.CheckService
+	NotificationEndpointService platform.NotificationEndpointService
+	UserResourceMappingService  platform.UserResourceMappingService
+}
+
+// NewCheckHandler creates a new CheckHandler.
+func NewCheckHandler() *CheckHandler {
+	h := &CheckHandler{
+		Router: httprouter.New(),
+	}
+
+	h.HandlerFunc("GET", checksPath, h.handleGetChecks)
+	h.HandlerFunc("POST", checksPath, h.handlePostCheck)
+	h.HandlerFunc("GET", checksIDPath, h.handleGetCheck)
+	h.HandlerFunc("PATCH", checksIDPath, h.handlePatchCheck)
+	h.HandlerFunc("DELETE", checksIDPath, h.handleDeleteCheck)
+
+	return h
+}
+
+type checkLinks struct {
+	Self string `json:"self"`
+}
+
+type checkResponse struct {
+	*platform.Check
+	Links checkLinks `json:"links"`
+}
+
+func newCheckResponse(c *platform.Check) checkResponse {
+	return checkResponse{
+		Check: c,
+		Links: checkLinks{
+			Self: fmt.Sprintf("%s/%s", checksPath, c.ID),
+		},
+	}
+}
+
+type getChecksResponse struct {
+	Checks []checkResponse `json:"checks"`
+	Links  checkLinks      `json:"links"`
+}
+
+func newGetChecksResponse(checks []*platform.Check) getChecksResponse {
+	resp := getChecksResponse{
+		Checks: make([]checkResponse, 0, len(checks)),
+		Links: checkLinks{
+			Self: checksPath,
+		},
+	}
+	for _, c := range checks {
+		resp.Checks = append(resp.Checks, newCheckResponse(c))
+	}
+	return resp
+}
+
+func (h *CheckHandler) handleGetChecks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := decodeRequiredOrganizationID(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, orgID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	checks, err := h.CheckService.FindChecks(ctx, platform.CheckFilter{OrganizationID: &orgID})
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newGetChecksResponse(checks)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// findAuthorizedCheck returns the check unless the user making the request
+// is not a member of its organization.
+func (h *CheckHandler) findAuthorizedCheck(ctx context.Context, id platform.ID) (*platform.Check, error) {
+	c, err := h.CheckService.FindCheckByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, c.OrganizationID); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// validateEndpoints returns an error unless all endpoints belong to the organization.
+func (h *CheckHandler) validateEndpoints(ctx context.Context, orgID platform.ID, ids []platform.ID) error {
+	for _, id := range ids {
+		e, err := h.NotificationEndpointService.FindNotificationEndpointByID(ctx, id)
+		if err != nil {
+			return kerrors.InvalidDataf("invalid notification endpoint %s: %v", id, err)
+		}
+		if e.OrganizationID != orgID {
+			return kerrors.InvalidDataf("notification endpoint %s does not belong to organization %s", id, orgID)
+		}
+	}
+	return nil
+}
+
+func requestCheckID(ctx context.Context) (platform.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	urlID := params.ByName("id")
+	if urlID == "" {
+		return platform.InvalidID(), kerrors.InvalidDataf("url missing id")
+	}
+
+	var id platform.ID
+	if err := id.DecodeFromString(urlID); err != nil {
+		return platform.InvalidID(), kerrors.InvalidDataf("invalid check id: %v", err)
+	}
+	return id, nil
+}
+
+func (h *CheckHandler) handleGetCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestCheckID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	c, err := h.findAuthorizedCheck(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newCheckResponse(c)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *CheckHandler) handlePostCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	c := &platform.Check{}
+	if err := json.NewDecoder(r.Body).Decode(c); err != nil {
+		EncodeError(ctx, kerrors.MalformedDataf("%v", err), w)
+		return
+	}
+	if !c.OrganizationID.Valid() {
+		EncodeError(ctx, kerrors.InvalidDataf("check requires an organization"), w)
+		return
+	}
+	if err := c.Valid(); err != nil {
+		EncodeError(ctx, kerrors.InvalidDataf("%v", err), w)
+		return
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, c.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	if err := h.validateEndpoints(ctx, c.OrganizationID, c.EndpointIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	// The task of the check runs on behalf of the user creating it.
+	userID, err := authorizerUserID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	c.OwnerID = userID
+	c.TaskID = 0
+
+	if err := h.CheckService.CreateCheck(ctx, c); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newCheckResponse(c)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *CheckHandler) handlePatchCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestCheckID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	upd := platform.CheckUpdate{}
+	if err := json.NewDecoder(r.Body).Decode(&upd); err != nil {
+		EncodeError(ctx, kerrors.MalformedDataf("%v", err), w)
+		return
+	}
+	if err := upd.Valid(); err != nil {
+		EncodeError(ctx, kerrors.InvalidDataf("%v", err), w)
+		return
+	}
+
+	existing, err := h.findAuthorizedCheck(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	upd.Apply(existing)
+	if err := existing.Valid(); err != nil {
+		EncodeError(ctx, kerrors.InvalidDataf("%v", err), w)
+		return
+	}
+	if err := h.validateEndpoints(ctx, existing.OrganizationID, upd.EndpointIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	c, err := h.CheckService.UpdateCheck(ctx, id, upd)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newCheckResponse(c)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *CheckHandler) handleDeleteCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestCheckID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if _, err := h.findAuthorizedCheck(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.CheckService.DeleteCheck(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
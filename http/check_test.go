@@ -0,0 +1,110 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/inmem"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func TestCheckHandler(t *testing.T) {
+	ctx := context.Background()
+	orgID := platformtesting.MustIDBase16("020f755c3c082000")
+	otherOrgID := platformtesting.MustIDBase16("020f755c3c082001")
+
+	svc := inmem.NewService()
+	endpoint := &platform.NotificationEndpoint{OrganizationID: orgID, Name: "hook", Type: platform.HTTPEndpointType, URL: "http://localhost/hook"}
+	otherEndpoint := &platform.NotificationEndpoint{OrganizationID: otherOrgID, Name: "hook", Type: platform.HTTPEndpointType, URL: "http://localhost/hook"}
+	for _, e := range []*platform.NotificationEndpoint{endpoint, otherEndpoint} {
+		if err := svc.CreateNotificationEndpoint(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	h := NewCheckHandler()
+	h.CheckService = svc
+	h.NotificationEndpointService = svc
+	h.UserResourceMappingService = memberMappingService()
+	server := httptest.NewServer(authorizedHandler(h))
+	defer server.Close()
+
+	do := func(method, path string, body interface{}, wantCode int, out interface{}) {
+		t.Helper()
+		var b bytes.Buffer
+		if body != nil {
+			if err := json.NewEncoder(&b).Encode(body); err != nil {
+				t.Fatal(err)
+			}
+		}
+		req, err := http.NewRequest(method, server.URL+path, &b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != wantCode {
+			t.Fatalf("%s %s: expected status %d, got %d", method, path, wantCode, resp.StatusCode)
+		}
+		if out != nil {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	check := platform.Check{
+		OrganizationID: orgID,
+		Name:           "high cpu",
+		Type:           platform.ThresholdCheckType,
+		Every:          "1m",
+		Query: platform.CheckQuery{
+			Bucket:      "telegraf",
+			Measurement: "cpu",
+			Field:       "usage_user",
+		},
+		Thresholds:  []platform.Threshold{{Level: platform.CheckLevelCrit, Value: 90}},
+		EndpointIDs: []platform.ID{endpoint.ID},
+	}
+
+	// Checks may only notify the endpoints of their organization.
+	invalid := check
+	invalid.EndpointIDs = []platform.ID{otherEndpoint.ID}
+	do("POST", "/api/v2/checks", invalid, http.StatusUnprocessableEntity, nil)
+	invalid = check
+	invalid.Thresholds = nil
+	do("POST", "/api/v2/checks", invalid, http.StatusUnprocessableEntity, nil)
+
+	var created platform.Check
+	do("POST", "/api/v2/checks", check, http.StatusCreated, &created)
+	if !created.ID.Valid() || created.OwnerID != testUserID {
+		t.Fatalf("expected check to be created and owned by the requesting user, got %+v", created)
+	}
+
+	var list struct {
+		Checks []platform.Check `json:"checks"`
+	}
+	do("GET", "/api/v2/checks?organizationID="+orgID.String(), nil, http.StatusOK, &list)
+	if len(list.Checks) != 1 || list.Checks[0].ID != created.ID {
+		t.Fatalf("expected created check to be listed, got %+v", list.Checks)
+	}
+
+	path := "/api/v2/checks/" + created.ID.String()
+	do("PATCH", path, map[string]interface{}{"every": "0s"}, http.StatusUnprocessableEntity, nil)
+	var updated platform.Check
+	do("PATCH", path, map[string]interface{}{"every": "5m"}, http.StatusOK, &updated)
+	if updated.Every != "5m" {
+		t.Fatalf("expected check to be updated, got %+v", updated)
+	}
+
+	do("DELETE", path, nil, http.StatusNoContent, nil)
+	do("GET", path, nil, http.StatusNotFound, nil)
+}
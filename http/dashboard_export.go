@@ -0,0 +1,299 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/errors"
+)
+
+const (
+	dashboardsImportPath   = "/api/v2/dashboards/import"
+	dashboardsIDExportPath = "/api/v2/dashboards/:id/export"
+)
+
+// ServeHTTP serves dashboard imports before the router, as the router
+// cannot match the import path alongside the ID paths of dashboards.
+func (h *DashboardHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" && r.URL.Path == dashboardsImportPath {
+		h.handlePostDashboardImport(w, r)
+		return
+	}
+	h.Router.ServeHTTP(w, r)
+}
+
+// handleGetDashboardExport returns the dashboard with its views and the macros they use.
+func (h *DashboardHandler) handleGetDashboardExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetDashboardRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	e, err := h.exportDashboard(ctx, req.DashboardID)
+	if err != nil {
+		if err == platform.ErrViewNotFound {
+			err = errors.New(err.Error(), errors.NotFound)
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, e); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *DashboardHandler) exportDashboard(ctx context.Context, id platform.ID) (*platform.DashboardExport, error) {
+	d, err := h.findAuthorizedDashboard(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &platform.DashboardExport{
+		Version:   platform.DashboardExportVersion,
+		Dashboard: d,
+		Views:     make([]*platform.View, 0, len(d.Cells)),
+		Macros:    []*platform.Macro{},
+	}
+
+	var queries []platform.DashboardQuery
+	seen := make(map[platform.ID]bool, len(d.Cells))
+	for _, c := range d.Cells {
+		if seen[c.ViewID] {
+			continue
+		}
+		seen[c.ViewID] = true
+
+		v, err := h.ViewService.FindViewByID(ctx, c.ViewID)
+		if err != nil {
+			return nil, err
+		}
+		e.Views = append(e.Views, v)
+		queries = append(queries, v.Queries()...)
+	}
+
+	macros, err := h.MacroService.FindMacros(ctx, platform.MacroFilter{OrganizationID: &d.OrganizationID})
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range macros {
+		re := platform.MacroReferenceRegexp(m.Name)
+		for _, q := range queries {
+			if re.MatchString(q.Text) {
+				e.Macros = append(e.Macros, m)
+				break
+			}
+		}
+	}
+	return e, nil
+}
+
+// handlePostDashboardImport recreates an exported dashboard, its views and
+// macros with new IDs in an organization, owned by the user making the request.
+func (h *DashboardHandler) handlePostDashboardImport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := decodeRequiredOrganizationID(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	e := &platform.DashboardExport{}
+	if err := json.NewDecoder(r.Body).Decode(e); err != nil {
+		EncodeError(ctx, errors.MalformedDataf("%v", err), w)
+		return
+	}
+	if err := e.Valid(); err != nil {
+		EncodeError(ctx, errors.InvalidDataf("%v", err), w)
+		return
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, orgID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	userID, err := authorizerUserID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	d, err := h.importDashboard(ctx, e, orgID, userID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newDashboardResponse(d)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// importDashboard creates the resources of the export owned by the user, and
+// deletes those it created if any of them cannot be created.
+func (h *DashboardHandler) importDashboard(ctx context.Context, e *platform.DashboardExport, orgID, userID platform.ID) (_ *platform.Dashboard, err error) {
+	var undo []func()
+	defer func() {
+		if err != nil {
+			for i := len(undo) - 1; i >= 0; i-- {
+				undo[i]()
+			}
+		}
+	}()
+	own := func(id platform.ID, typ platform.ResourceType) error {
+		if err := createOwnerMapping(ctx, h.UserResourceMappingService, id, typ, userID); err != nil {
+			return err
+		}
+		undo = append(undo, func() { h.UserResourceMappingService.DeleteUserResourceMapping(ctx, id, userID) })
+		return nil
+	}
+
+	// Views reference macros by name, so they use the macros of the
+	// organization named like the exported ones rather than duplicates.
+	existing, err := h.MacroService.FindMacros(ctx, platform.MacroFilter{OrganizationID: &orgID})
+	if err != nil {
+		return nil, err
+	}
+	macros := make(map[string]bool, len(existing))
+	for _, m := range existing {
+		macros[m.Name] = true
+	}
+	for _, m := range e.Macros {
+		if macros[m.Name] {
+			continue
+		}
+		if err := validateMacroName(m.Name); err != nil {
+			return nil, errors.InvalidDataf("%v", err)
+		}
+		m.ID = 0
+		m.OrganizationID = orgID
+		if err := h.MacroService.CreateMacro(ctx, m); err != nil {
+			return nil, err
+		}
+		id := m.ID
+		undo = append(undo, func() { h.MacroService.DeleteMacro(ctx, id) })
+		macros[m.Name] = true
+
+		if err := own(id, platform.MacroResourceType); err != nil {
+			return nil, err
+		}
+	}
+
+	viewIDs := make(map[platform.ID]platform.ID, len(e.Views))
+	for _, v := range e.Views {
+		exportedID := v.ID
+		v.ID = 0
+		v.OrganizationID = orgID
+		if err := h.ViewService.CreateView(ctx, v); err != nil {
+			return nil, err
+		}
+		id := v.ID
+		undo = append(undo, func() { h.ViewService.DeleteView(ctx, id) })
+		viewIDs[exportedID] = id
+
+		if err := own(id, platform.ViewResourceType); err != nil {
+			return nil, err
+		}
+	}
+
+	d := e.Dashboard
+	d.ID = 0
+	d.OrganizationID = orgID
+	for _, c := range d.Cells {
+		c.ID = 0
+		c.ViewID = viewIDs[c.ViewID]
+	}
+	if err := h.DashboardService.CreateDashboard(ctx, d); err != nil {
+		return nil, err
+	}
+	id := d.ID
+	undo = append(undo, func() { h.DashboardService.DeleteDashboard(ctx, id) })
+
+	if err := own(id, platform.DashboardResourceType); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// ExportDashboard returns the export of a dashboard, its views and the macros they use.
+func (s *DashboardService) ExportDashboard(ctx context.Context, id platform.ID) (*platform.DashboardExport, error) {
+	url, err := newURL(s.Addr, dashboardIDPath(id)+"/export")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(s.Token, req)
+	hc := newClient(url.Scheme, s.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var e platform.DashboardExport
+	if err := json.NewDecoder(resp.Body).Decode(&e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// ImportDashboard recreates an exported dashboard in an organization and returns it.
+func (s *DashboardService) ImportDashboard(ctx context.Context, orgID platform.ID, e *platform.DashboardExport) (*platform.Dashboard, error) {
+	url, err := newURL(s.Addr, dashboardsImportPath)
+	if err != nil {
+		return nil, err
+	}
+	qp := url.Query()
+	qp.Set(OrgID, orgID.String())
+	url.RawQuery = qp.Encode()
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url.String(), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+	hc := newClient(url.Scheme, s.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var dr dashboardResponse
+	if err := json.NewDecoder(resp.Body).Decode(&dr); err != nil {
+		return nil, err
+	}
+	return dr.toPlatform(), nil
+}
@@ -0,0 +1,272 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/inmem"
+)
+
+func newDashboardExportHandler(svc *inmem.Service) *DashboardHandler {
+	h := NewDashboardHandler()
+	h.DashboardService = svc
+	h.ViewService = svc
+	h.MacroService = svc
+	h.UserResourceMappingService = svc
+	return h
+}
+
+func TestDashboardHandler_ExportImport(t *testing.T) {
+	ctx := context.Background()
+	src := inmem.NewService()
+	userID := platform.ID(0x020f755c3c082000)
+	srcOrg := &platform.Organization{Name: "src"}
+	if err := src.CreateOrganization(ctx, srcOrg); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.PutUserResourceMapping(ctx, &platform.UserResourceMapping{
+		ResourceID:   srcOrg.ID,
+		ResourceType: platform.OrgResourceType,
+		UserID:       userID,
+		UserType:     platform.Member,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	usedMacro := &platform.Macro{
+		OrganizationID: srcOrg.ID,
+		Name:           "host",
+		Selected:       []string{"a"},
+		Arguments:      &platform.MacroArguments{Type: "constant", Values: platform.MacroConstantValues{"a", "b"}},
+	}
+	unusedMacro := &platform.Macro{
+		OrganizationID: srcOrg.ID,
+		Name:           "region",
+		Selected:       []string{"us"},
+		Arguments:      &platform.MacroArguments{Type: "constant", Values: platform.MacroConstantValues{"us"}},
+	}
+	for _, m := range []*platform.Macro{usedMacro, unusedMacro} {
+		if err := src.CreateMacro(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	view := &platform.View{
+		ViewContents: platform.ViewContents{OrganizationID: srcOrg.ID, Name: "cpu"},
+		Properties: platform.LineViewProperties{
+			Type: "line",
+			Queries: []platform.DashboardQuery{
+				{Type: "flux", Text: `from(bucket: "telegraf") |> range(start: -1h) |> filter(fn: (r) => r.host == v.host)`},
+			},
+		},
+	}
+	if err := src.CreateView(ctx, view); err != nil {
+		t.Fatal(err)
+	}
+	dashboard := &platform.Dashboard{
+		OrganizationID: srcOrg.ID,
+		Name:           "hosts",
+		Cells: []*platform.Cell{
+			{ID: 1, W: 4, H: 4, ViewID: view.ID},
+			{ID: 2, X: 4, W: 4, H: 4, ViewID: view.ID},
+		},
+	}
+	if err := src.CreateDashboard(ctx, dashboard); err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest("GET", "http://any.url/api/v2/dashboards/"+dashboard.ID.String()+"/export", nil)
+	r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{UserID: userID, Status: platform.Active}))
+	w := httptest.NewRecorder()
+	newDashboardExportHandler(src).ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("export status = %d, want %d", w.Code, http.StatusOK)
+	}
+	exported := w.Body.Bytes()
+
+	var e platform.DashboardExport
+	if err := json.Unmarshal(exported, &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Version != platform.DashboardExportVersion || e.Dashboard.Name != "hosts" {
+		t.Fatalf("unexpected export %+v", e)
+	}
+	if len(e.Views) != 1 || e.Views[0].ID != view.ID {
+		t.Fatalf("expected the shared view to be exported once, got %+v", e.Views)
+	}
+	if len(e.Macros) != 1 || e.Macros[0].Name != "host" {
+		t.Fatalf("expected only the used macro to be exported, got %+v", e.Macros)
+	}
+
+	// Import into another instance.
+	dst := inmem.NewService()
+	dstOrg := &platform.Organization{Name: "dst"}
+	if err := dst.CreateOrganization(ctx, dstOrg); err != nil {
+		t.Fatal(err)
+	}
+	if err := dst.PutUserResourceMapping(ctx, &platform.UserResourceMapping{
+		ResourceID:   dstOrg.ID,
+		ResourceType: platform.OrgResourceType,
+		UserID:       userID,
+		UserType:     platform.Member,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	r = httptest.NewRequest("POST", "http://any.url/api/v2/dashboards/import?organizationID="+dstOrg.ID.String(), bytes.NewReader(exported))
+	r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{UserID: userID, Status: platform.Active}))
+	w = httptest.NewRecorder()
+	newDashboardExportHandler(dst).ServeHTTP(w, r)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("import status = %d, want %d: %s", w.Code, http.StatusCreated, w.Header().Get(ErrorHeader))
+	}
+
+	var res dashboardResponse
+	if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+	imported := res.toPlatform()
+	if imported.Name != "hosts" || imported.OrganizationID != dstOrg.ID || len(imported.Cells) != 2 {
+		t.Fatalf("unexpected imported dashboard %+v", imported)
+	}
+	viewID := imported.Cells[0].ViewID
+	if imported.Cells[1].ViewID != viewID {
+		t.Fatalf("expected cells to share the imported view")
+	}
+	v, err := dst.FindViewByID(ctx, viewID)
+	if err != nil {
+		t.Fatalf("expected imported view: %v", err)
+	}
+	if v.Name != "cpu" || v.OrganizationID != dstOrg.ID || len(v.Queries()) != 1 {
+		t.Fatalf("unexpected imported view %+v", v)
+	}
+
+	macros, err := dst.FindMacros(ctx, platform.MacroFilter{OrganizationID: &dstOrg.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(macros) != 1 || macros[0].Name != "host" {
+		t.Fatalf("unexpected imported macros %+v", macros)
+	}
+
+	mappings, _, err := dst.FindUserResourceMappings(ctx, platform.UserResourceMappingFilter{UserID: userID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	owned := map[platform.ResourceType]platform.ID{}
+	for _, m := range mappings {
+		if m.ResourceType == platform.OrgResourceType {
+			continue
+		}
+		if m.UserType != platform.Owner {
+			t.Errorf("unexpected mapping %+v", m)
+		}
+		owned[m.ResourceType] = m.ResourceID
+	}
+	if owned[platform.DashboardResourceType] != imported.ID || owned[platform.ViewResourceType] != viewID || owned[platform.MacroResourceType] != macros[0].ID {
+		t.Errorf("expected the importer to own the dashboard, view and macro, got %+v", mappings)
+	}
+
+	// Importing again reuses the macro of the same name.
+	r = httptest.NewRequest("POST", "http://any.url/api/v2/dashboards/import?organizationID="+dstOrg.ID.String(), bytes.NewReader(exported))
+	r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{UserID: userID, Status: platform.Active}))
+	w = httptest.NewRecorder()
+	newDashboardExportHandler(dst).ServeHTTP(w, r)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("second import status = %d, want %d: %s", w.Code, http.StatusCreated, w.Header().Get(ErrorHeader))
+	}
+	if macros, err := dst.FindMacros(ctx, platform.MacroFilter{OrganizationID: &dstOrg.ID}); err != nil {
+		t.Fatal(err)
+	} else if len(macros) != 1 {
+		t.Fatalf("expected the macro to be reused, got %+v", macros)
+	}
+}
+
+// failingDashboardService fails to create dashboards.
+type failingDashboardService struct {
+	platform.DashboardService
+}
+
+func (failingDashboardService) CreateDashboard(ctx context.Context, d *platform.Dashboard) error {
+	return &platform.Error{Code: platform.EInternal, Msg: "dashboard not created"}
+}
+
+func TestDashboardHandler_ImportRollback(t *testing.T) {
+	ctx := context.Background()
+	svc := inmem.NewService()
+	userID := platform.ID(0x020f755c3c082000)
+	org := &platform.Organization{Name: "dst"}
+	if err := svc.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.PutUserResourceMapping(ctx, &platform.UserResourceMapping{
+		ResourceID:   org.ID,
+		ResourceType: platform.OrgResourceType,
+		UserID:       userID,
+		UserType:     platform.Member,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{
+  "version": 1,
+  "dashboard": {"name": "hosts", "cells": [{"id": "0000000000000001", "viewID": "020f755c3c082001"}]},
+  "views": [{"id": "020f755c3c082001", "name": "cpu", "properties": {"shape": "empty"}}],
+  "macros": [{"id": "020f755c3c082002", "name": "host", "selected": ["a"], "arguments": {"type": "constant", "values": ["a"]}}]
+}`
+	h := newDashboardExportHandler(svc)
+	h.DashboardService = failingDashboardService{svc}
+	r := httptest.NewRequest("POST", "http://any.url/api/v2/dashboards/import?organizationID="+org.ID.String(), bytes.NewBufferString(body))
+	r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{UserID: userID, Status: platform.Active}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("import status = %d, want %d: %s", w.Code, http.StatusInternalServerError, w.Header().Get(ErrorHeader))
+	}
+
+	if macros, err := svc.FindMacros(ctx, platform.MacroFilter{OrganizationID: &org.ID}); err != nil {
+		t.Fatal(err)
+	} else if len(macros) != 0 {
+		t.Errorf("expected imported macros to be deleted, got %+v", macros)
+	}
+	if views, _, err := svc.FindViews(ctx, platform.ViewFilter{}); err != nil {
+		t.Fatal(err)
+	} else if len(views) != 0 {
+		t.Errorf("expected imported views to be deleted, got %+v", views)
+	}
+	if mappings, _, err := svc.FindUserResourceMappings(ctx, platform.UserResourceMappingFilter{UserID: userID}); err != nil {
+		t.Fatal(err)
+	} else if len(mappings) != 1 {
+		t.Errorf("expected only the organization mapping to remain, got %+v", mappings)
+	}
+}
+
+func TestDashboardHandler_ImportInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "unsupported version",
+			body: `{"version": 2, "dashboard": {"name": "d", "cells": []}}`,
+		},
+		{
+			name: "missing view",
+			body: `{"version": 1, "dashboard": {"name": "d", "cells": [{"id": "020f755c3c082000", "viewID": "020f755c3c082001"}]}, "views": []}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			newDashboardExportHandler(inmem.NewService()).ServeHTTP(w, httptest.NewRequest("POST", "http://any.url/api/v2/dashboards/import?organizationID=020f755c3c082000", bytes.NewBufferString(tt.body)))
+			if w.Code != http.StatusUnprocessableEntity {
+				t.Errorf("import status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+			}
+		})
+	}
+}
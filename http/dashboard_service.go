@@ -19,6 +19,8 @@ type DashboardHandler struct {
 	*httprouter.Router
 
 	DashboardService           platform.DashboardService
+	ViewService                platform.ViewService
+	MacroService               platform.MacroService
 	UserResourceMappingService platform.UserResourceMappingService
 }
 
@@ -44,6 +46,7 @@ func NewDashboardHandler() *DashboardHandler {
 	h.HandlerFunc("GET", dashboardsIDPath, h.handleGetDashboard)
 	h.HandlerFunc("DELETE", dashboardsIDPath, h.handleDeleteDashboard)
 	h.HandlerFunc("PATCH", dashboardsIDPath, h.handlePatchDashboard)
+	h.HandlerFunc("GET", dashboardsIDExportPath, h.handleGetDashboardExport)
 
 	h.HandlerFunc("PUT", dashboardsIDCellsPath, h.handlePutDashboardCells)
 	h.HandlerFunc("POST", dashboardsIDCellsPath, h.handlePostDashboardCell)
@@ -74,8 +77,9 @@ type dashboardResponse struct {
 func (d dashboardResponse) toPlatform() *platform.Dashboard {
 	if len(d.Cells) == 0 {
 		return &platform.Dashboard{
-			ID:   d.ID,
-			Name: d.Name,
+			ID:             d.ID,
+			OrganizationID: d.OrganizationID,
+			Name:           d.Name,
 		}
 	}
 
@@ -84,9 +88,10 @@ func (d dashboardResponse) toPlatform() *platform.Dashboard {
 		cells = append(cells, d.Cells[i].toPlatform())
 	}
 	return &platform.Dashboard{
-		ID:    d.ID,
-		Name:  d.Name,
-		Cells: cells,
+		ID:             d.ID,
+		OrganizationID: d.OrganizationID,
+		Name:           d.Name,
+		Cells:          cells,
 	}
 }
 
@@ -146,7 +151,7 @@ func newDashboardCellsResponse(dashboardID platform.ID, cs []*platform.Cell) das
 	return res
 }
 
-// handleGetDashboards returns all dashboards within the store.
+// handleGetDashboards returns the dashboards of an organization.
 func (h *DashboardHandler) handleGetDashboards(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	req, err := decodeGetDashboardsRequest(ctx, r)
@@ -155,22 +160,9 @@ func (h *DashboardHandler) handleGetDashboards(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if req.ownerID != nil {
-		filter := platform.UserResourceMappingFilter{
-			UserID:       *req.ownerID,
-			UserType:     platform.Owner,
-			ResourceType: platform.DashboardResourceType,
-		}
-
-		mappings, _, err := h.UserResourceMappingService.FindUserResourceMappings(ctx, filter)
-		if err != nil {
-			EncodeError(ctx, errors.InternalErrorf("Error loading dashboard owners: %v", err), w)
-			return
-		}
-
-		for _, mapping := range mappings {
-			req.filter.IDs = append(req.filter.IDs, &mapping.ResourceID)
-		}
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, *req.filter.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
 	}
 
 	dashboards, _, err := h.DashboardService.FindDashboards(ctx, req.filter)
@@ -186,14 +178,19 @@ func (h *DashboardHandler) handleGetDashboards(w http.ResponseWriter, r *http.Re
 }
 
 type getDashboardsRequest struct {
-	filter  platform.DashboardFilter
-	ownerID *platform.ID
+	filter platform.DashboardFilter
 }
 
 func decodeGetDashboardsRequest(ctx context.Context, r *http.Request) (*getDashboardsRequest, error) {
 	qp := r.URL.Query()
 	req := &getDashboardsRequest{}
 
+	orgID, err := decodeRequiredOrganizationID(r)
+	if err != nil {
+		return nil, err
+	}
+	req.filter.OrganizationID = &orgID
+
 	initialID := platform.InvalidID()
 	if ids, ok := qp["id"]; ok {
 		for _, id := range ids {
@@ -203,13 +200,19 @@ func decodeGetDashboardsRequest(ctx context.Context, r *http.Request) (*getDashb
 			}
 			req.filter.IDs = append(req.filter.IDs, &i)
 		}
-	} else if owner := qp.Get("owner"); owner != "" {
-		req.ownerID = &initialID
-		if err := req.ownerID.DecodeFromString(owner); err != nil {
+	}
+
+	if owner := qp.Get("owner"); owner != "" {
+		req.filter.Owner = &initialID
+		if err := req.filter.Owner.DecodeFromString(owner); err != nil {
 			return nil, err
 		}
 	}
 
+	if prefix := qp.Get("prefix"); prefix != "" {
+		req.filter.NamePrefix = &prefix
+	}
+
 	return req, nil
 }
 
@@ -254,6 +257,21 @@ func (h *DashboardHandler) handlePostDashboard(w http.ResponseWriter, r *http.Re
 		EncodeError(ctx, err, w)
 		return
 	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, req.Dashboard.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	for _, c := range req.Dashboard.Cells {
+		if !c.ViewID.Valid() {
+			continue
+		}
+		if err := h.checkViewOrganization(ctx, c.ViewID, req.Dashboard.OrganizationID); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+	}
+
 	if err := h.DashboardService.CreateDashboard(ctx, req.Dashboard); err != nil {
 		EncodeError(ctx, errors.InternalErrorf("Error loading dashboards: %v", err), w)
 		return
@@ -274,6 +292,9 @@ func decodePostDashboardRequest(ctx context.Context, r *http.Request) (*postDash
 	if err := json.NewDecoder(r.Body).Decode(c); err != nil {
 		return nil, err
 	}
+	if !c.OrganizationID.Valid() {
+		return nil, errors.InvalidDataf("dashboard requires an organization")
+	}
 	return &postDashboardRequest{
 		Dashboard: c,
 	}, nil
@@ -289,11 +310,8 @@ func (h *DashboardHandler) handleGetDashboard(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	dashboard, err := h.DashboardService.FindDashboardByID(ctx, req.DashboardID)
+	dashboard, err := h.findAuthorizedDashboard(ctx, req.DashboardID)
 	if err != nil {
-		if err == platform.ErrDashboardNotFound {
-			err = errors.New(err.Error(), errors.NotFound)
-		}
 		EncodeError(ctx, err, w)
 		return
 	}
@@ -304,6 +322,39 @@ func (h *DashboardHandler) handleGetDashboard(w http.ResponseWriter, r *http.Req
 	}
 }
 
+// findAuthorizedDashboard returns the dashboard unless the user making the request
+// is not a member of its organization.
+func (h *DashboardHandler) findAuthorizedDashboard(ctx context.Context, id platform.ID) (*platform.Dashboard, error) {
+	d, err := h.DashboardService.FindDashboardByID(ctx, id)
+	if err != nil {
+		if err == platform.ErrDashboardNotFound {
+			err = errors.New(err.Error(), errors.NotFound)
+		}
+		return nil, err
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, d.OrganizationID); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// checkViewOrganization returns an error unless the view exists and belongs to
+// the organization, as dashboards only show the views of their organization.
+func (h *DashboardHandler) checkViewOrganization(ctx context.Context, viewID, orgID platform.ID) error {
+	v, err := h.ViewService.FindViewByID(ctx, viewID)
+	if err != nil {
+		if err == platform.ErrViewNotFound {
+			err = errors.New(err.Error(), errors.NotFound)
+		}
+		return err
+	}
+	if v.OrganizationID != orgID {
+		return errors.InvalidDataf("view %s does not belong to organization %s", viewID, orgID)
+	}
+	return nil
+}
+
 type getDashboardRequest struct {
 	DashboardID platform.ID
 }
@@ -335,6 +386,11 @@ func (h *DashboardHandler) handleDeleteDashboard(w http.ResponseWriter, r *http.
 		return
 	}
 
+	if _, err := h.findAuthorizedDashboard(ctx, req.DashboardID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.DashboardService.DeleteDashboard(ctx, req.DashboardID); err != nil {
 		if err == platform.ErrDashboardNotFound {
 			err = errors.New(err.Error(), errors.NotFound)
@@ -376,6 +432,11 @@ func (h *DashboardHandler) handlePatchDashboard(w http.ResponseWriter, r *http.R
 		EncodeError(ctx, err, w)
 		return
 	}
+
+	if _, err := h.findAuthorizedDashboard(ctx, req.DashboardID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
 	dashboard, err := h.DashboardService.UpdateDashboard(ctx, req.DashboardID, req.Upd)
 	if err != nil {
 		if err == platform.ErrDashboardNotFound {
@@ -476,6 +537,21 @@ func (h *DashboardHandler) handlePostDashboardCell(w http.ResponseWriter, r *htt
 		EncodeError(ctx, err, w)
 		return
 	}
+
+	d, err := h.findAuthorizedDashboard(ctx, req.dashboardID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	for _, id := range []platform.ID{req.opts.UsingView, req.cell.ViewID} {
+		if !id.Valid() {
+			continue
+		}
+		if err := h.checkViewOrganization(ctx, id, d.OrganizationID); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+	}
 	if err := h.DashboardService.AddDashboardCell(ctx, req.dashboardID, req.cell, req.opts); err != nil {
 		if err == platform.ErrDashboardNotFound {
 			err = errors.New(err.Error(), errors.NotFound)
@@ -525,6 +601,11 @@ func (h *DashboardHandler) handlePutDashboardCells(w http.ResponseWriter, r *htt
 		return
 	}
 
+	if _, err := h.findAuthorizedDashboard(ctx, req.dashboardID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.DashboardService.ReplaceDashboardCells(ctx, req.dashboardID, req.cells); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -573,6 +654,11 @@ func (h *DashboardHandler) handleDeleteDashboardCell(w http.ResponseWriter, r *h
 		EncodeError(ctx, err, w)
 		return
 	}
+
+	if _, err := h.findAuthorizedDashboard(ctx, req.dashboardID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
 	if err := h.DashboardService.RemoveDashboardCell(ctx, req.dashboardID, req.cellID); err != nil {
 		if err == platform.ErrDashboardNotFound || err == platform.ErrCellNotFound {
 			err = errors.New(err.Error(), errors.NotFound)
@@ -626,6 +712,11 @@ func (h *DashboardHandler) handlePatchDashboardCell(w http.ResponseWriter, r *ht
 		EncodeError(ctx, err, w)
 		return
 	}
+
+	if _, err := h.findAuthorizedDashboard(ctx, req.dashboardID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
 	cell, err := h.DashboardService.UpdateDashboardCell(ctx, req.dashboardID, req.cellID, req.upd)
 	if err != nil {
 		if err == platform.ErrDashboardNotFound || err == platform.ErrCellNotFound {
@@ -691,9 +782,18 @@ func (s *DashboardService) FindDashboards(ctx context.Context, filter platform.D
 	}
 
 	qp := url.Query()
+	if filter.OrganizationID != nil {
+		qp.Set(OrgID, filter.OrganizationID.String())
+	}
 	for _, id := range filter.IDs {
 		qp.Add("id", id.String())
 	}
+	if filter.Owner != nil {
+		qp.Set("owner", filter.Owner.String())
+	}
+	if filter.NamePrefix != nil {
+		qp.Set("prefix", *filter.NamePrefix)
+	}
 	url.RawQuery = qp.Encode()
 
 	req, err := http.NewRequest("GET", url.String(), nil)
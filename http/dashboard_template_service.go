@@ -0,0 +1,204 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/errors"
+	"github.com/julienschmidt/httprouter"
+)
+
+// DashboardTemplateHandler is the handler for the dashboard template service.
+type DashboardTemplateHandler struct {
+	*httprouter.Router
+
+	DashboardTemplateService   platform.DashboardTemplateService
+	UserResourceMappingService platform.UserResourceMappingService
+}
+
+const (
+	dashboardTemplatesPath            = "/api/v2/templates/dashboards"
+	dashboardTemplatesIDPath          = "/api/v2/templates/dashboards/:id"
+	dashboardTemplatesIDInstancesPath = "/api/v2/templates/dashboards/:id/instances"
+)
+
+// NewDashboardTemplateHandler returns a new instance of DashboardTemplateHandler.
+func NewDashboardTemplateHandler() *DashboardTemplateHandler {
+	h := &DashboardTemplateHandler{
+		Router: httprouter.New(),
+	}
+
+	h.HandlerFunc("GET", dashboardTemplatesPath, h.handleGetDashboardTemplates)
+	h.HandlerFunc("GET", dashboardTemplatesIDPath, h.handleGetDashboardTemplate)
+	h.HandlerFunc("POST", dashboardTemplatesIDInstancesPath, h.handlePostDashboardTemplateInstance)
+
+	return h
+}
+
+type dashboardTemplateLinks struct {
+	Self      string `json:"self"`
+	Instances string `json:"instances"`
+}
+
+type dashboardTemplateResponse struct {
+	platform.DashboardTemplate
+	Links dashboardTemplateLinks `json:"links"`
+}
+
+func newDashboardTemplateResponse(t *platform.DashboardTemplate) dashboardTemplateResponse {
+	return dashboardTemplateResponse{
+		DashboardTemplate: *t,
+		Links: dashboardTemplateLinks{
+			Self:      fmt.Sprintf("/api/v2/templates/dashboards/%s", t.ID),
+			Instances: fmt.Sprintf("/api/v2/templates/dashboards/%s/instances", t.ID),
+		},
+	}
+}
+
+type getDashboardTemplatesLinks struct {
+	Self string `json:"self"`
+}
+
+type getDashboardTemplatesResponse struct {
+	Links     getDashboardTemplatesLinks  `json:"links"`
+	Templates []dashboardTemplateResponse `json:"templates"`
+}
+
+// handleGetDashboardTemplates returns the dashboard templates, optionally
+// only those of the Telegraf plugin of the plugin query parameter.
+func (h *DashboardTemplateHandler) handleGetDashboardTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var filter platform.DashboardTemplateFilter
+	if plugin := r.URL.Query().Get("plugin"); plugin != "" {
+		filter.Plugin = &plugin
+	}
+
+	ts, err := h.DashboardTemplateService.FindDashboardTemplates(ctx, filter)
+	if err != nil {
+		EncodeError(ctx, errors.InternalErrorf("Error loading dashboard templates: %v", err), w)
+		return
+	}
+
+	res := getDashboardTemplatesResponse{
+		Links:     getDashboardTemplatesLinks{Self: dashboardTemplatesPath},
+		Templates: make([]dashboardTemplateResponse, 0, len(ts)),
+	}
+	for _, t := range ts {
+		res.Templates = append(res.Templates, newDashboardTemplateResponse(t))
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handleGetDashboardTemplate retrieves a dashboard template by ID.
+func (h *DashboardTemplateHandler) handleGetDashboardTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := decodeDashboardTemplateID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	t, err := h.DashboardTemplateService.FindDashboardTemplateByID(ctx, id)
+	if err != nil {
+		if err == platform.ErrDashboardTemplateNotFound {
+			err = errors.New(err.Error(), errors.NotFound)
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newDashboardTemplateResponse(t)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handlePostDashboardTemplateInstance creates a dashboard from a template.
+func (h *DashboardTemplateHandler) handlePostDashboardTemplateInstance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePostDashboardTemplateInstanceRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, req.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	userID, err := authorizerUserID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	d, err := h.DashboardTemplateService.CreateDashboardFromTemplate(ctx, req.TemplateID, req.DashboardTemplateRequest)
+	if err != nil {
+		if err == platform.ErrDashboardTemplateNotFound {
+			err = errors.New(err.Error(), errors.NotFound)
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	// The user creating the dashboard from the template owns it and its views.
+	if err := createOwnerMapping(ctx, h.UserResourceMappingService, d.ID, platform.DashboardResourceType, userID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	for _, c := range d.Cells {
+		if err := createOwnerMapping(ctx, h.UserResourceMappingService, c.ViewID, platform.ViewResourceType, userID); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newDashboardResponse(d)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+type postDashboardTemplateInstanceRequest struct {
+	TemplateID string
+	platform.DashboardTemplateRequest
+}
+
+func decodePostDashboardTemplateInstanceRequest(ctx context.Context, r *http.Request) (*postDashboardTemplateInstanceRequest, error) {
+	id, err := decodeDashboardTemplateID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &postDashboardTemplateInstanceRequest{TemplateID: id}
+	if err := json.NewDecoder(r.Body).Decode(&req.DashboardTemplateRequest); err != nil {
+		return nil, errors.MalformedDataf("%v", err)
+	}
+	if !req.OrganizationID.Valid() {
+		return nil, errors.InvalidDataf("orgID is required")
+	}
+	if !req.BucketID.Valid() {
+		return nil, errors.InvalidDataf("bucketID is required")
+	}
+	return req, nil
+}
+
+func decodeDashboardTemplateID(ctx context.Context) (string, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return "", errors.InvalidDataf("url missing id")
+	}
+	return id, nil
+}
@@ -0,0 +1,198 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+)
+
+func TestService_handleGetDashboardTemplates(t *testing.T) {
+	var gotPlugin *string
+	h := NewDashboardTemplateHandler()
+	h.DashboardTemplateService = &mock.DashboardTemplateService{
+		FindDashboardTemplatesF: func(ctx context.Context, filter platform.DashboardTemplateFilter) ([]*platform.DashboardTemplate, error) {
+			gotPlugin = filter.Plugin
+			return []*platform.DashboardTemplate{
+				{
+					ID:          "0fa47984-825b-46f1-9ca5-0366e3220007",
+					Name:        "cpu",
+					Application: "system",
+					Measurement: "cpu",
+					Cells: []*platform.DashboardTemplateCell{
+						{Name: "System - CPU Usage", W: 4, H: 4},
+					},
+				},
+			}, nil
+		},
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "http://any.url/api/v2/templates/dashboards?plugin=cpu", nil))
+
+	res := w.Result()
+	body, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("handleGetDashboardTemplates() = %v, want %v", res.StatusCode, http.StatusOK)
+	}
+	if gotPlugin == nil || *gotPlugin != "cpu" {
+		t.Errorf("expected templates to be filtered by plugin cpu")
+	}
+
+	want := `
+{
+  "links": {
+    "self": "/api/v2/templates/dashboards"
+  },
+  "templates": [
+    {
+      "id": "0fa47984-825b-46f1-9ca5-0366e3220007",
+      "name": "cpu",
+      "application": "system",
+      "measurement": "cpu",
+      "cells": [
+        {
+          "name": "System - CPU Usage",
+          "x": 0,
+          "y": 0,
+          "w": 4,
+          "h": 4
+        }
+      ],
+      "links": {
+        "self": "/api/v2/templates/dashboards/0fa47984-825b-46f1-9ca5-0366e3220007",
+        "instances": "/api/v2/templates/dashboards/0fa47984-825b-46f1-9ca5-0366e3220007/instances"
+      }
+    }
+  ]
+}`
+	if eq, _ := jsonEqual(string(body), want); !eq {
+		t.Errorf("handleGetDashboardTemplates() = %s, want %s", body, want)
+	}
+}
+
+func TestService_handlePostDashboardTemplateInstance(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		statusCode int
+		want       string
+		owned      []platform.UserResourceMapping
+	}{
+		{
+			name:       "create dashboard from template",
+			id:         "cpu",
+			body:       `{"orgID": "020f755c3c082000", "bucketID": "020f755c3c082001"}`,
+			statusCode: http.StatusCreated,
+			owned: []platform.UserResourceMapping{
+				{ResourceID: platform.ID(0x020f755c3c082002), ResourceType: platform.DashboardResourceType, UserID: testUserID, UserType: platform.Owner},
+				{ResourceID: platform.ID(0x020f755c3c082004), ResourceType: platform.ViewResourceType, UserID: testUserID, UserType: platform.Owner},
+			},
+			want: `
+{
+  "id": "020f755c3c082002",
+  "name": "cpu",
+  "cells": [
+    {
+      "id": "020f755c3c082003",
+      "x": 0,
+      "y": 0,
+      "w": 4,
+      "h": 4,
+      "viewID": "020f755c3c082004",
+      "links": {
+        "self": "/api/v2/dashboards/020f755c3c082002/cells/020f755c3c082003",
+        "view": "/api/v2/views/020f755c3c082004"
+      }
+    }
+  ],
+  "links": {
+    "self": "/api/v2/dashboards/020f755c3c082002",
+    "cells": "/api/v2/dashboards/020f755c3c082002/cells"
+  }
+}`,
+		},
+		{
+			name:       "template not found",
+			id:         "missing",
+			body:       `{"orgID": "020f755c3c082000", "bucketID": "020f755c3c082001"}`,
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "missing bucket",
+			id:         "cpu",
+			body:       `{"orgID": "020f755c3c082000"}`,
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "not a member of the organization",
+			id:         "cpu",
+			body:       `{"orgID": "020f755c3c082009", "bucketID": "020f755c3c082001"}`,
+			statusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var owned []platform.UserResourceMapping
+			h := NewDashboardTemplateHandler()
+			h.UserResourceMappingService = &mock.UserResourceMappingService{
+				FindMappingsF: func(ctx context.Context, filter platform.UserResourceMappingFilter) ([]*platform.UserResourceMapping, int, error) {
+					if filter.ResourceID != platform.ID(0x020f755c3c082000) {
+						return nil, 0, nil
+					}
+					return memberMappingService().FindMappingsF(ctx, filter)
+				},
+				CreateMappingF: func(ctx context.Context, m *platform.UserResourceMapping) error {
+					owned = append(owned, *m)
+					return nil
+				},
+			}
+			h.DashboardTemplateService = &mock.DashboardTemplateService{
+				CreateDashboardFromTemplateF: func(ctx context.Context, id string, req platform.DashboardTemplateRequest) (*platform.Dashboard, error) {
+					if id != "cpu" {
+						return nil, platform.ErrDashboardTemplateNotFound
+					}
+					return &platform.Dashboard{
+						ID:   platform.ID(0x020f755c3c082002),
+						Name: "cpu",
+						Cells: []*platform.Cell{
+							{
+								ID:     platform.ID(0x020f755c3c082003),
+								W:      4,
+								H:      4,
+								ViewID: platform.ID(0x020f755c3c082004),
+							},
+						},
+					}, nil
+				},
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://any.url/api/v2/templates/dashboards/"+tt.id+"/instances", bytes.NewBufferString(tt.body))
+			h.ServeHTTP(w, withTestAuthorizer(r))
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.statusCode {
+				t.Fatalf("handlePostDashboardTemplateInstance() = %v, want %v", res.StatusCode, tt.statusCode)
+			}
+			if !reflect.DeepEqual(owned, tt.owned) {
+				t.Errorf("owner mappings = %+v, want %+v", owned, tt.owned)
+			}
+			if tt.want == "" {
+				return
+			}
+			if eq, _ := jsonEqual(string(body), tt.want); !eq {
+				t.Errorf("handlePostDashboardTemplateInstance() = %s, want %s", body, tt.want)
+			}
+		})
+	}
+}
@@ -43,8 +43,9 @@ func TestService_handleGetDashboards(t *testing.T) {
 					FindDashboardsF: func(ctx context.Context, filter platform.DashboardFilter) ([]*platform.Dashboard, int, error) {
 						return []*platform.Dashboard{
 							{
-								ID:   platformtesting.MustIDBase16("da7aba5e5d81e550"),
-								Name: "hello",
+								ID:             platformtesting.MustIDBase16("da7aba5e5d81e550"),
+								OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+								Name:           "hello",
 								Cells: []*platform.Cell{
 									{
 										ID:     platformtesting.MustIDBase16("da7aba5e5d81e550"),
@@ -57,14 +58,19 @@ func TestService_handleGetDashboards(t *testing.T) {
 								},
 							},
 							{
-								ID:   platformtesting.MustIDBase16("0ca2204eca2204e0"),
-								Name: "example",
+								ID:             platformtesting.MustIDBase16("0ca2204eca2204e0"),
+								OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+								Name:           "example",
 							},
 						}, 2, nil
 					},
 				},
 			},
-			args: args{},
+			args: args{
+				queryParams: map[string][]string{
+					"organizationID": {"0b501e7e557ab1ed"},
+				},
+			},
 			wants: wants{
 				statusCode:  http.StatusOK,
 				contentType: "application/json; charset=utf-8",
@@ -76,6 +82,7 @@ func TestService_handleGetDashboards(t *testing.T) {
   "dashboards": [
     {
       "id": "da7aba5e5d81e550",
+      "organizationID": "0b501e7e557ab1ed",
       "name": "hello",
       "cells": [
         {
@@ -98,6 +105,7 @@ func TestService_handleGetDashboards(t *testing.T) {
     },
     {
       "id": "0ca2204eca2204e0",
+      "organizationID": "0b501e7e557ab1ed",
       "name": "example",
       "cells": [],
       "links": {
@@ -119,7 +127,11 @@ func TestService_handleGetDashboards(t *testing.T) {
 					},
 				},
 			},
-			args: args{},
+			args: args{
+				queryParams: map[string][]string{
+					"organizationID": {"0b501e7e557ab1ed"},
+				},
+			},
 			wants: wants{
 				statusCode:  http.StatusOK,
 				contentType: "application/json; charset=utf-8",
@@ -132,12 +144,23 @@ func TestService_handleGetDashboards(t *testing.T) {
 }`,
 			},
 		},
+		{
+			name: "get dashboards without an organization",
+			fields: fields{
+				&mock.DashboardService{},
+			},
+			args: args{},
+			wants: wants{
+				statusCode: http.StatusUnprocessableEntity,
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewDashboardHandler()
 			h.DashboardService = tt.fields.DashboardService
+			h.UserResourceMappingService = memberMappingService()
 
 			r := httptest.NewRequest("GET", "http://any.url", nil)
 
@@ -151,7 +174,7 @@ func TestService_handleGetDashboards(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
-			h.handleGetDashboards(w, r)
+			h.handleGetDashboards(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			content := res.Header.Get("Content-Type")
@@ -197,8 +220,9 @@ func TestService_handleGetDashboard(t *testing.T) {
 					FindDashboardByIDF: func(ctx context.Context, id platform.ID) (*platform.Dashboard, error) {
 						if id == platformtesting.MustIDBase16("020f755c3c082000") {
 							return &platform.Dashboard{
-								ID:   platformtesting.MustIDBase16("020f755c3c082000"),
-								Name: "hello",
+								ID:             platformtesting.MustIDBase16("020f755c3c082000"),
+								OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+								Name:           "hello",
 								Cells: []*platform.Cell{
 									{
 										ID:     platformtesting.MustIDBase16("da7aba5e5d81e550"),
@@ -225,6 +249,7 @@ func TestService_handleGetDashboard(t *testing.T) {
 				body: `
 {
   "id": "020f755c3c082000",
+  "organizationID": "0b501e7e557ab1ed",
   "name": "hello",
   "cells": [
     {
@@ -270,6 +295,7 @@ func TestService_handleGetDashboard(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewDashboardHandler()
 			h.DashboardService = tt.fields.DashboardService
+			h.UserResourceMappingService = memberMappingService()
 
 			r := httptest.NewRequest("GET", "http://any.url", nil)
 
@@ -285,7 +311,7 @@ func TestService_handleGetDashboard(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
-			h.handleGetDashboard(w, r)
+			h.handleGetDashboard(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			content := res.Header.Get("Content-Type")
@@ -307,6 +333,7 @@ func TestService_handleGetDashboard(t *testing.T) {
 func TestService_handlePostDashboard(t *testing.T) {
 	type fields struct {
 		DashboardService platform.DashboardService
+		ViewService      platform.ViewService
 	}
 	type args struct {
 		dashboard *platform.Dashboard
@@ -332,11 +359,17 @@ func TestService_handlePostDashboard(t *testing.T) {
 						return nil
 					},
 				},
+				&mock.ViewService{
+					FindViewByIDF: func(ctx context.Context, id platform.ID) (*platform.View, error) {
+						return &platform.View{ViewContents: platform.ViewContents{ID: id, OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed")}}, nil
+					},
+				},
 			},
 			args: args{
 				dashboard: &platform.Dashboard{
-					ID:   platformtesting.MustIDBase16("020f755c3c082000"),
-					Name: "hello",
+					ID:             platformtesting.MustIDBase16("020f755c3c082000"),
+					OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+					Name:           "hello",
 					Cells: []*platform.Cell{
 						{
 							ID:     platformtesting.MustIDBase16("da7aba5e5d81e550"),
@@ -355,6 +388,7 @@ func TestService_handlePostDashboard(t *testing.T) {
 				body: `
 {
   "id": "020f755c3c082000",
+  "organizationID": "0b501e7e557ab1ed",
   "name": "hello",
   "cells": [
     {
@@ -378,12 +412,45 @@ func TestService_handlePostDashboard(t *testing.T) {
 `,
 			},
 		},
+		{
+			name: "create a dashboard with a view of another organization",
+			fields: fields{
+				&mock.DashboardService{
+					CreateDashboardF: func(ctx context.Context, c *platform.Dashboard) error {
+						t.Error("expected the dashboard not to be created")
+						return nil
+					},
+				},
+				&mock.ViewService{
+					FindViewByIDF: func(ctx context.Context, id platform.ID) (*platform.View, error) {
+						return &platform.View{ViewContents: platform.ViewContents{ID: id, OrganizationID: platformtesting.MustIDBase16("020f755c3c082999")}}, nil
+					},
+				},
+			},
+			args: args{
+				dashboard: &platform.Dashboard{
+					OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+					Name:           "hello",
+					Cells: []*platform.Cell{
+						{
+							ID:     platformtesting.MustIDBase16("da7aba5e5d81e550"),
+							ViewID: platformtesting.MustIDBase16("ba0bab707a11ed12"),
+						},
+					},
+				},
+			},
+			wants: wants{
+				statusCode: http.StatusUnprocessableEntity,
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewDashboardHandler()
 			h.DashboardService = tt.fields.DashboardService
+			h.ViewService = tt.fields.ViewService
+			h.UserResourceMappingService = memberMappingService()
 
 			b, err := json.Marshal(tt.args.dashboard)
 			if err != nil {
@@ -393,7 +460,7 @@ func TestService_handlePostDashboard(t *testing.T) {
 			r := httptest.NewRequest("GET", "http://any.url", bytes.NewReader(b))
 			w := httptest.NewRecorder()
 
-			h.handlePostDashboard(w, r)
+			h.handlePostDashboard(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			content := res.Header.Get("Content-Type")
@@ -435,6 +502,12 @@ func TestService_handleDeleteDashboard(t *testing.T) {
 			name: "remove a dashboard by id",
 			fields: fields{
 				&mock.DashboardService{
+					FindDashboardByIDF: func(ctx context.Context, id platform.ID) (*platform.Dashboard, error) {
+						return &platform.Dashboard{
+							ID:             id,
+							OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+						}, nil
+					},
 					DeleteDashboardF: func(ctx context.Context, id platform.ID) error {
 						if id == platformtesting.MustIDBase16("020f755c3c082000") {
 							return nil
@@ -455,8 +528,8 @@ func TestService_handleDeleteDashboard(t *testing.T) {
 			name: "dashboard not found",
 			fields: fields{
 				&mock.DashboardService{
-					DeleteDashboardF: func(ctx context.Context, id platform.ID) error {
-						return platform.ErrDashboardNotFound
+					FindDashboardByIDF: func(ctx context.Context, id platform.ID) (*platform.Dashboard, error) {
+						return nil, platform.ErrDashboardNotFound
 					},
 				},
 			},
@@ -473,6 +546,7 @@ func TestService_handleDeleteDashboard(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewDashboardHandler()
 			h.DashboardService = tt.fields.DashboardService
+			h.UserResourceMappingService = memberMappingService()
 
 			r := httptest.NewRequest("GET", "http://any.url", nil)
 
@@ -488,7 +562,7 @@ func TestService_handleDeleteDashboard(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
-			h.handleDeleteDashboard(w, r)
+			h.handleDeleteDashboard(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			content := res.Header.Get("Content-Type")
@@ -532,11 +606,18 @@ func TestService_handlePatchDashboard(t *testing.T) {
 			name: "update a dashboard name",
 			fields: fields{
 				&mock.DashboardService{
+					FindDashboardByIDF: func(ctx context.Context, id platform.ID) (*platform.Dashboard, error) {
+						return &platform.Dashboard{
+							ID:             id,
+							OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+						}, nil
+					},
 					UpdateDashboardF: func(ctx context.Context, id platform.ID, upd platform.DashboardUpdate) (*platform.Dashboard, error) {
 						if id == platformtesting.MustIDBase16("020f755c3c082000") {
 							d := &platform.Dashboard{
-								ID:   platformtesting.MustIDBase16("020f755c3c082000"),
-								Name: "hello",
+								ID:             platformtesting.MustIDBase16("020f755c3c082000"),
+								OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+								Name:           "hello",
 								Cells: []*platform.Cell{
 									{
 										ID:     platformtesting.MustIDBase16("da7aba5e5d81e550"),
@@ -570,6 +651,7 @@ func TestService_handlePatchDashboard(t *testing.T) {
 				body: `
 {
   "id": "020f755c3c082000",
+  "organizationID": "0b501e7e557ab1ed",
   "name": "example",
   "cells": [
     {
@@ -597,6 +679,12 @@ func TestService_handlePatchDashboard(t *testing.T) {
 			name: "update a dashboard with empty request body",
 			fields: fields{
 				&mock.DashboardService{
+					FindDashboardByIDF: func(ctx context.Context, id platform.ID) (*platform.Dashboard, error) {
+						return &platform.Dashboard{
+							ID:             id,
+							OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+						}, nil
+					},
 					UpdateDashboardF: func(ctx context.Context, id platform.ID, upd platform.DashboardUpdate) (*platform.Dashboard, error) {
 						return nil, fmt.Errorf("not found")
 					},
@@ -613,7 +701,7 @@ func TestService_handlePatchDashboard(t *testing.T) {
 			name: "dashboard not found",
 			fields: fields{
 				&mock.DashboardService{
-					UpdateDashboardF: func(ctx context.Context, id platform.ID, upd platform.DashboardUpdate) (*platform.Dashboard, error) {
+					FindDashboardByIDF: func(ctx context.Context, id platform.ID) (*platform.Dashboard, error) {
 						return nil, platform.ErrDashboardNotFound
 					},
 				},
@@ -632,6 +720,7 @@ func TestService_handlePatchDashboard(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewDashboardHandler()
 			h.DashboardService = tt.fields.DashboardService
+			h.UserResourceMappingService = memberMappingService()
 
 			upd := platform.DashboardUpdate{}
 			if tt.args.name != "" {
@@ -657,7 +746,7 @@ func TestService_handlePatchDashboard(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
-			h.handlePatchDashboard(w, r)
+			h.handlePatchDashboard(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			content := res.Header.Get("Content-Type")
@@ -679,10 +768,12 @@ func TestService_handlePatchDashboard(t *testing.T) {
 func TestService_handlePostDashboardCell(t *testing.T) {
 	type fields struct {
 		DashboardService platform.DashboardService
+		ViewService      platform.ViewService
 	}
 	type args struct {
-		id   string
-		cell *platform.Cell
+		id        string
+		cell      *platform.Cell
+		usingView platform.ID
 	}
 	type wants struct {
 		statusCode  int
@@ -700,11 +791,22 @@ func TestService_handlePostDashboardCell(t *testing.T) {
 			name: "create a dashboard cell",
 			fields: fields{
 				&mock.DashboardService{
+					FindDashboardByIDF: func(ctx context.Context, id platform.ID) (*platform.Dashboard, error) {
+						return &platform.Dashboard{
+							ID:             id,
+							OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+						}, nil
+					},
 					AddDashboardCellF: func(ctx context.Context, id platform.ID, c *platform.Cell, opt platform.AddDashboardCellOptions) error {
 						c.ID = platformtesting.MustIDBase16("020f755c3c082000")
 						return nil
 					},
 				},
+				&mock.ViewService{
+					FindViewByIDF: func(ctx context.Context, id platform.ID) (*platform.View, error) {
+						return &platform.View{ViewContents: platform.ViewContents{ID: id, OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed")}}, nil
+					},
+				},
 			},
 			args: args{
 				id: "020f755c3c082000",
@@ -734,17 +836,52 @@ func TestService_handlePostDashboardCell(t *testing.T) {
 `,
 			},
 		},
+		{
+			name: "create a dashboard cell using a view of another organization",
+			fields: fields{
+				&mock.DashboardService{
+					FindDashboardByIDF: func(ctx context.Context, id platform.ID) (*platform.Dashboard, error) {
+						return &platform.Dashboard{
+							ID:             id,
+							OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+						}, nil
+					},
+					AddDashboardCellF: func(ctx context.Context, id platform.ID, c *platform.Cell, opt platform.AddDashboardCellOptions) error {
+						t.Error("expected the cell not to be added")
+						return nil
+					},
+				},
+				&mock.ViewService{
+					FindViewByIDF: func(ctx context.Context, id platform.ID) (*platform.View, error) {
+						return &platform.View{ViewContents: platform.ViewContents{ID: id, OrganizationID: platformtesting.MustIDBase16("020f755c3c082999")}}, nil
+					},
+				},
+			},
+			args: args{
+				id:        "020f755c3c082000",
+				cell:      &platform.Cell{ID: platformtesting.MustIDBase16("020f755c3c082000"), ViewID: platformtesting.MustIDBase16("da7aba5e5d81e550")},
+				usingView: platformtesting.MustIDBase16("da7aba5e5d81e550"),
+			},
+			wants: wants{
+				statusCode: http.StatusUnprocessableEntity,
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewDashboardHandler()
 			h.DashboardService = tt.fields.DashboardService
+			h.ViewService = tt.fields.ViewService
+			h.UserResourceMappingService = memberMappingService()
 
 			b, err := json.Marshal(tt.args.cell)
 			if err != nil {
 				t.Fatalf("failed to unmarshal cell: %v", err)
 			}
+			if tt.args.usingView.Valid() {
+				b = bytes.Replace(b, []byte("{"), []byte(`{"usingView":"`+tt.args.usingView.String()+`",`), 1)
+			}
 
 			r := httptest.NewRequest("GET", "http://any.url", bytes.NewReader(b))
 
@@ -760,7 +897,7 @@ func TestService_handlePostDashboardCell(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
-			h.handlePostDashboardCell(w, r)
+			h.handlePostDashboardCell(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			content := res.Header.Get("Content-Type")
@@ -803,6 +940,12 @@ func TestService_handleDeleteDashboardCell(t *testing.T) {
 			name: "remove a dashboard cell",
 			fields: fields{
 				&mock.DashboardService{
+					FindDashboardByIDF: func(ctx context.Context, id platform.ID) (*platform.Dashboard, error) {
+						return &platform.Dashboard{
+							ID:             id,
+							OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+						}, nil
+					},
 					RemoveDashboardCellF: func(ctx context.Context, id platform.ID, cellID platform.ID) error {
 						return nil
 					},
@@ -822,6 +965,7 @@ func TestService_handleDeleteDashboardCell(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewDashboardHandler()
 			h.DashboardService = tt.fields.DashboardService
+			h.UserResourceMappingService = memberMappingService()
 
 			r := httptest.NewRequest("GET", "http://any.url", nil)
 
@@ -841,7 +985,7 @@ func TestService_handleDeleteDashboardCell(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
-			h.handleDeleteDashboardCell(w, r)
+			h.handleDeleteDashboardCell(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			content := res.Header.Get("Content-Type")
@@ -889,6 +1033,12 @@ func TestService_handlePatchDashboardCell(t *testing.T) {
 			name: "update a dashboard cell",
 			fields: fields{
 				&mock.DashboardService{
+					FindDashboardByIDF: func(ctx context.Context, id platform.ID) (*platform.Dashboard, error) {
+						return &platform.Dashboard{
+							ID:             id,
+							OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+						}, nil
+					},
 					UpdateDashboardCellF: func(ctx context.Context, id, cellID platform.ID, upd platform.CellUpdate) (*platform.Cell, error) {
 						cell := &platform.Cell{
 							ID:     platformtesting.MustIDBase16("020f755c3c082000"),
@@ -935,6 +1085,7 @@ func TestService_handlePatchDashboardCell(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewDashboardHandler()
 			h.DashboardService = tt.fields.DashboardService
+			h.UserResourceMappingService = memberMappingService()
 
 			upd := platform.CellUpdate{}
 			if tt.args.x != 0 {
@@ -976,7 +1127,7 @@ func TestService_handlePatchDashboardCell(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
-			h.handlePatchDashboardCell(w, r)
+			h.handlePatchDashboardCell(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			content := res.Header.Get("Content-Type")
@@ -1029,10 +1180,17 @@ func initDashboardService(f platformtesting.DashboardFields, t *testing.T) (plat
 			t.Fatalf("failed to populate views")
 		}
 	}
+	for _, m := range f.UserResourceMappings {
+		if err := svc.PutUserResourceMapping(ctx, m); err != nil {
+			t.Fatalf("failed to populate user resource mappings")
+		}
+	}
 
 	handler := NewDashboardHandler()
 	handler.DashboardService = svc
-	server := httptest.NewServer(handler)
+	handler.ViewService = svc
+	handler.UserResourceMappingService = memberMappingService()
+	server := httptest.NewServer(authorizedHandler(handler))
 	client := DashboardService{
 		Addr: server.URL,
 	}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/influxdata/platform"
 	kerrors "github.com/influxdata/platform/kit/errors"
 	"github.com/pkg/errors"
 )
@@ -73,6 +74,14 @@ func CheckError(resp *http.Response) error {
 	return errors.Wrap(errors.New(resp.Status), msg)
 }
 
+// platformErrorReferences are the reference codes of the responses to platform errors, by error code.
+var platformErrorReferences = map[string]int{
+	platform.ENotFound:   kerrors.NotFound,
+	platform.EInvalid:    kerrors.InvalidData,
+	platform.EEmptyValue: kerrors.InvalidData,
+	platform.EConflict:   kerrors.InvalidData,
+}
+
 // EncodeError encodes err with the appropriate status code and format,
 // sets the X-Influx-Error and X-Influx-Reference headers on the response,
 // and sets the response status to the corresponding status code.
@@ -80,24 +89,34 @@ func EncodeError(ctx context.Context, err error, w http.ResponseWriter) {
 	if err == nil {
 		return
 	}
+	e := kitError(err)
+	if len(e.Err) > errorHeaderMaxLength {
+		e.Err = e.Err[0:errorHeaderMaxLength]
+	}
+	code := statusCode(e)
+
+	w.Header().Set(ErrorHeader, e.Err)
+	w.Header().Set(ReferenceHeader, strconv.Itoa(e.Reference))
+	w.WriteHeader(code)
+}
+
+// kitError converts err to the error that is encoded in the response.
+func kitError(err error) kerrors.Error {
 	e, ok := err.(kerrors.Error)
 	if !ok {
+		ref, ok := platformErrorReferences[platform.ErrorCode(err)]
+		if !ok {
+			ref = kerrors.InternalError
+		}
 		e = kerrors.Error{
-			Reference: kerrors.InternalError,
+			Reference: ref,
 			Err:       err.Error(),
 		}
 	}
 	if e.Reference == 0 {
 		e.Reference = kerrors.InternalError
 	}
-	if len(e.Err) > errorHeaderMaxLength {
-		e.Err = e.Err[0:errorHeaderMaxLength]
-	}
-	code := statusCode(e)
-
-	w.Header().Set(ErrorHeader, e.Err)
-	w.Header().Set(ReferenceHeader, strconv.Itoa(e.Reference))
-	w.WriteHeader(code)
+	return e
 }
 
 // ForbiddenError encodes error with a forbidden status code.
@@ -121,6 +140,8 @@ func statusCode(e kerrors.Error) int {
 		return http.StatusForbidden
 	case kerrors.NotFound:
 		return http.StatusNotFound
+	case kerrors.TooManyRequests:
+		return http.StatusTooManyRequests
 	default:
 		return http.StatusInternalServerError
 	}
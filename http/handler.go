@@ -24,6 +24,8 @@ const (
 	MetricsPath = "/metrics"
 	// HealthPath exposes the health of the service over /health.
 	HealthPath = "/health"
+	// ReadyPath exposes the readiness of the service over /ready.
+	ReadyPath = "/ready"
 	// DebugPath exposes /debug/pprof for go debugging.
 	DebugPath = "/debug"
 )
@@ -34,6 +36,8 @@ type Handler struct {
 	name string
 	// HealthHandler handles health requests
 	HealthHandler http.Handler
+	// ReadyHandler handles readiness requests
+	ReadyHandler http.Handler
 	// MetricsHandler handles metrics requests
 	MetricsHandler http.Handler
 	// DebugHandler handles debug requests
@@ -62,6 +66,8 @@ func NewHandler(name string) *Handler {
 		name:           name,
 		MetricsHandler: promhttp.Handler(),
 		DebugHandler:   http.DefaultServeMux,
+		HealthHandler:  http.HandlerFunc(HealthHandler),
+		ReadyHandler:   http.HandlerFunc(ReadyHandler),
 	}
 	h.initMetrics()
 	return h
@@ -76,6 +82,7 @@ func NewHandlerFromRegistry(name string, reg *prom.Registry) *Handler {
 		MetricsHandler: reg.HTTPHandler(),
 		DebugHandler:   http.DefaultServeMux,
 		HealthHandler:  http.HandlerFunc(HealthHandler),
+		ReadyHandler:   http.HandlerFunc(ReadyHandler),
 	}
 	h.initMetrics()
 	reg.MustRegister(h.PrometheusCollectors()...)
@@ -153,6 +160,8 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.MetricsHandler.ServeHTTP(w, r)
 	case r.URL.Path == HealthPath:
 		h.HealthHandler.ServeHTTP(w, r)
+	case r.URL.Path == ReadyPath:
+		h.ReadyHandler.ServeHTTP(w, r)
 	case strings.HasPrefix(r.URL.Path, DebugPath):
 		h.DebugHandler.ServeHTTP(w, r)
 	default:
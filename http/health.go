@@ -6,7 +6,15 @@ import (
 )
 
 // HealthHandler returns the status of the process.
+// Use the HealthHandler of a check.Check to report the status of the components of the process.
 func HealthHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	fmt.Fprintln(w, `{"message": "howdy y'all", "status": "healthy"}`)
 }
+
+// ReadyHandler returns the readiness of the process.
+// Use the ReadyHandler of a check.Check to wait for the components of the process to be ready.
+func ReadyHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintln(w, `{"message": "ready for queries and writes", "status": "healthy"}`)
+}
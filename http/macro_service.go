@@ -10,6 +10,8 @@ import (
 
 	"github.com/influxdata/platform"
 	kerrors "github.com/influxdata/platform/kit/errors"
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/influxql"
 	"github.com/julienschmidt/httprouter"
 )
 
@@ -21,7 +23,13 @@ const (
 type MacroHandler struct {
 	*httprouter.Router
 
-	MacroService platform.MacroService
+	MacroService               platform.MacroService
+	UserResourceMappingService platform.UserResourceMappingService
+	AuthorizationService       platform.AuthorizationService
+	ProxyQueryService          query.ProxyQueryService
+	DBRPMappingService         platform.DBRPMappingService
+	BucketService              platform.BucketService
+	SchemaQuerier              influxql.SchemaQuerier
 }
 
 // NewMacroHandler creates a new MacroHandler
@@ -36,6 +44,7 @@ func NewMacroHandler() *MacroHandler {
 	h.HandlerFunc("PATCH", "/api/v2/macros/:id", h.handlePatchMacro)
 	h.HandlerFunc("PUT", "/api/v2/macros/:id", h.handlePutMacro)
 	h.HandlerFunc("DELETE", "/api/v2/macros/:id", h.handleDeleteMacro)
+	h.HandlerFunc("POST", macrosIDValuesPath, h.handlePostMacroValues)
 
 	return h
 }
@@ -75,7 +84,18 @@ func newGetMacrosResponse(macros []*platform.Macro) getMacrosResponse {
 func (h *MacroHandler) handleGetMacros(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	macros, err := h.MacroService.FindMacros(ctx)
+	req, err := decodeGetMacrosRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, *req.filter.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	macros, err := h.MacroService.FindMacros(ctx, req.filter)
 	if err != nil {
 		EncodeError(ctx, kerrors.InternalErrorf("could not read macros: %v", err), w)
 		return
@@ -88,6 +108,49 @@ func (h *MacroHandler) handleGetMacros(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+type getMacrosRequest struct {
+	filter platform.MacroFilter
+}
+
+func decodeGetMacrosRequest(ctx context.Context, r *http.Request) (*getMacrosRequest, error) {
+	qp := r.URL.Query()
+	req := &getMacrosRequest{}
+
+	orgID, err := decodeRequiredOrganizationID(r)
+	if err != nil {
+		return nil, err
+	}
+	req.filter.OrganizationID = &orgID
+
+	if owner := qp.Get("owner"); owner != "" {
+		var i platform.ID
+		if err := i.DecodeFromString(owner); err != nil {
+			return nil, kerrors.InvalidDataf("invalid owner id: %v", err)
+		}
+		req.filter.Owner = &i
+	}
+
+	if prefix := qp.Get("prefix"); prefix != "" {
+		req.filter.NamePrefix = &prefix
+	}
+
+	return req, nil
+}
+
+// findAuthorizedMacro returns the macro unless the user making the request
+// is not a member of its organization.
+func (h *MacroHandler) findAuthorizedMacro(ctx context.Context, id platform.ID) (*platform.Macro, error) {
+	macro, err := h.MacroService.FindMacroByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, macro.OrganizationID); err != nil {
+		return nil, err
+	}
+	return macro, nil
+}
+
 func requestMacroID(ctx context.Context) (platform.ID, error) {
 	params := httprouter.ParamsFromContext(ctx)
 	urlID := params.ByName("id")
@@ -108,7 +171,7 @@ func (h *MacroHandler) handleGetMacro(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	macro, err := h.MacroService.FindMacroByID(ctx, id)
+	macro, err := h.findAuthorizedMacro(ctx, id)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -148,6 +211,11 @@ func (h *MacroHandler) handlePostMacro(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, req.macro.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	err = h.MacroService.CreateMacro(ctx, req.macro)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -166,9 +234,24 @@ type postMacroRequest struct {
 }
 
 func (r *postMacroRequest) Valid() error {
+	if !r.macro.OrganizationID.Valid() {
+		return fmt.Errorf("macro requires an organization")
+	}
+	if err := validateMacroName(r.macro.Name); err != nil {
+		return err
+	}
 	return r.macro.Valid()
 }
 
+// validateMacroName returns an error unless queries can reference the macro
+// by its name, that is v.<name> in Flux and :<name>: in InfluxQL.
+func validateMacroName(name string) error {
+	if !queryParamNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid macro name %q: must be an identifier", name)
+	}
+	return nil
+}
+
 func decodePostMacroRequest(ctx context.Context, r *http.Request) (*postMacroRequest, error) {
 	m := &platform.Macro{}
 
@@ -197,6 +280,11 @@ func (h *MacroHandler) handlePatchMacro(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if _, err := h.findAuthorizedMacro(ctx, req.id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	macro, err := h.MacroService.UpdateMacro(ctx, req.id, req.macroUpdate)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -216,6 +304,11 @@ type patchMacroRequest struct {
 }
 
 func (r *patchMacroRequest) Valid() error {
+	if r.macroUpdate.Name != "" {
+		if err := validateMacroName(r.macroUpdate.Name); err != nil {
+			return err
+		}
+	}
 	return r.macroUpdate.Valid()
 }
 
@@ -253,6 +346,19 @@ func (h *MacroHandler) handlePutMacro(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A macro may only be replaced by a member of both its current organization,
+	// if it exists, and its new one.
+	if existing, err := h.MacroService.FindMacroByID(ctx, req.macro.ID); err == nil {
+		if err := authorizeOrganization(ctx, h.UserResourceMappingService, existing.OrganizationID); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+	}
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, req.macro.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	err = h.MacroService.ReplaceMacro(ctx, req.macro)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -271,6 +377,12 @@ type putMacroRequest struct {
 }
 
 func (r *putMacroRequest) Valid() error {
+	if !r.macro.OrganizationID.Valid() {
+		return fmt.Errorf("macro requires an organization")
+	}
+	if err := validateMacroName(r.macro.Name); err != nil {
+		return err
+	}
 	return r.macro.Valid()
 }
 
@@ -302,6 +414,11 @@ func (h *MacroHandler) handleDeleteMacro(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if _, err := h.findAuthorizedMacro(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	err = h.MacroService.DeleteMacro(ctx, id)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -352,13 +469,25 @@ func (s *MacroService) FindMacroByID(ctx context.Context, id platform.ID) (*plat
 	return macro, nil
 }
 
-// FindMacros returns all macros in the store
-func (s *MacroService) FindMacros(ctx context.Context) ([]*platform.Macro, error) {
+// FindMacros returns all macros in the store that match filter, which requires an organization
+func (s *MacroService) FindMacros(ctx context.Context, filter platform.MacroFilter) ([]*platform.Macro, error) {
 	url, err := newURL(s.Addr, macroPath)
 	if err != nil {
 		return nil, err
 	}
 
+	qp := url.Query()
+	if filter.OrganizationID != nil {
+		qp.Set(OrgID, filter.OrganizationID.String())
+	}
+	if filter.Owner != nil {
+		qp.Set("owner", filter.Owner.String())
+	}
+	if filter.NamePrefix != nil {
+		qp.Set("prefix", *filter.NamePrefix)
+	}
+	url.RawQuery = qp.Encode()
+
 	req, err := http.NewRequest("GET", url.String(), nil)
 	if err != nil {
 		return nil, err
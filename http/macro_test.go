@@ -34,21 +34,23 @@ func TestMacroService_handleGetMacros(t *testing.T) {
 			name: "get all macros",
 			fields: fields{
 				&mock.MacroService{
-					FindMacrosF: func(ctx context.Context) ([]*platform.Macro, error) {
+					FindMacrosF: func(ctx context.Context, filter platform.MacroFilter) ([]*platform.Macro, error) {
 						return []*platform.Macro{
 							{
-								ID:       platformtesting.MustIDBase16("6162207574726f71"),
-								Name:     "macro-a",
-								Selected: []string{"b"},
+								ID:             platformtesting.MustIDBase16("6162207574726f71"),
+								OrganizationID: platformtesting.MustIDBase16("020f755c3c082000"),
+								Name:           "macro_a",
+								Selected:       []string{"b"},
 								Arguments: &platform.MacroArguments{
 									Type:   "constant",
 									Values: platform.MacroConstantValues{"a", "b"},
 								},
 							},
 							{
-								ID:       platformtesting.MustIDBase16("61726920617a696f"),
-								Name:     "macro-b",
-								Selected: []string{"c"},
+								ID:             platformtesting.MustIDBase16("61726920617a696f"),
+								OrganizationID: platformtesting.MustIDBase16("020f755c3c082000"),
+								Name:           "macro_b",
+								Selected:       []string{"c"},
 								Arguments: &platform.MacroArguments{
 									Type:   "map",
 									Values: platform.MacroMapValues{"a": "b", "c": "d"},
@@ -61,7 +63,7 @@ func TestMacroService_handleGetMacros(t *testing.T) {
 			wants: wants{
 				statusCode:  200,
 				contentType: "application/json; charset=utf-8",
-				body: `{"macros":[{"id":"6162207574726f71","name":"macro-a","selected":["b"],"arguments":{"type":"constant","values":["a","b"]},"links":{"self":"/api/v2/macros/6162207574726f71"}},{"id":"61726920617a696f","name":"macro-b","selected":["c"],"arguments":{"type":"map","values":{"a":"b","c":"d"}},"links":{"self":"/api/v2/macros/61726920617a696f"}}],"links":{"self":"/api/v2/macros"}}
+				body: `{"macros":[{"id":"6162207574726f71","organizationID":"020f755c3c082000","name":"macro_a","selected":["b"],"arguments":{"type":"constant","values":["a","b"]},"links":{"self":"/api/v2/macros/6162207574726f71"}},{"id":"61726920617a696f","organizationID":"020f755c3c082000","name":"macro_b","selected":["c"],"arguments":{"type":"map","values":{"a":"b","c":"d"}},"links":{"self":"/api/v2/macros/61726920617a696f"}}],"links":{"self":"/api/v2/macros"}}
 `,
 			},
 		},
@@ -71,10 +73,11 @@ func TestMacroService_handleGetMacros(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewMacroHandler()
 			h.MacroService = tt.fields.MacroService
-			r := httptest.NewRequest("GET", "http://howdy.tld", nil)
+			h.UserResourceMappingService = memberMappingService()
+			r := httptest.NewRequest("GET", "http://howdy.tld?organizationID=020f755c3c082000", nil)
 			w := httptest.NewRecorder()
 
-			h.handleGetMacros(w, r)
+			h.handleGetMacros(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			contentType := res.Header.Get("Content-Type")
@@ -123,9 +126,10 @@ func TestMacroService_handleGetMacro(t *testing.T) {
 				&mock.MacroService{
 					FindMacroByIDF: func(ctx context.Context, id platform.ID) (*platform.Macro, error) {
 						return &platform.Macro{
-							ID:       platformtesting.MustIDBase16("75650d0a636f6d70"),
-							Name:     "macro-a",
-							Selected: []string{"b"},
+							ID:             platformtesting.MustIDBase16("75650d0a636f6d70"),
+							OrganizationID: platformtesting.MustIDBase16("020f755c3c082000"),
+							Name:           "macro_a",
+							Selected:       []string{"b"},
 							Arguments: &platform.MacroArguments{
 								Type:   "constant",
 								Values: platform.MacroConstantValues{"a", "b"},
@@ -137,7 +141,7 @@ func TestMacroService_handleGetMacro(t *testing.T) {
 			wants: wants{
 				statusCode:  200,
 				contentType: "application/json; charset=utf-8",
-				body: `{"id":"75650d0a636f6d70","name":"macro-a","selected":["b"],"arguments":{"type":"constant","values":["a","b"]},"links":{"self":"/api/v2/macros/75650d0a636f6d70"}}
+				body: `{"id":"75650d0a636f6d70","organizationID":"020f755c3c082000","name":"macro_a","selected":["b"],"arguments":{"type":"constant","values":["a","b"]},"links":{"self":"/api/v2/macros/75650d0a636f6d70"}}
 `,
 			},
 		},
@@ -165,6 +169,7 @@ func TestMacroService_handleGetMacro(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewMacroHandler()
 			h.MacroService = tt.fields.MacroService
+			h.UserResourceMappingService = memberMappingService()
 			r := httptest.NewRequest("GET", "http://howdy.tld", nil)
 			r = r.WithContext(context.WithValue(
 				context.TODO(),
@@ -177,7 +182,7 @@ func TestMacroService_handleGetMacro(t *testing.T) {
 				}))
 			w := httptest.NewRecorder()
 
-			h.handleGetMacro(w, r)
+			h.handleGetMacro(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			contentType := res.Header.Get("Content-Type")
@@ -230,7 +235,8 @@ func TestMacroService_handlePostMacro(t *testing.T) {
 			args: args{
 				macro: `
 {
-  "name": "my-great-macro",
+  "organizationID": "020f755c3c082000",
+  "name": "my_great_macro",
   "arguments": {
     "type": "constant",
     "values": [
@@ -247,7 +253,7 @@ func TestMacroService_handlePostMacro(t *testing.T) {
 			wants: wants{
 				statusCode:  201,
 				contentType: "application/json; charset=utf-8",
-				body: `{"id":"75650d0a636f6d70","name":"my-great-macro","selected":["'foo'"],"arguments":{"type":"constant","values":["bar","foo"]},"links":{"self":"/api/v2/macros/75650d0a636f6d70"}}
+				body: `{"id":"75650d0a636f6d70","organizationID":"020f755c3c082000","name":"my_great_macro","selected":["'foo'"],"arguments":{"type":"constant","values":["bar","foo"]},"links":{"self":"/api/v2/macros/75650d0a636f6d70"}}
 `,
 			},
 		},
@@ -270,6 +276,35 @@ func TestMacroService_handlePostMacro(t *testing.T) {
 				body:        "",
 			},
 		},
+		{
+			name: "create a macro with a name that is not an identifier",
+			fields: fields{
+				&mock.MacroService{
+					CreateMacroF: func(ctx context.Context, m *platform.Macro) error {
+						m.ID = platformtesting.MustIDBase16("75650d0a636f6d70")
+						return nil
+					},
+				},
+			},
+			args: args{
+				macro: `
+{
+  "organizationID": "020f755c3c082000",
+  "name": "region} |> drop(columns: [\"_value\"]) |> yield(name: \"x\")",
+  "arguments": {
+    "type": "constant",
+    "values": ["us"]
+  },
+  "selected": ["us"]
+}
+`,
+			},
+			wants: wants{
+				statusCode:  422,
+				contentType: "",
+				body:        "",
+			},
+		},
 		{
 			name: "create a macro with invalid json",
 			fields: fields{
@@ -295,10 +330,11 @@ func TestMacroService_handlePostMacro(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewMacroHandler()
 			h.MacroService = tt.fields.MacroService
+			h.UserResourceMappingService = memberMappingService()
 			r := httptest.NewRequest("GET", "http://howdy.tld", bytes.NewReader([]byte(tt.args.macro)))
 			w := httptest.NewRecorder()
 
-			h.handlePostMacro(w, r)
+			h.handlePostMacro(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			contentType := res.Header.Get("Content-Type")
@@ -342,10 +378,17 @@ func TestMacroService_handlePatchMacro(t *testing.T) {
 			name: "update a macro name",
 			fields: fields{
 				&mock.MacroService{
+					FindMacroByIDF: func(ctx context.Context, id platform.ID) (*platform.Macro, error) {
+						return &platform.Macro{
+							ID:             id,
+							OrganizationID: platformtesting.MustIDBase16("020f755c3c082000"),
+						}, nil
+					},
 					UpdateMacroF: func(ctx context.Context, id platform.ID, u *platform.MacroUpdate) (*platform.Macro, error) {
 						return &platform.Macro{
-							ID:   platformtesting.MustIDBase16("75650d0a636f6d70"),
-							Name: "new-name",
+							ID:             platformtesting.MustIDBase16("75650d0a636f6d70"),
+							OrganizationID: platformtesting.MustIDBase16("020f755c3c082000"),
+							Name:           "new_name",
 							Arguments: &platform.MacroArguments{
 								Type:   "constant",
 								Values: platform.MacroConstantValues{},
@@ -357,12 +400,12 @@ func TestMacroService_handlePatchMacro(t *testing.T) {
 			},
 			args: args{
 				id:     "75650d0a636f6d70",
-				update: `{"name": "new-name"}`,
+				update: `{"name": "new_name"}`,
 			},
 			wants: wants{
 				statusCode:  200,
 				contentType: "application/json; charset=utf-8",
-				body: `{"id":"75650d0a636f6d70","name":"new-name","selected":[],"arguments":{"type":"constant","values":[]},"links":{"self":"/api/v2/macros/75650d0a636f6d70"}}
+				body: `{"id":"75650d0a636f6d70","organizationID":"020f755c3c082000","name":"new_name","selected":[],"arguments":{"type":"constant","values":[]},"links":{"self":"/api/v2/macros/75650d0a636f6d70"}}
 `,
 			},
 		},
@@ -387,6 +430,7 @@ func TestMacroService_handlePatchMacro(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewMacroHandler()
 			h.MacroService = tt.fields.MacroService
+			h.UserResourceMappingService = memberMappingService()
 			r := httptest.NewRequest("GET", "http://howdy.tld", bytes.NewReader([]byte(tt.args.update)))
 			r = r.WithContext(context.WithValue(
 				context.TODO(),
@@ -399,7 +443,7 @@ func TestMacroService_handlePatchMacro(t *testing.T) {
 				}))
 			w := httptest.NewRecorder()
 
-			h.handlePatchMacro(w, r)
+			h.handlePatchMacro(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			contentType := res.Header.Get("Content-Type")
@@ -441,6 +485,12 @@ func TestMacroService_handleDeleteMacro(t *testing.T) {
 			name: "delete a macro",
 			fields: fields{
 				&mock.MacroService{
+					FindMacroByIDF: func(ctx context.Context, id platform.ID) (*platform.Macro, error) {
+						return &platform.Macro{
+							ID:             id,
+							OrganizationID: platformtesting.MustIDBase16("020f755c3c082000"),
+						}, nil
+					},
 					DeleteMacroF: func(ctx context.Context, id platform.ID) error {
 						return nil
 					},
@@ -457,8 +507,8 @@ func TestMacroService_handleDeleteMacro(t *testing.T) {
 			name: "delete a non-existant macro",
 			fields: fields{
 				&mock.MacroService{
-					DeleteMacroF: func(ctx context.Context, id platform.ID) error {
-						return kerrors.Errorf(kerrors.NotFound, "macro with ID %v not found", id)
+					FindMacroByIDF: func(ctx context.Context, id platform.ID) (*platform.Macro, error) {
+						return nil, kerrors.Errorf(kerrors.NotFound, "macro with ID %v not found", id)
 					},
 				},
 			},
@@ -475,6 +525,7 @@ func TestMacroService_handleDeleteMacro(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewMacroHandler()
 			h.MacroService = tt.fields.MacroService
+			h.UserResourceMappingService = memberMappingService()
 			r := httptest.NewRequest("GET", "http://howdy.tld", nil)
 			r = r.WithContext(context.WithValue(
 				context.TODO(),
@@ -487,7 +538,7 @@ func TestMacroService_handleDeleteMacro(t *testing.T) {
 				}))
 			w := httptest.NewRecorder()
 
-			h.handleDeleteMacro(w, r)
+			h.handleDeleteMacro(w, withTestAuthorizer(r))
 
 			statusCode := w.Result().StatusCode
 
@@ -509,10 +560,16 @@ func initMacroService(f platformtesting.MacroFields, t *testing.T) (platform.Mac
 			t.Fatalf("failed to populate macros")
 		}
 	}
+	for _, m := range f.UserResourceMappings {
+		if err := svc.PutUserResourceMapping(ctx, m); err != nil {
+			t.Fatalf("failed to populate user resource mappings")
+		}
+	}
 
 	handler := NewMacroHandler()
 	handler.MacroService = svc
-	server := httptest.NewServer(handler)
+	handler.UserResourceMappingService = memberMappingService()
+	server := httptest.NewServer(authorizedHandler(handler))
 	client := MacroService{
 		Addr: server.URL,
 	}
@@ -0,0 +1,360 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/parser"
+	iql "github.com/influxdata/influxql"
+	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/influxql"
+)
+
+const (
+	macrosIDValuesPath = "/api/v2/macros/:id/values"
+)
+
+type postMacroValuesRequest struct {
+	// Selected overrides the selected values of the macros the macro depends on.
+	Selected map[string]string `json:"selected"`
+}
+
+type macroValuesLinks struct {
+	Self  string `json:"self"`
+	Macro string `json:"macro"`
+}
+
+type macroValuesResponse struct {
+	Values []string         `json:"values"`
+	Links  macroValuesLinks `json:"links"`
+}
+
+// handlePostMacroValues evaluates the macro and returns its values.
+func (h *MacroHandler) handlePostMacroValues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestMacroID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	req := &postMacroValuesRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil && err != io.EOF {
+		EncodeError(ctx, kerrors.MalformedDataf("%v", err), w)
+		return
+	}
+
+	macro, err := h.findAuthorizedMacro(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	auth, err := queryAuthorization(ctx, h.AuthorizationService)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	resolver, err := newMacroResolver(ctx, h.MacroService, h.ProxyQueryService, h.DBRPMappingService, h.BucketService, h.SchemaQuerier, auth, macro.OrganizationID, req.Selected)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	values, err := resolver.resolve(ctx, macro)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	res := macroValuesResponse{
+		Values: values,
+		Links: macroValuesLinks{
+			Self:  fmt.Sprintf("/api/v2/macros/%s/values", macro.ID),
+			Macro: fmt.Sprintf("/api/v2/macros/%s", macro.ID),
+		},
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// macroResolver evaluates the macros of an organization. The macros a query
+// macro depends on are evaluated first and their selected values substituted
+// into its query.
+type macroResolver struct {
+	proxyQueryService  query.ProxyQueryService
+	dbrpMappingService platform.DBRPMappingService
+	bucketService      platform.BucketService
+	schemaQuerier      influxql.SchemaQuerier
+	auth               *platform.Authorization
+	orgID              platform.ID
+	macros             map[string]*platform.Macro
+	selected           map[string]string
+
+	values    map[string][]string
+	resolving map[string]bool
+}
+
+func newMacroResolver(ctx context.Context, macroSvc platform.MacroService, proxySvc query.ProxyQueryService, dbrpSvc platform.DBRPMappingService, bucketSvc platform.BucketService, schemaQuerier influxql.SchemaQuerier, auth *platform.Authorization, orgID platform.ID, selected map[string]string) (*macroResolver, error) {
+	macros, err := macroSvc.FindMacros(ctx, platform.MacroFilter{OrganizationID: &orgID})
+	if err != nil {
+		return nil, err
+	}
+
+	r := &macroResolver{
+		proxyQueryService:  proxySvc,
+		dbrpMappingService: dbrpSvc,
+		bucketService:      bucketSvc,
+		schemaQuerier:      schemaQuerier,
+		auth:               auth,
+		orgID:              orgID,
+		macros:             make(map[string]*platform.Macro, len(macros)),
+		selected:           selected,
+		values:             map[string][]string{},
+		resolving:          map[string]bool{},
+	}
+	for _, m := range macros {
+		r.macros[m.Name] = m
+	}
+	return r, nil
+}
+
+// resolve returns the values of the macro.
+func (r *macroResolver) resolve(ctx context.Context, m *platform.Macro) ([]string, error) {
+	if values, ok := r.values[m.Name]; ok {
+		return values, nil
+	}
+	if r.resolving[m.Name] {
+		return nil, kerrors.InvalidDataf("macro %q depends on itself", m.Name)
+	}
+	r.resolving[m.Name] = true
+	defer delete(r.resolving, m.Name)
+
+	if m.Arguments == nil {
+		return nil, kerrors.InvalidDataf("macro %q has no arguments", m.Name)
+	}
+
+	var values []string
+	switch args := m.Arguments.Values.(type) {
+	case platform.MacroConstantValues:
+		values = append([]string{}, args...)
+	case platform.MacroMapValues:
+		for k := range args {
+			values = append(values, k)
+		}
+		sort.Strings(values)
+	case platform.MacroQueryValues:
+		var err error
+		if values, err = r.query(ctx, m, args); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, kerrors.InvalidDataf("macro %q has unsupported arguments %T", m.Name, args)
+	}
+
+	r.values[m.Name] = values
+	return values, nil
+}
+
+// selectedValue resolves the macro and returns the value to substitute for it.
+func (r *macroResolver) selectedValue(ctx context.Context, m *platform.Macro) (string, error) {
+	values, err := r.resolve(ctx, m)
+	if err != nil {
+		return "", err
+	}
+
+	contains := func(v string) bool {
+		for _, value := range values {
+			if value == v {
+				return true
+			}
+		}
+		return false
+	}
+
+	var selected string
+	switch {
+	case r.selected[m.Name] != "":
+		selected = r.selected[m.Name]
+		if !contains(selected) {
+			return "", kerrors.InvalidDataf("%q is not a value of macro %q", selected, m.Name)
+		}
+	case len(m.Selected) > 0 && contains(m.Selected[0]):
+		selected = m.Selected[0]
+	case len(values) > 0:
+		selected = values[0]
+	default:
+		return "", kerrors.InvalidDataf("macro %q has no values", m.Name)
+	}
+
+	if mapped, ok := m.Arguments.Values.(platform.MacroMapValues); ok {
+		return mapped[selected], nil
+	}
+	return selected, nil
+}
+
+// query runs the query of the macro once the macros it depends on are
+// substituted and returns the distinct values of its result column.
+func (r *macroResolver) query(ctx context.Context, m *platform.Macro, q platform.MacroQueryValues) ([]string, error) {
+	names := make([]string, 0, len(r.macros))
+	for name := range r.macros {
+		if m.UsesMacro(name) {
+			if err := validateMacroName(name); err != nil {
+				return nil, kerrors.InvalidDataf("macro %q depends on a macro it cannot reference: %v", m.Name, err)
+			}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	deps := make(map[string]string, len(names))
+	for _, name := range names {
+		v, err := r.selectedValue(ctx, r.macros[name])
+		if err != nil {
+			return nil, err
+		}
+		deps[name] = v
+	}
+
+	var compiler flux.Compiler
+	switch q.Language {
+	case "flux":
+		p, err := parser.NewAST(q.Query)
+		if err != nil {
+			return nil, kerrors.InvalidDataf("macro %q has an invalid flux query: %v", m.Name, err)
+		}
+		if len(names) > 0 {
+			obj := &ast.ObjectExpression{
+				Properties: make([]*ast.Property, 0, len(names)),
+			}
+			for _, name := range names {
+				obj.Properties = append(obj.Properties, &ast.Property{
+					Key:   &ast.Identifier{Name: name},
+					Value: &ast.StringLiteral{Value: deps[name]},
+				})
+			}
+			p = declareQueryParams(p, obj)
+		}
+		spec, err := toSpec(p, time.Now)
+		if err != nil {
+			return nil, kerrors.InvalidDataf("macro %q has an invalid flux query: %v", m.Name, err)
+		}
+		compiler = lang.SpecCompiler{Spec: spec}
+	case "influxql":
+		if r.dbrpMappingService == nil {
+			return nil, kerrors.InvalidDataf("influxql macros are not supported")
+		}
+		// The values of macros are string literals in InfluxQL queries.
+		text := q.Query
+		for _, name := range names {
+			text = strings.Replace(text, ":"+name+":", iql.QuoteString(deps[name]), -1)
+		}
+		c := influxql.NewCompiler(r.dbrpMappingService)
+		c.Query = text
+		c.OrganizationID = r.orgID
+		c.Authorization = r.auth
+		c.BucketService = r.bucketService
+		c.SchemaQuerier = r.schemaQuerier
+		compiler = c
+	default:
+		return nil, kerrors.InvalidDataf("macro %q has unsupported query language %q", m.Name, q.Language)
+	}
+
+	req := &query.ProxyRequest{
+		Request: query.Request{
+			Authorization:  r.auth,
+			OrganizationID: r.orgID,
+			Compiler:       compiler,
+		},
+		Dialect: csv.DefaultDialect(),
+	}
+
+	var buf bytes.Buffer
+	if _, err := r.proxyQueryService.Query(ctx, &buf, req); err != nil {
+		return nil, err
+	}
+
+	results, err := csv.NewMultiResultDecoder(csv.ResultDecoderConfig{}).Decode(ioutil.NopCloser(&buf))
+	if err != nil {
+		return nil, err
+	}
+	defer results.Cancel()
+
+	values := []string{}
+	seen := map[string]bool{}
+	for results.More() {
+		if err := results.Next().Tables().Do(func(tbl flux.Table) error {
+			j := macroValueColumn(tbl)
+			if j < 0 {
+				return nil
+			}
+			return tbl.Do(func(cr flux.ColReader) error {
+				for i := 0; i < cr.Len(); i++ {
+					v := macroColumnValue(cr, i, j)
+					if !seen[v] {
+						seen[v] = true
+						values = append(values, v)
+					}
+				}
+				return nil
+			})
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return values, results.Err()
+}
+
+// macroValueColumn returns the index of the _value column of the table or,
+// as for InfluxQL results, of the first column that is neither the time nor
+// part of the group key. It returns -1 if there is no such column.
+func macroValueColumn(tbl flux.Table) int {
+	cols := tbl.Cols()
+	if j := execute.ColIdx("_value", cols); j >= 0 {
+		return j
+	}
+	for j, c := range cols {
+		if c.Label != "_time" && !tbl.Key().HasCol(c.Label) {
+			return j
+		}
+	}
+	return -1
+}
+
+func macroColumnValue(cr flux.ColReader, i, j int) string {
+	switch cr.Cols()[j].Type {
+	case flux.TString:
+		return cr.Strings(j)[i]
+	case flux.TInt:
+		return strconv.FormatInt(cr.Ints(j)[i], 10)
+	case flux.TUInt:
+		return strconv.FormatUint(cr.UInts(j)[i], 10)
+	case flux.TFloat:
+		return strconv.FormatFloat(cr.Floats(j)[i], 'f', -1, 64)
+	case flux.TBool:
+		return strconv.FormatBool(cr.Bools(j)[i])
+	case flux.TTime:
+		return cr.Times(j)[i].Time().Format(time.RFC3339Nano)
+	}
+	return ""
+}
@@ -0,0 +1,301 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/inmem"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/influxql"
+	querymock "github.com/influxdata/platform/query/mock"
+)
+
+func TestMacroHandler_handlePostMacroValues(t *testing.T) {
+	finalizeTestBuiltIns()
+	orgID := platform.ID(0x020f755c3c082000)
+	fluxMacro := func(name, text string) *platform.Macro {
+		return &platform.Macro{
+			OrganizationID: orgID,
+			Name:           name,
+			Selected:       []string{"eu"},
+			Arguments: &platform.MacroArguments{
+				Type:   "query",
+				Values: platform.MacroQueryValues{Query: text, Language: "flux"},
+			},
+		}
+	}
+
+	// The query results by the kind of operation reading them; the host
+	// query returns duplicate values across tables.
+	results := map[flux.OperationKind]string{
+		transformations.KeepKind: `#datatype,string,long,string
+#group,false,false,false
+#default,_result,,
+,result,table,_value
+,,0,us
+,,0,eu
+,,0,"eu"" or true"
+`,
+		transformations.FilterKind: `#datatype,string,long,string,long
+#group,false,false,true,false
+#default,_result,,,
+,result,table,host,_value
+,,0,a,1
+,,0,a,2
+,,1,b,1
+`,
+	}
+
+	tests := []struct {
+		name       string
+		macros     []*platform.Macro
+		macro      string
+		body       string
+		statusCode int
+		values     []string
+		queries    []string
+	}{
+		{
+			name: "constant macro",
+			macros: []*platform.Macro{{
+				OrganizationID: orgID,
+				Name:           "host",
+				Selected:       []string{"a"},
+				Arguments:      &platform.MacroArguments{Type: "constant", Values: platform.MacroConstantValues{"a", "b"}},
+			}},
+			macro:      "host",
+			statusCode: http.StatusOK,
+			values:     []string{"a", "b"},
+		},
+		{
+			name: "map macro",
+			macros: []*platform.Macro{{
+				OrganizationID: orgID,
+				Name:           "host",
+				Selected:       []string{"b"},
+				Arguments:      &platform.MacroArguments{Type: "map", Values: platform.MacroMapValues{"b": "host-b", "a": "host-a"}},
+			}},
+			macro:      "host",
+			statusCode: http.StatusOK,
+			values:     []string{"a", "b"},
+		},
+		{
+			name: "query macro depending on the selected value of another",
+			macros: []*platform.Macro{
+				fluxMacro("region", `from(bucket: "b") |> keep(columns: ["region"])`),
+				fluxMacro("host", `from(bucket: "b") |> filter(fn: (r) => r.region == v.region)`),
+			},
+			macro:      "host",
+			statusCode: http.StatusOK,
+			values:     []string{"1", "2"},
+			queries: []string{
+				"keep",
+				`filter "eu"`,
+			},
+		},
+		{
+			name: "selected value overridden by the request",
+			macros: []*platform.Macro{
+				fluxMacro("region", `from(bucket: "b") |> keep(columns: ["region"])`),
+				fluxMacro("host", `from(bucket: "b") |> filter(fn: (r) => r.region == v.region)`),
+			},
+			macro:      "host",
+			body:       `{"selected": {"region": "us"}}`,
+			statusCode: http.StatusOK,
+			values:     []string{"1", "2"},
+			queries: []string{
+				"keep",
+				`filter "us"`,
+			},
+		},
+		{
+			name: "selected value that is flux",
+			macros: []*platform.Macro{
+				fluxMacro("region", `from(bucket: "b") |> keep(columns: ["region"])`),
+				fluxMacro("host", `from(bucket: "b") |> filter(fn: (r) => r.region == v.region)`),
+			},
+			macro:      "host",
+			body:       `{"selected": {"region": "eu\" or true"}}`,
+			statusCode: http.StatusOK,
+			values:     []string{"1", "2"},
+			queries: []string{
+				"keep",
+				`filter "eu\" or true"`,
+			},
+		},
+		{
+			name: "selected value that is not a value of the macro",
+			macros: []*platform.Macro{
+				fluxMacro("region", `from(bucket: "b") |> keep(columns: ["region"])`),
+				fluxMacro("host", `from(bucket: "b") |> filter(fn: (r) => r.region == v.region)`),
+			},
+			macro:      "host",
+			body:       `{"selected": {"region": "ap"}}`,
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "macros depending on each other",
+			macros: []*platform.Macro{
+				fluxMacro("region", `from(bucket: "b") |> filter(fn: (r) => r.host == v.host)`),
+				fluxMacro("host", `from(bucket: "b") |> filter(fn: (r) => r.region == v.region)`),
+			},
+			macro:      "host",
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := inmem.NewService()
+			var id platform.ID
+			for _, m := range tt.macros {
+				if err := svc.CreateMacro(ctx, m); err != nil {
+					t.Fatal(err)
+				}
+				if m.Name == tt.macro {
+					id = m.ID
+				}
+			}
+
+			var queries []string
+			h := NewMacroHandler()
+			h.MacroService = svc
+			h.UserResourceMappingService = memberMappingService()
+			h.AuthorizationService = &mock.AuthorizationService{
+				FindAuthorizationByIDFn: func(ctx context.Context, id platform.ID) (*platform.Authorization, error) {
+					return &platform.Authorization{ID: id, UserID: testUserID, Status: platform.Active}, nil
+				},
+			}
+			h.ProxyQueryService = &querymock.ProxyQueryService{
+				QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (int64, error) {
+					if req.Request.OrganizationID != orgID || req.Request.Authorization == nil {
+						t.Errorf("expected the query to run in the organization of the macro with the caller's authorization")
+					}
+					spec := req.Request.Compiler.(lang.SpecCompiler).Spec
+					for _, op := range spec.Operations {
+						res, ok := results[op.Spec.Kind()]
+						if !ok {
+							continue
+						}
+						q := string(op.Spec.Kind())
+						if f, ok := op.Spec.(*transformations.FilterOpSpec); ok {
+							var lits stringLiterals
+							semantic.Walk(&lits, f.Fn)
+							q += " " + strings.Join(lits, " ")
+						}
+						queries = append(queries, q)
+						n, err := io.WriteString(w, res)
+						return int64(n), err
+					}
+					return 0, nil
+				},
+			}
+
+			r := httptest.NewRequest("POST", "http://any.url/api/v2/macros/"+id.String()+"/values", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, withTestAuthorizer(r))
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.statusCode {
+				t.Fatalf("handlePostMacroValues() = %v, want %v: %s", res.StatusCode, tt.statusCode, res.Header.Get(ErrorHeader))
+			}
+			if tt.statusCode != http.StatusOK {
+				return
+			}
+
+			var got macroValuesResponse
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.Values, tt.values) {
+				t.Errorf("handlePostMacroValues() values = %v, want %v", got.Values, tt.values)
+			}
+			if !reflect.DeepEqual(queries, tt.queries) {
+				t.Errorf("handlePostMacroValues() queries = %q, want %q", queries, tt.queries)
+			}
+		})
+	}
+}
+
+// stringLiterals collects the quoted values of the string literals of a
+// semantic graph.
+type stringLiterals []string
+
+func (s *stringLiterals) Visit(n semantic.Node) semantic.Visitor {
+	if l, ok := n.(*semantic.StringLiteral); ok {
+		*s = append(*s, strconv.Quote(l.Value))
+	}
+	return s
+}
+
+func (s *stringLiterals) Done() {}
+
+func TestMacroHandler_handlePostMacroValues_InfluxQL(t *testing.T) {
+	ctx := context.Background()
+	orgID := platform.ID(0x020f755c3c082000)
+	svc := inmem.NewService()
+	region := &platform.Macro{
+		OrganizationID: orgID,
+		Name:           "region",
+		Selected:       []string{`eu' OR 'a' = 'a`},
+		Arguments:      &platform.MacroArguments{Type: "constant", Values: platform.MacroConstantValues{"us", `eu' OR 'a' = 'a`}},
+	}
+	host := &platform.Macro{
+		OrganizationID: orgID,
+		Name:           "host",
+		Arguments: &platform.MacroArguments{
+			Type:   "query",
+			Values: platform.MacroQueryValues{Query: `SHOW TAG VALUES FROM cpu WITH KEY = host WHERE region = :region:`, Language: "influxql"},
+		},
+	}
+	for _, m := range []*platform.Macro{region, host} {
+		if err := svc.CreateMacro(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var text string
+	h := NewMacroHandler()
+	h.MacroService = svc
+	h.DBRPMappingService = mock.NewDBRPMappingService()
+	h.UserResourceMappingService = memberMappingService()
+	h.AuthorizationService = &mock.AuthorizationService{
+		FindAuthorizationByIDFn: func(ctx context.Context, id platform.ID) (*platform.Authorization, error) {
+			return &platform.Authorization{ID: id, UserID: testUserID, Status: platform.Active}, nil
+		},
+	}
+	h.ProxyQueryService = &querymock.ProxyQueryService{
+		QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (int64, error) {
+			text = req.Request.Compiler.(*influxql.Compiler).Query
+			return 0, nil
+		},
+	}
+
+	r := httptest.NewRequest("POST", "http://any.url/api/v2/macros/"+host.ID.String()+"/values", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, withTestAuthorizer(r))
+
+	if res := w.Result(); res.StatusCode != http.StatusOK {
+		t.Fatalf("handlePostMacroValues() = %v, want %v: %s", res.StatusCode, http.StatusOK, res.Header.Get(ErrorHeader))
+	}
+	if want := `SHOW TAG VALUES FROM cpu WITH KEY = host WHERE region = 'eu\' OR \'a\' = \'a'`; text != want {
+		t.Errorf("handlePostMacroValues() query = %q, want %q", text, want)
+	}
+}
@@ -0,0 +1,249 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	notificationEndpointsPath   = "/api/v2/notificationEndpoints"
+	notificationEndpointsIDPath = "/api/v2/notificationEndpoints/:id"
+)
+
+// NotificationEndpointHandler is the handler for the notification endpoint service.
+type NotificationEndpointHandler struct {
+	*httprouter.Router
+
+	NotificationEndpointService platform.NotificationEndpointService
+	UserResourceMappingService  platform.UserResourceMappingService
+}
+
+// NewNotificationEndpointHandler creates a new NotificationEndpointHandler.
+func NewNotificationEndpointHandler() *NotificationEndpointHandler {
+	h := &NotificationEndpointHandler{
+		Router: httprouter.New(),
+	}
+
+	h.HandlerFunc("GET", notificationEndpointsPath, h.handleGetNotificationEndpoints)
+	h.HandlerFunc("POST", notificationEndpointsPath, h.handlePostNotificationEndpoint)
+	h.HandlerFunc("GET", notificationEndpointsIDPath, h.handleGetNotificationEndpoint)
+	h.HandlerFunc("PATCH", notificationEndpointsIDPath, h.handlePatchNotificationEndpoint)
+	h.HandlerFunc("DELETE", notificationEndpointsIDPath, h.handleDeleteNotificationEndpoint)
+
+	return h
+}
+
+type notificationEndpointLinks struct {
+	Self string `json:"self"`
+}
+
+type notificationEndpointResponse struct {
+	*platform.NotificationEndpoint
+	Links notificationEndpointLinks `json:"links"`
+}
+
+// newNotificationEndpointResponse leaves out the secrets of e, which are only accepted on write.
+func newNotificationEndpointResponse(e *platform.NotificationEndpoint) notificationEndpointResponse {
+	return notificationEndpointResponse{
+		NotificationEndpoint: e.Redacted(),
+		Links: notificationEndpointLinks{
+			Self: fmt.Sprintf("%s/%s", notificationEndpointsPath, e.ID),
+		},
+	}
+}
+
+type getNotificationEndpointsResponse struct {
+	NotificationEndpoints []notificationEndpointResponse `json:"notificationEndpoints"`
+	Links                 notificationEndpointLinks      `json:"links"`
+}
+
+func newGetNotificationEndpointsResponse(endpoints []*platform.NotificationEndpoint) getNotificationEndpointsResponse {
+	resp := getNotificationEndpointsResponse{
+		NotificationEndpoints: make([]notificationEndpointResponse, 0, len(endpoints)),
+		Links: notificationEndpointLinks{
+			Self: notificationEndpointsPath,
+		},
+	}
+	for _, e := range endpoints {
+		resp.NotificationEndpoints = append(resp.NotificationEndpoints, newNotificationEndpointResponse(e))
+	}
+	return resp
+}
+
+func (h *NotificationEndpointHandler) handleGetNotificationEndpoints(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := decodeRequiredOrganizationID(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, orgID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	endpoints, err := h.NotificationEndpointService.FindNotificationEndpoints(ctx, platform.NotificationEndpointFilter{OrganizationID: &orgID})
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newGetNotificationEndpointsResponse(endpoints)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// findAuthorizedNotificationEndpoint returns the notification endpoint unless the user making the request
+// is not a member of its organization.
+func (h *NotificationEndpointHandler) findAuthorizedNotificationEndpoint(ctx context.Context, id platform.ID) (*platform.NotificationEndpoint, error) {
+	e, err := h.NotificationEndpointService.FindNotificationEndpointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, e.OrganizationID); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func requestNotificationEndpointID(ctx context.Context) (platform.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	urlID := params.ByName("id")
+	if urlID == "" {
+		return platform.InvalidID(), kerrors.InvalidDataf("url missing id")
+	}
+
+	var id platform.ID
+	if err := id.DecodeFromString(urlID); err != nil {
+		return platform.InvalidID(), kerrors.InvalidDataf("invalid notification endpoint id: %v", err)
+	}
+	return id, nil
+}
+
+func (h *NotificationEndpointHandler) handleGetNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestNotificationEndpointID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	e, err := h.findAuthorizedNotificationEndpoint(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationEndpointResponse(e)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *NotificationEndpointHandler) handlePostNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	e := &platform.NotificationEndpoint{}
+	if err := json.NewDecoder(r.Body).Decode(e); err != nil {
+		EncodeError(ctx, kerrors.MalformedDataf("%v", err), w)
+		return
+	}
+	if !e.OrganizationID.Valid() {
+		EncodeError(ctx, kerrors.InvalidDataf("notification endpoint requires an organization"), w)
+		return
+	}
+	if err := e.Valid(); err != nil {
+		EncodeError(ctx, kerrors.InvalidDataf("%v", err), w)
+		return
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, e.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.NotificationEndpointService.CreateNotificationEndpoint(ctx, e); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newNotificationEndpointResponse(e)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *NotificationEndpointHandler) handlePatchNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestNotificationEndpointID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	upd := platform.NotificationEndpointUpdate{}
+	if err := json.NewDecoder(r.Body).Decode(&upd); err != nil {
+		EncodeError(ctx, kerrors.MalformedDataf("%v", err), w)
+		return
+	}
+	if err := upd.Valid(); err != nil {
+		EncodeError(ctx, kerrors.InvalidDataf("%v", err), w)
+		return
+	}
+
+	existing, err := h.findAuthorizedNotificationEndpoint(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	upd.Apply(existing)
+	if err := existing.Valid(); err != nil {
+		EncodeError(ctx, kerrors.InvalidDataf("%v", err), w)
+		return
+	}
+
+	e, err := h.NotificationEndpointService.UpdateNotificationEndpoint(ctx, id, upd)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationEndpointResponse(e)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *NotificationEndpointHandler) handleDeleteNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestNotificationEndpointID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if _, err := h.findAuthorizedNotificationEndpoint(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.NotificationEndpointService.DeleteNotificationEndpoint(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,102 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/inmem"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func TestNotificationEndpointHandler_Secrets(t *testing.T) {
+	orgID := platformtesting.MustIDBase16("020f755c3c082000")
+
+	svc := inmem.NewService()
+	h := NewNotificationEndpointHandler()
+	h.NotificationEndpointService = svc
+	h.UserResourceMappingService = memberMappingService()
+	server := httptest.NewServer(authorizedHandler(h))
+	defer server.Close()
+
+	do := func(method, path string, body interface{}, wantCode int, out interface{}) {
+		t.Helper()
+		var b bytes.Buffer
+		if body != nil {
+			if err := json.NewEncoder(&b).Encode(body); err != nil {
+				t.Fatal(err)
+			}
+		}
+		req, err := http.NewRequest(method, server.URL+path, &b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != wantCode {
+			t.Fatalf("%s %s: expected status %d, got %d", method, path, wantCode, resp.StatusCode)
+		}
+		if out != nil {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	const hookURL = "https://hooks.slack.com/services/T0/B0/secret"
+	redacted := func(t *testing.T, e platform.NotificationEndpoint) {
+		t.Helper()
+		if e.URL != "" {
+			t.Fatalf("expected the url of a slack endpoint to be left out, got %q", e.URL)
+		}
+		if want := map[string]string{"Authorization": ""}; !reflect.DeepEqual(e.Headers, want) {
+			t.Fatalf("expected header values to be left out, got %v", e.Headers)
+		}
+	}
+
+	var created platform.NotificationEndpoint
+	do("POST", notificationEndpointsPath, platform.NotificationEndpoint{
+		OrganizationID: orgID,
+		Name:           "ops",
+		Type:           platform.SlackEndpointType,
+		URL:            hookURL,
+		Headers:        map[string]string{"Authorization": "Bearer secret"},
+	}, http.StatusCreated, &created)
+	redacted(t, created)
+
+	var got platform.NotificationEndpoint
+	do("GET", notificationEndpointsPath+"/"+created.ID.String(), nil, http.StatusOK, &got)
+	redacted(t, got)
+
+	var list getNotificationEndpointsResponse
+	do("GET", notificationEndpointsPath+"?"+OrgID+"="+orgID.String(), nil, http.StatusOK, &list)
+	if len(list.NotificationEndpoints) != 1 {
+		t.Fatalf("expected 1 notification endpoint, got %d", len(list.NotificationEndpoints))
+	}
+	redacted(t, *list.NotificationEndpoints[0].NotificationEndpoint)
+
+	// Writing back what was read keeps the secrets.
+	name := "oncall"
+	do("PATCH", notificationEndpointsPath+"/"+created.ID.String(), platform.NotificationEndpointUpdate{
+		Name:    &name,
+		URL:     &got.URL,
+		Headers: got.Headers,
+	}, http.StatusOK, &got)
+	redacted(t, got)
+
+	stored, err := svc.FindNotificationEndpointByID(context.Background(), created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Name != name || stored.URL != hookURL || stored.Headers["Authorization"] != "Bearer secret" {
+		t.Fatalf("expected the update to keep the secrets, got %+v", stored)
+	}
+}
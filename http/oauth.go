@@ -0,0 +1,208 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/chronograf/oauth2"
+	"go.uber.org/zap"
+)
+
+var _ oauth2.Authenticator = (*OAuthAuthenticator)(nil)
+
+// OAuthAuthenticator signs in the users authenticated by an OAuth2 provider
+// with a platform session. Users are created the first time they sign in.
+type OAuthAuthenticator struct {
+	Logger *zap.Logger
+
+	UserService                platform.UserService
+	UserIdentityService        platform.UserIdentityService
+	SessionService             platform.SessionService
+	OrganizationService        platform.OrganizationService
+	UserResourceMappingService platform.UserResourceMappingService
+
+	// GroupOrganizations maps the groups a provider reports a user belongs to,
+	// such as the organizations of a GitHub user or the domain of a Google user,
+	// to the names of the organizations the user is made a member of.
+	GroupOrganizations map[string]string
+
+	// SecureCookie restricts the session cookie to HTTPS, which must be set
+	// whenever influxd is reached by browsers over HTTPS.
+	SecureCookie bool
+}
+
+// Validate returns the principal of the session of the request.
+func (a *OAuthAuthenticator) Validate(ctx context.Context, r *http.Request) (oauth2.Principal, error) {
+	key, err := decodeCookieSession(ctx, r)
+	if err != nil {
+		return oauth2.Principal{}, oauth2.ErrAuthentication
+	}
+
+	s, err := a.SessionService.FindSession(ctx, key)
+	if err != nil || s.Expired() != nil {
+		return oauth2.Principal{}, oauth2.ErrAuthentication
+	}
+
+	u, err := a.UserService.FindUserByID(ctx, s.UserID)
+	if err != nil {
+		return oauth2.Principal{}, oauth2.ErrAuthentication
+	}
+
+	return oauth2.Principal{
+		Subject:   u.Name,
+		IssuedAt:  s.CreatedAt,
+		ExpiresAt: s.ExpiresAt,
+	}, nil
+}
+
+// Authorize finds or creates the user of the principal, makes it a member of
+// the organizations of its groups and sets a session for it on the response.
+func (a *OAuthAuthenticator) Authorize(ctx context.Context, w http.ResponseWriter, p oauth2.Principal) error {
+	u, err := a.findOrCreateUser(ctx, p.Issuer, p.Subject)
+	if err != nil {
+		return err
+	}
+
+	if err := a.addMemberships(ctx, u, p.Group); err != nil {
+		return err
+	}
+
+	s, err := a.SessionService.CreateSession(ctx, u.Name)
+	if err != nil {
+		return err
+	}
+
+	// The callback is served beneath the provider's path, so the cookie is
+	// set for the whole API rather than for the path of the callback.
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookieSessionName,
+		Value:    s.Key,
+		Path:     "/",
+		Expires:  s.ExpiresAt,
+		HttpOnly: true,
+		Secure:   a.SecureCookie,
+		// The cookie must be sent along the redirect of the callback, which
+		// is a navigation from the provider's site.
+		SameSite: http.SameSiteLaxMode,
+	})
+	return nil
+}
+
+// Extend returns the principal as is; sessions expire at a fixed time.
+func (a *OAuthAuthenticator) Extend(ctx context.Context, w http.ResponseWriter, p oauth2.Principal) (oauth2.Principal, error) {
+	return p, nil
+}
+
+// Expire removes the session cookie from the browser.
+func (a *OAuthAuthenticator) Expire(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookieSessionName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   a.SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// findOrCreateUser returns the user linked to the subject at the provider.
+// The first time a subject signs in, a user named after it is created and linked
+// to it. Existing users are never linked by name, since anyone may choose the
+// subject they are known by at some provider.
+func (a *OAuthAuthenticator) findOrCreateUser(ctx context.Context, provider, subject string) (*platform.User, error) {
+	if provider == "" || subject == "" {
+		return nil, fmt.Errorf("oauth principal has no issuer or subject")
+	}
+
+	i, err := a.UserIdentityService.FindUserIdentity(ctx, provider, subject)
+	if err == nil {
+		return a.UserService.FindUserByID(ctx, i.UserID)
+	}
+	if platform.ErrorCode(err) != platform.ENotFound {
+		return nil, err
+	}
+
+	// Creating the user fails if its name is taken.
+	u := &platform.User{Name: subject}
+	if err := a.UserService.CreateUser(ctx, u); err != nil {
+		return nil, err
+	}
+	if err := a.UserIdentityService.PutUserIdentity(ctx, &platform.UserIdentity{
+		Provider: provider,
+		Subject:  subject,
+		UserID:   u.ID,
+	}); err != nil {
+		return nil, err
+	}
+	a.Logger.Info("created user signed in with oauth", zap.String("user", subject), zap.String("provider", provider))
+	return u, nil
+}
+
+// addMemberships makes the user a member of the organizations mapped from the
+// comma separated groups. Organizations that do not exist are skipped.
+func (a *OAuthAuthenticator) addMemberships(ctx context.Context, u *platform.User, groups string) error {
+	for _, group := range strings.Split(groups, ",") {
+		name, ok := a.GroupOrganizations[strings.TrimSpace(group)]
+		if !ok {
+			continue
+		}
+
+		o, err := a.OrganizationService.FindOrganization(ctx, platform.OrganizationFilter{Name: &name})
+		if err != nil {
+			a.Logger.Info("skipping organization of oauth group", zap.String("group", group), zap.String("org", name), zap.Error(err))
+			continue
+		}
+
+		member, err := a.isMember(ctx, u.ID, o.ID)
+		if err != nil {
+			return err
+		}
+		if member {
+			continue
+		}
+
+		if err := a.UserResourceMappingService.CreateUserResourceMapping(ctx, &platform.UserResourceMapping{
+			ResourceID:   o.ID,
+			ResourceType: platform.OrgResourceType,
+			UserID:       u.ID,
+			UserType:     platform.Member,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isMember reports whether the user is a member or an owner of the organization.
+func (a *OAuthAuthenticator) isMember(ctx context.Context, userID, orgID platform.ID) (bool, error) {
+	mappings, _, err := a.UserResourceMappingService.FindUserResourceMappings(ctx, platform.UserResourceMappingFilter{
+		ResourceType: platform.OrgResourceType,
+		UserID:       userID,
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, m := range mappings {
+		if m.ResourceID == orgID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ParseGroupOrganizations parses a list of group=organization pairs.
+func ParseGroupOrganizations(pairs []string) (map[string]string, error) {
+	m := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("invalid group to organization mapping %q, expected group=organization", pair)
+		}
+		m[kv[0]] = kv[1]
+	}
+	return m, nil
+}
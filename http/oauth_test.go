@@ -0,0 +1,254 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/chronograf"
+	"github.com/influxdata/platform/chronograf/oauth2"
+	"github.com/influxdata/platform/inmem"
+	"github.com/influxdata/platform/mock"
+	"go.uber.org/zap"
+)
+
+// newFakeOAuthServer returns an OAuth2 server that authenticates any code as email.
+func newFakeOAuthServer(t *testing.T, email string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access-" + r.FormValue("code"),
+			"token_type":   "bearer",
+			"expires_in":   3600,
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-secret-code" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"email": email})
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestSessionHandler_OAuth(t *testing.T) {
+	provider := newFakeOAuthServer(t, "jane@example.com")
+	defer provider.Close()
+
+	ctx := context.Background()
+	svc := inmem.NewService()
+	org := &platform.Organization{Name: "example"}
+	if err := svc.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+
+	var sessionUser string
+	sessions := mock.NewSessionService()
+	sessions.CreateSessionFn = func(ctx context.Context, user string) (*platform.Session, error) {
+		sessionUser = user
+		return &platform.Session{Key: "session-key", ExpiresAt: time.Now().Add(time.Hour)}, nil
+	}
+
+	auth := &OAuthAuthenticator{
+		Logger:                     zap.NewNop(),
+		UserService:                svc,
+		UserIdentityService:        svc,
+		SessionService:             sessions,
+		OrganizationService:        svc,
+		UserResourceMappingService: svc,
+		GroupOrganizations:         map[string]string{"example.com": "example", "other.com": "missing"},
+	}
+	gen := &oauth2.Generic{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		AuthURL:      provider.URL + "/authorize",
+		TokenURL:     provider.URL + "/token",
+		APIURL:       provider.URL + "/userinfo",
+		APIKey:       "email",
+		RedirectURL:  "http://localhost:9999/api/v2/oauth/generic/callback",
+		Logger:       &chronograf.NoopLogger{},
+	}
+	tokens := oauth2.NewJWT("token-secret", "")
+
+	h := NewSessionHandler()
+	h.Logger = zap.NewNop()
+	h.SessionService = sessions
+	h.OAuthMuxes = map[string]oauth2.Mux{
+		"generic": oauth2.NewAuthMux(gen, auth, tokens, "", &chronograf.NoopLogger{}, false),
+	}
+
+	// The login redirects to the provider with a state to be sent back.
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "http://localhost:9999/api/v2/oauth/generic/login", nil))
+	if w.Code != http.StatusTemporaryRedirect {
+		t.Fatalf("login status = %d, want %d", w.Code, http.StatusTemporaryRedirect)
+	}
+	loc, err := url.Parse(w.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := loc.Query().Get("state")
+	if loc.Path != "/authorize" || state == "" {
+		t.Fatalf("unexpected provider redirect %s", loc)
+	}
+
+	// The provider calls back with a code that is exchanged for the user.
+	w = httptest.NewRecorder()
+	callback := "http://localhost:9999/api/v2/oauth/generic/callback?" + url.Values{"state": {state}, "code": {"secret-code"}}.Encode()
+	h.ServeHTTP(w, httptest.NewRequest("GET", callback, nil))
+	if w.Code != http.StatusTemporaryRedirect || w.Header().Get("Location") != "/" {
+		t.Fatalf("callback = %d to %q, want successful redirect", w.Code, w.Header().Get("Location"))
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != "session" || cookies[0].Value != "session-key" || cookies[0].Path != "/" || cookies[0].SameSite != http.SameSiteLaxMode {
+		t.Fatalf("unexpected session cookies %v", cookies)
+	}
+
+	name := "jane@example.com"
+	u, err := svc.FindUser(ctx, platform.UserFilter{Name: &name})
+	if err != nil {
+		t.Fatalf("expected user to be created: %v", err)
+	}
+	if sessionUser != name {
+		t.Fatalf("session created for %q, want %q", sessionUser, name)
+	}
+	if i, err := svc.FindUserIdentity(ctx, "generic", name); err != nil || i.UserID != u.ID {
+		t.Fatalf("expected user to be linked to its identity, got %+v, %v", i, err)
+	}
+	mappings, _, err := svc.FindUserResourceMappings(ctx, platform.UserResourceMappingFilter{UserID: u.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mappings) != 1 || mappings[0].ResourceID != org.ID || mappings[0].UserType != platform.Member {
+		t.Fatalf("unexpected memberships %+v", mappings)
+	}
+
+	// Signing in again finds the user and keeps its membership.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "http://localhost:9999/api/v2/oauth/generic/login", nil))
+	loc, _ = url.Parse(w.Header().Get("Location"))
+	w = httptest.NewRecorder()
+	callback = "http://localhost:9999/api/v2/oauth/generic/callback?" + url.Values{"state": {loc.Query().Get("state")}, "code": {"secret-code"}}.Encode()
+	h.ServeHTTP(w, httptest.NewRequest("GET", callback, nil))
+	if len(w.Result().Cookies()) != 1 {
+		t.Fatalf("expected session cookie on second sign in")
+	}
+	users, _, err := svc.FindUsers(ctx, platform.UserFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 {
+		t.Fatalf("got %d users, want 1", len(users))
+	}
+	if mappings, _, _ := svc.FindUserResourceMappings(ctx, platform.UserResourceMappingFilter{UserID: u.ID}); len(mappings) != 1 {
+		t.Fatalf("got %d memberships, want 1", len(mappings))
+	}
+
+	// A forged state is rejected.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "http://localhost:9999/api/v2/oauth/generic/callback?state=forged&code=secret-code", nil))
+	if w.Header().Get("Location") != "/login" || len(w.Result().Cookies()) != 0 {
+		t.Fatalf("expected forged state to fail, got redirect to %q", w.Header().Get("Location"))
+	}
+
+	// An unknown provider is not found.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "http://localhost:9999/api/v2/oauth/github/login", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("unknown provider status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestOAuthAuthenticator_Authorize(t *testing.T) {
+	ctx := context.Background()
+	svc := inmem.NewService()
+	admin := &platform.User{Name: "admin"}
+	if err := svc.CreateUser(ctx, admin); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions := mock.NewSessionService()
+	sessions.CreateSessionFn = func(ctx context.Context, user string) (*platform.Session, error) {
+		return &platform.Session{Key: "session-key", ExpiresAt: time.Now().Add(time.Hour)}, nil
+	}
+	auth := &OAuthAuthenticator{
+		Logger:                     zap.NewNop(),
+		UserService:                svc,
+		UserIdentityService:        svc,
+		SessionService:             sessions,
+		OrganizationService:        svc,
+		UserResourceMappingService: svc,
+		SecureCookie:               true,
+	}
+
+	// A subject named after a local user does not sign in as that user.
+	w := httptest.NewRecorder()
+	if err := auth.Authorize(ctx, w, oauth2.Principal{Issuer: "github", Subject: "admin"}); err == nil {
+		t.Fatal("expected sign in of a subject named after a local user to fail")
+	}
+	if len(w.Result().Cookies()) != 0 {
+		t.Fatal("expected no session cookie")
+	}
+	if _, err := svc.FindUserIdentity(ctx, "github", "admin"); platform.ErrorCode(err) != platform.ENotFound {
+		t.Fatalf("expected local user not to be linked, got %v", err)
+	}
+
+	// The same subject at two providers signs in two users.
+	w = httptest.NewRecorder()
+	if err := auth.Authorize(ctx, w, oauth2.Principal{Issuer: "github", Subject: "jane"}); err != nil {
+		t.Fatal(err)
+	}
+	if cookies := w.Result().Cookies(); len(cookies) != 1 || !cookies[0].Secure {
+		t.Fatalf("expected a secure session cookie, got %v", cookies)
+	}
+	if err := auth.Authorize(ctx, httptest.NewRecorder(), oauth2.Principal{Issuer: "google", Subject: "jane"}); err == nil {
+		t.Fatal("expected sign in of a subject linked at another provider to fail")
+	}
+}
+
+func TestSessionHandler_handleGetOAuthProviders(t *testing.T) {
+	h := NewSessionHandler()
+	h.OAuthMuxes = map[string]oauth2.Mux{
+		"google": &oauth2.AuthMux{},
+		"github": &oauth2.AuthMux{},
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "http://localhost:9999/api/v2/oauth", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	want := `
+{
+  "providers": [
+    {
+      "name": "github",
+      "links": {
+        "login": "/api/v2/oauth/github/login",
+        "callback": "/api/v2/oauth/github/callback",
+        "logout": "/api/v2/oauth/github/logout"
+      }
+    },
+    {
+      "name": "google",
+      "links": {
+        "login": "/api/v2/oauth/google/login",
+        "callback": "/api/v2/oauth/google/callback",
+        "logout": "/api/v2/oauth/google/logout"
+      }
+    }
+  ]
+}`
+	if eq, _ := jsonEqual(w.Body.String(), want); !eq {
+		t.Errorf("got %s, want %s", w.Body.String(), want)
+	}
+}
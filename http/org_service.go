@@ -17,9 +17,10 @@ import (
 type OrgHandler struct {
 	*httprouter.Router
 
-	OrganizationService        platform.OrganizationService
-	BucketService              platform.BucketService
-	UserResourceMappingService platform.UserResourceMappingService
+	OrganizationService         platform.OrganizationService
+	OrganizationSettingsService platform.OrganizationSettingsService
+	BucketService               platform.BucketService
+	UserResourceMappingService  platform.UserResourceMappingService
 }
 
 const (
@@ -29,6 +30,7 @@ const (
 	organizationsIDMembersIDPath = "/api/v2/orgs/:id/members/:userID"
 	organizationsIDOwnersPath    = "/api/v2/orgs/:id/owners"
 	organizationsIDOwnersIDPath  = "/api/v2/orgs/:id/owners/:userID"
+	organizationsIDSettingsPath  = "/api/v2/orgs/:id/settings"
 )
 
 // NewOrgHandler returns a new instance of OrgHandler.
@@ -51,6 +53,9 @@ func NewOrgHandler() *OrgHandler {
 	h.HandlerFunc("GET", organizationsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, platform.Owner))
 	h.HandlerFunc("DELETE", organizationsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
 
+	h.HandlerFunc("GET", organizationsIDSettingsPath, h.handleGetOrgSettings)
+	h.HandlerFunc("PATCH", organizationsIDSettingsPath, h.handlePatchOrgSettings)
+
 	return h
 }
 
@@ -90,6 +95,7 @@ func newOrgResponse(o *platform.Organization) *orgResponse {
 		Links: map[string]string{
 			"self":       fmt.Sprintf("/api/v2/orgs/%s", o.ID),
 			"members":    fmt.Sprintf("/api/v2/orgs/%s/members", o.ID),
+			"settings":   fmt.Sprintf("/api/v2/orgs/%s/settings", o.ID),
 			"buckets":    fmt.Sprintf("/api/v2/buckets?org=%s", o.Name),
 			"tasks":      fmt.Sprintf("/api/v2/tasks?org=%s", o.Name),
 			"dashboards": fmt.Sprintf("/api/v2/dashboards?org=%s", o.Name),
@@ -382,6 +388,182 @@ func (h *OrgHandler) handleDeleteOrgMember(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// orgSettings is used for serialization/deserialization with duration string syntax.
+type orgSettings struct {
+	QueryConcurrency      int    `json:"queryConcurrency"`
+	QueryQueueSize        int    `json:"queryQueueSize"`
+	QueryQueueTimeout     string `json:"queryQueueTimeout"`
+	QueryMemoryBytes      int64  `json:"queryMemoryBytes"`
+	TotalQueryMemoryBytes int64  `json:"totalQueryMemoryBytes"`
+}
+
+func (s *orgSettings) toPlatform() (*platform.OrganizationSettings, error) {
+	d, err := ParseDuration(s.QueryQueueTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &platform.OrganizationSettings{
+		QueryConcurrency:      s.QueryConcurrency,
+		QueryQueueSize:        s.QueryQueueSize,
+		QueryQueueTimeout:     d,
+		QueryMemoryBytes:      s.QueryMemoryBytes,
+		TotalQueryMemoryBytes: s.TotalQueryMemoryBytes,
+	}, nil
+}
+
+type orgSettingsResponse struct {
+	Links map[string]string `json:"links"`
+	orgSettings
+}
+
+func newOrgSettingsResponse(orgID platform.ID, s *platform.OrganizationSettings) *orgSettingsResponse {
+	return &orgSettingsResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/orgs/%s/settings", orgID),
+			"org":  fmt.Sprintf("/api/v2/orgs/%s", orgID),
+		},
+		orgSettings: orgSettings{
+			QueryConcurrency:      s.QueryConcurrency,
+			QueryQueueSize:        s.QueryQueueSize,
+			QueryQueueTimeout:     FormatDuration(s.QueryQueueTimeout),
+			QueryMemoryBytes:      s.QueryMemoryBytes,
+			TotalQueryMemoryBytes: s.TotalQueryMemoryBytes,
+		},
+	}
+}
+
+// orgSettingsUpdate is used for serialization/deserialization with duration string syntax.
+type orgSettingsUpdate struct {
+	QueryConcurrency      *int    `json:"queryConcurrency,omitempty"`
+	QueryQueueSize        *int    `json:"queryQueueSize,omitempty"`
+	QueryQueueTimeout     *string `json:"queryQueueTimeout,omitempty"`
+	QueryMemoryBytes      *int64  `json:"queryMemoryBytes,omitempty"`
+	TotalQueryMemoryBytes *int64  `json:"totalQueryMemoryBytes,omitempty"`
+}
+
+func (u *orgSettingsUpdate) toPlatform() (*platform.OrganizationSettingsUpdate, error) {
+	upd := &platform.OrganizationSettingsUpdate{
+		QueryConcurrency:      u.QueryConcurrency,
+		QueryQueueSize:        u.QueryQueueSize,
+		QueryMemoryBytes:      u.QueryMemoryBytes,
+		TotalQueryMemoryBytes: u.TotalQueryMemoryBytes,
+	}
+	if u.QueryQueueTimeout != nil {
+		d, err := ParseDuration(*u.QueryQueueTimeout)
+		if err != nil {
+			return nil, err
+		}
+		upd.QueryQueueTimeout = &d
+	}
+	return upd, nil
+}
+
+func newOrgSettingsUpdate(upd platform.OrganizationSettingsUpdate) *orgSettingsUpdate {
+	u := &orgSettingsUpdate{
+		QueryConcurrency:      upd.QueryConcurrency,
+		QueryQueueSize:        upd.QueryQueueSize,
+		QueryMemoryBytes:      upd.QueryMemoryBytes,
+		TotalQueryMemoryBytes: upd.TotalQueryMemoryBytes,
+	}
+	if upd.QueryQueueTimeout != nil {
+		d := FormatDuration(*upd.QueryQueueTimeout)
+		u.QueryQueueTimeout = &d
+	}
+	return u
+}
+
+// handleGetOrgSettings is the HTTP handler for the GET /api/v2/orgs/:id/settings route.
+func (h *OrgHandler) handleGetOrgSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetOrgRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, req.OrgID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	s, err := h.OrganizationSettingsService.FindOrganizationSettings(ctx, req.OrgID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newOrgSettingsResponse(req.OrgID, s)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handlePatchOrgSettings is the HTTP handler for the PATCH /api/v2/orgs/:id/settings route.
+// Only the owners of the organization may change its settings.
+func (h *OrgHandler) handlePatchOrgSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePatchOrgSettingsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorizeOrganizationOwner(ctx, h.UserResourceMappingService, req.OrgID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	s, err := h.OrganizationSettingsService.UpdateOrganizationSettings(ctx, req.OrgID, req.Update)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newOrgSettingsResponse(req.OrgID, s)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+type patchOrgSettingsRequest struct {
+	Update platform.OrganizationSettingsUpdate
+	OrgID  platform.ID
+}
+
+func decodePatchOrgSettingsRequest(ctx context.Context, r *http.Request) (*patchOrgSettingsRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return nil, kerrors.InvalidDataf("url missing id")
+	}
+
+	var i platform.ID
+	if err := i.DecodeFromString(id); err != nil {
+		return nil, err
+	}
+
+	u := &orgSettingsUpdate{}
+	if err := json.NewDecoder(r.Body).Decode(u); err != nil {
+		return nil, kerrors.MalformedDataf("invalid json: %v", err)
+	}
+
+	upd, err := u.toPlatform()
+	if err != nil {
+		return nil, kerrors.InvalidDataf("invalid query queue timeout: %v", err)
+	}
+	if err := upd.Valid(); err != nil {
+		return nil, kerrors.InvalidDataf("%v", err)
+	}
+
+	return &patchOrgSettingsRequest{
+		Update: *upd,
+		OrgID:  i,
+	}, nil
+}
+
 const (
 	organizationPath = "/api/v2/orgs"
 )
@@ -563,3 +745,78 @@ func (s *OrganizationService) DeleteOrganization(ctx context.Context, id platfor
 func organizationIDPath(id platform.ID) string {
 	return path.Join(organizationPath, id.String())
 }
+
+var _ platform.OrganizationSettingsService = (*OrganizationService)(nil)
+
+// FindOrganizationSettings returns the settings of an organization over HTTP.
+func (s *OrganizationService) FindOrganizationSettings(ctx context.Context, orgID platform.ID) (*platform.OrganizationSettings, error) {
+	u, err := newURL(s.Addr, organizationSettingsPath(orgID))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var os orgSettingsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&os); err != nil {
+		return nil, err
+	}
+	return os.toPlatform()
+}
+
+// UpdateOrganizationSettings updates the settings of an organization over HTTP.
+func (s *OrganizationService) UpdateOrganizationSettings(ctx context.Context, orgID platform.ID, upd platform.OrganizationSettingsUpdate) (*platform.OrganizationSettings, error) {
+	u, err := newURL(s.Addr, organizationSettingsPath(orgID))
+	if err != nil {
+		return nil, err
+	}
+
+	octets, err := json.Marshal(newOrgSettingsUpdate(upd))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var os orgSettingsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&os); err != nil {
+		return nil, err
+	}
+	return os.toPlatform()
+}
+
+func organizationSettingsPath(id platform.ID) string {
+	return path.Join(organizationPath, id.String(), "settings")
+}
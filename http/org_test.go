@@ -2,11 +2,16 @@ package http
 
 import (
 	"context"
+	"io/ioutil"
+	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
 	"github.com/influxdata/platform/inmem"
+	"github.com/influxdata/platform/mock"
 	platformtesting "github.com/influxdata/platform/testing"
 )
 
@@ -37,3 +42,91 @@ func TestOrganizationService(t *testing.T) {
 	t.Parallel()
 	platformtesting.OrganizationService(initOrganizationService, t)
 }
+
+func TestOrgHandler_handlePatchOrgSettings(t *testing.T) {
+	orgID := platformtesting.MustIDBase16("020f755c3c082000")
+	userID := platformtesting.MustIDBase16("020f755c3c082001")
+
+	tests := []struct {
+		name       string
+		userType   platform.UserType
+		body       string
+		statusCode int
+		response   string
+	}{
+		{
+			name:       "owners update the settings",
+			userType:   platform.Owner,
+			body:       `{"queryConcurrency": 4, "queryQueueTimeout": "1m"}`,
+			statusCode: http.StatusOK,
+			response: `
+{
+  "links": {
+    "self": "/api/v2/orgs/020f755c3c082000/settings",
+    "org": "/api/v2/orgs/020f755c3c082000"
+  },
+  "queryConcurrency": 4,
+  "queryQueueSize": 10,
+  "queryQueueTimeout": "1m",
+  "queryMemoryBytes": 0,
+  "totalQueryMemoryBytes": 0
+}`,
+		},
+		{
+			name:       "members may not update the settings",
+			userType:   platform.Member,
+			body:       `{"queryConcurrency": 4}`,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "limits must not be negative",
+			userType:   platform.Owner,
+			body:       `{"queryQueueSize": -1}`,
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewOrgHandler()
+			h.OrganizationSettingsService = &mock.OrganizationSettingsService{
+				UpdateOrganizationSettingsF: func(ctx context.Context, id platform.ID, upd platform.OrganizationSettingsUpdate) (*platform.OrganizationSettings, error) {
+					s := &platform.OrganizationSettings{QueryQueueSize: 10}
+					upd.Apply(s)
+					return s, nil
+				},
+			}
+			h.UserResourceMappingService = &mock.UserResourceMappingService{
+				FindMappingsF: func(ctx context.Context, filter platform.UserResourceMappingFilter) ([]*platform.UserResourceMapping, int, error) {
+					if filter.ResourceID != orgID || filter.UserID != userID || (filter.UserType != "" && filter.UserType != tt.userType) {
+						return nil, 0, nil
+					}
+					return []*platform.UserResourceMapping{{
+						ResourceID:   orgID,
+						ResourceType: platform.OrgResourceType,
+						UserID:       userID,
+						UserType:     tt.userType,
+					}}, 1, nil
+				},
+			}
+
+			r := httptest.NewRequest("PATCH", "http://any.url/api/v2/orgs/020f755c3c082000/settings", strings.NewReader(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{
+				UserID: userID,
+				Status: platform.Active,
+			}))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.statusCode {
+				t.Errorf("handlePatchOrgSettings() = %v, want %v: %s", res.StatusCode, tt.statusCode, body)
+			}
+			if eq, _ := jsonEqual(string(body), tt.response); tt.response != "" && !eq {
+				t.Errorf("handlePatchOrgSettings() = \n***%v***\n,\nwant\n***%v***", string(body), tt.response)
+			}
+		})
+	}
+}
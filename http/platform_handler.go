@@ -31,6 +31,10 @@ func NewPlatformHandler(b *APIBackend) *PlatformHandler {
 	h.RegisterNoAuthRoute("GET", "/api/v2")
 	h.RegisterNoAuthRoute("POST", "/api/v2/signin")
 	h.RegisterNoAuthRoute("POST", "/api/v2/signout")
+	h.RegisterNoAuthRoute("GET", "/api/v2/oauth")
+	h.RegisterNoAuthRoute("GET", "/api/v2/oauth/:provider/login")
+	h.RegisterNoAuthRoute("GET", "/api/v2/oauth/:provider/callback")
+	h.RegisterNoAuthRoute("GET", "/api/v2/oauth/:provider/logout")
 	h.RegisterNoAuthRoute("POST", "/api/v2/setup")
 	h.RegisterNoAuthRoute("GET", "/api/v2/setup")
 
@@ -0,0 +1,701 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/errors"
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/promql"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const (
+	prometheusPath = "/api/v2/prometheus/:orgID/:bucketID/api/v1"
+
+	// maxPrometheusPoints is the most points that a series in the result of a
+	// range query can have. It is the same as in Prometheus.
+	maxPrometheusPoints = 11000
+
+	// prometheusSeriesLookback is how long before the end the series, labels and
+	// label values are found if there is no start.
+	prometheusSeriesLookback = time.Hour
+)
+
+// PrometheusHandler serves the query API of Prometheus for the metrics in a bucket,
+// such as those written by the scraper, so that clients like the Prometheus data
+// source of Grafana can read them. The URL of a data source is
+// /api/v2/prometheus/:orgID/:bucketID and it must send "Token <token>" in the
+// Authorization header for a token that can read the bucket.
+type PrometheusHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	AuthorizationService platform.AuthorizationService
+	BucketService        platform.BucketService
+	ProxyQueryService    query.ProxyQueryService
+}
+
+// NewPrometheusHandler returns a new instance of PrometheusHandler.
+func NewPrometheusHandler() *PrometheusHandler {
+	h := &PrometheusHandler{
+		Router: httprouter.New(),
+		Logger: zap.NewNop(),
+	}
+
+	for _, method := range []string{"GET", "POST"} {
+		h.HandlerFunc(method, prometheusPath+"/query", h.handleQuery)
+		h.HandlerFunc(method, prometheusPath+"/query_range", h.handleQueryRange)
+		h.HandlerFunc(method, prometheusPath+"/labels", h.handleLabels)
+		h.HandlerFunc(method, prometheusPath+"/series", h.handleSeries)
+	}
+	h.HandlerFunc("GET", prometheusPath+"/label/:name/values", h.handleLabelValues)
+	return h
+}
+
+// The types of the errors in the Prometheus API.
+const (
+	prometheusBadData   = "bad_data"
+	prometheusExecution = "execution"
+	prometheusNotFound  = "not_found"
+	prometheusInternal  = "internal"
+)
+
+// prometheusError is an error with its type in the Prometheus API.
+type prometheusError struct {
+	typ string
+	err error
+}
+
+func (e *prometheusError) Error() string {
+	return e.err.Error()
+}
+
+func prometheusBadDataf(format string, args ...interface{}) error {
+	return &prometheusError{
+		typ: prometheusBadData,
+		err: fmt.Errorf(format, args...),
+	}
+}
+
+type prometheusResponse struct {
+	Status    string      `json:"status"`
+	Data      interface{} `json:"data,omitempty"`
+	ErrorType string      `json:"errorType,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+type prometheusQueryData struct {
+	ResultType string      `json:"resultType"`
+	Result     interface{} `json:"result"`
+}
+
+// prometheusSeries is a series in the result of a query. The value is set for
+// an instant vector and the values for a range vector.
+type prometheusSeries struct {
+	Metric map[string]string  `json:"metric"`
+	Value  *prometheusPoint   `json:"value,omitempty"`
+	Values []*prometheusPoint `json:"values,omitempty"`
+}
+
+// prometheusPoint is a value at a time. It is encoded as the Unix time in seconds
+// and the value as a string.
+type prometheusPoint struct {
+	t time.Time
+	v float64
+}
+
+func (p *prometheusPoint) MarshalJSON() ([]byte, error) {
+	// The times in Prometheus have a precision of milliseconds.
+	ts := strconv.FormatFloat(float64(p.t.UnixNano()/int64(time.Millisecond))/1e3, 'f', -1, 64)
+	v := strconv.FormatFloat(p.v, 'f', -1, 64)
+	return []byte("[" + ts + "," + strconv.Quote(v) + "]"), nil
+}
+
+func encodePrometheusResponse(ctx context.Context, w http.ResponseWriter, data interface{}) {
+	res := prometheusResponse{
+		Status: "success",
+		Data:   data,
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		encodePrometheusError(ctx, w, err)
+	}
+}
+
+// encodePrometheusError encodes the error in the format of the Prometheus API so
+// that its clients can show it.
+func encodePrometheusError(ctx context.Context, w http.ResponseWriter, err error) {
+	typ, code := prometheusInternal, http.StatusInternalServerError
+	if e, ok := err.(*prometheusError); ok {
+		typ = e.typ
+		switch typ {
+		case prometheusBadData:
+			code = http.StatusBadRequest
+		case prometheusExecution:
+			code = http.StatusUnprocessableEntity
+		}
+	} else {
+		code = statusCode(kitError(err))
+		switch {
+		case code == http.StatusNotFound:
+			typ = prometheusNotFound
+		case code < http.StatusInternalServerError:
+			typ = prometheusBadData
+		}
+	}
+
+	res := prometheusResponse{
+		Status:    "error",
+		ErrorType: typ,
+		Error:     err.Error(),
+	}
+	if err := encodeResponse(ctx, w, code, res); err != nil {
+		EncodeError(ctx, err, w)
+	}
+}
+
+// errPrometheusBucketNotFound is returned both for buckets that do not exist
+// and for buckets that the authorization cannot read, so that callers cannot
+// probe for the buckets of other organizations.
+var errPrometheusBucketNotFound = &platform.Error{Code: platform.ENotFound, Msg: "bucket not found"}
+
+// prometheusRequest is the bucket that a request reads and the authorization to
+// read it.
+type prometheusRequest struct {
+	auth   *platform.Authorization
+	bucket *platform.Bucket
+}
+
+func (h *PrometheusHandler) decodeRequest(r *http.Request) (*prometheusRequest, error) {
+	ctx := r.Context()
+	if err := r.ParseForm(); err != nil {
+		return nil, prometheusBadDataf("%v", err)
+	}
+
+	params := httprouter.ParamsFromContext(ctx)
+	var orgID, bucketID platform.ID
+	if err := orgID.DecodeFromString(params.ByName("orgID")); err != nil {
+		return nil, errors.InvalidDataf("invalid organization id: %v", err)
+	}
+	if err := bucketID.DecodeFromString(params.ByName("bucketID")); err != nil {
+		return nil, errors.InvalidDataf("invalid bucket id: %v", err)
+	}
+
+	auth, err := queryAuthorization(ctx, h.AuthorizationService)
+	if err != nil {
+		return nil, err
+	}
+	if !auth.Allowed(platform.ReadBucketPermission(bucketID)) {
+		return nil, errPrometheusBucketNotFound
+	}
+
+	bucket, err := h.BucketService.FindBucket(ctx, platform.BucketFilter{
+		OrganizationID: &orgID,
+		ID:             &bucketID,
+	})
+	if err != nil {
+		if platform.ErrorCode(err) == platform.ENotFound {
+			return nil, errPrometheusBucketNotFound
+		}
+		return nil, err
+	}
+
+	return &prometheusRequest{
+		auth:   auth,
+		bucket: bucket,
+	}, nil
+}
+
+// parsePrometheusTime parses a time that is either a Unix time in seconds or in
+// RFC 3339 format.
+func parsePrometheusTime(s string) (time.Time, error) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(frac*1e9)).UTC(), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q to a valid timestamp", s)
+}
+
+// parsePrometheusDuration parses a duration that is either a number of seconds
+// or a duration like 5m.
+func parsePrometheusDuration(s string) (time.Duration, error) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		d := f * float64(time.Second)
+		if d >= math.MaxInt64 || d <= math.MinInt64 || math.IsNaN(d) {
+			return 0, fmt.Errorf("cannot parse %q to a valid duration. It overflows int64", s)
+		}
+		return time.Duration(d), nil
+	}
+	if d, err := ParseDuration(s); err == nil {
+		return d, nil
+	}
+	return 0, fmt.Errorf("cannot parse %q to a valid duration", s)
+}
+
+// handleQuery evaluates an expression at a single time.
+func (h *PrometheusHandler) handleQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := h.decodeRequest(r)
+	if err != nil {
+		encodePrometheusError(ctx, w, err)
+		return
+	}
+
+	ts := time.Now()
+	if s := r.FormValue("time"); s != "" {
+		if ts, err = parsePrometheusTime(s); err != nil {
+			encodePrometheusError(ctx, w, prometheusBadDataf("invalid parameter 'time': %v", err))
+			return
+		}
+	}
+
+	expr, err := promql.ParsePromQL(r.FormValue("query"))
+	if err != nil {
+		encodePrometheusError(ctx, w, prometheusBadDataf("%v", err))
+		return
+	}
+
+	var data prometheusQueryData
+	switch typ := promql.TypeOf(expr); typ {
+	case promql.ScalarType:
+		p := &prometheusPoint{t: ts}
+		if n, ok := expr.(*promql.Number); ok {
+			p.v = n.Val
+		} else {
+			// A scalar that changes with the time of the evaluation, such as
+			// time(), is a series without any labels.
+			series, err := h.evaluate(ctx, req, expr, promql.Config{End: ts})
+			if err != nil {
+				encodePrometheusError(ctx, w, err)
+				return
+			}
+			p.v = math.NaN()
+			if len(series) == 1 {
+				p.v = series[0].Values[len(series[0].Values)-1].v
+			}
+		}
+		data = prometheusQueryData{
+			ResultType: typ.String(),
+			Result:     p,
+		}
+	case promql.VectorType, promql.MatrixType:
+		series, err := h.evaluate(ctx, req, expr, promql.Config{End: ts})
+		if err != nil {
+			encodePrometheusError(ctx, w, err)
+			return
+		}
+		if typ == promql.VectorType {
+			// The value of each series is at the time of the evaluation.
+			for _, s := range series {
+				s.Value = &prometheusPoint{t: ts, v: s.Values[len(s.Values)-1].v}
+				s.Values = nil
+			}
+		}
+		data = prometheusQueryData{
+			ResultType: typ.String(),
+			Result:     series,
+		}
+	default:
+		encodePrometheusError(ctx, w, prometheusBadDataf("unsupported expression type %q", typ))
+		return
+	}
+	encodePrometheusResponse(ctx, w, data)
+}
+
+// handleQueryRange evaluates an expression at each step between two times.
+func (h *PrometheusHandler) handleQueryRange(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := h.decodeRequest(r)
+	if err != nil {
+		encodePrometheusError(ctx, w, err)
+		return
+	}
+
+	start, err := parsePrometheusTime(r.FormValue("start"))
+	if err != nil {
+		encodePrometheusError(ctx, w, prometheusBadDataf("invalid parameter 'start': %v", err))
+		return
+	}
+	end, err := parsePrometheusTime(r.FormValue("end"))
+	if err != nil {
+		encodePrometheusError(ctx, w, prometheusBadDataf("invalid parameter 'end': %v", err))
+		return
+	}
+	step, err := parsePrometheusDuration(r.FormValue("step"))
+	if err != nil {
+		encodePrometheusError(ctx, w, prometheusBadDataf("invalid parameter 'step': %v", err))
+		return
+	}
+
+	if end.Before(start) {
+		encodePrometheusError(ctx, w, prometheusBadDataf("end timestamp must not be before start time"))
+		return
+	} else if step <= 0 {
+		encodePrometheusError(ctx, w, prometheusBadDataf("zero or negative query resolution step widths are not accepted. Try a positive integer"))
+		return
+	} else if end.Sub(start)/step > maxPrometheusPoints {
+		encodePrometheusError(ctx, w, prometheusBadDataf("exceeded maximum resolution of 11,000 points per timeseries. Try decreasing the query resolution (?step=XX)"))
+		return
+	}
+
+	expr, err := promql.ParsePromQL(r.FormValue("query"))
+	if err != nil {
+		encodePrometheusError(ctx, w, prometheusBadDataf("%v", err))
+		return
+	}
+
+	var series []*prometheusSeries
+	switch typ := promql.TypeOf(expr); typ {
+	case promql.ScalarType, promql.VectorType:
+		if n, ok := expr.(*promql.Number); ok {
+			s := &prometheusSeries{
+				Metric: map[string]string{},
+			}
+			for ts := start; !ts.After(end); ts = ts.Add(step) {
+				s.Values = append(s.Values, &prometheusPoint{t: ts, v: n.Val})
+			}
+			series = []*prometheusSeries{s}
+			break
+		}
+		// A scalar that changes with the time of the evaluation is evaluated
+		// in the same way as a vector.
+		series, err = h.evaluate(ctx, req, expr, promql.Config{
+			Start: start,
+			End:   end,
+			Step:  step,
+		})
+		if err != nil {
+			encodePrometheusError(ctx, w, err)
+			return
+		}
+	default:
+		encodePrometheusError(ctx, w, prometheusBadDataf("invalid expression type %q for range query, must be scalar or instant vector", typ))
+		return
+	}
+	encodePrometheusResponse(ctx, w, prometheusQueryData{
+		ResultType: promql.MatrixType.String(),
+		Result:     series,
+	})
+}
+
+// evaluate runs the query for the expression and returns the series of its result
+// in order of their labels.
+func (h *PrometheusHandler) evaluate(ctx context.Context, req *prometheusRequest, expr interface{}, config promql.Config) ([]*prometheusSeries, error) {
+	config.BucketID = req.bucket.ID
+	spec, err := promql.NewQuerySpec(expr, config)
+	if err != nil {
+		return nil, prometheusBadDataf("%v", err)
+	}
+
+	bySignature := make(map[string]*prometheusSeries)
+	if err := h.query(ctx, req, spec, func(cr flux.ColReader) error {
+		timeIdx := execute.ColIdx(execute.DefaultTimeColLabel, cr.Cols())
+		valueIdx := execute.ColIdx(execute.DefaultValueColLabel, cr.Cols())
+		if valueIdx < 0 {
+			return nil
+		}
+		for i := 0; i < cr.Len(); i++ {
+			p := &prometheusPoint{t: config.End}
+			if timeIdx >= 0 && cr.Cols()[timeIdx].Type == flux.TTime {
+				p.t = cr.Times(timeIdx)[i].Time()
+			}
+			switch cr.Cols()[valueIdx].Type {
+			case flux.TFloat:
+				p.v = cr.Floats(valueIdx)[i]
+			case flux.TInt:
+				p.v = float64(cr.Ints(valueIdx)[i])
+			case flux.TUInt:
+				p.v = float64(cr.UInts(valueIdx)[i])
+			default:
+				// Only numbers are samples of a metric.
+				continue
+			}
+
+			labels := prometheusLabels(cr, i)
+			sig := prometheusSignature(labels)
+			s, ok := bySignature[sig]
+			if !ok {
+				s = &prometheusSeries{Metric: labels}
+				bySignature[sig] = s
+			}
+			s.Values = append(s.Values, p)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	sigs := make([]string, 0, len(bySignature))
+	for sig := range bySignature {
+		sigs = append(sigs, sig)
+	}
+	sort.Strings(sigs)
+	series := make([]*prometheusSeries, len(sigs))
+	for i, sig := range sigs {
+		s := bySignature[sig]
+		sort.SliceStable(s.Values, func(i, j int) bool {
+			return s.Values[i].t.Before(s.Values[j].t)
+		})
+		series[i] = s
+	}
+	return series, nil
+}
+
+// query runs the query and calls fn with the rows of each table of its results.
+func (h *PrometheusHandler) query(ctx context.Context, req *prometheusRequest, spec *flux.Spec, fn func(cr flux.ColReader) error) error {
+	spec.Now = time.Now()
+	preq := &query.ProxyRequest{
+		Request: query.Request{
+			Authorization:  req.auth,
+			OrganizationID: req.bucket.OrganizationID,
+			Compiler:       lang.SpecCompiler{Spec: spec},
+		},
+		Dialect: csv.DefaultDialect(),
+	}
+
+	var buf bytes.Buffer
+	if _, err := h.ProxyQueryService.Query(ctx, &buf, preq); err != nil {
+		return &prometheusError{typ: prometheusExecution, err: err}
+	}
+
+	results, err := csv.NewMultiResultDecoder(csv.ResultDecoderConfig{}).Decode(ioutil.NopCloser(&buf))
+	if err != nil {
+		return &prometheusError{typ: prometheusExecution, err: err}
+	}
+	defer results.Cancel()
+
+	for results.More() {
+		if err := results.Next().Tables().Do(func(tbl flux.Table) error {
+			return tbl.Do(fn)
+		}); err != nil {
+			return &prometheusError{typ: prometheusExecution, err: err}
+		}
+	}
+	if err := results.Err(); err != nil {
+		return &prometheusError{typ: prometheusExecution, err: err}
+	}
+	return nil
+}
+
+// prometheusLabels returns the labels of the series of the row. A label that is
+// empty is the same as a label that is not set.
+func prometheusLabels(cr flux.ColReader, i int) map[string]string {
+	labels := make(map[string]string)
+	for j, c := range cr.Cols() {
+		name, ok := promql.LabelName(c)
+		if !ok {
+			continue
+		}
+		if v := cr.Strings(j)[i]; v != "" {
+			labels[name] = v
+		}
+	}
+	return labels
+}
+
+// prometheusSignature returns the labels as a string with the labels in order.
+func prometheusSignature(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(labels[name]))
+		b.WriteByte(',')
+	}
+	return b.String()
+}
+
+// findSeries returns the labels of the series with points between the start and
+// end that match any of the match[] selectors, in order of their labels. If there
+// are no selectors, all of the series match unless they are required. The end
+// defaults to now and the start to the lookback before the end.
+func (h *PrometheusHandler) findSeries(ctx context.Context, req *prometheusRequest, r *http.Request, matchRequired bool) ([]map[string]string, error) {
+	end := time.Now()
+	var err error
+	if s := r.FormValue("end"); s != "" {
+		if end, err = parsePrometheusTime(s); err != nil {
+			return nil, prometheusBadDataf("invalid parameter 'end': %v", err)
+		}
+	}
+	start := end.Add(-prometheusSeriesLookback)
+	if s := r.FormValue("start"); s != "" {
+		if start, err = parsePrometheusTime(s); err != nil {
+			return nil, prometheusBadDataf("invalid parameter 'start': %v", err)
+		}
+	}
+
+	matches := r.Form["match[]"]
+	if len(matches) == 0 && matchRequired {
+		return nil, prometheusBadDataf("no match[] parameter provided")
+	}
+	var wheres []*flux.Operation
+	for _, m := range matches {
+		expr, err := promql.ParsePromQL(m)
+		if err != nil {
+			return nil, prometheusBadDataf("%v", err)
+		}
+		sel, ok := expr.(*promql.Selector)
+		if !ok || sel.Range > 0 {
+			return nil, prometheusBadDataf("invalid parameter 'match[]': %q is not a vector selector", m)
+		}
+		where, err := promql.NewWhereOperation(sel.Name, sel.LabelMatchers)
+		if err != nil {
+			return nil, prometheusBadDataf("%v", err)
+		}
+		wheres = append(wheres, where)
+	}
+	if len(wheres) == 0 {
+		wheres = append(wheres, nil)
+	}
+
+	bySignature := make(map[string]map[string]string)
+	for _, where := range wheres {
+		// Only the last point of each series is read to find its labels.
+		ops := []*flux.Operation{
+			{
+				ID:   "from",
+				Spec: &inputs.FromOpSpec{BucketID: req.bucket.ID.String()},
+			},
+			promql.NewRangeOp(flux.Time{Absolute: start}, flux.Time{Absolute: end.Add(time.Nanosecond)}),
+		}
+		if where != nil {
+			ops = append(ops, where)
+		}
+		ops = append(ops, &flux.Operation{
+			ID: "last",
+			Spec: &transformations.LastOpSpec{
+				SelectorConfig: execute.SelectorConfig{Column: execute.DefaultValueColLabel},
+			},
+		})
+		spec := &flux.Spec{Operations: ops}
+		for i := 1; i < len(ops); i++ {
+			spec.Edges = append(spec.Edges, flux.Edge{Parent: ops[i-1].ID, Child: ops[i].ID})
+		}
+
+		if err := h.query(ctx, req, spec, func(cr flux.ColReader) error {
+			for i := 0; i < cr.Len(); i++ {
+				labels := prometheusLabels(cr, i)
+				bySignature[prometheusSignature(labels)] = labels
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	sigs := make([]string, 0, len(bySignature))
+	for sig := range bySignature {
+		sigs = append(sigs, sig)
+	}
+	sort.Strings(sigs)
+	series := make([]map[string]string, len(sigs))
+	for i, sig := range sigs {
+		series[i] = bySignature[sig]
+	}
+	return series, nil
+}
+
+// handleSeries returns the labels of the series that match the selectors.
+func (h *PrometheusHandler) handleSeries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := h.decodeRequest(r)
+	if err != nil {
+		encodePrometheusError(ctx, w, err)
+		return
+	}
+
+	series, err := h.findSeries(ctx, req, r, true)
+	if err != nil {
+		encodePrometheusError(ctx, w, err)
+		return
+	}
+	encodePrometheusResponse(ctx, w, series)
+}
+
+// handleLabels returns the names of the labels of the series.
+func (h *PrometheusHandler) handleLabels(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := h.decodeRequest(r)
+	if err != nil {
+		encodePrometheusError(ctx, w, err)
+		return
+	}
+
+	series, err := h.findSeries(ctx, req, r, false)
+	if err != nil {
+		encodePrometheusError(ctx, w, err)
+		return
+	}
+
+	seen := make(map[string]bool)
+	names := []string{}
+	for _, labels := range series {
+		for name := range labels {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	encodePrometheusResponse(ctx, w, names)
+}
+
+// handleLabelValues returns the values of a label of the series.
+func (h *PrometheusHandler) handleLabelValues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := h.decodeRequest(r)
+	if err != nil {
+		encodePrometheusError(ctx, w, err)
+		return
+	}
+
+	name := httprouter.ParamsFromContext(ctx).ByName("name")
+	series, err := h.findSeries(ctx, req, r, false)
+	if err != nil {
+		encodePrometheusError(ctx, w, err)
+		return
+	}
+
+	seen := make(map[string]bool)
+	values := []string{}
+	for _, labels := range series {
+		if v, ok := labels[name]; ok && !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+	sort.Strings(values)
+	encodePrometheusResponse(ctx, w, values)
+}
@@ -0,0 +1,285 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/query"
+	querymock "github.com/influxdata/platform/query/mock"
+)
+
+func TestPrometheusHandler(t *testing.T) {
+	finalizeTestBuiltIns()
+	orgID := platform.ID(0x020f755c3c082000)
+	bucketID := platform.ID(0x020f755c3c082001)
+	now := time.Date(2018, 10, 1, 0, 50, 0, 0, time.UTC)
+
+	// The points of two series of a counter every five minutes, like those
+	// written by the scraper.
+	data := "#datatype,string,long,dateTime:RFC3339,double,string,string,string,string\n" +
+		"#group,false,false,false,false,true,true,true,true\n" +
+		"#default,_result,,,,,,,\n" +
+		",result,table,_time,_value,_measurement,_field,job,path\n"
+	for i, path := range []string{"/foo", "/bar"} {
+		for j := 0; j <= 10; j++ {
+			ts := now.Add(time.Duration(j-10) * 5 * time.Minute).Format(time.RFC3339)
+			data += fmt.Sprintf(",,%d,%s,%d,http_requests,counter,api,%s\n", i, ts, j*10*(i+1), path)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		params      url.Values
+		bucketID    platform.ID // The bucket of the request, if not bucketID.
+		permissions []platform.Permission
+		statusCode  int
+		want        string
+	}{
+		{
+			name:       "instant query",
+			path:       "/query",
+			params:     url.Values{"query": {"sum(http_requests) by (job)"}, "time": {"1538355000"}},
+			statusCode: http.StatusOK,
+			want:       `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"job":"api"},"value":[1538355000,"300"]}]}}`,
+		},
+		{
+			name:       "range query",
+			path:       "/query_range",
+			params:     url.Values{"query": {`http_requests{path="/foo"} * 2`}, "start": {"2018-10-01T00:40:00Z"}, "end": {"1538355000"}, "step": {"5m"}},
+			statusCode: http.StatusOK,
+			want:       `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"job":"api","path":"/foo"},"values":[[1538354400,"160"],[1538354700,"180"],[1538355000,"200"]]}]}}`,
+		},
+		{
+			name:       "scalar query",
+			path:       "/query",
+			params:     url.Values{"query": {"1 + 2 * 3"}, "time": {"1538355000.5"}},
+			statusCode: http.StatusOK,
+			want:       `{"status":"success","data":{"resultType":"scalar","result":[1538355000.5,"7"]}}`,
+		},
+		{
+			name:       "time query",
+			path:       "/query",
+			params:     url.Values{"query": {"time() - 1538354400"}, "time": {"1538355000"}},
+			statusCode: http.StatusOK,
+			want:       `{"status":"success","data":{"resultType":"scalar","result":[1538355000,"600"]}}`,
+		},
+		{
+			name:       "range query of a vector divided by a scalar",
+			path:       "/query_range",
+			params:     url.Values{"query": {`http_requests{path="/foo"} / scalar(sum(http_requests))`}, "start": {"2018-10-01T00:40:00Z"}, "end": {"1538355000"}, "step": {"5m"}},
+			statusCode: http.StatusOK,
+			want:       `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"job":"api","path":"/foo"},"values":[[1538354400,"0.3333333333333333"],[1538354700,"0.3333333333333333"],[1538355000,"0.3333333333333333"]]}]}}`,
+		},
+		{
+			name:       "range query of vector",
+			path:       "/query_range",
+			params:     url.Values{"query": {"vector(1)"}, "start": {"2018-10-01T00:40:00Z"}, "end": {"1538355000"}, "step": {"5m"}},
+			statusCode: http.StatusOK,
+			want:       `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[1538354400,"1"],[1538354700,"1"],[1538355000,"1"]]}]}}`,
+		},
+		{
+			name:       "invalid query",
+			path:       "/query",
+			params:     url.Values{"query": {"sum("}},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "range query of a range vector",
+			path:       "/query_range",
+			params:     url.Values{"query": {"http_requests[5m]"}, "start": {"1538354400"}, "end": {"1538355000"}, "step": {"60"}},
+			statusCode: http.StatusBadRequest,
+			want:       `{"status":"error","errorType":"bad_data","error":"invalid expression type \"matrix\" for range query, must be scalar or instant vector"}`,
+		},
+		{
+			name:       "range query with too many points",
+			path:       "/query_range",
+			params:     url.Values{"query": {"http_requests"}, "start": {"0"}, "end": {"1538355000"}, "step": {"1"}},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "series",
+			path:       "/series",
+			params:     url.Values{"match[]": {`http_requests{path=~"/b.*"}`}, "end": {"1538355000"}},
+			statusCode: http.StatusOK,
+			want:       `{"status":"success","data":[{"__name__":"http_requests","job":"api","path":"/bar"}]}`,
+		},
+		{
+			name:       "series without a selector",
+			path:       "/series",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "labels",
+			path:       "/labels",
+			params:     url.Values{"end": {"1538355000"}},
+			statusCode: http.StatusOK,
+			want:       `{"status":"success","data":["__name__","job","path"]}`,
+		},
+		{
+			name:       "label values",
+			path:       "/label/path/values",
+			params:     url.Values{"end": {"1538355000"}},
+			statusCode: http.StatusOK,
+			want:       `{"status":"success","data":["/bar","/foo"]}`,
+		},
+		{
+			name:        "authorization that cannot read the bucket",
+			path:        "/query",
+			params:      url.Values{"query": {"http_requests"}},
+			permissions: []platform.Permission{platform.WriteBucketPermission(bucketID)},
+			statusCode:  http.StatusNotFound,
+			want:        `{"status":"error","errorType":"not_found","error":"\u003cnot found\u003e bucket not found"}`,
+		},
+		{
+			name:        "bucket of another organization",
+			path:        "/query",
+			params:      url.Values{"query": {"http_requests"}},
+			bucketID:    bucketID + 1,
+			permissions: []platform.Permission{platform.ReadBucketPermission(bucketID + 1)},
+			statusCode:  http.StatusNotFound,
+			want:        `{"status":"error","errorType":"not_found","error":"\u003cnot found\u003e bucket not found"}`,
+		},
+		{
+			name:       "bucket that the authorization cannot read",
+			path:       "/query",
+			params:     url.Values{"query": {"http_requests"}},
+			bucketID:   bucketID + 1,
+			statusCode: http.StatusNotFound,
+			want:       `{"status":"error","errorType":"not_found","error":"\u003cnot found\u003e bucket not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permissions := tt.permissions
+			if permissions == nil {
+				permissions = []platform.Permission{platform.ReadBucketPermission(bucketID)}
+			}
+
+			h := NewPrometheusHandler()
+			h.AuthorizationService = &mock.AuthorizationService{
+				FindAuthorizationByIDFn: func(ctx context.Context, id platform.ID) (*platform.Authorization, error) {
+					return &platform.Authorization{ID: id, UserID: testUserID, Status: platform.Active, Permissions: permissions}, nil
+				},
+			}
+			h.BucketService = &mock.BucketService{
+				FindBucketFn: func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+					if *filter.OrganizationID != orgID || *filter.ID != bucketID {
+						return nil, &platform.Error{Code: platform.ENotFound, Msg: "bucket not found"}
+					}
+					return &platform.Bucket{ID: bucketID, OrganizationID: orgID, Name: "metrics"}, nil
+				},
+			}
+			// The queries read the points from the CSV instead of the bucket.
+			h.ProxyQueryService = &querymock.ProxyQueryService{
+				QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (int64, error) {
+					if req.Request.OrganizationID != orgID || req.Request.Authorization == nil {
+						t.Errorf("expected the query to run in the organization of the bucket with the caller's authorization")
+					}
+					spec := req.Request.Compiler.(lang.SpecCompiler).Spec
+					for _, op := range spec.Operations {
+						if from, ok := op.Spec.(*inputs.FromOpSpec); ok {
+							if from.BucketID != bucketID.String() {
+								t.Errorf("expected the query to read bucket %s, got %s", bucketID, from.BucketID)
+							}
+							op.Spec = &inputs.FromCSVOpSpec{CSV: data}
+						}
+					}
+					return querytest.NewQuerier().Query(ctx, w, lang.SpecCompiler{Spec: spec}, csv.DefaultDialect())
+				},
+			}
+
+			reqBucketID := bucketID
+			if tt.bucketID.Valid() {
+				reqBucketID = tt.bucketID
+			}
+			target := "http://any.url/api/v2/prometheus/" + orgID.String() + "/" + reqBucketID.String() + "/api/v1" + tt.path
+			r := httptest.NewRequest("POST", target, bytes.NewBufferString(tt.params.Encode()))
+			if tt.path == "/label/path/values" {
+				r = httptest.NewRequest("GET", target+"?"+tt.params.Encode(), nil)
+			}
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, withTestAuthorizer(r))
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.statusCode {
+				t.Fatalf("ServeHTTP() = %v, want %v: %s", res.StatusCode, tt.statusCode, body)
+			}
+			if tt.want == "" {
+				return
+			}
+
+			var got, want interface{}
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ServeHTTP() = %s, want %s", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrometheusHandler_SeriesLookback(t *testing.T) {
+	orgID := platform.ID(0x020f755c3c082000)
+	bucketID := platform.ID(0x020f755c3c082001)
+	end := time.Date(2018, 10, 1, 0, 50, 0, 0, time.UTC)
+
+	h := NewPrometheusHandler()
+	h.AuthorizationService = &mock.AuthorizationService{
+		FindAuthorizationByIDFn: func(ctx context.Context, id platform.ID) (*platform.Authorization, error) {
+			return &platform.Authorization{ID: id, UserID: testUserID, Status: platform.Active, Permissions: []platform.Permission{platform.ReadBucketPermission(bucketID)}}, nil
+		},
+	}
+	h.BucketService = &mock.BucketService{
+		FindBucketFn: func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+			return &platform.Bucket{ID: bucketID, OrganizationID: orgID, Name: "metrics"}, nil
+		},
+	}
+	var start flux.Time
+	h.ProxyQueryService = &querymock.ProxyQueryService{
+		QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (int64, error) {
+			for _, op := range req.Request.Compiler.(lang.SpecCompiler).Spec.Operations {
+				if r, ok := op.Spec.(*transformations.RangeOpSpec); ok {
+					start = r.Start
+				}
+			}
+			return 0, nil
+		},
+	}
+
+	target := "http://any.url/api/v2/prometheus/" + orgID.String() + "/" + bucketID.String() + "/api/v1/labels?end=1538355000"
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, withTestAuthorizer(httptest.NewRequest("GET", target, nil)))
+	if res := w.Result(); res.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(res.Body)
+		t.Fatalf("ServeHTTP() = %v, want %v: %s", res.StatusCode, http.StatusOK, body)
+	}
+	if want := end.Add(-time.Hour); !start.Absolute.Equal(want) {
+		t.Errorf("unexpected start of the series: got %v, want %v", start.Absolute, want)
+	}
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/dialect"
+	"github.com/influxdata/platform/query/influxql"
 	"github.com/julienschmidt/httprouter"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -30,10 +32,18 @@ type ProxyQueryHandler struct {
 	DialectMappings  flux.DialectMappings
 }
 
-// NewProxyQueryHandler returns a new instance of ProxyQueryHandler.
+// NewProxyQueryHandler returns a new instance of ProxyQueryHandler that
+// decodes the dialects query results can be encoded in.
 func NewProxyQueryHandler() *ProxyQueryHandler {
 	h := &ProxyQueryHandler{
-		Router: httprouter.New(),
+		Router:          httprouter.New(),
+		DialectMappings: make(flux.DialectMappings),
+	}
+	if err := dialect.AddDialectMappings(h.DialectMappings); err != nil {
+		panic(err)
+	}
+	if err := influxql.AddDialectMappings(h.DialectMappings); err != nil {
+		panic(err)
 	}
 
 	h.HandlerFunc("POST", proxyQueryPath, h.handlePostQuery)
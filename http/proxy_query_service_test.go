@@ -0,0 +1,69 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/dialect/arrow"
+	jsondialect "github.com/influxdata/platform/query/dialect/json"
+	"github.com/influxdata/platform/query/dialect/lineprotocol"
+	"github.com/influxdata/platform/query/influxql"
+	querymock "github.com/influxdata/platform/query/mock"
+)
+
+func TestProxyQueryService_Query(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect flux.Dialect
+	}{
+		{name: "csv", dialect: csv.DefaultDialect()},
+		{name: "json", dialect: &jsondialect.Dialect{}},
+		{name: "line protocol", dialect: &lineprotocol.Dialect{}},
+		{name: "arrow", dialect: &arrow.Dialect{}},
+		{name: "influxql", dialect: &influxql.Dialect{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewProxyQueryHandler()
+			h.CompilerMappings = make(flux.CompilerMappings)
+			if err := lang.AddCompilerMappings(h.CompilerMappings); err != nil {
+				t.Fatal(err)
+			}
+			h.ProxyQueryService = &querymock.ProxyQueryService{
+				QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (int64, error) {
+					if got, want := req.Dialect.DialectType(), tt.dialect.DialectType(); got != want {
+						t.Errorf("Query() dialect = %s, want %s", got, want)
+					}
+					n, err := io.WriteString(w, "ok")
+					return int64(n), err
+				},
+			}
+			server := httptest.NewServer(h)
+			defer server.Close()
+
+			s := &ProxyQueryService{Addr: server.URL}
+			var buf bytes.Buffer
+			if _, err := s.Query(context.Background(), &buf, &query.ProxyRequest{
+				Request: query.Request{
+					OrganizationID: platform.ID(0x020f755c3c082000),
+					Compiler:       lang.FluxCompiler{Query: `from(bucket: "telegraf")`},
+				},
+				Dialect: tt.dialect,
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != "ok" {
+				t.Errorf("Query() = %q, want %q", got, "ok")
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/errors"
+	"github.com/influxdata/platform/query"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const (
+	queriesPath    = "/api/v2/queries"
+	queriesIDPath  = "/api/v2/queries/:id"
+	queriesLogPath = "/api/v2/queries/log"
+)
+
+// QueriesHandler represents an HTTP API handler for inspecting the queries of an organization.
+type QueriesHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	OrganizationService        platform.OrganizationService
+	UserResourceMappingService platform.UserResourceMappingService
+	QueryLogService            query.LogService
+	ActiveQueryService         query.ActiveQueryService
+}
+
+// NewQueriesHandler returns a new instance of QueriesHandler.
+func NewQueriesHandler() *QueriesHandler {
+	h := &QueriesHandler{
+		Router: httprouter.New(),
+		Logger: zap.NewNop(),
+	}
+
+	h.HandlerFunc("GET", queriesPath, h.handleGetActiveQueries)
+	h.HandlerFunc("DELETE", queriesIDPath, h.handleDeleteActiveQuery)
+	h.HandlerFunc("GET", queriesLogPath, h.handleGetQueryLog)
+	return h
+}
+
+type activeQueriesResponse struct {
+	Links   map[string]string    `json:"links"`
+	Queries []*query.ActiveQuery `json:"queries"`
+}
+
+// handleGetActiveQueries is the HTTP handler for the GET /api/v2/queries route.
+func (h *QueriesHandler) handleGetActiveQueries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	org, err := decodeRequiredOrganization(ctx, r, h.OrganizationService)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, org.ID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	qs, err := h.ActiveQueryService.FindActiveQueries(ctx, query.ActiveQueryFilter{
+		OrganizationID: &org.ID,
+	})
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	res := activeQueriesResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("%s?%s=%s", queriesPath, OrgID, org.ID),
+			"log":  fmt.Sprintf("%s?%s=%s", queriesLogPath, OrgID, org.ID),
+		},
+		Queries: qs,
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handleDeleteActiveQuery is the HTTP handler for the DELETE /api/v2/queries/:id route.
+func (h *QueriesHandler) handleDeleteActiveQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	var id platform.ID
+	if err := id.DecodeFromString(params.ByName("id")); err != nil {
+		EncodeError(ctx, errors.InvalidDataf("invalid query id: %v", err), w)
+		return
+	}
+
+	q, err := h.ActiveQueryService.FindActiveQueryByID(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, q.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.ActiveQueryService.CancelQuery(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type queryLogResponse struct {
+	Links   map[string]string `json:"links"`
+	Queries []*query.LogEntry `json:"queries"`
+}
+
+// handleGetQueryLog is the HTTP handler for the GET /api/v2/queries/log route.
+func (h *QueriesHandler) handleGetQueryLog(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetQueryLogRequest(ctx, r, h.OrganizationService)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, req.filter.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	entries, err := h.QueryLogService.FindLogEntries(ctx, req.filter)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	res := queryLogResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("%s?%s=%s", queriesLogPath, OrgID, req.filter.OrganizationID),
+		},
+		Queries: entries,
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+type getQueryLogRequest struct {
+	filter query.LogFilter
+}
+
+func decodeGetQueryLogRequest(ctx context.Context, r *http.Request, svc platform.OrganizationService) (*getQueryLogRequest, error) {
+	org, err := decodeRequiredOrganization(ctx, r, svc)
+	if err != nil {
+		return nil, err
+	}
+	qp := r.URL.Query()
+
+	req := &getQueryLogRequest{
+		filter: query.LogFilter{
+			OrganizationID: org.ID,
+		},
+	}
+
+	if since := qp.Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return nil, errors.InvalidDataf("invalid since time: %v", err)
+		}
+		req.filter.Since = t
+	}
+
+	if slow := qp.Get("slow"); slow != "" {
+		b, err := strconv.ParseBool(slow)
+		if err != nil {
+			return nil, errors.InvalidDataf("invalid slow parameter: %v", err)
+		}
+		req.filter.SlowOnly = b
+	}
+
+	if limit := qp.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			return nil, errors.InvalidDataf("invalid limit %q", limit)
+		}
+		req.filter.Limit = n
+	}
+
+	return req, nil
+}
+
+// decodeRequiredOrganization returns the organization of the request, which must be specified.
+func decodeRequiredOrganization(ctx context.Context, r *http.Request, svc platform.OrganizationService) (*platform.Organization, error) {
+	qp := r.URL.Query()
+	if qp.Get(OrgID) == "" && qp.Get(OrgName) == "" {
+		return nil, errors.InvalidDataf("organization is required")
+	}
+	return queryOrganization(ctx, r, svc)
+}
+
+var _ query.ActiveQueryService = (*ActiveQueryService)(nil)
+
+// ActiveQueryService connects to Influx via HTTP using tokens to list and cancel queries in flight.
+type ActiveQueryService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+// FindActiveQueries returns the queries in flight of the organization in filter, which is required.
+func (s *ActiveQueryService) FindActiveQueries(ctx context.Context, filter query.ActiveQueryFilter) ([]*query.ActiveQuery, error) {
+	if filter.OrganizationID == nil {
+		return nil, fmt.Errorf("organization is required to find active queries")
+	}
+
+	u, err := newURL(s.Addr, queriesPath)
+	if err != nil {
+		return nil, err
+	}
+	qp := u.Query()
+	qp.Set(OrgID, filter.OrganizationID.String())
+	u.RawQuery = qp.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var res activeQueriesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	return res.Queries, nil
+}
+
+// FindActiveQueryByID is not supported over HTTP; list the queries of an organization instead.
+func (s *ActiveQueryService) FindActiveQueryByID(ctx context.Context, id platform.ID) (*query.ActiveQuery, error) {
+	return nil, &platform.Error{
+		Code: platform.ENotImplemented,
+		Op:   "http/find active query by id",
+		Msg:  "finding an active query by ID is not supported over HTTP; list the queries of an organization instead",
+	}
+}
+
+// CancelQuery cancels a query in flight.
+func (s *ActiveQueryService) CancelQuery(ctx context.Context, id platform.ID) error {
+	u, err := newURL(s.Addr, path.Join(queriesPath, id.String()))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return CheckError(resp)
+}
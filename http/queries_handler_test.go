@@ -0,0 +1,229 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/kit/errors"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/query"
+	qmock "github.com/influxdata/platform/query/mock"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func TestQueriesHandler_handleGetQueryLog(t *testing.T) {
+	orgID := platformtesting.MustIDBase16("020f755c3c082000")
+	userID := platformtesting.MustIDBase16("020f755c3c082001")
+
+	orgSvc := &mock.OrganizationService{
+		FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+			return &platform.Organization{ID: *filter.ID, Name: "o1"}, nil
+		},
+	}
+	logSvc := &qmock.LogService{
+		FindLogEntriesF: func(ctx context.Context, filter query.LogFilter) ([]*query.LogEntry, error) {
+			if !filter.SlowOnly || filter.Limit != 10 {
+				t.Errorf("unexpected filter %+v", filter)
+			}
+			return []*query.LogEntry{
+				{
+					ID:             platform.ID(0x020f755c3c082010),
+					Time:           time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC),
+					OrganizationID: filter.OrganizationID,
+					CompilerType:   "flux",
+					Query:          `from(bucket: "telegraf")`,
+					ResponseSize:   10,
+					Duration:       time.Second,
+					Slow:           true,
+				},
+			}, nil
+		},
+	}
+
+	tests := []struct {
+		name       string
+		member     bool
+		query      string
+		statusCode int
+		body       string
+	}{
+		{
+			name:       "list slow queries of a member organization",
+			member:     true,
+			query:      "organizationID=020f755c3c082000&slow=true&limit=10",
+			statusCode: http.StatusOK,
+			body: `
+{
+  "links": {
+    "self": "/api/v2/queries/log?organizationID=020f755c3c082000"
+  },
+  "queries": [
+    {
+      "id": "020f755c3c082010",
+      "time": "2018-10-01T00:00:00Z",
+      "orgID": "020f755c3c082000",
+      "compilerType": "flux",
+      "query": "from(bucket: \"telegraf\")",
+      "responseSize": 10,
+      "duration": 1000000000,
+      "statistics": {
+        "total_duration": 0,
+        "compile_duration": 0,
+        "queue_duration": 0,
+        "plan_duration": 0,
+        "requeue_duration": 0,
+        "execute_duration": 0,
+        "concurrency": 0,
+        "max_allocated": 0
+      },
+      "slow": true
+    }
+  ]
+}`,
+		},
+		{
+			name:       "forbidden for non members",
+			query:      "organizationID=020f755c3c082000&slow=true&limit=10",
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "organization is required",
+			member:     true,
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewQueriesHandler()
+			h.OrganizationService = orgSvc
+			h.QueryLogService = logSvc
+			h.UserResourceMappingService = &mock.UserResourceMappingService{
+				FindMappingsF: func(ctx context.Context, filter platform.UserResourceMappingFilter) ([]*platform.UserResourceMapping, int, error) {
+					if !tt.member || filter.ResourceID != orgID || filter.UserID != userID {
+						return nil, 0, nil
+					}
+					return []*platform.UserResourceMapping{{
+						ResourceID:   orgID,
+						ResourceType: platform.OrgResourceType,
+						UserID:       userID,
+						UserType:     platform.Member,
+					}}, 1, nil
+				},
+			}
+
+			r := httptest.NewRequest("GET", "http://any.url"+queriesLogPath+"?"+tt.query, nil)
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{
+				UserID: userID,
+				Status: platform.Active,
+			}))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.statusCode {
+				t.Errorf("handleGetQueryLog() = %v, want %v: %s", res.StatusCode, tt.statusCode, body)
+			}
+			if eq, _ := jsonEqual(string(body), tt.body); tt.body != "" && !eq {
+				t.Errorf("handleGetQueryLog() = \n***%v***\n,\nwant\n***%v***", string(body), tt.body)
+			}
+		})
+	}
+}
+
+func TestQueriesHandler_handleDeleteActiveQuery(t *testing.T) {
+	orgID := platformtesting.MustIDBase16("020f755c3c082000")
+	userID := platformtesting.MustIDBase16("020f755c3c082001")
+	queryID := platformtesting.MustIDBase16("0000000000000001")
+
+	tests := []struct {
+		name       string
+		member     bool
+		id         string
+		statusCode int
+		canceled   bool
+	}{
+		{
+			name:       "cancel a query of a member organization",
+			member:     true,
+			id:         "0000000000000001",
+			statusCode: http.StatusNoContent,
+			canceled:   true,
+		},
+		{
+			name:       "forbidden for non members",
+			id:         "0000000000000001",
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "unknown query",
+			member:     true,
+			id:         "0000000000000002",
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var canceled bool
+			h := NewQueriesHandler()
+			h.ActiveQueryService = &qmock.ActiveQueryService{
+				FindActiveQueryByIDF: func(ctx context.Context, id platform.ID) (*query.ActiveQuery, error) {
+					if id != queryID {
+						return nil, errors.Errorf(errors.NotFound, "query %s not found", id)
+					}
+					return &query.ActiveQuery{ID: queryID, OrganizationID: orgID}, nil
+				},
+				CancelQueryF: func(ctx context.Context, id platform.ID) error {
+					canceled = true
+					return nil
+				},
+			}
+			h.UserResourceMappingService = &mock.UserResourceMappingService{
+				FindMappingsF: func(ctx context.Context, filter platform.UserResourceMappingFilter) ([]*platform.UserResourceMapping, int, error) {
+					if !tt.member || filter.ResourceID != orgID || filter.UserID != userID {
+						return nil, 0, nil
+					}
+					return []*platform.UserResourceMapping{{
+						ResourceID:   orgID,
+						ResourceType: platform.OrgResourceType,
+						UserID:       userID,
+						UserType:     platform.Owner,
+					}}, 1, nil
+				},
+			}
+
+			r := httptest.NewRequest("DELETE", "http://any.url"+queriesPath+"/"+tt.id, nil)
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Session{
+				UserID:    userID,
+				ExpiresAt: time.Now().Add(time.Hour),
+			}))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if res := w.Result(); res.StatusCode != tt.statusCode {
+				t.Errorf("handleDeleteActiveQuery() = %v, want %v", res.StatusCode, tt.statusCode)
+			}
+			if canceled != tt.canceled {
+				t.Errorf("query canceled = %v, want %v", canceled, tt.canceled)
+			}
+		})
+	}
+}
+
+func TestActiveQueryService_FindActiveQueryByID(t *testing.T) {
+	s := &ActiveQueryService{Addr: "http://any.url"}
+	_, err := s.FindActiveQueryByID(context.Background(), platformtesting.MustIDBase16("020f755c3c082000"))
+	if code := platform.ErrorCode(err); code != platform.ENotImplemented {
+		t.Errorf("FindActiveQueryByID() error code = %q, want %q", code, platform.ENotImplemented)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"mime"
 	"net/http"
+	"strings"
 	"time"
 	"unicode/utf8"
 
@@ -12,14 +14,19 @@ import (
 	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/csv"
 	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/parser"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/kit/errors"
 	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/dialect/arrow"
+	jsondialect "github.com/influxdata/platform/query/dialect/json"
+	"github.com/influxdata/platform/query/dialect/lineprotocol"
+	"github.com/influxdata/platform/query/influxql"
 )
 
-// QueryRequest is a flux query request.
+// QueryRequest is a flux or influxql query request.
 type QueryRequest struct {
 	Spec    *flux.Spec   `json:"spec,omitempty"`
 	AST     *ast.Program `json:"ast,omitempty"`
@@ -27,11 +34,35 @@ type QueryRequest struct {
 	Type    string       `json:"type"`
 	Dialect QueryDialect `json:"dialect"`
 
-	org *platform.Organization
+	// Cluster, DB and RP are the defaults of an influxql query. They are
+	// mapped to a bucket by the DBRPMappingService, unless Bucket names the
+	// bucket to read from. Measurements naming their database or retention
+	// policy read from the bucket mapped to them.
+	Cluster string `json:"cluster,omitempty"`
+	DB      string `json:"db,omitempty"`
+	RP      string `json:"rp,omitempty"`
+	Bucket  string `json:"bucket,omitempty"`
+
+	// Params are declared as the properties of v before the Flux query.
+	Params QueryParams `json:"params,omitempty"`
+	// Macros are declared as the properties of v with their selected values.
+	Macros []QueryMacro `json:"macros,omitempty"`
+
+	org                *platform.Organization
+	auth               *platform.Authorization
+	dbrpMappingService platform.DBRPMappingService
+	bucketService      platform.BucketService
+	schemaQuerier      influxql.SchemaQuerier
 }
 
 // QueryDialect is the formatting options for the query response.
+// The options other than Type only apply to the csv dialect.
 type QueryDialect struct {
+	// Type is the dialect of the response: csv, json, lineprotocol, arrow
+	// or influxql. It defaults to the dialect of the Accept header of the
+	// request, if any, or csv. The response of an influxql query defaults to
+	// the influxql dialect instead of csv and json.
+	Type           string   `json:"type,omitempty"`
 	Header         *bool    `json:"header"`
 	Delimiter      string   `json:"delimiter"`
 	CommentPrefix  string   `json:"commentPrefix"`
@@ -44,6 +75,12 @@ func (r QueryRequest) WithDefaults() QueryRequest {
 	if r.Type == "" {
 		r.Type = "flux"
 	}
+	if r.Type == influxql.CompilerType {
+		switch r.Dialect.Type {
+		case "", jsondialect.DialectType:
+			r.Dialect.Type = influxql.DialectType
+		}
+	}
 	if r.Dialect.Delimiter == "" {
 		r.Dialect.Delimiter = ","
 	}
@@ -63,10 +100,39 @@ func (r QueryRequest) Validate() error {
 		return errors.New(`request body requires either query, spec, or AST`)
 	}
 
-	if r.Type != "flux" {
+	switch r.Type {
+	case "flux":
+	case influxql.CompilerType:
+		if r.Query == "" || r.Spec != nil || r.AST != nil {
+			return fmt.Errorf("influxql requests require a query and no spec or AST")
+		}
+		if len(r.Params) > 0 || len(r.Macros) > 0 {
+			return fmt.Errorf("params and macros are not supported by influxql queries")
+		}
+	default:
 		return fmt.Errorf(`unknown query type: %s`, r.Type)
 	}
 
+	if (len(r.Params) > 0 || len(r.Macros) > 0) && r.Query == "" && r.AST == nil {
+		return fmt.Errorf("params and macros require a query or AST")
+	}
+
+	for name, v := range r.Params {
+		if err := validateQueryParam(name, v); err != nil {
+			return err
+		}
+	}
+
+	switch r.Dialect.Type {
+	case "", csv.DialectType, jsondialect.DialectType, lineprotocol.DialectType, arrow.DialectType:
+	case influxql.DialectType:
+		if r.Type != influxql.CompilerType {
+			return fmt.Errorf("the influxql dialect requires an influxql query")
+		}
+	default:
+		return fmt.Errorf(`unknown dialect type: %s`, r.Dialect.Type)
+	}
+
 	if len(r.Dialect.CommentPrefix) > 1 {
 		return fmt.Errorf("invalid dialect comment prefix: must be length 0 or 1")
 	}
@@ -135,7 +201,38 @@ func (r QueryRequest) proxyRequest(now func() time.Time) (*query.ProxyRequest, e
 	}
 	// Query is preferred over spec
 	var compiler flux.Compiler
-	if r.Query != "" {
+	if r.Type == influxql.CompilerType {
+		c := influxql.NewCompiler(r.dbrpMappingService)
+		c.Cluster = r.Cluster
+		c.DB = r.DB
+		c.RP = r.RP
+		c.Bucket = r.Bucket
+		c.Query = r.Query
+		c.OrganizationID = r.org.ID
+		c.Authorization = r.auth
+		c.BucketService = r.bucketService
+		c.SchemaQuerier = r.schemaQuerier
+		compiler = c
+	} else if len(r.Params) > 0 {
+		p := r.AST
+		if r.Query != "" {
+			var err error
+			if p, err = parser.NewAST(r.Query); err != nil {
+				return nil, errors.InvalidDataf("invalid flux query: %v", err)
+			}
+		}
+		p, err := withQueryParams(p, r.Params)
+		if err != nil {
+			return nil, err
+		}
+		r.Spec, err = toSpec(p, now)
+		if err != nil {
+			return nil, err
+		}
+		compiler = lang.SpecCompiler{
+			Spec: r.Spec,
+		}
+	} else if r.Query != "" {
 		compiler = lang.FluxCompiler{
 			Query: r.Query,
 		}
@@ -154,6 +251,27 @@ func (r QueryRequest) proxyRequest(now func() time.Time) (*query.ProxyRequest, e
 		}
 	}
 
+	return &query.ProxyRequest{
+		Request: query.Request{
+			OrganizationID: r.org.ID,
+			Compiler:       compiler,
+		},
+		Dialect: r.dialect(),
+	}, nil
+}
+
+func (r QueryRequest) dialect() flux.Dialect {
+	switch r.Dialect.Type {
+	case jsondialect.DialectType:
+		return &jsondialect.Dialect{}
+	case lineprotocol.DialectType:
+		return &lineprotocol.Dialect{}
+	case arrow.DialectType:
+		return &arrow.Dialect{}
+	case influxql.DialectType:
+		return &influxql.Dialect{}
+	}
+
 	delimiter, _ := utf8.DecodeRuneInString(r.Dialect.Delimiter)
 
 	noHeader := false
@@ -163,19 +281,36 @@ func (r QueryRequest) proxyRequest(now func() time.Time) (*query.ProxyRequest, e
 
 	// TODO(nathanielc): Use commentPrefix and dateTimeFormat
 	// once they are supported.
-	return &query.ProxyRequest{
-		Request: query.Request{
-			OrganizationID: r.org.ID,
-			Compiler:       compiler,
+	return csv.Dialect{
+		ResultEncoderConfig: csv.ResultEncoderConfig{
+			NoHeader:    noHeader,
+			Delimiter:   delimiter,
+			Annotations: r.Dialect.Annotations,
 		},
-		Dialect: csv.Dialect{
-			ResultEncoderConfig: csv.ResultEncoderConfig{
-				NoHeader:    noHeader,
-				Delimiter:   delimiter,
-				Annotations: r.Dialect.Annotations,
-			},
-		},
-	}, nil
+	}
+}
+
+// dialectContentTypes are the content types of the dialects of a query response.
+var dialectContentTypes = map[string]string{
+	"text/csv":                     csv.DialectType,
+	"application/json":             jsondialect.DialectType,
+	"text/plain":                   lineprotocol.DialectType,
+	"application/vnd.influx.arrow": arrow.DialectType,
+}
+
+// acceptedDialectType returns the type of the first dialect of the Accept
+// header that a query response can be encoded in, if any.
+func acceptedDialectType(accept string) string {
+	for _, t := range strings.Split(accept, ",") {
+		mt, _, err := mime.ParseMediaType(strings.TrimSpace(t))
+		if err != nil {
+			continue
+		}
+		if typ, ok := dialectContentTypes[mt]; ok {
+			return typ
+		}
+	}
+	return ""
 }
 
 // QueryRequestFromProxyRequest converts a query.ProxyRequest into a QueryRequest.
@@ -189,6 +324,13 @@ func QueryRequestFromProxyRequest(req *query.ProxyRequest) (*QueryRequest, error
 	case lang.SpecCompiler:
 		qr.Type = "flux"
 		qr.Spec = c.Spec
+	case *influxql.Compiler:
+		qr.Type = influxql.CompilerType
+		qr.Query = c.Query
+		qr.Cluster = c.Cluster
+		qr.DB = c.DB
+		qr.RP = c.RP
+		qr.Bucket = c.Bucket
 	default:
 		return nil, fmt.Errorf("unsupported compiler %T", c)
 	}
@@ -200,6 +342,14 @@ func QueryRequestFromProxyRequest(req *query.ProxyRequest) (*QueryRequest, error
 		qr.Dialect.CommentPrefix = "#"
 		qr.Dialect.DateTimeFormat = "RFC3339"
 		qr.Dialect.Annotations = d.ResultEncoderConfig.Annotations
+	case *jsondialect.Dialect:
+		qr.Dialect.Type = jsondialect.DialectType
+	case *lineprotocol.Dialect:
+		qr.Dialect.Type = lineprotocol.DialectType
+	case *arrow.Dialect:
+		qr.Dialect.Type = arrow.DialectType
+	case *influxql.Dialect:
+		qr.Dialect.Type = influxql.DialectType
 	default:
 		return nil, fmt.Errorf("unsupported dialect %T", d)
 	}
@@ -213,6 +363,9 @@ func decodeQueryRequest(ctx context.Context, r *http.Request, svc platform.Organ
 		return nil, err
 	}
 
+	if req.Dialect.Type == "" {
+		req.Dialect.Type = acceptedDialectType(r.Header.Get("Accept"))
+	}
 	req = req.WithDefaults()
 	err := req.Validate()
 	if err != nil {
@@ -223,11 +376,21 @@ func decodeQueryRequest(ctx context.Context, r *http.Request, svc platform.Organ
 	return &req, err
 }
 
-func decodeProxyQueryRequest(ctx context.Context, r *http.Request, auth *platform.Authorization, svc platform.OrganizationService) (*query.ProxyRequest, error) {
+// decodeProxyQueryRequest decodes the query request. prepare, if not nil, is
+// called with the decoded request before it is compiled, such as to add the
+// values of the macros the request references to its params.
+func decodeProxyQueryRequest(ctx context.Context, r *http.Request, auth *platform.Authorization, svc platform.OrganizationService, prepare func(*QueryRequest) error) (*query.ProxyRequest, error) {
 	req, err := decodeQueryRequest(ctx, r, svc)
 	if err != nil {
 		return nil, err
 	}
+	req.auth = auth
+
+	if prepare != nil {
+		if err := prepare(req); err != nil {
+			return nil, err
+		}
+	}
 
 	pr, err := req.ProxyRequest()
 	if err != nil {
@@ -19,6 +19,7 @@ import (
 	pcontext "github.com/influxdata/platform/context"
 	"github.com/influxdata/platform/kit/errors"
 	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/influxql"
 	"github.com/julienschmidt/httprouter"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -38,6 +39,10 @@ type FluxHandler struct {
 	AuthorizationService platform.AuthorizationService
 	OrganizationService  platform.OrganizationService
 	ProxyQueryService    query.ProxyQueryService
+	MacroService         platform.MacroService
+	DBRPMappingService   platform.DBRPMappingService
+	BucketService        platform.BucketService
+	SchemaQuerier        influxql.SchemaQuerier
 }
 
 // NewFluxHandler returns a new handler at /api/v2/query for flux queries.
@@ -58,24 +63,18 @@ func NewFluxHandler() *FluxHandler {
 
 func (h *FluxHandler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	a, err := pcontext.GetAuthorizer(ctx)
-	if err != nil {
-		EncodeError(ctx, err, w)
-		return
-	}
-
-	auth, err := h.AuthorizationService.FindAuthorizationByID(ctx, a.Identifier())
+	auth, err := queryAuthorization(ctx, h.AuthorizationService)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
 
-	if !auth.IsActive() {
-		EncodeError(ctx, errors.Forbiddenf("insufficient permissions for query"), w)
-		return
-	}
-
-	req, err := decodeProxyQueryRequest(ctx, r, auth, h.OrganizationService)
+	req, err := decodeProxyQueryRequest(ctx, r, auth, h.OrganizationService, func(req *QueryRequest) error {
+		req.dbrpMappingService = h.DBRPMappingService
+		req.bucketService = h.BucketService
+		req.schemaQuerier = h.SchemaQuerier
+		return h.resolveQueryMacros(ctx, auth, req)
+	})
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -102,6 +101,25 @@ func (h *FluxHandler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// queryAuthorization returns the active authorization on whose behalf the
+// request queries.
+func queryAuthorization(ctx context.Context, svc platform.AuthorizationService) (*platform.Authorization, error) {
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := svc.FindAuthorizationByID(ctx, a.Identifier())
+	if err != nil {
+		return nil, err
+	}
+
+	if !auth.IsActive() {
+		return nil, errors.Forbiddenf("insufficient permissions for query")
+	}
+	return auth, nil
+}
+
 type langRequest struct {
 	Query string `json:"query"`
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -12,9 +13,14 @@ import (
 	"time"
 
 	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/functions/inputs"
 	"github.com/influxdata/flux/lang"
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/inmem"
+	"github.com/influxdata/platform/mock"
 	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/influxql"
+	querymock "github.com/influxdata/platform/query/mock"
 )
 
 func TestFluxService_Query(t *testing.T) {
@@ -281,3 +287,85 @@ var crlfPattern = regexp.MustCompile(`\r?\n`)
 func toCRLF(data string) string {
 	return crlfPattern.ReplaceAllString(data, "\r\n")
 }
+
+func TestFluxHandler_handlePostQuery_InfluxQL(t *testing.T) {
+	orgID := platform.ID(0x020f755c3c082000)
+	bucketID := platform.ID(0x020f755c3c082001)
+	otherBucketID := platform.ID(0x020f755c3c082002)
+
+	ctx := context.Background()
+	dbrpSvc := inmem.NewService()
+	for _, m := range []*platform.DBRPMapping{
+		{Cluster: "cluster", Database: "telegraf", RetentionPolicy: "autogen", Default: true, OrganizationID: orgID, BucketID: bucketID},
+		{Cluster: "cluster", Database: "telegraf", RetentionPolicy: "weekly", OrganizationID: orgID, BucketID: otherBucketID},
+	} {
+		if err := dbrpSvc.Create(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		body   string
+		bucket string
+		status int
+	}{
+		{
+			name:   "default retention policy of the database",
+			body:   `{"type": "influxql", "query": "SELECT value FROM cpu", "cluster": "cluster", "db": "telegraf"}`,
+			bucket: bucketID.String(),
+			status: http.StatusOK,
+		},
+		{
+			name:   "retention policy of the measurement",
+			body:   `{"type": "influxql", "query": "SELECT value FROM telegraf.weekly.cpu", "cluster": "cluster", "bucket": "metrics"}`,
+			bucket: otherBucketID.String(),
+			status: http.StatusOK,
+		},
+		{
+			name:   "database that is not mapped",
+			body:   `{"type": "influxql", "query": "SELECT value FROM cpu", "cluster": "cluster", "db": "unknown"}`,
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewFluxHandler()
+			h.DBRPMappingService = dbrpSvc
+			h.OrganizationService = &mock.OrganizationService{
+				FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+					return &platform.Organization{ID: orgID}, nil
+				},
+			}
+			h.AuthorizationService = &mock.AuthorizationService{
+				FindAuthorizationByIDFn: func(ctx context.Context, id platform.ID) (*platform.Authorization, error) {
+					return &platform.Authorization{ID: id, UserID: testUserID, Status: platform.Active}, nil
+				},
+			}
+			h.ProxyQueryService = &querymock.ProxyQueryService{
+				QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (int64, error) {
+					spec, err := req.Request.Compiler.(*influxql.Compiler).Compile(ctx)
+					if err != nil {
+						return 0, err
+					}
+					for _, op := range spec.Operations {
+						if from, ok := op.Spec.(*inputs.FromOpSpec); ok && from.BucketID != tt.bucket {
+							t.Errorf("expected the query to read bucket %s, got %q", tt.bucket, from.BucketID)
+						}
+					}
+					n, err := io.WriteString(w, `{"results":[]}`)
+					return int64(n), err
+				},
+			}
+
+			r := httptest.NewRequest("POST", "http://any.url"+fluxPath+"?orgID="+orgID.String(), bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, withTestAuthorizer(r))
+
+			if got := w.Code; got != tt.status {
+				t.Errorf("handlePostQuery() = %d, want %d: %s", got, tt.status, w.Header().Get(ErrorHeader))
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/errors"
+)
+
+// queryParamsIdentifier is the identifier of the object a Flux query reads its params from.
+const queryParamsIdentifier = "v"
+
+var (
+	queryParamNameRegexp     = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	queryParamDurationRegexp = regexp.MustCompile(`^-?([0-9]+(ns|us|µs|μs|ms|mo|s|m|h|d|w|y))+$`)
+)
+
+// QueryMacro references a macro whose value a Flux query reads as v.<name>.
+type QueryMacro struct {
+	ID platform.ID `json:"id"`
+	// Value is used instead of the selected value of the macro and must be
+	// one of its values.
+	Value string `json:"value,omitempty"`
+}
+
+// QueryParams are the values a Flux query reads as the properties of v. The
+// Flux types of the values are their JSON types: strings, booleans, and
+// numbers, which are integers unless they have a fraction or an exponent.
+// Durations and times are objects giving their type explicitly, such as
+// {"type": "duration", "value": "-1h"}.
+type QueryParams map[string]interface{}
+
+// UnmarshalJSON decodes the params keeping the text of numbers so that
+// integers and floats are told apart.
+func (p *QueryParams) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil {
+		return err
+	}
+	*p = m
+	return nil
+}
+
+// Param types that are given explicitly.
+const (
+	queryParamDurationType = "duration"
+	queryParamDateTimeType = "dateTime"
+)
+
+// validateQueryParam returns an error if the param cannot be read by a
+// Flux query.
+func validateQueryParam(name string, value interface{}) error {
+	if !queryParamNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid param name %q: must be an identifier", name)
+	}
+	_, err := queryParamLiteral(name, value)
+	return err
+}
+
+// queryParamLiteral returns the Flux literal of the param.
+func queryParamLiteral(name string, value interface{}) (ast.Expression, error) {
+	switch v := value.(type) {
+	case string:
+		return &ast.StringLiteral{Value: v}, nil
+	case bool:
+		return &ast.BooleanLiteral{Value: v}, nil
+	case json.Number:
+		if !strings.ContainsAny(string(v), ".eE") {
+			i, err := v.Int64()
+			if err != nil {
+				return nil, fmt.Errorf("invalid integer param %q: %v", name, err)
+			}
+			return &ast.IntegerLiteral{Value: i}, nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid float param %q: %v", name, err)
+		}
+		return &ast.FloatLiteral{Value: f}, nil
+	case int:
+		return &ast.IntegerLiteral{Value: int64(v)}, nil
+	case int64:
+		return &ast.IntegerLiteral{Value: v}, nil
+	case float64:
+		return &ast.FloatLiteral{Value: v}, nil
+	case map[string]interface{}:
+		return typedQueryParamLiteral(name, v)
+	}
+	return nil, fmt.Errorf("param %q has unsupported type %T", name, value)
+}
+
+// typedQueryParamLiteral returns the Flux literal of a param whose type is
+// given explicitly.
+func typedQueryParamLiteral(name string, param map[string]interface{}) (ast.Expression, error) {
+	typ, _ := param["type"].(string)
+	v, ok := param["value"].(string)
+	if !ok || len(param) != 2 {
+		return nil, fmt.Errorf("param %q must have a type and a string value", name)
+	}
+	switch typ {
+	case queryParamDurationType:
+		if !queryParamDurationRegexp.MatchString(v) {
+			return nil, fmt.Errorf("invalid duration param %q: %q is not a duration", name, v)
+		}
+		p, err := parser.NewAST(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration param %q: %v", name, err)
+		}
+		return p.Body[0].(*ast.ExpressionStatement).Expression, nil
+	case queryParamDateTimeType:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dateTime param %q: %v", name, err)
+		}
+		return &ast.DateTimeLiteral{Value: t}, nil
+	}
+	return nil, fmt.Errorf("param %q has unsupported type %q", name, typ)
+}
+
+// withQueryParams returns the program preceded by the declaration of the
+// params as the properties of v.
+func withQueryParams(p *ast.Program, params QueryParams) (*ast.Program, error) {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	obj := &ast.ObjectExpression{
+		Properties: make([]*ast.Property, 0, len(names)),
+	}
+	for _, name := range names {
+		lit, err := queryParamLiteral(name, params[name])
+		if err != nil {
+			return nil, err
+		}
+		obj.Properties = append(obj.Properties, &ast.Property{
+			Key:   &ast.Identifier{Name: name},
+			Value: lit,
+		})
+	}
+
+	return declareQueryParams(p, obj), nil
+}
+
+// declareQueryParams returns the program preceded by the declaration of v as
+// the object of the params.
+func declareQueryParams(p *ast.Program, obj *ast.ObjectExpression) *ast.Program {
+	decl := &ast.VariableDeclaration{
+		Declarations: []*ast.VariableDeclarator{{
+			ID:   &ast.Identifier{Name: queryParamsIdentifier},
+			Init: obj,
+		}},
+	}
+	return &ast.Program{
+		BaseNode: p.BaseNode,
+		Body:     append([]ast.Statement{decl}, p.Body...),
+	}
+}
+
+// resolveQueryMacros adds the values of the macros the request references to
+// its params, once validated against the values of the macros.
+func (h *FluxHandler) resolveQueryMacros(ctx context.Context, auth *platform.Authorization, req *QueryRequest) error {
+	if len(req.Macros) == 0 {
+		return nil
+	}
+	if h.MacroService == nil {
+		return errors.InvalidDataf("queries cannot reference macros")
+	}
+
+	macros := make([]*platform.Macro, 0, len(req.Macros))
+	selected := map[string]string{}
+	for _, ref := range req.Macros {
+		m, err := h.MacroService.FindMacroByID(ctx, ref.ID)
+		if err != nil {
+			return err
+		}
+		if m.OrganizationID != req.org.ID {
+			return errors.InvalidDataf("macro %s does not belong to organization %s", m.ID, req.org.ID)
+		}
+		if ref.Value != "" {
+			selected[m.Name] = ref.Value
+		}
+		macros = append(macros, m)
+	}
+
+	resolver, err := newMacroResolver(ctx, h.MacroService, h.ProxyQueryService, h.DBRPMappingService, h.BucketService, h.SchemaQuerier, auth, req.org.ID, selected)
+	if err != nil {
+		return err
+	}
+
+	if req.Params == nil {
+		req.Params = make(QueryParams, len(macros))
+	}
+	for _, m := range macros {
+		if _, ok := req.Params[m.Name]; ok {
+			return errors.InvalidDataf("macro %q conflicts with the param of the same name", m.Name)
+		}
+		v, err := resolver.selectedValue(ctx, m)
+		if err != nil {
+			return err
+		}
+		if err := validateQueryParam(m.Name, v); err != nil {
+			return errors.InvalidDataf("macro %q cannot be read by the query: %v", m.Name, err)
+		}
+		req.Params[m.Name] = v
+	}
+	return nil
+}
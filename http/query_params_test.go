@@ -0,0 +1,291 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/inmem"
+)
+
+func TestWithQueryParams(t *testing.T) {
+	start := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	var params QueryParams
+	if err := json.Unmarshal([]byte(`{
+		"bucket": "telegraf",
+		"notDuration": "-1h",
+		"notTime": "2018-10-01T00:00:00Z",
+		"timeRangeStart": {"type": "duration", "value": "-1h"},
+		"timeRangeStop": {"type": "dateTime", "value": "2018-10-01T00:00:00Z"},
+		"limit": 10,
+		"ratio": 0.5,
+		"scale": 1e3,
+		"raw": true
+	}`), &params); err != nil {
+		t.Fatal(err)
+	}
+	p, err := withQueryParams(&ast.Program{}, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decl := p.Body[0].(*ast.VariableDeclaration).Declarations[0]
+	if decl.ID.Name != "v" {
+		t.Fatalf("expected params to be declared as v, got %s", decl.ID.Name)
+	}
+	got := map[string]ast.Expression{}
+	for _, prop := range decl.Init.(*ast.ObjectExpression).Properties {
+		got[prop.Key.Name] = prop.Value
+	}
+	want := map[string]ast.Expression{
+		"bucket":      &ast.StringLiteral{Value: "telegraf"},
+		"notDuration": &ast.StringLiteral{Value: "-1h"},
+		"notTime":     &ast.StringLiteral{Value: "2018-10-01T00:00:00Z"},
+		"timeRangeStart": &ast.UnaryExpression{
+			Operator: ast.SubtractionOperator,
+			Argument: &ast.DurationLiteral{Values: []ast.Duration{{Magnitude: 1, Unit: "h"}}},
+		},
+		"timeRangeStop": &ast.DateTimeLiteral{Value: start},
+		"limit":         &ast.IntegerLiteral{Value: 10},
+		"ratio":         &ast.FloatLiteral{Value: 0.5},
+		"scale":         &ast.FloatLiteral{Value: 1000},
+		"raw":           &ast.BooleanLiteral{Value: true},
+	}
+	for name, w := range want {
+		g, ok := got[name]
+		if !ok {
+			t.Errorf("missing param %s", name)
+			continue
+		}
+		if g.Type() != w.Type() {
+			t.Errorf("param %s is a %s, want %s", name, g.Type(), w.Type())
+		}
+	}
+	if s := got["bucket"].(*ast.StringLiteral).Value; s != "telegraf" {
+		t.Errorf("bucket = %q, want telegraf", s)
+	}
+	if ts := got["timeRangeStop"].(*ast.DateTimeLiteral).Value; !ts.Equal(start) {
+		t.Errorf("timeRangeStop = %v, want %v", ts, start)
+	}
+	if i := got["limit"].(*ast.IntegerLiteral).Value; i != 10 {
+		t.Errorf("limit = %d, want 10", i)
+	}
+}
+
+func TestQueryRequest_proxyRequestParams(t *testing.T) {
+	finalizeTestBuiltIns()
+	tests := []struct {
+		name    string
+		req     QueryRequest
+		wantErr bool
+	}{
+		{
+			name: "params of a query",
+			req: QueryRequest{
+				Query:  `x = v.bucket + ""`,
+				Params: QueryParams{"bucket": "telegraf"},
+			},
+		},
+		{
+			name: "params of an AST",
+			req: QueryRequest{
+				AST: &ast.Program{
+					Body: []ast.Statement{
+						&ast.ExpressionStatement{
+							Expression: &ast.MemberExpression{
+								Object:   &ast.Identifier{Name: "v"},
+								Property: &ast.Identifier{Name: "bucket"},
+							},
+						},
+					},
+				},
+				Params: QueryParams{"bucket": "telegraf"},
+			},
+		},
+		{
+			name: "params of a spec",
+			req: QueryRequest{
+				Spec:   &flux.Spec{},
+				Params: QueryParams{"bucket": "telegraf"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "param that is not an identifier",
+			req: QueryRequest{
+				Query:  `x = 1`,
+				Params: QueryParams{"my-bucket": "telegraf"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "param of an unsupported type",
+			req: QueryRequest{
+				Query:  `x = 1`,
+				Params: QueryParams{"hosts": []interface{}{"a", "b"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "param of an unknown explicit type",
+			req: QueryRequest{
+				Query:  `x = 1`,
+				Params: QueryParams{"start": map[string]interface{}{"type": "time", "value": "-1h"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duration param that is not a duration",
+			req: QueryRequest{
+				Query:  `x = 1`,
+				Params: QueryParams{"start": map[string]interface{}{"type": "duration", "value": "1h) |> yield("}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "query referencing a missing param",
+			req: QueryRequest{
+				Query:  `x = v.host + ""`,
+				Params: QueryParams{"bucket": "telegraf"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.req.WithDefaults()
+			r.org = &platform.Organization{ID: platform.ID(0x020f755c3c082000)}
+			got, err := r.proxyRequest(func() time.Time { return time.Unix(0, 0) })
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("proxyRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if _, ok := got.Request.Compiler.(lang.SpecCompiler); !ok {
+				t.Errorf("expected a query with params to be compiled to a spec, got %T", got.Request.Compiler)
+			}
+		})
+	}
+}
+
+func TestFluxHandler_resolveQueryMacros(t *testing.T) {
+	orgID := platform.ID(0x020f755c3c082000)
+	otherOrgID := platform.ID(0x020f755c3c082001)
+
+	tests := []struct {
+		name    string
+		params  QueryParams
+		macros  func(ids map[string]platform.ID) []QueryMacro
+		want    QueryParams
+		wantErr bool
+	}{
+		{
+			name: "selected values",
+			macros: func(ids map[string]platform.ID) []QueryMacro {
+				return []QueryMacro{{ID: ids["host"]}, {ID: ids["region"]}}
+			},
+			want: QueryParams{"host": "b", "region": "eu-west-1"},
+		},
+		{
+			name:   "value overridden by the request",
+			params: QueryParams{"bucket": "telegraf"},
+			macros: func(ids map[string]platform.ID) []QueryMacro {
+				return []QueryMacro{{ID: ids["host"], Value: "a"}}
+			},
+			want: QueryParams{"bucket": "telegraf", "host": "a"},
+		},
+		{
+			name: "value that is not a value of the macro",
+			macros: func(ids map[string]platform.ID) []QueryMacro {
+				return []QueryMacro{{ID: ids["host"], Value: "c"}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "macro of another organization",
+			macros: func(ids map[string]platform.ID) []QueryMacro {
+				return []QueryMacro{{ID: ids["other"]}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "macro whose name is not an identifier",
+			macros: func(ids map[string]platform.ID) []QueryMacro {
+				return []QueryMacro{{ID: ids["my-host"]}}
+			},
+			wantErr: true,
+		},
+		{
+			name:   "macro named as a param",
+			params: QueryParams{"host": "a"},
+			macros: func(ids map[string]platform.ID) []QueryMacro {
+				return []QueryMacro{{ID: ids["host"]}}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := inmem.NewService()
+			ids := map[string]platform.ID{}
+			for _, m := range []*platform.Macro{
+				{
+					OrganizationID: orgID,
+					Name:           "host",
+					Selected:       []string{"b"},
+					Arguments:      &platform.MacroArguments{Type: "constant", Values: platform.MacroConstantValues{"a", "b"}},
+				},
+				{
+					OrganizationID: orgID,
+					Name:           "region",
+					Selected:       []string{"eu"},
+					Arguments:      &platform.MacroArguments{Type: "map", Values: platform.MacroMapValues{"eu": "eu-west-1", "us": "us-east-1"}},
+				},
+				{
+					OrganizationID: orgID,
+					Name:           "my-host",
+					Selected:       []string{"a"},
+					Arguments:      &platform.MacroArguments{Type: "constant", Values: platform.MacroConstantValues{"a"}},
+				},
+				{
+					OrganizationID: otherOrgID,
+					Name:           "other",
+					Selected:       []string{"a"},
+					Arguments:      &platform.MacroArguments{Type: "constant", Values: platform.MacroConstantValues{"a"}},
+				},
+			} {
+				if err := svc.CreateMacro(ctx, m); err != nil {
+					t.Fatal(err)
+				}
+				ids[m.Name] = m.ID
+			}
+
+			h := NewFluxHandler()
+			h.MacroService = svc
+			req := &QueryRequest{
+				Query:  `x = 1`,
+				Params: tt.params,
+				Macros: tt.macros(ids),
+				org:    &platform.Organization{ID: orgID},
+			}
+			err := h.resolveQueryMacros(ctx, &platform.Authorization{UserID: testUserID, Status: platform.Active}, req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveQueryMacros() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(req.Params, tt.want) {
+				t.Errorf("resolveQueryMacros() params = %v, want %v", req.Params, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -16,6 +17,9 @@ import (
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/mock"
 	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/dialect/arrow"
+	"github.com/influxdata/platform/query/dialect/lineprotocol"
+	"github.com/influxdata/platform/query/influxql"
 )
 
 func TestQueryRequest_WithDefaults(t *testing.T) {
@@ -90,6 +94,31 @@ func TestQueryRequest_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "influxql requires a query",
+			fields: fields{
+				Spec: &flux.Spec{},
+				Type: "influxql",
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "influxql dialect requires an influxql query",
+			fields: fields{
+				Query: "from()",
+				Type:  "flux",
+				Dialect: QueryDialect{
+					Type:           "influxql",
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "comment must be a single character",
 			fields: fields{
@@ -176,8 +205,15 @@ func TestQueryRequest_Validate(t *testing.T) {
 	}
 }
 
+var finalizeBuiltIns sync.Once
+
+// finalizeTestBuiltIns finalizes the Flux builtins once for the tests that compile programs.
+func finalizeTestBuiltIns() {
+	finalizeBuiltIns.Do(flux.FinalizeBuiltIns)
+}
+
 func Test_toSpec(t *testing.T) {
-	flux.FinalizeBuiltIns()
+	finalizeTestBuiltIns()
 	type args struct {
 		p   *ast.Program
 		now func() time.Time
@@ -460,10 +496,150 @@ func Test_decodeProxyQueryRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "dialect type",
+			args: args{
+				r: httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"query": "from()", "dialect": {"type": "lineprotocol"}}`)),
+				svc: &mock.OrganizationService{
+					FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+						return &platform.Organization{
+							ID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+						}, nil
+					},
+				},
+			},
+			want: &query.ProxyRequest{
+				Request: query.Request{
+					OrganizationID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+					Compiler: lang.FluxCompiler{
+						Query: "from()",
+					},
+				},
+				Dialect: &lineprotocol.Dialect{},
+			},
+		},
+		{
+			name: "arrow dialect type",
+			args: args{
+				r: httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"query": "from()", "dialect": {"type": "arrow"}}`)),
+				svc: &mock.OrganizationService{
+					FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+						return &platform.Organization{
+							ID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+						}, nil
+					},
+				},
+			},
+			want: &query.ProxyRequest{
+				Request: query.Request{
+					OrganizationID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+					Compiler: lang.FluxCompiler{
+						Query: "from()",
+					},
+				},
+				Dialect: &arrow.Dialect{},
+			},
+		},
+		{
+			name: "dialect of the accept header",
+			args: args{
+				r: func() *http.Request {
+					r := httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"query": "from()"}`))
+					r.Header.Set("Accept", "text/html, application/vnd.influx.arrow, application/json;q=0.9")
+					return r
+				}(),
+				svc: &mock.OrganizationService{
+					FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+						return &platform.Organization{
+							ID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+						}, nil
+					},
+				},
+			},
+			want: &query.ProxyRequest{
+				Request: query.Request{
+					OrganizationID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+					Compiler: lang.FluxCompiler{
+						Query: "from()",
+					},
+				},
+				Dialect: &arrow.Dialect{},
+			},
+		},
+		{
+			name: "influxql query",
+			args: args{
+				r: func() *http.Request {
+					r := httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"query": "SELECT value FROM cpu", "type": "influxql", "db": "telegraf", "rp": "autogen"}`))
+					r.Header.Set("Accept", "application/json")
+					return r
+				}(),
+				svc: &mock.OrganizationService{
+					FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+						return &platform.Organization{
+							ID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+						}, nil
+					},
+				},
+			},
+			want: &query.ProxyRequest{
+				Request: query.Request{
+					OrganizationID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+					Compiler: &influxql.Compiler{
+						DB:             "telegraf",
+						RP:             "autogen",
+						Query:          "SELECT value FROM cpu",
+						OrganizationID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+					},
+				},
+				Dialect: &influxql.Dialect{},
+			},
+		},
+		{
+			name: "influxql query of a bucket",
+			args: args{
+				r: httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"query": "SELECT value FROM cpu", "type": "influxql", "bucket": "telegraf", "dialect": {"type": "csv"}}`)),
+				svc: &mock.OrganizationService{
+					FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+						return &platform.Organization{
+							ID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+						}, nil
+					},
+				},
+			},
+			want: &query.ProxyRequest{
+				Request: query.Request{
+					OrganizationID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+					Compiler: &influxql.Compiler{
+						Bucket:         "telegraf",
+						Query:          "SELECT value FROM cpu",
+						OrganizationID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+					},
+				},
+				Dialect: csv.Dialect{
+					ResultEncoderConfig: csv.ResultEncoderConfig{
+						NoHeader:  false,
+						Delimiter: ',',
+					},
+				},
+			},
+		},
+		{
+			name: "unknown dialect type",
+			args: args{
+				r: httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"query": "from()", "dialect": {"type": "xml"}}`)),
+				svc: &mock.OrganizationService{
+					FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+						return &platform.Organization{}, nil
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := decodeProxyQueryRequest(tt.args.ctx, tt.args.r, tt.args.auth, tt.args.svc)
+			got, err := decodeProxyQueryRequest(tt.args.ctx, tt.args.r, tt.args.auth, tt.args.svc, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("decodeProxyQueryRequest() error = %v, wantErr %v", err, tt.wantErr)
 				return
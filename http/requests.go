@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/errors"
 )
 
 const (
@@ -30,3 +31,17 @@ func queryOrganization(ctx context.Context, r *http.Request, svc platform.Organi
 
 	return svc.FindOrganization(ctx, filter)
 }
+
+// decodeRequiredOrganizationID returns the ID of the organization of the request, which must be specified.
+func decodeRequiredOrganizationID(r *http.Request) (platform.ID, error) {
+	reqID := r.URL.Query().Get(OrgID)
+	if reqID == "" {
+		return 0, errors.InvalidDataf("organization is required")
+	}
+
+	var orgID platform.ID
+	if err := orgID.DecodeFromString(reqID); err != nil {
+		return 0, errors.InvalidDataf("invalid organization id: %v", err)
+	}
+	return orgID, nil
+}
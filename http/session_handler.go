@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/chronograf/oauth2"
+	kerrors "github.com/influxdata/platform/kit/errors"
 	"github.com/julienschmidt/httprouter"
 	"go.uber.org/zap"
 )
@@ -17,6 +20,9 @@ type SessionHandler struct {
 
 	BasicAuthService platform.BasicAuthService
 	SessionService   platform.SessionService
+
+	// OAuthMuxes serve the sign in with the OAuth2 providers by provider name.
+	OAuthMuxes map[string]oauth2.Mux
 }
 
 // NewSessionHandler returns a new instance of SessionHandler.
@@ -27,9 +33,97 @@ func NewSessionHandler() *SessionHandler {
 
 	h.HandlerFunc("POST", "/api/v2/signin", h.handleSignin)
 	h.HandlerFunc("POST", "/api/v2/signout", h.handleSignout)
+
+	h.HandlerFunc("GET", oauthPath, h.handleGetOAuthProviders)
+	h.HandlerFunc("GET", oauthLoginPath, h.handleOAuth(oauth2.Mux.Login))
+	h.HandlerFunc("GET", oauthCallbackPath, h.handleOAuth(oauth2.Mux.Callback))
+	h.HandlerFunc("GET", oauthLogoutPath, h.handleOAuthLogout)
 	return h
 }
 
+const (
+	oauthPath         = "/api/v2/oauth"
+	oauthLoginPath    = "/api/v2/oauth/:provider/login"
+	oauthCallbackPath = "/api/v2/oauth/:provider/callback"
+	oauthLogoutPath   = "/api/v2/oauth/:provider/logout"
+)
+
+type oauthProviderResponse struct {
+	Name  string            `json:"name"`
+	Links map[string]string `json:"links"`
+}
+
+type oauthProvidersResponse struct {
+	Providers []oauthProviderResponse `json:"providers"`
+}
+
+// handleGetOAuthProviders is the HTTP handler for the GET /api/v2/oauth route.
+func (h *SessionHandler) handleGetOAuthProviders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	names := make([]string, 0, len(h.OAuthMuxes))
+	for name := range h.OAuthMuxes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	res := oauthProvidersResponse{Providers: []oauthProviderResponse{}}
+	for _, name := range names {
+		res.Providers = append(res.Providers, oauthProviderResponse{
+			Name: name,
+			Links: map[string]string{
+				"login":    fmt.Sprintf("%s/%s/login", oauthPath, name),
+				"callback": fmt.Sprintf("%s/%s/callback", oauthPath, name),
+				"logout":   fmt.Sprintf("%s/%s/logout", oauthPath, name),
+			},
+		})
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handleOAuth returns the HTTP handler serving a route with the handler of the provider's mux.
+func (h *SessionHandler) handleOAuth(handler func(oauth2.Mux) http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		mux, err := h.oauthMux(r)
+		if err != nil {
+			EncodeError(r.Context(), err, w)
+			return
+		}
+		handler(mux).ServeHTTP(w, r)
+	}
+}
+
+// handleOAuthLogout is the HTTP handler for the GET /api/v2/oauth/:provider/logout route.
+func (h *SessionHandler) handleOAuthLogout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	mux, err := h.oauthMux(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if key, err := decodeCookieSession(ctx, r); err == nil {
+		if err := h.SessionService.ExpireSession(ctx, key); err != nil {
+			h.Logger.Info("failed to expire session", zap.String("handler", "oauth"), zap.Error(err))
+		}
+	}
+	mux.Logout().ServeHTTP(w, r)
+}
+
+func (h *SessionHandler) oauthMux(r *http.Request) (oauth2.Mux, error) {
+	name := httprouter.ParamsFromContext(r.Context()).ByName("provider")
+	mux, ok := h.OAuthMuxes[name]
+	if !ok {
+		return nil, kerrors.New(fmt.Sprintf("oauth provider %q is not configured", name), kerrors.NotFound)
+	}
+	return mux, nil
+}
+
 // handleSignin is the HTTP handler for the POST /signin route.
 func (h *SessionHandler) handleSignin(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
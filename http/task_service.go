@@ -3,8 +3,10 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/influxdata/platform"
 	pcontext "github.com/influxdata/platform/context"
@@ -64,6 +66,7 @@ func NewTaskHandler(logger *zap.Logger) *TaskHandler {
 	h.HandlerFunc("DELETE", tasksIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
 
 	h.HandlerFunc("GET", tasksIDRunsPath, h.handleGetRuns)
+	h.HandlerFunc("POST", tasksIDRunsPath, h.handleBackfillRuns)
 	h.HandlerFunc("GET", tasksIDRunsIDPath, h.handleGetRun)
 	h.HandlerFunc("POST", tasksIDRunsIDRetryPath, h.handleRetryRun)
 
@@ -542,7 +545,7 @@ func (h *TaskHandler) handleRetryRun(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	run, err := h.TaskService.RetryRun(ctx, req.RunID)
+	run, err := h.TaskService.RetryRun(ctx, req.TaskID, req.RunID)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -555,22 +558,94 @@ func (h *TaskHandler) handleRetryRun(w http.ResponseWriter, r *http.Request) {
 }
 
 type retryRunRequest struct {
-	RunID platform.ID
+	TaskID platform.ID
+	RunID  platform.ID
 }
 
 func decodeRetryRunRequest(ctx context.Context, r *http.Request) (*retryRunRequest, error) {
 	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("tid")
+	if tid == "" {
+		return nil, kerrors.InvalidDataf("you must provide a task ID")
+	}
 	id := params.ByName("rid")
 	if id == "" {
 		return nil, kerrors.InvalidDataf("you must provide a run ID")
 	}
 
-	var i platform.ID
+	var ti, i platform.ID
+	if err := ti.DecodeFromString(tid); err != nil {
+		return nil, err
+	}
 	if err := i.DecodeFromString(id); err != nil {
 		return nil, err
 	}
 
 	return &retryRunRequest{
-		RunID: i,
+		TaskID: ti,
+		RunID:  i,
 	}, nil
 }
+
+func (h *TaskHandler) handleBackfillRuns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeBackfillRunsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.TaskService.BackfillTask(ctx, req.TaskID, req.Start, req.End); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusAccepted, newBackfillRunsResponse(req)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+type backfillRunsRequest struct {
+	TaskID platform.ID
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+}
+
+func decodeBackfillRunsRequest(ctx context.Context, r *http.Request) (*backfillRunsRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("tid")
+	if id == "" {
+		return nil, kerrors.InvalidDataf("you must provide a task ID")
+	}
+
+	req := &backfillRunsRequest{}
+	if err := req.TaskID.DecodeFromString(id); err != nil {
+		return nil, err
+	}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return nil, kerrors.InvalidDataf("invalid backfill: %v", err)
+	}
+	if req.Start.IsZero() || req.End.IsZero() {
+		return nil, kerrors.InvalidDataf("backfill requires a start and an end")
+	}
+
+	return req, nil
+}
+
+type backfillRunsResponse struct {
+	Links map[string]string `json:"links"`
+	Start string            `json:"start"`
+	End   string            `json:"end"`
+}
+
+func newBackfillRunsResponse(req *backfillRunsRequest) *backfillRunsResponse {
+	return &backfillRunsResponse{
+		Links: map[string]string{
+			"runs": fmt.Sprintf("/api/v2/tasks/%s/runs", req.TaskID),
+		},
+		Start: req.Start.UTC().Format(time.RFC3339),
+		End:   req.End.UTC().Format(time.RFC3339),
+	}
+}
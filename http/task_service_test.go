@@ -0,0 +1,250 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/task"
+	"github.com/influxdata/platform/task/backend"
+	"go.uber.org/zap"
+)
+
+func TestTaskHandler_handleBackfillRuns(t *testing.T) {
+	finalizeTestBuiltIns()
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int
+		runs       []string
+	}{
+		{
+			name:       "backfill a past range",
+			body:       `{"start": "1970-01-01T00:01:00Z", "end": "1970-01-01T00:03:00Z"}`,
+			statusCode: http.StatusAccepted,
+			runs:       []string{"1970-01-01T00:01:00Z", "1970-01-01T00:02:00Z", "1970-01-01T00:03:00Z"},
+		},
+		{
+			name:       "backfill without an end",
+			body:       `{"start": "1970-01-01T00:01:00Z"}`,
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "backfill ending before it starts",
+			body:       `{"start": "1970-01-01T00:03:00Z", "end": "1970-01-01T00:01:00Z"}`,
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "backfill ending in the future",
+			body:       `{"start": "1970-01-01T00:01:00Z", "end": "2999-01-01T00:00:00Z"}`,
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "backfill with invalid json",
+			body:       `howdy`,
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := task.PlatformAdapter(backend.NewInMemStore(), backend.NewInMemRunReaderWriter())
+			tsk := &platform.Task{
+				Organization: platform.ID(0x020f755c3c082000),
+				Owner:        platform.User{ID: testUserID},
+				Flux: `option task = {name: "backfilled", cron: "* * * * *"}
+from(bucket: "b") |> range(start: -1m)`,
+			}
+			if err := svc.CreateTask(ctx, tsk); err != nil {
+				t.Fatal(err)
+			}
+
+			h := NewTaskHandler(zap.NewNop())
+			h.TaskService = svc
+			h.AuthorizationService = &mock.AuthorizationService{
+				FindAuthorizationByTokenFn: func(ctx context.Context, token string) (*platform.Authorization, error) {
+					return &platform.Authorization{UserID: testUserID, Status: platform.Active}, nil
+				},
+			}
+
+			r := httptest.NewRequest("POST", fmt.Sprintf("http://any.url/api/v2/tasks/%s/runs", tsk.ID), bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if got := w.Code; got != tt.statusCode {
+				t.Fatalf("handleBackfillRuns() = %d, want %d: %s", got, tt.statusCode, w.Header().Get(ErrorHeader))
+			}
+			if tt.statusCode != http.StatusAccepted {
+				return
+			}
+
+			// The queued runs are listed as scheduled.
+			r = httptest.NewRequest("GET", fmt.Sprintf("http://any.url/api/v2/tasks/%s/runs", tsk.ID), nil)
+			r.Header.Set("Authorization", "Token secret")
+			w = httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			var runs []*platform.Run
+			if err := json.NewDecoder(w.Body).Decode(&runs); err != nil {
+				t.Fatal(err)
+			}
+			if len(runs) != len(tt.runs) {
+				t.Fatalf("handleGetRuns() returned %d runs, want %d", len(runs), len(tt.runs))
+			}
+			for i, run := range runs {
+				if run.Status != "scheduled" || run.ScheduledFor != tt.runs[i] {
+					t.Errorf("run %d is %s for %s, want scheduled for %s", i, run.Status, run.ScheduledFor, tt.runs[i])
+				}
+				if _, err := time.Parse(time.RFC3339, run.RequestedAt); err != nil {
+					t.Errorf("run %d has invalid requested time %q", i, run.RequestedAt)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskHandler_handleRetryRun(t *testing.T) {
+	finalizeTestBuiltIns()
+	ctx := context.Background()
+	rw := backend.NewInMemRunReaderWriter()
+	svc := task.PlatformAdapter(backend.NewInMemStore(), rw)
+	tsk := &platform.Task{
+		Organization: platform.ID(0x020f755c3c082000),
+		Owner:        platform.User{ID: testUserID},
+		Flux: `option task = {name: "retried", cron: "* * * * *"}
+from(bucket: "b") |> range(start: -1m)`,
+	}
+	if err := svc.CreateTask(ctx, tsk); err != nil {
+		t.Fatal(err)
+	}
+
+	// One run has finished and another is still running.
+	for _, run := range []struct {
+		id     platform.ID
+		status backend.RunStatus
+	}{
+		{id: 1, status: backend.RunSuccess},
+		{id: 2, status: backend.RunStarted},
+	} {
+		rlb := backend.RunLogBase{
+			Task:            &backend.StoreTask{ID: tsk.ID, Org: tsk.Organization},
+			RunID:           run.id,
+			RunScheduledFor: 60,
+		}
+		if err := rw.UpdateRunState(ctx, rlb, time.Unix(61, 0), run.status); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	h := NewTaskHandler(zap.NewNop())
+	h.TaskService = svc
+	h.AuthorizationService = &mock.AuthorizationService{
+		FindAuthorizationByTokenFn: func(ctx context.Context, token string) (*platform.Authorization, error) {
+			return &platform.Authorization{UserID: testUserID, Status: platform.Active}, nil
+		},
+	}
+
+	tests := []struct {
+		name       string
+		taskID     platform.ID
+		runID      platform.ID
+		statusCode int
+	}{
+		{
+			name:       "retry a finished run",
+			taskID:     tsk.ID,
+			runID:      1,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "retry a running run",
+			taskID:     tsk.ID,
+			runID:      2,
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "retry an unknown run",
+			taskID:     tsk.ID,
+			runID:      3,
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "retry a run of an unknown task",
+			taskID:     tsk.ID + 1,
+			runID:      1,
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", fmt.Sprintf("http://any.url/api/v2/tasks/%s/runs/%s/retry", tt.taskID, tt.runID), nil)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if got := w.Code; got != tt.statusCode {
+				t.Fatalf("handleRetryRun() = %d, want %d: %s", got, tt.statusCode, w.Header().Get(ErrorHeader))
+			}
+		})
+	}
+}
+
+func TestTaskHandler_handlePostTask(t *testing.T) {
+	finalizeTestBuiltIns()
+	const flux = `option task = {name: "created", cron: "* * * * *"}
+from(bucket: "b") |> range(start: -1m)`
+
+	tests := []struct {
+		name       string
+		task       platform.Task
+		statusCode int
+	}{
+		{
+			name:       "create a task",
+			task:       platform.Task{ScheduleAfter: "1970-01-01T00:01:00Z", CatchUp: "latest"},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:       "create a task with an invalid scheduleAfter",
+			task:       platform.Task{ScheduleAfter: "yesterday"},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "create a task with an unknown catchUp",
+			task:       platform.Task{CatchUp: "some"},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTaskHandler(zap.NewNop())
+			h.TaskService = task.PlatformAdapter(backend.NewInMemStore(), backend.NewInMemRunReaderWriter())
+
+			tsk := tt.task
+			tsk.Organization = platform.ID(0x020f755c3c082000)
+			tsk.Owner = platform.User{ID: testUserID}
+			tsk.Flux = flux
+			var b bytes.Buffer
+			if err := json.NewEncoder(&b).Encode(tsk); err != nil {
+				t.Fatal(err)
+			}
+
+			r := httptest.NewRequest("POST", "http://any.url/api/v2/tasks", &b)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if got := w.Code; got != tt.statusCode {
+				t.Fatalf("handlePostTask() = %d, want %d: %s", got, tt.statusCode, w.Header().Get(ErrorHeader))
+			}
+		})
+	}
+}
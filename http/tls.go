@@ -0,0 +1,59 @@
+package http
+
+import (
+	"crypto/tls"
+	"sync"
+)
+
+// CertificateLoader serves a TLS certificate and key loaded from files,
+// which may be reloaded to rotate the certificate without a restart.
+type CertificateLoader struct {
+	CertPath string
+	KeyPath  string
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+// NewCertificateLoader returns a CertificateLoader that loaded the certificate
+// and key at certPath and keyPath.
+func NewCertificateLoader(certPath, keyPath string) (*CertificateLoader, error) {
+	l := &CertificateLoader{
+		CertPath: certPath,
+		KeyPath:  keyPath,
+	}
+	if err := l.Reload(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Reload loads the certificate and key again. The previous certificate keeps
+// being served if they cannot be loaded.
+func (l *CertificateLoader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(l.CertPath, l.KeyPath)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cert = &cert
+	return nil
+}
+
+// GetCertificate returns the loaded certificate. It is meant to be used as
+// the GetCertificate function of a tls.Config.
+func (l *CertificateLoader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.cert, nil
+}
+
+// TLSConfig returns a TLS configuration serving the loaded certificate.
+func (l *CertificateLoader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: l.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+}
@@ -0,0 +1,102 @@
+package http
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCertificate writes a self-signed certificate for commonName and its key into dir.
+func writeCertificate(t *testing.T, dir, commonName string) (certPath, keyPath string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPath, keyPath = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certPath, keyPath
+}
+
+func commonName(t *testing.T, l *CertificateLoader) string {
+	t.Helper()
+	cert, err := l.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return x.Subject.CommonName
+}
+
+func TestCertificateLoader_Reload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "influxdb-tls-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	certPath, keyPath := writeCertificate(t, dir, "first")
+	l, err := NewCertificateLoader(certPath, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := commonName(t, l); got != "first" {
+		t.Fatalf("certificate = %q, want %q", got, "first")
+	}
+
+	writeCertificate(t, dir, "second")
+	if err := l.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if got := commonName(t, l); got != "second" {
+		t.Fatalf("certificate = %q, want %q", got, "second")
+	}
+
+	// A broken certificate is not served.
+	if err := ioutil.WriteFile(certPath, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Reload(); err == nil {
+		t.Fatal("expected error reloading a broken certificate")
+	}
+	if got := commonName(t, l); got != "second" {
+		t.Fatalf("certificate = %q, want %q", got, "second")
+	}
+
+	if _, err := NewCertificateLoader(filepath.Join(dir, "missing.pem"), keyPath); err == nil {
+		t.Fatal("expected error loading a missing certificate")
+	}
+}
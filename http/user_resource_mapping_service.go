@@ -281,3 +281,13 @@ func resourceIDPath(basePath string, resourceID platform.ID) string {
 func memberIDPath(basePath string, resourceID platform.ID, memberID platform.ID) string {
 	return path.Join(basePath, resourceID.String(), "members", memberID.String())
 }
+
+// createOwnerMapping makes the user an owner of the resource.
+func createOwnerMapping(ctx context.Context, svc platform.UserResourceMappingService, id platform.ID, typ platform.ResourceType, userID platform.ID) error {
+	return svc.CreateUserResourceMapping(ctx, &platform.UserResourceMapping{
+		ResourceID:   id,
+		ResourceType: typ,
+		UserID:       userID,
+		UserType:     platform.Owner,
+	})
+}
@@ -87,11 +87,21 @@ func newViewResponse(c *platform.View) viewResponse {
 	}
 }
 
-// handleGetViews returns all views within the store.
+// handleGetViews returns the views of an organization.
 func (h *ViewHandler) handleGetViews(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	// TODO(desa): support filtering via query params
-	views, _, err := h.ViewService.FindViews(ctx, platform.ViewFilter{})
+	req, err := decodeGetViewsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, *req.filter.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	views, _, err := h.ViewService.FindViews(ctx, req.filter)
 	if err != nil {
 		EncodeError(ctx, errors.InternalErrorf("Error loading views: %v", err), w)
 		return
@@ -103,6 +113,35 @@ func (h *ViewHandler) handleGetViews(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+type getViewsRequest struct {
+	filter platform.ViewFilter
+}
+
+func decodeGetViewsRequest(ctx context.Context, r *http.Request) (*getViewsRequest, error) {
+	qp := r.URL.Query()
+	req := &getViewsRequest{}
+
+	orgID, err := decodeRequiredOrganizationID(r)
+	if err != nil {
+		return nil, err
+	}
+	req.filter.OrganizationID = &orgID
+
+	if owner := qp.Get("owner"); owner != "" {
+		var i platform.ID
+		if err := i.DecodeFromString(owner); err != nil {
+			return nil, err
+		}
+		req.filter.Owner = &i
+	}
+
+	if prefix := qp.Get("prefix"); prefix != "" {
+		req.filter.NamePrefix = &prefix
+	}
+
+	return req, nil
+}
+
 type getViewsLinks struct {
 	Self string `json:"self"`
 }
@@ -136,6 +175,12 @@ func (h *ViewHandler) handlePostViews(w http.ResponseWriter, r *http.Request) {
 		EncodeError(ctx, err, w)
 		return
 	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, req.View.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.ViewService.CreateView(ctx, req.View); err != nil {
 		EncodeError(ctx, errors.InternalErrorf("Error loading views: %v", err), w)
 		return
@@ -156,6 +201,9 @@ func decodePostViewRequest(ctx context.Context, r *http.Request) (*postViewReque
 	if err := json.NewDecoder(r.Body).Decode(c); err != nil {
 		return nil, err
 	}
+	if !c.OrganizationID.Valid() {
+		return nil, errors.InvalidDataf("view requires an organization")
+	}
 	return &postViewRequest{
 		View: c,
 	}, nil
@@ -171,11 +219,8 @@ func (h *ViewHandler) handleGetView(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	view, err := h.ViewService.FindViewByID(ctx, req.ViewID)
+	view, err := h.findAuthorizedView(ctx, req.ViewID)
 	if err != nil {
-		if err == platform.ErrViewNotFound {
-			err = errors.New(err.Error(), errors.NotFound)
-		}
 		EncodeError(ctx, err, w)
 		return
 	}
@@ -186,6 +231,23 @@ func (h *ViewHandler) handleGetView(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// findAuthorizedView returns the view unless the user making the request
+// is not a member of its organization.
+func (h *ViewHandler) findAuthorizedView(ctx context.Context, id platform.ID) (*platform.View, error) {
+	v, err := h.ViewService.FindViewByID(ctx, id)
+	if err != nil {
+		if err == platform.ErrViewNotFound {
+			err = errors.New(err.Error(), errors.NotFound)
+		}
+		return nil, err
+	}
+
+	if err := authorizeOrganization(ctx, h.UserResourceMappingService, v.OrganizationID); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
 type getViewRequest struct {
 	ViewID platform.ID
 }
@@ -217,6 +279,11 @@ func (h *ViewHandler) handleDeleteView(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, err := h.findAuthorizedView(ctx, req.ViewID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.ViewService.DeleteView(ctx, req.ViewID); err != nil {
 		if err == platform.ErrViewNotFound {
 			err = errors.New(err.Error(), errors.NotFound)
@@ -258,6 +325,11 @@ func (h *ViewHandler) handlePatchView(w http.ResponseWriter, r *http.Request) {
 		EncodeError(ctx, err, w)
 		return
 	}
+
+	if _, err := h.findAuthorizedView(ctx, req.ViewID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
 	view, err := h.ViewService.UpdateView(ctx, req.ViewID, req.Upd)
 	if err != nil {
 		if err == platform.ErrViewNotFound {
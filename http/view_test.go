@@ -44,8 +44,9 @@ func TestService_handleGetViews(t *testing.T) {
 						return []*platform.View{
 							{
 								ViewContents: platform.ViewContents{
-									ID:   platformtesting.MustIDBase16("7365637465747572"),
-									Name: "hello",
+									ID:             platformtesting.MustIDBase16("7365637465747572"),
+									OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+									Name:           "hello",
 								},
 								Properties: platform.LineViewProperties{
 									Type: "line",
@@ -53,15 +54,20 @@ func TestService_handleGetViews(t *testing.T) {
 							},
 							{
 								ViewContents: platform.ViewContents{
-									ID:   platformtesting.MustIDBase16("6167697474697320"),
-									Name: "example",
+									ID:             platformtesting.MustIDBase16("6167697474697320"),
+									OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+									Name:           "example",
 								},
 							},
 						}, 2, nil
 					},
 				},
 			},
-			args: args{},
+			args: args{
+				queryParams: map[string][]string{
+					"organizationID": {"0b501e7e557ab1ed"},
+				},
+			},
 			wants: wants{
 				statusCode:  http.StatusOK,
 				contentType: "application/json; charset=utf-8",
@@ -73,6 +79,7 @@ func TestService_handleGetViews(t *testing.T) {
   "views": [
     {
       "id": "7365637465747572",
+      "organizationID": "0b501e7e557ab1ed",
       "name": "hello",
       "links": {
         "self": "/api/v2/views/7365637465747572"
@@ -88,6 +95,7 @@ func TestService_handleGetViews(t *testing.T) {
     },
     {
       "id": "6167697474697320",
+      "organizationID": "0b501e7e557ab1ed",
       "name": "example",
       "links": {
         "self": "/api/v2/views/6167697474697320"
@@ -109,7 +117,11 @@ func TestService_handleGetViews(t *testing.T) {
 					},
 				},
 			},
-			args: args{},
+			args: args{
+				queryParams: map[string][]string{
+					"organizationID": {"0b501e7e557ab1ed"},
+				},
+			},
 			wants: wants{
 				statusCode:  http.StatusOK,
 				contentType: "application/json; charset=utf-8",
@@ -122,12 +134,23 @@ func TestService_handleGetViews(t *testing.T) {
 }`,
 			},
 		},
+		{
+			name: "get views without an organization",
+			fields: fields{
+				&mock.ViewService{},
+			},
+			args: args{},
+			wants: wants{
+				statusCode: http.StatusUnprocessableEntity,
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewViewHandler()
 			h.ViewService = tt.fields.ViewService
+			h.UserResourceMappingService = memberMappingService()
 
 			r := httptest.NewRequest("GET", "http://any.url", nil)
 
@@ -141,7 +164,7 @@ func TestService_handleGetViews(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
-			h.handleGetViews(w, r)
+			h.handleGetViews(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			content := res.Header.Get("Content-Type")
@@ -187,8 +210,9 @@ func TestService_handleGetView(t *testing.T) {
 					FindViewByIDF: func(ctx context.Context, id platform.ID) (*platform.View, error) {
 						return &platform.View{
 							ViewContents: platform.ViewContents{
-								ID:   platformtesting.MustIDBase16("020f755c3c082000"),
-								Name: "example",
+								ID:             platformtesting.MustIDBase16("020f755c3c082000"),
+								OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+								Name:           "example",
 							},
 						}, nil
 					},
@@ -203,6 +227,7 @@ func TestService_handleGetView(t *testing.T) {
 				body: `
 {
   "id": "020f755c3c082000",
+  "organizationID": "0b501e7e557ab1ed",
   "name": "example",
   "links": {
     "self": "/api/v2/views/020f755c3c082000"
@@ -236,6 +261,7 @@ func TestService_handleGetView(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewViewHandler()
 			h.ViewService = tt.fields.ViewService
+			h.UserResourceMappingService = memberMappingService()
 
 			r := httptest.NewRequest("GET", "http://any.url", nil)
 
@@ -251,7 +277,7 @@ func TestService_handleGetView(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
-			h.handleGetView(w, r)
+			h.handleGetView(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			content := res.Header.Get("Content-Type")
@@ -302,8 +328,9 @@ func TestService_handlePostViews(t *testing.T) {
 			args: args{
 				view: &platform.View{
 					ViewContents: platform.ViewContents{
-						ID:   platformtesting.MustIDBase16("020f755c3c082000"),
-						Name: "hello",
+						ID:             platformtesting.MustIDBase16("020f755c3c082000"),
+						OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+						Name:           "hello",
 					},
 					Properties: platform.LineViewProperties{
 						Type: "line",
@@ -316,6 +343,7 @@ func TestService_handlePostViews(t *testing.T) {
 				body: `
 {
   "id": "020f755c3c082000",
+  "organizationID": "0b501e7e557ab1ed",
   "name": "hello",
   "links": {
     "self": "/api/v2/views/020f755c3c082000"
@@ -338,6 +366,7 @@ func TestService_handlePostViews(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewViewHandler()
 			h.ViewService = tt.fields.ViewService
+			h.UserResourceMappingService = memberMappingService()
 
 			b, err := json.Marshal(tt.args.view)
 			if err != nil {
@@ -347,7 +376,7 @@ func TestService_handlePostViews(t *testing.T) {
 			r := httptest.NewRequest("GET", "http://any.url", bytes.NewReader(b))
 			w := httptest.NewRecorder()
 
-			h.handlePostViews(w, r)
+			h.handlePostViews(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			content := res.Header.Get("Content-Type")
@@ -389,6 +418,14 @@ func TestService_handleDeleteView(t *testing.T) {
 			name: "remove a view by id",
 			fields: fields{
 				&mock.ViewService{
+					FindViewByIDF: func(ctx context.Context, id platform.ID) (*platform.View, error) {
+						return &platform.View{
+							ViewContents: platform.ViewContents{
+								ID:             id,
+								OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+							},
+						}, nil
+					},
 					DeleteViewF: func(ctx context.Context, id platform.ID) error {
 						if id == platformtesting.MustIDBase16("020f755c3c082000") {
 							return nil
@@ -409,8 +446,8 @@ func TestService_handleDeleteView(t *testing.T) {
 			name: "view not found",
 			fields: fields{
 				&mock.ViewService{
-					DeleteViewF: func(ctx context.Context, id platform.ID) error {
-						return platform.ErrViewNotFound
+					FindViewByIDF: func(ctx context.Context, id platform.ID) (*platform.View, error) {
+						return nil, platform.ErrViewNotFound
 					},
 				},
 			},
@@ -427,6 +464,7 @@ func TestService_handleDeleteView(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewViewHandler()
 			h.ViewService = tt.fields.ViewService
+			h.UserResourceMappingService = memberMappingService()
 
 			r := httptest.NewRequest("GET", "http://any.url", nil)
 
@@ -442,7 +480,7 @@ func TestService_handleDeleteView(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
-			h.handleDeleteView(w, r)
+			h.handleDeleteView(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			content := res.Header.Get("Content-Type")
@@ -486,12 +524,21 @@ func TestService_handlePatchView(t *testing.T) {
 			name: "update a view",
 			fields: fields{
 				&mock.ViewService{
+					FindViewByIDF: func(ctx context.Context, id platform.ID) (*platform.View, error) {
+						return &platform.View{
+							ViewContents: platform.ViewContents{
+								ID:             id,
+								OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+							},
+						}, nil
+					},
 					UpdateViewF: func(ctx context.Context, id platform.ID, upd platform.ViewUpdate) (*platform.View, error) {
 						if id == platformtesting.MustIDBase16("020f755c3c082000") {
 							return &platform.View{
 								ViewContents: platform.ViewContents{
-									ID:   platformtesting.MustIDBase16("020f755c3c082000"),
-									Name: "example",
+									ID:             platformtesting.MustIDBase16("020f755c3c082000"),
+									OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+									Name:           "example",
 								},
 								Properties: platform.LineViewProperties{
 									Type: "line",
@@ -513,6 +560,7 @@ func TestService_handlePatchView(t *testing.T) {
 				body: `
 {
   "id": "020f755c3c082000",
+  "organizationID": "0b501e7e557ab1ed",
   "name": "example",
   "links": {
     "self": "/api/v2/views/020f755c3c082000"
@@ -533,12 +581,21 @@ func TestService_handlePatchView(t *testing.T) {
 			name: "update a view with empty request body",
 			fields: fields{
 				&mock.ViewService{
+					FindViewByIDF: func(ctx context.Context, id platform.ID) (*platform.View, error) {
+						return &platform.View{
+							ViewContents: platform.ViewContents{
+								ID:             id,
+								OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+							},
+						}, nil
+					},
 					UpdateViewF: func(ctx context.Context, id platform.ID, upd platform.ViewUpdate) (*platform.View, error) {
 						if id == platformtesting.MustIDBase16("020f755c3c082000") {
 							return &platform.View{
 								ViewContents: platform.ViewContents{
-									ID:   platformtesting.MustIDBase16("020f755c3c082000"),
-									Name: "example",
+									ID:             platformtesting.MustIDBase16("020f755c3c082000"),
+									OrganizationID: platformtesting.MustIDBase16("0b501e7e557ab1ed"),
+									Name:           "example",
 								},
 								Properties: platform.LineViewProperties{
 									Type: "line",
@@ -561,7 +618,7 @@ func TestService_handlePatchView(t *testing.T) {
 			name: "view not found",
 			fields: fields{
 				&mock.ViewService{
-					UpdateViewF: func(ctx context.Context, id platform.ID, upd platform.ViewUpdate) (*platform.View, error) {
+					FindViewByIDF: func(ctx context.Context, id platform.ID) (*platform.View, error) {
 						return nil, platform.ErrViewNotFound
 					},
 				},
@@ -580,6 +637,7 @@ func TestService_handlePatchView(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewViewHandler()
 			h.ViewService = tt.fields.ViewService
+			h.UserResourceMappingService = memberMappingService()
 
 			upd := platform.ViewUpdate{}
 			if tt.args.name != "" {
@@ -608,7 +666,7 @@ func TestService_handlePatchView(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
-			h.handlePatchView(w, r)
+			h.handlePatchView(w, withTestAuthorizer(r))
 
 			res := w.Result()
 			content := res.Header.Get("Content-Type")
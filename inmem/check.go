@@ -0,0 +1,115 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform"
+)
+
+var (
+	_ platform.CheckService       = new(Service)
+	_ platform.CheckStatusService = new(Service)
+)
+
+// FindCheckByID returns a single check by ID.
+func (s *Service) FindCheckByID(ctx context.Context, id platform.ID) (*platform.Check, error) {
+	c, pErr := s.findCheckByID(ctx, id)
+	if pErr != nil {
+		pErr.Op = "inmem/find check by id"
+		return nil, pErr
+	}
+	return c, nil
+}
+
+func (s *Service) findCheckByID(ctx context.Context, id platform.ID) (*platform.Check, *platform.Error) {
+	if !id.Valid() {
+		return nil, &platform.Error{
+			Code: platform.EEmptyValue,
+			Err:  platform.ErrInvalidID,
+		}
+	}
+	result, found := s.checkKV.Load(id)
+	if !found {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("check with ID %v not found", id),
+		}
+	}
+	c := new(platform.Check)
+	*c = result.(platform.Check)
+	return c, nil
+}
+
+// FindChecks returns all checks that match filter.
+func (s *Service) FindChecks(ctx context.Context, filter platform.CheckFilter) ([]*platform.Check, error) {
+	checks := []*platform.Check{}
+	s.checkKV.Range(func(k, v interface{}) bool {
+		c := v.(platform.Check)
+		if (filter.ID == nil || c.ID == *filter.ID) &&
+			(filter.OrganizationID == nil || c.OrganizationID == *filter.OrganizationID) &&
+			(filter.TaskID == nil || c.TaskID == *filter.TaskID) {
+			checks = append(checks, &c)
+		}
+		return true
+	})
+	return checks, nil
+}
+
+// CreateCheck creates a new check and sets c.ID with the new identifier.
+func (s *Service) CreateCheck(ctx context.Context, c *platform.Check) error {
+	c.ID = s.IDGenerator.ID()
+	return s.PutCheck(ctx, c)
+}
+
+// PutCheck puts a check in the store, as is.
+func (s *Service) PutCheck(ctx context.Context, c *platform.Check) error {
+	s.checkKV.Store(c.ID, *c)
+	return nil
+}
+
+// UpdateCheck updates a single check with changeset.
+// Returns the new check state after update.
+func (s *Service) UpdateCheck(ctx context.Context, id platform.ID, upd platform.CheckUpdate) (*platform.Check, error) {
+	c, pErr := s.findCheckByID(ctx, id)
+	if pErr != nil {
+		pErr.Op = "inmem/update check"
+		return nil, pErr
+	}
+	upd.Apply(c)
+	s.checkKV.Store(c.ID, *c)
+	return c, nil
+}
+
+// DeleteCheck removes a check by ID.
+func (s *Service) DeleteCheck(ctx context.Context, id platform.ID) error {
+	if _, pErr := s.findCheckByID(ctx, id); pErr != nil {
+		pErr.Op = "inmem/delete check"
+		return pErr
+	}
+	s.checkKV.Delete(id)
+	s.checkStatusKV.Delete(id)
+	return nil
+}
+
+// FindCheckStatuses returns the last status of each series of the check.
+func (s *Service) FindCheckStatuses(ctx context.Context, checkID platform.ID) ([]*platform.CheckStatus, error) {
+	statuses := []*platform.CheckStatus{}
+	if result, found := s.checkStatusKV.Load(checkID); found {
+		for _, st := range result.([]platform.CheckStatus) {
+			st := st
+			statuses = append(statuses, &st)
+		}
+	}
+	return statuses, nil
+}
+
+// PutCheckStatuses replaces the statuses of the series of the check.
+func (s *Service) PutCheckStatuses(ctx context.Context, checkID platform.ID, statuses []*platform.CheckStatus) error {
+	stored := make([]platform.CheckStatus, len(statuses))
+	for i, st := range statuses {
+		stored[i] = *st
+	}
+	s.checkStatusKV.Store(checkID, stored)
+	return nil
+}
@@ -0,0 +1,33 @@
+package inmem
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initCheckService(f platformtesting.CheckFields, t *testing.T) (platform.CheckService, func()) {
+	return initCheckNStatusService(f, t)
+}
+
+func initCheckNStatusService(f platformtesting.CheckFields, t *testing.T) (platformtesting.CheckNStatusService, func()) {
+	s := NewService()
+	s.IDGenerator = f.IDGenerator
+	ctx := context.Background()
+	for _, c := range f.Checks {
+		if err := s.PutCheck(ctx, c); err != nil {
+			t.Fatalf("failed to populate checks")
+		}
+	}
+	return s, func() {}
+}
+
+func TestCheckService(t *testing.T) {
+	platformtesting.CheckService(initCheckService, t)
+}
+
+func TestCheckStatusService(t *testing.T) {
+	platformtesting.CheckStatuses(initCheckNStatusService, t)
+}
@@ -3,7 +3,7 @@ package inmem
 import (
 	"context"
 	"fmt"
-	"sync"
+	"strings"
 
 	"github.com/influxdata/platform"
 )
@@ -26,35 +26,49 @@ func (s *Service) FindDashboardByID(ctx context.Context, id platform.ID) (*platf
 	return s.loadDashboard(ctx, id)
 }
 
-func filterDashboardFn(filter platform.DashboardFilter) func(d *platform.Dashboard) bool {
+func filterDashboardFn(filter platform.DashboardFilter, owned map[platform.ID]bool) func(d *platform.Dashboard) bool {
+	var ids map[platform.ID]bool
 	if len(filter.IDs) > 0 {
-		var sm sync.Map
+		ids = make(map[platform.ID]bool, len(filter.IDs))
 		for _, id := range filter.IDs {
-			sm.Store(id.String(), true)
-		}
-		return func(d *platform.Dashboard) bool {
-			_, ok := sm.Load(d.ID.String())
-			return ok
+			ids[*id] = true
 		}
 	}
 
-	return func(d *platform.Dashboard) bool { return true }
+	return func(d *platform.Dashboard) bool {
+		return (ids == nil || ids[d.ID]) &&
+			(filter.OrganizationID == nil || d.OrganizationID == *filter.OrganizationID) &&
+			(filter.NamePrefix == nil || strings.HasPrefix(d.Name, *filter.NamePrefix)) &&
+			(filter.Owner == nil || owned[d.ID])
+	}
 }
 
 // FindDashboards implements platform.DashboardService interface.
 func (s *Service) FindDashboards(ctx context.Context, filter platform.DashboardFilter) ([]*platform.Dashboard, int, error) {
+	var owned map[platform.ID]bool
+	if filter.Owner != nil {
+		ids, err := s.ownedResourceIDs(ctx, *filter.Owner, platform.DashboardResourceType)
+		if err != nil {
+			return nil, 0, err
+		}
+		owned = ids
+	}
+	filterF := filterDashboardFn(filter, owned)
+
 	if len(filter.IDs) == 1 {
 		d, err := s.FindDashboardByID(ctx, *filter.IDs[0])
 		if err != nil {
 			return nil, 0, err
 		}
+		if !filterF(d) {
+			return []*platform.Dashboard{}, 0, nil
+		}
 
 		return []*platform.Dashboard{d}, 1, nil
 	}
 
 	var ds []*platform.Dashboard
 	var err error
-	filterF := filterDashboardFn(filter)
 	s.dashboardKV.Range(func(k, v interface{}) bool {
 		d, ok := v.(*platform.Dashboard)
 		if !ok {
@@ -111,7 +125,7 @@ func (s *Service) AddDashboardCell(ctx context.Context, id platform.ID, cell *pl
 		return err
 	}
 	cell.ID = s.IDGenerator.ID()
-	if err := s.createViewIfNotExists(ctx, cell, opts); err != nil {
+	if err := s.createViewIfNotExists(ctx, d.OrganizationID, cell, opts); err != nil {
 		return err
 	}
 
@@ -126,6 +140,7 @@ func (s *Service) PutDashboardCell(ctx context.Context, id platform.ID, cell *pl
 	}
 	view := &platform.View{}
 	view.ID = cell.ViewID
+	view.OrganizationID = d.OrganizationID
 	if err := s.PutView(ctx, view); err != nil {
 		return err
 	}
@@ -22,6 +22,11 @@ func initDashboardService(f platformtesting.DashboardFields, t *testing.T) (plat
 			t.Fatalf("failed to populate views")
 		}
 	}
+	for _, m := range f.UserResourceMappings {
+		if err := s.PutUserResourceMapping(ctx, m); err != nil {
+			t.Fatalf("failed to populate user resource mappings")
+		}
+	}
 	return s, func() {}
 }
 
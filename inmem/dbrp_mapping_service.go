@@ -119,7 +119,7 @@ func (s *Service) FindMany(ctx context.Context, filter platform.DBRPMappingFilte
 // Create creates a new dbrp mapping.
 func (s *Service) Create(ctx context.Context, m *platform.DBRPMapping) error {
 	if err := m.Validate(); err != nil {
-		return nil
+		return err
 	}
 	existing, err := s.loadDBRPMapping(ctx, m.Cluster, m.Database, m.RetentionPolicy)
 	if err != nil {
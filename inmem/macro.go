@@ -3,6 +3,7 @@ package inmem
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/influxdata/platform"
 	kerrors "github.com/influxdata/platform/kit/errors"
@@ -23,8 +24,27 @@ func (s *Service) FindMacroByID(ctx context.Context, id platform.ID) (*platform.
 	return macro, nil
 }
 
+func filterMacroFn(filter platform.MacroFilter, owned map[platform.ID]bool) func(m *platform.Macro) bool {
+	return func(m *platform.Macro) bool {
+		return (filter.ID == nil || m.ID == *filter.ID) &&
+			(filter.OrganizationID == nil || m.OrganizationID == *filter.OrganizationID) &&
+			(filter.NamePrefix == nil || strings.HasPrefix(m.Name, *filter.NamePrefix)) &&
+			(filter.Owner == nil || owned[m.ID])
+	}
+}
+
 // FindMacros implements the platform.MacroService interface
-func (s *Service) FindMacros(ctx context.Context) ([]*platform.Macro, error) {
+func (s *Service) FindMacros(ctx context.Context, filter platform.MacroFilter) ([]*platform.Macro, error) {
+	var owned map[platform.ID]bool
+	if filter.Owner != nil {
+		ids, err := s.ownedResourceIDs(ctx, *filter.Owner, platform.MacroResourceType)
+		if err != nil {
+			return nil, err
+		}
+		owned = ids
+	}
+	filterF := filterMacroFn(filter, owned)
+
 	var err error
 	var macros []*platform.Macro
 	s.macroKV.Range(func(k, v interface{}) bool {
@@ -34,7 +54,9 @@ func (s *Service) FindMacros(ctx context.Context) ([]*platform.Macro, error) {
 			return false
 		}
 
-		macros = append(macros, macro)
+		if filterF(macro) {
+			macros = append(macros, macro)
+		}
 		return true
 	})
 
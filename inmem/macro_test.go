@@ -18,6 +18,11 @@ func initMacroService(f platformtesting.MacroFields, t *testing.T) (platform.Mac
 			t.Fatalf("failed to populate macros")
 		}
 	}
+	for _, m := range f.UserResourceMappings {
+		if err := s.PutUserResourceMapping(ctx, m); err != nil {
+			t.Fatalf("failed to populate user resource mappings")
+		}
+	}
 
 	done := func() {}
 	return s, done
@@ -31,6 +36,10 @@ func TestMacroService_FindMacroByID(t *testing.T) {
 	platformtesting.FindMacroByID(initMacroService, t)
 }
 
+func TestMacroService_FindMacros(t *testing.T) {
+	platformtesting.FindMacros(initMacroService, t)
+}
+
 func TestMacroService_UpdateMacro(t *testing.T) {
 	platformtesting.UpdateMacro(initMacroService, t)
 }
@@ -0,0 +1,88 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.NotificationEndpointService = new(Service)
+
+// FindNotificationEndpointByID returns a single notification endpoint by ID.
+func (s *Service) FindNotificationEndpointByID(ctx context.Context, id platform.ID) (*platform.NotificationEndpoint, error) {
+	e, pErr := s.findNotificationEndpointByID(ctx, id)
+	if pErr != nil {
+		pErr.Op = "inmem/find notification endpoint by id"
+		return nil, pErr
+	}
+	return e, nil
+}
+
+func (s *Service) findNotificationEndpointByID(ctx context.Context, id platform.ID) (*platform.NotificationEndpoint, *platform.Error) {
+	if !id.Valid() {
+		return nil, &platform.Error{
+			Code: platform.EEmptyValue,
+			Err:  platform.ErrInvalidID,
+		}
+	}
+	result, found := s.notificationEndpointKV.Load(id)
+	if !found {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("notification endpoint with ID %v not found", id),
+		}
+	}
+	e := new(platform.NotificationEndpoint)
+	*e = result.(platform.NotificationEndpoint)
+	return e, nil
+}
+
+// FindNotificationEndpoints returns all notification endpoints that match filter.
+func (s *Service) FindNotificationEndpoints(ctx context.Context, filter platform.NotificationEndpointFilter) ([]*platform.NotificationEndpoint, error) {
+	endpoints := []*platform.NotificationEndpoint{}
+	s.notificationEndpointKV.Range(func(k, v interface{}) bool {
+		e := v.(platform.NotificationEndpoint)
+		if (filter.ID == nil || e.ID == *filter.ID) &&
+			(filter.OrganizationID == nil || e.OrganizationID == *filter.OrganizationID) {
+			endpoints = append(endpoints, &e)
+		}
+		return true
+	})
+	return endpoints, nil
+}
+
+// CreateNotificationEndpoint creates a new notification endpoint and sets e.ID with the new identifier.
+func (s *Service) CreateNotificationEndpoint(ctx context.Context, e *platform.NotificationEndpoint) error {
+	e.ID = s.IDGenerator.ID()
+	return s.PutNotificationEndpoint(ctx, e)
+}
+
+// PutNotificationEndpoint puts a notification endpoint in the store, as is.
+func (s *Service) PutNotificationEndpoint(ctx context.Context, e *platform.NotificationEndpoint) error {
+	s.notificationEndpointKV.Store(e.ID, *e)
+	return nil
+}
+
+// UpdateNotificationEndpoint updates a single notification endpoint with changeset.
+// Returns the new notification endpoint state after update.
+func (s *Service) UpdateNotificationEndpoint(ctx context.Context, id platform.ID, upd platform.NotificationEndpointUpdate) (*platform.NotificationEndpoint, error) {
+	e, pErr := s.findNotificationEndpointByID(ctx, id)
+	if pErr != nil {
+		pErr.Op = "inmem/update notification endpoint"
+		return nil, pErr
+	}
+	upd.Apply(e)
+	s.notificationEndpointKV.Store(e.ID, *e)
+	return e, nil
+}
+
+// DeleteNotificationEndpoint removes a notification endpoint by ID.
+func (s *Service) DeleteNotificationEndpoint(ctx context.Context, id platform.ID) error {
+	if _, pErr := s.findNotificationEndpointByID(ctx, id); pErr != nil {
+		pErr.Op = "inmem/delete notification endpoint"
+		return pErr
+	}
+	s.notificationEndpointKV.Delete(id)
+	return nil
+}
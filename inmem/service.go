@@ -10,17 +10,21 @@ import (
 
 // Service implements various top level services.
 type Service struct {
-	authorizationKV       sync.Map
-	organizationKV        sync.Map
-	bucketKV              sync.Map
-	userKV                sync.Map
-	dashboardKV           sync.Map
-	viewKV                sync.Map
-	macroKV               sync.Map
-	dbrpMappingKV         sync.Map
-	userResourceMappingKV sync.Map
-	scraperTargetKV       sync.Map
-	telegrafConfigKV      sync.Map
+	authorizationKV        sync.Map
+	organizationKV         sync.Map
+	bucketKV               sync.Map
+	userKV                 sync.Map
+	dashboardKV            sync.Map
+	viewKV                 sync.Map
+	macroKV                sync.Map
+	dbrpMappingKV          sync.Map
+	userResourceMappingKV  sync.Map
+	scraperTargetKV        sync.Map
+	telegrafConfigKV       sync.Map
+	checkKV                sync.Map
+	checkStatusKV          sync.Map
+	notificationEndpointKV sync.Map
+	userIdentityKV         sync.Map
 
 	TokenGenerator platform.TokenGenerator
 	IDGenerator    platform.IDGenerator
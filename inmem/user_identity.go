@@ -0,0 +1,39 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.UserIdentityService = new(Service)
+
+// FindUserIdentity returns the identity of the subject at the provider.
+func (s *Service) FindUserIdentity(ctx context.Context, provider, subject string) (*platform.UserIdentity, error) {
+	v, ok := s.userIdentityKV.Load(provider + "\x00" + subject)
+	if !ok {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Op:   "inmem/find user identity",
+			Msg:  fmt.Sprintf("identity %s of provider %s not found", subject, provider),
+		}
+	}
+	i := v.(platform.UserIdentity)
+	return &i, nil
+}
+
+// PutUserIdentity links the identity to its user, replacing any previous link.
+func (s *Service) PutUserIdentity(ctx context.Context, i *platform.UserIdentity) error {
+	s.userIdentityKV.Store(i.Provider+"\x00"+i.Subject, *i)
+	return nil
+}
+
+func (s *Service) deleteUserIdentities(userID platform.ID) {
+	s.userIdentityKV.Range(func(k, v interface{}) bool {
+		if v.(platform.UserIdentity).UserID == userID {
+			s.userIdentityKV.Delete(k)
+		}
+		return true
+	})
+}
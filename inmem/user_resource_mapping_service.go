@@ -84,6 +84,24 @@ func (s *Service) FindUserResourceMappings(ctx context.Context, filter platform.
 	return mappings, len(mappings), nil
 }
 
+// ownedResourceIDs returns the IDs of the resources of type typ owned by the user.
+func (s *Service) ownedResourceIDs(ctx context.Context, userID platform.ID, typ platform.ResourceType) (map[platform.ID]bool, error) {
+	ms, _, err := s.FindUserResourceMappings(ctx, platform.UserResourceMappingFilter{
+		UserID:       userID,
+		UserType:     platform.Owner,
+		ResourceType: typ,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[platform.ID]bool, len(ms))
+	for _, m := range ms {
+		ids[m.ResourceID] = true
+	}
+	return ids, nil
+}
+
 // TODO(jm): remove this once etcd is no longer using it
 func (s *Service) FindManyUserResourceMappings(ctx context.Context, filter platform.UserResourceMappingFilter, opt ...platform.FindOptions) ([]*platform.UserResourceMapping, int, error) {
 	return s.FindUserResourceMappings(ctx, filter)
@@ -137,5 +137,6 @@ func (s *Service) DeleteUser(ctx context.Context, id platform.ID) error {
 		return err
 	}
 	s.userKV.Delete(id.String())
+	s.deleteUserIdentities(id)
 	return nil
 }
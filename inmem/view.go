@@ -3,6 +3,7 @@ package inmem
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/influxdata/platform"
 )
@@ -25,30 +26,41 @@ func (s *Service) FindViewByID(ctx context.Context, id platform.ID) (*platform.V
 	return s.loadView(ctx, id)
 }
 
-func filterViewFn(filter platform.ViewFilter) func(d *platform.View) bool {
-	if filter.ID != nil {
-		return func(d *platform.View) bool {
-			return d.ID == *filter.ID
-		}
+func filterViewFn(filter platform.ViewFilter, owned map[platform.ID]bool) func(d *platform.View) bool {
+	return func(d *platform.View) bool {
+		return (filter.ID == nil || d.ID == *filter.ID) &&
+			(filter.OrganizationID == nil || d.OrganizationID == *filter.OrganizationID) &&
+			(filter.NamePrefix == nil || strings.HasPrefix(d.Name, *filter.NamePrefix)) &&
+			(filter.Owner == nil || owned[d.ID])
 	}
-
-	return func(d *platform.View) bool { return true }
 }
 
 // FindViews implements platform.ViewService interface.
 func (s *Service) FindViews(ctx context.Context, filter platform.ViewFilter) ([]*platform.View, int, error) {
+	var owned map[platform.ID]bool
+	if filter.Owner != nil {
+		ids, err := s.ownedResourceIDs(ctx, *filter.Owner, platform.ViewResourceType)
+		if err != nil {
+			return nil, 0, err
+		}
+		owned = ids
+	}
+	filterF := filterViewFn(filter, owned)
+
 	if filter.ID != nil {
 		d, err := s.FindViewByID(ctx, *filter.ID)
 		if err != nil {
 			return nil, 0, err
 		}
+		if !filterF(d) {
+			return []*platform.View{}, 0, nil
+		}
 
 		return []*platform.View{d}, 1, nil
 	}
 
 	var ds []*platform.View
 	var err error
-	filterF := filterViewFn(filter)
 	s.viewKV.Range(func(k, v interface{}) bool {
 		d, ok := v.(*platform.View)
 		if !ok {
@@ -107,14 +119,26 @@ func (s *Service) DeleteView(ctx context.Context, id platform.ID) error {
 	return nil
 }
 
-func (s *Service) createViewIfNotExists(ctx context.Context, cell *platform.Cell, opts platform.AddDashboardCellOptions) error {
+// viewOrganizationError is returned when a cell of a dashboard is to show a view of another organization.
+func viewOrganizationError(viewID, orgID platform.ID) error {
+	return &platform.Error{
+		Code: platform.EInvalid,
+		Op:   "inmem/add dashboard cell",
+		Msg:  fmt.Sprintf("view %s does not belong to organization %s", viewID, orgID),
+	}
+}
+
+func (s *Service) createViewIfNotExists(ctx context.Context, orgID platform.ID, cell *platform.Cell, opts platform.AddDashboardCellOptions) error {
 	if opts.UsingView.Valid() {
 		// Creates a hard copy of a view
 		v, err := s.FindViewByID(ctx, opts.UsingView)
 		if err != nil {
 			return err
 		}
-		view, err := s.copyView(ctx, v.ID)
+		if v.OrganizationID != orgID {
+			return viewOrganizationError(v.ID, orgID)
+		}
+		view, err := s.copyView(ctx, v.ID, orgID)
 		if err != nil {
 			return err
 		}
@@ -122,15 +146,22 @@ func (s *Service) createViewIfNotExists(ctx context.Context, cell *platform.Cell
 		return nil
 	} else if cell.ViewID.Valid() {
 		// Creates a soft copy of a view
-		_, err := s.FindViewByID(ctx, cell.ViewID)
+		v, err := s.FindViewByID(ctx, cell.ViewID)
 		if err != nil {
 			return err
 		}
+		if v.OrganizationID != orgID {
+			return viewOrganizationError(v.ID, orgID)
+		}
 		return nil
 	}
 
 	// If not view exists create the view
-	view := &platform.View{}
+	view := &platform.View{
+		ViewContents: platform.ViewContents{
+			OrganizationID: orgID,
+		},
+	}
 	if err := s.CreateView(ctx, view); err != nil {
 		return err
 	}
@@ -139,7 +170,7 @@ func (s *Service) createViewIfNotExists(ctx context.Context, cell *platform.Cell
 	return nil
 }
 
-func (s *Service) copyView(ctx context.Context, id platform.ID) (*platform.View, error) {
+func (s *Service) copyView(ctx context.Context, id, orgID platform.ID) (*platform.View, error) {
 	v, err := s.FindViewByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -147,7 +178,8 @@ func (s *Service) copyView(ctx context.Context, id platform.ID) (*platform.View,
 
 	view := &platform.View{
 		ViewContents: platform.ViewContents{
-			Name: v.Name,
+			OrganizationID: orgID,
+			Name:           v.Name,
 		},
 		Properties: v.Properties,
 	}
@@ -17,6 +17,11 @@ func initViewService(f platformtesting.ViewFields, t *testing.T) (platform.ViewS
 			t.Fatalf("failed to populate Views")
 		}
 	}
+	for _, m := range f.UserResourceMappings {
+		if err := s.PutUserResourceMapping(ctx, m); err != nil {
+			t.Fatalf("failed to populate user resource mappings")
+		}
+	}
 	return s, func() {}
 }
 
@@ -0,0 +1,206 @@
+// Package check reports the health and readiness of the components of a process.
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Status is the status of a check.
+type Status string
+
+const (
+	// StatusPass is the status of a component that works.
+	StatusPass Status = "healthy"
+	// StatusFail is the status of a component that does not work.
+	StatusFail Status = "unhealthy"
+)
+
+// DefaultTimeout is the time the checks of a process may take to respond.
+const DefaultTimeout = 5 * time.Second
+
+// Response is the result of a check, which may hold the results of nested checks.
+type Response struct {
+	Name    string    `json:"name"`
+	Status  Status    `json:"status"`
+	Message string    `json:"message,omitempty"`
+	Checks  Responses `json:"checks,omitempty"`
+}
+
+// Responses is a list of check results sorted by name.
+type Responses []Response
+
+func (r Responses) Len() int           { return len(r) }
+func (r Responses) Less(i, j int) bool { return r[i].Name < r[j].Name }
+func (r Responses) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+
+// Pass returns the response of a check that passed.
+func Pass() Response {
+	return Response{Status: StatusPass}
+}
+
+// Info returns the response of a check that passed with a message.
+func Info(format string, args ...interface{}) Response {
+	return Response{Status: StatusPass, Message: fmt.Sprintf(format, args...)}
+}
+
+// Error returns the response of a check that failed because of err.
+func Error(err error) Response {
+	return Response{Status: StatusFail, Message: err.Error()}
+}
+
+// Checker reports the status of a component.
+// The name of the response is the name the checker is added with.
+type Checker interface {
+	Check(ctx context.Context) Response
+}
+
+// CheckerFunc is an adapter to allow a function to be used as a Checker.
+type CheckerFunc func(ctx context.Context) Response
+
+// Check calls fn(ctx).
+func (fn CheckerFunc) Check(ctx context.Context) Response {
+	return fn(ctx)
+}
+
+// ErrorFunc returns a Checker that fails when fn returns an error.
+func ErrorFunc(fn func(ctx context.Context) error) Checker {
+	return CheckerFunc(func(ctx context.Context) Response {
+		if err := fn(ctx); err != nil {
+			return Error(err)
+		}
+		return Pass()
+	})
+}
+
+type namedChecker struct {
+	name string
+	Checker
+}
+
+// Check holds the health and readiness checks of the components of a process.
+// A process is healthy while it works and may need to be restarted otherwise;
+// a process is ready once it can serve requests.
+type Check struct {
+	mu           sync.RWMutex
+	healthChecks []namedChecker
+	readyChecks  []namedChecker
+}
+
+// NewCheck returns a Check without any checks, which is healthy and ready.
+func NewCheck() *Check {
+	return &Check{}
+}
+
+// AddHealthCheck adds the check of the component called name to the health of the process.
+func (c *Check) AddHealthCheck(name string, chk Checker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.healthChecks = append(c.healthChecks, namedChecker{name: name, Checker: chk})
+}
+
+// AddReadyCheck adds the check of the component called name to the readiness of the process.
+func (c *Check) AddReadyCheck(name string, chk Checker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readyChecks = append(c.readyChecks, namedChecker{name: name, Checker: chk})
+}
+
+// CheckHealth runs the health checks and reports whether all of them passed.
+func (c *Check) CheckHealth(ctx context.Context) Response {
+	c.mu.RLock()
+	checks := c.healthChecks
+	c.mu.RUnlock()
+	return run(ctx, "health", checks)
+}
+
+// CheckReady runs the readiness checks and reports whether all of them passed.
+func (c *Check) CheckReady(ctx context.Context) Response {
+	c.mu.RLock()
+	checks := c.readyChecks
+	c.mu.RUnlock()
+	return run(ctx, "ready", checks)
+}
+
+// run runs checks concurrently. The status of a check that does not respond
+// before ctx is done is failed.
+func run(ctx context.Context, name string, checks []namedChecker) Response {
+	res := Response{
+		Name:   name,
+		Status: StatusPass,
+		Checks: make(Responses, len(checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, chk := range checks {
+		wg.Add(1)
+		go func(i int, chk namedChecker) {
+			defer wg.Done()
+			res.Checks[i] = runOne(ctx, chk)
+		}(i, chk)
+	}
+	wg.Wait()
+
+	sort.Sort(res.Checks)
+	for _, r := range res.Checks {
+		if r.Status != StatusPass {
+			res.Status = StatusFail
+		}
+	}
+	if res.Status == StatusPass {
+		res.Message = fmt.Sprintf("%s checks passed", name)
+	} else {
+		res.Message = fmt.Sprintf("%s checks failed", name)
+	}
+	return res
+}
+
+func runOne(ctx context.Context, chk namedChecker) Response {
+	ch := make(chan Response, 1)
+	go func() {
+		ch <- chk.Check(ctx)
+	}()
+
+	var r Response
+	select {
+	case r = <-ch:
+	case <-ctx.Done():
+		r = Response{Status: StatusFail, Message: fmt.Sprintf("check did not respond: %v", ctx.Err())}
+	}
+	r.Name = chk.name
+	return r
+}
+
+// HealthHandler returns an HTTP handler reporting the health of the process.
+// It responds 503 Service Unavailable when the process is unhealthy.
+func (c *Check) HealthHandler() http.Handler {
+	return handler(c.CheckHealth)
+}
+
+// ReadyHandler returns an HTTP handler reporting the readiness of the process.
+// It responds 503 Service Unavailable until the process is ready.
+func (c *Check) ReadyHandler() http.Handler {
+	return handler(c.CheckReady)
+}
+
+func handler(check func(context.Context) Response) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), DefaultTimeout)
+		defer cancel()
+
+		res := check(ctx)
+		code := http.StatusOK
+		if res.Status != StatusPass {
+			code = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(res)
+	})
+}
@@ -0,0 +1,104 @@
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCheck_HealthHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     map[string]Checker
+		statusCode int
+		want       Response
+	}{
+		{
+			name:       "no checks",
+			statusCode: http.StatusOK,
+			want: Response{
+				Name:    "health",
+				Status:  StatusPass,
+				Message: "health checks passed",
+			},
+		},
+		{
+			name: "all checks pass",
+			checks: map[string]Checker{
+				"b": CheckerFunc(func(context.Context) Response { return Info("loading") }),
+				"a": ErrorFunc(func(context.Context) error { return nil }),
+			},
+			statusCode: http.StatusOK,
+			want: Response{
+				Name:    "health",
+				Status:  StatusPass,
+				Message: "health checks passed",
+				Checks: Responses{
+					{Name: "a", Status: StatusPass},
+					{Name: "b", Status: StatusPass, Message: "loading"},
+				},
+			},
+		},
+		{
+			name: "a check fails",
+			checks: map[string]Checker{
+				"a": ErrorFunc(func(context.Context) error { return nil }),
+				"b": ErrorFunc(func(context.Context) error { return errors.New("down") }),
+			},
+			statusCode: http.StatusServiceUnavailable,
+			want: Response{
+				Name:    "health",
+				Status:  StatusFail,
+				Message: "health checks failed",
+				Checks: Responses{
+					{Name: "a", Status: StatusPass},
+					{Name: "b", Status: StatusFail, Message: "down"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCheck()
+			for name, chk := range tt.checks {
+				c.AddHealthCheck(name, chk)
+			}
+
+			w := httptest.NewRecorder()
+			c.HealthHandler().ServeHTTP(w, httptest.NewRequest("GET", "/health", nil))
+
+			if w.Code != tt.statusCode {
+				t.Errorf("HealthHandler() = %v, want %v", w.Code, tt.statusCode)
+			}
+			var got Response
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("HealthHandler() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck_CheckReadyTimeout(t *testing.T) {
+	c := NewCheck()
+	c.AddReadyCheck("slow", CheckerFunc(func(ctx context.Context) Response {
+		time.Sleep(time.Second)
+		return Pass()
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	res := c.CheckReady(ctx)
+	if res.Status != StatusFail || len(res.Checks) != 1 || res.Checks[0].Name != "slow" || res.Checks[0].Status != StatusFail {
+		t.Fatalf("expected the slow check to fail, got %+v", res)
+	}
+}
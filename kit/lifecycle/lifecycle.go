@@ -0,0 +1,66 @@
+// Package lifecycle shuts down the components of a process in order.
+package lifecycle
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Component is a component of a process that is closed on shutdown.
+type Component struct {
+	Name  string
+	Close func(ctx context.Context) error
+}
+
+// Manager closes the components of a process in the reverse order they were
+// added, so that a component is closed before the components it depends on.
+type Manager struct {
+	mu         sync.Mutex
+	components []Component
+	closed     bool
+
+	logger *zap.Logger
+}
+
+// NewManager returns a Manager without any components.
+func NewManager(logger *zap.Logger) *Manager {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &Manager{logger: logger}
+}
+
+// Add adds the component called name, which is closed by calling fn.
+// Components must be added once the components they depend on are added.
+func (m *Manager) Add(name string, fn func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.components = append(m.components, Component{Name: name, Close: fn})
+}
+
+// Shutdown closes the components in the reverse order they were added.
+// A component failing to close does not keep the others from being closed;
+// the first error is returned. Shutdown closes the components only once.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+
+	var firstErr error
+	for i := len(m.components) - 1; i >= 0; i-- {
+		c := m.components[i]
+		m.logger.Info("Stopping", zap.String("component", c.Name))
+		if err := c.Close(ctx); err != nil {
+			m.logger.Error("Failed to stop", zap.String("component", c.Name), zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,40 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestManager_Shutdown(t *testing.T) {
+	var closed []string
+	closer := func(name string, err error) func(context.Context) error {
+		return func(context.Context) error {
+			closed = append(closed, name)
+			return err
+		}
+	}
+
+	m := NewManager(nil)
+	m.Add("bolt", closer("bolt", nil))
+	m.Add("engine", closer("engine", errors.New("engine failed")))
+	m.Add("scheduler", closer("scheduler", errors.New("scheduler failed")))
+	m.Add("http", closer("http", nil))
+
+	err := m.Shutdown(context.Background())
+	if err == nil || err.Error() != "scheduler failed" {
+		t.Fatalf("Shutdown() = %v, want the first error", err)
+	}
+	if want := []string{"http", "scheduler", "engine", "bolt"}; !reflect.DeepEqual(closed, want) {
+		t.Fatalf("closed %v, want %v", closed, want)
+	}
+
+	closed = nil
+	if err := m.Shutdown(context.Background()); err != nil {
+		t.Fatalf("second Shutdown() = %v", err)
+	}
+	if len(closed) != 0 {
+		t.Fatalf("components closed twice: %v", closed)
+	}
+}
@@ -0,0 +1,176 @@
+// Package layouts creates platform dashboards from the canned layouts of chronograf.
+package layouts
+
+import (
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/chronograf"
+)
+
+const (
+	// autoflowColumns is the number of cells in a row of an autoflow layout.
+	autoflowColumns = 3
+	autoflowWidth   = 4
+	autoflowHeight  = 4
+)
+
+// cellView is a dashboard cell and the view it shows.
+type cellView struct {
+	Cell *platform.Cell
+	View *platform.View
+}
+
+// convert converts a layout into the cells of a dashboard and their views
+// querying bucket. The IDs of the cells and views are left unset.
+// Cells without a query that can be written in Flux are dropped.
+func convert(l chronograf.Layout, bucket string) []cellView {
+	cvs := make([]cellView, 0, len(l.Cells))
+	for _, c := range l.Cells {
+		queries := convertQueries(c.Queries, bucket)
+		if len(queries) == 0 {
+			continue
+		}
+
+		cell := &platform.Cell{X: c.X, Y: c.Y, W: c.W, H: c.H}
+		if l.Autoflow {
+			n := int32(len(cvs))
+			cell.X = n % autoflowColumns * autoflowWidth
+			cell.Y = n / autoflowColumns * autoflowHeight
+			cell.W = autoflowWidth
+			cell.H = autoflowHeight
+		}
+
+		cvs = append(cvs, cellView{
+			Cell: cell,
+			View: &platform.View{
+				ViewContents: platform.ViewContents{Name: c.Name},
+				Properties:   convertProperties(c, queries),
+			},
+		})
+	}
+	return cvs
+}
+
+// Template returns the dashboard template of a layout, or nil if none of its
+// cells can be converted.
+func Template(l chronograf.Layout) *platform.DashboardTemplate {
+	cvs := convert(l, "")
+	if len(cvs) == 0 {
+		return nil
+	}
+
+	t := &platform.DashboardTemplate{
+		ID:          l.ID,
+		Name:        l.Measurement,
+		Application: l.Application,
+		Measurement: l.Measurement,
+		Cells:       make([]*platform.DashboardTemplateCell, 0, len(cvs)),
+	}
+	for _, cv := range cvs {
+		t.Cells = append(t.Cells, &platform.DashboardTemplateCell{
+			Name: cv.View.Name,
+			X:    cv.Cell.X,
+			Y:    cv.Cell.Y,
+			W:    cv.Cell.W,
+			H:    cv.Cell.H,
+		})
+	}
+	return t
+}
+
+func convertQueries(qs []chronograf.Query, bucket string) []platform.DashboardQuery {
+	var queries []platform.DashboardQuery
+	for _, q := range qs {
+		fqs, err := fieldQueries(q.Command, q.Wheres, q.GroupBys, bucket)
+		if err != nil {
+			continue
+		}
+
+		var r *platform.Range
+		if q.Range != nil {
+			r = &platform.Range{Upper: q.Range.Upper, Lower: q.Range.Lower}
+		}
+		for _, fq := range fqs {
+			queries = append(queries, platform.DashboardQuery{
+				Label: q.Label,
+				Range: r,
+				Text:  fq.Text,
+				Type:  "flux",
+			})
+		}
+	}
+	return queries
+}
+
+func convertProperties(c chronograf.Cell, queries []platform.DashboardQuery) platform.ViewProperties {
+	axes := make(map[string]platform.Axis, len(c.Axes))
+	for k, a := range c.Axes {
+		axes[k] = platform.Axis{
+			Bounds: a.Bounds,
+			Label:  a.Label,
+			Prefix: a.Prefix,
+			Suffix: a.Suffix,
+			Base:   a.Base,
+			Scale:  a.Scale,
+		}
+	}
+
+	colors := make([]platform.ViewColor, 0, len(c.CellColors))
+	for _, cc := range c.CellColors {
+		colors = append(colors, platform.ViewColor{
+			ID:    cc.ID,
+			Type:  cc.Type,
+			Hex:   cc.Hex,
+			Name:  cc.Name,
+			Value: cc.Value,
+		})
+	}
+
+	switch c.Type {
+	case "line-stacked":
+		return platform.StackedViewProperties{
+			Type:       "stacked",
+			Queries:    queries,
+			Axes:       axes,
+			ViewColors: colors,
+		}
+	case "line-stepplot":
+		return platform.StepPlotViewProperties{
+			Type:       "step-plot",
+			Queries:    queries,
+			Axes:       axes,
+			ViewColors: colors,
+		}
+	case "line-plus-single-stat":
+		return platform.LinePlusSingleStatProperties{
+			Type:       "line-plus-single-stat",
+			Queries:    queries,
+			Axes:       axes,
+			ViewColors: colors,
+		}
+	case "single-stat":
+		return platform.SingleStatViewProperties{
+			Type:       "single-stat",
+			Queries:    queries,
+			ViewColors: colors,
+		}
+	case "gauge":
+		return platform.GaugeViewProperties{
+			Type:       "gauge",
+			Queries:    queries,
+			ViewColors: colors,
+		}
+	case "table":
+		return platform.TableViewProperties{
+			Type:       "table",
+			Queries:    queries,
+			ViewColors: colors,
+		}
+	default:
+		return platform.LineViewProperties{
+			Type:       "line",
+			Queries:    queries,
+			Axes:       axes,
+			ViewColors: colors,
+		}
+	}
+}
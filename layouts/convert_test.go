@@ -0,0 +1,183 @@
+package layouts
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/chronograf"
+	_ "github.com/influxdata/platform/query/builtin"
+)
+
+func loadCanned(t *testing.T) []chronograf.Layout {
+	t.Helper()
+	files, err := filepath.Glob("../chronograf/canned/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no canned layouts found")
+	}
+
+	ls := make([]chronograf.Layout, 0, len(files))
+	for _, f := range files {
+		octets, err := ioutil.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var l chronograf.Layout
+		if err := json.Unmarshal(octets, &l); err != nil {
+			t.Fatalf("%s: %v", f, err)
+		}
+		ls = append(ls, l)
+	}
+	return ls
+}
+
+func viewQueries(t *testing.T, p platform.ViewProperties) []platform.DashboardQuery {
+	t.Helper()
+	switch p := p.(type) {
+	case platform.LineViewProperties:
+		return p.Queries
+	case platform.StackedViewProperties:
+		return p.Queries
+	case platform.StepPlotViewProperties:
+		return p.Queries
+	case platform.SingleStatViewProperties:
+		return p.Queries
+	default:
+		t.Fatalf("unexpected view properties %T", p)
+		return nil
+	}
+}
+
+func TestConvert_Canned(t *testing.T) {
+	for _, l := range loadCanned(t) {
+		cvs := convert(l, "telegraf")
+		if len(cvs) == 0 {
+			t.Errorf("layout %s (%s) has no convertible cells", l.ID, l.Measurement)
+			continue
+		}
+		for _, cv := range cvs {
+			for _, q := range viewQueries(t, cv.View.Properties) {
+				if _, err := flux.Compile(context.Background(), q.Text, time.Now()); err != nil {
+					t.Errorf("layout %s cell %q: invalid flux %v:\n%s", l.Measurement, cv.View.Name, err, q.Text)
+				}
+			}
+		}
+	}
+}
+
+func TestFieldQueries(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		wheres   []string
+		groupBys []string
+		want     []fieldQuery
+		wantErr  bool
+	}{
+		{
+			name:    "math with aggregate",
+			command: `SELECT 100 - mean("usage_idle") AS "usage" FROM ":db:".":rp:"."cpu"`,
+			want: []fieldQuery{
+				{
+					Name: "usage",
+					Text: `from(bucket: "telegraf")
+  |> range(start: -1h)
+  |> filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_idle")
+  |> group(by: ["_measurement", "_field"])
+  |> window(every: 1m)
+  |> mean()
+  |> duplicate(column: "_stop", as: "_time")
+  |> window(every: inf)
+  |> map(fn: (r) => ({_time: r._time, _value: 100.0 - r._value}), mergeKey: true)
+  |> yield(name: "usage")`,
+				},
+			},
+		},
+		{
+			name:     "derivative with conditions and tags",
+			command:  `SELECT non_negative_derivative(max("read_bytes"), 10s) / 1000000 AS "read" FROM ":db:".":rp:"."diskio"`,
+			wheres:   []string{`"status" = 'critical'`},
+			groupBys: []string{`"name"`},
+			want: []fieldQuery{
+				{
+					Name: "read",
+					Text: `from(bucket: "telegraf")
+  |> range(start: -1h)
+  |> filter(fn: (r) => r._measurement == "diskio" and r._field == "read_bytes" and r["status"] == "critical")
+  |> group(by: ["_measurement", "_field", "name"])
+  |> window(every: 1m)
+  |> max()
+  |> duplicate(column: "_stop", as: "_time")
+  |> window(every: inf)
+  |> derivative(unit: 10s, nonNegative: true)
+  |> map(fn: (r) => ({_time: r._time, _value: r._value / 1000000.0}), mergeKey: true)
+  |> yield(name: "read")`,
+				},
+			},
+		},
+		{
+			name:    "query per field",
+			command: `select median(f) as median, percentile(f, 95) as p95 from m`,
+			want: []fieldQuery{
+				{
+					Name: "median",
+					Text: `from(bucket: "telegraf")
+  |> range(start: -1h)
+  |> filter(fn: (r) => r._measurement == "m" and r._field == "f")
+  |> group(by: ["_measurement", "_field"])
+  |> window(every: 1m)
+  |> percentile(percentile: 0.5, method: "exact_mean")
+  |> duplicate(column: "_stop", as: "_time")
+  |> window(every: inf)
+  |> yield(name: "median")`,
+				},
+				{
+					Name: "p95",
+					Text: `from(bucket: "telegraf")
+  |> range(start: -1h)
+  |> filter(fn: (r) => r._measurement == "m" and r._field == "f")
+  |> group(by: ["_measurement", "_field"])
+  |> window(every: 1m)
+  |> percentile(percentile: 0.95, method: "exact_mean")
+  |> duplicate(column: "_stop", as: "_time")
+  |> window(every: inf)
+  |> yield(name: "p95")`,
+				},
+			},
+		},
+		{
+			name:    "arithmetic between fields",
+			command: `SELECT max("scur") / max("slim") FROM "haproxy"`,
+			wantErr: true,
+		},
+		{
+			name:    "multiple measurements",
+			command: `SELECT max("a") FROM "m1", "m2"`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fieldQueries(tt.command, tt.wheres, tt.groupBys, "telegraf")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fieldQueries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d queries, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("query %d:\ngot  %+v\nwant %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
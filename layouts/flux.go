@@ -0,0 +1,300 @@
+package layouts
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/influxql"
+)
+
+// windowEvery is the period the values of a field are aggregated over.
+// Canned layouts leave the interval to the dashboard; Flux queries need it up front.
+const windowEvery = "1m"
+
+// fieldQuery is a Flux query of a single field of an InfluxQL SELECT statement.
+type fieldQuery struct {
+	Name string
+	Text string
+}
+
+// fieldQueries translates an InfluxQL query of a canned layout into a Flux
+// query against bucket for each of its fields. Extra conditions and tags to
+// group by, as stored alongside the query in a layout, are added to the query.
+//
+// Only the shape of query used by layouts is supported: an aggregate of a
+// field, optionally wrapped in a derivative, and arithmetic with a number.
+// Fields of other shapes are skipped; an error is returned when none remain.
+func fieldQueries(command string, wheres, groupBys []string, bucket string) ([]fieldQuery, error) {
+	stmt, err := influxql.ParseStatement(command)
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.(*influxql.SelectStatement)
+	if !ok {
+		return nil, fmt.Errorf("unsupported statement %q", command)
+	}
+	if len(sel.Sources) != 1 {
+		return nil, fmt.Errorf("query %q must select from a single measurement", command)
+	}
+	m, ok := sel.Sources[0].(*influxql.Measurement)
+	if !ok || m.Regex != nil {
+		return nil, fmt.Errorf("query %q must select from a single measurement", command)
+	}
+
+	conds := make([]string, 0, len(wheres)+1)
+	if sel.Condition != nil {
+		cond, err := condition(sel.Condition)
+		if err != nil {
+			return nil, err
+		}
+		if cond != "" {
+			conds = append(conds, cond)
+		}
+	}
+	for _, where := range wheres {
+		expr, err := influxql.ParseExpr(where)
+		if err != nil {
+			return nil, err
+		}
+		cond, err := condition(expr)
+		if err != nil {
+			return nil, err
+		}
+		if cond != "" {
+			conds = append(conds, cond)
+		}
+	}
+
+	tags := make([]string, 0, len(groupBys)+len(sel.Dimensions))
+	for _, d := range sel.Dimensions {
+		if ref, ok := d.Expr.(*influxql.VarRef); ok {
+			tags = append(tags, ref.Val)
+		}
+	}
+	for _, g := range groupBys {
+		tags = append(tags, strings.Trim(g, `"`))
+	}
+
+	var queries []fieldQuery
+	for _, f := range sel.Fields {
+		fq, err := newFieldPipeline(f.Expr)
+		if err != nil {
+			continue
+		}
+		name := f.Name()
+		queries = append(queries, fieldQuery{
+			Name: name,
+			Text: fq.text(bucket, m.Name, conds, tags, name),
+		})
+	}
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("query %q has no supported fields", command)
+	}
+	return queries, nil
+}
+
+// fieldPipeline holds the steps of the Flux query of a field.
+type fieldPipeline struct {
+	field     string
+	aggregate string
+	// derivative is the call to derivative applied after the aggregate, if any.
+	derivative string
+	// math is the expression the value is mapped to, if any.
+	math string
+}
+
+func newFieldPipeline(expr influxql.Expr) (*fieldPipeline, error) {
+	switch expr := expr.(type) {
+	case *influxql.ParenExpr:
+		return newFieldPipeline(expr.Expr)
+	case *influxql.BinaryExpr:
+		// Arithmetic is only supported between a field and a number.
+		if n, ok := number(expr.RHS); ok {
+			p, err := newFieldPipeline(expr.LHS)
+			if err != nil {
+				return nil, err
+			}
+			return p.withMath(fmt.Sprintf("%s %s %s", p.value(), expr.Op, n))
+		}
+		if n, ok := number(expr.LHS); ok {
+			p, err := newFieldPipeline(expr.RHS)
+			if err != nil {
+				return nil, err
+			}
+			return p.withMath(fmt.Sprintf("%s %s %s", n, expr.Op, p.value()))
+		}
+	case *influxql.Call:
+		switch expr.Name {
+		case "derivative", "non_negative_derivative":
+			if len(expr.Args) == 0 || len(expr.Args) > 2 {
+				break
+			}
+			p, err := newFieldPipeline(expr.Args[0])
+			if err != nil || p.derivative != "" || p.math != "" {
+				break
+			}
+			unit := "1s"
+			if len(expr.Args) == 2 {
+				d, ok := expr.Args[1].(*influxql.DurationLiteral)
+				if !ok {
+					break
+				}
+				unit = influxql.FormatDuration(d.Val)
+			}
+			p.derivative = fmt.Sprintf("derivative(unit: %s, nonNegative: %t)", unit, expr.Name == "non_negative_derivative")
+			return p, nil
+		default:
+			return newAggregate(expr)
+		}
+	}
+	return nil, fmt.Errorf("unsupported expression %s", expr)
+}
+
+// newAggregate returns the pipeline of an aggregate of a field.
+func newAggregate(call *influxql.Call) (*fieldPipeline, error) {
+	if len(call.Args) == 0 {
+		return nil, fmt.Errorf("unsupported call %s", call)
+	}
+	ref, ok := call.Args[0].(*influxql.VarRef)
+	if !ok {
+		return nil, fmt.Errorf("unsupported call %s", call)
+	}
+
+	p := &fieldPipeline{field: ref.Val}
+	switch call.Name {
+	case "mean", "max", "min", "sum", "count", "first", "last":
+		if len(call.Args) != 1 {
+			return nil, fmt.Errorf("unsupported call %s", call)
+		}
+		p.aggregate = call.Name + "()"
+	case "median":
+		if len(call.Args) != 1 {
+			return nil, fmt.Errorf("unsupported call %s", call)
+		}
+		p.aggregate = `percentile(percentile: 0.5, method: "exact_mean")`
+	case "percentile":
+		if len(call.Args) != 2 {
+			return nil, fmt.Errorf("unsupported call %s", call)
+		}
+		n, ok := number(call.Args[1])
+		if !ok {
+			return nil, fmt.Errorf("unsupported call %s", call)
+		}
+		v, _ := strconv.ParseFloat(n, 64)
+		p.aggregate = fmt.Sprintf(`percentile(percentile: %s, method: "exact_mean")`, formatFloat(v/100))
+	default:
+		return nil, fmt.Errorf("unsupported call %s", call)
+	}
+	return p, nil
+}
+
+func (p *fieldPipeline) value() string {
+	if p.math != "" {
+		return "(" + p.math + ")"
+	}
+	return "r._value"
+}
+
+func (p *fieldPipeline) withMath(math string) (*fieldPipeline, error) {
+	p.math = math
+	return p, nil
+}
+
+func (p *fieldPipeline) text(bucket, measurement string, conds, tags []string, name string) string {
+	filter := fmt.Sprintf("r._measurement == %s and r._field == %s", strconv.Quote(measurement), strconv.Quote(p.field))
+	for _, cond := range conds {
+		filter += " and " + cond
+	}
+
+	by := []string{strconv.Quote("_measurement"), strconv.Quote("_field")}
+	for _, tag := range tags {
+		by = append(by, strconv.Quote(tag))
+	}
+
+	lines := []string{
+		fmt.Sprintf("from(bucket: %s)", strconv.Quote(bucket)),
+		"range(start: -1h)",
+		fmt.Sprintf("filter(fn: (r) => %s)", filter),
+		fmt.Sprintf("group(by: [%s])", strings.Join(by, ", ")),
+		fmt.Sprintf("window(every: %s)", windowEvery),
+		p.aggregate,
+		`duplicate(column: "_stop", as: "_time")`,
+		"window(every: inf)",
+	}
+	if p.derivative != "" {
+		lines = append(lines, p.derivative)
+	}
+	if p.math != "" {
+		lines = append(lines, fmt.Sprintf("map(fn: (r) => ({_time: r._time, _value: %s}), mergeKey: true)", p.math))
+	}
+	lines = append(lines, fmt.Sprintf("yield(name: %s)", strconv.Quote(name)))
+	return strings.Join(lines, "\n  |> ")
+}
+
+// condition translates a comparison of a tag with a string, or a conjunction
+// of them, to a Flux predicate. Conditions on time are left to the dashboard
+// range and return an empty predicate.
+func condition(expr influxql.Expr) (string, error) {
+	switch expr := expr.(type) {
+	case *influxql.ParenExpr:
+		return condition(expr.Expr)
+	case *influxql.BinaryExpr:
+		switch expr.Op {
+		case influxql.AND:
+			lhs, err := condition(expr.LHS)
+			if err != nil {
+				return "", err
+			}
+			rhs, err := condition(expr.RHS)
+			if err != nil {
+				return "", err
+			}
+			if lhs == "" || rhs == "" {
+				return lhs + rhs, nil
+			}
+			return lhs + " and " + rhs, nil
+		case influxql.EQ, influxql.NEQ:
+			ref, ok := expr.LHS.(*influxql.VarRef)
+			if !ok {
+				break
+			}
+			if strings.ToLower(ref.Val) == "time" {
+				return "", nil
+			}
+			s, ok := expr.RHS.(*influxql.StringLiteral)
+			if !ok {
+				break
+			}
+			op := "=="
+			if expr.Op == influxql.NEQ {
+				op = "!="
+			}
+			return fmt.Sprintf("r[%s] %s %s", strconv.Quote(ref.Val), op, strconv.Quote(s.Val)), nil
+		case influxql.LT, influxql.LTE, influxql.GT, influxql.GTE:
+			if ref, ok := expr.LHS.(*influxql.VarRef); ok && strings.ToLower(ref.Val) == "time" {
+				return "", nil
+			}
+		}
+	}
+	return "", fmt.Errorf("unsupported condition %s", expr)
+}
+
+// number returns the Flux float literal of a number literal.
+func number(expr influxql.Expr) (string, bool) {
+	switch expr := expr.(type) {
+	case *influxql.IntegerLiteral:
+		return formatFloat(float64(expr.Val)), true
+	case *influxql.NumberLiteral:
+		return formatFloat(expr.Val), true
+	}
+	return "", false
+}
+
+func formatFloat(v float64) string {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
@@ -0,0 +1,144 @@
+package layouts
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/chronograf"
+	kerrors "github.com/influxdata/platform/kit/errors"
+)
+
+var _ platform.DashboardTemplateService = (*Service)(nil)
+
+// Service serves the canned layouts of a store as dashboard templates.
+type Service struct {
+	LayoutsStore     chronograf.LayoutsStore
+	DashboardService platform.DashboardService
+	ViewService      platform.ViewService
+	BucketService    platform.BucketService
+}
+
+// FindDashboardTemplates returns the templates of the layouts that match filter,
+// sorted by application and measurement.
+func (s *Service) FindDashboardTemplates(ctx context.Context, filter platform.DashboardTemplateFilter) ([]*platform.DashboardTemplate, error) {
+	ls, err := s.LayoutsStore.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := make([]*platform.DashboardTemplate, 0, len(ls))
+	for _, l := range ls {
+		if filter.Plugin != nil && !matchesPlugin(l, *filter.Plugin) {
+			continue
+		}
+		if t := Template(l); t != nil {
+			ts = append(ts, t)
+		}
+	}
+
+	sort.Slice(ts, func(i, j int) bool {
+		if ts[i].Application != ts[j].Application {
+			return ts[i].Application < ts[j].Application
+		}
+		if ts[i].Measurement != ts[j].Measurement {
+			return ts[i].Measurement < ts[j].Measurement
+		}
+		return ts[i].ID < ts[j].ID
+	})
+	return ts, nil
+}
+
+// matchesPlugin reports whether a layout shows data of a Telegraf input plugin.
+// Plugins write to the measurement of their name or to measurements prefixed with it.
+func matchesPlugin(l chronograf.Layout, plugin string) bool {
+	return l.Measurement == plugin || strings.HasPrefix(l.Measurement, plugin+"_")
+}
+
+// FindDashboardTemplateByID returns the template of the layout with the ID.
+func (s *Service) FindDashboardTemplateByID(ctx context.Context, id string) (*platform.DashboardTemplate, error) {
+	l, err := s.findLayout(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	t := Template(l)
+	if t == nil {
+		return nil, platform.ErrDashboardTemplateNotFound
+	}
+	return t, nil
+}
+
+// CreateDashboardFromTemplate creates a view for each cell of the layout with
+// the ID querying the bucket of the request, and a dashboard showing them.
+// The views are deleted if the dashboard cannot be created.
+func (s *Service) CreateDashboardFromTemplate(ctx context.Context, id string, req platform.DashboardTemplateRequest) (_ *platform.Dashboard, err error) {
+	l, err := s.findLayout(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.BucketService.FindBucketByID(ctx, req.BucketID)
+	if err != nil {
+		return nil, err
+	}
+	if b.OrganizationID != req.OrganizationID {
+		return nil, kerrors.InvalidDataf("bucket %s does not belong to organization %s", req.BucketID, req.OrganizationID)
+	}
+
+	cvs := convert(l, b.Name)
+	if len(cvs) == 0 {
+		return nil, platform.ErrDashboardTemplateNotFound
+	}
+
+	d := &platform.Dashboard{
+		OrganizationID: req.OrganizationID,
+		Name:           req.Name,
+		Cells:          make([]*platform.Cell, 0, len(cvs)),
+	}
+	if d.Name == "" {
+		d.Name = l.Measurement
+	}
+	defer func() {
+		if err != nil {
+			s.deleteViews(ctx, d.Cells)
+		}
+	}()
+	for _, cv := range cvs {
+		cv.View.OrganizationID = req.OrganizationID
+		if err := s.ViewService.CreateView(ctx, cv.View); err != nil {
+			return nil, err
+		}
+		cv.Cell.ViewID = cv.View.ID
+		d.Cells = append(d.Cells, cv.Cell)
+	}
+
+	if err := s.DashboardService.CreateDashboard(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// deleteViews deletes the views of cells on a best effort basis,
+// as the error that made them unused is the one to report.
+func (s *Service) deleteViews(ctx context.Context, cells []*platform.Cell) {
+	for _, c := range cells {
+		s.ViewService.DeleteView(ctx, c.ViewID)
+	}
+}
+
+// findLayout searches all layouts rather than using Get, as the stores
+// of canned layouts report missing and unreadable layouts alike.
+func (s *Service) findLayout(ctx context.Context, id string) (chronograf.Layout, error) {
+	ls, err := s.LayoutsStore.All(ctx)
+	if err != nil {
+		return chronograf.Layout{}, err
+	}
+	for _, l := range ls {
+		if l.ID == id {
+			return l, nil
+		}
+	}
+	return chronograf.Layout{}, platform.ErrDashboardTemplateNotFound
+}
@@ -0,0 +1,225 @@
+package layouts_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/chronograf"
+	"github.com/influxdata/platform/chronograf/mocks"
+	"github.com/influxdata/platform/inmem"
+	"github.com/influxdata/platform/layouts"
+)
+
+var testLayouts = []chronograf.Layout{
+	{
+		ID:          "mem",
+		Application: "system",
+		Measurement: "mem",
+		Autoflow:    true,
+		Cells: []chronograf.Cell{
+			{
+				Name: "System - Memory Gigabytes Used",
+				Type: "line-stacked",
+				Queries: []chronograf.Query{
+					{Command: `SELECT mean("used") / 1073741824 AS "used", mean("available") / 1073741824 AS "available" FROM ":db:".":rp:"."mem"`},
+				},
+			},
+			{
+				Name: "Unsupported",
+				Queries: []chronograf.Query{
+					{Command: `SELECT max("a") / max("b") FROM "mem"`},
+				},
+			},
+			{
+				Name: "System - Memory Used",
+				Type: "single-stat",
+				Queries: []chronograf.Query{
+					{Command: `SELECT last("used_percent") FROM "mem"`},
+				},
+			},
+		},
+	},
+	{
+		ID:          "docker",
+		Application: "docker",
+		Measurement: "docker_container_net",
+		Cells: []chronograf.Cell{
+			{
+				X: 0, Y: 0, W: 6, H: 4,
+				Name: "Docker - Network",
+				Queries: []chronograf.Query{
+					{Command: `SELECT derivative(mean("rx_bytes"), 10s) AS "net_rx_bytes" FROM "docker_container_net"`},
+				},
+			},
+		},
+	},
+	{
+		ID:          "netstat",
+		Application: "system",
+		Measurement: "netstat",
+		Cells: []chronograf.Cell{
+			{
+				Name: "Unsupported",
+				Queries: []chronograf.Query{
+					{Command: `SELECT max("a") / max("b") FROM "netstat"`},
+				},
+			},
+		},
+	},
+}
+
+func newService(t *testing.T) (*layouts.Service, *inmem.Service) {
+	t.Helper()
+	svc := inmem.NewService()
+	return &layouts.Service{
+		LayoutsStore: &mocks.LayoutsStore{
+			AllF: func(context.Context) ([]chronograf.Layout, error) {
+				return testLayouts, nil
+			},
+		},
+		DashboardService: svc,
+		ViewService:      svc,
+		BucketService:    svc,
+	}, svc
+}
+
+func TestService_FindDashboardTemplates(t *testing.T) {
+	s, _ := newService(t)
+	ctx := context.Background()
+
+	ts, err := s.FindDashboardTemplates(ctx, platform.DashboardTemplateFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The netstat layout has no cell that can be converted.
+	if len(ts) != 2 || ts[0].ID != "docker" || ts[1].ID != "mem" {
+		t.Fatalf("unexpected templates %+v", ts)
+	}
+
+	mem := ts[1]
+	if len(mem.Cells) != 2 {
+		t.Fatalf("got %d cells, want 2", len(mem.Cells))
+	}
+	if c := mem.Cells[1]; c.Name != "System - Memory Used" || c.X != 4 || c.Y != 0 || c.W != 4 || c.H != 4 {
+		t.Errorf("unexpected autoflow cell %+v", c)
+	}
+
+	for plugin, want := range map[string]string{"docker": "docker", "mem": "mem", "net": ""} {
+		plugin := plugin
+		ts, err := s.FindDashboardTemplates(ctx, platform.DashboardTemplateFilter{Plugin: &plugin})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want == "" {
+			if len(ts) != 0 {
+				t.Errorf("plugin %s: got %d templates, want none", plugin, len(ts))
+			}
+			continue
+		}
+		if len(ts) != 1 || ts[0].ID != want {
+			t.Errorf("plugin %s: unexpected templates %+v", plugin, ts)
+		}
+	}
+
+	if _, err := s.FindDashboardTemplateByID(ctx, "netstat"); err != platform.ErrDashboardTemplateNotFound {
+		t.Errorf("FindDashboardTemplateByID() error = %v, want %v", err, platform.ErrDashboardTemplateNotFound)
+	}
+}
+
+func TestService_CreateDashboardFromTemplate(t *testing.T) {
+	s, svc := newService(t)
+	ctx := context.Background()
+
+	org := &platform.Organization{Name: "org"}
+	if err := svc.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+	other := &platform.Organization{Name: "other"}
+	if err := svc.CreateOrganization(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	bucket := &platform.Bucket{Name: "telegraf", OrganizationID: org.ID}
+	if err := svc.CreateBucket(ctx, bucket); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := s.CreateDashboardFromTemplate(ctx, "mem", platform.DashboardTemplateRequest{
+		OrganizationID: org.ID,
+		BucketID:       bucket.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Name != "mem" || len(d.Cells) != 2 {
+		t.Fatalf("unexpected dashboard %+v", d)
+	}
+
+	v, err := svc.FindViewByID(ctx, d.Cells[0].ViewID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	props, ok := v.Properties.(platform.StackedViewProperties)
+	if !ok {
+		t.Fatalf("got properties %T, want stacked", v.Properties)
+	}
+	if len(props.Queries) != 2 || props.Queries[0].Type != "flux" {
+		t.Fatalf("unexpected queries %+v", props.Queries)
+	}
+	if v, err := svc.FindViewByID(ctx, d.Cells[1].ViewID); err != nil {
+		t.Fatal(err)
+	} else if _, ok := v.Properties.(platform.SingleStatViewProperties); !ok {
+		t.Fatalf("got properties %T, want single stat", v.Properties)
+	}
+
+	if _, err := s.CreateDashboardFromTemplate(ctx, "mem", platform.DashboardTemplateRequest{
+		OrganizationID: other.ID,
+		BucketID:       bucket.ID,
+	}); err == nil {
+		t.Error("expected error creating dashboard from a bucket of another organization")
+	}
+
+	if _, err := s.CreateDashboardFromTemplate(ctx, "missing", platform.DashboardTemplateRequest{
+		OrganizationID: org.ID,
+		BucketID:       bucket.ID,
+	}); err != platform.ErrDashboardTemplateNotFound {
+		t.Errorf("CreateDashboardFromTemplate() error = %v, want %v", err, platform.ErrDashboardTemplateNotFound)
+	}
+}
+
+// failingDashboardService fails to create dashboards.
+type failingDashboardService struct {
+	platform.DashboardService
+}
+
+func (failingDashboardService) CreateDashboard(ctx context.Context, d *platform.Dashboard) error {
+	return errors.New("dashboard not created")
+}
+
+func TestService_CreateDashboardFromTemplate_DeletesViewsOnError(t *testing.T) {
+	s, svc := newService(t)
+	s.DashboardService = failingDashboardService{svc}
+	ctx := context.Background()
+
+	org := &platform.Organization{Name: "org"}
+	if err := svc.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+	bucket := &platform.Bucket{Name: "telegraf", OrganizationID: org.ID}
+	if err := svc.CreateBucket(ctx, bucket); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.CreateDashboardFromTemplate(ctx, "mem", platform.DashboardTemplateRequest{
+		OrganizationID: org.ID,
+		BucketID:       bucket.ID,
+	}); err == nil {
+		t.Fatal("expected error creating dashboard")
+	}
+	if vs, _, err := svc.FindViews(ctx, platform.ViewFilter{}); err != nil {
+		t.Fatal(err)
+	} else if len(vs) != 0 {
+		t.Fatalf("expected the views of the dashboard to be deleted, got %d views", len(vs))
+	}
+}
@@ -11,8 +11,8 @@ type MacroService interface {
 	// FindMacro finds a single macro from the store by its ID
 	FindMacroByID(ctx context.Context, id ID) (*Macro, error)
 
-	// FindMacros returns all macros in the store
-	FindMacros(ctx context.Context) ([]*Macro, error)
+	// FindMacros returns all macros in the store that match filter
+	FindMacros(ctx context.Context, filter MacroFilter) ([]*Macro, error)
 
 	// CreateMacro creates a new macro and assigns it an ID
 	CreateMacro(ctx context.Context, m *Macro) error
@@ -30,10 +30,21 @@ type MacroService interface {
 // A Macro describes a keyword that can be expanded into several possible
 // values when used in an InfluxQL or Flux query
 type Macro struct {
-	ID        ID              `json:"id,omitempty"`
-	Name      string          `json:"name"`
-	Selected  []string        `json:"selected"`
-	Arguments *MacroArguments `json:"arguments"`
+	ID             ID              `json:"id,omitempty"`
+	OrganizationID ID              `json:"organizationID,omitempty"`
+	Name           string          `json:"name"`
+	Selected       []string        `json:"selected"`
+	Arguments      *MacroArguments `json:"arguments"`
+}
+
+// MacroFilter represents a set of filters that restrict the returned macros
+type MacroFilter struct {
+	ID             *ID
+	OrganizationID *ID
+	// NamePrefix matches macros whose name starts with the prefix.
+	NamePrefix *string
+	// Owner matches macros owned by the user.
+	Owner *ID
 }
 
 // A MacroUpdate describes a set of changes that can be applied to a Macro
@@ -84,6 +95,19 @@ func (m *Macro) Valid() error {
 	return nil
 }
 
+// UsesMacro reports whether the query of a query macro references the macro
+// with the name, making its values depend on the selected value of that macro.
+func (m *Macro) UsesMacro(name string) bool {
+	if m.Arguments == nil || name == m.Name {
+		return false
+	}
+	q, ok := m.Arguments.Values.(MacroQueryValues)
+	if !ok {
+		return false
+	}
+	return queryUsesMacro(q.Query, name)
+}
+
 // Valid returns an error if a Macro changeset is not valid
 func (u *MacroUpdate) Valid() error {
 	if u.Name == "" && u.Selected == nil && u.Arguments == nil {
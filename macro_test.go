@@ -112,3 +112,65 @@ func TestMacro_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestMacro_UsesMacro(t *testing.T) {
+	query := func(text string) *platform.Macro {
+		return &platform.Macro{
+			Name: "host",
+			Arguments: &platform.MacroArguments{
+				Type:   "query",
+				Values: platform.MacroQueryValues{Query: text, Language: "flux"},
+			},
+		}
+	}
+	tests := []struct {
+		name  string
+		macro *platform.Macro
+		uses  string
+		want  bool
+	}{
+		{
+			name:  "flux reference",
+			macro: query(`from(bucket: "b") |> filter(fn: (r) => r.region == v.region)`),
+			uses:  "region",
+			want:  true,
+		},
+		{
+			name:  "influxql reference",
+			macro: query(`SHOW TAG VALUES WITH KEY = "host" WHERE "region" = :region:`),
+			uses:  "region",
+			want:  true,
+		},
+		{
+			name:  "no reference",
+			macro: query(`from(bucket: "b") |> filter(fn: (r) => r.region == "us")`),
+			uses:  "region",
+			want:  false,
+		},
+		{
+			name:  "itself",
+			macro: query(`from(bucket: "b") |> filter(fn: (r) => r.host == v.host)`),
+			uses:  "host",
+			want:  false,
+		},
+		{
+			name: "constant macro",
+			macro: &platform.Macro{
+				Name: "host",
+				Arguments: &platform.MacroArguments{
+					Type:   "constant",
+					Values: platform.MacroConstantValues{"v.region"},
+				},
+			},
+			uses: "region",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.macro.UsesMacro(tt.uses); got != tt.want {
+				t.Errorf("UsesMacro(%q) = %v, want %v", tt.uses, got, tt.want)
+			}
+		})
+	}
+}
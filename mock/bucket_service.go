@@ -80,3 +80,13 @@ func (s *BucketService) UpdateBucket(ctx context.Context, id platform.ID, upd pl
 func (s *BucketService) DeleteBucket(ctx context.Context, id platform.ID) error {
 	return s.DeleteBucketFn(ctx, id)
 }
+
+// BucketStatsService is a mock implementation of a platform.BucketStatsService.
+type BucketStatsService struct {
+	FindBucketStatsFn func(context.Context, platform.ID) (*platform.BucketStats, error)
+}
+
+// FindBucketStats returns the stats of a bucket by ID.
+func (s *BucketStatsService) FindBucketStats(ctx context.Context, id platform.ID) (*platform.BucketStats, error) {
+	return s.FindBucketStatsFn(ctx, id)
+}
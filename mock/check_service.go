@@ -0,0 +1,37 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.CheckService = &CheckService{}
+
+type CheckService struct {
+	FindCheckByIDF func(context.Context, platform.ID) (*platform.Check, error)
+	FindChecksF    func(context.Context, platform.CheckFilter) ([]*platform.Check, error)
+	CreateCheckF   func(context.Context, *platform.Check) error
+	UpdateCheckF   func(context.Context, platform.ID, platform.CheckUpdate) (*platform.Check, error)
+	DeleteCheckF   func(context.Context, platform.ID) error
+}
+
+func (s *CheckService) FindCheckByID(ctx context.Context, id platform.ID) (*platform.Check, error) {
+	return s.FindCheckByIDF(ctx, id)
+}
+
+func (s *CheckService) FindChecks(ctx context.Context, filter platform.CheckFilter) ([]*platform.Check, error) {
+	return s.FindChecksF(ctx, filter)
+}
+
+func (s *CheckService) CreateCheck(ctx context.Context, c *platform.Check) error {
+	return s.CreateCheckF(ctx, c)
+}
+
+func (s *CheckService) UpdateCheck(ctx context.Context, id platform.ID, upd platform.CheckUpdate) (*platform.Check, error) {
+	return s.UpdateCheckF(ctx, id, upd)
+}
+
+func (s *CheckService) DeleteCheck(ctx context.Context, id platform.ID) error {
+	return s.DeleteCheckF(ctx, id)
+}
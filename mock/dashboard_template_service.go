@@ -0,0 +1,27 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.DashboardTemplateService = &DashboardTemplateService{}
+
+type DashboardTemplateService struct {
+	FindDashboardTemplatesF      func(context.Context, platform.DashboardTemplateFilter) ([]*platform.DashboardTemplate, error)
+	FindDashboardTemplateByIDF   func(context.Context, string) (*platform.DashboardTemplate, error)
+	CreateDashboardFromTemplateF func(context.Context, string, platform.DashboardTemplateRequest) (*platform.Dashboard, error)
+}
+
+func (s *DashboardTemplateService) FindDashboardTemplates(ctx context.Context, filter platform.DashboardTemplateFilter) ([]*platform.DashboardTemplate, error) {
+	return s.FindDashboardTemplatesF(ctx, filter)
+}
+
+func (s *DashboardTemplateService) FindDashboardTemplateByID(ctx context.Context, id string) (*platform.DashboardTemplate, error) {
+	return s.FindDashboardTemplateByIDF(ctx, id)
+}
+
+func (s *DashboardTemplateService) CreateDashboardFromTemplate(ctx context.Context, id string, req platform.DashboardTemplateRequest) (*platform.Dashboard, error) {
+	return s.CreateDashboardFromTemplateF(ctx, id, req)
+}
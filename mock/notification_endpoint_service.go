@@ -0,0 +1,37 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.NotificationEndpointService = &NotificationEndpointService{}
+
+type NotificationEndpointService struct {
+	FindNotificationEndpointByIDF func(context.Context, platform.ID) (*platform.NotificationEndpoint, error)
+	FindNotificationEndpointsF    func(context.Context, platform.NotificationEndpointFilter) ([]*platform.NotificationEndpoint, error)
+	CreateNotificationEndpointF   func(context.Context, *platform.NotificationEndpoint) error
+	UpdateNotificationEndpointF   func(context.Context, platform.ID, platform.NotificationEndpointUpdate) (*platform.NotificationEndpoint, error)
+	DeleteNotificationEndpointF   func(context.Context, platform.ID) error
+}
+
+func (s *NotificationEndpointService) FindNotificationEndpointByID(ctx context.Context, id platform.ID) (*platform.NotificationEndpoint, error) {
+	return s.FindNotificationEndpointByIDF(ctx, id)
+}
+
+func (s *NotificationEndpointService) FindNotificationEndpoints(ctx context.Context, filter platform.NotificationEndpointFilter) ([]*platform.NotificationEndpoint, error) {
+	return s.FindNotificationEndpointsF(ctx, filter)
+}
+
+func (s *NotificationEndpointService) CreateNotificationEndpoint(ctx context.Context, e *platform.NotificationEndpoint) error {
+	return s.CreateNotificationEndpointF(ctx, e)
+}
+
+func (s *NotificationEndpointService) UpdateNotificationEndpoint(ctx context.Context, id platform.ID, upd platform.NotificationEndpointUpdate) (*platform.NotificationEndpoint, error) {
+	return s.UpdateNotificationEndpointF(ctx, id, upd)
+}
+
+func (s *NotificationEndpointService) DeleteNotificationEndpoint(ctx context.Context, id platform.ID) error {
+	return s.DeleteNotificationEndpointF(ctx, id)
+}
@@ -47,3 +47,21 @@ func (s *OrganizationService) UpdateOrganization(ctx context.Context, id platfor
 func (s *OrganizationService) DeleteOrganization(ctx context.Context, id platform.ID) error {
 	return s.DeleteOrganizationF(ctx, id)
 }
+
+var _ platform.OrganizationSettingsService = &OrganizationSettingsService{}
+
+// OrganizationSettingsService is a mock organization settings service.
+type OrganizationSettingsService struct {
+	FindOrganizationSettingsF   func(ctx context.Context, orgID platform.ID) (*platform.OrganizationSettings, error)
+	UpdateOrganizationSettingsF func(ctx context.Context, orgID platform.ID, upd platform.OrganizationSettingsUpdate) (*platform.OrganizationSettings, error)
+}
+
+// FindOrganizationSettings calls FindOrganizationSettingsF.
+func (s *OrganizationSettingsService) FindOrganizationSettings(ctx context.Context, orgID platform.ID) (*platform.OrganizationSettings, error) {
+	return s.FindOrganizationSettingsF(ctx, orgID)
+}
+
+// UpdateOrganizationSettings calls UpdateOrganizationSettingsF.
+func (s *OrganizationSettingsService) UpdateOrganizationSettings(ctx context.Context, orgID platform.ID, upd platform.OrganizationSettingsUpdate) (*platform.OrganizationSettings, error) {
+	return s.UpdateOrganizationSettingsF(ctx, orgID, upd)
+}
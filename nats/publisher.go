@@ -52,3 +52,11 @@ func (p *AsyncPublisher) Publish(subject string, r io.Reader) error {
 	_, err = p.Connection.PublishAsync(subject, data, ah)
 	return err
 }
+
+// Close closes the connection to the NATS streaming server.
+func (p *AsyncPublisher) Close() error {
+	if p.Connection == nil {
+		return nil
+	}
+	return p.Connection.Close()
+}
@@ -1,8 +1,11 @@
 package nats
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
+	"github.com/influxdata/platform/kit/check"
 	stand "github.com/nats-io/nats-streaming-server/server"
 	"github.com/nats-io/nats-streaming-server/stores"
 )
@@ -33,6 +36,22 @@ func (s *Server) Open() error {
 	return nil
 }
 
+// Check reports whether the NATS streaming server is running.
+func (s *Server) Check(ctx context.Context) check.Response {
+	if s.Server == nil {
+		return check.Error(errors.New("nats streaming server is not running"))
+	}
+
+	switch state := s.Server.State(); state {
+	case stand.Failed:
+		return check.Error(fmt.Errorf("nats streaming server failed: %v", s.Server.LastError()))
+	case stand.Shutdown:
+		return check.Error(errors.New("nats streaming server is shut down"))
+	default:
+		return check.Info("nats streaming server is %s", state)
+	}
+}
+
 // Config is the configuration for the NATS streaming server
 type Config struct {
 	// The directory where nats persists message information
@@ -45,3 +64,11 @@ func NewServer(c Config) *Server {
 		config: c,
 	}
 }
+
+// Close shuts down the NATS streaming server.
+func (s *Server) Close() error {
+	if s.Server != nil {
+		s.Server.Shutdown()
+	}
+	return nil
+}
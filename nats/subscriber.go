@@ -50,3 +50,11 @@ func (s *QueueSubscriber) Subscribe(subject, group string, handler Handler) erro
 	mh.sub = subscription{sub: sub}
 	return nil
 }
+
+// Close closes the connection to the NATS streaming server.
+func (s *QueueSubscriber) Close() error {
+	if s.Connection == nil {
+		return nil
+	}
+	return s.Connection.Close()
+}
@@ -0,0 +1,138 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// NotificationEndpointService represents a service for managing the endpoints
+// that checks notify when the level of a series changes.
+type NotificationEndpointService interface {
+	// FindNotificationEndpointByID returns a single notification endpoint by ID.
+	FindNotificationEndpointByID(ctx context.Context, id ID) (*NotificationEndpoint, error)
+
+	// FindNotificationEndpoints returns all notification endpoints that match filter.
+	FindNotificationEndpoints(ctx context.Context, filter NotificationEndpointFilter) ([]*NotificationEndpoint, error)
+
+	// CreateNotificationEndpoint creates a new notification endpoint and sets e.ID with the new identifier.
+	CreateNotificationEndpoint(ctx context.Context, e *NotificationEndpoint) error
+
+	// UpdateNotificationEndpoint updates a single notification endpoint with changeset.
+	// Returns the new notification endpoint state after update.
+	UpdateNotificationEndpoint(ctx context.Context, id ID, upd NotificationEndpointUpdate) (*NotificationEndpoint, error)
+
+	// DeleteNotificationEndpoint removes a notification endpoint by ID.
+	DeleteNotificationEndpoint(ctx context.Context, id ID) error
+}
+
+// NotificationEndpointType is the kind of receiver of a notification endpoint.
+type NotificationEndpointType string
+
+const (
+	// HTTPEndpointType sends notifications as JSON to a generic HTTP webhook.
+	HTTPEndpointType NotificationEndpointType = "http"
+	// SlackEndpointType sends notification messages to a Slack-compatible incoming webhook.
+	SlackEndpointType NotificationEndpointType = "slack"
+)
+
+// NotificationEndpoint is a webhook notified of the level changes of checks.
+type NotificationEndpoint struct {
+	ID             ID                       `json:"id,omitempty"`
+	OrganizationID ID                       `json:"organizationID"`
+	Name           string                   `json:"name"`
+	Type           NotificationEndpointType `json:"type"`
+	// URL is a secret of slack endpoints, whose webhook URLs carry their token.
+	URL string `json:"url,omitempty"`
+	// Method is the HTTP method of the requests to an http endpoint. If empty, it is POST.
+	Method string `json:"method,omitempty"`
+	// Headers are added to the requests to the endpoint, such as for authorization.
+	// Their values are secrets.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Channel overrides the channel of the webhook of a slack endpoint.
+	Channel string `json:"channel,omitempty"`
+}
+
+// NotificationEndpointFilter represents a set of filters that restrict the returned notification endpoints.
+type NotificationEndpointFilter struct {
+	ID             *ID
+	OrganizationID *ID
+}
+
+// NotificationEndpointUpdate is the set of changes that can be applied to a notification endpoint.
+// Nil fields are left unchanged. Since the secrets of an endpoint are left out when it is read,
+// an empty URL, or an empty value of one of the headers, keeps the current value.
+type NotificationEndpointUpdate struct {
+	Name    *string           `json:"name,omitempty"`
+	URL     *string           `json:"url,omitempty"`
+	Method  *string           `json:"method,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Channel *string           `json:"channel,omitempty"`
+}
+
+// Valid returns an error if the changeset is empty.
+func (u *NotificationEndpointUpdate) Valid() error {
+	if u.Name == nil && u.URL == nil && u.Method == nil && u.Headers == nil && u.Channel == nil {
+		return fmt.Errorf("no fields supplied in update")
+	}
+	return nil
+}
+
+// Apply applies the non-nil fields of the changeset to e.
+func (u *NotificationEndpointUpdate) Apply(e *NotificationEndpoint) {
+	if u.Name != nil {
+		e.Name = *u.Name
+	}
+	if u.URL != nil && *u.URL != "" {
+		e.URL = *u.URL
+	}
+	if u.Method != nil {
+		e.Method = *u.Method
+	}
+	if u.Headers != nil {
+		headers := make(map[string]string, len(u.Headers))
+		for k, v := range u.Headers {
+			if v == "" {
+				v = e.Headers[k]
+			}
+			headers[k] = v
+		}
+		e.Headers = headers
+	}
+	if u.Channel != nil {
+		e.Channel = *u.Channel
+	}
+}
+
+// Redacted returns a copy of e without its secrets: the values of its headers and the URL of a slack endpoint.
+func (e *NotificationEndpoint) Redacted() *NotificationEndpoint {
+	r := *e
+	if r.Type == SlackEndpointType {
+		r.URL = ""
+	}
+	if r.Headers != nil {
+		r.Headers = make(map[string]string, len(e.Headers))
+		for k := range e.Headers {
+			r.Headers[k] = ""
+		}
+	}
+	return &r
+}
+
+// Valid returns an error if the notification endpoint contains invalid data.
+func (e *NotificationEndpoint) Valid() error {
+	if e.Name == "" {
+		return fmt.Errorf("name empty")
+	}
+	if e.Type != HTTPEndpointType && e.Type != SlackEndpointType {
+		return fmt.Errorf("invalid notification endpoint type %q", e.Type)
+	}
+	u, err := url.Parse(e.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid url %q: scheme must be http or https", e.URL)
+	}
+	return nil
+}
@@ -1,6 +1,10 @@
 package platform
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"time"
+)
 
 // Organization is an organization. 🎉
 type Organization struct {
@@ -42,3 +46,79 @@ type OrganizationFilter struct {
 	Name *string
 	ID   *ID
 }
+
+// OrganizationSettings are the limits on the resources used by the queries of an organization.
+// A zero limit falls back to the default of the server.
+type OrganizationSettings struct {
+	// QueryConcurrency is the number of queries of the organization that may execute at once.
+	QueryConcurrency int `json:"queryConcurrency"`
+	// QueryQueueSize is the number of queries that may wait for an execution slot.
+	// Queries submitted while the queue is full are rejected.
+	QueryQueueSize int `json:"queryQueueSize"`
+	// QueryQueueTimeout is how long a query may wait for an execution slot.
+	QueryQueueTimeout time.Duration `json:"queryQueueTimeout"`
+	// QueryMemoryBytes is the memory a single query may allocate.
+	QueryMemoryBytes int64 `json:"queryMemoryBytes"`
+	// TotalQueryMemoryBytes is the memory all the executing queries of the organization may allocate together.
+	TotalQueryMemoryBytes int64 `json:"totalQueryMemoryBytes"`
+}
+
+// OrganizationSettingsUpdate represents updates to the settings of an organization.
+// Only fields which are set are updated.
+type OrganizationSettingsUpdate struct {
+	QueryConcurrency      *int
+	QueryQueueSize        *int
+	QueryQueueTimeout     *time.Duration
+	QueryMemoryBytes      *int64
+	TotalQueryMemoryBytes *int64
+}
+
+// Valid returns an error if any of the limits in the update is negative.
+func (u OrganizationSettingsUpdate) Valid() error {
+	if u.QueryConcurrency != nil && *u.QueryConcurrency < 0 {
+		return fmt.Errorf("query concurrency must not be negative")
+	}
+	if u.QueryQueueSize != nil && *u.QueryQueueSize < 0 {
+		return fmt.Errorf("query queue size must not be negative")
+	}
+	if u.QueryQueueTimeout != nil && *u.QueryQueueTimeout < 0 {
+		return fmt.Errorf("query queue timeout must not be negative")
+	}
+	if u.QueryMemoryBytes != nil && *u.QueryMemoryBytes < 0 {
+		return fmt.Errorf("query memory bytes must not be negative")
+	}
+	if u.TotalQueryMemoryBytes != nil && *u.TotalQueryMemoryBytes < 0 {
+		return fmt.Errorf("total query memory bytes must not be negative")
+	}
+	return nil
+}
+
+// Apply sets the fields of the update on s.
+func (u OrganizationSettingsUpdate) Apply(s *OrganizationSettings) {
+	if u.QueryConcurrency != nil {
+		s.QueryConcurrency = *u.QueryConcurrency
+	}
+	if u.QueryQueueSize != nil {
+		s.QueryQueueSize = *u.QueryQueueSize
+	}
+	if u.QueryQueueTimeout != nil {
+		s.QueryQueueTimeout = *u.QueryQueueTimeout
+	}
+	if u.QueryMemoryBytes != nil {
+		s.QueryMemoryBytes = *u.QueryMemoryBytes
+	}
+	if u.TotalQueryMemoryBytes != nil {
+		s.TotalQueryMemoryBytes = *u.TotalQueryMemoryBytes
+	}
+}
+
+// OrganizationSettingsService represents a service for managing the settings of organizations.
+type OrganizationSettingsService interface {
+	// FindOrganizationSettings returns the settings of an organization.
+	// An organization that has never been configured has zero settings.
+	FindOrganizationSettings(ctx context.Context, orgID ID) (*OrganizationSettings, error)
+
+	// UpdateOrganizationSettings updates the settings of an organization with changeset.
+	// Returns the new settings after update.
+	UpdateOrganizationSettings(ctx context.Context, orgID ID, upd OrganizationSettingsUpdate) (*OrganizationSettings, error)
+}
@@ -0,0 +1,40 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/platform"
+)
+
+// ActiveQuery describes a query that is being processed by a query controller.
+type ActiveQuery struct {
+	// ID is an ephemeral identifier of the query, unique within its controller.
+	ID             platform.ID       `json:"id"`
+	OrganizationID platform.ID       `json:"orgID"`
+	UserID         platform.ID       `json:"userID,omitempty"`
+	CompilerType   flux.CompilerType `json:"compilerType"`
+	StartTime      time.Time         `json:"startTime"`
+	// State is the processing stage of the query such as queueing or executing.
+	State string `json:"state"`
+	// MemoryBytes is the maximum number of bytes allocated by the query so far.
+	MemoryBytes int64 `json:"memoryBytes"`
+}
+
+// ActiveQueryFilter restricts the active queries returned by an ActiveQueryService.
+type ActiveQueryFilter struct {
+	OrganizationID *platform.ID
+}
+
+// ActiveQueryService lists and cancels the queries in flight.
+type ActiveQueryService interface {
+	// FindActiveQueries returns the queries in flight that match filter.
+	FindActiveQueries(ctx context.Context, filter ActiveQueryFilter) ([]*ActiveQuery, error)
+
+	// FindActiveQueryByID returns a single query in flight by ID.
+	FindActiveQueryByID(ctx context.Context, id platform.ID) (*ActiveQuery, error)
+
+	// CancelQuery stops the execution of a query in flight and frees its resources.
+	CancelQuery(ctx context.Context, id platform.ID) error
+}
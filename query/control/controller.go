@@ -2,9 +2,15 @@ package control
 
 import (
 	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/control"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/errors"
 	"github.com/influxdata/platform/query"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -12,28 +18,329 @@ import (
 // orgLabel is the metric label to use in the controller
 const orgLabel = "org"
 
+// settingsTTL is how long the settings of an organization are cached before they are read again,
+// so that queries do not look them up on every request.
+const settingsTTL = 10 * time.Second
+
 // Controller implements AsyncQueryService by consuming a control.Controller.
 type Controller struct {
 	c *control.Controller
+
+	settingsService platform.OrganizationSettingsService
+	defaults        platform.OrganizationSettings
+	memoryQuota     int64
+	quotas          *orgQuotas
+
+	settingsMu sync.Mutex
+	settings   map[platform.ID]cachedSettings
+
+	mu       sync.RWMutex
+	queries  map[control.QueryID]*activeQuery
+	shutdown bool
+	drained  chan struct{} // Closed once the controller is shut down and its queries are done.
+}
+
+// cachedSettings are the settings of an organization as read at some time.
+type cachedSettings struct {
+	settings platform.OrganizationSettings
+	expires  time.Time
+}
+
+// Config configures a Controller.
+type Config struct {
+	control.Config
+
+	// OrganizationSettingsService looks up the query limits of each organization.
+	// When nil, DefaultOrganizationSettings apply to every organization.
+	OrganizationSettingsService platform.OrganizationSettingsService
+
+	// DefaultOrganizationSettings are the query limits of the organizations that do not set their own.
+	// A zero limit is unlimited, except that the memory of a single query defaults to
+	// the memory of the organization divided by its concurrency.
+	DefaultOrganizationSettings platform.OrganizationSettings
+}
+
+// activeQuery records who submitted a query in flight, which the flux controller does not track.
+type activeQuery struct {
+	q *control.Query
+
+	orgID        platform.ID
+	userID       platform.ID
+	compilerType flux.CompilerType
+	start        time.Time
 }
 
 // NewController creates a new Controller specific to platform.
-func New(config control.Config) *Controller {
+// A MemoryBytesQuota of zero lets the queries of all organizations allocate unlimited memory.
+func New(config Config) *Controller {
 	config.MetricLabelKeys = append(config.MetricLabelKeys, orgLabel)
-	c := control.New(config)
-	return &Controller{c: c}
+	memoryQuota := config.MemoryBytesQuota
+	if memoryQuota <= 0 {
+		config.MemoryBytesQuota = math.MaxInt64
+	}
+	c := control.New(config.Config)
+	return &Controller{
+		c:               c,
+		settingsService: config.OrganizationSettingsService,
+		defaults:        config.DefaultOrganizationSettings,
+		memoryQuota:     memoryQuota,
+		quotas:          newOrgQuotas(),
+		settings:        make(map[platform.ID]cachedSettings),
+		queries:         make(map[control.QueryID]*activeQuery),
+		drained:         make(chan struct{}),
+	}
 }
 
 // Query satisifies the AsyncQueryService while ensuring the request is propogated on the context.
+// The query waits for an execution slot within the limits of its organization.
 func (c *Controller) Query(ctx context.Context, req *query.Request) (flux.Query, error) {
+	c.mu.RLock()
+	shutdown := c.shutdown
+	c.mu.RUnlock()
+	if shutdown {
+		return nil, errors.Errorf(errors.InternalError, "query controller is shut down")
+	}
+
+	limits, err := c.limits(ctx, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A query reserves the memory it may allocate against the memory of its organization.
+	memory := limits.QueryMemoryBytes
+	if err := c.quotas.acquire(ctx, req.OrganizationID, limits, memory); err != nil {
+		return nil, err
+	}
+
+	q, err := c.query(ctx, req, memory)
+	if err != nil {
+		c.quotas.release(req.OrganizationID, memory)
+		return nil, err
+	}
+	return q, nil
+}
+
+func (c *Controller) query(ctx context.Context, req *query.Request, memory int64) (flux.Query, error) {
 	// Set the request on the context so platform specific Flux operations can retrieve it later.
 	ctx = query.ContextWithRequest(ctx, req)
 	// Set the org label value for controller metrics
 	ctx = context.WithValue(ctx, orgLabel, req.OrganizationID.String())
-	return c.c.Query(ctx, req.Compiler)
+
+	compiler := req.Compiler
+	if memory > 0 {
+		compiler = memoryQuotaCompiler{Compiler: compiler, memory: memory}
+	}
+
+	start := time.Now()
+	q, err := c.c.Query(ctx, compiler)
+	if err != nil {
+		return nil, err
+	}
+
+	tq := &trackedQuery{Query: q, c: c, orgID: req.OrganizationID, memory: memory}
+	cq, ok := q.(*control.Query)
+	if !ok {
+		return tq, nil
+	}
+
+	aq := &activeQuery{
+		q:            cq,
+		orgID:        req.OrganizationID,
+		compilerType: req.Compiler.CompilerType(),
+		start:        start,
+	}
+	if req.Authorization != nil {
+		aq.userID = req.Authorization.UserID
+	}
+
+	c.mu.Lock()
+	if c.shutdown {
+		// The controller was shut down while the query was submitted.
+		cq.Cancel()
+	} else {
+		c.queries[cq.ID()] = aq
+	}
+	c.mu.Unlock()
+
+	tq.id = cq.ID()
+	return tq, nil
+}
+
+// limits returns the query limits of an organization, falling back to the defaults for the limits it does not set.
+// Unless it is set, the memory of a single query is a share of the memory of the organization for each query
+// that may execute at once. It is capped by the memory of the organization and of the controller.
+func (c *Controller) limits(ctx context.Context, orgID platform.ID) (platform.OrganizationSettings, error) {
+	limits := c.defaults
+	s, err := c.organizationSettings(ctx, orgID)
+	if err != nil {
+		return limits, err
+	}
+	if s != nil {
+		if s.QueryConcurrency > 0 {
+			limits.QueryConcurrency = s.QueryConcurrency
+		}
+		if s.QueryQueueSize > 0 {
+			limits.QueryQueueSize = s.QueryQueueSize
+		}
+		if s.QueryQueueTimeout > 0 {
+			limits.QueryQueueTimeout = s.QueryQueueTimeout
+		}
+		if s.QueryMemoryBytes > 0 {
+			limits.QueryMemoryBytes = s.QueryMemoryBytes
+		}
+		if s.TotalQueryMemoryBytes > 0 {
+			limits.TotalQueryMemoryBytes = s.TotalQueryMemoryBytes
+		}
+	}
+
+	if limits.QueryMemoryBytes == 0 && limits.TotalQueryMemoryBytes > 0 && limits.QueryConcurrency > 0 {
+		limits.QueryMemoryBytes = limits.TotalQueryMemoryBytes / int64(limits.QueryConcurrency)
+	}
+	for _, max := range []int64{limits.TotalQueryMemoryBytes, c.memoryQuota} {
+		if max > 0 && (limits.QueryMemoryBytes == 0 || limits.QueryMemoryBytes > max) {
+			limits.QueryMemoryBytes = max
+		}
+	}
+	return limits, nil
+}
+
+// organizationSettings returns the settings of an organization, or nil when it uses the defaults.
+// The settings are cached for settingsTTL.
+func (c *Controller) organizationSettings(ctx context.Context, orgID platform.ID) (*platform.OrganizationSettings, error) {
+	if c.settingsService == nil {
+		return nil, nil
+	}
+
+	now := time.Now()
+	c.settingsMu.Lock()
+	cached, ok := c.settings[orgID]
+	c.settingsMu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return &cached.settings, nil
+	}
+
+	s, err := c.settingsService.FindOrganizationSettings(ctx, orgID)
+	if err != nil {
+		if platform.ErrorCode(err) != platform.ENotFound {
+			return nil, err
+		}
+		// An organization without settings uses the defaults.
+		s = &platform.OrganizationSettings{}
+	}
+
+	c.settingsMu.Lock()
+	c.settings[orgID] = cachedSettings{settings: *s, expires: now.Add(settingsTTL)}
+	c.settingsMu.Unlock()
+	return s, nil
+}
+
+// FindActiveQueries returns the queries in flight that match filter, oldest first.
+func (c *Controller) FindActiveQueries(ctx context.Context, filter query.ActiveQueryFilter) ([]*query.ActiveQuery, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	qs := make([]*query.ActiveQuery, 0, len(c.queries))
+	for _, aq := range c.queries {
+		if filter.OrganizationID != nil && *filter.OrganizationID != aq.orgID {
+			continue
+		}
+		qs = append(qs, aq.toActiveQuery())
+	}
+	sort.Slice(qs, func(i, j int) bool {
+		return qs[i].StartTime.Before(qs[j].StartTime)
+	})
+	return qs, nil
+}
+
+// FindActiveQueryByID returns a single query in flight by ID.
+func (c *Controller) FindActiveQueryByID(ctx context.Context, id platform.ID) (*query.ActiveQuery, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	aq, ok := c.queries[control.QueryID(id)]
+	if !ok {
+		return nil, errors.Errorf(errors.NotFound, "query %s not found", id)
+	}
+	return aq.toActiveQuery(), nil
+}
+
+// CancelQuery cancels a query in flight.
+// The query remains active until the caller that submitted it calls Done.
+func (c *Controller) CancelQuery(ctx context.Context, id platform.ID) error {
+	c.mu.RLock()
+	aq, ok := c.queries[control.QueryID(id)]
+	c.mu.RUnlock()
+	if !ok {
+		return errors.Errorf(errors.NotFound, "query %s not found", id)
+	}
+
+	aq.q.Cancel()
+	return nil
+}
+
+// Shutdown stops the controller from accepting queries and cancels the queries in flight.
+// It waits until the callers that submitted them are done with them, or until ctx is done.
+func (c *Controller) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	if !c.shutdown {
+		c.shutdown = true
+		for _, aq := range c.queries {
+			aq.q.Cancel()
+		}
+		if len(c.queries) == 0 {
+			close(c.drained)
+		}
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-c.drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // PrometheusCollectors satisifies the prom.PrometheusCollector interface.
 func (c *Controller) PrometheusCollectors() []prometheus.Collector {
-	return c.c.PrometheusCollectors()
+	return append(c.c.PrometheusCollectors(), c.quotas.metrics.PrometheusCollectors()...)
+}
+
+func (aq *activeQuery) toActiveQuery() *query.ActiveQuery {
+	return &query.ActiveQuery{
+		ID:             platform.ID(aq.q.ID()),
+		OrganizationID: aq.orgID,
+		UserID:         aq.userID,
+		CompilerType:   aq.compilerType,
+		StartTime:      aq.start,
+		State:          aq.q.State().String(),
+		MemoryBytes:    aq.q.Statistics().MaxAllocated,
+	}
+}
+
+// trackedQuery forgets the query in the controller and frees its slot once it is done.
+type trackedQuery struct {
+	flux.Query
+
+	c      *Controller
+	id     control.QueryID
+	orgID  platform.ID
+	memory int64
+	once   sync.Once
+}
+
+func (q *trackedQuery) Done() {
+	q.Query.Done()
+	q.once.Do(func() {
+		q.c.mu.Lock()
+		if _, ok := q.c.queries[q.id]; ok {
+			delete(q.c.queries, q.id)
+			if q.c.shutdown && len(q.c.queries) == 0 {
+				close(q.c.drained)
+			}
+		}
+		q.c.mu.Unlock()
+
+		q.c.quotas.release(q.orgID, q.memory)
+	})
 }
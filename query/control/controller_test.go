@@ -0,0 +1,276 @@
+package control_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/control"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/query"
+	_ "github.com/influxdata/platform/query/builtin"
+	pcontrol "github.com/influxdata/platform/query/control"
+)
+
+func TestController_ActiveQueries(t *testing.T) {
+	c := pcontrol.New(pcontrol.Config{
+		Config: control.Config{
+			ExecutorDependencies: make(execute.Dependencies),
+			ConcurrencyQuota:     1,
+		},
+	})
+
+	orgID := platform.ID(1)
+	ctx := context.Background()
+	q, err := c.Query(ctx, &query.Request{
+		Authorization:  &platform.Authorization{UserID: platform.ID(2)},
+		OrganizationID: orgID,
+		Compiler: lang.FluxCompiler{
+			Query: `from(bucket: "telegraf") |> range(start: -1m)`,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherOrgID := platform.ID(3)
+	qs, err := c.FindActiveQueries(ctx, query.ActiveQueryFilter{OrganizationID: &otherOrgID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(qs) != 0 {
+		t.Fatalf("expected no active queries for another organization, got %d", len(qs))
+	}
+
+	qs, err = c.FindActiveQueries(ctx, query.ActiveQueryFilter{OrganizationID: &orgID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(qs) != 1 {
+		t.Fatalf("expected one active query, got %d", len(qs))
+	}
+	if qs[0].OrganizationID != orgID || qs[0].UserID != platform.ID(2) || qs[0].CompilerType != lang.FluxCompilerType {
+		t.Fatalf("unexpected active query %+v", qs[0])
+	}
+
+	id := qs[0].ID
+	if _, err := c.FindActiveQueryByID(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.CancelQuery(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := c.FindActiveQueryByID(ctx, id); err != nil {
+		t.Fatal(err)
+	} else if got.State != control.Canceled.String() && got.State != control.Errored.String() {
+		// The query may fail on its own before being canceled as no storage is available.
+		t.Fatalf("unexpected state of canceled query: %s", got.State)
+	}
+
+	q.Done()
+	if _, err := c.FindActiveQueryByID(ctx, id); err == nil {
+		t.Fatal("expected query to be forgotten once done")
+	}
+	if err := c.CancelQuery(ctx, id); err == nil {
+		t.Fatal("expected error canceling a finished query")
+	}
+}
+
+func TestController_OrganizationQuotas(t *testing.T) {
+	orgID := platform.ID(1)
+	c := pcontrol.New(pcontrol.Config{
+		Config: control.Config{
+			ExecutorDependencies: make(execute.Dependencies),
+			ConcurrencyQuota:     10,
+		},
+		OrganizationSettingsService: &mock.OrganizationSettingsService{
+			FindOrganizationSettingsF: func(ctx context.Context, id platform.ID) (*platform.OrganizationSettings, error) {
+				if id != orgID {
+					return &platform.OrganizationSettings{}, nil
+				}
+				return &platform.OrganizationSettings{
+					QueryMemoryBytes:      1024,
+					TotalQueryMemoryBytes: 2048,
+				}, nil
+			},
+		},
+		DefaultOrganizationSettings: platform.OrganizationSettings{
+			QueryConcurrency:  2,
+			QueryQueueSize:    1,
+			QueryQueueTimeout: 50 * time.Millisecond,
+		},
+	})
+
+	submit := func(ctx context.Context, orgID platform.ID) (flux.Query, error) {
+		return c.Query(ctx, &query.Request{
+			OrganizationID: orgID,
+			Compiler: lang.FluxCompiler{
+				Query: `from(bucket: "telegraf") |> range(start: -1m)`,
+			},
+		})
+	}
+
+	// Wait for the queries to fail for lack of storage before releasing them.
+	done := func(q flux.Query) {
+		<-q.Ready()
+		q.Done()
+	}
+
+	ctx := context.Background()
+	var running []flux.Query
+	for i := 0; i < 2; i++ {
+		q, err := submit(ctx, orgID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		running = append(running, q)
+	}
+
+	// Another organization is not limited by the queries of the first one.
+	q, err := submit(ctx, platform.ID(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	done(q)
+
+	// The third query waits for a slot while the fourth one finds the queue full.
+	waited := make(chan error, 1)
+	go func() {
+		q, err := submit(ctx, orgID)
+		if err == nil {
+			done(q)
+		}
+		waited <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if _, err := submit(ctx, orgID); err == nil || !strings.Contains(err.Error(), "too many queries waiting") {
+		t.Fatalf("expected queue full error, got %v", err)
+	}
+	if err := <-waited; err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+
+	// Freeing a slot admits a waiting query.
+	go func() {
+		q, err := submit(ctx, orgID)
+		if err == nil {
+			done(q)
+		}
+		waited <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	done(running[0])
+	if err := <-waited; err != nil {
+		t.Fatalf("expected waiting query to be admitted, got %v", err)
+	}
+	done(running[1])
+}
+
+func TestController_OrganizationMemoryShare(t *testing.T) {
+	orgID := platform.ID(1)
+	var lookups int
+	c := pcontrol.New(pcontrol.Config{
+		Config: control.Config{
+			ExecutorDependencies: make(execute.Dependencies),
+			ConcurrencyQuota:     10,
+		},
+		OrganizationSettingsService: &mock.OrganizationSettingsService{
+			FindOrganizationSettingsF: func(ctx context.Context, id platform.ID) (*platform.OrganizationSettings, error) {
+				lookups++
+				if id != orgID {
+					return nil, &platform.Error{Code: platform.ENotFound, Msg: "organization not found"}
+				}
+				return &platform.OrganizationSettings{TotalQueryMemoryBytes: 2048}, nil
+			},
+		},
+		DefaultOrganizationSettings: platform.OrganizationSettings{
+			QueryConcurrency:  2,
+			QueryQueueTimeout: 50 * time.Millisecond,
+		},
+	})
+
+	submit := func(orgID platform.ID) (flux.Query, error) {
+		return c.Query(context.Background(), &query.Request{
+			OrganizationID: orgID,
+			Compiler: lang.FluxCompiler{
+				Query: `from(bucket: "telegraf") |> range(start: -1m)`,
+			},
+		})
+	}
+
+	// Each query reserves half of the memory of the organization, so both execute at once.
+	var running []flux.Query
+	for i := 0; i < 2; i++ {
+		q, err := submit(orgID)
+		if err != nil {
+			t.Fatalf("expected query %d to execute, got %v", i, err)
+		}
+		running = append(running, q)
+	}
+	for _, q := range running {
+		<-q.Ready()
+		q.Done()
+	}
+	if lookups != 1 {
+		t.Errorf("expected the settings of the organization to be looked up once, got %d", lookups)
+	}
+
+	// An organization without settings uses the defaults.
+	q, err := submit(platform.ID(2))
+	if err != nil {
+		t.Fatalf("expected the query of an organization without settings to execute, got %v", err)
+	}
+	<-q.Ready()
+	q.Done()
+}
+
+func TestController_Shutdown(t *testing.T) {
+	c := pcontrol.New(pcontrol.Config{
+		Config: control.Config{
+			ExecutorDependencies: make(execute.Dependencies),
+			ConcurrencyQuota:     1,
+		},
+	})
+
+	req := &query.Request{
+		Authorization:  &platform.Authorization{UserID: platform.ID(2)},
+		OrganizationID: platform.ID(1),
+		Compiler: lang.FluxCompiler{
+			Query: `from(bucket: "telegraf") |> range(start: -1m)`,
+		},
+	}
+	ctx := context.Background()
+	q, err := c.Query(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Shutdown(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("expected shutdown to wait for the active query, got %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	if _, err := c.Query(ctx, req); err == nil {
+		t.Fatal("expected error submitting a query once shut down")
+	}
+
+	q.Done()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected shutdown to return once the active query is done")
+	}
+}
@@ -0,0 +1,296 @@
+package control
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// orgQuotas admits the queries of each organization within the limits of the organization.
+// Queries that cannot be admitted wait in a first in, first out queue per organization.
+type orgQuotas struct {
+	mu   sync.Mutex
+	orgs map[platform.ID]*orgQuota
+
+	metrics *quotaMetrics
+}
+
+// orgQuota holds the resources reserved by the executing queries of an organization.
+type orgQuota struct {
+	executing int
+	memory    int64
+	waiting   []*quotaWaiter
+}
+
+// quotaWaiter is a query waiting for an execution slot.
+type quotaWaiter struct {
+	limits platform.OrganizationSettings
+	memory int64
+	ready  chan struct{}
+}
+
+func newOrgQuotas() *orgQuotas {
+	return &orgQuotas{
+		orgs:    make(map[platform.ID]*orgQuota),
+		metrics: newQuotaMetrics(),
+	}
+}
+
+// acquire reserves an execution slot and memory bytes for a query of the organization,
+// waiting in the queue of the organization until they are available.
+// A zero limit is unlimited.
+func (q *orgQuotas) acquire(ctx context.Context, orgID platform.ID, limits platform.OrganizationSettings, memory int64) error {
+	q.observeLimits(orgID, limits)
+
+	q.mu.Lock()
+	o := q.org(orgID)
+	if len(o.waiting) == 0 && o.fits(limits, memory) {
+		o.consume(memory)
+		q.observe(orgID, o)
+		q.mu.Unlock()
+		return nil
+	}
+	if limits.QueryQueueSize > 0 && len(o.waiting) >= limits.QueryQueueSize {
+		q.mu.Unlock()
+		q.metrics.rejected.WithLabelValues(orgID.String(), "queue_full").Inc()
+		return errors.Errorf(errors.TooManyRequests, "too many queries waiting to execute for organization %s", orgID)
+	}
+	w := &quotaWaiter{
+		limits: limits,
+		memory: memory,
+		ready:  make(chan struct{}),
+	}
+	o.waiting = append(o.waiting, w)
+	q.observe(orgID, o)
+	q.mu.Unlock()
+
+	var timeout <-chan time.Time
+	if limits.QueryQueueTimeout > 0 {
+		t := time.NewTimer(limits.QueryQueueTimeout)
+		defer t.Stop()
+		timeout = t.C
+	}
+
+	var (
+		err      error
+		timedOut bool
+	)
+	select {
+	case <-w.ready:
+		return nil
+	case <-timeout:
+		timedOut = true
+		err = errors.Errorf(errors.TooManyRequests, "timed out waiting to execute query for organization %s", orgID)
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	select {
+	case <-w.ready:
+		// The slot was granted while giving up, so keep it.
+		return nil
+	default:
+	}
+	for i, ww := range o.waiting {
+		if ww == w {
+			o.waiting = append(o.waiting[:i], o.waiting[i+1:]...)
+			break
+		}
+	}
+	// The queries behind the one giving up may now be admitted.
+	q.grant(o)
+	q.observe(orgID, o)
+	q.forget(orgID, o)
+	if timedOut {
+		q.metrics.rejected.WithLabelValues(orgID.String(), "timeout").Inc()
+	}
+	return err
+}
+
+// release frees the resources reserved by a query of the organization and admits waiting queries.
+func (q *orgQuotas) release(orgID platform.ID, memory int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	o := q.org(orgID)
+	o.executing--
+	o.memory -= memory
+	q.grant(o)
+	q.observe(orgID, o)
+	q.forget(orgID, o)
+}
+
+func (q *orgQuotas) org(orgID platform.ID) *orgQuota {
+	o, ok := q.orgs[orgID]
+	if !ok {
+		o = &orgQuota{}
+		q.orgs[orgID] = o
+	}
+	return o
+}
+
+// forget drops the state of an organization without queries.
+func (q *orgQuotas) forget(orgID platform.ID, o *orgQuota) {
+	if o.executing == 0 && len(o.waiting) == 0 {
+		delete(q.orgs, orgID)
+	}
+}
+
+// grant admits the waiting queries in order for as long as they fit.
+func (q *orgQuotas) grant(o *orgQuota) {
+	for len(o.waiting) > 0 {
+		w := o.waiting[0]
+		if !o.fits(w.limits, w.memory) {
+			return
+		}
+		o.consume(w.memory)
+		o.waiting = o.waiting[1:]
+		close(w.ready)
+	}
+}
+
+func (o *orgQuota) fits(limits platform.OrganizationSettings, memory int64) bool {
+	if limits.QueryConcurrency > 0 && o.executing >= limits.QueryConcurrency {
+		return false
+	}
+	if limits.TotalQueryMemoryBytes > 0 && o.memory+memory > limits.TotalQueryMemoryBytes {
+		return false
+	}
+	return true
+}
+
+func (o *orgQuota) consume(memory int64) {
+	o.executing++
+	o.memory += memory
+}
+
+func (q *orgQuotas) observe(orgID platform.ID, o *orgQuota) {
+	org := orgID.String()
+	q.metrics.executing.WithLabelValues(org).Set(float64(o.executing))
+	q.metrics.waiting.WithLabelValues(org).Set(float64(len(o.waiting)))
+	q.metrics.memory.WithLabelValues(org).Set(float64(o.memory))
+}
+
+func (q *orgQuotas) observeLimits(orgID platform.ID, limits platform.OrganizationSettings) {
+	org := orgID.String()
+	q.metrics.concurrencyLimit.WithLabelValues(org).Set(float64(limits.QueryConcurrency))
+	q.metrics.queueSizeLimit.WithLabelValues(org).Set(float64(limits.QueryQueueSize))
+	q.metrics.queryMemoryLimit.WithLabelValues(org).Set(float64(limits.QueryMemoryBytes))
+	q.metrics.totalMemoryLimit.WithLabelValues(org).Set(float64(limits.TotalQueryMemoryBytes))
+}
+
+// quotaMetrics holds metrics related to the per organization query quotas.
+type quotaMetrics struct {
+	executing *prometheus.GaugeVec
+	waiting   *prometheus.GaugeVec
+	memory    *prometheus.GaugeVec
+	rejected  *prometheus.CounterVec
+
+	concurrencyLimit *prometheus.GaugeVec
+	queueSizeLimit   *prometheus.GaugeVec
+	queryMemoryLimit *prometheus.GaugeVec
+	totalMemoryLimit *prometheus.GaugeVec
+}
+
+func newQuotaMetrics() *quotaMetrics {
+	const (
+		namespace = "query"
+		subsystem = "quota"
+	)
+
+	return &quotaMetrics{
+		executing: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "executing",
+			Help:      "Number of queries of the organization holding an execution slot",
+		}, []string{orgLabel}),
+
+		waiting: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "waiting",
+			Help:      "Number of queries of the organization waiting for an execution slot",
+		}, []string{orgLabel}),
+
+		memory: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "memory_reserved_bytes",
+			Help:      "Number of bytes reserved by the executing queries of the organization",
+		}, []string{orgLabel}),
+
+		rejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "rejected_total",
+			Help:      "Number of queries of the organization rejected because the queue was full or the wait timed out",
+		}, []string{orgLabel, "reason"}),
+
+		concurrencyLimit: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "concurrency_limit",
+			Help:      "Number of queries of the organization that may execute at once, 0 is unlimited",
+		}, []string{orgLabel}),
+
+		queueSizeLimit: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "queue_size_limit",
+			Help:      "Number of queries of the organization that may wait for an execution slot, 0 is unlimited",
+		}, []string{orgLabel}),
+
+		queryMemoryLimit: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "query_memory_limit_bytes",
+			Help:      "Number of bytes a single query of the organization may allocate, 0 is unlimited",
+		}, []string{orgLabel}),
+
+		totalMemoryLimit: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "total_memory_limit_bytes",
+			Help:      "Number of bytes all the executing queries of the organization may allocate, 0 is unlimited",
+		}, []string{orgLabel}),
+	}
+}
+
+// PrometheusCollectors satisifies the prom.PrometheusCollector interface.
+func (m *quotaMetrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.executing,
+		m.waiting,
+		m.memory,
+		m.rejected,
+		m.concurrencyLimit,
+		m.queueSizeLimit,
+		m.queryMemoryLimit,
+		m.totalMemoryLimit,
+	}
+}
+
+// memoryQuotaCompiler caps the memory quota of the queries it compiles.
+type memoryQuotaCompiler struct {
+	flux.Compiler
+	memory int64
+}
+
+func (c memoryQuotaCompiler) Compile(ctx context.Context) (*flux.Spec, error) {
+	spec, err := c.Compiler.Compile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if q := spec.Resources.MemoryBytesQuota; q == 0 || q > c.memory {
+		spec.Resources.MemoryBytesQuota = c.memory
+	}
+	return spec, nil
+}
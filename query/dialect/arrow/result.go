@@ -0,0 +1,226 @@
+// Package arrow encodes query results as Apache Arrow IPC streams.
+//
+// As the tables of a result have different schemas, every table is written
+// as an IPC stream of its own, one after another; readers read streams until
+// the end of the response. The schema metadata of a stream holds the name of
+// the result and the index of the table within it, and the metadata of the
+// columns of the group key marks them as such. Times are timestamps in
+// nanoseconds.
+//
+// An error is written as a stream without columns whose schema metadata holds
+// the error.
+package arrow
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/pkg/errors"
+)
+
+// The keys of the metadata of the streams.
+const (
+	ResultMetadataKey = "result"
+	TableMetadataKey  = "table"
+	GroupMetadataKey  = "group"
+	ErrorMetadataKey  = "error"
+)
+
+type encoderError struct {
+	msg string
+}
+
+func (e *encoderError) Error() string {
+	return e.msg
+}
+
+func (e *encoderError) IsEncoderError() bool {
+	return true
+}
+
+func wrapEncodingError(err error) error {
+	return errors.Wrap(&encoderError{msg: err.Error()}, "arrow encoder error")
+}
+
+// ResultEncoder encodes the tables of a result as Arrow IPC streams.
+type ResultEncoder struct {
+	mem memory.Allocator
+}
+
+// NewResultEncoder returns a new encoder of results as Arrow IPC streams.
+func NewResultEncoder() *ResultEncoder {
+	return &ResultEncoder{
+		mem: memory.NewGoAllocator(),
+	}
+}
+
+// Encode writes the tables of the result to w, one stream per table and
+// one record batch per chunk of the table.
+func (e *ResultEncoder) Encode(w io.Writer, result flux.Result) (int64, error) {
+	wc := &iocounter.Writer{Writer: w}
+
+	tableID := 0
+	err := result.Tables().Do(func(tbl flux.Table) error {
+		schema, err := tableSchema(result.Name(), tableID, tbl)
+		if err != nil {
+			return wrapEncodingError(err)
+		}
+		tableID++
+
+		sw := ipc.NewWriter(wc, ipc.WithSchema(schema), ipc.WithAllocator(e.mem))
+		builders := make([]array.Builder, len(schema.Fields()))
+		for j, f := range schema.Fields() {
+			builders[j] = newBuilder(e.mem, f.Type)
+			defer builders[j].Release()
+		}
+
+		written := false
+		if err := tbl.Do(func(cr flux.ColReader) error {
+			for j := range cr.Cols() {
+				appendColumn(builders[j], cr, j)
+			}
+			written = true
+			return writeRecord(sw, schema, builders, cr.Len())
+		}); err != nil {
+			return err
+		}
+		// The schema is only written with the first record, so an empty
+		// table is written as a record without rows.
+		if !written {
+			if err := writeRecord(sw, schema, builders, 0); err != nil {
+				return err
+			}
+		}
+
+		if err := sw.Close(); err != nil {
+			return wrapEncodingError(err)
+		}
+		return nil
+	})
+	return wc.Count(), err
+}
+
+// EncodeError writes the error to w as a stream without columns.
+func (e *ResultEncoder) EncodeError(w io.Writer, err error) error {
+	md := arrow.NewMetadata([]string{ErrorMetadataKey}, []string{err.Error()})
+	schema := arrow.NewSchema(nil, &md)
+	sw := ipc.NewWriter(w, ipc.WithSchema(schema), ipc.WithAllocator(e.mem))
+	if err := writeRecord(sw, schema, nil, 0); err != nil {
+		return err
+	}
+	return sw.Close()
+}
+
+// writeRecord writes the values appended to the builders as a record of n
+// rows. The builders are reset to build the next record.
+func writeRecord(sw *ipc.Writer, schema *arrow.Schema, builders []array.Builder, n int) error {
+	cols := make([]array.Interface, len(builders))
+	for j, b := range builders {
+		cols[j] = b.NewArray()
+		defer cols[j].Release()
+	}
+	rec := array.NewRecord(schema, cols, int64(n))
+	defer rec.Release()
+	if err := sw.Write(rec); err != nil {
+		return wrapEncodingError(err)
+	}
+	return nil
+}
+
+// tableSchema returns the Arrow schema of the table.
+func tableSchema(result string, tableID int, tbl flux.Table) (*arrow.Schema, error) {
+	cols := tbl.Cols()
+	fields := make([]arrow.Field, len(cols))
+	for j, c := range cols {
+		typ, err := arrowType(c.Type)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %v", c.Label, err)
+		}
+		fields[j] = arrow.Field{
+			Name: c.Label,
+			Type: typ,
+			Metadata: arrow.NewMetadata(
+				[]string{GroupMetadataKey},
+				[]string{strconv.FormatBool(tbl.Key().HasCol(c.Label))},
+			),
+		}
+	}
+	md := arrow.NewMetadata(
+		[]string{ResultMetadataKey, TableMetadataKey},
+		[]string{result, strconv.Itoa(tableID)},
+	)
+	return arrow.NewSchema(fields, &md), nil
+}
+
+func arrowType(t flux.DataType) (arrow.DataType, error) {
+	switch t {
+	case flux.TBool:
+		return arrow.FixedWidthTypes.Boolean, nil
+	case flux.TInt:
+		return arrow.PrimitiveTypes.Int64, nil
+	case flux.TUInt:
+		return arrow.PrimitiveTypes.Uint64, nil
+	case flux.TFloat:
+		return arrow.PrimitiveTypes.Float64, nil
+	case flux.TString:
+		return arrow.BinaryTypes.String, nil
+	case flux.TTime:
+		return arrow.FixedWidthTypes.Timestamp, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// newBuilder returns the builder of arrays of a type returned by arrowType.
+func newBuilder(mem memory.Allocator, typ arrow.DataType) array.Builder {
+	switch typ := typ.(type) {
+	case *arrow.BooleanType:
+		return array.NewBooleanBuilder(mem)
+	case *arrow.Int64Type:
+		return array.NewInt64Builder(mem)
+	case *arrow.Uint64Type:
+		return array.NewUint64Builder(mem)
+	case *arrow.Float64Type:
+		return array.NewFloat64Builder(mem)
+	case *arrow.StringType:
+		return array.NewStringBuilder(mem)
+	case *arrow.TimestampType:
+		return array.NewTimestampBuilder(mem, typ)
+	}
+	panic(fmt.Sprintf("arrow: unexpected type %s", typ))
+}
+
+// appendColumn appends the values of column j of the chunk to the builder
+// of its type, as created by newBuilder for the schema returned by tableSchema.
+func appendColumn(b array.Builder, cr flux.ColReader, j int) {
+	switch cr.Cols()[j].Type {
+	case flux.TBool:
+		b.(*array.BooleanBuilder).AppendValues(cr.Bools(j), nil)
+	case flux.TInt:
+		b.(*array.Int64Builder).AppendValues(cr.Ints(j), nil)
+	case flux.TUInt:
+		b.(*array.Uint64Builder).AppendValues(cr.UInts(j), nil)
+	case flux.TFloat:
+		b.(*array.Float64Builder).AppendValues(cr.Floats(j), nil)
+	case flux.TString:
+		b.(*array.StringBuilder).AppendValues(cr.Strings(j), nil)
+	case flux.TTime:
+		tb := b.(*array.TimestampBuilder)
+		for _, t := range cr.Times(j) {
+			tb.Append(arrow.Timestamp(t))
+		}
+	}
+}
+
+// NewMultiResultEncoder returns an encoder of results as Arrow IPC streams.
+func NewMultiResultEncoder() flux.MultiResultEncoder {
+	return &flux.DelimitedMultiResultEncoder{
+		Encoder: NewResultEncoder(),
+	}
+}
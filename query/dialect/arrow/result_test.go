@@ -0,0 +1,163 @@
+package arrow_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	arrowpb "github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/platform/query/dialect/arrow"
+	"github.com/pkg/errors"
+)
+
+type stream struct {
+	schema  *arrowpb.Schema
+	records []array.Record
+}
+
+// readStreams reads the consecutive streams of the encoded results.
+func readStreams(t *testing.T, r io.Reader) []stream {
+	t.Helper()
+	var streams []stream
+	for {
+		rdr, err := ipc.NewReader(r)
+		if err != nil {
+			if errors.Cause(err) == io.EOF {
+				return streams
+			}
+			t.Fatal(err)
+		}
+		s := stream{schema: rdr.Schema()}
+		for rdr.Next() {
+			rec := rdr.Record()
+			rec.Retain()
+			s.records = append(s.records, rec)
+		}
+		if err := rdr.Err(); err != nil {
+			t.Fatal(err)
+		}
+		streams = append(streams, s)
+	}
+}
+
+func metadataValue(md arrowpb.Metadata, key string) string {
+	if i := md.FindKey(key); i >= 0 {
+		return md.Values()[i]
+	}
+	return ""
+}
+
+func TestMultiResultEncoder_Encode(t *testing.T) {
+	ts := func(s int) values.Time {
+		return values.ConvertTime(time.Date(2018, 4, 17, 0, 0, s, 0, time.UTC))
+	}
+	in := flux.NewSliceResultIterator([]flux.Result{&executetest.Result{
+		Nm: "_result",
+		Tbls: []*executetest.Table{
+			{
+				KeyCols: []string{"host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{ts(0), "A", 42.5},
+					{ts(1), "A", 43.5},
+				},
+			},
+			{
+				KeyCols: []string{"host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{ts(0), "B", int64(7)},
+				},
+			},
+		},
+	}})
+
+	var buf bytes.Buffer
+	if _, err := arrow.NewMultiResultEncoder().Encode(&buf, in); err != nil {
+		t.Fatal(err)
+	}
+
+	streams := readStreams(t, &buf)
+	if len(streams) != 2 {
+		t.Fatalf("expected a stream per table, got %d", len(streams))
+	}
+
+	schema := streams[0].schema
+	if got, want := metadataValue(schema.Metadata(), arrow.ResultMetadataKey), "_result"; got != want {
+		t.Errorf("unexpected result name: got %q, want %q", got, want)
+	}
+	if got, want := metadataValue(streams[1].schema.Metadata(), arrow.TableMetadataKey), "1"; got != want {
+		t.Errorf("unexpected table index: got %q, want %q", got, want)
+	}
+	if got, want := metadataValue(schema.Field(1).Metadata, arrow.GroupMetadataKey), "true"; got != want {
+		t.Errorf("expected host to be in the group key: got %q", got)
+	}
+	if ts, ok := schema.Field(0).Type.(*arrowpb.TimestampType); !ok || ts.Unit != arrowpb.Nanosecond {
+		t.Errorf("unexpected time type: got %v, want timestamps in nanoseconds", schema.Field(0).Type)
+	}
+
+	var (
+		times  []int64
+		hosts  []string
+		floats []float64
+	)
+	for _, rec := range streams[0].records {
+		for i := 0; i < int(rec.NumRows()); i++ {
+			times = append(times, int64(rec.Column(0).(*array.Timestamp).Value(i)))
+			hosts = append(hosts, rec.Column(1).(*array.String).Value(i))
+		}
+		floats = append(floats, rec.Column(2).(*array.Float64).Float64Values()...)
+	}
+	if got, want := times, []int64{int64(ts(0)), int64(ts(1))}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected times: got %v, want %v", got, want)
+	}
+	if got, want := hosts, []string{"A", "A"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected hosts: got %v, want %v", got, want)
+	}
+	if got, want := floats, []float64{42.5, 43.5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected values: got %v, want %v", got, want)
+	}
+
+	var ints []int64
+	for _, rec := range streams[1].records {
+		ints = append(ints, rec.Column(2).(*array.Int64).Int64Values()...)
+	}
+	if got, want := ints, []int64{7}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected values: got %v, want %v", got, want)
+	}
+}
+
+func TestMultiResultEncoder_EncodeError(t *testing.T) {
+	in := flux.NewSliceResultIterator([]flux.Result{&executetest.Result{
+		Nm:  "_result",
+		Err: errors.New("expected error"),
+	}})
+
+	var buf bytes.Buffer
+	if _, err := arrow.NewMultiResultEncoder().Encode(&buf, in); err != nil {
+		t.Fatal(err)
+	}
+
+	streams := readStreams(t, &buf)
+	if len(streams) != 1 {
+		t.Fatalf("expected a single stream, got %d", len(streams))
+	}
+	if got, want := metadataValue(streams[0].schema.Metadata(), arrow.ErrorMetadataKey), "expected error"; got != want {
+		t.Errorf("unexpected error: got %q, want %q", got, want)
+	}
+}
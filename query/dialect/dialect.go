@@ -0,0 +1,26 @@
+// Package dialect registers the dialects query results can be encoded in.
+package dialect
+
+import (
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/platform/query/dialect/arrow"
+	"github.com/influxdata/platform/query/dialect/json"
+	"github.com/influxdata/platform/query/dialect/lineprotocol"
+)
+
+// AddDialectMappings adds the mappings of the CSV, JSON, line protocol and
+// Arrow dialects.
+func AddDialectMappings(mappings flux.DialectMappings) error {
+	for _, add := range []func(flux.DialectMappings) error{
+		csv.AddDialectMappings,
+		json.AddDialectMappings,
+		lineprotocol.AddDialectMappings,
+		arrow.AddDialectMappings,
+	} {
+		if err := add(mappings); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// Package json encodes query results as newline delimited JSON.
+//
+// Every table is written on its own line as an object with the name of its
+// result, its index within the result, its typed columns and its rows:
+//
+//	{"result":"_result","table":0,"columns":[{"label":"_time","type":"time","group":false},...],"data":[["2018-05-08T20:50:00Z",...],...]}
+//
+// Times are RFC3339Nano strings and the float values NaN, +Inf and -Inf are
+// written as strings. An error is written as an object with an error key.
+package json
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/pkg/errors"
+)
+
+// Column describes a column of a table.
+type Column struct {
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Group bool   `json:"group"`
+}
+
+// Table is the JSON representation of a table.
+type Table struct {
+	Result  string          `json:"result"`
+	Table   int             `json:"table"`
+	Columns []Column        `json:"columns"`
+	Data    [][]interface{} `json:"data"`
+}
+
+type jsonError struct {
+	Error string `json:"error"`
+}
+
+type encoderError struct {
+	msg string
+}
+
+func (e *encoderError) Error() string {
+	return e.msg
+}
+
+func (e *encoderError) IsEncoderError() bool {
+	return true
+}
+
+func wrapEncodingError(err error) error {
+	return errors.Wrap(&encoderError{msg: err.Error()}, "json encoder error")
+}
+
+// ResultEncoder encodes the tables of a result as lines of JSON.
+type ResultEncoder struct{}
+
+// NewResultEncoder returns a new encoder of results as JSON.
+func NewResultEncoder() *ResultEncoder {
+	return new(ResultEncoder)
+}
+
+// Encode writes the tables of the result to w, one per line.
+func (e *ResultEncoder) Encode(w io.Writer, result flux.Result) (int64, error) {
+	wc := &iocounter.Writer{Writer: w}
+	enc := json.NewEncoder(wc)
+
+	tableID := 0
+	err := result.Tables().Do(func(tbl flux.Table) error {
+		t := Table{
+			Result:  result.Name(),
+			Table:   tableID,
+			Columns: make([]Column, len(tbl.Cols())),
+			Data:    [][]interface{}{},
+		}
+		for j, c := range tbl.Cols() {
+			t.Columns[j] = Column{
+				Label: c.Label,
+				Type:  c.Type.String(),
+				Group: tbl.Key().HasCol(c.Label),
+			}
+		}
+		if err := tbl.Do(func(cr flux.ColReader) error {
+			for i := 0; i < cr.Len(); i++ {
+				row := make([]interface{}, len(cr.Cols()))
+				for j := range row {
+					row[j] = columnValue(cr, i, j)
+				}
+				t.Data = append(t.Data, row)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		tableID++
+
+		if err := enc.Encode(t); err != nil {
+			return wrapEncodingError(err)
+		}
+		return nil
+	})
+	return wc.Count(), err
+}
+
+// EncodeError writes the error to w as a line of JSON.
+func (e *ResultEncoder) EncodeError(w io.Writer, err error) error {
+	return json.NewEncoder(w).Encode(jsonError{Error: err.Error()})
+}
+
+func columnValue(cr flux.ColReader, i, j int) interface{} {
+	switch cr.Cols()[j].Type {
+	case flux.TBool:
+		return cr.Bools(j)[i]
+	case flux.TInt:
+		return cr.Ints(j)[i]
+	case flux.TUInt:
+		return cr.UInts(j)[i]
+	case flux.TFloat:
+		return floatValue(cr.Floats(j)[i])
+	case flux.TString:
+		return cr.Strings(j)[i]
+	case flux.TTime:
+		return cr.Times(j)[i].Time().Format(time.RFC3339Nano)
+	}
+	return nil
+}
+
+// floatValue returns f or, as JSON has no representation for them, the
+// string of NaN and infinite values.
+func floatValue(f float64) interface{} {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	}
+	return f
+}
+
+// NewMultiResultEncoder returns an encoder of results as newline delimited JSON.
+func NewMultiResultEncoder() flux.MultiResultEncoder {
+	return &flux.DelimitedMultiResultEncoder{
+		Encoder: NewResultEncoder(),
+	}
+}
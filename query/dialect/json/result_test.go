@@ -0,0 +1,85 @@
+package json_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/platform/query/dialect/json"
+)
+
+func TestMultiResultEncoder_Encode(t *testing.T) {
+	ts := func(s int) values.Time {
+		return values.ConvertTime(time.Date(2018, 4, 17, 0, 0, s, 0, time.UTC))
+	}
+	for _, tt := range []struct {
+		name string
+		in   flux.ResultIterator
+		out  string
+	}{
+		{
+			name: "tables",
+			in: flux.NewSliceResultIterator([]flux.Result{&executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{
+					{
+						KeyCols: []string{"host"},
+						ColMeta: []flux.ColMeta{
+							{Label: "_time", Type: flux.TTime},
+							{Label: "host", Type: flux.TString},
+							{Label: "_value", Type: flux.TFloat},
+							{Label: "ok", Type: flux.TBool},
+						},
+						Data: [][]interface{}{
+							{ts(0), "A", 42.5, true},
+							{ts(1), "A", math.NaN(), false},
+						},
+					},
+					{
+						KeyCols: []string{"host"},
+						ColMeta: []flux.ColMeta{
+							{Label: "_time", Type: flux.TTime},
+							{Label: "host", Type: flux.TString},
+							{Label: "_value", Type: flux.TInt},
+						},
+						Data: [][]interface{}{
+							{ts(0), "B", int64(7)},
+						},
+					},
+				},
+			}}),
+			out: `{"result":"_result","table":0,"columns":[{"label":"_time","type":"time","group":false},{"label":"host","type":"string","group":true},{"label":"_value","type":"float","group":false},{"label":"ok","type":"bool","group":false}],"data":[["2018-04-17T00:00:00Z","A",42.5,true],["2018-04-17T00:00:01Z","A","NaN",false]]}
+{"result":"_result","table":1,"columns":[{"label":"_time","type":"time","group":false},{"label":"host","type":"string","group":true},{"label":"_value","type":"int","group":false}],"data":[["2018-04-17T00:00:00Z","B",7]]}
+`,
+		},
+		{
+			name: "error",
+			in: flux.NewSliceResultIterator([]flux.Result{&executetest.Result{
+				Nm:  "_result",
+				Err: errors.New("expected error"),
+			}}),
+			out: `{"error":"expected error"}
+`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := json.NewMultiResultEncoder()
+			n, err := enc.Encode(&buf, tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := buf.String(), tt.out; got != want {
+				t.Errorf("unexpected output:\ngot:  %s\nwant: %s", got, want)
+			}
+			if n != int64(buf.Len()) {
+				t.Errorf("unexpected byte count: got %d, want %d", n, buf.Len())
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Package lineprotocol encodes query results as line protocol so they can be
+// written to another instance.
+//
+// Every row is written as a point. Its measurement is the _measurement column
+// and its time the _time column. The string columns of the group key other
+// than _measurement, _field, _start and _stop are tags. The _value column is
+// the field named by the _field column, if any, and the other columns outside
+// of the group key are fields of their own name; times other than _time are
+// written as integer nanoseconds.
+// An error is written as a comment line.
+package lineprotocol
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/line-protocol"
+	"github.com/pkg/errors"
+)
+
+const (
+	measurementColLabel = "_measurement"
+	fieldColLabel       = "_field"
+)
+
+type encoderError struct {
+	msg string
+}
+
+func (e *encoderError) Error() string {
+	return e.msg
+}
+
+func (e *encoderError) IsEncoderError() bool {
+	return true
+}
+
+func wrapEncodingError(err error) error {
+	return errors.Wrap(&encoderError{msg: err.Error()}, "line protocol encoder error")
+}
+
+// ResultEncoder encodes the rows of a result as line protocol.
+type ResultEncoder struct{}
+
+// NewResultEncoder returns a new encoder of results as line protocol.
+func NewResultEncoder() *ResultEncoder {
+	return new(ResultEncoder)
+}
+
+// colKind is how a column is encoded in a point.
+type colKind int
+
+const (
+	skipCol colKind = iota
+	measurementCol
+	timeCol
+	tagCol
+	fieldCol
+	valueCol
+)
+
+// Encode writes the rows of the result to w, one point per line.
+func (e *ResultEncoder) Encode(w io.Writer, result flux.Result) (int64, error) {
+	wc := &iocounter.Writer{Writer: w}
+	enc := protocol.NewEncoder(wc)
+	enc.FailOnFieldErr(true)
+	enc.SetFieldSortOrder(protocol.SortFields)
+
+	err := result.Tables().Do(func(tbl flux.Table) error {
+		kinds, err := colKinds(tbl)
+		if err != nil {
+			return wrapEncodingError(err)
+		}
+		fieldIdx := execute.ColIdx(fieldColLabel, tbl.Cols())
+
+		m := new(metric)
+		return tbl.Do(func(cr flux.ColReader) error {
+			for i := 0; i < cr.Len(); i++ {
+				m.reset()
+				for j, c := range cr.Cols() {
+					switch kinds[j] {
+					case measurementCol:
+						m.name = cr.Strings(j)[i]
+					case timeCol:
+						m.t = cr.Times(j)[i].Time()
+					case tagCol:
+						m.tags = append(m.tags, &protocol.Tag{Key: c.Label, Value: cr.Strings(j)[i]})
+					case fieldCol:
+						m.fields = append(m.fields, &protocol.Field{Key: c.Label, Value: fieldValue(cr, i, j)})
+					case valueCol:
+						key := c.Label
+						if fieldIdx >= 0 {
+							key = cr.Strings(fieldIdx)[i]
+						}
+						m.fields = append(m.fields, &protocol.Field{Key: key, Value: fieldValue(cr, i, j)})
+					}
+				}
+				if _, err := enc.Encode(m); err != nil {
+					return wrapEncodingError(err)
+				}
+			}
+			return nil
+		})
+	})
+	return wc.Count(), err
+}
+
+// EncodeError writes the error to w as a comment.
+func (e *ResultEncoder) EncodeError(w io.Writer, err error) error {
+	msg := strings.Replace(err.Error(), "\n", " ", -1)
+	_, werr := fmt.Fprintf(w, "# error: %s\n", msg)
+	return werr
+}
+
+// colKinds returns how each column of the table is encoded.
+func colKinds(tbl flux.Table) ([]colKind, error) {
+	cols := tbl.Cols()
+	kinds := make([]colKind, len(cols))
+	var hasMeasurement, hasTime bool
+	for j, c := range cols {
+		switch {
+		case c.Label == measurementColLabel:
+			if c.Type != flux.TString {
+				return nil, fmt.Errorf("column %s is not of type %s", c.Label, flux.TString)
+			}
+			kinds[j], hasMeasurement = measurementCol, true
+		case c.Label == execute.DefaultTimeColLabel:
+			if c.Type != flux.TTime {
+				return nil, fmt.Errorf("column %s is not of type %s", c.Label, flux.TTime)
+			}
+			kinds[j], hasTime = timeCol, true
+		case c.Label == fieldColLabel:
+			if c.Type != flux.TString {
+				return nil, fmt.Errorf("column %s is not of type %s", c.Label, flux.TString)
+			}
+		case c.Label == execute.DefaultStartColLabel,
+			c.Label == execute.DefaultStopColLabel:
+			kinds[j] = skipCol
+		case c.Label == execute.DefaultValueColLabel:
+			kinds[j] = valueCol
+		case tbl.Key().HasCol(c.Label):
+			if c.Type == flux.TString {
+				kinds[j] = tagCol
+			}
+		default:
+			kinds[j] = fieldCol
+		}
+	}
+	if !hasMeasurement {
+		return nil, fmt.Errorf("table has no %s column", measurementColLabel)
+	}
+	if !hasTime {
+		return nil, fmt.Errorf("table has no %s column", execute.DefaultTimeColLabel)
+	}
+	return kinds, nil
+}
+
+func fieldValue(cr flux.ColReader, i, j int) interface{} {
+	switch cr.Cols()[j].Type {
+	case flux.TBool:
+		return cr.Bools(j)[i]
+	case flux.TInt:
+		return cr.Ints(j)[i]
+	case flux.TUInt:
+		return cr.UInts(j)[i]
+	case flux.TFloat:
+		return cr.Floats(j)[i]
+	case flux.TString:
+		return cr.Strings(j)[i]
+	case flux.TTime:
+		return cr.Times(j)[i].Time().UnixNano()
+	}
+	return nil
+}
+
+// metric is a row of a table as a point.
+type metric struct {
+	name   string
+	t      time.Time
+	tags   []*protocol.Tag
+	fields []*protocol.Field
+}
+
+func (m *metric) reset() {
+	m.name = ""
+	m.t = time.Time{}
+	m.tags = m.tags[:0]
+	m.fields = m.fields[:0]
+}
+
+func (m *metric) Name() string {
+	return m.name
+}
+
+func (m *metric) Time() time.Time {
+	return m.t
+}
+
+func (m *metric) TagList() []*protocol.Tag {
+	return m.tags
+}
+
+func (m *metric) FieldList() []*protocol.Field {
+	return m.fields
+}
+
+// NewMultiResultEncoder returns an encoder of results as line protocol.
+func NewMultiResultEncoder() flux.MultiResultEncoder {
+	return &flux.DelimitedMultiResultEncoder{
+		Encoder: NewResultEncoder(),
+	}
+}
@@ -0,0 +1,113 @@
+package lineprotocol_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/platform/query/dialect/lineprotocol"
+)
+
+func TestMultiResultEncoder_Encode(t *testing.T) {
+	ts := func(s int) values.Time {
+		return values.ConvertTime(time.Date(2018, 4, 17, 0, 0, s, 0, time.UTC))
+	}
+	for _, tt := range []struct {
+		name    string
+		in      flux.ResultIterator
+		out     string
+		wantErr bool
+	}{
+		{
+			name: "field and value",
+			in: flux.NewSliceResultIterator([]flux.Result{&executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					KeyCols: []string{"_start", "_stop", "_measurement", "_field", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_stop", Type: flux.TTime},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{ts(0), ts(10), ts(1), "cpu", "usage_user", "A", 42.5},
+						{ts(0), ts(10), ts(2), "cpu", "usage_user", "A", 43.0},
+					},
+				}},
+			}}),
+			out: "cpu,host=A usage_user=42.5 1523923201000000000\n" +
+				"cpu,host=A usage_user=43 1523923202000000000\n",
+		},
+		{
+			name: "pivoted fields",
+			in: flux.NewSliceResultIterator([]flux.Result{&executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					KeyCols: []string{"_measurement", "region"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "region", Type: flux.TString},
+						{Label: "count", Type: flux.TInt},
+						{Label: "ok", Type: flux.TBool},
+						{Label: "status", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{ts(1), "http", "eu", int64(3), true, "up"},
+					},
+				}},
+			}}),
+			out: "http,region=eu count=3i,ok=true,status=\"up\" 1523923201000000000\n",
+		},
+		{
+			name: "query error",
+			in: flux.NewSliceResultIterator([]flux.Result{&executetest.Result{
+				Nm:  "_result",
+				Err: errors.New("expected error"),
+			}}),
+			out: "# error: expected error\n",
+		},
+		{
+			name: "no measurement",
+			in: flux.NewSliceResultIterator([]flux.Result{&executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{ts(1), 1.0},
+					},
+				}},
+			}}),
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := lineprotocol.NewMultiResultEncoder()
+			_, err := enc.Encode(&buf, tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !flux.IsEncoderError(err) {
+					t.Errorf("expected an encoder error, got %v", err)
+				}
+				return
+			}
+			if got, want := buf.String(), tt.out; got != want {
+				t.Errorf("unexpected output:\ngot:  %q\nwant: %q", got, want)
+			}
+		})
+	}
+}
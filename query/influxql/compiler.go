@@ -21,8 +21,24 @@ type Compiler struct {
 	Cluster string `json:"cluster,omitempty"`
 	DB      string `json:"db,omitempty"`
 	RP      string `json:"rp,omitempty"`
+	Bucket  string `json:"bucket,omitempty"`
 	Query   string `json:"query"`
 
+	// OrganizationID is the organization running the query.
+	OrganizationID platform.ID `json:"organizationID,omitempty"`
+
+	// Authorization is the authorization the query runs with. The queries
+	// used to expand wildcards are run with it.
+	Authorization *platform.Authorization `json:"-"`
+
+	// SchemaQuerier is used to expand wildcards. Queries with wildcards
+	// return an error when it is not set.
+	SchemaQuerier SchemaQuerier `json:"-"`
+
+	// BucketService is used to show the retention period of retention
+	// policies. SHOW RETENTION POLICIES returns an error when it is not set.
+	BucketService platform.BucketService `json:"-"`
+
 	dbrpMappingSvc platform.DBRPMappingService
 }
 
@@ -40,6 +56,11 @@ func (c *Compiler) Compile(ctx context.Context) (*flux.Spec, error) {
 			Cluster:                c.Cluster,
 			DefaultDatabase:        c.DB,
 			DefaultRetentionPolicy: c.RP,
+			Bucket:                 c.Bucket,
+			SchemaQuerier:          c.SchemaQuerier,
+			OrganizationID:         c.OrganizationID,
+			Authorization:          c.Authorization,
+			BucketService:          c.BucketService,
 		},
 	)
 	return transpiler.Transpile(ctx, c.Query)
@@ -1,12 +1,101 @@
 package influxql_test
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
 	"github.com/influxdata/platform/query/influxql"
 )
 
 func TestCompiler(t *testing.T) {
 	var _ flux.Compiler = (*influxql.Compiler)(nil)
 }
+
+func TestCompiler_Bucket(t *testing.T) {
+	svc := mock.NewDBRPMappingService()
+	svc.FindFn = func(ctx context.Context, filter platform.DBRPMappingFilter) (*platform.DBRPMapping, error) {
+		t.Error("unexpected lookup of the bucket mapped to the database")
+		return nil, nil
+	}
+
+	c := influxql.NewCompiler(svc)
+	c.Bucket = "telegraf"
+	c.Query = `SELECT value FROM cpu`
+	spec, err := c.Compile(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var found bool
+	for _, op := range spec.Operations {
+		if from, ok := op.Spec.(*inputs.FromOpSpec); ok {
+			found = true
+			if got, want := from.Bucket, "telegraf"; got != want {
+				t.Errorf("unexpected bucket: got %q, want %q", got, want)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected the query to read from a bucket")
+	}
+}
+
+func TestCompiler_BucketExplicitSource(t *testing.T) {
+	bucketID := platform.ID(0x020f755c3c082001)
+	svc := mock.NewDBRPMappingService()
+	svc.FindFn = func(ctx context.Context, filter platform.DBRPMappingFilter) (*platform.DBRPMapping, error) {
+		if *filter.Database != "db0" || *filter.RetentionPolicy != "autogen" {
+			t.Errorf("unexpected lookup of the bucket mapped to %s", filter)
+		}
+		return &platform.DBRPMapping{BucketID: bucketID}, nil
+	}
+
+	c := influxql.NewCompiler(svc)
+	c.Bucket = "telegraf"
+	c.Query = `SELECT value FROM db0.autogen.cpu`
+	spec, err := c.Compile(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, op := range spec.Operations {
+		if from, ok := op.Spec.(*inputs.FromOpSpec); ok {
+			if from.Bucket != "" || from.BucketID != bucketID.String() {
+				t.Errorf("expected the query to read the mapped bucket %s, got bucket %q and bucket ID %q", bucketID, from.Bucket, from.BucketID)
+			}
+		}
+	}
+}
+
+func TestCompiler_ShowDatabasesOfOrganization(t *testing.T) {
+	orgID, otherOrgID := platform.ID(0x020f755c3c082000), platform.ID(0x020f755c3c082001)
+	svc := mock.NewDBRPMappingService()
+	svc.FindManyFn = func(ctx context.Context, filter platform.DBRPMappingFilter, opt ...platform.FindOptions) ([]*platform.DBRPMapping, int, error) {
+		return []*platform.DBRPMapping{
+			{Cluster: "cluster", Database: "db0", RetentionPolicy: "autogen", OrganizationID: orgID},
+			{Cluster: "cluster", Database: "db1", RetentionPolicy: "autogen", OrganizationID: otherOrgID},
+		}, 2, nil
+	}
+
+	c := influxql.NewCompiler(svc)
+	c.Cluster = "cluster"
+	c.OrganizationID = orgID
+	c.Query = `SHOW DATABASES`
+	spec, err := c.Compile(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, op := range spec.Operations {
+		if from, ok := op.Spec.(*inputs.FromCSVOpSpec); ok {
+			if !strings.Contains(from.CSV, "db0") || strings.Contains(from.CSV, "db1") {
+				t.Errorf("expected only the databases of the organization, got:\n%s", from.CSV)
+			}
+		}
+	}
+}
@@ -2,6 +2,8 @@ package influxql
 
 import (
 	"time"
+
+	"github.com/influxdata/platform"
 )
 
 // Config modifies the behavior of the Transpiler.
@@ -10,4 +12,21 @@ type Config struct {
 	DefaultRetentionPolicy string
 	NowFn                  func() time.Time
 	Cluster                string
+	// Bucket is the name of the bucket to read from instead of the bucket
+	// mapped to the default database and retention policy. Measurements
+	// naming their database or retention policy read from the bucket mapped
+	// to them.
+	Bucket string
+	// SchemaQuerier is used to discover the fields and tags of measurements
+	// when a query contains wildcards.
+	SchemaQuerier SchemaQuerier
+	// OrganizationID is the organization running the query. Meta queries
+	// only list the databases and retention policies mapped to its buckets.
+	OrganizationID platform.ID
+	// Authorization is the authorization the query runs with. The schema
+	// queries are run with it.
+	Authorization *platform.Authorization
+	// BucketService is used to read the retention period of the buckets
+	// mapped to retention policies.
+	BucketService platform.BucketService
 }
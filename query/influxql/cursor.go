@@ -1,7 +1,9 @@
 package influxql
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/ast"
@@ -26,45 +28,84 @@ type cursor interface {
 	Value(expr influxql.Expr) (string, bool)
 }
 
-// varRefCursor contains a cursor for a single variable. This is usually the raw value
-// coming from the database and points to the default value column property.
+// varRefCursor contains a cursor for the variables read from the sources. When there is a
+// single variable, it points to the default value column. Otherwise, the fields are pivoted
+// so each variable is in a column with the same name as the variable.
 type varRefCursor struct {
-	id  flux.OperationID
-	ref *influxql.VarRef
+	id   flux.OperationID
+	refs []*influxql.VarRef
 }
 
-// createVarRefCursor creates a new cursor from a variable reference using the sources
+// createVarRefCursor creates a new cursor from the variable references using the sources
 // in the transpilerState.
-func createVarRefCursor(t *transpilerState, ref *influxql.VarRef) (cursor, error) {
-	if len(t.stmt.Sources) != 1 {
-		// TODO(jsternberg): Support multiple sources.
-		return nil, errors.New("unimplemented: only one source is allowed")
+func createVarRefCursor(ctx context.Context, t *transpilerState, refs ...*influxql.VarRef) (cursor, error) {
+	tr, err := t.timeRange()
+	if err != nil {
+		return nil, err
 	}
 
-	// Only support a direct measurement. Subqueries are not supported yet.
-	mm, ok := t.stmt.Sources[0].(*influxql.Measurement)
-	if !ok {
-		return nil, errors.New("unimplemented: source must be a measurement")
+	// Measurements that are read from the same database and retention policy share a single
+	// read of the bucket. Each subquery is its own input. The inputs are kept in the order
+	// they first appear in the sources.
+	type input struct {
+		measurements []*influxql.Measurement
+		subquery     *influxql.SubQuery
+	}
+	var inputs []*input
+	buckets := make(map[[2]string]*input)
+	for _, source := range t.stmt.Sources {
+		switch source := source.(type) {
+		case *influxql.Measurement:
+			key := [2]string{source.Database, source.RetentionPolicy}
+			in, ok := buckets[key]
+			if !ok {
+				in = &input{}
+				buckets[key] = in
+				inputs = append(inputs, in)
+			}
+			in.measurements = append(in.measurements, source)
+		case *influxql.SubQuery:
+			inputs = append(inputs, &input{subquery: source})
+		default:
+			return nil, fmt.Errorf("unimplemented: source type %T", source)
+		}
 	}
 
-	// Create the from spec and add it to the list of operations.
-	from, err := t.from(mm)
-	if err != nil {
-		return nil, err
+	ids := make([]flux.OperationID, 0, len(inputs))
+	for _, in := range inputs {
+		var (
+			id  flux.OperationID
+			err error
+		)
+		if in.subquery != nil {
+			id, err = t.subqueryInput(ctx, in.subquery, refs, tr)
+		} else {
+			id, err = t.measurementInput(in.measurements, refs, tr)
+		}
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
 	}
 
-	valuer := influxql.NowValuer{Now: t.spec.Now}
-	_, tr, err := influxql.ConditionExpr(t.stmt.Condition, &valuer)
-	if err != nil {
-		return nil, err
+	id := ids[0]
+	if len(ids) > 1 {
+		id = t.op("union", &transformations.UnionOpSpec{}, ids...)
 	}
+	return &varRefCursor{
+		id:   id,
+		refs: refs,
+	}, nil
+}
 
-	// If the maximum is not set and we have a windowing function, then
-	// the end time will be set to now.
-	if tr.Max.IsZero() {
-		if window, err := t.stmt.GroupByInterval(); err == nil && window > 0 {
-			tr.Max = t.spec.Now
-		}
+// measurementInput reads the fields from the measurements. All of the measurements must
+// be from the same database and retention policy. If there is more than one field, the
+// fields are pivoted into their own columns.
+func (t *transpilerState) measurementInput(measurements []*influxql.Measurement, refs []*influxql.VarRef, tr influxql.TimeRange) (flux.OperationID, error) {
+	// Create the from spec and add it to the list of operations.
+	from, err := t.from(measurements[0])
+	if err != nil {
+		return "", err
 	}
 
 	range_ := t.op("range", &transformations.RangeOpSpec{
@@ -82,29 +123,259 @@ func createVarRefCursor(t *transpilerState, ref *influxql.VarRef) (cursor, error
 			},
 			Body: &semantic.LogicalExpression{
 				Operator: ast.AndOperator,
-				Left: &semantic.BinaryExpression{
-					Operator: ast.EqualOperator,
-					Left: &semantic.MemberExpression{
-						Object:   &semantic.IdentifierExpression{Name: "r"},
-						Property: "_measurement",
-					},
-					Right: &semantic.StringLiteral{Value: mm.Name},
-				},
-				Right: &semantic.BinaryExpression{
-					Operator: ast.EqualOperator,
-					Left: &semantic.MemberExpression{
-						Object:   &semantic.IdentifierExpression{Name: "r"},
-						Property: "_field",
-					},
-					Right: &semantic.StringLiteral{Value: ref.Val},
-				},
+				Left:     measurementExpr(measurements),
+				Right:    fieldExpr(refs),
 			},
 		},
 	}, range_)
-	return &varRefCursor{
-		id:  id,
-		ref: ref,
-	}, nil
+	if len(refs) == 1 {
+		return id, nil
+	}
+	return t.op("pivot", &transformations.PivotOpSpec{
+		RowKey:   []string{execute.DefaultTimeColLabel},
+		ColKey:   []string{"_field"},
+		ValueCol: execute.DefaultValueColLabel,
+	}, id), nil
+}
+
+// fieldExpr creates an expression that matches any of the fields by name.
+func fieldExpr(refs []*influxql.VarRef) semantic.Expression {
+	exprs := make([]semantic.Expression, 0, len(refs))
+	names := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		if names[ref.Val] {
+			continue
+		}
+		names[ref.Val] = true
+		exprs = append(exprs, &semantic.BinaryExpression{
+			Operator: ast.EqualOperator,
+			Left: &semantic.MemberExpression{
+				Object:   &semantic.IdentifierExpression{Name: "r"},
+				Property: "_field",
+			},
+			Right: &semantic.StringLiteral{Value: ref.Val},
+		})
+	}
+
+	expr := exprs[len(exprs)-1]
+	for i := len(exprs) - 2; i >= 0; i-- {
+		expr = &semantic.LogicalExpression{
+			Operator: ast.OrOperator,
+			Left:     exprs[i],
+			Right:    expr,
+		}
+	}
+	return expr
+}
+
+// subqueryInput reads the columns for the variables from the results of the subquery.
+// The subquery is transpiled the first time it is used. If there is a single variable,
+// its column is renamed to the value column so it can be used the same way as a field.
+func (t *transpilerState) subqueryInput(ctx context.Context, subquery *influxql.SubQuery, refs []*influxql.VarRef, tr influxql.TimeRange) (flux.OperationID, error) {
+	// A subquery without an ordering inherits the ordering of the outer query.
+	if len(subquery.Statement.SortFields) > 0 && subquery.Statement.TimeAscending() != t.stmt.TimeAscending() {
+		return "", errors.New("subqueries must be ordered in the same direction as the query itself")
+	}
+
+	id, ok := t.subqueries[subquery]
+	if !ok {
+		// Transpile the subquery using the current state so the operations are
+		// added to the same spec. The outer statement is restored afterwards.
+		stmt := t.stmt
+		subID, err := t.transpileSelect(ctx, t.inheritInterval(restrictTimeRange(subquery.Statement, tr)))
+		t.stmt = stmt
+		if err != nil {
+			return "", err
+		}
+		id = subID
+		t.subqueries[subquery] = id
+	}
+
+	// Find the columns that the variables refer to and drop the others. Tags are
+	// kept so they can still be referenced by the query.
+	names := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		names[ref.Val] = false
+	}
+
+	var drop []string
+	stmt := subquery.Statement.Clone()
+	stmt.OmitTime = true
+	for i, name := range stmt.ColumnNames() {
+		if v, ok := stmt.Fields[i].Expr.(*influxql.VarRef); ok && (v.Val == "time" || v.Type == influxql.Tag) {
+			continue
+		} else if _, ok := names[name]; ok {
+			names[name] = true
+			continue
+		}
+		drop = append(drop, name)
+	}
+	var found, missing bool
+	for _, ok := range names {
+		found, missing = found || ok, missing || !ok
+	}
+	if found && missing {
+		// The fields that do exist would need to be read with a null value for the others.
+		for _, ref := range refs {
+			if !names[ref.Val] {
+				return "", fmt.Errorf("unimplemented: field %q does not exist in subquery and null values are not supported", ref.Val)
+			}
+		}
+	}
+
+	if missing {
+		id = t.noValues(id, refs)
+	} else {
+		if len(drop) > 0 {
+			id = t.op("drop", &transformations.DropOpSpec{
+				Cols: drop,
+			}, id)
+		}
+		if len(refs) == 1 {
+			id = t.op("rename", &transformations.RenameOpSpec{
+				Cols: map[string]string{
+					refs[0].Val: execute.DefaultValueColLabel,
+				},
+			}, id)
+		}
+	}
+
+	// Restrict the results to the time range of the outer query. This also resets
+	// the start and stop columns to the boundaries of the outer query.
+	return t.op("range", &transformations.RangeOpSpec{
+		Start:    flux.Time{Absolute: tr.MinTime()},
+		Stop:     flux.Time{Absolute: tr.MaxTime()},
+		TimeCol:  execute.DefaultTimeColLabel,
+		StartCol: execute.DefaultStartColLabel,
+		StopCol:  execute.DefaultStopColLabel,
+	}, id), nil
+}
+
+// noValues filters out every row of the subquery, which is what 1.x returns for fields
+// that do not exist in the subquery. The columns of the subquery are replaced with the
+// columns of the variables so the input can be used the same way as when they exist.
+// The columns are floats since they never have a value.
+func (t *transpilerState) noValues(id flux.OperationID, refs []*influxql.VarRef) flux.OperationID {
+	id = t.op("filter", &transformations.FilterOpSpec{
+		Fn: &semantic.FunctionExpression{
+			Params: []*semantic.FunctionParam{
+				{Key: &semantic.Identifier{Name: "r"}},
+			},
+			Body: &semantic.BooleanLiteral{Value: false},
+		},
+	}, id)
+
+	properties := []*semantic.Property{{
+		Key: &semantic.Identifier{Name: execute.DefaultTimeColLabel},
+		Value: &semantic.MemberExpression{
+			Object:   &semantic.IdentifierExpression{Name: "r"},
+			Property: execute.DefaultTimeColLabel,
+		},
+	}}
+	if len(refs) == 1 {
+		properties = append(properties, &semantic.Property{
+			Key:   &semantic.Identifier{Name: execute.DefaultValueColLabel},
+			Value: &semantic.FloatLiteral{Value: 0},
+		})
+	} else {
+		names := make(map[string]bool, len(refs))
+		for _, ref := range refs {
+			if names[ref.Val] {
+				continue
+			}
+			names[ref.Val] = true
+			properties = append(properties, &semantic.Property{
+				Key:   &semantic.Identifier{Name: ref.Val},
+				Value: &semantic.FloatLiteral{Value: 0},
+			})
+		}
+	}
+	return t.op("map", &transformations.MapOpSpec{
+		Fn: &semantic.FunctionExpression{
+			Params: []*semantic.FunctionParam{
+				{Key: &semantic.Identifier{Name: "r"}},
+			},
+			Body: &semantic.ObjectExpression{
+				Properties: properties,
+			},
+		},
+		MergeKey: true,
+	}, id)
+}
+
+// restrictTimeRange returns the subquery statement with its condition restricted to the
+// time range of the outer query, so the subquery only reads the data within that range.
+func restrictTimeRange(stmt *influxql.SelectStatement, tr influxql.TimeRange) *influxql.SelectStatement {
+	var cond influxql.Expr
+	if !tr.Min.IsZero() {
+		cond = &influxql.BinaryExpr{
+			Op:  influxql.GTE,
+			LHS: &influxql.VarRef{Val: "time"},
+			RHS: &influxql.TimeLiteral{Val: tr.Min},
+		}
+	}
+	if !tr.Max.IsZero() {
+		max := &influxql.BinaryExpr{
+			Op:  influxql.LTE,
+			LHS: &influxql.VarRef{Val: "time"},
+			RHS: &influxql.TimeLiteral{Val: tr.Max},
+		}
+		if cond == nil {
+			cond = max
+		} else {
+			cond = &influxql.BinaryExpr{Op: influxql.AND, LHS: cond, RHS: max}
+		}
+	}
+	if cond == nil {
+		return stmt
+	}
+
+	stmt = stmt.Clone()
+	if stmt.Condition != nil {
+		cond = &influxql.BinaryExpr{
+			Op:  influxql.AND,
+			LHS: &influxql.ParenExpr{Expr: stmt.Condition},
+			RHS: cond,
+		}
+	}
+	stmt.Condition = cond
+	return stmt
+}
+
+// inheritInterval returns the subquery statement with the time dimension of the outer
+// query when the subquery does not have an interval of its own. The interval is only
+// inherited when the subquery has a function that is evaluated for each interval.
+func (t *transpilerState) inheritInterval(stmt *influxql.SelectStatement) *influxql.SelectStatement {
+	if interval, err := stmt.GroupByInterval(); err != nil || interval > 0 {
+		return stmt
+	}
+
+	var dimension *influxql.Dimension
+	for _, d := range t.stmt.Dimensions {
+		if call, ok := d.Expr.(*influxql.Call); ok && call.Name == "time" {
+			dimension = d
+			break
+		}
+	}
+	if dimension == nil {
+		return stmt
+	}
+
+	hasAggregate := false
+	influxql.WalkFunc(stmt.Fields, func(n influxql.Node) {
+		if call, ok := n.(*influxql.Call); ok && !isMathFunction(call) && !isTransformation(call) {
+			hasAggregate = true
+		}
+	})
+	if !hasAggregate {
+		return stmt
+	}
+
+	stmt = stmt.Clone()
+	stmt.Dimensions = append(stmt.Dimensions, &influxql.Dimension{
+		Expr: influxql.CloneExpr(dimension.Expr),
+	})
+	return stmt
 }
 
 func (c *varRefCursor) ID() flux.OperationID {
@@ -112,7 +383,11 @@ func (c *varRefCursor) ID() flux.OperationID {
 }
 
 func (c *varRefCursor) Keys() []influxql.Expr {
-	return []influxql.Expr{c.ref}
+	keys := make([]influxql.Expr, 0, len(c.refs))
+	for _, ref := range c.refs {
+		keys = append(keys, ref)
+	}
+	return keys
 }
 
 func (c *varRefCursor) Value(expr influxql.Expr) (string, bool) {
@@ -121,9 +396,14 @@ func (c *varRefCursor) Value(expr influxql.Expr) (string, bool) {
 		return "", false
 	}
 
-	// If these are the same variable reference (by pointer), then they are equal.
-	if ref == c.ref || *ref == *c.ref {
-		return execute.DefaultValueColLabel, true
+	for _, r := range c.refs {
+		// If these are the same variable reference (by pointer), then they are equal.
+		if ref == r || *ref == *r {
+			if len(c.refs) == 1 {
+				return execute.DefaultValueColLabel, true
+			}
+			return ref.Val, true
+		}
 	}
 	return "", false
 }
@@ -0,0 +1,308 @@
+package influxql
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/influxql"
+)
+
+// FillKind is the transformation that fills in the windows of a table that do not have
+// any points. It implements the fill options of influxql other than fill(null) and
+// fill(none) and is not registered as a Flux function.
+const FillKind = "influxqlFill"
+
+func init() {
+	flux.RegisterOpSpec(FillKind, newFillOp)
+	plan.RegisterProcedureSpec(FillKind, newFillProcedure, FillKind)
+	execute.RegisterTransformation(FillKind, createFillTransformation)
+}
+
+// FillOpSpec adds a row for each window between the start and stop that does not
+// have a row. The windows are every interval starting from the offset. If the start
+// or stop is not set, the windows start or stop with the first or last row.
+type FillOpSpec struct {
+	Column string              `json:"column"`
+	Fill   influxql.FillOption `json:"fill"`
+	Value  interface{}         `json:"value"`
+	Every  flux.Duration       `json:"every"`
+	Offset flux.Duration       `json:"offset"`
+	Start  time.Time           `json:"start"`
+	Stop   time.Time           `json:"stop"`
+}
+
+func newFillOp() flux.OperationSpec {
+	return new(FillOpSpec)
+}
+
+func (s *FillOpSpec) Kind() flux.OperationKind {
+	return FillKind
+}
+
+type FillProcedureSpec struct {
+	Column string
+	Fill   influxql.FillOption
+	Value  interface{}
+	Every  flux.Duration
+	Offset flux.Duration
+	Start  time.Time
+	Stop   time.Time
+}
+
+func newFillProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*FillOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	} else if spec.Every <= 0 {
+		return nil, fmt.Errorf("fill interval must be positive, got %s", time.Duration(spec.Every))
+	}
+	return &FillProcedureSpec{
+		Column: spec.Column,
+		Fill:   spec.Fill,
+		Value:  spec.Value,
+		Every:  spec.Every,
+		Offset: spec.Offset,
+		Start:  spec.Start,
+		Stop:   spec.Stop,
+	}, nil
+}
+
+func (s *FillProcedureSpec) Kind() plan.ProcedureKind {
+	return FillKind
+}
+
+func (s *FillProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createFillTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*FillProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewFillTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type fillTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+	spec  FillProcedureSpec
+}
+
+func NewFillTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *FillProcedureSpec) execute.Transformation {
+	return &fillTransformation{
+		d:     d,
+		cache: cache,
+		spec:  *spec,
+	}
+}
+
+func (t *fillTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+// fillRow is a row that was read from a table and the window that it is in.
+type fillRow struct {
+	window execute.Time
+	values []values.Value
+}
+
+func (t *fillTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return fmt.Errorf("fill found duplicate table with key: %v", tbl.Key())
+	}
+
+	timeIdx := execute.ColIdx(execute.DefaultTimeColLabel, tbl.Cols())
+	if timeIdx < 0 {
+		return fmt.Errorf("no %s column found", execute.DefaultTimeColLabel)
+	}
+	valueIdx := execute.ColIdx(t.spec.Column, tbl.Cols())
+	if valueIdx < 0 {
+		return fmt.Errorf("no %s column found", t.spec.Column)
+	}
+
+	var rows []fillRow
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			row := fillRow{
+				window: t.window(cr.Times(timeIdx)[i]),
+				values: make([]values.Value, len(cr.Cols())),
+			}
+			for j := range cr.Cols() {
+				row.values[j] = execute.ValueForRow(i, j, cr)
+			}
+			rows = append(rows, row)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	execute.AddTableCols(tbl, builder)
+	if len(rows) == 0 {
+		return nil
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].window < rows[j].window
+	})
+
+	// The windows always include every row even if the rows are outside of
+	// the start and stop.
+	every := execute.Time(t.spec.Every)
+	start, stop := rows[0].window, rows[len(rows)-1].window+every
+	if !t.spec.Start.IsZero() {
+		if ts := t.window(execute.Time(t.spec.Start.UnixNano())); ts < start {
+			start = ts
+		}
+	}
+	if !t.spec.Stop.IsZero() {
+		if ts := execute.Time(t.spec.Stop.UnixNano()); ts > stop {
+			stop = ts
+		}
+	}
+
+	typ := tbl.Cols()[valueIdx].Type
+	next := 0
+	for window := start; window < stop; window += every {
+		if next < len(rows) && rows[next].window == window {
+			// Write every row that is in this window.
+			for ; next < len(rows) && rows[next].window == window; next++ {
+				appendValues(builder, rows[next].values)
+			}
+			continue
+		}
+
+		var prev, following *fillRow
+		if next > 0 {
+			prev = &rows[next-1]
+		}
+		if next < len(rows) {
+			following = &rows[next]
+		}
+
+		v, ok := t.fillValue(typ, valueIdx, window, prev, following)
+		if !ok {
+			continue
+		}
+
+		// The other columns are copied from the nearest row.
+		template := prev
+		if template == nil {
+			template = following
+		}
+		row := make([]values.Value, len(template.values))
+		copy(row, template.values)
+		row[timeIdx] = values.NewTimeValue(window)
+		row[valueIdx] = v
+		appendValues(builder, row)
+	}
+	return nil
+}
+
+// fillValue returns the value to fill the window with using the rows before and after
+// the window. If the window cannot be filled, this returns false.
+func (t *fillTransformation) fillValue(typ flux.DataType, valueIdx int, window execute.Time, prev, next *fillRow) (values.Value, bool) {
+	switch t.spec.Fill {
+	case influxql.NumberFill:
+		return castFillValue(typ, t.spec.Value)
+	case influxql.PreviousFill:
+		if prev == nil {
+			return nil, false
+		}
+		return prev.values[valueIdx], true
+	case influxql.LinearFill:
+		if prev == nil || next == nil {
+			return nil, false
+		}
+		return linearValue(window, prev.window, next.window, prev.values[valueIdx], next.values[valueIdx])
+	default:
+		return nil, false
+	}
+}
+
+// castFillValue converts the number used with fill to the type of the column.
+func castFillValue(typ flux.DataType, v interface{}) (values.Value, bool) {
+	var f float64
+	switch v := v.(type) {
+	case int64:
+		f = float64(v)
+	case float64:
+		f = v
+	default:
+		return nil, false
+	}
+
+	switch typ {
+	case flux.TFloat:
+		return values.NewFloatValue(f), true
+	case flux.TInt:
+		if i, ok := v.(int64); ok {
+			return values.NewIntValue(i), true
+		}
+		return values.NewIntValue(int64(f)), true
+	case flux.TUInt:
+		return values.NewUIntValue(uint64(f)), true
+	default:
+		return nil, false
+	}
+}
+
+// linearValue interpolates the value at the window using the values of the previous
+// and next windows. This is the same as the linear fill used by influxdb 1.x.
+func linearValue(window, prevWindow, nextWindow execute.Time, prev, next values.Value) (values.Value, bool) {
+	x := float64(window - prevWindow)
+	dx := float64(nextWindow - prevWindow)
+	switch prev.Type().Kind() {
+	case semantic.Float:
+		m := (next.Float() - prev.Float()) / dx
+		return values.NewFloatValue(m*x + prev.Float()), true
+	case semantic.Int:
+		m := float64(next.Int()-prev.Int()) / dx
+		return values.NewIntValue(int64(m*x + float64(prev.Int()))), true
+	case semantic.UInt:
+		m := float64(next.UInt()-prev.UInt()) / dx
+		return values.NewUIntValue(uint64(m*x + float64(prev.UInt()))), true
+	default:
+		return nil, false
+	}
+}
+
+// appendValues appends a row with the values to the builder.
+func appendValues(builder execute.TableBuilder, row []values.Value) {
+	for j, v := range row {
+		execute.AppendValue(builder, j, v)
+	}
+}
+
+// window returns the start of the window that the time is in.
+func (t *fillTransformation) window(ts execute.Time) execute.Time {
+	every, offset := execute.Time(t.spec.Every), execute.Time(t.spec.Offset)
+	rem := (ts - offset) % every
+	if rem < 0 {
+		rem += every
+	}
+	return ts - rem
+}
+
+func (t *fillTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+
+func (t *fillTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *fillTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
@@ -0,0 +1,101 @@
+package influxql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	influxqllib "github.com/influxdata/influxql"
+	"github.com/influxdata/platform/query/influxql"
+)
+
+func TestFill_Process(t *testing.T) {
+	data := func() []flux.Table {
+		return []flux.Table{&executetest.Table{
+			KeyCols: []string{"host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "host", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{execute.Time(10), "server01", 2.0},
+				{execute.Time(40), "server01", 8.0},
+			},
+		}}
+	}
+	cols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "host", Type: flux.TString},
+		{Label: "_value", Type: flux.TFloat},
+	}
+
+	testCases := []struct {
+		name  string
+		fill  influxqllib.FillOption
+		value interface{}
+		want  [][]interface{}
+	}{
+		{
+			name:  "number",
+			fill:  influxqllib.NumberFill,
+			value: int64(0),
+			want: [][]interface{}{
+				{execute.Time(0), "server01", 0.0},
+				{execute.Time(10), "server01", 2.0},
+				{execute.Time(20), "server01", 0.0},
+				{execute.Time(30), "server01", 0.0},
+				{execute.Time(40), "server01", 8.0},
+				{execute.Time(50), "server01", 0.0},
+			},
+		},
+		{
+			name: "previous",
+			fill: influxqllib.PreviousFill,
+			want: [][]interface{}{
+				{execute.Time(10), "server01", 2.0},
+				{execute.Time(20), "server01", 2.0},
+				{execute.Time(30), "server01", 2.0},
+				{execute.Time(40), "server01", 8.0},
+				{execute.Time(50), "server01", 8.0},
+			},
+		},
+		{
+			name: "linear",
+			fill: influxqllib.LinearFill,
+			want: [][]interface{}{
+				{execute.Time(10), "server01", 2.0},
+				{execute.Time(20), "server01", 4.0},
+				{execute.Time(30), "server01", 6.0},
+				{execute.Time(40), "server01", 8.0},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				data(),
+				[]*executetest.Table{{
+					KeyCols: []string{"host"},
+					ColMeta: cols,
+					Data:    tc.want,
+				}},
+				nil,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return influxql.NewFillTransformation(d, c, &influxql.FillProcedureSpec{
+						Column: "_value",
+						Fill:   tc.fill,
+						Value:  tc.value,
+						Every:  flux.Duration(10),
+						Start:  time.Unix(0, 0),
+						Stop:   time.Unix(0, 60),
+					})
+				},
+			)
+		})
+	}
+}
@@ -3,10 +3,13 @@ package influxql
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/influxql"
 )
 
@@ -34,12 +37,17 @@ func parseFunction(expr *influxql.Call) (*function, error) {
 				call: expr,
 			}, nil
 		case *influxql.Call:
-			if ref.Name == "distinct" {
-				return nil, errors.New("unimplemented: count(distinct)")
+			if ref.Name != "distinct" {
+				return nil, fmt.Errorf("expected field argument in %s()", expr.Name)
 			}
-			return nil, fmt.Errorf("expected field argument in %s()", expr.Name)
-		case *influxql.Distinct:
-			return nil, errors.New("unimplemented: count(distinct)")
+			fn, err := parseFunction(ref)
+			if err != nil {
+				return nil, err
+			}
+			return &function{
+				Ref:  fn.Ref,
+				call: expr,
+			}, nil
 		case *influxql.Wildcard:
 			return nil, errors.New("unimplemented: wildcard function")
 		case *influxql.RegexLiteral:
@@ -47,7 +55,22 @@ func parseFunction(expr *influxql.Call) (*function, error) {
 		default:
 			return nil, fmt.Errorf("expected field argument in %s()", expr.Name)
 		}
-	case "min", "max", "sum", "first", "last", "mean":
+	case "distinct":
+		if len(expr.Args) == 0 {
+			return nil, errors.New("distinct function requires at least one argument")
+		} else if len(expr.Args) != 1 {
+			return nil, errors.New("distinct function can only have one argument")
+		}
+
+		ref, ok := expr.Args[0].(*influxql.VarRef)
+		if !ok {
+			return nil, fmt.Errorf("expected field argument in %s()", expr.Name)
+		}
+		return &function{
+			Ref:  ref,
+			call: expr,
+		}, nil
+	case "min", "max", "sum", "first", "last", "mean", "median", "mode", "spread", "stddev":
 		if exp, got := 1, len(expr.Args); exp != got {
 			return nil, fmt.Errorf("invalid number of arguments for %s, expected %d, got %d", expr.Name, exp, got)
 		}
@@ -94,114 +117,280 @@ func parseFunction(expr *influxql.Call) (*function, error) {
 			Ref:  functionRef,
 			call: expr,
 		}, nil
+	case "top", "bottom":
+		if exp, got := 2, len(expr.Args); got < exp {
+			return nil, fmt.Errorf("invalid number of arguments for %s, expected at least %d, got %d", expr.Name, exp, got)
+		}
+
+		ref, ok := expr.Args[0].(*influxql.VarRef)
+		if !ok {
+			return nil, fmt.Errorf("expected first argument to be a field in %s(), found %s", expr.Name, expr.Args[0])
+		}
+
+		last := expr.Args[len(expr.Args)-1]
+		limit, ok := last.(*influxql.IntegerLiteral)
+		if !ok {
+			return nil, fmt.Errorf("expected integer as last argument in %s(), found %s", expr.Name, last)
+		} else if limit.Val <= 0 {
+			return nil, fmt.Errorf("limit (%d) in %s function must be at least 1", limit.Val, expr.Name)
+		}
+
+		tags := expr.Args[1 : len(expr.Args)-1]
+		for _, tag := range tags {
+			if _, ok := tag.(*influxql.VarRef); !ok {
+				return nil, fmt.Errorf("only fields or tags are allowed in %s(), found %s", expr.Name, tag)
+			}
+		}
+		if len(tags) > 0 {
+			return nil, fmt.Errorf("unimplemented: %s() with tag arguments", expr.Name)
+		}
+
+		return &function{
+			Ref:  ref,
+			call: expr,
+		}, nil
+	case "derivative", "non_negative_derivative", "elapsed":
+		if min, max, got := 1, 2, len(expr.Args); got > max || got < min {
+			return nil, fmt.Errorf("invalid number of arguments for %s, expected at least %d but no more than %d, got %d", expr.Name, min, max, got)
+		}
+
+		if len(expr.Args) == 2 {
+			switch arg := expr.Args[1].(type) {
+			case *influxql.DurationLiteral:
+				if arg.Val <= 0 {
+					return nil, fmt.Errorf("duration argument must be positive, got %s", arg)
+				}
+			default:
+				return nil, fmt.Errorf("second argument to %s must be a duration, got %T", expr.Name, expr.Args[1])
+			}
+		}
+		return parseTransformation(expr)
+	case "difference", "non_negative_difference", "cumulative_sum":
+		if exp, got := 1, len(expr.Args); exp != got {
+			return nil, fmt.Errorf("invalid number of arguments for %s, expected %d, got %d", expr.Name, exp, got)
+		}
+		return parseTransformation(expr)
+	case "moving_average":
+		if exp, got := 2, len(expr.Args); exp != got {
+			return nil, fmt.Errorf("invalid number of arguments for %s, expected %d, got %d", expr.Name, exp, got)
+		}
+
+		switch arg := expr.Args[1].(type) {
+		case *influxql.IntegerLiteral:
+			if arg.Val <= 1 {
+				return nil, fmt.Errorf("%s window must be greater than 1, got %d", expr.Name, arg.Val)
+			}
+		default:
+			return nil, fmt.Errorf("second argument for %s must be an integer, got %T", expr.Name, expr.Args[1])
+		}
+		return parseTransformation(expr)
+	case "holt_winters", "holt_winters_with_fit":
+		if exp, got := 3, len(expr.Args); exp != got {
+			return nil, fmt.Errorf("invalid number of arguments for %s, expected %d, got %d", expr.Name, exp, got)
+		}
+
+		if _, ok := expr.Args[0].(*influxql.Call); !ok {
+			return nil, fmt.Errorf("must use aggregate function with %s", expr.Name)
+		}
+
+		switch arg := expr.Args[1].(type) {
+		case *influxql.IntegerLiteral:
+			if arg.Val <= 0 {
+				return nil, fmt.Errorf("second arg to %s must be greater than 0, got %d", expr.Name, arg.Val)
+			}
+		default:
+			return nil, fmt.Errorf("expected integer argument as second arg in %s", expr.Name)
+		}
+
+		switch arg := expr.Args[2].(type) {
+		case *influxql.IntegerLiteral:
+			if arg.Val < 0 {
+				return nil, fmt.Errorf("third arg to %s cannot be negative, got %d", expr.Name, arg.Val)
+			}
+		default:
+			return nil, fmt.Errorf("expected integer argument as third arg in %s", expr.Name)
+		}
+		return parseTransformation(expr)
 	default:
 		return nil, fmt.Errorf("unimplemented function: %q", expr.Name)
 	}
 
 }
 
+// parseTransformation parses the first argument of a transformation function.
+// A transformation can be used with a field or with the result of an aggregate.
+func parseTransformation(expr *influxql.Call) (*function, error) {
+	switch ref := expr.Args[0].(type) {
+	case *influxql.VarRef:
+		return &function{
+			Ref:  ref,
+			call: expr,
+		}, nil
+	case *influxql.Call:
+		if isTransformation(ref) {
+			return nil, fmt.Errorf("unimplemented: nested transformation %s() in %s()", ref.Name, expr.Name)
+		}
+		fn, err := parseFunction(ref)
+		if err != nil {
+			return nil, err
+		}
+		return &function{
+			Ref:  fn.Ref,
+			call: expr,
+		}, nil
+	case *influxql.Wildcard:
+		return nil, errors.New("unimplemented: wildcard function")
+	case *influxql.RegexLiteral:
+		return nil, errors.New("unimplemented: wildcard regex function")
+	default:
+		return nil, fmt.Errorf("expected field argument in %s()", expr.Name)
+	}
+}
+
+// isTransformation returns true if the function computes its values from the points
+// that are next to each other instead of computing a value for each window.
+func isTransformation(call *influxql.Call) bool {
+	switch call.Name {
+	case "derivative", "non_negative_derivative", "difference", "non_negative_difference",
+		"moving_average", "cumulative_sum", "elapsed", "holt_winters", "holt_winters_with_fit":
+		return true
+	default:
+		return false
+	}
+}
+
 // createFunctionCursor creates a new cursor that calls a function on one of the columns
 // and returns the result.
-func createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, normalize bool) (cursor, error) {
+func (gr *groupInfo) createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, normalize bool) (cursor, error) {
+	interval, err := t.stmt.GroupByInterval()
+	if err != nil {
+		return nil, err
+	}
+
+	// If the argument is another function, that function is evaluated first and
+	// this function uses its result. A transformation uses the result of every window
+	// so the windows are merged before the transformation is applied.
+	if arg, ok := call.Args[0].(*influxql.Call); ok {
+		if isTransformation(call) {
+			if interval == 0 {
+				return nil, fmt.Errorf("%s aggregate requires a GROUP BY interval", call.Name)
+			}
+			c, err := gr.createFunctionCursor(t, arg, in, true)
+			if err != nil {
+				return nil, err
+			}
+			value, _ := c.Value(arg)
+			if in, err = gr.mergeWindows(t, c, value); err != nil {
+				return nil, err
+			}
+		} else {
+			c, err := gr.createFunctionCursor(t, arg, in, false)
+			if err != nil {
+				return nil, err
+			}
+			in = c
+		}
+	} else if isTransformation(call) {
+		if interval > 0 {
+			return nil, fmt.Errorf("aggregate function required inside the call to %s", call.Name)
+		}
+		// The points from every series in the group need to be in time order.
+		in = &opCursor{
+			id: t.op("sort", &transformations.SortOpSpec{
+				Cols: []string{execute.DefaultTimeColLabel},
+			}, in.ID()),
+			cursor: in,
+		}
+	}
+
+	value, ok := in.Value(call.Args[0])
+	if !ok {
+		return nil, fmt.Errorf("undefined variable: %s", call.Args[0])
+	}
 	cur := &functionCursor{
-		call:   call,
-		parent: in,
+		call:    call,
+		value:   value,
+		exclude: map[influxql.Expr]struct{}{call.Args[0]: {}},
+		parent:  in,
 	}
 	switch call.Name {
 	case "count":
-		value, ok := in.Value(call.Args[0])
-		if !ok {
-			return nil, fmt.Errorf("undefined variable: %s", call.Args[0])
-		}
 		cur.id = t.op("count", &transformations.CountOpSpec{
 			AggregateConfig: execute.AggregateConfig{
 				Columns: []string{value},
 			},
 		}, in.ID())
-		cur.value = value
-		cur.exclude = map[influxql.Expr]struct{}{call.Args[0]: {}}
 	case "min":
-		value, ok := in.Value(call.Args[0])
-		if !ok {
-			return nil, fmt.Errorf("undefined variable: %s", call.Args[0])
-		}
 		cur.id = t.op("min", &transformations.MinOpSpec{
 			SelectorConfig: execute.SelectorConfig{
 				Column: value,
 			},
 		}, in.ID())
-		cur.value = value
-		cur.exclude = map[influxql.Expr]struct{}{call.Args[0]: {}}
 	case "max":
-		value, ok := in.Value(call.Args[0])
-		if !ok {
-			return nil, fmt.Errorf("undefined variable: %s", call.Args[0])
-		}
 		cur.id = t.op("max", &transformations.MaxOpSpec{
 			SelectorConfig: execute.SelectorConfig{
 				Column: value,
 			},
 		}, in.ID())
-		cur.value = value
-		cur.exclude = map[influxql.Expr]struct{}{call.Args[0]: {}}
 	case "sum":
-		value, ok := in.Value(call.Args[0])
-		if !ok {
-			return nil, fmt.Errorf("undefined variable: %s", call.Args[0])
-		}
 		cur.id = t.op("sum", &transformations.SumOpSpec{
 			AggregateConfig: execute.AggregateConfig{
 				Columns: []string{value},
 			},
 		}, in.ID())
-		cur.value = value
-		cur.exclude = map[influxql.Expr]struct{}{call.Args[0]: {}}
 	case "first":
-		value, ok := in.Value(call.Args[0])
-		if !ok {
-			return nil, fmt.Errorf("undefined variable: %s", call.Args[0])
-		}
 		cur.id = t.op("first", &transformations.FirstOpSpec{
 			SelectorConfig: execute.SelectorConfig{
 				Column: value,
 			},
 		}, in.ID())
-		cur.value = value
-		cur.exclude = map[influxql.Expr]struct{}{call.Args[0]: {}}
 	case "last":
-		value, ok := in.Value(call.Args[0])
-		if !ok {
-			return nil, fmt.Errorf("undefined variable: %s", call.Args[0])
-		}
 		cur.id = t.op("last", &transformations.LastOpSpec{
 			SelectorConfig: execute.SelectorConfig{
 				Column: value,
 			},
 		}, in.ID())
-		cur.value = value
-		cur.exclude = map[influxql.Expr]struct{}{call.Args[0]: {}}
 	case "mean":
-		value, ok := in.Value(call.Args[0])
-		if !ok {
-			return nil, fmt.Errorf("undefined variable: %s", call.Args[0])
-		}
 		cur.id = t.op("mean", &transformations.MeanOpSpec{
 			AggregateConfig: execute.AggregateConfig{
 				Columns: []string{value},
 			},
 		}, in.ID())
-		cur.value = value
-		cur.exclude = map[influxql.Expr]struct{}{call.Args[0]: {}}
+	case "median":
+		cur.id = t.op("percentile", &transformations.PercentileOpSpec{
+			Percentile: 0.5,
+			Method:     "exact_mean",
+			AggregateConfig: execute.AggregateConfig{
+				Columns: []string{value},
+			},
+		}, in.ID())
+	case "mode":
+		cur.id = t.op(ModeKind, &ModeOpSpec{
+			Column: value,
+		}, in.ID())
+	case "spread":
+		cur.id = t.op("spread", &transformations.SpreadOpSpec{
+			AggregateConfig: execute.AggregateConfig{
+				Columns: []string{value},
+			},
+		}, in.ID())
+	case "stddev":
+		cur.id = t.op("stddev", &transformations.StddevOpSpec{
+			AggregateConfig: execute.AggregateConfig{
+				Columns: []string{value},
+			},
+		}, in.ID())
+	case "distinct":
+		// Distinct always writes the distinct values to the default value column.
+		cur.id = t.op("distinct", &transformations.DistinctOpSpec{
+			Column: value,
+		}, in.ID())
+		cur.value = execute.DefaultValueColLabel
 	case "percentile":
 		if len(call.Args) != 2 {
 			return nil, errors.New("percentile function requires two arguments field_key and N")
 		}
 
-		fieldName, ok := in.Value(call.Args[0])
-		if !ok {
-			return nil, fmt.Errorf("undefined variable: %s", call.Args[0])
-		}
-
 		var percentile float64
 		switch arg := call.Args[1].(type) {
 		case *influxql.NumberLiteral:
@@ -221,17 +410,79 @@ func createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, no
 			Compression: 0,
 			Method:      "exact_selector",
 			AggregateConfig: execute.AggregateConfig{
-				Columns: []string{fieldName},
+				Columns: []string{value},
 			},
 		}, in.ID())
-		cur.value = fieldName
-		cur.exclude = map[influxql.Expr]struct{}{call.Args[0]: {}}
+	case "top", "bottom":
+		// Sort the points by value to keep the top or bottom N points and then put
+		// the points that were kept back into time order.
+		n := call.Args[len(call.Args)-1].(*influxql.IntegerLiteral)
+		id := t.op("sort", &transformations.SortOpSpec{
+			Cols: []string{value},
+			Desc: call.Name == "top",
+		}, in.ID())
+		id = t.op("limit", &transformations.LimitOpSpec{
+			N: n.Val,
+		}, id)
+		cur.id = t.op("sort", &transformations.SortOpSpec{
+			Cols: []string{execute.DefaultTimeColLabel},
+		}, id)
+	case "derivative", "non_negative_derivative":
+		// The unit defaults to the interval when the points are grouped by time.
+		unit := time.Second
+		if len(call.Args) == 2 {
+			unit = call.Args[1].(*influxql.DurationLiteral).Val
+		} else if interval > 0 {
+			unit = interval
+		}
+		cur.id = t.op("derivative", &transformations.DerivativeOpSpec{
+			Unit:    flux.Duration(unit),
+			Columns: []string{value},
+			TimeSrc: execute.DefaultTimeColLabel,
+		}, in.ID())
+		if call.Name == "non_negative_derivative" {
+			cur.id = t.nonNegative(value, cur.id)
+		}
+	case "difference", "non_negative_difference":
+		cur.id = t.op("difference", &transformations.DifferenceOpSpec{
+			Columns: []string{value},
+		}, in.ID())
+		if call.Name == "non_negative_difference" {
+			cur.id = t.nonNegative(value, cur.id)
+		}
+	case "cumulative_sum":
+		cur.id = t.op("cumulativeSum", &transformations.CumulativeSumOpSpec{
+			Columns: []string{value},
+		}, in.ID())
+	case "moving_average":
+		cur.id = t.op(MovingAverageKind, &MovingAverageOpSpec{
+			Column: value,
+			N:      call.Args[1].(*influxql.IntegerLiteral).Val,
+		}, in.ID())
+	case "elapsed":
+		unit := time.Nanosecond
+		if len(call.Args) == 2 {
+			unit = call.Args[1].(*influxql.DurationLiteral).Val
+		}
+		cur.id = t.op(ElapsedKind, &ElapsedOpSpec{
+			Column: value,
+			Unit:   flux.Duration(unit),
+		}, in.ID())
+	case "holt_winters", "holt_winters_with_fit":
+		cur.id = t.op(HoltWintersKind, &HoltWintersOpSpec{
+			Column:      value,
+			N:           call.Args[1].(*influxql.IntegerLiteral).Val,
+			Seasonality: call.Args[2].(*influxql.IntegerLiteral).Val,
+			Interval:    flux.Duration(interval),
+			WithFit:     call.Name == "holt_winters_with_fit",
+		}, in.ID())
 	default:
 		return nil, fmt.Errorf("unimplemented function: %q", call.Name)
 	}
 
-	// If we have been told to normalize the time, we do it here.
-	if normalize {
+	// If we have been told to normalize the time, we do it here. A transformation
+	// keeps the time of each point so it is never normalized.
+	if normalize && !isTransformation(call) {
 		if influxql.IsSelector(call) {
 			cur.id = t.op("drop", &transformations.DropOpSpec{
 				Cols: []string{execute.DefaultTimeColLabel},
@@ -245,6 +496,27 @@ func createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, no
 	return cur, nil
 }
 
+// nonNegative filters out the negative values that were computed for the column.
+func (t *transpilerState) nonNegative(column string, id flux.OperationID) flux.OperationID {
+	return t.op("filter", &transformations.FilterOpSpec{
+		Fn: &semantic.FunctionExpression{
+			Params: []*semantic.FunctionParam{{
+				Key: &semantic.Identifier{Name: "r"},
+			}},
+			Body: &semantic.BinaryExpression{
+				Operator: ast.GreaterThanEqualOperator,
+				Left: &semantic.MemberExpression{
+					Object: &semantic.IdentifierExpression{
+						Name: "r",
+					},
+					Property: column,
+				},
+				Right: &semantic.IntegerLiteral{Value: 0},
+			},
+		},
+	}, id)
+}
+
 type functionCursor struct {
 	id      flux.OperationID
 	call    *influxql.Call
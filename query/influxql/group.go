@@ -1,6 +1,7 @@
 package influxql
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"strings"
@@ -16,8 +17,14 @@ import (
 
 type groupInfo struct {
 	call     *influxql.Call
+	ref      *influxql.VarRef
 	refs     []*influxql.VarRef
 	selector bool
+
+	// windowEvery and windowOffset are the interval and offset of the
+	// time dimension. They are set when the cursor is grouped.
+	windowEvery  time.Duration
+	windowOffset time.Duration
 }
 
 type groupVisitor struct {
@@ -69,6 +76,23 @@ func identifyGroups(stmt *influxql.SelectStatement) ([]*groupInfo, error) {
 		return nil, v.err
 	}
 
+	// Some of the functions cannot be combined with any other functions.
+	for _, fn := range v.calls {
+		switch fn.call.Name {
+		case "top", "bottom":
+			if len(v.calls) > 1 {
+				return nil, fmt.Errorf("selector function %s() cannot be combined with other functions", fn.call.Name)
+			}
+			if limit := fn.call.Args[len(fn.call.Args)-1].(*influxql.IntegerLiteral); stmt.Limit > 0 && int(limit.Val) > stmt.Limit {
+				return nil, fmt.Errorf("limit (%d) in %s function can not be larger than the LIMIT (%d) in the select statement", limit.Val, fn.call.Name, stmt.Limit)
+			}
+		case "distinct":
+			if len(v.calls) > 1 || len(v.refs) > 0 {
+				return nil, errors.New("aggregate function distinct() cannot be combined with other functions or fields")
+			}
+		}
+	}
+
 	// Attempt to take the calls and variables and put them into groups.
 	if len(v.refs) > 0 {
 		// If any of the calls are not selectors, we have an error message.
@@ -84,12 +108,16 @@ func identifyGroups(stmt *influxql.SelectStatement) ([]*groupInfo, error) {
 		}
 
 		// Otherwise, we create a single group.
-		var call *influxql.Call
+		var (
+			call *influxql.Call
+			ref  *influxql.VarRef
+		)
 		if len(v.calls) == 1 {
-			call = v.calls[0].call
+			call, ref = v.calls[0].call, v.calls[0].Ref
 		}
 		return []*groupInfo{{
 			call:     call,
+			ref:      ref,
 			refs:     v.refs,
 			selector: true, // Always a selector if we are here.
 		}}, nil
@@ -99,7 +127,7 @@ func identifyGroups(stmt *influxql.SelectStatement) ([]*groupInfo, error) {
 	// its own group.
 	groups := make([]*groupInfo, 0, len(v.calls))
 	for _, fn := range v.calls {
-		groups = append(groups, &groupInfo{call: fn.call})
+		groups = append(groups, &groupInfo{call: fn.call, ref: fn.Ref})
 	}
 
 	// If there is exactly one group and that contains a selector, then mark it as so.
@@ -109,92 +137,84 @@ func identifyGroups(stmt *influxql.SelectStatement) ([]*groupInfo, error) {
 	return groups, nil
 }
 
-func (gr *groupInfo) createCursor(t *transpilerState) (cursor, error) {
-	// Create all of the cursors for every variable reference.
-	// TODO(jsternberg): Determine which of these cursors are from fields and which are tags.
-	var cursors []cursor
-	if gr.call != nil {
-		ref, ok := gr.call.Args[0].(*influxql.VarRef)
-		if !ok {
-			// TODO(jsternberg): This should be validated and figured out somewhere else.
-			return nil, fmt.Errorf("first argument to %q must be a variable", gr.call.Name)
+func (gr *groupInfo) createCursor(ctx context.Context, t *transpilerState) (cursor, error) {
+	// Identify the fields that need to be read for every variable reference. Tags are
+	// not read from the sources since they are part of the group key of the fields.
+	var (
+		fields []*influxql.VarRef
+		tags   map[influxql.VarRef]struct{}
+	)
+	hasField := func(ref *influxql.VarRef) bool {
+		for _, f := range fields {
+			if *f == *ref {
+				return true
+			}
 		}
-		cur, err := createVarRefCursor(t, ref)
-		if err != nil {
-			return nil, err
+		return false
+	}
+	addTag := func(ref *influxql.VarRef) {
+		if tags == nil {
+			tags = make(map[influxql.VarRef]struct{})
 		}
-		cursors = append(cursors, cur)
+		tags[*ref] = struct{}{}
+	}
+
+	if gr.ref != nil {
+		fields = append(fields, gr.ref)
 	}
 
 	for _, ref := range gr.refs {
-		cur, err := createVarRefCursor(t, ref)
-		if err != nil {
-			return nil, err
+		if ref.Type == influxql.Tag {
+			addTag(ref)
+		} else if !hasField(ref) {
+			fields = append(fields, ref)
 		}
-		cursors = append(cursors, cur)
 	}
 
 	// TODO(jsternberg): Establish which variables in the condition are tags and which are fields.
-	// We need to create the references to fields here so they can be joined.
-	var (
-		tags map[influxql.VarRef]struct{}
-		cond influxql.Expr
-	)
+	// We need to add the references to fields here so they are read with the other fields.
+	var cond influxql.Expr
 	valuer := influxql.NowValuer{Now: t.spec.Now}
 	if t.stmt.Condition != nil {
 		var err error
 		if cond, _, err = influxql.ConditionExpr(t.stmt.Condition, &valuer); err != nil {
 			return nil, err
 		} else if cond != nil {
-			tags = make(map[influxql.VarRef]struct{})
-
 			// Walk through the condition for every variable reference. There will be no function
 			// calls here.
-			var condErr error
 			influxql.WalkFunc(cond, func(node influxql.Node) {
-				if condErr != nil {
-					return
-				}
 				ref, ok := node.(*influxql.VarRef)
 				if !ok {
 					return
 				}
 
-				// If the variable reference is in any of the cursors, it is definitely
+				// If the variable reference is one of the fields, it is definitely
 				// a field and we do not have to inspect it further.
-				for _, cur := range cursors {
-					if _, ok := cur.Value(ref); ok {
-						return
-					}
+				if hasField(ref) {
+					return
 				}
 
-				// This may be a field or a tag. If it is a field, we need to create the cursor
-				// and add it to the listing of cursors so it can be joined before we evaluate the condition.
+				// This may be a field or a tag. If it is a field, we need to read it
+				// with the other fields before we evaluate the condition.
 				switch typ := t.mapType(ref); typ {
 				case influxql.Tag:
 					// Add this variable name to the listing of tags.
-					tags[*ref] = struct{}{}
+					addTag(ref)
 				default:
-					cur, err := createVarRefCursor(t, ref)
-					if err != nil {
-						condErr = err
-						return
-					}
-					cursors = append(cursors, cur)
+					fields = append(fields, ref)
 				}
 			})
 		}
 	}
 
-	// Join the cursors using an inner join.
-	// TODO(jsternberg): We need to differentiate between various join types and this needs to be
-	// except: ["_field"] rather than joining on the _measurement. This also needs to specify what the time
-	// column should be.
-	if len(cursors) > 1 {
-		return nil, errors.New("unimplemented: joining fields within a cursor")
+	if len(fields) == 0 {
+		return nil, errors.New("statement must have at least one field in select clause")
 	}
 
-	cur := Join(t, cursors, []string{"_measurement"})
+	cur, err := createVarRefCursor(ctx, t, fields...)
+	if err != nil {
+		return nil, err
+	}
 	if len(tags) > 0 {
 		cur = &tagsCursor{cursor: cur, tags: tags}
 	}
@@ -231,24 +251,18 @@ func (gr *groupInfo) createCursor(t *transpilerState) (cursor, error) {
 
 	// If a function call is present, evaluate the function call.
 	if gr.call != nil {
-		c, err := createFunctionCursor(t, gr.call, cur, !gr.selector)
+		c, err := gr.createFunctionCursor(t, gr.call, cur, !gr.selector)
 		if err != nil {
 			return nil, err
 		}
 		cur = c
 
-		// If there was a window operation, we now need to undo that and sort by the start column
-		// so they stay in the same table and are joined in the correct order.
-		if interval > 0 {
-			cur = &groupCursor{
-				id: t.op("window", &transformations.WindowOpSpec{
-					Every:         flux.Duration(math.MaxInt64),
-					Period:        flux.Duration(math.MaxInt64),
-					TimeCol:       execute.DefaultTimeColLabel,
-					StartColLabel: execute.DefaultStartColLabel,
-					StopColLabel:  execute.DefaultStopColLabel,
-				}, cur.ID()),
-				cursor: cur,
+		// If there was a window operation, we now need to undo that. A transformation
+		// merges the windows before it is applied so it does not need to be done again.
+		if interval > 0 && !isTransformation(gr.call) {
+			value, _ := cur.Value(gr.call)
+			if cur, err = gr.mergeWindows(t, cur, value); err != nil {
+				return nil, err
 			}
 		}
 	} else {
@@ -258,8 +272,6 @@ func (gr *groupInfo) createCursor(t *transpilerState) (cursor, error) {
 			return nil, errors.New("GROUP BY requires at least one aggregate function")
 		}
 
-		// TODO(jsternberg): Fill needs to be somewhere and it's probably here somewhere.
-		// Move this to the correct location once we've figured it out.
 		switch t.stmt.Fill {
 		case influxql.NoFill:
 			return nil, errors.New("fill(none) must be used with a function")
@@ -270,13 +282,52 @@ func (gr *groupInfo) createCursor(t *transpilerState) (cursor, error) {
 	return cur, nil
 }
 
+// mergeWindows undoes the window operation and sorts by the start column so the windows
+// stay in the same table and are joined in the correct order. The windows without any
+// points are then filled in according to the fill option of the statement.
+func (gr *groupInfo) mergeWindows(t *transpilerState, in cursor, column string) (cursor, error) {
+	cur := &groupCursor{
+		id: t.op("window", &transformations.WindowOpSpec{
+			Every:         flux.Duration(math.MaxInt64),
+			Period:        flux.Duration(math.MaxInt64),
+			TimeCol:       execute.DefaultTimeColLabel,
+			StartColLabel: execute.DefaultStartColLabel,
+			StopColLabel:  execute.DefaultStopColLabel,
+		}, in.ID()),
+		cursor: in,
+	}
+
+	switch t.stmt.Fill {
+	case influxql.NumberFill, influxql.PreviousFill, influxql.LinearFill:
+	default:
+		// Flux does not have null values so a window without any points is
+		// omitted for both fill(null) and fill(none).
+		return cur, nil
+	}
+
+	tr, err := t.timeRange()
+	if err != nil {
+		return nil, err
+	}
+	cur.id = t.op(FillKind, &FillOpSpec{
+		Column: column,
+		Fill:   t.stmt.Fill,
+		Value:  t.stmt.FillValue,
+		Every:  flux.Duration(gr.windowEvery),
+		Offset: flux.Duration(gr.windowOffset),
+		Start:  tr.Min,
+		Stop:   tr.Max,
+	}, cur.id)
+	return cur, nil
+}
+
 type groupCursor struct {
 	cursor
 	id flux.OperationID
 }
 
 func (gr *groupInfo) group(t *transpilerState, in cursor) (cursor, error) {
-	var windowEvery time.Duration
+	var windowEvery, windowOffset time.Duration
 	var windowStart time.Time
 	tags := []string{"_measurement", "_start"}
 	if len(t.stmt.Dimensions) > 0 {
@@ -309,7 +360,6 @@ func (gr *groupInfo) group(t *transpilerState, in cursor) (cursor, error) {
 					return nil, errors.New("multiple time dimensions not allowed")
 				} else {
 					windowEvery = lit.Val
-					var windowOffset time.Duration
 					if len(expr.Args) == 2 {
 						switch lit2 := expr.Args[1].(type) {
 						case *influxql.DurationLiteral:
@@ -348,10 +398,9 @@ func (gr *groupInfo) group(t *transpilerState, in cursor) (cursor, error) {
 						windowStart = time.Unix(0, 0).Add(windowOffset)
 					}
 				}
-			case *influxql.Wildcard:
-				return nil, errors.New("unimplemented: dimension wildcards")
-			case *influxql.RegexLiteral:
-				return nil, errors.New("unimplemented: dimension regex wildcards")
+			case *influxql.Wildcard, *influxql.RegexLiteral:
+				// Wildcards are expanded into the tags of the sources before the statement is grouped.
+				return nil, errors.New("dimension wildcards must be expanded before grouping")
 			default:
 				return nil, errors.New("only time and tag dimensions allowed")
 			}
@@ -359,8 +408,8 @@ func (gr *groupInfo) group(t *transpilerState, in cursor) (cursor, error) {
 	}
 
 	// Perform the grouping by the tags we found. There is always a group by because
-	// there is always something to group in influxql.
-	// TODO(jsternberg): A wildcard will skip this step.
+	// there is always something to group in influxql. A wildcard groups by every
+	// tag since it has been expanded into the tags of the sources.
 	id := t.op("group", &transformations.GroupOpSpec{
 		By: tags,
 	}, in.ID())
@@ -380,6 +429,7 @@ func (gr *groupInfo) group(t *transpilerState, in cursor) (cursor, error) {
 
 		id = t.op("window", windowOp, id)
 	}
+	gr.windowEvery, gr.windowOffset = windowEvery, windowOffset
 
 	return &groupCursor{id: id, cursor: in}, nil
 }
@@ -29,10 +29,11 @@ func Join(t *transpilerState, cursors []cursor, on []string) cursor {
 		tables[cur.ID()] = tableName
 
 		for _, k := range cur.Keys() {
-			// Combine the table name with the name to access this attribute so we can know
-			// what it will be mapped to.
+			// Combine the name to access this attribute with the table name so we can know
+			// what it will be mapped to. This is the same way the join names the columns
+			// that exist in more than one table.
 			varName, _ := cur.Value(k)
-			name := fmt.Sprintf("%s_%s", tableName, varName)
+			name := fmt.Sprintf("%s_%s", varName, tableName)
 			exprs = append(exprs, k)
 			m[k] = name
 		}
@@ -0,0 +1,497 @@
+package influxql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/influxdb/query"
+)
+
+// The transformations in this file implement the influxql functions that do not have
+// an equivalent function in Flux. They are not registered as Flux functions and can
+// only be created from a transpiled spec.
+const (
+	ModeKind          = "influxqlMode"
+	MovingAverageKind = "influxqlMovingAverage"
+	ElapsedKind       = "influxqlElapsed"
+	HoltWintersKind   = "influxqlHoltWinters"
+)
+
+func init() {
+	flux.RegisterOpSpec(ModeKind, newModeOp)
+	plan.RegisterProcedureSpec(ModeKind, newModeProcedure, ModeKind)
+	execute.RegisterTransformation(ModeKind, createModeTransformation)
+
+	flux.RegisterOpSpec(MovingAverageKind, newMovingAverageOp)
+	plan.RegisterProcedureSpec(MovingAverageKind, newMovingAverageProcedure, MovingAverageKind)
+	execute.RegisterTransformation(MovingAverageKind, createMovingAverageTransformation)
+
+	flux.RegisterOpSpec(ElapsedKind, newElapsedOp)
+	plan.RegisterProcedureSpec(ElapsedKind, newElapsedProcedure, ElapsedKind)
+	execute.RegisterTransformation(ElapsedKind, createElapsedTransformation)
+
+	flux.RegisterOpSpec(HoltWintersKind, newHoltWintersOp)
+	plan.RegisterProcedureSpec(HoltWintersKind, newHoltWintersProcedure, HoltWintersKind)
+	execute.RegisterTransformation(HoltWintersKind, createHoltWintersTransformation)
+}
+
+// ModeOpSpec produces a single row for each table with the most frequent value
+// of the column. When there is a tie, the value that appeared first is used.
+type ModeOpSpec struct {
+	Column string `json:"column"`
+}
+
+func newModeOp() flux.OperationSpec {
+	return new(ModeOpSpec)
+}
+
+func (s *ModeOpSpec) Kind() flux.OperationKind {
+	return ModeKind
+}
+
+type ModeProcedureSpec struct {
+	Column string
+}
+
+func newModeProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ModeOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &ModeProcedureSpec{Column: spec.Column}, nil
+}
+
+func (s *ModeProcedureSpec) Kind() plan.ProcedureKind {
+	return ModeKind
+}
+
+func (s *ModeProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createModeTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ModeProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewModeTransformation(d, cache, s)
+	return t, d, nil
+}
+
+func NewModeTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *ModeProcedureSpec) execute.Transformation {
+	return &pointsTransformation{
+		d:         d,
+		cache:     cache,
+		name:      "mode",
+		column:    spec.Column,
+		aggregate: true,
+		fn:        modeValue,
+	}
+}
+
+// modeValue returns the most frequent value within the points.
+func modeValue(typ flux.DataType, points []point) (flux.DataType, []point, error) {
+	type frequency struct {
+		count int
+		first int
+	}
+	m := make(map[interface{}]*frequency, len(points))
+	keys := make([]interface{}, len(points))
+	for i, p := range points {
+		keys[i] = valueKey(p.value)
+		if f, ok := m[keys[i]]; ok {
+			f.count++
+		} else {
+			m[keys[i]] = &frequency{count: 1, first: i}
+		}
+	}
+
+	var mode *frequency
+	for _, key := range keys {
+		if f := m[key]; mode == nil || f.count > mode.count {
+			mode = f
+		}
+	}
+	if mode == nil {
+		return typ, nil, nil
+	}
+	return typ, []point{points[mode.first]}, nil
+}
+
+// valueKey returns the go value for a value so it can be used as a map key.
+func valueKey(v values.Value) interface{} {
+	switch v.Type().Kind() {
+	case semantic.Bool:
+		return v.Bool()
+	case semantic.Int:
+		return v.Int()
+	case semantic.UInt:
+		return v.UInt()
+	case semantic.Float:
+		return v.Float()
+	case semantic.String:
+		return v.Str()
+	case semantic.Time:
+		return v.Time()
+	default:
+		return nil
+	}
+}
+
+// MovingAverageOpSpec computes the mean of the column over a rolling window of N points.
+type MovingAverageOpSpec struct {
+	Column string `json:"column"`
+	N      int64  `json:"n"`
+}
+
+func newMovingAverageOp() flux.OperationSpec {
+	return new(MovingAverageOpSpec)
+}
+
+func (s *MovingAverageOpSpec) Kind() flux.OperationKind {
+	return MovingAverageKind
+}
+
+type MovingAverageProcedureSpec struct {
+	Column string
+	N      int64
+}
+
+func newMovingAverageProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*MovingAverageOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &MovingAverageProcedureSpec{
+		Column: spec.Column,
+		N:      spec.N,
+	}, nil
+}
+
+func (s *MovingAverageProcedureSpec) Kind() plan.ProcedureKind {
+	return MovingAverageKind
+}
+
+func (s *MovingAverageProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createMovingAverageTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*MovingAverageProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewMovingAverageTransformation(d, cache, s)
+	return t, d, nil
+}
+
+func NewMovingAverageTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *MovingAverageProcedureSpec) execute.Transformation {
+	n := int(spec.N)
+	return &pointsTransformation{
+		d:      d,
+		cache:  cache,
+		name:   "moving_average",
+		column: spec.Column,
+		fn: func(typ flux.DataType, points []point) (flux.DataType, []point, error) {
+			return movingAverage(n, typ, points)
+		},
+	}
+}
+
+// movingAverage returns the mean of the last n points for every point after the first n-1.
+func movingAverage(n int, typ flux.DataType, points []point) (flux.DataType, []point, error) {
+	if !isNumeric(typ) {
+		return 0, nil, fmt.Errorf("unsupported type for moving_average: %s", typ)
+	}
+
+	var (
+		sum float64
+		out []point
+	)
+	for i, p := range points {
+		sum += floatValue(p.value)
+		if i >= n {
+			sum -= floatValue(points[i-n].value)
+		}
+		if i >= n-1 {
+			out = append(out, point{time: p.time, value: values.NewFloatValue(sum / float64(n))})
+		}
+	}
+	return flux.TFloat, out, nil
+}
+
+// ElapsedOpSpec computes the time that elapsed between each point in the given unit.
+type ElapsedOpSpec struct {
+	Column string        `json:"column"`
+	Unit   flux.Duration `json:"unit"`
+}
+
+func newElapsedOp() flux.OperationSpec {
+	return new(ElapsedOpSpec)
+}
+
+func (s *ElapsedOpSpec) Kind() flux.OperationKind {
+	return ElapsedKind
+}
+
+type ElapsedProcedureSpec struct {
+	Column string
+	Unit   flux.Duration
+}
+
+func newElapsedProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ElapsedOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &ElapsedProcedureSpec{
+		Column: spec.Column,
+		Unit:   spec.Unit,
+	}, nil
+}
+
+func (s *ElapsedProcedureSpec) Kind() plan.ProcedureKind {
+	return ElapsedKind
+}
+
+func (s *ElapsedProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createElapsedTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ElapsedProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewElapsedTransformation(d, cache, s)
+	return t, d, nil
+}
+
+func NewElapsedTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *ElapsedProcedureSpec) execute.Transformation {
+	unit := execute.Time(spec.Unit)
+	return &pointsTransformation{
+		d:      d,
+		cache:  cache,
+		name:   "elapsed",
+		column: spec.Column,
+		fn: func(typ flux.DataType, points []point) (flux.DataType, []point, error) {
+			var out []point
+			for i := 1; i < len(points); i++ {
+				elapsed := (points[i].time - points[i-1].time) / unit
+				out = append(out, point{time: points[i].time, value: values.NewIntValue(int64(elapsed))})
+			}
+			return flux.TInt, out, nil
+		},
+	}
+}
+
+// HoltWintersOpSpec predicts N points using the Holt-Winters method with the given seasonality.
+// The points are predicted at the given interval after the last point. If WithFit is set, the
+// points that were fit to the existing points are included before the predicted points.
+type HoltWintersOpSpec struct {
+	Column      string        `json:"column"`
+	N           int64         `json:"n"`
+	Seasonality int64         `json:"seasonality"`
+	Interval    flux.Duration `json:"interval"`
+	WithFit     bool          `json:"withFit"`
+}
+
+func newHoltWintersOp() flux.OperationSpec {
+	return new(HoltWintersOpSpec)
+}
+
+func (s *HoltWintersOpSpec) Kind() flux.OperationKind {
+	return HoltWintersKind
+}
+
+type HoltWintersProcedureSpec struct {
+	Column      string
+	N           int64
+	Seasonality int64
+	Interval    flux.Duration
+	WithFit     bool
+}
+
+func newHoltWintersProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*HoltWintersOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &HoltWintersProcedureSpec{
+		Column:      spec.Column,
+		N:           spec.N,
+		Seasonality: spec.Seasonality,
+		Interval:    spec.Interval,
+		WithFit:     spec.WithFit,
+	}, nil
+}
+
+func (s *HoltWintersProcedureSpec) Kind() plan.ProcedureKind {
+	return HoltWintersKind
+}
+
+func (s *HoltWintersProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createHoltWintersTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*HoltWintersProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewHoltWintersTransformation(d, cache, s)
+	return t, d, nil
+}
+
+func NewHoltWintersTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *HoltWintersProcedureSpec) execute.Transformation {
+	s := *spec
+	return &pointsTransformation{
+		d:      d,
+		cache:  cache,
+		name:   "holt_winters",
+		column: spec.Column,
+		fn: func(typ flux.DataType, points []point) (flux.DataType, []point, error) {
+			if !isNumeric(typ) {
+				return 0, nil, fmt.Errorf("unsupported type for holt_winters: %s", typ)
+			}
+
+			// Use the reducer from 1.x so the predictions are the same.
+			r := query.NewFloatHoltWintersReducer(int(s.N), int(s.Seasonality), s.WithFit, time.Duration(s.Interval))
+			for _, p := range points {
+				r.AggregateFloat(&query.FloatPoint{
+					Time:  int64(p.time),
+					Value: floatValue(p.value),
+				})
+			}
+
+			var out []point
+			for _, p := range r.Emit() {
+				out = append(out, point{time: execute.Time(p.Time), value: values.NewFloatValue(p.Value)})
+			}
+			return flux.TFloat, out, nil
+		},
+	}
+}
+
+// isNumeric returns true if the type is a number.
+func isNumeric(typ flux.DataType) bool {
+	switch typ {
+	case flux.TInt, flux.TUInt, flux.TFloat:
+		return true
+	default:
+		return false
+	}
+}
+
+// floatValue converts a numeric value to a float.
+func floatValue(v values.Value) float64 {
+	switch v.Type().Kind() {
+	case semantic.Int:
+		return float64(v.Int())
+	case semantic.UInt:
+		return float64(v.UInt())
+	default:
+		return v.Float()
+	}
+}
+
+// point is a value from a table and the time it was recorded at.
+type point struct {
+	time  execute.Time
+	value values.Value
+}
+
+// pointsTransformation reads the points from a column in each table and writes the
+// points returned by fn to a table with the same group key. If the function is an
+// aggregate, the time of the points is not written.
+type pointsTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	name      string
+	column    string
+	aggregate bool
+	fn        func(typ flux.DataType, points []point) (flux.DataType, []point, error)
+}
+
+func (t *pointsTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *pointsTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return fmt.Errorf("%s found duplicate table with key: %v", t.name, tbl.Key())
+	}
+
+	timeIdx := execute.ColIdx(execute.DefaultTimeColLabel, tbl.Cols())
+	if timeIdx < 0 {
+		return fmt.Errorf("no %s column found", execute.DefaultTimeColLabel)
+	}
+	valueIdx := execute.ColIdx(t.column, tbl.Cols())
+	if valueIdx < 0 {
+		return fmt.Errorf("no %s column found", t.column)
+	}
+
+	var points []point
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		times := cr.Times(timeIdx)
+		for i := 0; i < cr.Len(); i++ {
+			points = append(points, point{
+				time:  times[i],
+				value: execute.ValueForRow(i, valueIdx, cr),
+			})
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	typ, out, err := t.fn(tbl.Cols()[valueIdx].Type, points)
+	if err != nil {
+		return err
+	}
+
+	execute.AddTableKeyCols(tbl.Key(), builder)
+	if !t.aggregate {
+		timeIdx = builder.AddCol(flux.ColMeta{Label: execute.DefaultTimeColLabel, Type: flux.TTime})
+	}
+	valueIdx = builder.AddCol(flux.ColMeta{Label: t.column, Type: typ})
+	for _, p := range out {
+		execute.AppendKeyValues(tbl.Key(), builder)
+		if !t.aggregate {
+			builder.AppendTime(timeIdx, p.time)
+		}
+		execute.AppendValue(builder, valueIdx, p.value)
+	}
+	return nil
+}
+
+func (t *pointsTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+
+func (t *pointsTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *pointsTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
@@ -0,0 +1,157 @@
+package influxql_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/platform/query/influxql"
+)
+
+func TestMode_Process(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []flux.Table
+		want []*executetest.Table
+	}{
+		{
+			name: "most frequent",
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "server01", 2.0},
+					{execute.Time(2), "server01", 3.0},
+					{execute.Time(3), "server01", 3.0},
+					{execute.Time(4), "server01", 1.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{"server01", 3.0},
+				},
+			}},
+		},
+		{
+			name: "tie uses first value",
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "server01", "b"},
+					{execute.Time(2), "server01", "a"},
+					{execute.Time(3), "server01", "a"},
+					{execute.Time(4), "server01", "b"},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{"server01", "b"},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				nil,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return influxql.NewModeTransformation(d, c, &influxql.ModeProcedureSpec{
+						Column: "_value",
+					})
+				},
+			)
+		})
+	}
+}
+
+func TestMovingAverage_Process(t *testing.T) {
+	executetest.ProcessTestHelper(
+		t,
+		[]flux.Table{&executetest.Table{
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TInt},
+			},
+			Data: [][]interface{}{
+				{execute.Time(1), int64(2)},
+				{execute.Time(2), int64(4)},
+				{execute.Time(3), int64(9)},
+			},
+		}},
+		[]*executetest.Table{{
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{execute.Time(2), 3.0},
+				{execute.Time(3), 6.5},
+			},
+		}},
+		nil,
+		func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+			return influxql.NewMovingAverageTransformation(d, c, &influxql.MovingAverageProcedureSpec{
+				Column: "_value",
+				N:      2,
+			})
+		},
+	)
+}
+
+func TestElapsed_Process(t *testing.T) {
+	executetest.ProcessTestHelper(
+		t,
+		[]flux.Table{&executetest.Table{
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{execute.Time(0), 1.0},
+				{execute.Time(10), 2.0},
+				{execute.Time(40), 3.0},
+			},
+		}},
+		[]*executetest.Table{{
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TInt},
+			},
+			Data: [][]interface{}{
+				{execute.Time(10), int64(1)},
+				{execute.Time(40), int64(3)},
+			},
+		}},
+		nil,
+		func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+			return influxql.NewElapsedTransformation(d, c, &influxql.ElapsedProcedureSpec{
+				Column: "_value",
+				Unit:   flux.Duration(10),
+			})
+		},
+	)
+}
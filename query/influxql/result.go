@@ -24,9 +24,8 @@ type MultiResultEncoder struct{}
 //  3.  All columns in the group key must be strings and they will be used as tags. There is no current way
 //      to have a tag and field be the same name in the results.
 //      TODO(jsternberg): For full compatibility, the above must be possible.
-//  4.  All other columns are fields and will be output in the order they are found.
-//      TODO(jsternberg): This function currently requires the first column to be a time field, but this isn't
-//      a strict requirement and will be lifted when we begin to work on transpiling meta queries.
+//  4.  All other columns are fields and will be output in the order they are found. If there is a time
+//      column, it is always output first.
 func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
 	resp := Response{}
 	wc := &iocounter.Writer{Writer: w}
@@ -69,11 +68,15 @@ func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (i
 			}
 
 			// TODO: resultColMap should be constructed from query metadata once it is provided.
-			// for now we know that an influxql query ALWAYS has time first, so we put this placeholder
-			// here to catch this most obvious requirement.  Column orderings should be explicitly determined
-			// from the ordering given in the original flux.
+			// for now we know that an influxql query ALWAYS has time first when it has a time column,
+			// so we put this placeholder here to catch this most obvious requirement. Meta queries do
+			// not have a time column. Column orderings should be explicitly determined from the ordering
+			// given in the original flux.
 			resultColMap := map[string]int{}
-			j := 1
+			j := 0
+			if execute.ColIdx(execute.DefaultTimeColLabel, tbl.Cols()) >= 0 {
+				j = 1
+			}
 			for _, c := range tbl.Cols() {
 				if c.Label == execute.DefaultTimeColLabel {
 					resultColMap[c.Label] = 0
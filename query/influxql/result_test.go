@@ -40,6 +40,45 @@ func TestMultiResultEncoder_Encode(t *testing.T) {
 			),
 			out: `{"results":[{"statement_id":0,"series":[{"name":"m0","tags":{"host":"server01"},"columns":["time","value"],"values":[["2018-05-24T09:00:00Z",2]]}]}]}`,
 		},
+		{
+			name: "NoTimeColumn",
+			in: flux.NewSliceResultIterator(
+				[]flux.Result{&executetest.Result{
+					Nm: "0",
+					Tbls: []*executetest.Table{{
+						KeyCols: []string{"_measurement"},
+						ColMeta: []flux.ColMeta{
+							{Label: "_measurement", Type: flux.TString},
+							{Label: "fieldKey", Type: flux.TString},
+							{Label: "fieldType", Type: flux.TString},
+						},
+						Data: [][]interface{}{
+							{"cpu", "usage_system", "float"},
+							{"cpu", "usage_user", "float"},
+						},
+					}},
+				}},
+			),
+			out: `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["fieldKey","fieldType"],"values":[["usage_system","float"],["usage_user","float"]]}]}]}`,
+		},
+		{
+			name: "NoName",
+			in: flux.NewSliceResultIterator(
+				[]flux.Result{&executetest.Result{
+					Nm: "0",
+					Tbls: []*executetest.Table{{
+						ColMeta: []flux.ColMeta{
+							{Label: "key", Type: flux.TString},
+						},
+						Data: [][]interface{}{
+							{"cpu,host=server01"},
+							{"cpu,host=server02"},
+						},
+					}},
+				}},
+			),
+			out: `{"results":[{"statement_id":0,"series":[{"columns":["key"],"values":[["cpu,host=server01"],["cpu,host=server02"]]}]}]}`,
+		},
 		{
 			name: "Error",
 			in:   &resultErrorIterator{Error: "expected"},
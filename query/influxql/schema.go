@@ -0,0 +1,276 @@
+package influxql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/influxql"
+	"github.com/influxdata/platform/query"
+)
+
+// SchemaQuerier executes the queries that the transpiler uses to discover the
+// fields and tags of a measurement when expanding wildcards. The queries are
+// requested for the organization and with the authorization of the query being
+// transpiled so they only read the buckets that query can read. Any
+// query.QueryService is a SchemaQuerier.
+type SchemaQuerier interface {
+	Query(ctx context.Context, req *query.Request) (flux.ResultIterator, error)
+}
+
+const (
+	schemaFieldsResultName = "fields"
+	schemaTagsResultName   = "tags"
+)
+
+// measurementSchema holds the fields and their types and the tags for a measurement.
+type measurementSchema struct {
+	fields     map[string]influxql.DataType
+	dimensions map[string]struct{}
+}
+
+// schemaMapper implements the influxql.FieldMapper so a select statement can be
+// rewritten with the schema that was discovered by the transpiler.
+type schemaMapper struct {
+	ctx context.Context
+	t   *transpilerState
+}
+
+func (m *schemaMapper) FieldDimensions(mm *influxql.Measurement) (map[string]influxql.DataType, map[string]struct{}, error) {
+	schema, err := m.t.measurementSchema(m.ctx, mm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Copy the schema since the caller is allowed to modify the maps.
+	fields := make(map[string]influxql.DataType, len(schema.fields))
+	for k, typ := range schema.fields {
+		fields[k] = typ
+	}
+	dimensions := make(map[string]struct{}, len(schema.dimensions))
+	for k := range schema.dimensions {
+		dimensions[k] = struct{}{}
+	}
+	return fields, dimensions, nil
+}
+
+func (m *schemaMapper) MapType(mm *influxql.Measurement, field string) influxql.DataType {
+	schema, err := m.t.measurementSchema(m.ctx, mm)
+	if err != nil {
+		return influxql.Unknown
+	}
+
+	if typ, ok := schema.fields[field]; ok {
+		return typ
+	} else if _, ok := schema.dimensions[field]; ok {
+		return influxql.Tag
+	}
+	return influxql.Unknown
+}
+
+// rewriteWildcards expands the wildcards within the fields and dimensions of the
+// current statement and its subqueries using the schema of the sources.
+func (t *transpilerState) rewriteWildcards(ctx context.Context) error {
+	if !hasWildcard(t.stmt) {
+		return nil
+	} else if err := validateWildcardCalls(t.stmt); err != nil {
+		return err
+	} else if t.config.SchemaQuerier == nil {
+		return errors.New("a schema querier is required to expand wildcards")
+	}
+
+	stmt, err := t.stmt.RewriteFields(&schemaMapper{ctx: ctx, t: t})
+	if err != nil {
+		return err
+	}
+	t.stmt = stmt
+	return nil
+}
+
+// hasWildcard returns true if the statement or any of its subqueries has a wildcard.
+func hasWildcard(stmt *influxql.SelectStatement) bool {
+	if stmt.HasFieldWildcard() || stmt.HasDimensionWildcard() {
+		return true
+	}
+	for _, source := range stmt.Sources {
+		if subquery, ok := source.(*influxql.SubQuery); ok && hasWildcard(subquery.Statement) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateWildcardCalls ensures that a function with a wildcard is not used with
+// any other fields. A function with a wildcard expands to a call for each field
+// so it cannot be used with any other fields.
+func validateWildcardCalls(stmt *influxql.SelectStatement) error {
+	var hasCall, hasAux bool
+	for _, f := range stmt.Fields {
+		switch expr := f.Expr.(type) {
+		case *influxql.Call:
+			if len(expr.Args) > 0 {
+				switch expr.Args[0].(type) {
+				case *influxql.Wildcard, *influxql.RegexLiteral:
+					hasCall = true
+				}
+			}
+		case *influxql.VarRef:
+			if expr.Val != "time" {
+				hasAux = true
+			}
+		case *influxql.Wildcard, *influxql.RegexLiteral:
+			hasAux = true
+		}
+	}
+	if hasCall && hasAux {
+		return errors.New("mixing aggregate and non-aggregate queries is not supported")
+	}
+
+	for _, source := range stmt.Sources {
+		if subquery, ok := source.(*influxql.SubQuery); ok {
+			if err := validateWildcardCalls(subquery.Statement); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// schemaKey identifies the schema of a measurement within a time range.
+type schemaKey struct {
+	measurement string
+	tr          influxql.TimeRange
+}
+
+// measurementSchema returns the schema for the measurement within the time range of the
+// current statement. The schema is queried from storage the first time it is needed
+// and is reused for the rest of the query.
+func (t *transpilerState) measurementSchema(ctx context.Context, mm *influxql.Measurement) (*measurementSchema, error) {
+	tr, err := t.timeRange()
+	if err != nil {
+		return nil, err
+	}
+
+	key := schemaKey{measurement: mm.String(), tr: tr}
+	if schema, ok := t.schemas[key]; ok {
+		return schema, nil
+	}
+
+	spec, err := t.schemaSpec(mm, tr)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := t.config.SchemaQuerier.Query(ctx, &query.Request{
+		Authorization:  t.config.Authorization,
+		OrganizationID: t.config.OrganizationID,
+		Compiler:       lang.SpecCompiler{Spec: spec},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Cancel()
+
+	schema := &measurementSchema{
+		fields:     make(map[string]influxql.DataType),
+		dimensions: make(map[string]struct{}),
+	}
+	for results.More() {
+		res := results.Next()
+		switch name := res.Name(); name {
+		case schemaFieldsResultName:
+			err = readStrings(res, []string{"_field", fieldTypeColLabel}, func(values []string) {
+				typ := influxql.DataTypeFromString(values[1])
+				if schema.fields[values[0]].LessThan(typ) {
+					schema.fields[values[0]] = typ
+				}
+			})
+		case schemaTagsResultName:
+			err = readStrings(res, []string{execute.DefaultValueColLabel}, func(values []string) {
+				schema.dimensions[values[0]] = struct{}{}
+			})
+		default:
+			err = fmt.Errorf("unexpected schema result: %s", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := results.Err(); err != nil {
+		return nil, err
+	}
+
+	t.schemas[key] = schema
+	return schema, nil
+}
+
+// schemaSpec creates the spec that reads the fields and tags of the measurement within
+// the time range. The field types are yielded as the fields result and the tag keys
+// are yielded as the tags result.
+func (t *transpilerState) schemaSpec(mm *influxql.Measurement, tr influxql.TimeRange) (*flux.Spec, error) {
+	// Use a separate state so the operations are not added to the spec being transpiled.
+	state := newTranspilerState(t.dbrpMappingSvc, &t.config)
+	state.spec.Now = t.spec.Now
+
+	op, err := state.from(mm)
+	if err != nil {
+		return nil, err
+	}
+	op = state.op("range", &transformations.RangeOpSpec{
+		Start:    flux.Time{Absolute: tr.MinTime()},
+		Stop:     flux.Time{Absolute: tr.MaxTime()},
+		TimeCol:  execute.DefaultTimeColLabel,
+		StartCol: execute.DefaultStartColLabel,
+		StopCol:  execute.DefaultStopColLabel,
+	}, op)
+	op = state.op("filter", &transformations.FilterOpSpec{
+		Fn: &semantic.FunctionExpression{
+			Params: []*semantic.FunctionParam{
+				{Key: &semantic.Identifier{Name: "r"}},
+			},
+			Body: measurementExpr([]*influxql.Measurement{mm}),
+		},
+	}, op)
+
+	state.op("yield", &transformations.YieldOpSpec{
+		Name: schemaFieldsResultName,
+	}, state.op(FieldTypeKind, &FieldTypeOpSpec{}, op))
+	state.op("yield", &transformations.YieldOpSpec{
+		Name: schemaTagsResultName,
+	}, state.op("keys", &transformations.KeysOpSpec{
+		Except: []string{"_field", "_measurement", "_start", "_stop", "_time", "_value"},
+	}, op))
+	return state.spec, nil
+}
+
+// readStrings calls fn with the values of the string columns for every row in the result.
+func readStrings(res flux.Result, labels []string, fn func(values []string)) error {
+	return res.Tables().Do(func(tbl flux.Table) error {
+		indices := make([]int, len(labels))
+		for i, label := range labels {
+			idx := execute.ColIdx(label, tbl.Cols())
+			if idx < 0 {
+				return fmt.Errorf("no %s column found", label)
+			} else if typ := tbl.Cols()[idx].Type; typ != flux.TString {
+				return fmt.Errorf("column %s is not a string: %s", label, typ)
+			}
+			indices[i] = idx
+		}
+
+		values := make([]string, len(labels))
+		return tbl.Do(func(cr flux.ColReader) error {
+			for row := 0; row < cr.Len(); row++ {
+				for i, idx := range indices {
+					values[i] = cr.Strings(idx)[row]
+				}
+				fn(values)
+			}
+			return nil
+		})
+	})
+}
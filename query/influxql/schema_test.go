@@ -0,0 +1,93 @@
+package influxql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/influxql"
+)
+
+// recordingQuerier records the requests and specs it is asked to run and returns an error.
+type recordingQuerier struct {
+	reqs  []*query.Request
+	specs []*flux.Spec
+	err   error
+}
+
+func (q *recordingQuerier) Query(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+	spec, err := req.Compiler.Compile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q.reqs = append(q.reqs, req)
+	q.specs = append(q.specs, spec)
+	if q.err != nil {
+		return nil, q.err
+	}
+	return flux.NewSliceResultIterator(nil), nil
+}
+
+func TestTranspiler_SchemaQuery(t *testing.T) {
+	querier := &recordingQuerier{}
+	auth := &platform.Authorization{ID: platform.ID(0x020f755c3c082002)}
+	transpiler := influxql.NewTranspilerWithConfig(
+		dbrpMappingSvc,
+		influxql.Config{
+			DefaultDatabase: "db0",
+			SchemaQuerier:   querier,
+			OrganizationID:  platform.ID(0x020f755c3c082000),
+			Authorization:   auth,
+		},
+	)
+
+	// The measurement has no fields so the wildcard expands to nothing. The schema
+	// is only queried once even though the measurement is used twice.
+	if _, err := transpiler.Transpile(context.Background(), `SELECT * FROM cpu GROUP BY *`); err == nil {
+		t.Fatal("expected error")
+	} else if got, want := err.Error(), "at least 1 non-time field must be queried"; got != want {
+		t.Fatalf("unexpected error: got=%q want=%q", got, want)
+	}
+
+	if got, want := len(querier.specs), 1; got != want {
+		t.Fatalf("unexpected number of schema queries: got=%d want=%d", got, want)
+	}
+	// The schema is read by the organization running the query with its authorization.
+	if req := querier.reqs[0]; req.OrganizationID != platform.ID(0x020f755c3c082000) || req.Authorization != auth {
+		t.Fatalf("unexpected schema query scope: organization=%s authorization=%v", req.OrganizationID, req.Authorization)
+	}
+	spec := querier.specs[0]
+	if err := spec.Validate(); err != nil {
+		t.Fatalf("schema spec is not valid: %s", err)
+	}
+
+	var yields []string
+	for _, op := range spec.Operations {
+		if spec, ok := op.Spec.(*transformations.YieldOpSpec); ok {
+			yields = append(yields, spec.Name)
+		}
+	}
+	if want := []string{"fields", "tags"}; !cmp.Equal(yields, want) {
+		t.Fatalf("unexpected results -want/+got:\n%s", cmp.Diff(want, yields))
+	}
+}
+
+func TestTranspiler_SchemaQueryError(t *testing.T) {
+	transpiler := influxql.NewTranspilerWithConfig(
+		dbrpMappingSvc,
+		influxql.Config{
+			DefaultDatabase: "db0",
+			SchemaQuerier:   &recordingQuerier{err: errors.New("expected")},
+		},
+	)
+	if _, err := transpiler.Transpile(context.Background(), `SELECT * FROM cpu`); err == nil {
+		t.Fatal("expected error")
+	} else if got, want := err.Error(), "expected"; got != want {
+		t.Fatalf("unexpected error: got=%q want=%q", got, want)
+	}
+}
@@ -0,0 +1,437 @@
+package influxql
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/influxql"
+	"github.com/influxdata/platform"
+	"github.com/pkg/errors"
+)
+
+func (t *transpilerState) transpileShowMeasurements(ctx context.Context, stmt *influxql.ShowMeasurementsStatement) (flux.OperationID, error) {
+	op, err := t.showFrom(stmt.Database, stmt.Condition)
+	if err != nil {
+		return "", err
+	}
+
+	if stmt.Source != nil {
+		op, err = t.filterMeasurements(op, influxql.Sources{stmt.Source})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// Reduce each table to the measurement name and find the distinct names. The measurement
+	// is then set to "measurements" so the encoder names the series the same way as 1.x.
+	op = t.op("distinct", &transformations.DistinctOpSpec{
+		Column: "_measurement",
+	}, t.op("keep", &transformations.KeepOpSpec{
+		Cols: []string{"_measurement"},
+	}, op))
+	op = t.op("set", &transformations.SetOpSpec{
+		Key:   "_measurement",
+		Value: "measurements",
+	}, op)
+	op = t.op("sort", &transformations.SortOpSpec{
+		Cols: []string{execute.DefaultValueColLabel},
+	}, op)
+	op = t.limit(op, stmt.Limit, stmt.Offset)
+	return t.op("rename", &transformations.RenameOpSpec{
+		Cols: map[string]string{
+			execute.DefaultValueColLabel: "name",
+		},
+	}, op), nil
+}
+
+func (t *transpilerState) transpileShowTagKeys(ctx context.Context, stmt *influxql.ShowTagKeysStatement) (flux.OperationID, error) {
+	op, err := t.showFrom(stmt.Database, stmt.Condition)
+	if err != nil {
+		return "", err
+	}
+
+	op, err = t.filterMeasurements(op, stmt.Sources)
+	if err != nil {
+		return "", err
+	}
+
+	// List the columns in each table that are not part of the data model and find the
+	// distinct ones for each measurement.
+	op = t.op("keys", &transformations.KeysOpSpec{
+		Except: []string{"_field", "_measurement", "_start", "_stop", "_time", "_value"},
+	}, op)
+	op = t.op("distinct", &transformations.DistinctOpSpec{
+		Column: execute.DefaultValueColLabel,
+	}, t.op("keep", &transformations.KeepOpSpec{
+		Cols: []string{"_measurement", execute.DefaultValueColLabel},
+	}, op))
+	op = t.op("sort", &transformations.SortOpSpec{
+		Cols: []string{execute.DefaultValueColLabel},
+	}, op)
+	op = t.limit(op, stmt.Limit, stmt.Offset)
+	return t.op("rename", &transformations.RenameOpSpec{
+		Cols: map[string]string{
+			execute.DefaultValueColLabel: "tagKey",
+		},
+	}, op), nil
+}
+
+func (t *transpilerState) transpileShowFieldKeys(ctx context.Context, stmt *influxql.ShowFieldKeysStatement) (flux.OperationID, error) {
+	op, err := t.showFrom(stmt.Database, nil)
+	if err != nil {
+		return "", err
+	}
+
+	op, err = t.filterMeasurements(op, stmt.Sources)
+	if err != nil {
+		return "", err
+	}
+
+	// Determine the type of each field from its table and then find the unique
+	// fields for each measurement.
+	op = t.op(FieldTypeKind, &FieldTypeOpSpec{}, op)
+	op = t.op("keep", &transformations.KeepOpSpec{
+		Cols: []string{"_field", "_measurement", fieldTypeColLabel},
+	}, op)
+	op = t.op("group", &transformations.GroupOpSpec{
+		By: []string{"_measurement"},
+	}, op)
+	op = t.op("unique", &transformations.UniqueOpSpec{
+		Column: "_field",
+	}, op)
+	op = t.op("sort", &transformations.SortOpSpec{
+		Cols: []string{"_field"},
+	}, op)
+	op = t.limit(op, stmt.Limit, stmt.Offset)
+	return t.op("rename", &transformations.RenameOpSpec{
+		Cols: map[string]string{
+			"_field": "fieldKey",
+		},
+	}, op), nil
+}
+
+func (t *transpilerState) transpileShowSeries(ctx context.Context, stmt *influxql.ShowSeriesStatement) (flux.OperationID, error) {
+	op, err := t.showFrom(stmt.Database, stmt.Condition)
+	if err != nil {
+		return "", err
+	}
+
+	op, err = t.filterMeasurements(op, stmt.Sources)
+	if err != nil {
+		return "", err
+	}
+
+	// Construct the series key for each table. The tables for each field of a series
+	// share the same series key so the keys are merged and made distinct.
+	op = t.op(SeriesKeyKind, &SeriesKeyOpSpec{}, op)
+	op = t.op("distinct", &transformations.DistinctOpSpec{
+		Column: seriesKeyColLabel,
+	}, t.op("keep", &transformations.KeepOpSpec{
+		Cols: []string{seriesKeyColLabel},
+	}, op))
+	op = t.op("sort", &transformations.SortOpSpec{
+		Cols: []string{execute.DefaultValueColLabel},
+	}, op)
+	op = t.limit(op, stmt.Limit, stmt.Offset)
+	return t.op("rename", &transformations.RenameOpSpec{
+		Cols: map[string]string{
+			execute.DefaultValueColLabel: seriesKeyColLabel,
+		},
+	}, op), nil
+}
+
+func (t *transpilerState) transpileShowDatabases(ctx context.Context, stmt *influxql.ShowDatabasesStatement) (flux.OperationID, error) {
+	// Databases only exist as DBRP mappings so the list is read from the
+	// mapping service when the query is transpiled. Only the databases of the
+	// organization running the query are listed.
+	mappings, err := t.findDBRPMappings(ctx, platform.DBRPMappingFilter{})
+	if err != nil {
+		return "", err
+	}
+
+	names := make(map[string]bool, len(mappings))
+	rows := make([][]string, 0, len(mappings))
+	for _, m := range mappings {
+		if names[m.Database] {
+			continue
+		}
+		names[m.Database] = true
+		rows = append(rows, []string{m.Database})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i][0] < rows[j][0]
+	})
+
+	return t.fromTable(map[string]string{
+		"_measurement": "databases",
+	}, []flux.ColMeta{
+		{Label: "_measurement", Type: flux.TString},
+		{Label: "name", Type: flux.TString},
+	}, rows)
+}
+
+func (t *transpilerState) transpileShowRetentionPolicies(ctx context.Context, stmt *influxql.ShowRetentionPoliciesStatement) (flux.OperationID, error) {
+	db := stmt.Database
+	if db == "" {
+		if t.config.DefaultDatabase == "" {
+			return "", errDatabaseNameRequired
+		}
+		db = t.config.DefaultDatabase
+	}
+
+	mappings, err := t.findDBRPMappings(ctx, platform.DBRPMappingFilter{Database: &db})
+	if err != nil {
+		return "", err
+	}
+
+	// The retention period belongs to the bucket mapped to the retention policy,
+	// and the shard group duration is the default for the period in 1.x.
+	if len(mappings) > 0 && t.config.BucketService == nil {
+		return "", errors.New("unable to find the retention period of retention policies without a bucket service")
+	}
+	rows := make([][]string, 0, len(mappings))
+	for _, m := range mappings {
+		b, err := t.config.BucketService.FindBucketByID(ctx, m.BucketID)
+		if err != nil {
+			return "", err
+		}
+		rows = append(rows, []string{
+			m.RetentionPolicy,
+			b.RetentionPeriod.String(),
+			shardGroupDuration(b.RetentionPeriod).String(),
+			"1",
+			strconv.FormatBool(m.Default),
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i][0] < rows[j][0]
+	})
+
+	return t.fromTable(nil, []flux.ColMeta{
+		{Label: "name", Type: flux.TString},
+		{Label: "duration", Type: flux.TString},
+		{Label: "shardGroupDuration", Type: flux.TString},
+		{Label: "replicaN", Type: flux.TInt},
+		{Label: "default", Type: flux.TBool},
+	}, rows)
+}
+
+// shardGroupDuration returns the default shard group duration of a retention policy with
+// the duration d in 1.x.
+func shardGroupDuration(d time.Duration) time.Duration {
+	switch {
+	case d >= 180*24*time.Hour || d == 0:
+		return 7 * 24 * time.Hour
+	case d >= 2*24*time.Hour:
+		return 24 * time.Hour
+	default:
+		return time.Hour
+	}
+}
+
+// showFrom reads from the bucket for the database over the time range of the condition,
+// or the last hour by default, and filters the series by the rest of the condition.
+func (t *transpilerState) showFrom(database string, cond influxql.Expr) (flux.OperationID, error) {
+	if database == "" && t.config.Bucket == "" {
+		if t.config.DefaultDatabase == "" {
+			return "", errDatabaseNameRequired
+		}
+		database = t.config.DefaultDatabase
+	}
+
+	op, err := t.from(&influxql.Measurement{Database: database})
+	if err != nil {
+		return "", err
+	}
+
+	valuer := influxql.NowValuer{Now: t.spec.Now}
+	cond, tr, err := influxql.ConditionExpr(cond, &valuer)
+	if err != nil {
+		return "", err
+	}
+
+	start := flux.Time{
+		Relative:   -time.Hour,
+		IsRelative: true,
+	}
+	if !tr.Min.IsZero() {
+		start = flux.Time{Absolute: tr.Min}
+	}
+	stop := flux.Now
+	if !tr.Max.IsZero() {
+		stop = flux.Time{Absolute: tr.Max}
+	}
+	op = t.op("range", &transformations.RangeOpSpec{
+		Start:    start,
+		Stop:     stop,
+		TimeCol:  execute.DefaultTimeColLabel,
+		StartCol: execute.DefaultStartColLabel,
+		StopCol:  execute.DefaultStopColLabel,
+	}, op)
+	if cond == nil {
+		return op, nil
+	}
+
+	expr, err := t.mapField(cond, &showCursor{id: op})
+	if err != nil {
+		return "", errors.Wrap(err, "unable to evaluate condition")
+	}
+	return t.op("filter", &transformations.FilterOpSpec{
+		Fn: &semantic.FunctionExpression{
+			Params: []*semantic.FunctionParam{
+				{Key: &semantic.Identifier{Name: "r"}},
+			},
+			Body: expr,
+		},
+	}, op), nil
+}
+
+// showCursor resolves the variables in the condition of a meta query. The variables
+// are tags, except for _name which is the measurement.
+type showCursor struct {
+	id flux.OperationID
+}
+
+func (c *showCursor) ID() flux.OperationID {
+	return c.id
+}
+
+func (c *showCursor) Keys() []influxql.Expr {
+	panic("unimplemented")
+}
+
+func (c *showCursor) Value(expr influxql.Expr) (string, bool) {
+	ref, ok := expr.(*influxql.VarRef)
+	if !ok {
+		return "", false
+	}
+	switch ref.Val {
+	case "_name":
+		return "_measurement", true
+	case "_tagKey":
+		// The tag keys are only known once the keys of the tables are listed.
+		return "", false
+	}
+	return ref.Val, true
+}
+
+// filterMeasurements filters the input to the measurements named or matched by the sources.
+// If there are no sources, the input is returned unchanged.
+func (t *transpilerState) filterMeasurements(op flux.OperationID, sources influxql.Sources) (flux.OperationID, error) {
+	if len(sources) == 0 {
+		return op, nil
+	}
+
+	measurements := make([]*influxql.Measurement, 0, len(sources))
+	for _, source := range sources {
+		mm, ok := source.(*influxql.Measurement)
+		if !ok {
+			return "", fmt.Errorf("unsupported source type: %T", source)
+		}
+		measurements = append(measurements, mm)
+	}
+
+	return t.op("filter", &transformations.FilterOpSpec{
+		Fn: &semantic.FunctionExpression{
+			Params: []*semantic.FunctionParam{
+				{Key: &semantic.Identifier{Name: "r"}},
+			},
+			Body: measurementExpr(measurements),
+		},
+	}, op), nil
+}
+
+// limit adds a limit operation when a limit or offset was specified.
+func (t *transpilerState) limit(op flux.OperationID, limit, offset int) flux.OperationID {
+	if limit <= 0 && offset <= 0 {
+		return op
+	}
+	n := int64(limit)
+	if n <= 0 {
+		// The limit transformation requires a limit so use the largest one possible.
+		n = 1<<63 - 1
+	}
+	return t.op("limit", &transformations.LimitOpSpec{
+		N:      n,
+		Offset: int64(offset),
+	}, op)
+}
+
+// findDBRPMappings returns the mappings of the organization for the configured cluster that match the filter.
+func (t *transpilerState) findDBRPMappings(ctx context.Context, filter platform.DBRPMappingFilter) ([]*platform.DBRPMapping, error) {
+	if t.dbrpMappingSvc == nil {
+		return nil, nil
+	}
+	filter.Cluster = &t.config.Cluster
+	mappings, _, err := t.dbrpMappingSvc.FindMany(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	orgMappings := mappings[:0]
+	for _, m := range mappings {
+		if m.OrganizationID == t.config.OrganizationID {
+			orgMappings = append(orgMappings, m)
+		}
+	}
+	return orgMappings, nil
+}
+
+// fromTable creates an operation that produces a single table with the columns and rows.
+// The values for the columns in the group key are taken from key and each row contains
+// the formatted values for the remaining columns in order. The table is produced even
+// when there are no rows.
+func (t *transpilerState) fromTable(key map[string]string, cols []flux.ColMeta, rows [][]string) (flux.OperationID, error) {
+	datatypes := []string{"#datatype", "string", "long"}
+	groups := []string{"#group", "false", "false"}
+	defaults := []string{"#default", "_result", "0"}
+	header := []string{"", "result", "table"}
+	for _, c := range cols {
+		switch c.Type {
+		case flux.TString:
+			datatypes = append(datatypes, "string")
+		case flux.TInt:
+			datatypes = append(datatypes, "long")
+		case flux.TBool:
+			datatypes = append(datatypes, "boolean")
+		default:
+			return "", fmt.Errorf("unsupported column type: %s", c.Type)
+		}
+		v, ok := key[c.Label]
+		groups = append(groups, strconv.FormatBool(ok))
+		defaults = append(defaults, v)
+		header = append(header, c.Label)
+	}
+
+	var buf strings.Builder
+	w := csv.NewWriter(&buf)
+	w.WriteAll([][]string{datatypes, groups, defaults, header})
+	for _, row := range rows {
+		record := make([]string, 0, len(header))
+		record = append(record, "", "", "")
+		for _, c := range cols {
+			if _, ok := key[c.Label]; ok {
+				record = append(record, "")
+				continue
+			}
+			record = append(record, row[0])
+			row = row[1:]
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return t.op("fromCSV", &inputs.FromCSVOpSpec{CSV: buf.String()}), nil
+}
@@ -25,6 +25,12 @@ var aggregateCreateFuncs = []func(config execute.AggregateConfig) flux.Operation
 	func(config execute.AggregateConfig) flux.OperationSpec {
 		return &transformations.SumOpSpec{AggregateConfig: config}
 	},
+	func(config execute.AggregateConfig) flux.OperationSpec {
+		return &transformations.SpreadOpSpec{AggregateConfig: config}
+	},
+	func(config execute.AggregateConfig) flux.OperationSpec {
+		return &transformations.StddevOpSpec{AggregateConfig: config}
+	},
 }
 
 func AggregateTest(fn func(aggregate flux.Operation) (string, *flux.Spec)) Fixture {
@@ -0,0 +1,249 @@
+package spectests
+
+import (
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/functions/transformations"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/execute"
+
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/influxql"
+)
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SELECT mean(*) FROM db0..cpu GROUP BY *`,
+			&flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &inputs.FromOpSpec{
+							BucketID: bucketID.String(),
+						},
+					},
+					{
+						ID: "range0",
+						Spec: &transformations.RangeOpSpec{
+							Start:    flux.Time{Absolute: time.Unix(0, influxql.MinTime)},
+							Stop:     flux.Time{Absolute: time.Unix(0, influxql.MaxTime)},
+							TimeCol:  execute.DefaultTimeColLabel,
+							StartCol: execute.DefaultStartColLabel,
+							StopCol:  execute.DefaultStopColLabel,
+						},
+					},
+					{
+						ID: "filter0",
+						Spec: &transformations.FilterOpSpec{
+							Fn: &semantic.FunctionExpression{
+								Params: []*semantic.FunctionParam{
+									{Key: &semantic.Identifier{Name: "r"}},
+								},
+								Body: &semantic.LogicalExpression{
+									Operator: ast.AndOperator,
+									Left: &semantic.BinaryExpression{
+										Operator: ast.EqualOperator,
+										Left: &semantic.MemberExpression{
+											Object: &semantic.IdentifierExpression{
+												Name: "r",
+											},
+											Property: "_measurement",
+										},
+										Right: &semantic.StringLiteral{
+											Value: "cpu",
+										},
+									},
+									Right: &semantic.BinaryExpression{
+										Operator: ast.EqualOperator,
+										Left: &semantic.MemberExpression{
+											Object: &semantic.IdentifierExpression{
+												Name: "r",
+											},
+											Property: "_field",
+										},
+										Right: &semantic.StringLiteral{
+											Value: "usage_system",
+										},
+									},
+								},
+							},
+						},
+					},
+					{
+						ID: "group0",
+						Spec: &transformations.GroupOpSpec{
+							By: []string{"_measurement", "_start", "host"},
+						},
+					},
+					{
+						ID: "mean0",
+						Spec: &transformations.MeanOpSpec{
+							AggregateConfig: execute.AggregateConfig{
+								Columns: []string{execute.DefaultValueColLabel},
+							},
+						},
+					},
+					{
+						ID: "duplicate0",
+						Spec: &transformations.DuplicateOpSpec{
+							Col: execute.DefaultStartColLabel,
+							As:  execute.DefaultTimeColLabel,
+						},
+					},
+					{
+						ID: "from1",
+						Spec: &inputs.FromOpSpec{
+							BucketID: bucketID.String(),
+						},
+					},
+					{
+						ID: "range1",
+						Spec: &transformations.RangeOpSpec{
+							Start:    flux.Time{Absolute: time.Unix(0, influxql.MinTime)},
+							Stop:     flux.Time{Absolute: time.Unix(0, influxql.MaxTime)},
+							TimeCol:  execute.DefaultTimeColLabel,
+							StartCol: execute.DefaultStartColLabel,
+							StopCol:  execute.DefaultStopColLabel,
+						},
+					},
+					{
+						ID: "filter1",
+						Spec: &transformations.FilterOpSpec{
+							Fn: &semantic.FunctionExpression{
+								Params: []*semantic.FunctionParam{
+									{Key: &semantic.Identifier{Name: "r"}},
+								},
+								Body: &semantic.LogicalExpression{
+									Operator: ast.AndOperator,
+									Left: &semantic.BinaryExpression{
+										Operator: ast.EqualOperator,
+										Left: &semantic.MemberExpression{
+											Object: &semantic.IdentifierExpression{
+												Name: "r",
+											},
+											Property: "_measurement",
+										},
+										Right: &semantic.StringLiteral{
+											Value: "cpu",
+										},
+									},
+									Right: &semantic.BinaryExpression{
+										Operator: ast.EqualOperator,
+										Left: &semantic.MemberExpression{
+											Object: &semantic.IdentifierExpression{
+												Name: "r",
+											},
+											Property: "_field",
+										},
+										Right: &semantic.StringLiteral{
+											Value: "usage_user",
+										},
+									},
+								},
+							},
+						},
+					},
+					{
+						ID: "group1",
+						Spec: &transformations.GroupOpSpec{
+							By: []string{"_measurement", "_start", "host"},
+						},
+					},
+					{
+						ID: "mean1",
+						Spec: &transformations.MeanOpSpec{
+							AggregateConfig: execute.AggregateConfig{
+								Columns: []string{execute.DefaultValueColLabel},
+							},
+						},
+					},
+					{
+						ID: "duplicate1",
+						Spec: &transformations.DuplicateOpSpec{
+							Col: execute.DefaultStartColLabel,
+							As:  execute.DefaultTimeColLabel,
+						},
+					},
+					{
+						ID: "join0",
+						Spec: &transformations.JoinOpSpec{
+							On: []string{"_time", "_measurement", "host"},
+							TableNames: map[flux.OperationID]string{
+								"duplicate0": "t0",
+								"duplicate1": "t1",
+							},
+						},
+					},
+					{
+						ID: "map0",
+						Spec: &transformations.MapOpSpec{
+							Fn: &semantic.FunctionExpression{
+								Params: []*semantic.FunctionParam{{
+									Key: &semantic.Identifier{Name: "r"},
+								}},
+								Body: &semantic.ObjectExpression{
+									Properties: []*semantic.Property{
+										{
+											Key: &semantic.Identifier{Name: "_time"},
+											Value: &semantic.MemberExpression{
+												Object: &semantic.IdentifierExpression{
+													Name: "r",
+												},
+												Property: "_time",
+											},
+										},
+										{
+											Key: &semantic.Identifier{Name: "mean_usage_system"},
+											Value: &semantic.MemberExpression{
+												Object: &semantic.IdentifierExpression{
+													Name: "r",
+												},
+												Property: "_value_t0",
+											},
+										},
+										{
+											Key: &semantic.Identifier{Name: "mean_usage_user"},
+											Value: &semantic.MemberExpression{
+												Object: &semantic.IdentifierExpression{
+													Name: "r",
+												},
+												Property: "_value_t1",
+											},
+										},
+									},
+								},
+							},
+							MergeKey: true,
+						},
+					},
+					{
+						ID: "yield0",
+						Spec: &transformations.YieldOpSpec{
+							Name: "0",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "range0"},
+					{Parent: "range0", Child: "filter0"},
+					{Parent: "filter0", Child: "group0"},
+					{Parent: "group0", Child: "mean0"},
+					{Parent: "mean0", Child: "duplicate0"},
+					{Parent: "from1", Child: "range1"},
+					{Parent: "range1", Child: "filter1"},
+					{Parent: "filter1", Child: "group1"},
+					{Parent: "group1", Child: "mean1"},
+					{Parent: "mean1", Child: "duplicate1"},
+					{Parent: "duplicate0", Child: "join0"},
+					{Parent: "duplicate1", Child: "join0"},
+					{Parent: "join0", Child: "map0"},
+					{Parent: "map0", Child: "yield0"},
+				},
+				Now: Now(),
+			},
+		),
+	)
+}
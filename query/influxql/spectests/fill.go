@@ -0,0 +1,98 @@
+package spectests
+
+import (
+	"math"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/influxql"
+	pinfluxql "github.com/influxdata/platform/query/influxql"
+)
+
+// windowedMean returns the operations for the mean of each window after the
+// windows have been combined.
+func windowedMean() []*flux.Operation {
+	return []*flux.Operation{
+		op("window0", &transformations.WindowOpSpec{
+			Every:         flux.Duration(time.Minute),
+			Period:        flux.Duration(time.Minute),
+			TimeCol:       execute.DefaultTimeColLabel,
+			StartColLabel: execute.DefaultStartColLabel,
+			StopColLabel:  execute.DefaultStopColLabel,
+		}),
+		op("mean0", &transformations.MeanOpSpec{
+			AggregateConfig: execute.AggregateConfig{
+				Columns: []string{execute.DefaultValueColLabel},
+			},
+		}),
+		normalizeTime,
+		op("window1", &transformations.WindowOpSpec{
+			Every:         flux.Duration(math.MaxInt64),
+			Period:        flux.Duration(math.MaxInt64),
+			TimeCol:       execute.DefaultTimeColLabel,
+			StartColLabel: execute.DefaultStartColLabel,
+			StopColLabel:  execute.DefaultStopColLabel,
+		}),
+	}
+}
+
+func fillOp(fill influxql.FillOption, value interface{}) *flux.Operation {
+	return op("influxqlFill0", &pinfluxql.FillOpSpec{
+		Column: execute.DefaultValueColLabel,
+		Fill:   fill,
+		Value:  value,
+		Every:  flux.Duration(time.Minute),
+		Start:  Now().Add(-10 * time.Minute),
+		Stop:   Now(),
+	})
+}
+
+func init() {
+	start, stop := Now().Add(-10*time.Minute), Now()
+	RegisterFixture(
+		NewFixture(
+			`SELECT mean(value) FROM db0..cpu WHERE time >= now() - 10m GROUP BY time(1m) fill(0)`,
+			functionSpec(start, stop, "mean", append(windowedMean(), fillOp(influxql.NumberFill, int64(0)))),
+		),
+		NewFixture(
+			`SELECT mean(value) FROM db0..cpu WHERE time >= now() - 10m GROUP BY time(1m) fill(previous)`,
+			functionSpec(start, stop, "mean", append(windowedMean(), fillOp(influxql.PreviousFill, nil))),
+		),
+		NewFixture(
+			`SELECT mean(value) FROM db0..cpu WHERE time >= now() - 10m GROUP BY time(1m) fill(linear)`,
+			functionSpec(start, stop, "mean", append(windowedMean(), fillOp(influxql.LinearFill, nil))),
+		),
+		NewFixture(
+			`SELECT mean(value) FROM db0..cpu WHERE time >= now() - 10m GROUP BY time(1m) fill(null)`,
+			functionSpec(start, stop, "mean", windowedMean()),
+		),
+		NewFixture(
+			`SELECT mean(value) FROM db0..cpu WHERE time >= now() - 10m GROUP BY time(1m) fill(none)`,
+			functionSpec(start, stop, "mean", windowedMean()),
+		),
+		NewFixture(
+			`SELECT derivative(mean(value)) FROM db0..cpu WHERE time >= now() - 10m GROUP BY time(1m) fill(previous)`,
+			functionSpec(start, stop, "derivative", append(windowedMean(),
+				fillOp(influxql.PreviousFill, nil),
+				op("derivative0", &transformations.DerivativeOpSpec{
+					Unit:    flux.Duration(time.Minute),
+					Columns: []string{execute.DefaultValueColLabel},
+					TimeSrc: execute.DefaultTimeColLabel,
+				}),
+			)),
+		),
+		NewFixture(
+			`SELECT holt_winters(mean(value), 5, 2) FROM db0..cpu WHERE time >= now() - 10m GROUP BY time(1m)`,
+			functionSpec(start, stop, "holt_winters", append(windowedMean(),
+				op("influxqlHoltWinters0", &pinfluxql.HoltWintersOpSpec{
+					Column:      execute.DefaultValueColLabel,
+					N:           5,
+					Seasonality: 2,
+					Interval:    flux.Duration(time.Minute),
+				}),
+			)),
+		),
+	)
+}
@@ -0,0 +1,292 @@
+package spectests
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/influxql"
+	pinfluxql "github.com/influxdata/platform/query/influxql"
+)
+
+// FunctionTest creates a fixture for each statement that selects a function of the
+// value field from the cpu measurement. The operations are the ones that are
+// expected between the grouping and the map that names the column.
+func FunctionTest(fn func(add func(stmt, name string, ops ...*flux.Operation))) Fixture {
+	_, file, line, _ := runtime.Caller(1)
+	fixture := &collection{
+		file: filepath.Base(file),
+		line: line,
+	}
+
+	fn(func(stmt, name string, ops ...*flux.Operation) {
+		fixture.Add(stmt, functionSpec(time.Unix(0, influxql.MinTime), time.Unix(0, influxql.MaxTime), name, ops))
+	})
+	return fixture
+}
+
+// functionSpec creates the spec for reading the value field from the cpu measurement
+// within the time range and passing it through the operations.
+func functionSpec(start, stop time.Time, name string, ops []*flux.Operation) *flux.Spec {
+	spec := &flux.Spec{
+		Operations: []*flux.Operation{
+			{
+				ID: "from0",
+				Spec: &inputs.FromOpSpec{
+					BucketID: bucketID.String(),
+				},
+			},
+			{
+				ID: "range0",
+				Spec: &transformations.RangeOpSpec{
+					Start:    flux.Time{Absolute: start},
+					Stop:     flux.Time{Absolute: stop},
+					TimeCol:  execute.DefaultTimeColLabel,
+					StartCol: execute.DefaultStartColLabel,
+					StopCol:  execute.DefaultStopColLabel,
+				},
+			},
+			{
+				ID: "filter0",
+				Spec: &transformations.FilterOpSpec{
+					Fn: &semantic.FunctionExpression{
+						Params: []*semantic.FunctionParam{
+							{Key: &semantic.Identifier{Name: "r"}},
+						},
+						Body: &semantic.LogicalExpression{
+							Operator: ast.AndOperator,
+							Left: &semantic.BinaryExpression{
+								Operator: ast.EqualOperator,
+								Left: &semantic.MemberExpression{
+									Object: &semantic.IdentifierExpression{
+										Name: "r",
+									},
+									Property: "_measurement",
+								},
+								Right: &semantic.StringLiteral{
+									Value: "cpu",
+								},
+							},
+							Right: &semantic.BinaryExpression{
+								Operator: ast.EqualOperator,
+								Left: &semantic.MemberExpression{
+									Object: &semantic.IdentifierExpression{
+										Name: "r",
+									},
+									Property: "_field",
+								},
+								Right: &semantic.StringLiteral{
+									Value: "value",
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				ID: "group0",
+				Spec: &transformations.GroupOpSpec{
+					By: []string{"_measurement", "_start"},
+				},
+			},
+		},
+		Now: Now(),
+	}
+	spec.Operations = append(spec.Operations, ops...)
+	spec.Operations = append(spec.Operations,
+		&flux.Operation{
+			ID: "map0",
+			Spec: &transformations.MapOpSpec{
+				Fn: &semantic.FunctionExpression{
+					Params: []*semantic.FunctionParam{{
+						Key: &semantic.Identifier{Name: "r"},
+					}},
+					Body: &semantic.ObjectExpression{
+						Properties: []*semantic.Property{
+							{
+								Key: &semantic.Identifier{Name: "_time"},
+								Value: &semantic.MemberExpression{
+									Object: &semantic.IdentifierExpression{
+										Name: "r",
+									},
+									Property: "_time",
+								},
+							},
+							{
+								Key: &semantic.Identifier{Name: name},
+								Value: &semantic.MemberExpression{
+									Object: &semantic.IdentifierExpression{
+										Name: "r",
+									},
+									Property: "_value",
+								},
+							},
+						},
+					},
+				},
+				MergeKey: true,
+			},
+		},
+		&flux.Operation{
+			ID: "yield0",
+			Spec: &transformations.YieldOpSpec{
+				Name: "0",
+			},
+		},
+	)
+
+	// Each of the operations is a child of the previous one.
+	for i := 1; i < len(spec.Operations); i++ {
+		spec.Edges = append(spec.Edges, flux.Edge{
+			Parent: spec.Operations[i-1].ID,
+			Child:  spec.Operations[i].ID,
+		})
+	}
+	return spec
+}
+
+func op(id string, spec flux.OperationSpec) *flux.Operation {
+	return &flux.Operation{ID: flux.OperationID(id), Spec: spec}
+}
+
+var (
+	normalizeTime = op("duplicate0", &transformations.DuplicateOpSpec{
+		Col: execute.DefaultStartColLabel,
+		As:  execute.DefaultTimeColLabel,
+	})
+	sortByTime = op("sort0", &transformations.SortOpSpec{
+		Cols: []string{execute.DefaultTimeColLabel},
+	})
+	nonNegative = op("filter1", &transformations.FilterOpSpec{
+		Fn: &semantic.FunctionExpression{
+			Params: []*semantic.FunctionParam{
+				{Key: &semantic.Identifier{Name: "r"}},
+			},
+			Body: &semantic.BinaryExpression{
+				Operator: ast.GreaterThanEqualOperator,
+				Left: &semantic.MemberExpression{
+					Object: &semantic.IdentifierExpression{
+						Name: "r",
+					},
+					Property: "_value",
+				},
+				Right: &semantic.IntegerLiteral{Value: 0},
+			},
+		},
+	})
+)
+
+func init() {
+	RegisterFixture(
+		FunctionTest(func(add func(stmt, name string, ops ...*flux.Operation)) {
+			add(`SELECT median(value) FROM db0..cpu`, "median",
+				op("percentile0", &transformations.PercentileOpSpec{
+					Percentile: 0.5,
+					Method:     "exact_mean",
+					AggregateConfig: execute.AggregateConfig{
+						Columns: []string{execute.DefaultValueColLabel},
+					},
+				}),
+				normalizeTime,
+			)
+			add(`SELECT mode(value) FROM db0..cpu`, "mode",
+				op("influxqlMode0", &pinfluxql.ModeOpSpec{
+					Column: execute.DefaultValueColLabel,
+				}),
+				normalizeTime,
+			)
+			add(`SELECT distinct(value) FROM db0..cpu`, "distinct",
+				op("distinct0", &transformations.DistinctOpSpec{
+					Column: execute.DefaultValueColLabel,
+				}),
+				normalizeTime,
+			)
+			add(`SELECT DISTINCT value FROM db0..cpu`, "distinct",
+				op("distinct0", &transformations.DistinctOpSpec{
+					Column: execute.DefaultValueColLabel,
+				}),
+				normalizeTime,
+			)
+			add(`SELECT count(distinct(value)) FROM db0..cpu`, "count",
+				op("distinct0", &transformations.DistinctOpSpec{
+					Column: execute.DefaultValueColLabel,
+				}),
+				op("count0", &transformations.CountOpSpec{
+					AggregateConfig: execute.AggregateConfig{
+						Columns: []string{execute.DefaultValueColLabel},
+					},
+				}),
+				normalizeTime,
+			)
+			for _, name := range []string{"top", "bottom"} {
+				add(fmt.Sprintf(`SELECT %s(value, 2) FROM db0..cpu`, name), name,
+					op("sort0", &transformations.SortOpSpec{
+						Cols: []string{execute.DefaultValueColLabel},
+						Desc: name == "top",
+					}),
+					op("limit0", &transformations.LimitOpSpec{N: 2}),
+					op("sort1", &transformations.SortOpSpec{
+						Cols: []string{execute.DefaultTimeColLabel},
+					}),
+				)
+			}
+			add(`SELECT derivative(value) FROM db0..cpu`, "derivative",
+				sortByTime,
+				op("derivative0", &transformations.DerivativeOpSpec{
+					Unit:    flux.Duration(time.Second),
+					Columns: []string{execute.DefaultValueColLabel},
+					TimeSrc: execute.DefaultTimeColLabel,
+				}),
+			)
+			add(`SELECT non_negative_derivative(value, 10s) FROM db0..cpu`, "non_negative_derivative",
+				sortByTime,
+				op("derivative0", &transformations.DerivativeOpSpec{
+					Unit:    flux.Duration(10 * time.Second),
+					Columns: []string{execute.DefaultValueColLabel},
+					TimeSrc: execute.DefaultTimeColLabel,
+				}),
+				nonNegative,
+			)
+			add(`SELECT difference(value) FROM db0..cpu`, "difference",
+				sortByTime,
+				op("difference0", &transformations.DifferenceOpSpec{
+					Columns: []string{execute.DefaultValueColLabel},
+				}),
+			)
+			add(`SELECT non_negative_difference(value) FROM db0..cpu`, "non_negative_difference",
+				sortByTime,
+				op("difference0", &transformations.DifferenceOpSpec{
+					Columns: []string{execute.DefaultValueColLabel},
+				}),
+				nonNegative,
+			)
+			add(`SELECT cumulative_sum(value) FROM db0..cpu`, "cumulative_sum",
+				sortByTime,
+				op("cumulativeSum0", &transformations.CumulativeSumOpSpec{
+					Columns: []string{execute.DefaultValueColLabel},
+				}),
+			)
+			add(`SELECT moving_average(value, 3) FROM db0..cpu`, "moving_average",
+				sortByTime,
+				op("influxqlMovingAverage0", &pinfluxql.MovingAverageOpSpec{
+					Column: execute.DefaultValueColLabel,
+					N:      3,
+				}),
+			)
+			add(`SELECT elapsed(value, 1s) FROM db0..cpu`, "elapsed",
+				sortByTime,
+				op("influxqlElapsed0", &pinfluxql.ElapsedOpSpec{
+					Column: execute.DefaultValueColLabel,
+					Unit:   flux.Duration(time.Second),
+				}),
+			)
+		}),
+	)
+}
@@ -207,7 +207,7 @@ func init() {
 												Object: &semantic.IdentifierExpression{
 													Name: "r",
 												},
-												Property: "t0__value",
+												Property: "_value_t0",
 											},
 										},
 										{
@@ -216,7 +216,7 @@ func init() {
 												Object: &semantic.IdentifierExpression{
 													Name: "r",
 												},
-												Property: "t1__value",
+												Property: "_value_t1",
 											},
 										},
 									},
@@ -0,0 +1,174 @@
+package spectests
+
+import (
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/functions/transformations"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/execute"
+
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/influxql"
+)
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SELECT * FROM db0..cpu`,
+			&flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &inputs.FromOpSpec{
+							BucketID: bucketID.String(),
+						},
+					},
+					{
+						ID: "range0",
+						Spec: &transformations.RangeOpSpec{
+							Start:    flux.Time{Absolute: time.Unix(0, influxql.MinTime)},
+							Stop:     flux.Time{Absolute: time.Unix(0, influxql.MaxTime)},
+							TimeCol:  execute.DefaultTimeColLabel,
+							StartCol: execute.DefaultStartColLabel,
+							StopCol:  execute.DefaultStopColLabel,
+						},
+					},
+					{
+						ID: "filter0",
+						Spec: &transformations.FilterOpSpec{
+							Fn: &semantic.FunctionExpression{
+								Params: []*semantic.FunctionParam{
+									{Key: &semantic.Identifier{Name: "r"}},
+								},
+								Body: &semantic.LogicalExpression{
+									Operator: ast.AndOperator,
+									Left: &semantic.BinaryExpression{
+										Operator: ast.EqualOperator,
+										Left: &semantic.MemberExpression{
+											Object: &semantic.IdentifierExpression{
+												Name: "r",
+											},
+											Property: "_measurement",
+										},
+										Right: &semantic.StringLiteral{
+											Value: "cpu",
+										},
+									},
+									Right: &semantic.LogicalExpression{
+										Operator: ast.OrOperator,
+										Left: &semantic.BinaryExpression{
+											Operator: ast.EqualOperator,
+											Left: &semantic.MemberExpression{
+												Object: &semantic.IdentifierExpression{
+													Name: "r",
+												},
+												Property: "_field",
+											},
+											Right: &semantic.StringLiteral{
+												Value: "usage_system",
+											},
+										},
+										Right: &semantic.BinaryExpression{
+											Operator: ast.EqualOperator,
+											Left: &semantic.MemberExpression{
+												Object: &semantic.IdentifierExpression{
+													Name: "r",
+												},
+												Property: "_field",
+											},
+											Right: &semantic.StringLiteral{
+												Value: "usage_user",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					{
+						ID: "pivot0",
+						Spec: &transformations.PivotOpSpec{
+							RowKey:   []string{execute.DefaultTimeColLabel},
+							ColKey:   []string{"_field"},
+							ValueCol: execute.DefaultValueColLabel,
+						},
+					},
+					{
+						ID: "group0",
+						Spec: &transformations.GroupOpSpec{
+							By: []string{"_measurement", "_start"},
+						},
+					},
+					{
+						ID: "map0",
+						Spec: &transformations.MapOpSpec{
+							Fn: &semantic.FunctionExpression{
+								Params: []*semantic.FunctionParam{{
+									Key: &semantic.Identifier{Name: "r"},
+								}},
+								Body: &semantic.ObjectExpression{
+									Properties: []*semantic.Property{
+										{
+											Key: &semantic.Identifier{Name: "_time"},
+											Value: &semantic.MemberExpression{
+												Object: &semantic.IdentifierExpression{
+													Name: "r",
+												},
+												Property: "_time",
+											},
+										},
+										{
+											Key: &semantic.Identifier{Name: "host"},
+											Value: &semantic.MemberExpression{
+												Object: &semantic.IdentifierExpression{
+													Name: "r",
+												},
+												Property: "host",
+											},
+										},
+										{
+											Key: &semantic.Identifier{Name: "usage_system"},
+											Value: &semantic.MemberExpression{
+												Object: &semantic.IdentifierExpression{
+													Name: "r",
+												},
+												Property: "usage_system",
+											},
+										},
+										{
+											Key: &semantic.Identifier{Name: "usage_user"},
+											Value: &semantic.MemberExpression{
+												Object: &semantic.IdentifierExpression{
+													Name: "r",
+												},
+												Property: "usage_user",
+											},
+										},
+									},
+								},
+							},
+							MergeKey: true,
+						},
+					},
+					{
+						ID: "yield0",
+						Spec: &transformations.YieldOpSpec{
+							Name: "0",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "range0"},
+					{Parent: "range0", Child: "filter0"},
+					{Parent: "filter0", Child: "pivot0"},
+					{Parent: "pivot0", Child: "group0"},
+					{Parent: "group0", Child: "map0"},
+					{Parent: "map0", Child: "yield0"},
+				},
+				Now: Now(),
+			},
+		),
+	)
+}
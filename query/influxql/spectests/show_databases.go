@@ -0,0 +1,40 @@
+package spectests
+
+import (
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/functions/transformations"
+)
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SHOW DATABASES`,
+			&flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "fromCSV0",
+						Spec: &inputs.FromCSVOpSpec{
+							CSV: `#datatype,string,long,string,string
+#group,false,false,true,false
+#default,_result,0,databases,
+,result,table,_measurement,name
+,,,,db0
+`,
+						},
+					},
+					{
+						ID: "yield0",
+						Spec: &transformations.YieldOpSpec{
+							Name: "0",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "fromCSV0", Child: "yield0"},
+				},
+				Now: Now(),
+			},
+		),
+	)
+}
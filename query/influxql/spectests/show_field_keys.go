@@ -0,0 +1,102 @@
+package spectests
+
+import (
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/platform/query/influxql"
+)
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SHOW FIELD KEYS ON "db0" LIMIT 10`,
+			&flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &inputs.FromOpSpec{
+							BucketID: bucketID.String(),
+						},
+					},
+					{
+						ID: "range0",
+						Spec: &transformations.RangeOpSpec{
+							Start: flux.Time{
+								Relative:   -time.Hour,
+								IsRelative: true,
+							},
+							Stop:     flux.Now,
+							TimeCol:  execute.DefaultTimeColLabel,
+							StartCol: execute.DefaultStartColLabel,
+							StopCol:  execute.DefaultStopColLabel,
+						},
+					},
+					{
+						ID:   "influxqlFieldType0",
+						Spec: &influxql.FieldTypeOpSpec{},
+					},
+					{
+						ID: "keep0",
+						Spec: &transformations.KeepOpSpec{
+							Cols: []string{"_field", "_measurement", "fieldType"},
+						},
+					},
+					{
+						ID: "group0",
+						Spec: &transformations.GroupOpSpec{
+							By: []string{"_measurement"},
+						},
+					},
+					{
+						ID: "unique0",
+						Spec: &transformations.UniqueOpSpec{
+							Column: "_field",
+						},
+					},
+					{
+						ID: "sort0",
+						Spec: &transformations.SortOpSpec{
+							Cols: []string{"_field"},
+						},
+					},
+					{
+						ID: "limit0",
+						Spec: &transformations.LimitOpSpec{
+							N: 10,
+						},
+					},
+					{
+						ID: "rename0",
+						Spec: &transformations.RenameOpSpec{
+							Cols: map[string]string{
+								"_field": "fieldKey",
+							},
+						},
+					},
+					{
+						ID: "yield0",
+						Spec: &transformations.YieldOpSpec{
+							Name: "0",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "range0"},
+					{Parent: "range0", Child: "influxqlFieldType0"},
+					{Parent: "influxqlFieldType0", Child: "keep0"},
+					{Parent: "keep0", Child: "group0"},
+					{Parent: "group0", Child: "unique0"},
+					{Parent: "unique0", Child: "sort0"},
+					{Parent: "sort0", Child: "limit0"},
+					{Parent: "limit0", Child: "rename0"},
+					{Parent: "rename0", Child: "yield0"},
+				},
+				Now: Now(),
+			},
+		),
+	)
+}
@@ -0,0 +1,114 @@
+package spectests
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/flux/semantic"
+)
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SHOW MEASUREMENTS ON "db0" WITH MEASUREMENT =~ /cpu.*/`,
+			&flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &inputs.FromOpSpec{
+							BucketID: bucketID.String(),
+						},
+					},
+					{
+						ID: "range0",
+						Spec: &transformations.RangeOpSpec{
+							Start: flux.Time{
+								Relative:   -time.Hour,
+								IsRelative: true,
+							},
+							Stop:     flux.Now,
+							TimeCol:  execute.DefaultTimeColLabel,
+							StartCol: execute.DefaultStartColLabel,
+							StopCol:  execute.DefaultStopColLabel,
+						},
+					},
+					{
+						ID: "filter0",
+						Spec: &transformations.FilterOpSpec{
+							Fn: &semantic.FunctionExpression{
+								Params: []*semantic.FunctionParam{
+									{Key: &semantic.Identifier{Name: "r"}},
+								},
+								Body: &semantic.BinaryExpression{
+									Operator: ast.RegexpMatchOperator,
+									Left: &semantic.MemberExpression{
+										Object:   &semantic.IdentifierExpression{Name: "r"},
+										Property: "_measurement",
+									},
+									Right: &semantic.RegexpLiteral{
+										Value: regexp.MustCompile(`cpu.*`),
+									},
+								},
+							},
+						},
+					},
+					{
+						ID: "keep0",
+						Spec: &transformations.KeepOpSpec{
+							Cols: []string{"_measurement"},
+						},
+					},
+					{
+						ID: "distinct0",
+						Spec: &transformations.DistinctOpSpec{
+							Column: "_measurement",
+						},
+					},
+					{
+						ID: "set0",
+						Spec: &transformations.SetOpSpec{
+							Key:   "_measurement",
+							Value: "measurements",
+						},
+					},
+					{
+						ID: "sort0",
+						Spec: &transformations.SortOpSpec{
+							Cols: []string{execute.DefaultValueColLabel},
+						},
+					},
+					{
+						ID: "rename0",
+						Spec: &transformations.RenameOpSpec{
+							Cols: map[string]string{
+								"_value": "name",
+							},
+						},
+					},
+					{
+						ID: "yield0",
+						Spec: &transformations.YieldOpSpec{
+							Name: "0",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "range0"},
+					{Parent: "range0", Child: "filter0"},
+					{Parent: "filter0", Child: "keep0"},
+					{Parent: "keep0", Child: "distinct0"},
+					{Parent: "distinct0", Child: "set0"},
+					{Parent: "set0", Child: "sort0"},
+					{Parent: "sort0", Child: "rename0"},
+					{Parent: "rename0", Child: "yield0"},
+				},
+				Now: Now(),
+			},
+		),
+	)
+}
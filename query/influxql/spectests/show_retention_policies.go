@@ -0,0 +1,40 @@
+package spectests
+
+import (
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/functions/transformations"
+)
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SHOW RETENTION POLICIES ON "db0"`,
+			&flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "fromCSV0",
+						Spec: &inputs.FromCSVOpSpec{
+							CSV: `#datatype,string,long,string,string,string,long,boolean
+#group,false,false,false,false,false,false,false
+#default,_result,0,,,,,
+,result,table,name,duration,shardGroupDuration,replicaN,default
+,,,autogen,72h0m0s,24h0m0s,1,true
+`,
+						},
+					},
+					{
+						ID: "yield0",
+						Spec: &transformations.YieldOpSpec{
+							Name: "0",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "fromCSV0", Child: "yield0"},
+				},
+				Now: Now(),
+			},
+		),
+	)
+}
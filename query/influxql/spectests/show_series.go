@@ -0,0 +1,88 @@
+package spectests
+
+import (
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/platform/query/influxql"
+)
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SHOW SERIES ON "db0"`,
+			&flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &inputs.FromOpSpec{
+							BucketID: bucketID.String(),
+						},
+					},
+					{
+						ID: "range0",
+						Spec: &transformations.RangeOpSpec{
+							Start: flux.Time{
+								Relative:   -time.Hour,
+								IsRelative: true,
+							},
+							Stop:     flux.Now,
+							TimeCol:  execute.DefaultTimeColLabel,
+							StartCol: execute.DefaultStartColLabel,
+							StopCol:  execute.DefaultStopColLabel,
+						},
+					},
+					{
+						ID:   "influxqlSeriesKey0",
+						Spec: &influxql.SeriesKeyOpSpec{},
+					},
+					{
+						ID: "keep0",
+						Spec: &transformations.KeepOpSpec{
+							Cols: []string{"key"},
+						},
+					},
+					{
+						ID: "distinct0",
+						Spec: &transformations.DistinctOpSpec{
+							Column: "key",
+						},
+					},
+					{
+						ID: "sort0",
+						Spec: &transformations.SortOpSpec{
+							Cols: []string{execute.DefaultValueColLabel},
+						},
+					},
+					{
+						ID: "rename0",
+						Spec: &transformations.RenameOpSpec{
+							Cols: map[string]string{
+								"_value": "key",
+							},
+						},
+					},
+					{
+						ID: "yield0",
+						Spec: &transformations.YieldOpSpec{
+							Name: "0",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "range0"},
+					{Parent: "range0", Child: "influxqlSeriesKey0"},
+					{Parent: "influxqlSeriesKey0", Child: "keep0"},
+					{Parent: "keep0", Child: "distinct0"},
+					{Parent: "distinct0", Child: "sort0"},
+					{Parent: "sort0", Child: "rename0"},
+					{Parent: "rename0", Child: "yield0"},
+				},
+				Now: Now(),
+			},
+		),
+	)
+}
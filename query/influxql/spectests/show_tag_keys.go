@@ -0,0 +1,110 @@
+package spectests
+
+import (
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/flux/semantic"
+)
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SHOW TAG KEYS ON "db0" FROM "cpu"`,
+			&flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &inputs.FromOpSpec{
+							BucketID: bucketID.String(),
+						},
+					},
+					{
+						ID: "range0",
+						Spec: &transformations.RangeOpSpec{
+							Start: flux.Time{
+								Relative:   -time.Hour,
+								IsRelative: true,
+							},
+							Stop:     flux.Now,
+							TimeCol:  execute.DefaultTimeColLabel,
+							StartCol: execute.DefaultStartColLabel,
+							StopCol:  execute.DefaultStopColLabel,
+						},
+					},
+					{
+						ID: "filter0",
+						Spec: &transformations.FilterOpSpec{
+							Fn: &semantic.FunctionExpression{
+								Params: []*semantic.FunctionParam{
+									{Key: &semantic.Identifier{Name: "r"}},
+								},
+								Body: &semantic.BinaryExpression{
+									Operator: ast.EqualOperator,
+									Left: &semantic.MemberExpression{
+										Object:   &semantic.IdentifierExpression{Name: "r"},
+										Property: "_measurement",
+									},
+									Right: &semantic.StringLiteral{Value: "cpu"},
+								},
+							},
+						},
+					},
+					{
+						ID: "keys0",
+						Spec: &transformations.KeysOpSpec{
+							Except: []string{"_field", "_measurement", "_start", "_stop", "_time", "_value"},
+						},
+					},
+					{
+						ID: "keep0",
+						Spec: &transformations.KeepOpSpec{
+							Cols: []string{"_measurement", execute.DefaultValueColLabel},
+						},
+					},
+					{
+						ID: "distinct0",
+						Spec: &transformations.DistinctOpSpec{
+							Column: execute.DefaultValueColLabel,
+						},
+					},
+					{
+						ID: "sort0",
+						Spec: &transformations.SortOpSpec{
+							Cols: []string{execute.DefaultValueColLabel},
+						},
+					},
+					{
+						ID: "rename0",
+						Spec: &transformations.RenameOpSpec{
+							Cols: map[string]string{
+								"_value": "tagKey",
+							},
+						},
+					},
+					{
+						ID: "yield0",
+						Spec: &transformations.YieldOpSpec{
+							Name: "0",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "range0"},
+					{Parent: "range0", Child: "filter0"},
+					{Parent: "filter0", Child: "keys0"},
+					{Parent: "keys0", Child: "keep0"},
+					{Parent: "keep0", Child: "distinct0"},
+					{Parent: "distinct0", Child: "sort0"},
+					{Parent: "sort0", Child: "rename0"},
+					{Parent: "rename0", Child: "yield0"},
+				},
+				Now: Now(),
+			},
+		),
+	)
+}
@@ -28,6 +28,10 @@ func init() {
 								Relative:   -time.Hour,
 								IsRelative: true,
 							},
+							Stop:     flux.Now,
+							TimeCol:  execute.DefaultTimeColLabel,
+							StartCol: execute.DefaultStartColLabel,
+							StopCol:  execute.DefaultStopColLabel,
 						},
 					},
 					{
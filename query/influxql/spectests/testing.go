@@ -11,16 +11,19 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute/executetest"
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/query"
 	"github.com/influxdata/platform/query/influxql"
 	platformtesting "github.com/influxdata/platform/testing"
 )
 
 var dbrpMappingSvc = mock.NewDBRPMappingService()
-var organizationID platform.ID
-var bucketID platform.ID
-var altBucketID platform.ID
+var bucketSvc = mock.NewBucketService()
+var organizationID = platformtesting.MustIDBase16("aaaaaaaaaaaaaaaa")
+var bucketID = platformtesting.MustIDBase16("bbbbbbbbbbbbbbbb")
+var altBucketID = platformtesting.MustIDBase16("cccccccccccccccc")
 
 func init() {
 	mapping := platform.DBRPMapping{
@@ -58,6 +61,42 @@ func init() {
 		}
 		return []*platform.DBRPMapping{m}, 1, nil
 	}
+	bucketSvc.FindBucketByIDFn = func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+		return &platform.Bucket{ID: id, OrganizationID: organizationID, RetentionPeriod: 72 * time.Hour}, nil
+	}
+}
+
+// schemaQuerier returns the same fields and tags for every measurement
+// so wildcards can be expanded.
+type schemaQuerier struct{}
+
+func (schemaQuerier) Query(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+	return flux.NewSliceResultIterator([]flux.Result{
+		&executetest.Result{
+			Nm: "fields",
+			Tbls: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_field", Type: flux.TString},
+					{Label: "fieldType", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{"usage_system", "float"},
+					{"usage_user", "float"},
+				},
+			}},
+		},
+		&executetest.Result{
+			Nm: "tags",
+			Tbls: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_value", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{"host"},
+				},
+			}},
+		},
+	}), nil
 }
 
 // Fixture is a structure that will run tests.
@@ -84,10 +123,6 @@ func NewFixture(stmt string, spec *flux.Spec) Fixture {
 }
 
 func (f *fixture) Run(t *testing.T) {
-	organizationID = platformtesting.MustIDBase16("aaaaaaaaaaaaaaaa")
-	bucketID = platformtesting.MustIDBase16("bbbbbbbbbbbbbbbb")
-	altBucketID = platformtesting.MustIDBase16("cccccccccccccccc")
-
 	t.Run(f.stmt, func(t *testing.T) {
 		if err := f.spec.Validate(); err != nil {
 			t.Fatalf("%s:%d: expected spec is not valid: %s", f.file, f.line, err)
@@ -99,6 +134,9 @@ func (f *fixture) Run(t *testing.T) {
 				DefaultDatabase: "db0",
 				Cluster:         "cluster",
 				NowFn:           Now,
+				SchemaQuerier:   schemaQuerier{},
+				OrganizationID:  organizationID,
+				BucketService:   bucketSvc,
 			},
 		)
 		spec, err := transpiler.Transpile(context.Background(), f.stmt)
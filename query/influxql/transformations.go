@@ -0,0 +1,222 @@
+package influxql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/platform/models"
+)
+
+// The transformations in this file are used by the transpiler to answer meta queries
+// that need information about the schema of a table rather than its values. They are
+// not registered as Flux functions and can only be created from a transpiled spec.
+const (
+	FieldTypeKind = "influxqlFieldType"
+	SeriesKeyKind = "influxqlSeriesKey"
+)
+
+const (
+	// fieldTypeColLabel is the column that the field type transformation writes to.
+	fieldTypeColLabel = "fieldType"
+	// seriesKeyColLabel is the column that the series key transformation writes to.
+	seriesKeyColLabel = "key"
+)
+
+func init() {
+	flux.RegisterOpSpec(FieldTypeKind, newFieldTypeOp)
+	plan.RegisterProcedureSpec(FieldTypeKind, newFieldTypeProcedure, FieldTypeKind)
+	execute.RegisterTransformation(FieldTypeKind, createFieldTypeTransformation)
+
+	flux.RegisterOpSpec(SeriesKeyKind, newSeriesKeyOp)
+	plan.RegisterProcedureSpec(SeriesKeyKind, newSeriesKeyProcedure, SeriesKeyKind)
+	execute.RegisterTransformation(SeriesKeyKind, createSeriesKeyTransformation)
+}
+
+// FieldTypeOpSpec produces a single row for each table containing the group key
+// and the influxql name for the type of the _value column.
+type FieldTypeOpSpec struct{}
+
+func newFieldTypeOp() flux.OperationSpec {
+	return new(FieldTypeOpSpec)
+}
+
+func (s *FieldTypeOpSpec) Kind() flux.OperationKind {
+	return FieldTypeKind
+}
+
+type FieldTypeProcedureSpec struct{}
+
+func newFieldTypeProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	if _, ok := qs.(*FieldTypeOpSpec); !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &FieldTypeProcedureSpec{}, nil
+}
+
+func (s *FieldTypeProcedureSpec) Kind() plan.ProcedureKind {
+	return FieldTypeKind
+}
+
+func (s *FieldTypeProcedureSpec) Copy() plan.ProcedureSpec {
+	return &FieldTypeProcedureSpec{}
+}
+
+func createFieldTypeTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	if _, ok := spec.(*FieldTypeProcedureSpec); !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewFieldTypeTransformation(d, cache)
+	return t, d, nil
+}
+
+func NewFieldTypeTransformation(d execute.Dataset, cache execute.TableBuilderCache) execute.Transformation {
+	return &tableInfoTransformation{
+		d:     d,
+		cache: cache,
+		label: fieldTypeColLabel,
+		fn:    fieldType,
+	}
+}
+
+// fieldType returns the influxql name of the type of the _value column.
+func fieldType(tbl flux.Table) (string, error) {
+	idx := execute.ColIdx(execute.DefaultValueColLabel, tbl.Cols())
+	if idx < 0 {
+		return "", fmt.Errorf("no %s column found", execute.DefaultValueColLabel)
+	}
+
+	switch typ := tbl.Cols()[idx].Type; typ {
+	case flux.TFloat:
+		return "float", nil
+	case flux.TInt:
+		return "integer", nil
+	case flux.TUInt:
+		return "unsigned", nil
+	case flux.TString:
+		return "string", nil
+	case flux.TBool:
+		return "boolean", nil
+	default:
+		return "", fmt.Errorf("unsupported field type: %s", typ)
+	}
+}
+
+// SeriesKeyOpSpec produces a single row for each table containing the group key
+// and the 1.x series key constructed from the measurement and tags in the group key.
+type SeriesKeyOpSpec struct{}
+
+func newSeriesKeyOp() flux.OperationSpec {
+	return new(SeriesKeyOpSpec)
+}
+
+func (s *SeriesKeyOpSpec) Kind() flux.OperationKind {
+	return SeriesKeyKind
+}
+
+type SeriesKeyProcedureSpec struct{}
+
+func newSeriesKeyProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	if _, ok := qs.(*SeriesKeyOpSpec); !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &SeriesKeyProcedureSpec{}, nil
+}
+
+func (s *SeriesKeyProcedureSpec) Kind() plan.ProcedureKind {
+	return SeriesKeyKind
+}
+
+func (s *SeriesKeyProcedureSpec) Copy() plan.ProcedureSpec {
+	return &SeriesKeyProcedureSpec{}
+}
+
+func createSeriesKeyTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	if _, ok := spec.(*SeriesKeyProcedureSpec); !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewSeriesKeyTransformation(d, cache)
+	return t, d, nil
+}
+
+func NewSeriesKeyTransformation(d execute.Dataset, cache execute.TableBuilderCache) execute.Transformation {
+	return &tableInfoTransformation{
+		d:     d,
+		cache: cache,
+		label: seriesKeyColLabel,
+		fn:    seriesKey,
+	}
+}
+
+// seriesKey constructs the series key from the _measurement and the tags
+// within the group key. Columns that begin with an underscore are not tags.
+func seriesKey(tbl flux.Table) (string, error) {
+	var name string
+	tags := make(map[string]string)
+	key := tbl.Key()
+	for j, c := range key.Cols() {
+		if c.Type != flux.TString {
+			continue
+		}
+		if c.Label == "_measurement" {
+			name = key.ValueString(j)
+		} else if !strings.HasPrefix(c.Label, "_") {
+			tags[c.Label] = key.ValueString(j)
+		}
+	}
+	return string(models.MakeKey([]byte(name), models.NewTags(tags))), nil
+}
+
+// tableInfoTransformation writes a single row for each table with the group key
+// and a string column computed from the table by fn.
+type tableInfoTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	label string
+	fn    func(tbl flux.Table) (string, error)
+}
+
+func (t *tableInfoTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *tableInfoTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return fmt.Errorf("%s found duplicate table with key: %v", t.label, tbl.Key())
+	}
+
+	v, err := t.fn(tbl)
+	if err != nil {
+		return err
+	}
+
+	execute.AddTableKeyCols(tbl.Key(), builder)
+	colIdx := builder.AddCol(flux.ColMeta{Label: t.label, Type: flux.TString})
+	execute.AppendKeyValues(tbl.Key(), builder)
+	builder.AppendString(colIdx, v)
+
+	// The table must still be consumed even though none of its values are used.
+	return tbl.Do(func(flux.ColReader) error {
+		return nil
+	})
+}
+
+func (t *tableInfoTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+
+func (t *tableInfoTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *tableInfoTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
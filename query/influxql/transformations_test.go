@@ -0,0 +1,150 @@
+package influxql_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/platform/query/influxql"
+)
+
+func TestFieldType_Process(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []flux.Table
+		want []*executetest.Table
+	}{
+		{
+			name: "float and string",
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"_measurement", "_field", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), "cpu", "usage_user", "server01", 2.0},
+						{execute.Time(2), "cpu", "usage_user", "server01", 3.0},
+					},
+				},
+				&executetest.Table{
+					KeyCols: []string{"_measurement", "_field", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), "cpu", "status", "server01", "ok"},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"_measurement", "_field", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "fieldType", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{"cpu", "usage_user", "server01", "float"},
+					},
+				},
+				{
+					KeyCols: []string{"_measurement", "_field", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "fieldType", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{"cpu", "status", "server01", "string"},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				nil,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return influxql.NewFieldTypeTransformation(d, c)
+				},
+			)
+		})
+	}
+}
+
+func TestSeriesKey_Process(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []flux.Table
+		want []*executetest.Table
+	}{
+		{
+			name: "tags",
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"_start", "_measurement", "_field", "region", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+						{Label: "region", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(0), execute.Time(1), "cpu", "usage_user", "us west", "server01", 2.0},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"_start", "_measurement", "_field", "region", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+						{Label: "region", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "key", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(0), "cpu", "usage_user", "us west", "server01", `cpu,host=server01,region=us\ west`},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				nil,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return influxql.NewSeriesKeyTransformation(d, c)
+				},
+			)
+		})
+	}
+}
@@ -57,6 +57,8 @@ type transpilerState struct {
 	config         Config
 	spec           *flux.Spec
 	nextID         map[string]int
+	subqueries     map[*influxql.SubQuery]flux.OperationID
+	schemas        map[schemaKey]*measurementSchema
 	dbrpMappingSvc platform.DBRPMappingService
 }
 
@@ -64,6 +66,8 @@ func newTranspilerState(dbrpMappingSvc platform.DBRPMappingService, config *Conf
 	state := &transpilerState{
 		spec:           &flux.Spec{},
 		nextID:         make(map[string]int),
+		subqueries:     make(map[*influxql.SubQuery]flux.OperationID),
+		schemas:        make(map[schemaKey]*measurementSchema),
 		dbrpMappingSvc: dbrpMappingSvc,
 	}
 	if config != nil {
@@ -93,6 +97,18 @@ func (t *transpilerState) transpile(ctx context.Context, s influxql.Statement) (
 		return t.transpileSelect(ctx, stmt)
 	case *influxql.ShowTagValuesStatement:
 		return t.transpileShowTagValues(ctx, stmt)
+	case *influxql.ShowMeasurementsStatement:
+		return t.transpileShowMeasurements(ctx, stmt)
+	case *influxql.ShowTagKeysStatement:
+		return t.transpileShowTagKeys(ctx, stmt)
+	case *influxql.ShowFieldKeysStatement:
+		return t.transpileShowFieldKeys(ctx, stmt)
+	case *influxql.ShowSeriesStatement:
+		return t.transpileShowSeries(ctx, stmt)
+	case *influxql.ShowDatabasesStatement:
+		return t.transpileShowDatabases(ctx, stmt)
+	case *influxql.ShowRetentionPoliciesStatement:
+		return t.transpileShowRetentionPolicies(ctx, stmt)
 	default:
 		return "", fmt.Errorf("unknown statement type %T", s)
 	}
@@ -103,69 +119,17 @@ func (t *transpilerState) transpileShowTagValues(ctx context.Context, stmt *infl
 	// not actually contain the database and we do not factor in retention policies. So we are always going to use
 	// the default retention policy when evaluating which bucket we are querying and we do not have to consult
 	// the sources in the statement.
-	if stmt.Database == "" {
-		if t.config.DefaultDatabase == "" {
-			return "", errDatabaseNameRequired
-		}
-		stmt.Database = t.config.DefaultDatabase
-	}
-
-	op, err := t.from(&influxql.Measurement{Database: stmt.Database})
+	op, err := t.showFrom(stmt.Database, stmt.Condition)
 	if err != nil {
 		return "", err
 	}
 
-	// TODO(jsternberg): Read the range from the condition expression. 1.x doesn't actually do this so it isn't
-	// urgent to implement this functionality so we can use the default range.
-	op = t.op("range", &transformations.RangeOpSpec{
-		Start: flux.Time{
-			Relative:   -time.Hour,
-			IsRelative: true,
-		},
-	}, op)
-
-	// If we have a list of sources, look through it and add each of the measurement names.
-	measurementNames := make([]string, 0, len(stmt.Sources))
-	for _, source := range stmt.Sources {
-		mm := source.(*influxql.Measurement)
-		measurementNames = append(measurementNames, mm.Name)
-	}
-
-	if len(measurementNames) > 0 {
-		var expr semantic.Expression = &semantic.BinaryExpression{
-			Operator: ast.EqualOperator,
-			Left: &semantic.MemberExpression{
-				Object:   &semantic.IdentifierExpression{Name: "r"},
-				Property: "_measurement",
-			},
-			Right: &semantic.StringLiteral{Value: measurementNames[len(measurementNames)-1]},
-		}
-		for i := len(measurementNames) - 2; i >= 0; i-- {
-			expr = &semantic.LogicalExpression{
-				Operator: ast.OrOperator,
-				Left: &semantic.BinaryExpression{
-					Operator: ast.EqualOperator,
-					Left: &semantic.MemberExpression{
-						Object:   &semantic.IdentifierExpression{Name: "r"},
-						Property: "_measurement",
-					},
-					Right: &semantic.StringLiteral{Value: measurementNames[i]},
-				},
-				Right: expr,
-			}
-		}
-		op = t.op("filter", &transformations.FilterOpSpec{
-			Fn: &semantic.FunctionExpression{
-				Params: []*semantic.FunctionParam{
-					{Key: &semantic.Identifier{Name: "r"}},
-				},
-				Body: expr,
-			},
-		}, op)
+	// If we have a list of sources, filter to only the measurements from those sources.
+	op, err = t.filterMeasurements(op, stmt.Sources)
+	if err != nil {
+		return "", err
 	}
 
-	// TODO(jsternberg): Add the condition filter for the where clause.
-
 	// Create the key values op spec from the
 	var keyValues transformations.KeyValuesOpSpec
 	switch expr := stmt.TagKeyExpr.(type) {
@@ -206,6 +170,21 @@ func (t *transpilerState) transpileSelect(ctx context.Context, stmt *influxql.Se
 	t.stmt = stmt.Clone()
 	t.stmt.OmitTime = true
 
+	// Expand any wildcards using the schema of the sources.
+	if err := t.rewriteWildcards(ctx); err != nil {
+		return "", err
+	}
+
+	// The distinct keyword is the same as calling the distinct() function.
+	for _, f := range t.stmt.Fields {
+		f.Expr = influxql.RewriteExpr(f.Expr, func(expr influxql.Expr) influxql.Expr {
+			if d, ok := expr.(*influxql.Distinct); ok {
+				return d.NewCall()
+			}
+			return expr
+		})
+	}
+
 	groups, err := identifyGroups(t.stmt)
 	if err != nil {
 		return "", err
@@ -215,16 +194,21 @@ func (t *transpilerState) transpileSelect(ctx context.Context, stmt *influxql.Se
 
 	cursors := make([]cursor, 0, len(groups))
 	for _, gr := range groups {
-		cur, err := gr.createCursor(t)
+		cur, err := gr.createCursor(ctx, t)
 		if err != nil {
 			return "", err
 		}
 		cursors = append(cursors, cur)
 	}
 
-	// Join the cursors together on the measurement name.
-	// TODO(jsternberg): This needs to join on all remaining group keys.
-	cur := Join(t, cursors, []string{"_time", "_measurement"})
+	// Join the cursors together on the measurement name and the tags in the group key.
+	on := []string{"_time", "_measurement"}
+	for _, d := range t.stmt.Dimensions {
+		if ref, ok := d.Expr.(*influxql.VarRef); ok && !contains(on, ref.Val) {
+			on = append(on, ref.Val)
+		}
+	}
+	cur := Join(t, cursors, on)
 
 	// Map each of the fields into another cursor. This evaluates any lingering expressions.
 	cur, err = t.mapFields(cur)
@@ -234,13 +218,48 @@ func (t *transpilerState) transpileSelect(ctx context.Context, stmt *influxql.Se
 	return cur.ID(), nil
 }
 
+// contains returns true if the name is in the list of names.
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *transpilerState) mapType(ref *influxql.VarRef) influxql.DataType {
-	// TODO(jsternberg): Actually evaluate the type against the schema.
+	// The type is only known when the statement was rewritten with the schema.
+	// Otherwise, assume that it is a tag.
+	if ref.Type != influxql.Unknown {
+		return ref.Type
+	}
 	return influxql.Tag
 }
 
+// timeRange returns the time range for the current statement from the condition.
+func (t *transpilerState) timeRange() (influxql.TimeRange, error) {
+	valuer := influxql.NowValuer{Now: t.spec.Now}
+	_, tr, err := influxql.ConditionExpr(t.stmt.Condition, &valuer)
+	if err != nil {
+		return influxql.TimeRange{}, err
+	}
+
+	// If the maximum is not set and we have a windowing function, then
+	// the end time will be set to now.
+	if tr.Max.IsZero() {
+		if window, err := t.stmt.GroupByInterval(); err == nil && window > 0 {
+			tr.Max = t.spec.Now
+		}
+	}
+	return tr, nil
+}
+
 func (t *transpilerState) from(m *influxql.Measurement) (flux.OperationID, error) {
 	db, rp := m.Database, m.RetentionPolicy
+	if db == "" && rp == "" && t.config.Bucket != "" {
+		return t.op("from", &inputs.FromOpSpec{Bucket: t.config.Bucket}), nil
+	}
 	if db == "" {
 		if t.config.DefaultDatabase == "" {
 			return "", errors.New("database is required")
@@ -263,6 +282,9 @@ func (t *transpilerState) from(m *influxql.Measurement) (flux.OperationID, error
 	}
 	defaultRP := rp == ""
 	filter.Default = &defaultRP
+	if t.dbrpMappingSvc == nil {
+		return "", fmt.Errorf("no bucket is mapped to database %q", db)
+	}
 	mapping, err := t.dbrpMappingSvc.Find(context.TODO(), filter)
 	if err != nil {
 		return "", err
@@ -274,6 +296,36 @@ func (t *transpilerState) from(m *influxql.Measurement) (flux.OperationID, error
 	return t.op("from", spec), nil
 }
 
+// measurementExpr creates an expression that matches any of the measurements by name or regex.
+func measurementExpr(measurements []*influxql.Measurement) semantic.Expression {
+	exprs := make([]semantic.Expression, 0, len(measurements))
+	for _, mm := range measurements {
+		expr := &semantic.BinaryExpression{
+			Operator: ast.EqualOperator,
+			Left: &semantic.MemberExpression{
+				Object:   &semantic.IdentifierExpression{Name: "r"},
+				Property: "_measurement",
+			},
+			Right: &semantic.StringLiteral{Value: mm.Name},
+		}
+		if mm.Regex != nil {
+			expr.Operator = ast.RegexpMatchOperator
+			expr.Right = &semantic.RegexpLiteral{Value: mm.Regex.Val}
+		}
+		exprs = append(exprs, expr)
+	}
+
+	expr := exprs[len(exprs)-1]
+	for i := len(exprs) - 2; i >= 0; i-- {
+		expr = &semantic.LogicalExpression{
+			Operator: ast.OrOperator,
+			Left:     exprs[i],
+			Right:    expr,
+		}
+	}
+	return expr
+}
+
 func (t *transpilerState) op(name string, spec flux.OperationSpec, parents ...flux.OperationID) flux.OperationID {
 	op := flux.Operation{
 		ID:   flux.OperationID(fmt.Sprintf("%s%d", name, t.nextID[name])),
@@ -5,8 +5,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute/executetest"
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/query"
 	"github.com/influxdata/platform/query/influxql"
 	"github.com/influxdata/platform/query/influxql/spectests"
 	platformtesting "github.com/influxdata/platform/testing"
@@ -35,6 +38,38 @@ func init() {
 	}
 }
 
+// schemaQuerier returns the same fields and tags for every measurement.
+type schemaQuerier struct{}
+
+func (schemaQuerier) Query(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+	return flux.NewSliceResultIterator([]flux.Result{
+		&executetest.Result{
+			Nm: "fields",
+			Tbls: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_field", Type: flux.TString},
+					{Label: "fieldType", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{"value", "float"},
+				},
+			}},
+		},
+		&executetest.Result{
+			Nm: "tags",
+			Tbls: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_value", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{"host"},
+					{"region"},
+				},
+			}},
+		},
+	}), nil
+}
+
 func TestTranspiler(t *testing.T) {
 	for _, fixture := range spectests.All() {
 		fixture.Run(t)
@@ -53,6 +88,12 @@ func TestTranspiler_Compile(t *testing.T) {
 		{s: `SELECT value, host FROM cpu`},
 		{s: `SELECT * FROM cpu`},
 		{s: `SELECT time, * FROM cpu`},
+		{s: `SELECT * FROM cpu GROUP BY *`},
+		{s: `SELECT * FROM cpu GROUP BY /ho/`},
+		{s: `SELECT mean(*) FROM cpu GROUP BY *`},
+		{s: `SELECT /val/, host FROM cpu`},
+		{s: `SELECT max(value) FROM (SELECT * FROM cpu)`},
+		{s: `SELECT max(value) FROM (SELECT max(*), host FROM cpu)`, err: `mixing aggregate and non-aggregate queries is not supported`},
 		{s: `SELECT value, * FROM cpu`},
 		{s: `SELECT max(value) FROM cpu`},
 		{s: `SELECT max(value), host FROM cpu`},
@@ -390,6 +431,7 @@ func TestTranspiler_Compile(t *testing.T) {
 				dbrpMappingSvc,
 				influxql.Config{
 					DefaultDatabase: "db0",
+					SchemaQuerier:   schemaQuerier{},
 				},
 			)
 			if _, err := transpiler.Transpile(context.Background(), tt.s); err != nil {
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"context"
 	"time"
 
 	"github.com/influxdata/flux"
@@ -27,6 +28,8 @@ type Log struct {
 	ResponseSize int64
 	// Statistics is a set of statistics about the query execution
 	Statistics flux.Statistics
+	// Duration is the wall clock time spent servicing the query request
+	Duration time.Duration
 }
 
 // Redact removes any sensitive information before logging
@@ -49,3 +52,35 @@ func (q *Log) Redact() {
 		q.ProxyRequest = request
 	}
 }
+
+// LogService reads the query logs persisted by a Logger.
+type LogService interface {
+	// FindLogEntries returns the logged queries that match filter, most recent first.
+	FindLogEntries(ctx context.Context, filter LogFilter) ([]*LogEntry, error)
+}
+
+// LogFilter restricts the query logs returned by a LogService.
+type LogFilter struct {
+	// OrganizationID is the organization whose queries are returned. It is required.
+	OrganizationID platform.ID
+	// Since excludes queries completed before this time.
+	Since time.Time
+	// SlowOnly restricts the results to queries exceeding the slow query threshold.
+	SlowOnly bool
+	// Limit is the maximum number of entries returned. Zero means no limit.
+	Limit int
+}
+
+// LogEntry is a query log as read back from persistent storage.
+type LogEntry struct {
+	ID             platform.ID       `json:"id"`
+	Time           time.Time         `json:"time"`
+	OrganizationID platform.ID       `json:"orgID"`
+	CompilerType   flux.CompilerType `json:"compilerType"`
+	Query          string            `json:"query"`
+	ResponseSize   int64             `json:"responseSize"`
+	Duration       time.Duration     `json:"duration"`
+	Statistics     flux.Statistics   `json:"statistics"`
+	Error          string            `json:"error,omitempty"`
+	Slow           bool              `json:"slow"`
+}
@@ -18,6 +18,7 @@ type LoggingServiceBridge struct {
 // Query executes and logs the query.
 func (s *LoggingServiceBridge) Query(ctx context.Context, w io.Writer, req *ProxyRequest) (n int64, err error) {
 	var stats flux.Statistics
+	start := time.Now()
 	defer func() {
 		r := recover()
 		if r != nil {
@@ -29,6 +30,7 @@ func (s *LoggingServiceBridge) Query(ctx context.Context, w io.Writer, req *Prox
 			ResponseSize:   n,
 			Time:           time.Now(),
 			Statistics:     stats,
+			Duration:       time.Since(start),
 		}
 		if err != nil {
 			log.Error = err
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/query"
 )
 
@@ -37,3 +38,35 @@ type AsyncQueryService struct {
 func (s *AsyncQueryService) Query(ctx context.Context, req *query.Request) (flux.Query, error) {
 	return s.QueryF(ctx, req)
 }
+
+// LogService mocks the query LogService for testing.
+type LogService struct {
+	FindLogEntriesF func(ctx context.Context, filter query.LogFilter) ([]*query.LogEntry, error)
+}
+
+// FindLogEntries returns the logged queries matching filter.
+func (s *LogService) FindLogEntries(ctx context.Context, filter query.LogFilter) ([]*query.LogEntry, error) {
+	return s.FindLogEntriesF(ctx, filter)
+}
+
+// ActiveQueryService mocks the query ActiveQueryService for testing.
+type ActiveQueryService struct {
+	FindActiveQueriesF   func(ctx context.Context, filter query.ActiveQueryFilter) ([]*query.ActiveQuery, error)
+	FindActiveQueryByIDF func(ctx context.Context, id platform.ID) (*query.ActiveQuery, error)
+	CancelQueryF         func(ctx context.Context, id platform.ID) error
+}
+
+// FindActiveQueries returns the queries in flight matching filter.
+func (s *ActiveQueryService) FindActiveQueries(ctx context.Context, filter query.ActiveQueryFilter) ([]*query.ActiveQuery, error) {
+	return s.FindActiveQueriesF(ctx, filter)
+}
+
+// FindActiveQueryByID returns a single query in flight.
+func (s *ActiveQueryService) FindActiveQueryByID(ctx context.Context, id platform.ID) (*query.ActiveQuery, error) {
+	return s.FindActiveQueryByIDF(ctx, id)
+}
+
+// CancelQuery cancels a query in flight.
+func (s *ActiveQueryService) CancelQuery(ctx context.Context, id platform.ID) error {
+	return s.CancelQueryF(ctx, id)
+}
@@ -0,0 +1,627 @@
+package promql
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/values"
+)
+
+// BinaryKind is the transformation that applies a PromQL binary operator to the
+// samples of two instant vectors or of an instant vector and a scalar. It is not
+// registered as a Flux function.
+const BinaryKind = "promqlBinary"
+
+func init() {
+	flux.RegisterOpSpec(BinaryKind, newBinaryOp)
+	plan.RegisterProcedureSpec(BinaryKind, newBinaryProcedure, BinaryKind)
+	execute.RegisterTransformation(BinaryKind, createBinaryTransformation)
+}
+
+// arithmeticOperators are the binary operators that calculate a new value from
+// the values of the operands.
+var arithmeticOperators = map[string]bool{
+	"+": true,
+	"-": true,
+	"*": true,
+	"/": true,
+	"%": true,
+	"^": true,
+}
+
+// comparisonOperators are the binary operators that compare the values of the
+// operands. Unless they return a bool, the samples that are false are removed.
+var comparisonOperators = map[string]bool{
+	"==": true,
+	"!=": true,
+	">":  true,
+	"<":  true,
+	">=": true,
+	"<=": true,
+}
+
+// binaryOp applies the operator to the values. For a comparison, the result is
+// the left value and whether the comparison is true.
+func binaryOp(op string, lhs, rhs float64) (float64, bool) {
+	switch op {
+	case "+":
+		return lhs + rhs, true
+	case "-":
+		return lhs - rhs, true
+	case "*":
+		return lhs * rhs, true
+	case "/":
+		return lhs / rhs, true
+	case "%":
+		return math.Mod(lhs, rhs), true
+	case "^":
+		return math.Pow(lhs, rhs), true
+	case "==":
+		return lhs, lhs == rhs
+	case "!=":
+		return lhs, lhs != rhs
+	case ">":
+		return lhs, lhs > rhs
+	case "<":
+		return lhs, lhs < rhs
+	case ">=":
+		return lhs, lhs >= rhs
+	case "<=":
+		return lhs, lhs <= rhs
+	}
+	panic(fmt.Sprintf("unknown binary operator %q", op))
+}
+
+// boolValue returns 1 if the comparison is true and 0 otherwise.
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// BinaryOpSpec applies the operator to the samples of the vectors at each time.
+// When one of the operands is a number, its operation is empty and the other
+// operation is the only parent.
+type BinaryOpSpec struct {
+	Operator string `json:"operator"`
+	// ReturnBool is set when a comparison results in 0 or 1 instead of
+	// removing the samples that are false.
+	ReturnBool bool             `json:"returnBool"`
+	LHS        flux.OperationID `json:"lhs"`
+	RHS        flux.OperationID `json:"rhs"`
+	Scalar     float64          `json:"scalar"`
+	// ScalarLHS and ScalarRHS are set when the operation on that side is a
+	// scalar that changes with the time of the evaluation, such as time().
+	ScalarLHS bool `json:"scalarLHS"`
+	ScalarRHS bool `json:"scalarRHS"`
+	// Matching is how the samples of two vectors are matched. If it is not
+	// set, the samples with the same labels are matched one-to-one.
+	Matching *VectorMatching `json:"matching"`
+}
+
+func newBinaryOp() flux.OperationSpec {
+	return new(BinaryOpSpec)
+}
+
+func (s *BinaryOpSpec) Kind() flux.OperationKind {
+	return BinaryKind
+}
+
+type BinaryProcedureSpec struct {
+	Operator   string
+	ReturnBool bool
+	// LHS and RHS are the parents. The side that is a number is the zero id.
+	LHS       plan.ProcedureID
+	RHS       plan.ProcedureID
+	Scalar    float64
+	ScalarLHS bool
+	ScalarRHS bool
+	Matching  *VectorMatching
+}
+
+func newBinaryProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*BinaryOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	} else if !arithmeticOperators[spec.Operator] && !comparisonOperators[spec.Operator] {
+		return nil, fmt.Errorf("unknown binary operator %q", spec.Operator)
+	} else if spec.LHS == "" && spec.RHS == "" {
+		return nil, fmt.Errorf("binary operator %s requires a vector", spec.Operator)
+	}
+
+	p := &BinaryProcedureSpec{
+		Operator:   spec.Operator,
+		ReturnBool: spec.ReturnBool,
+		Scalar:     spec.Scalar,
+		ScalarLHS:  spec.ScalarLHS,
+		ScalarRHS:  spec.ScalarRHS,
+		Matching:   spec.Matching,
+	}
+	if spec.LHS != "" {
+		p.LHS = pa.ConvertID(spec.LHS)
+	}
+	if spec.RHS != "" {
+		p.RHS = pa.ConvertID(spec.RHS)
+	}
+	return p, nil
+}
+
+func (s *BinaryProcedureSpec) Kind() plan.ProcedureKind {
+	return BinaryKind
+}
+
+func (s *BinaryProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	if s.Matching != nil {
+		m := *s.Matching
+		ns.Matching = &m
+	}
+	return &ns
+}
+
+func createBinaryTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*BinaryProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	var lhs, rhs execute.DatasetID
+	if s.LHS != (plan.ProcedureID{}) {
+		lhs = a.ConvertID(s.LHS)
+	}
+	if s.RHS != (plan.ProcedureID{}) {
+		rhs = a.ConvertID(s.RHS)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewBinaryTransformation(d, cache, s, lhs, rhs)
+	return t, d, nil
+}
+
+// element is a sample of a series in an instant vector.
+type element struct {
+	labels map[string]string
+	v      float64
+}
+
+type binaryTransformation struct {
+	mu sync.Mutex
+
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	op         string
+	returnBool bool
+	scalar     float64
+	matching   *VectorMatching
+
+	// lhs and rhs are the parents. The side that is a number is the zero id.
+	lhs, rhs execute.DatasetID
+	// scalarLHS and scalarRHS are set when the parent on that side is a scalar.
+	scalarLHS, scalarRHS bool
+
+	// samples are the elements of each parent by time.
+	samples  map[execute.DatasetID]map[execute.Time][]element
+	finished map[execute.DatasetID]bool
+	done     bool
+}
+
+// NewBinaryTransformation creates a transformation for the parents. If one of the
+// operands is a number, its parent is the zero id.
+func NewBinaryTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *BinaryProcedureSpec, lhs, rhs execute.DatasetID) execute.Transformation {
+	matching := spec.Matching
+	if matching == nil {
+		matching = &VectorMatching{Card: CardOneToOne}
+	}
+	t := &binaryTransformation{
+		d:          d,
+		cache:      cache,
+		op:         spec.Operator,
+		returnBool: spec.ReturnBool,
+		scalar:     spec.Scalar,
+		matching:   matching,
+		lhs:        lhs,
+		rhs:        rhs,
+		scalarLHS:  lhs == (execute.DatasetID{}) || spec.ScalarLHS,
+		scalarRHS:  rhs == (execute.DatasetID{}) || spec.ScalarRHS,
+		samples:    make(map[execute.DatasetID]map[execute.Time][]element, 2),
+		finished:   make(map[execute.DatasetID]bool, 2),
+	}
+	for _, id := range t.parents() {
+		t.samples[id] = make(map[execute.Time][]element)
+	}
+	return t
+}
+
+// parents returns the parents that are not numbers.
+func (t *binaryTransformation) parents() []execute.DatasetID {
+	var parents []execute.DatasetID
+	for _, id := range []execute.DatasetID{t.lhs, t.rhs} {
+		if id != (execute.DatasetID{}) {
+			parents = append(parents, id)
+		}
+	}
+	return parents
+}
+
+func (t *binaryTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return nil
+}
+
+// Process reads the samples of the table. The result is calculated once all of the
+// samples of both operands are read.
+func (t *binaryTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	samples, ok := t.samples[id]
+	if !ok {
+		return fmt.Errorf("unexpected parent %v", id)
+	}
+	timeIdx := execute.ColIdx(execute.DefaultTimeColLabel, tbl.Cols())
+	if timeIdx < 0 {
+		return fmt.Errorf("no %s column found", execute.DefaultTimeColLabel)
+	}
+	valueIdx := execute.ColIdx(execute.DefaultValueColLabel, tbl.Cols())
+	if valueIdx < 0 {
+		return fmt.Errorf("no %s column found", execute.DefaultValueColLabel)
+	}
+
+	return tbl.Do(func(cr flux.ColReader) error {
+		times := cr.Times(timeIdx)
+		for i := 0; i < cr.Len(); i++ {
+			v, err := floatValue(cr, i, valueIdx)
+			if err != nil {
+				return err
+			}
+			labels := make(map[string]string)
+			for j, c := range cr.Cols() {
+				if _, ok := LabelName(c); ok {
+					if s := cr.Strings(j)[i]; s != "" {
+						labels[c.Label] = s
+					}
+				}
+			}
+			samples[times[i]] = append(samples[times[i]], element{labels: labels, v: v})
+		}
+		return nil
+	})
+}
+
+func (t *binaryTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return nil
+}
+
+func (t *binaryTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return nil
+}
+
+func (t *binaryTransformation) Finish(id execute.DatasetID, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.done {
+		return
+	} else if err != nil {
+		t.done = true
+		t.d.Finish(err)
+		return
+	}
+
+	t.finished[id] = true
+	for _, id := range t.parents() {
+		if !t.finished[id] {
+			return
+		}
+	}
+	t.done = true
+	t.d.Finish(t.evaluate())
+}
+
+// evaluate applies the operator to the samples at each time and adds a table for
+// each series of the result.
+func (t *binaryTransformation) evaluate() error {
+	var times []execute.Time
+	seen := make(map[execute.Time]bool)
+	for _, samples := range t.samples {
+		for ts := range samples {
+			if !seen[ts] {
+				seen[ts] = true
+				times = append(times, ts)
+			}
+		}
+	}
+	sort.Slice(times, func(i, j int) bool {
+		return times[i] < times[j]
+	})
+
+	type series struct {
+		labels map[string]string
+		times  []execute.Time
+		values []float64
+	}
+	var result []*series
+	bySignature := make(map[string]*series)
+	names := make(map[string]bool)
+	for _, ts := range times {
+		var vector []element
+		switch {
+		case t.scalarLHS && t.scalarRHS:
+			lhs, lok := t.scalarValue(t.lhs, ts)
+			rhs, rok := t.scalarValue(t.rhs, ts)
+			if !lok || !rok {
+				continue
+			}
+			// The parser only allows comparisons between scalars that return a bool.
+			v, keep := binaryOp(t.op, lhs, rhs)
+			if t.returnBool {
+				v = boolValue(keep)
+			}
+			vector = []element{{labels: map[string]string{}, v: v}}
+		case t.scalarLHS:
+			if scalar, ok := t.scalarValue(t.lhs, ts); ok {
+				vector = t.scalarOp(t.samples[t.rhs][ts], scalar, true)
+			}
+		case t.scalarRHS:
+			if scalar, ok := t.scalarValue(t.rhs, ts); ok {
+				vector = t.scalarOp(t.samples[t.lhs][ts], scalar, false)
+			}
+		default:
+			var err error
+			if vector, err = t.vectorOp(t.samples[t.lhs][ts], t.samples[t.rhs][ts]); err != nil {
+				return err
+			}
+		}
+
+		added := make(map[string]bool, len(vector))
+		for _, e := range vector {
+			sig := signature(e.labels)
+			if added[sig] {
+				return fmt.Errorf("vector cannot contain metrics with the same labelset")
+			}
+			added[sig] = true
+
+			s, ok := bySignature[sig]
+			if !ok {
+				s = &series{labels: e.labels}
+				bySignature[sig] = s
+				result = append(result, s)
+				for name := range e.labels {
+					names[name] = true
+				}
+			}
+			s.times = append(s.times, ts)
+			s.values = append(s.values, e.v)
+		}
+	}
+
+	// Each table has a column for all of the labels of the result so the tables
+	// have the same columns. A label that a series does not have is empty.
+	labels := make([]string, 0, len(names))
+	for name := range names {
+		labels = append(labels, name)
+	}
+	sort.Strings(labels)
+	cols := make([]flux.ColMeta, len(labels))
+	for j, label := range labels {
+		cols[j] = flux.ColMeta{Label: label, Type: flux.TString}
+	}
+
+	for _, s := range result {
+		vs := make([]values.Value, len(labels))
+		for j, label := range labels {
+			vs[j] = values.NewStringValue(s.labels[label])
+		}
+		key := execute.NewGroupKey(cols, vs)
+		builder, created := t.cache.TableBuilder(key)
+		if !created {
+			return fmt.Errorf("vector cannot contain metrics with the same labelset")
+		}
+		execute.AddTableKeyCols(key, builder)
+		timeIdx := builder.AddCol(flux.ColMeta{Label: execute.DefaultTimeColLabel, Type: flux.TTime})
+		valueIdx := builder.AddCol(flux.ColMeta{Label: execute.DefaultValueColLabel, Type: flux.TFloat})
+		for i := range s.times {
+			execute.AppendKeyValues(key, builder)
+			builder.AppendTime(timeIdx, s.times[i])
+			builder.AppendFloat(valueIdx, s.values[i])
+		}
+	}
+	return nil
+}
+
+// scalarValue returns the value of the scalar operand at the time. The value of a
+// number is the same at every time.
+func (t *binaryTransformation) scalarValue(id execute.DatasetID, ts execute.Time) (float64, bool) {
+	if id == (execute.DatasetID{}) {
+		return t.scalar, true
+	}
+	samples := t.samples[id][ts]
+	if len(samples) != 1 {
+		return 0, false
+	}
+	return samples[0].v, true
+}
+
+// scalarOp applies the operator to each element of the vector and the scalar.
+func (t *binaryTransformation) scalarOp(vector []element, scalar float64, scalarLeft bool) []element {
+	result := make([]element, 0, len(vector))
+	for _, e := range vector {
+		lhs, rhs := e.v, scalar
+		if scalarLeft {
+			lhs, rhs = rhs, lhs
+		}
+		v, keep := binaryOp(t.op, lhs, rhs)
+		if comparisonOperators[t.op] && scalarLeft {
+			// A comparison keeps the value of the vector even if it is on the right.
+			v = rhs
+		}
+		if t.returnBool {
+			v, keep = boolValue(keep), true
+		}
+		if !keep {
+			continue
+		}
+		result = append(result, element{labels: t.dropMetricName(e.labels), v: v})
+	}
+	return result
+}
+
+// vectorOp applies the operator to the elements of the vectors that match.
+// This is the same as the vector matching in Prometheus.
+func (t *binaryTransformation) vectorOp(lhs, rhs []element) ([]element, error) {
+	m := t.matching
+	if m.Card == CardOneToMany {
+		// The many side is always on the left.
+		lhs, rhs = rhs, lhs
+	}
+
+	// Each element of the one side must have a unique signature.
+	rightSigs := make(map[string]element, len(rhs))
+	for _, rs := range rhs {
+		sig := m.signature(rs.labels)
+		if _, ok := rightSigs[sig]; ok {
+			side := "right"
+			if m.Card == CardOneToMany {
+				side = "left"
+			}
+			return nil, fmt.Errorf("found duplicate series for the match group %s on the %s hand-side of the operation; many-to-many matching not allowed: matching labels must be unique on one side", sig, side)
+		}
+		rightSigs[sig] = rs
+	}
+
+	// matched are the signatures of the results for each signature that matched.
+	matched := make(map[string]map[string]bool)
+	result := make([]element, 0, len(lhs))
+	for _, ls := range lhs {
+		sig := m.signature(ls.labels)
+		rs, ok := rightSigs[sig]
+		if !ok {
+			continue
+		}
+
+		vl, vr := ls.v, rs.v
+		if m.Card == CardOneToMany {
+			vl, vr = vr, vl
+		}
+		v, keep := binaryOp(t.op, vl, vr)
+		if t.returnBool {
+			v, keep = boolValue(keep), true
+		}
+		if !keep {
+			continue
+		}
+		labels := t.resultLabels(ls.labels, rs.labels)
+
+		results, exists := matched[sig]
+		if m.Card == CardOneToOne {
+			if exists {
+				return nil, fmt.Errorf("multiple matches for labels: many-to-one matching must be explicit (group_left/group_right)")
+			}
+			matched[sig] = nil
+		} else {
+			resultSig := signature(labels)
+			if !exists {
+				results = make(map[string]bool)
+				matched[sig] = results
+			} else if results[resultSig] {
+				return nil, fmt.Errorf("multiple matches for labels: grouping labels must ensure unique matches")
+			}
+			results[resultSig] = true
+		}
+		result = append(result, element{labels: labels, v: v})
+	}
+	return result, nil
+}
+
+// dropMetricName removes the metric name from the labels if the result of the
+// operator is not the value of the metric.
+func (t *binaryTransformation) dropMetricName(labels map[string]string) map[string]string {
+	if !arithmeticOperators[t.op] && !t.returnBool {
+		return labels
+	}
+	result := make(map[string]string, len(labels))
+	for name, v := range labels {
+		if name != MetricNameColumn {
+			result[name] = v
+		}
+	}
+	return result
+}
+
+// resultLabels returns the labels of the result of matching the elements with
+// the labels. The lhs is the many side of the match.
+func (t *binaryTransformation) resultLabels(lhs, rhs map[string]string) map[string]string {
+	m := t.matching
+	result := make(map[string]string, len(lhs))
+	for name, v := range t.dropMetricName(lhs) {
+		result[name] = v
+	}
+	if m.Card == CardOneToOne {
+		for name := range result {
+			if m.On != contains(m.MatchingLabels, name) {
+				delete(result, name)
+			}
+		}
+		return result
+	}
+
+	// The labels in the group modifier are from the one side.
+	for _, name := range m.Include {
+		if v := rhs[name]; v != "" {
+			result[name] = v
+		} else {
+			delete(result, name)
+		}
+	}
+	return result
+}
+
+// signature returns the labels that are matched as a string. The metric name is
+// only matched if it is listed with on().
+func (m *VectorMatching) signature(labels map[string]string) string {
+	matched := make(map[string]string, len(labels))
+	for name, v := range labels {
+		if m.On == contains(m.MatchingLabels, name) && (m.On || name != MetricNameColumn) {
+			matched[name] = v
+		}
+	}
+	return signature(matched)
+}
+
+// signature returns the labels as a string with the labels sorted by name.
+func signature(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(labels[name]))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
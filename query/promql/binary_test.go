@@ -0,0 +1,289 @@
+package promql
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+)
+
+// vector creates a table for a series with the labels, which are pairs of names and
+// values, and a point at each time starting at zero.
+func vector(labels []string, values ...float64) *executetest.Table {
+	tbl := new(executetest.Table)
+	var key []interface{}
+	for i := 0; i < len(labels); i += 2 {
+		tbl.KeyCols = append(tbl.KeyCols, labels[i])
+		tbl.ColMeta = append(tbl.ColMeta, flux.ColMeta{Label: labels[i], Type: flux.TString})
+		key = append(key, labels[i+1])
+	}
+	tbl.ColMeta = append(tbl.ColMeta,
+		flux.ColMeta{Label: "_time", Type: flux.TTime},
+		flux.ColMeta{Label: "_value", Type: flux.TFloat},
+	)
+	for i, v := range values {
+		row := append(append([]interface{}{}, key...), execute.Time(i), v)
+		tbl.Data = append(tbl.Data, row)
+	}
+	return tbl
+}
+
+// processBinaryTestHelper is like executetest.ProcessTestHelper for the two parents
+// of a binary operator. If the lhs or rhs is nil, that operand is a scalar.
+func processBinaryTestHelper(t *testing.T, spec *BinaryProcedureSpec, lhs, rhs []flux.Table, want []*executetest.Table, wantErr error) {
+	t.Helper()
+
+	d := executetest.NewDataset(executetest.RandomDatasetID())
+	c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+	c.SetTriggerSpec(execute.DefaultTriggerSpec)
+
+	var lhsID, rhsID execute.DatasetID
+	if lhs != nil {
+		lhsID = executetest.RandomDatasetID()
+	}
+	if rhs != nil {
+		rhsID = executetest.RandomDatasetID()
+	}
+	tx := NewBinaryTransformation(d, c, spec, lhsID, rhsID)
+
+	for _, parent := range []struct {
+		id     execute.DatasetID
+		tables []flux.Table
+	}{
+		{id: lhsID, tables: lhs},
+		{id: rhsID, tables: rhs},
+	} {
+		if parent.id == (execute.DatasetID{}) {
+			continue
+		}
+		for _, tbl := range parent.tables {
+			if err := tx.Process(parent.id, tbl); err != nil {
+				t.Fatal(err)
+			}
+		}
+		tx.Finish(parent.id, nil)
+	}
+
+	if !d.Finished {
+		t.Fatal("expected the transformation to finish")
+	} else if wantErr != nil || d.FinishedErr != nil {
+		if !cmp.Equal(wantErr, d.FinishedErr, cmp.Comparer(func(x, y error) bool {
+			return x.Error() == y.Error()
+		})) {
+			t.Fatalf("unexpected error -want/+got\n%s", cmp.Diff(wantErr, d.FinishedErr))
+		}
+		return
+	}
+
+	got, err := executetest.TablesFromCache(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	executetest.NormalizeTables(got)
+	executetest.NormalizeTables(want)
+	sort.Sort(executetest.SortedTables(got))
+	sort.Sort(executetest.SortedTables(want))
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestBinary_Process(t *testing.T) {
+	// The test cases are like the operator tests in Prometheus.
+	testCases := []struct {
+		name    string
+		spec    *BinaryProcedureSpec
+		lhs     []flux.Table
+		rhs     []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "vector times scalar",
+			spec: &BinaryProcedureSpec{Operator: "*", Scalar: 2},
+			lhs: []flux.Table{
+				vector([]string{"_measurement", "up", "job", "api"}, 1, 2),
+			},
+			want: []*executetest.Table{
+				vector([]string{"job", "api"}, 2, 4),
+			},
+		},
+		{
+			name: "scalar comparison keeps the vector value",
+			spec: &BinaryProcedureSpec{Operator: "<", Scalar: 2},
+			rhs: []flux.Table{
+				vector([]string{"_measurement", "up", "job", "api"}, 3, 1),
+			},
+			want: []*executetest.Table{
+				vector([]string{"_measurement", "up", "job", "api"}, 3),
+			},
+		},
+		{
+			name: "comparison with bool",
+			spec: &BinaryProcedureSpec{Operator: "==", ReturnBool: true, Scalar: 1},
+			lhs: []flux.Table{
+				vector([]string{"_measurement", "up", "job", "api"}, 1, 0),
+			},
+			want: []*executetest.Table{
+				vector([]string{"job", "api"}, 1, 0),
+			},
+		},
+		{
+			name: "vector times scalar expression",
+			spec: &BinaryProcedureSpec{Operator: "*", ScalarRHS: true},
+			lhs: []flux.Table{
+				vector([]string{"_measurement", "up", "job", "api"}, 1, 2),
+			},
+			rhs: []flux.Table{
+				vector(nil, 2, 4),
+			},
+			want: []*executetest.Table{
+				vector([]string{"job", "api"}, 2, 8),
+			},
+		},
+		{
+			name: "scalar expression comparison keeps the vector value",
+			spec: &BinaryProcedureSpec{Operator: "<", ScalarLHS: true},
+			lhs: []flux.Table{
+				vector(nil, 2, 2),
+			},
+			rhs: []flux.Table{
+				vector([]string{"_measurement", "up", "job", "api"}, 3, 1),
+			},
+			want: []*executetest.Table{
+				vector([]string{"_measurement", "up", "job", "api"}, 3),
+			},
+		},
+		{
+			name: "scalar expressions",
+			spec: &BinaryProcedureSpec{Operator: ">", ReturnBool: true, ScalarLHS: true, ScalarRHS: true},
+			lhs: []flux.Table{
+				vector(nil, 1, 4),
+			},
+			rhs: []flux.Table{
+				vector(nil, 2, 3),
+			},
+			want: []*executetest.Table{
+				vector(nil, 0, 1),
+			},
+		},
+		{
+			name: "one-to-one",
+			spec: &BinaryProcedureSpec{Operator: "/"},
+			lhs: []flux.Table{
+				vector([]string{"_measurement", "errors", "job", "api"}, 1),
+				vector([]string{"_measurement", "errors", "job", "db"}, 3),
+				vector([]string{"_measurement", "errors", "job", "web"}, 5),
+			},
+			rhs: []flux.Table{
+				vector([]string{"_measurement", "requests", "job", "api"}, 10),
+				vector([]string{"_measurement", "requests", "job", "db"}, 4),
+			},
+			want: []*executetest.Table{
+				vector([]string{"job", "api"}, 0.1),
+				vector([]string{"job", "db"}, 0.75),
+			},
+		},
+		{
+			name: "one-to-one ignoring",
+			spec: &BinaryProcedureSpec{
+				Operator: "-",
+				Matching: &VectorMatching{MatchingLabels: []string{"code"}},
+			},
+			lhs: []flux.Table{
+				vector([]string{"_measurement", "errors", "code", "500", "job", "api"}, 5),
+			},
+			rhs: []flux.Table{
+				vector([]string{"_measurement", "requests", "job", "api"}, 8),
+			},
+			want: []*executetest.Table{
+				vector([]string{"job", "api"}, -3),
+			},
+		},
+		{
+			name: "many-to-one",
+			spec: &BinaryProcedureSpec{
+				Operator: "/",
+				Matching: &VectorMatching{
+					Card:           CardManyToOne,
+					On:             true,
+					MatchingLabels: []string{"job"},
+				},
+			},
+			lhs: []flux.Table{
+				vector([]string{"_measurement", "errors", "code", "500", "job", "api"}, 1),
+				vector([]string{"_measurement", "errors", "code", "404", "job", "api"}, 2),
+			},
+			rhs: []flux.Table{
+				vector([]string{"_measurement", "requests", "job", "api"}, 4),
+			},
+			want: []*executetest.Table{
+				vector([]string{"code", "500", "job", "api"}, 0.25),
+				vector([]string{"code", "404", "job", "api"}, 0.5),
+			},
+		},
+		{
+			name: "one-to-many with included labels",
+			spec: &BinaryProcedureSpec{
+				Operator: "*",
+				Matching: &VectorMatching{
+					Card:           CardOneToMany,
+					On:             true,
+					MatchingLabels: []string{"job"},
+					Include:        []string{"version"},
+				},
+			},
+			lhs: []flux.Table{
+				vector([]string{"_measurement", "build_info", "job", "api", "version", "1.0"}, 1),
+			},
+			rhs: []flux.Table{
+				vector([]string{"_measurement", "up", "instance", "a", "job", "api"}, 1),
+				vector([]string{"_measurement", "up", "instance", "b", "job", "api"}, 0),
+			},
+			want: []*executetest.Table{
+				vector([]string{"instance", "a", "job", "api", "version", "1.0"}, 1),
+				vector([]string{"instance", "b", "job", "api", "version", "1.0"}, 0),
+			},
+		},
+		{
+			name: "many-to-many",
+			spec: &BinaryProcedureSpec{
+				Operator: "+",
+				Matching: &VectorMatching{On: true, MatchingLabels: []string{"job"}},
+			},
+			lhs: []flux.Table{
+				vector([]string{"_measurement", "up", "job", "api"}, 1),
+			},
+			rhs: []flux.Table{
+				vector([]string{"_measurement", "errors", "code", "500", "job", "api"}, 1),
+				vector([]string{"_measurement", "errors", "code", "404", "job", "api"}, 2),
+			},
+			wantErr: errors.New(`found duplicate series for the match group {job="api"} on the right hand-side of the operation; many-to-many matching not allowed: matching labels must be unique on one side`),
+		},
+		{
+			name: "many-to-one must be explicit",
+			spec: &BinaryProcedureSpec{
+				Operator: "+",
+				Matching: &VectorMatching{On: true, MatchingLabels: []string{"job"}},
+			},
+			lhs: []flux.Table{
+				vector([]string{"_measurement", "errors", "code", "500", "job", "api"}, 1),
+				vector([]string{"_measurement", "errors", "code", "404", "job", "api"}, 2),
+			},
+			rhs: []flux.Table{
+				vector([]string{"_measurement", "up", "job", "api"}, 1),
+			},
+			wantErr: errors.New("multiple matches for labels: many-to-one matching must be explicit (group_left/group_right)"),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			processBinaryTestHelper(t, tc.spec, tc.lhs, tc.rhs, tc.want, tc.wantErr)
+		})
+	}
+}
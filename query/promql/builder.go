@@ -0,0 +1,107 @@
+package promql
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/functions/inputs"
+)
+
+// expression is a PromQL expression that adds the operations that evaluate it to
+// a query specification.
+type expression interface {
+	// build adds the operations of the expression and returns the operation
+	// with its result.
+	build(b *builder) (flux.OperationID, error)
+}
+
+// builder creates a query specification from the operations of an expression.
+type builder struct {
+	config Config
+	spec   *flux.Spec
+	ids    map[flux.OperationID]bool
+}
+
+func newBuilder(config Config) *builder {
+	return &builder{
+		config: config,
+		spec:   new(flux.Spec),
+		ids:    make(map[flux.OperationID]bool),
+	}
+}
+
+// build returns the query specification for the expression.
+func (b *builder) build(expr expression) (*flux.Spec, error) {
+	if _, err := expr.build(b); err != nil {
+		return nil, err
+	}
+	return b.spec, nil
+}
+
+// add adds the operation as a child of the parents. If an operation already has
+// its id, a number is appended to the id so that it is unique.
+func (b *builder) add(op *flux.Operation, parents ...flux.OperationID) flux.OperationID {
+	id := op.ID
+	for i := 1; b.ids[id]; i++ {
+		id = op.ID + flux.OperationID(strconv.Itoa(i))
+	}
+	op.ID = id
+	b.ids[id] = true
+
+	b.spec.Operations = append(b.spec.Operations, op)
+	for _, parent := range parents {
+		b.spec.Edges = append(b.spec.Edges, flux.Edge{
+			Parent: parent,
+			Child:  id,
+		})
+	}
+	return id
+}
+
+// chain adds the operations so that each is the child of the one before it.
+func (b *builder) chain(parent flux.OperationID, ops ...*flux.Operation) flux.OperationID {
+	for _, op := range ops {
+		parent = b.add(op, parent)
+	}
+	return parent
+}
+
+// from adds the operation that reads the series from storage.
+func (b *builder) from() flux.OperationID {
+	spec := &inputs.FromOpSpec{
+		Bucket: "prometheus",
+	}
+	if b.config.BucketID.Valid() {
+		spec = &inputs.FromOpSpec{
+			BucketID: b.config.BucketID.String(),
+		}
+	}
+	return b.add(&flux.Operation{
+		ID:   "from", // TODO: Change this to a UUID
+		Spec: spec,
+	})
+}
+
+// evaluation returns the times when the expression is evaluated. If the
+// configuration has no end, the expression is evaluated once now.
+func (b *builder) evaluation() (start, stop flux.Time, step flux.Duration) {
+	if b.config.End.IsZero() {
+		return flux.Now, flux.Now, 0
+	}
+	begin := b.config.Start
+	if begin.IsZero() {
+		begin = b.config.End
+	}
+	return flux.Time{Absolute: begin}, flux.Time{Absolute: b.config.End}, flux.Duration(b.config.Step)
+}
+
+// shift returns the time moved by the duration.
+func shift(t flux.Time, d time.Duration) flux.Time {
+	if t.IsRelative {
+		t.Relative += d
+		return t
+	}
+	t.Absolute = t.Absolute.Add(d)
+	return t
+}
@@ -0,0 +1,685 @@
+package promql
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+// RangeFunctionKind is the transformation that evaluates a PromQL function over the
+// points of each series within a range vector. It is not registered as a Flux function.
+const RangeFunctionKind = "promqlRangeFunction"
+
+// InstantFunctionKind is the transformation that applies a PromQL function to the
+// value of each sample in an instant vector. It is not registered as a Flux function.
+const InstantFunctionKind = "promqlInstantFunction"
+
+func init() {
+	flux.RegisterOpSpec(RangeFunctionKind, newRangeFunctionOp)
+	plan.RegisterProcedureSpec(RangeFunctionKind, newRangeFunctionProcedure, RangeFunctionKind)
+	execute.RegisterTransformation(RangeFunctionKind, createRangeFunctionTransformation)
+
+	flux.RegisterOpSpec(InstantFunctionKind, newInstantFunctionOp)
+	plan.RegisterProcedureSpec(InstantFunctionKind, newInstantFunctionProcedure, InstantFunctionKind)
+	execute.RegisterTransformation(InstantFunctionKind, createInstantFunctionTransformation)
+}
+
+// sample is a point from a series.
+type sample struct {
+	t execute.Time
+	v float64
+}
+
+// rangeFunc evaluates a function over the samples of a series that are sorted by time.
+// The start and stop are the bounds of the range vector. If there is no result for
+// the series, this returns false.
+type rangeFunc func(samples []sample, start, stop execute.Time) (float64, bool)
+
+// rangeFunctions are the functions that can be evaluated over a range vector.
+// The results are the same as the functions in Prometheus.
+var rangeFunctions = map[string]struct {
+	fn rangeFunc
+	// bounded is set when the function uses the bounds of the range.
+	bounded bool
+}{
+	"rate":             {fn: extrapolatedRate(true, true), bounded: true},
+	"increase":         {fn: extrapolatedRate(true, false), bounded: true},
+	"delta":            {fn: extrapolatedRate(false, false), bounded: true},
+	"irate":            {fn: instantRate},
+	"deriv":            {fn: deriv},
+	"avg_over_time":    {fn: avgOverTime},
+	"min_over_time":    {fn: minOverTime},
+	"max_over_time":    {fn: maxOverTime},
+	"sum_over_time":    {fn: sumOverTime},
+	"count_over_time":  {fn: countOverTime},
+	"last_over_time":   {fn: lastOverTime},
+	"stddev_over_time": {fn: stddevOverTime},
+	"stdvar_over_time": {fn: stdvarOverTime},
+}
+
+// RangeFunctionOpSpec evaluates the function over the points of each table.
+//
+// If the range is set, the function is evaluated at each step between the start
+// and stop over the points within the range before that time. The result is a table
+// for each series with the time of each evaluation and the result of the function
+// in the value column. Otherwise, the function is evaluated once over all of the
+// points and produces a table with the group key and the result.
+type RangeFunctionOpSpec struct {
+	Function string        `json:"function"`
+	Range    flux.Duration `json:"range"`
+	Offset   flux.Duration `json:"offset"`
+	Start    flux.Time     `json:"start"`
+	Stop     flux.Time     `json:"stop"`
+	Step     flux.Duration `json:"step"`
+}
+
+func newRangeFunctionOp() flux.OperationSpec {
+	return new(RangeFunctionOpSpec)
+}
+
+func (s *RangeFunctionOpSpec) Kind() flux.OperationKind {
+	return RangeFunctionKind
+}
+
+type RangeFunctionProcedureSpec struct {
+	Function string
+	Range    flux.Duration
+	Offset   flux.Duration
+	Start    flux.Time
+	Stop     flux.Time
+	Step     flux.Duration
+}
+
+func newRangeFunctionProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*RangeFunctionOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	f, ok := rangeFunctions[spec.Function]
+	if !ok {
+		return nil, fmt.Errorf("unknown range function %q", spec.Function)
+	} else if f.bounded && spec.Range <= 0 {
+		return nil, fmt.Errorf("%s requires a range", spec.Function)
+	}
+	return &RangeFunctionProcedureSpec{
+		Function: spec.Function,
+		Range:    spec.Range,
+		Offset:   spec.Offset,
+		Start:    spec.Start,
+		Stop:     spec.Stop,
+		Step:     spec.Step,
+	}, nil
+}
+
+func (s *RangeFunctionProcedureSpec) Kind() plan.ProcedureKind {
+	return RangeFunctionKind
+}
+
+func (s *RangeFunctionProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createRangeFunctionTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*RangeFunctionProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t, err := NewRangeFunctionTransformation(d, cache, s, a.ResolveTime(s.Start), a.ResolveTime(s.Stop))
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, d, nil
+}
+
+type rangeFunctionTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	name string
+	fn   rangeFunc
+
+	rng, offset, step execute.Duration
+	start, stop       execute.Time
+}
+
+// NewRangeFunctionTransformation creates a transformation that evaluates the function
+// at each step from the start to the stop.
+func NewRangeFunctionTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *RangeFunctionProcedureSpec, start, stop execute.Time) (execute.Transformation, error) {
+	f, ok := rangeFunctions[spec.Function]
+	if !ok {
+		return nil, fmt.Errorf("unknown range function %q", spec.Function)
+	}
+	return &rangeFunctionTransformation{
+		d:      d,
+		cache:  cache,
+		name:   spec.Function,
+		fn:     f.fn,
+		rng:    execute.Duration(spec.Range),
+		offset: execute.Duration(spec.Offset),
+		step:   execute.Duration(spec.Step),
+		start:  start,
+		stop:   stop,
+	}, nil
+}
+
+func (t *rangeFunctionTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *rangeFunctionTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	timeIdx := execute.ColIdx(execute.DefaultTimeColLabel, tbl.Cols())
+	if timeIdx < 0 {
+		return fmt.Errorf("no %s column found", execute.DefaultTimeColLabel)
+	}
+	valueIdx := execute.ColIdx(execute.DefaultValueColLabel, tbl.Cols())
+	if valueIdx < 0 {
+		return fmt.Errorf("no %s column found", execute.DefaultValueColLabel)
+	}
+
+	var samples []sample
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		times := cr.Times(timeIdx)
+		for i := 0; i < cr.Len(); i++ {
+			f, err := floatValue(cr, i, valueIdx)
+			if err != nil {
+				return fmt.Errorf("%s: %v", t.name, err)
+			}
+			samples = append(samples, sample{t: times[i], v: f})
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].t < samples[j].t
+	})
+
+	if t.rng <= 0 {
+		builder, created := t.cache.TableBuilder(tbl.Key())
+		if !created {
+			return fmt.Errorf("%s found duplicate table with key: %v", t.name, tbl.Key())
+		}
+		execute.AddTableKeyCols(tbl.Key(), builder)
+		valueIdx := builder.AddCol(flux.ColMeta{Label: execute.DefaultValueColLabel, Type: flux.TFloat})
+		if v, ok := t.fn(samples, 0, 0); ok {
+			execute.AppendKeyValues(tbl.Key(), builder)
+			builder.AppendFloat(valueIdx, v)
+		}
+		return nil
+	}
+
+	// The bounds of the table do not apply to the result of each evaluation.
+	key := withoutKeyCols(tbl.Key(), execute.DefaultStartColLabel, execute.DefaultStopColLabel)
+	builder, created := t.cache.TableBuilder(key)
+	if !created {
+		return fmt.Errorf("%s found duplicate table with key: %v", t.name, key)
+	}
+	execute.AddTableKeyCols(key, builder)
+	timeIdx = builder.AddCol(flux.ColMeta{Label: execute.DefaultTimeColLabel, Type: flux.TTime})
+	valueIdx = builder.AddCol(flux.ColMeta{Label: execute.DefaultValueColLabel, Type: flux.TFloat})
+	for ts := t.start; ts <= t.stop; ts += execute.Time(t.step) {
+		// The range includes the points at both of its bounds.
+		stop := ts - execute.Time(t.offset)
+		start := stop - execute.Time(t.rng)
+		i := sort.Search(len(samples), func(i int) bool {
+			return samples[i].t >= start
+		})
+		j := sort.Search(len(samples), func(j int) bool {
+			return samples[j].t > stop
+		})
+		if v, ok := t.fn(samples[i:j], start, stop); ok {
+			execute.AppendKeyValues(key, builder)
+			builder.AppendTime(timeIdx, ts)
+			builder.AppendFloat(valueIdx, v)
+		}
+		if t.step <= 0 {
+			break
+		}
+	}
+	return nil
+}
+
+func (t *rangeFunctionTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+
+func (t *rangeFunctionTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *rangeFunctionTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+// seconds converts a duration in nanoseconds to seconds.
+func seconds(d execute.Time) float64 {
+	return float64(d) / 1e9
+}
+
+// extrapolatedRate calculates the increase of the samples and extrapolates it to the
+// bounds of the range. If the samples are a counter, any resets are accounted for.
+// If it is a rate, the result is per second. This is the same as rate(), increase()
+// and delta() in Prometheus.
+func extrapolatedRate(isCounter, isRate bool) rangeFunc {
+	return func(samples []sample, start, stop execute.Time) (float64, bool) {
+		// No sensible result if there are fewer than two samples.
+		if len(samples) < 2 {
+			return 0, false
+		}
+
+		var counterCorrection, lastValue float64
+		for _, s := range samples {
+			if isCounter && s.v < lastValue {
+				counterCorrection += lastValue
+			}
+			lastValue = s.v
+		}
+		first, last := samples[0], samples[len(samples)-1]
+		resultValue := lastValue - first.v + counterCorrection
+
+		// Duration between the first and last samples and the bounds of the range.
+		durationToStart := seconds(first.t - start)
+		durationToEnd := seconds(stop - last.t)
+
+		sampledInterval := seconds(last.t - first.t)
+		averageDurationBetweenSamples := sampledInterval / float64(len(samples)-1)
+
+		if isCounter && resultValue > 0 && first.v >= 0 {
+			// A counter cannot be negative. If extrapolating to the start of the
+			// range would go below zero, stop extrapolating at zero instead.
+			durationToZero := sampledInterval * (first.v / resultValue)
+			if durationToZero < durationToStart {
+				durationToStart = durationToZero
+			}
+		}
+
+		// If the first or last samples are close to the bounds of the range,
+		// extrapolate to the bounds. Otherwise, extrapolate by half of the
+		// average duration between samples.
+		extrapolationThreshold := averageDurationBetweenSamples * 1.1
+		extrapolateToInterval := sampledInterval
+		if durationToStart < extrapolationThreshold {
+			extrapolateToInterval += durationToStart
+		} else {
+			extrapolateToInterval += averageDurationBetweenSamples / 2
+		}
+		if durationToEnd < extrapolationThreshold {
+			extrapolateToInterval += durationToEnd
+		} else {
+			extrapolateToInterval += averageDurationBetweenSamples / 2
+		}
+		resultValue = resultValue * (extrapolateToInterval / sampledInterval)
+		if isRate {
+			resultValue = resultValue / seconds(stop-start)
+		}
+		return resultValue, true
+	}
+}
+
+// instantRate calculates the per-second rate of the last two samples. This is the
+// same as irate() in Prometheus.
+func instantRate(samples []sample, start, stop execute.Time) (float64, bool) {
+	// No sensible result if there are fewer than two samples.
+	if len(samples) < 2 {
+		return 0, false
+	}
+
+	last, previous := samples[len(samples)-1], samples[len(samples)-2]
+	resultValue := last.v - previous.v
+	if last.v < previous.v {
+		// The counter was reset.
+		resultValue = last.v
+	}
+
+	sampledInterval := last.t - previous.t
+	if sampledInterval == 0 {
+		// Avoid dividing by zero.
+		return 0, false
+	}
+	return resultValue / seconds(sampledInterval), true
+}
+
+// deriv calculates the per-second derivative of the samples using a simple linear
+// regression. This is the same as deriv() in Prometheus.
+func deriv(samples []sample, start, stop execute.Time) (float64, bool) {
+	// No sensible result if there are fewer than two samples.
+	if len(samples) < 2 {
+		return 0, false
+	}
+
+	// The time of the first sample is used as the intercept to avoid
+	// floating point accuracy issues with large timestamps.
+	interceptTime := samples[0].t
+	var n, sumX, sumY, sumXY, sumX2 float64
+	for _, s := range samples {
+		x := seconds(s.t - interceptTime)
+		n += 1
+		sumY += s.v
+		sumX += x
+		sumXY += x * s.v
+		sumX2 += x * x
+	}
+	covXY := sumXY - sumX*sumY/n
+	varX := sumX2 - sumX*sumX/n
+	return covXY / varX, true
+}
+
+func avgOverTime(samples []sample, start, stop execute.Time) (float64, bool) {
+	if len(samples) == 0 {
+		return 0, false
+	}
+	var sum float64
+	for _, s := range samples {
+		sum += s.v
+	}
+	return sum / float64(len(samples)), true
+}
+
+func minOverTime(samples []sample, start, stop execute.Time) (float64, bool) {
+	if len(samples) == 0 {
+		return 0, false
+	}
+	min := math.NaN()
+	for _, s := range samples {
+		if s.v < min || math.IsNaN(min) {
+			min = s.v
+		}
+	}
+	return min, true
+}
+
+func maxOverTime(samples []sample, start, stop execute.Time) (float64, bool) {
+	if len(samples) == 0 {
+		return 0, false
+	}
+	max := math.NaN()
+	for _, s := range samples {
+		if s.v > max || math.IsNaN(max) {
+			max = s.v
+		}
+	}
+	return max, true
+}
+
+func sumOverTime(samples []sample, start, stop execute.Time) (float64, bool) {
+	if len(samples) == 0 {
+		return 0, false
+	}
+	var sum float64
+	for _, s := range samples {
+		sum += s.v
+	}
+	return sum, true
+}
+
+func countOverTime(samples []sample, start, stop execute.Time) (float64, bool) {
+	if len(samples) == 0 {
+		return 0, false
+	}
+	return float64(len(samples)), true
+}
+
+func lastOverTime(samples []sample, start, stop execute.Time) (float64, bool) {
+	if len(samples) == 0 {
+		return 0, false
+	}
+	return samples[len(samples)-1].v, true
+}
+
+func stddevOverTime(samples []sample, start, stop execute.Time) (float64, bool) {
+	v, ok := stdvarOverTime(samples, start, stop)
+	return math.Sqrt(v), ok
+}
+
+// stdvarOverTime calculates the population variance of the samples.
+func stdvarOverTime(samples []sample, start, stop execute.Time) (float64, bool) {
+	if len(samples) == 0 {
+		return 0, false
+	}
+	var aux, count, mean float64
+	for _, s := range samples {
+		count++
+		delta := s.v - mean
+		mean += delta / count
+		aux += delta * (s.v - mean)
+	}
+	return aux / count, true
+}
+
+// instantFunctions are the functions that are applied to each sample of an instant
+// vector. The arguments are the numbers that follow the vector in the call.
+var instantFunctions = map[string]struct {
+	fn               func(v float64, args []float64) float64
+	minArgs, maxArgs int
+}{
+	"abs":       {fn: mathFunc(math.Abs)},
+	"ceil":      {fn: mathFunc(math.Ceil)},
+	"floor":     {fn: mathFunc(math.Floor)},
+	"exp":       {fn: mathFunc(math.Exp)},
+	"ln":        {fn: mathFunc(math.Log)},
+	"log2":      {fn: mathFunc(math.Log2)},
+	"log10":     {fn: mathFunc(math.Log10)},
+	"sqrt":      {fn: mathFunc(math.Sqrt)},
+	"round":     {fn: round, maxArgs: 1},
+	"clamp_max": {fn: clampMax, minArgs: 1, maxArgs: 1},
+	"clamp_min": {fn: clampMin, minArgs: 1, maxArgs: 1},
+}
+
+func mathFunc(fn func(float64) float64) func(float64, []float64) float64 {
+	return func(v float64, args []float64) float64 {
+		return fn(v)
+	}
+}
+
+// round rounds the value to the nearest multiple of the argument, or to the
+// nearest integer if there is none. Ties are rounded up.
+func round(v float64, args []float64) float64 {
+	toNearest := 1.0
+	if len(args) > 0 {
+		toNearest = args[0]
+	}
+	// Dividing by the inverse is more accurate than multiplying by the argument.
+	toNearestInverse := 1.0 / toNearest
+	return math.Floor(v*toNearestInverse+0.5) / toNearestInverse
+}
+
+func clampMax(v float64, args []float64) float64 {
+	return math.Min(v, args[0])
+}
+
+func clampMin(v float64, args []float64) float64 {
+	return math.Max(v, args[0])
+}
+
+// checkInstantFunction returns an error if the function does not exist or the
+// number of arguments is wrong.
+func checkInstantFunction(name string, args []float64) error {
+	f, ok := instantFunctions[name]
+	if !ok {
+		return fmt.Errorf("unknown function %s", name)
+	} else if len(args) < f.minArgs || len(args) > f.maxArgs {
+		if f.minArgs == f.maxArgs {
+			return fmt.Errorf("expected %d argument(s) in call to %q, got %d", f.minArgs+1, name, len(args)+1)
+		}
+		return fmt.Errorf("expected at most %d argument(s) in call to %q, got %d", f.maxArgs+1, name, len(args)+1)
+	}
+	return nil
+}
+
+// InstantFunctionOpSpec applies the function to the value of each row. The metric
+// name is removed from the result.
+type InstantFunctionOpSpec struct {
+	Function string    `json:"function"`
+	Args     []float64 `json:"args"`
+}
+
+func newInstantFunctionOp() flux.OperationSpec {
+	return new(InstantFunctionOpSpec)
+}
+
+func (s *InstantFunctionOpSpec) Kind() flux.OperationKind {
+	return InstantFunctionKind
+}
+
+type InstantFunctionProcedureSpec struct {
+	Function string
+	Args     []float64
+}
+
+func newInstantFunctionProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*InstantFunctionOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	} else if err := checkInstantFunction(spec.Function, spec.Args); err != nil {
+		return nil, err
+	}
+	return &InstantFunctionProcedureSpec{
+		Function: spec.Function,
+		Args:     spec.Args,
+	}, nil
+}
+
+func (s *InstantFunctionProcedureSpec) Kind() plan.ProcedureKind {
+	return InstantFunctionKind
+}
+
+func (s *InstantFunctionProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	ns.Args = make([]float64, len(s.Args))
+	copy(ns.Args, s.Args)
+	return &ns
+}
+
+func createInstantFunctionTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*InstantFunctionProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t, err := NewInstantFunctionTransformation(d, cache, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, d, nil
+}
+
+type instantFunctionTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	name string
+	fn   func(v float64, args []float64) float64
+	args []float64
+}
+
+func NewInstantFunctionTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *InstantFunctionProcedureSpec) (execute.Transformation, error) {
+	if err := checkInstantFunction(spec.Function, spec.Args); err != nil {
+		return nil, err
+	}
+	return &instantFunctionTransformation{
+		d:     d,
+		cache: cache,
+		name:  spec.Function,
+		fn:    instantFunctions[spec.Function].fn,
+		args:  spec.Args,
+	}, nil
+}
+
+func (t *instantFunctionTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *instantFunctionTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	valueIdx := execute.ColIdx(execute.DefaultValueColLabel, tbl.Cols())
+	if valueIdx < 0 {
+		return fmt.Errorf("no %s column found", execute.DefaultValueColLabel)
+	}
+
+	key := withoutKeyCols(tbl.Key(), MetricNameColumn)
+	builder, created := t.cache.TableBuilder(key)
+	if !created {
+		return fmt.Errorf("%s found duplicate table with key: %v", t.name, key)
+	}
+
+	// cols is the index of the column in the table for each column of the result.
+	cols := make([]int, 0, len(tbl.Cols()))
+	for j, c := range tbl.Cols() {
+		if c.Label == MetricNameColumn {
+			continue
+		} else if j == valueIdx {
+			c.Type = flux.TFloat
+		}
+		builder.AddCol(c)
+		cols = append(cols, j)
+	}
+	return tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			for k, j := range cols {
+				if j != valueIdx {
+					execute.AppendValue(builder, k, execute.ValueForRow(i, j, cr))
+					continue
+				}
+				v, err := floatValue(cr, i, j)
+				if err != nil {
+					return fmt.Errorf("%s: %v", t.name, err)
+				}
+				builder.AppendFloat(k, t.fn(v, t.args))
+			}
+		}
+		return nil
+	})
+}
+
+func (t *instantFunctionTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+
+func (t *instantFunctionTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *instantFunctionTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+// floatValue returns the value of the column for the row as a float.
+func floatValue(cr flux.ColReader, i, j int) (float64, error) {
+	v := execute.ValueForRow(i, j, cr)
+	switch v.Type().Kind() {
+	case semantic.Float:
+		return v.Float(), nil
+	case semantic.Int:
+		return float64(v.Int()), nil
+	case semantic.UInt:
+		return float64(v.UInt()), nil
+	default:
+		return 0, fmt.Errorf("unsupported type for %s: %s", cr.Cols()[j].Label, v.Type())
+	}
+}
+
+// withoutKeyCols returns the group key without the columns.
+func withoutKeyCols(key flux.GroupKey, labels ...string) flux.GroupKey {
+	cols := make([]flux.ColMeta, 0, len(key.Cols()))
+	vs := make([]values.Value, 0, len(key.Cols()))
+COLS:
+	for j, c := range key.Cols() {
+		for _, label := range labels {
+			if c.Label == label {
+				continue COLS
+			}
+		}
+		cols = append(cols, c)
+		vs = append(vs, key.Value(j))
+	}
+	return execute.NewGroupKey(cols, vs)
+}
@@ -0,0 +1,359 @@
+package promql
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+)
+
+// series creates a table with a point every step starting at zero. The bounds of
+// the table are the start and stop, which are the same as an instant query in
+// Prometheus evaluated at the stop with a range of stop - start.
+func series(start, stop, step time.Duration, values ...float64) *executetest.Table {
+	tbl := &executetest.Table{
+		KeyCols: []string{"_start", "_stop"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_start", Type: flux.TTime},
+			{Label: "_stop", Type: flux.TTime},
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+	}
+	for i, v := range values {
+		ts := time.Duration(i) * step
+		if ts < start || ts > stop {
+			continue
+		}
+		tbl.Data = append(tbl.Data, []interface{}{
+			execute.Time(start), execute.Time(stop), execute.Time(ts), v,
+		})
+	}
+	return tbl
+}
+
+// counter creates the values for a counter that starts at zero and increases by the
+// step the number of times. This is the same as 0+<step>x<times> in the Prometheus tests.
+func counter(step float64, times int) []float64 {
+	values := make([]float64, 0, times+1)
+	for i := 0; i <= times; i++ {
+		values = append(values, float64(i)*step)
+	}
+	return values
+}
+
+func TestRangeFunction_Process(t *testing.T) {
+	// The test cases and results are from the function tests in Prometheus.
+	testCases := []struct {
+		name     string
+		function string
+		data     *executetest.Table
+		want     []float64
+	}{
+		{
+			name:     "rate",
+			function: "rate",
+			data:     series(0, 50*time.Minute, 5*time.Minute, counter(80, 10)...),
+			want:     []float64{0.26666666666666666},
+		},
+		{
+			name:     "rate with counter reset",
+			function: "rate",
+			data:     series(0, 50*time.Minute, 5*time.Minute, append(counter(10, 4), counter(10, 5)...)...),
+			want:     []float64{0.03},
+		},
+		{
+			name:     "rate with counter reset at the end",
+			function: "rate",
+			data:     series(45*time.Minute, 50*time.Minute, 5*time.Minute, append(counter(10, 9), 0, 10)...),
+			want:     []float64{0},
+		},
+		{
+			name:     "increase",
+			function: "increase",
+			data:     series(0, 50*time.Minute, 5*time.Minute, counter(10, 10)...),
+			want:     []float64{100},
+		},
+		{
+			name:     "increase with counter reset",
+			function: "increase",
+			data:     series(0, 50*time.Minute, 5*time.Minute, append(counter(10, 5), counter(10, 5)...)...),
+			want:     []float64{90},
+		},
+		{
+			name:     "increase extrapolated to zero",
+			function: "increase",
+			data:     series(-50*time.Minute, 50*time.Minute, 5*time.Minute, counter(10, 10)...),
+			want:     []float64{100},
+		},
+		{
+			name:     "increase with decreasing counter",
+			function: "increase",
+			data:     series(0, 30*time.Minute, 5*time.Minute, 0, 1, 2, 3, 2, 3, 4),
+			want:     []float64{7},
+		},
+		{
+			name:     "irate",
+			function: "irate",
+			data:     series(0, 50*time.Minute, 5*time.Minute, counter(10, 10)...),
+			want:     []float64{0.03333333333333333},
+		},
+		{
+			name:     "irate with counter reset",
+			function: "irate",
+			data:     series(0, 30*time.Minute, 5*time.Minute, append(counter(10, 5), counter(10, 5)...)...),
+			want:     []float64{0},
+		},
+		{
+			name:     "delta",
+			function: "delta",
+			data:     series(0, 20*time.Minute, 5*time.Minute, 0, 50, 100, 150, 200),
+			want:     []float64{200},
+		},
+		{
+			name:     "negative delta",
+			function: "delta",
+			data:     series(0, 20*time.Minute, 5*time.Minute, 200, 150, 100, 50, 0),
+			want:     []float64{-200},
+		},
+		{
+			name:     "deriv",
+			function: "deriv",
+			data:     series(0, 50*time.Minute, 5*time.Minute, counter(80, 10)...),
+			want:     []float64{0.26666666666666666},
+		},
+		{
+			name:     "deriv with counter reset",
+			function: "deriv",
+			data:     series(-50*time.Minute, 50*time.Minute, 5*time.Minute, append(counter(10, 4), counter(10, 5)...)...),
+			want:     []float64{0.010606060606060607},
+		},
+		{
+			name:     "rate with one point",
+			function: "rate",
+			data:     series(0, 5*time.Minute, 5*time.Minute, 1),
+		},
+		{
+			name:     "avg_over_time",
+			function: "avg_over_time",
+			data:     series(0, time.Minute, 10*time.Second, 2, 0, 3),
+			want:     []float64{1.6666666666666667},
+		},
+		{
+			name:     "min_over_time",
+			function: "min_over_time",
+			data:     series(0, time.Minute, 10*time.Second, 2, 0, 3),
+			want:     []float64{0},
+		},
+		{
+			name:     "max_over_time",
+			function: "max_over_time",
+			data:     series(0, time.Minute, 10*time.Second, 2, 0, 3),
+			want:     []float64{3},
+		},
+		{
+			name:     "sum_over_time",
+			function: "sum_over_time",
+			data:     series(0, time.Minute, 10*time.Second, 2, 0, 3),
+			want:     []float64{5},
+		},
+		{
+			name:     "count_over_time",
+			function: "count_over_time",
+			data:     series(0, time.Minute, 10*time.Second, 2, 0, 3),
+			want:     []float64{3},
+		},
+		{
+			name:     "stddev_over_time",
+			function: "stddev_over_time",
+			data:     series(0, time.Minute, 10*time.Second, 0, 8, 8, 2, 3),
+			want:     []float64{math.Sqrt(10.56)},
+		},
+		{
+			name:     "stdvar_over_time",
+			function: "stdvar_over_time",
+			data:     series(0, time.Minute, 10*time.Second, 0, 8, 8, 2, 3),
+			// Prometheus compares the results with a tolerance. The result
+			// in the Prometheus tests is 10.56.
+			want: []float64{10.559999999999999},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			key := tc.data.Key()
+			start, stop := key.ValueTime(0), key.ValueTime(1)
+			want := &executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+			}
+			for _, v := range tc.want {
+				want.Data = append(want.Data, []interface{}{stop, v})
+			}
+
+			executetest.ProcessTestHelper(
+				t,
+				[]flux.Table{tc.data},
+				[]*executetest.Table{want},
+				nil,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					tr, err := NewRangeFunctionTransformation(d, c, &RangeFunctionProcedureSpec{
+						Function: tc.function,
+						Range:    flux.Duration(stop - start),
+					}, stop, stop)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr
+				},
+			)
+		})
+	}
+}
+
+func TestRangeFunction_Process_Steps(t *testing.T) {
+	data := &executetest.Table{
+		KeyCols: []string{"_start", "_stop", "_measurement"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_start", Type: flux.TTime},
+			{Label: "_stop", Type: flux.TTime},
+			{Label: "_measurement", Type: flux.TString},
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(0), execute.Time(100), "up", execute.Time(10), 1.0},
+			{execute.Time(0), execute.Time(100), "up", execute.Time(20), 2.0},
+			{execute.Time(0), execute.Time(100), "up", execute.Time(30), 3.0},
+			{execute.Time(0), execute.Time(100), "up", execute.Time(40), 4.0},
+		},
+	}
+	want := []*executetest.Table{{
+		KeyCols: []string{"_measurement"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_measurement", Type: flux.TString},
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			// The range includes the points at both of its bounds and
+			// there are no points in the range at 70.
+			{"up", execute.Time(20), 1.0},
+			{"up", execute.Time(30), 2.0},
+			{"up", execute.Time(40), 2.0},
+			{"up", execute.Time(50), 2.0},
+			{"up", execute.Time(60), 1.0},
+		},
+	}}
+
+	executetest.ProcessTestHelper(
+		t,
+		[]flux.Table{data},
+		want,
+		nil,
+		func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+			tr, err := NewRangeFunctionTransformation(d, c, &RangeFunctionProcedureSpec{
+				Function: "count_over_time",
+				Range:    10,
+				Offset:   10,
+				Step:     10,
+			}, 20, 70)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return tr
+		},
+	)
+}
+
+func TestInstantFunction_Process(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function string
+		args     []float64
+		values   []float64
+		want     []float64
+	}{
+		{
+			name:     "abs",
+			function: "abs",
+			values:   []float64{-1.5, 2},
+			want:     []float64{1.5, 2},
+		},
+		{
+			name:     "round",
+			function: "round",
+			values:   []float64{1.5, -1.5, 2.4},
+			want:     []float64{2, -1, 2},
+		},
+		{
+			name:     "round to nearest",
+			function: "round",
+			args:     []float64{5},
+			values:   []float64{12, 13},
+			want:     []float64{10, 15},
+		},
+		{
+			name:     "clamp_max",
+			function: "clamp_max",
+			args:     []float64{2},
+			values:   []float64{1, 3},
+			want:     []float64{1, 2},
+		},
+		{
+			name:     "clamp_min",
+			function: "clamp_min",
+			args:     []float64{2},
+			values:   []float64{1, 3},
+			want:     []float64{2, 3},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			data := &executetest.Table{
+				KeyCols: []string{"_measurement", "job"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "job", Type: flux.TString},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+			}
+			want := &executetest.Table{
+				KeyCols: []string{"job"},
+				ColMeta: []flux.ColMeta{
+					{Label: "job", Type: flux.TString},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+			}
+			for i := range tc.values {
+				data.Data = append(data.Data, []interface{}{"m", "api", execute.Time(i), tc.values[i]})
+				want.Data = append(want.Data, []interface{}{"api", execute.Time(i), tc.want[i]})
+			}
+
+			executetest.ProcessTestHelper(
+				t,
+				[]flux.Table{data},
+				[]*executetest.Table{want},
+				nil,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					tr, err := NewInstantFunctionTransformation(d, c, &InstantFunctionProcedureSpec{
+						Function: tc.function,
+						Args:     tc.args,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr
+				},
+			)
+		})
+	}
+}
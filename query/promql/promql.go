@@ -46,17 +46,13 @@ var g = &grammar{
 									},
 									&ruleRefExpr{
 										pos:  position{line: 11, col: 32, offset: 265},
-										name: "AggregateExpression",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 11, col: 54, offset: 287},
-										name: "VectorSelector",
+										name: "Expression",
 									},
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 11, col: 71, offset: 304},
+							pos:  position{line: 11, col: 45, offset: 278},
 							name: "EOF",
 						},
 					},
@@ -65,39 +61,39 @@ var g = &grammar{
 		},
 		{
 			name: "SourceChar",
-			pos:  position{line: 15, col: 1, offset: 337},
+			pos:  position{line: 15, col: 1, offset: 311},
 			expr: &anyMatcher{
-				line: 15, col: 14, offset: 350,
+				line: 15, col: 14, offset: 324,
 			},
 		},
 		{
 			name: "Comment",
-			pos:  position{line: 17, col: 1, offset: 353},
+			pos:  position{line: 17, col: 1, offset: 327},
 			expr: &actionExpr{
-				pos: position{line: 17, col: 11, offset: 363},
+				pos: position{line: 17, col: 11, offset: 337},
 				run: (*parser).callonComment1,
 				expr: &seqExpr{
-					pos: position{line: 17, col: 11, offset: 363},
+					pos: position{line: 17, col: 11, offset: 337},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 17, col: 11, offset: 363},
+							pos:        position{line: 17, col: 11, offset: 337},
 							val:        "#",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 17, col: 15, offset: 367},
+							pos: position{line: 17, col: 15, offset: 341},
 							expr: &seqExpr{
-								pos: position{line: 17, col: 17, offset: 369},
+								pos: position{line: 17, col: 17, offset: 343},
 								exprs: []interface{}{
 									&notExpr{
-										pos: position{line: 17, col: 17, offset: 369},
+										pos: position{line: 17, col: 17, offset: 343},
 										expr: &ruleRefExpr{
-											pos:  position{line: 17, col: 18, offset: 370},
+											pos:  position{line: 17, col: 18, offset: 344},
 											name: "EOL",
 										},
 									},
 									&ruleRefExpr{
-										pos:  position{line: 17, col: 22, offset: 374},
+										pos:  position{line: 17, col: 22, offset: 348},
 										name: "SourceChar",
 									},
 								},
@@ -109,15 +105,15 @@ var g = &grammar{
 		},
 		{
 			name: "Identifier",
-			pos:  position{line: 21, col: 1, offset: 434},
+			pos:  position{line: 21, col: 1, offset: 408},
 			expr: &actionExpr{
-				pos: position{line: 21, col: 14, offset: 447},
+				pos: position{line: 21, col: 14, offset: 421},
 				run: (*parser).callonIdentifier1,
 				expr: &labeledExpr{
-					pos:   position{line: 21, col: 14, offset: 447},
+					pos:   position{line: 21, col: 14, offset: 421},
 					label: "ident",
 					expr: &ruleRefExpr{
-						pos:  position{line: 21, col: 20, offset: 453},
+						pos:  position{line: 21, col: 20, offset: 427},
 						name: "IdentifierName",
 					},
 				},
@@ -125,21 +121,21 @@ var g = &grammar{
 		},
 		{
 			name: "IdentifierName",
-			pos:  position{line: 29, col: 1, offset: 637},
+			pos:  position{line: 29, col: 1, offset: 611},
 			expr: &actionExpr{
-				pos: position{line: 29, col: 18, offset: 654},
+				pos: position{line: 29, col: 18, offset: 628},
 				run: (*parser).callonIdentifierName1,
 				expr: &seqExpr{
-					pos: position{line: 29, col: 18, offset: 654},
+					pos: position{line: 29, col: 18, offset: 628},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 29, col: 18, offset: 654},
+							pos:  position{line: 29, col: 18, offset: 628},
 							name: "IdentifierStart",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 29, col: 34, offset: 670},
+							pos: position{line: 29, col: 34, offset: 644},
 							expr: &ruleRefExpr{
-								pos:  position{line: 29, col: 34, offset: 670},
+								pos:  position{line: 29, col: 34, offset: 644},
 								name: "IdentifierPart",
 							},
 						},
@@ -149,9 +145,9 @@ var g = &grammar{
 		},
 		{
 			name: "IdentifierStart",
-			pos:  position{line: 32, col: 1, offset: 721},
+			pos:  position{line: 32, col: 1, offset: 695},
 			expr: &charClassMatcher{
-				pos:        position{line: 32, col: 19, offset: 739},
+				pos:        position{line: 32, col: 19, offset: 713},
 				val:        "[\\pL_]",
 				chars:      []rune{'_'},
 				classes:    []*unicode.RangeTable{rangeTable("L")},
@@ -161,16 +157,16 @@ var g = &grammar{
 		},
 		{
 			name: "IdentifierPart",
-			pos:  position{line: 33, col: 1, offset: 746},
+			pos:  position{line: 33, col: 1, offset: 720},
 			expr: &choiceExpr{
-				pos: position{line: 33, col: 18, offset: 763},
+				pos: position{line: 33, col: 18, offset: 737},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 33, col: 18, offset: 763},
+						pos:  position{line: 33, col: 18, offset: 737},
 						name: "IdentifierStart",
 					},
 					&charClassMatcher{
-						pos:        position{line: 33, col: 36, offset: 781},
+						pos:        position{line: 33, col: 36, offset: 755},
 						val:        "[\\p{Nd}]",
 						classes:    []*unicode.RangeTable{rangeTable("Nd")},
 						ignoreCase: false,
@@ -181,74 +177,74 @@ var g = &grammar{
 		},
 		{
 			name: "StringLiteral",
-			pos:  position{line: 35, col: 1, offset: 791},
+			pos:  position{line: 35, col: 1, offset: 765},
 			expr: &choiceExpr{
-				pos: position{line: 35, col: 17, offset: 807},
+				pos: position{line: 35, col: 17, offset: 781},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 35, col: 17, offset: 807},
+						pos: position{line: 35, col: 17, offset: 781},
 						run: (*parser).callonStringLiteral2,
 						expr: &choiceExpr{
-							pos: position{line: 35, col: 19, offset: 809},
+							pos: position{line: 35, col: 19, offset: 783},
 							alternatives: []interface{}{
 								&seqExpr{
-									pos: position{line: 35, col: 19, offset: 809},
+									pos: position{line: 35, col: 19, offset: 783},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 35, col: 19, offset: 809},
+											pos:        position{line: 35, col: 19, offset: 783},
 											val:        "\"",
 											ignoreCase: false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 35, col: 23, offset: 813},
+											pos: position{line: 35, col: 23, offset: 787},
 											expr: &ruleRefExpr{
-												pos:  position{line: 35, col: 23, offset: 813},
+												pos:  position{line: 35, col: 23, offset: 787},
 												name: "DoubleStringChar",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 35, col: 41, offset: 831},
+											pos:        position{line: 35, col: 41, offset: 805},
 											val:        "\"",
 											ignoreCase: false,
 										},
 									},
 								},
 								&seqExpr{
-									pos: position{line: 35, col: 47, offset: 837},
+									pos: position{line: 35, col: 47, offset: 811},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 35, col: 47, offset: 837},
+											pos:        position{line: 35, col: 47, offset: 811},
 											val:        "'",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 35, col: 51, offset: 841},
+											pos:  position{line: 35, col: 51, offset: 815},
 											name: "SingleStringChar",
 										},
 										&litMatcher{
-											pos:        position{line: 35, col: 68, offset: 858},
+											pos:        position{line: 35, col: 68, offset: 832},
 											val:        "'",
 											ignoreCase: false,
 										},
 									},
 								},
 								&seqExpr{
-									pos: position{line: 35, col: 74, offset: 864},
+									pos: position{line: 35, col: 74, offset: 838},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 35, col: 74, offset: 864},
+											pos:        position{line: 35, col: 74, offset: 838},
 											val:        "`",
 											ignoreCase: false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 35, col: 78, offset: 868},
+											pos: position{line: 35, col: 78, offset: 842},
 											expr: &ruleRefExpr{
-												pos:  position{line: 35, col: 78, offset: 868},
+												pos:  position{line: 35, col: 78, offset: 842},
 												name: "RawStringChar",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 35, col: 93, offset: 883},
+											pos:        position{line: 35, col: 93, offset: 857},
 											val:        "`",
 											ignoreCase: false,
 										},
@@ -258,35 +254,35 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 41, col: 5, offset: 1029},
+						pos: position{line: 41, col: 5, offset: 1003},
 						run: (*parser).callonStringLiteral18,
 						expr: &choiceExpr{
-							pos: position{line: 41, col: 7, offset: 1031},
+							pos: position{line: 41, col: 7, offset: 1005},
 							alternatives: []interface{}{
 								&seqExpr{
-									pos: position{line: 41, col: 9, offset: 1033},
+									pos: position{line: 41, col: 9, offset: 1007},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 41, col: 9, offset: 1033},
+											pos:        position{line: 41, col: 9, offset: 1007},
 											val:        "\"",
 											ignoreCase: false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 41, col: 13, offset: 1037},
+											pos: position{line: 41, col: 13, offset: 1011},
 											expr: &ruleRefExpr{
-												pos:  position{line: 41, col: 13, offset: 1037},
+												pos:  position{line: 41, col: 13, offset: 1011},
 												name: "DoubleStringChar",
 											},
 										},
 										&choiceExpr{
-											pos: position{line: 41, col: 33, offset: 1057},
+											pos: position{line: 41, col: 33, offset: 1031},
 											alternatives: []interface{}{
 												&ruleRefExpr{
-													pos:  position{line: 41, col: 33, offset: 1057},
+													pos:  position{line: 41, col: 33, offset: 1031},
 													name: "EOL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 41, col: 39, offset: 1063},
+													pos:  position{line: 41, col: 39, offset: 1037},
 													name: "EOF",
 												},
 											},
@@ -294,29 +290,29 @@ var g = &grammar{
 									},
 								},
 								&seqExpr{
-									pos: position{line: 41, col: 51, offset: 1075},
+									pos: position{line: 41, col: 51, offset: 1049},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 41, col: 51, offset: 1075},
+											pos:        position{line: 41, col: 51, offset: 1049},
 											val:        "'",
 											ignoreCase: false,
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 41, col: 55, offset: 1079},
+											pos: position{line: 41, col: 55, offset: 1053},
 											expr: &ruleRefExpr{
-												pos:  position{line: 41, col: 55, offset: 1079},
+												pos:  position{line: 41, col: 55, offset: 1053},
 												name: "SingleStringChar",
 											},
 										},
 										&choiceExpr{
-											pos: position{line: 41, col: 75, offset: 1099},
+											pos: position{line: 41, col: 75, offset: 1073},
 											alternatives: []interface{}{
 												&ruleRefExpr{
-													pos:  position{line: 41, col: 75, offset: 1099},
+													pos:  position{line: 41, col: 75, offset: 1073},
 													name: "EOL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 41, col: 81, offset: 1105},
+													pos:  position{line: 41, col: 81, offset: 1079},
 													name: "EOF",
 												},
 											},
@@ -324,22 +320,22 @@ var g = &grammar{
 									},
 								},
 								&seqExpr{
-									pos: position{line: 41, col: 91, offset: 1115},
+									pos: position{line: 41, col: 91, offset: 1089},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 41, col: 91, offset: 1115},
+											pos:        position{line: 41, col: 91, offset: 1089},
 											val:        "`",
 											ignoreCase: false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 41, col: 95, offset: 1119},
+											pos: position{line: 41, col: 95, offset: 1093},
 											expr: &ruleRefExpr{
-												pos:  position{line: 41, col: 95, offset: 1119},
+												pos:  position{line: 41, col: 95, offset: 1093},
 												name: "RawStringChar",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 41, col: 110, offset: 1134},
+											pos:  position{line: 41, col: 110, offset: 1108},
 											name: "EOF",
 										},
 									},
@@ -352,51 +348,51 @@ var g = &grammar{
 		},
 		{
 			name: "DoubleStringChar",
-			pos:  position{line: 45, col: 1, offset: 1205},
+			pos:  position{line: 45, col: 1, offset: 1179},
 			expr: &choiceExpr{
-				pos: position{line: 45, col: 20, offset: 1224},
+				pos: position{line: 45, col: 20, offset: 1198},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 45, col: 20, offset: 1224},
+						pos: position{line: 45, col: 20, offset: 1198},
 						exprs: []interface{}{
 							&notExpr{
-								pos: position{line: 45, col: 20, offset: 1224},
+								pos: position{line: 45, col: 20, offset: 1198},
 								expr: &choiceExpr{
-									pos: position{line: 45, col: 23, offset: 1227},
+									pos: position{line: 45, col: 23, offset: 1201},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 45, col: 23, offset: 1227},
+											pos:        position{line: 45, col: 23, offset: 1201},
 											val:        "\"",
 											ignoreCase: false,
 										},
 										&litMatcher{
-											pos:        position{line: 45, col: 29, offset: 1233},
+											pos:        position{line: 45, col: 29, offset: 1207},
 											val:        "\\",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 45, col: 36, offset: 1240},
+											pos:  position{line: 45, col: 36, offset: 1214},
 											name: "EOL",
 										},
 									},
 								},
 							},
 							&ruleRefExpr{
-								pos:  position{line: 45, col: 42, offset: 1246},
+								pos:  position{line: 45, col: 42, offset: 1220},
 								name: "SourceChar",
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 45, col: 55, offset: 1259},
+						pos: position{line: 45, col: 55, offset: 1233},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 45, col: 55, offset: 1259},
+								pos:        position{line: 45, col: 55, offset: 1233},
 								val:        "\\",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 45, col: 60, offset: 1264},
+								pos:  position{line: 45, col: 60, offset: 1238},
 								name: "DoubleStringEscape",
 							},
 						},
@@ -406,51 +402,51 @@ var g = &grammar{
 		},
 		{
 			name: "SingleStringChar",
-			pos:  position{line: 46, col: 1, offset: 1283},
+			pos:  position{line: 46, col: 1, offset: 1257},
 			expr: &choiceExpr{
-				pos: position{line: 46, col: 20, offset: 1302},
+				pos: position{line: 46, col: 20, offset: 1276},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 46, col: 20, offset: 1302},
+						pos: position{line: 46, col: 20, offset: 1276},
 						exprs: []interface{}{
 							&notExpr{
-								pos: position{line: 46, col: 20, offset: 1302},
+								pos: position{line: 46, col: 20, offset: 1276},
 								expr: &choiceExpr{
-									pos: position{line: 46, col: 23, offset: 1305},
+									pos: position{line: 46, col: 23, offset: 1279},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 46, col: 23, offset: 1305},
+											pos:        position{line: 46, col: 23, offset: 1279},
 											val:        "'",
 											ignoreCase: false,
 										},
 										&litMatcher{
-											pos:        position{line: 46, col: 29, offset: 1311},
+											pos:        position{line: 46, col: 29, offset: 1285},
 											val:        "\\",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 46, col: 36, offset: 1318},
+											pos:  position{line: 46, col: 36, offset: 1292},
 											name: "EOL",
 										},
 									},
 								},
 							},
 							&ruleRefExpr{
-								pos:  position{line: 46, col: 42, offset: 1324},
+								pos:  position{line: 46, col: 42, offset: 1298},
 								name: "SourceChar",
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 46, col: 55, offset: 1337},
+						pos: position{line: 46, col: 55, offset: 1311},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 46, col: 55, offset: 1337},
+								pos:        position{line: 46, col: 55, offset: 1311},
 								val:        "\\",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 46, col: 60, offset: 1342},
+								pos:  position{line: 46, col: 60, offset: 1316},
 								name: "SingleStringEscape",
 							},
 						},
@@ -460,20 +456,20 @@ var g = &grammar{
 		},
 		{
 			name: "RawStringChar",
-			pos:  position{line: 47, col: 1, offset: 1361},
+			pos:  position{line: 47, col: 1, offset: 1335},
 			expr: &seqExpr{
-				pos: position{line: 47, col: 17, offset: 1377},
+				pos: position{line: 47, col: 17, offset: 1351},
 				exprs: []interface{}{
 					&notExpr{
-						pos: position{line: 47, col: 17, offset: 1377},
+						pos: position{line: 47, col: 17, offset: 1351},
 						expr: &litMatcher{
-							pos:        position{line: 47, col: 18, offset: 1378},
+							pos:        position{line: 47, col: 18, offset: 1352},
 							val:        "`",
 							ignoreCase: false,
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 47, col: 22, offset: 1382},
+						pos:  position{line: 47, col: 22, offset: 1356},
 						name: "SourceChar",
 					},
 				},
@@ -481,40 +477,40 @@ var g = &grammar{
 		},
 		{
 			name: "DoubleStringEscape",
-			pos:  position{line: 49, col: 1, offset: 1394},
+			pos:  position{line: 49, col: 1, offset: 1368},
 			expr: &choiceExpr{
-				pos: position{line: 49, col: 22, offset: 1415},
+				pos: position{line: 49, col: 22, offset: 1389},
 				alternatives: []interface{}{
 					&choiceExpr{
-						pos: position{line: 49, col: 24, offset: 1417},
+						pos: position{line: 49, col: 24, offset: 1391},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 49, col: 24, offset: 1417},
+								pos:        position{line: 49, col: 24, offset: 1391},
 								val:        "\"",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 49, col: 30, offset: 1423},
+								pos:  position{line: 49, col: 30, offset: 1397},
 								name: "CommonEscapeSequence",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 50, col: 7, offset: 1452},
+						pos: position{line: 50, col: 7, offset: 1426},
 						run: (*parser).callonDoubleStringEscape5,
 						expr: &choiceExpr{
-							pos: position{line: 50, col: 9, offset: 1454},
+							pos: position{line: 50, col: 9, offset: 1428},
 							alternatives: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 50, col: 9, offset: 1454},
+									pos:  position{line: 50, col: 9, offset: 1428},
 									name: "SourceChar",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 50, col: 22, offset: 1467},
+									pos:  position{line: 50, col: 22, offset: 1441},
 									name: "EOL",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 50, col: 28, offset: 1473},
+									pos:  position{line: 50, col: 28, offset: 1447},
 									name: "EOF",
 								},
 							},
@@ -525,40 +521,40 @@ var g = &grammar{
 		},
 		{
 			name: "SingleStringEscape",
-			pos:  position{line: 53, col: 1, offset: 1538},
+			pos:  position{line: 53, col: 1, offset: 1512},
 			expr: &choiceExpr{
-				pos: position{line: 53, col: 22, offset: 1559},
+				pos: position{line: 53, col: 22, offset: 1533},
 				alternatives: []interface{}{
 					&choiceExpr{
-						pos: position{line: 53, col: 24, offset: 1561},
+						pos: position{line: 53, col: 24, offset: 1535},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 53, col: 24, offset: 1561},
+								pos:        position{line: 53, col: 24, offset: 1535},
 								val:        "'",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 53, col: 30, offset: 1567},
+								pos:  position{line: 53, col: 30, offset: 1541},
 								name: "CommonEscapeSequence",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 54, col: 7, offset: 1596},
+						pos: position{line: 54, col: 7, offset: 1570},
 						run: (*parser).callonSingleStringEscape5,
 						expr: &choiceExpr{
-							pos: position{line: 54, col: 9, offset: 1598},
+							pos: position{line: 54, col: 9, offset: 1572},
 							alternatives: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 54, col: 9, offset: 1598},
+									pos:  position{line: 54, col: 9, offset: 1572},
 									name: "SourceChar",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 54, col: 22, offset: 1611},
+									pos:  position{line: 54, col: 22, offset: 1585},
 									name: "EOL",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 54, col: 28, offset: 1617},
+									pos:  position{line: 54, col: 28, offset: 1591},
 									name: "EOF",
 								},
 							},
@@ -569,28 +565,28 @@ var g = &grammar{
 		},
 		{
 			name: "CommonEscapeSequence",
-			pos:  position{line: 58, col: 1, offset: 1683},
+			pos:  position{line: 58, col: 1, offset: 1657},
 			expr: &choiceExpr{
-				pos: position{line: 58, col: 24, offset: 1706},
+				pos: position{line: 58, col: 24, offset: 1680},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 58, col: 24, offset: 1706},
+						pos:  position{line: 58, col: 24, offset: 1680},
 						name: "SingleCharEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 58, col: 43, offset: 1725},
+						pos:  position{line: 58, col: 43, offset: 1699},
 						name: "OctalEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 58, col: 57, offset: 1739},
+						pos:  position{line: 58, col: 57, offset: 1713},
 						name: "HexEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 58, col: 69, offset: 1751},
+						pos:  position{line: 58, col: 69, offset: 1725},
 						name: "LongUnicodeEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 58, col: 89, offset: 1771},
+						pos:  position{line: 58, col: 89, offset: 1745},
 						name: "ShortUnicodeEscape",
 					},
 				},
@@ -598,47 +594,47 @@ var g = &grammar{
 		},
 		{
 			name: "SingleCharEscape",
-			pos:  position{line: 59, col: 1, offset: 1790},
+			pos:  position{line: 59, col: 1, offset: 1764},
 			expr: &choiceExpr{
-				pos: position{line: 59, col: 20, offset: 1809},
+				pos: position{line: 59, col: 20, offset: 1783},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 59, col: 20, offset: 1809},
+						pos:        position{line: 59, col: 20, offset: 1783},
 						val:        "a",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 59, col: 26, offset: 1815},
+						pos:        position{line: 59, col: 26, offset: 1789},
 						val:        "b",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 59, col: 32, offset: 1821},
+						pos:        position{line: 59, col: 32, offset: 1795},
 						val:        "n",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 59, col: 38, offset: 1827},
+						pos:        position{line: 59, col: 38, offset: 1801},
 						val:        "f",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 59, col: 44, offset: 1833},
+						pos:        position{line: 59, col: 44, offset: 1807},
 						val:        "r",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 59, col: 50, offset: 1839},
+						pos:        position{line: 59, col: 50, offset: 1813},
 						val:        "t",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 59, col: 56, offset: 1845},
+						pos:        position{line: 59, col: 56, offset: 1819},
 						val:        "v",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 59, col: 62, offset: 1851},
+						pos:        position{line: 59, col: 62, offset: 1825},
 						val:        "\\",
 						ignoreCase: false,
 					},
@@ -647,50 +643,50 @@ var g = &grammar{
 		},
 		{
 			name: "OctalEscape",
-			pos:  position{line: 60, col: 1, offset: 1856},
+			pos:  position{line: 60, col: 1, offset: 1830},
 			expr: &choiceExpr{
-				pos: position{line: 60, col: 15, offset: 1870},
+				pos: position{line: 60, col: 15, offset: 1844},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 60, col: 15, offset: 1870},
+						pos: position{line: 60, col: 15, offset: 1844},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 60, col: 15, offset: 1870},
+								pos:  position{line: 60, col: 15, offset: 1844},
 								name: "OctalDigit",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 60, col: 26, offset: 1881},
+								pos:  position{line: 60, col: 26, offset: 1855},
 								name: "OctalDigit",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 60, col: 37, offset: 1892},
+								pos:  position{line: 60, col: 37, offset: 1866},
 								name: "OctalDigit",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 61, col: 7, offset: 1909},
+						pos: position{line: 61, col: 7, offset: 1883},
 						run: (*parser).callonOctalEscape6,
 						expr: &seqExpr{
-							pos: position{line: 61, col: 7, offset: 1909},
+							pos: position{line: 61, col: 7, offset: 1883},
 							exprs: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 61, col: 7, offset: 1909},
+									pos:  position{line: 61, col: 7, offset: 1883},
 									name: "OctalDigit",
 								},
 								&choiceExpr{
-									pos: position{line: 61, col: 20, offset: 1922},
+									pos: position{line: 61, col: 20, offset: 1896},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 61, col: 20, offset: 1922},
+											pos:  position{line: 61, col: 20, offset: 1896},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 61, col: 33, offset: 1935},
+											pos:  position{line: 61, col: 33, offset: 1909},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 61, col: 39, offset: 1941},
+											pos:  position{line: 61, col: 39, offset: 1915},
 											name: "EOF",
 										},
 									},
@@ -703,52 +699,52 @@ var g = &grammar{
 		},
 		{
 			name: "HexEscape",
-			pos:  position{line: 64, col: 1, offset: 2002},
+			pos:  position{line: 64, col: 1, offset: 1976},
 			expr: &choiceExpr{
-				pos: position{line: 64, col: 13, offset: 2014},
+				pos: position{line: 64, col: 13, offset: 1988},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 64, col: 13, offset: 2014},
+						pos: position{line: 64, col: 13, offset: 1988},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 64, col: 13, offset: 2014},
+								pos:        position{line: 64, col: 13, offset: 1988},
 								val:        "x",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 64, col: 17, offset: 2018},
+								pos:  position{line: 64, col: 17, offset: 1992},
 								name: "HexDigit",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 64, col: 26, offset: 2027},
+								pos:  position{line: 64, col: 26, offset: 2001},
 								name: "HexDigit",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 65, col: 7, offset: 2042},
+						pos: position{line: 65, col: 7, offset: 2016},
 						run: (*parser).callonHexEscape6,
 						expr: &seqExpr{
-							pos: position{line: 65, col: 7, offset: 2042},
+							pos: position{line: 65, col: 7, offset: 2016},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 65, col: 7, offset: 2042},
+									pos:        position{line: 65, col: 7, offset: 2016},
 									val:        "x",
 									ignoreCase: false,
 								},
 								&choiceExpr{
-									pos: position{line: 65, col: 13, offset: 2048},
+									pos: position{line: 65, col: 13, offset: 2022},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 13, offset: 2048},
+											pos:  position{line: 65, col: 13, offset: 2022},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 26, offset: 2061},
+											pos:  position{line: 65, col: 26, offset: 2035},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 32, offset: 2067},
+											pos:  position{line: 65, col: 32, offset: 2041},
 											name: "EOF",
 										},
 									},
@@ -761,80 +757,80 @@ var g = &grammar{
 		},
 		{
 			name: "LongUnicodeEscape",
-			pos:  position{line: 68, col: 1, offset: 2134},
+			pos:  position{line: 68, col: 1, offset: 2108},
 			expr: &choiceExpr{
-				pos: position{line: 69, col: 5, offset: 2159},
+				pos: position{line: 69, col: 5, offset: 2133},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 69, col: 5, offset: 2159},
+						pos: position{line: 69, col: 5, offset: 2133},
 						run: (*parser).callonLongUnicodeEscape2,
 						expr: &seqExpr{
-							pos: position{line: 69, col: 5, offset: 2159},
+							pos: position{line: 69, col: 5, offset: 2133},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 69, col: 5, offset: 2159},
+									pos:        position{line: 69, col: 5, offset: 2133},
 									val:        "U",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 69, col: 9, offset: 2163},
+									pos:  position{line: 69, col: 9, offset: 2137},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 69, col: 18, offset: 2172},
+									pos:  position{line: 69, col: 18, offset: 2146},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 69, col: 27, offset: 2181},
+									pos:  position{line: 69, col: 27, offset: 2155},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 69, col: 36, offset: 2190},
+									pos:  position{line: 69, col: 36, offset: 2164},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 69, col: 45, offset: 2199},
+									pos:  position{line: 69, col: 45, offset: 2173},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 69, col: 54, offset: 2208},
+									pos:  position{line: 69, col: 54, offset: 2182},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 69, col: 63, offset: 2217},
+									pos:  position{line: 69, col: 63, offset: 2191},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 69, col: 72, offset: 2226},
+									pos:  position{line: 69, col: 72, offset: 2200},
 									name: "HexDigit",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 72, col: 7, offset: 2328},
+						pos: position{line: 72, col: 7, offset: 2302},
 						run: (*parser).callonLongUnicodeEscape13,
 						expr: &seqExpr{
-							pos: position{line: 72, col: 7, offset: 2328},
+							pos: position{line: 72, col: 7, offset: 2302},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 72, col: 7, offset: 2328},
+									pos:        position{line: 72, col: 7, offset: 2302},
 									val:        "U",
 									ignoreCase: false,
 								},
 								&choiceExpr{
-									pos: position{line: 72, col: 13, offset: 2334},
+									pos: position{line: 72, col: 13, offset: 2308},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 72, col: 13, offset: 2334},
+											pos:  position{line: 72, col: 13, offset: 2308},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 72, col: 26, offset: 2347},
+											pos:  position{line: 72, col: 26, offset: 2321},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 72, col: 32, offset: 2353},
+											pos:  position{line: 72, col: 32, offset: 2327},
 											name: "EOF",
 										},
 									},
@@ -847,64 +843,64 @@ var g = &grammar{
 		},
 		{
 			name: "ShortUnicodeEscape",
-			pos:  position{line: 75, col: 1, offset: 2416},
+			pos:  position{line: 75, col: 1, offset: 2390},
 			expr: &choiceExpr{
-				pos: position{line: 76, col: 5, offset: 2442},
+				pos: position{line: 76, col: 5, offset: 2416},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 76, col: 5, offset: 2442},
+						pos: position{line: 76, col: 5, offset: 2416},
 						run: (*parser).callonShortUnicodeEscape2,
 						expr: &seqExpr{
-							pos: position{line: 76, col: 5, offset: 2442},
+							pos: position{line: 76, col: 5, offset: 2416},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 76, col: 5, offset: 2442},
+									pos:        position{line: 76, col: 5, offset: 2416},
 									val:        "u",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 76, col: 9, offset: 2446},
+									pos:  position{line: 76, col: 9, offset: 2420},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 76, col: 18, offset: 2455},
+									pos:  position{line: 76, col: 18, offset: 2429},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 76, col: 27, offset: 2464},
+									pos:  position{line: 76, col: 27, offset: 2438},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 76, col: 36, offset: 2473},
+									pos:  position{line: 76, col: 36, offset: 2447},
 									name: "HexDigit",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 79, col: 7, offset: 2575},
+						pos: position{line: 79, col: 7, offset: 2549},
 						run: (*parser).callonShortUnicodeEscape9,
 						expr: &seqExpr{
-							pos: position{line: 79, col: 7, offset: 2575},
+							pos: position{line: 79, col: 7, offset: 2549},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 79, col: 7, offset: 2575},
+									pos:        position{line: 79, col: 7, offset: 2549},
 									val:        "u",
 									ignoreCase: false,
 								},
 								&choiceExpr{
-									pos: position{line: 79, col: 13, offset: 2581},
+									pos: position{line: 79, col: 13, offset: 2555},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 79, col: 13, offset: 2581},
+											pos:  position{line: 79, col: 13, offset: 2555},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 79, col: 26, offset: 2594},
+											pos:  position{line: 79, col: 26, offset: 2568},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 79, col: 32, offset: 2600},
+											pos:  position{line: 79, col: 32, offset: 2574},
 											name: "EOF",
 										},
 									},
@@ -917,9 +913,9 @@ var g = &grammar{
 		},
 		{
 			name: "OctalDigit",
-			pos:  position{line: 83, col: 1, offset: 2664},
+			pos:  position{line: 83, col: 1, offset: 2638},
 			expr: &charClassMatcher{
-				pos:        position{line: 83, col: 14, offset: 2677},
+				pos:        position{line: 83, col: 14, offset: 2651},
 				val:        "[0-7]",
 				ranges:     []rune{'0', '7'},
 				ignoreCase: false,
@@ -928,9 +924,9 @@ var g = &grammar{
 		},
 		{
 			name: "DecimalDigit",
-			pos:  position{line: 84, col: 1, offset: 2683},
+			pos:  position{line: 84, col: 1, offset: 2657},
 			expr: &charClassMatcher{
-				pos:        position{line: 84, col: 16, offset: 2698},
+				pos:        position{line: 84, col: 16, offset: 2672},
 				val:        "[0-9]",
 				ranges:     []rune{'0', '9'},
 				ignoreCase: false,
@@ -939,9 +935,9 @@ var g = &grammar{
 		},
 		{
 			name: "HexDigit",
-			pos:  position{line: 85, col: 1, offset: 2704},
+			pos:  position{line: 85, col: 1, offset: 2678},
 			expr: &charClassMatcher{
-				pos:        position{line: 85, col: 12, offset: 2715},
+				pos:        position{line: 85, col: 12, offset: 2689},
 				val:        "[0-9a-f]i",
 				ranges:     []rune{'0', '9', 'a', 'f'},
 				ignoreCase: true,
@@ -950,44 +946,44 @@ var g = &grammar{
 		},
 		{
 			name: "CharClassMatcher",
-			pos:  position{line: 87, col: 1, offset: 2726},
+			pos:  position{line: 87, col: 1, offset: 2700},
 			expr: &choiceExpr{
-				pos: position{line: 87, col: 20, offset: 2745},
+				pos: position{line: 87, col: 20, offset: 2719},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 87, col: 20, offset: 2745},
+						pos: position{line: 87, col: 20, offset: 2719},
 						run: (*parser).callonCharClassMatcher2,
 						expr: &seqExpr{
-							pos: position{line: 87, col: 20, offset: 2745},
+							pos: position{line: 87, col: 20, offset: 2719},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 87, col: 20, offset: 2745},
+									pos:        position{line: 87, col: 20, offset: 2719},
 									val:        "[",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 87, col: 24, offset: 2749},
+									pos: position{line: 87, col: 24, offset: 2723},
 									expr: &choiceExpr{
-										pos: position{line: 87, col: 26, offset: 2751},
+										pos: position{line: 87, col: 26, offset: 2725},
 										alternatives: []interface{}{
 											&ruleRefExpr{
-												pos:  position{line: 87, col: 26, offset: 2751},
+												pos:  position{line: 87, col: 26, offset: 2725},
 												name: "ClassCharRange",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 87, col: 43, offset: 2768},
+												pos:  position{line: 87, col: 43, offset: 2742},
 												name: "ClassChar",
 											},
 											&seqExpr{
-												pos: position{line: 87, col: 55, offset: 2780},
+												pos: position{line: 87, col: 55, offset: 2754},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 87, col: 55, offset: 2780},
+														pos:        position{line: 87, col: 55, offset: 2754},
 														val:        "\\",
 														ignoreCase: false,
 													},
 													&ruleRefExpr{
-														pos:  position{line: 87, col: 60, offset: 2785},
+														pos:  position{line: 87, col: 60, offset: 2759},
 														name: "UnicodeClassEscape",
 													},
 												},
@@ -996,14 +992,14 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 87, col: 82, offset: 2807},
+									pos:        position{line: 87, col: 82, offset: 2781},
 									val:        "]",
 									ignoreCase: false,
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 87, col: 86, offset: 2811},
+									pos: position{line: 87, col: 86, offset: 2785},
 									expr: &litMatcher{
-										pos:        position{line: 87, col: 86, offset: 2811},
+										pos:        position{line: 87, col: 86, offset: 2785},
 										val:        "i",
 										ignoreCase: false,
 									},
@@ -1012,44 +1008,44 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 89, col: 5, offset: 2853},
+						pos: position{line: 89, col: 5, offset: 2827},
 						run: (*parser).callonCharClassMatcher15,
 						expr: &seqExpr{
-							pos: position{line: 89, col: 5, offset: 2853},
+							pos: position{line: 89, col: 5, offset: 2827},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 89, col: 5, offset: 2853},
+									pos:        position{line: 89, col: 5, offset: 2827},
 									val:        "[",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 89, col: 9, offset: 2857},
+									pos: position{line: 89, col: 9, offset: 2831},
 									expr: &seqExpr{
-										pos: position{line: 89, col: 11, offset: 2859},
+										pos: position{line: 89, col: 11, offset: 2833},
 										exprs: []interface{}{
 											&notExpr{
-												pos: position{line: 89, col: 11, offset: 2859},
+												pos: position{line: 89, col: 11, offset: 2833},
 												expr: &ruleRefExpr{
-													pos:  position{line: 89, col: 14, offset: 2862},
+													pos:  position{line: 89, col: 14, offset: 2836},
 													name: "EOL",
 												},
 											},
 											&ruleRefExpr{
-												pos:  position{line: 89, col: 20, offset: 2868},
+												pos:  position{line: 89, col: 20, offset: 2842},
 												name: "SourceChar",
 											},
 										},
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 89, col: 36, offset: 2884},
+									pos: position{line: 89, col: 36, offset: 2858},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 89, col: 36, offset: 2884},
+											pos:  position{line: 89, col: 36, offset: 2858},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 89, col: 42, offset: 2890},
+											pos:  position{line: 89, col: 42, offset: 2864},
 											name: "EOF",
 										},
 									},
@@ -1062,21 +1058,21 @@ var g = &grammar{
 		},
 		{
 			name: "ClassCharRange",
-			pos:  position{line: 93, col: 1, offset: 2962},
+			pos:  position{line: 93, col: 1, offset: 2936},
 			expr: &seqExpr{
-				pos: position{line: 93, col: 18, offset: 2979},
+				pos: position{line: 93, col: 18, offset: 2953},
 				exprs: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 93, col: 18, offset: 2979},
+						pos:  position{line: 93, col: 18, offset: 2953},
 						name: "ClassChar",
 					},
 					&litMatcher{
-						pos:        position{line: 93, col: 28, offset: 2989},
+						pos:        position{line: 93, col: 28, offset: 2963},
 						val:        "-",
 						ignoreCase: false,
 					},
 					&ruleRefExpr{
-						pos:  position{line: 93, col: 32, offset: 2993},
+						pos:  position{line: 93, col: 32, offset: 2967},
 						name: "ClassChar",
 					},
 				},
@@ -1084,51 +1080,51 @@ var g = &grammar{
 		},
 		{
 			name: "ClassChar",
-			pos:  position{line: 94, col: 1, offset: 3003},
+			pos:  position{line: 94, col: 1, offset: 2977},
 			expr: &choiceExpr{
-				pos: position{line: 94, col: 13, offset: 3015},
+				pos: position{line: 94, col: 13, offset: 2989},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 94, col: 13, offset: 3015},
+						pos: position{line: 94, col: 13, offset: 2989},
 						exprs: []interface{}{
 							&notExpr{
-								pos: position{line: 94, col: 13, offset: 3015},
+								pos: position{line: 94, col: 13, offset: 2989},
 								expr: &choiceExpr{
-									pos: position{line: 94, col: 16, offset: 3018},
+									pos: position{line: 94, col: 16, offset: 2992},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 94, col: 16, offset: 3018},
+											pos:        position{line: 94, col: 16, offset: 2992},
 											val:        "]",
 											ignoreCase: false,
 										},
 										&litMatcher{
-											pos:        position{line: 94, col: 22, offset: 3024},
+											pos:        position{line: 94, col: 22, offset: 2998},
 											val:        "\\",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 94, col: 29, offset: 3031},
+											pos:  position{line: 94, col: 29, offset: 3005},
 											name: "EOL",
 										},
 									},
 								},
 							},
 							&ruleRefExpr{
-								pos:  position{line: 94, col: 35, offset: 3037},
+								pos:  position{line: 94, col: 35, offset: 3011},
 								name: "SourceChar",
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 94, col: 48, offset: 3050},
+						pos: position{line: 94, col: 48, offset: 3024},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 94, col: 48, offset: 3050},
+								pos:        position{line: 94, col: 48, offset: 3024},
 								val:        "\\",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 94, col: 53, offset: 3055},
+								pos:  position{line: 94, col: 53, offset: 3029},
 								name: "CharClassEscape",
 							},
 						},
@@ -1138,51 +1134,51 @@ var g = &grammar{
 		},
 		{
 			name: "CharClassEscape",
-			pos:  position{line: 95, col: 1, offset: 3071},
+			pos:  position{line: 95, col: 1, offset: 3045},
 			expr: &choiceExpr{
-				pos: position{line: 95, col: 19, offset: 3089},
+				pos: position{line: 95, col: 19, offset: 3063},
 				alternatives: []interface{}{
 					&choiceExpr{
-						pos: position{line: 95, col: 21, offset: 3091},
+						pos: position{line: 95, col: 21, offset: 3065},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 95, col: 21, offset: 3091},
+								pos:        position{line: 95, col: 21, offset: 3065},
 								val:        "]",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 95, col: 27, offset: 3097},
+								pos:  position{line: 95, col: 27, offset: 3071},
 								name: "CommonEscapeSequence",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 96, col: 7, offset: 3126},
+						pos: position{line: 96, col: 7, offset: 3100},
 						run: (*parser).callonCharClassEscape5,
 						expr: &seqExpr{
-							pos: position{line: 96, col: 7, offset: 3126},
+							pos: position{line: 96, col: 7, offset: 3100},
 							exprs: []interface{}{
 								&notExpr{
-									pos: position{line: 96, col: 7, offset: 3126},
+									pos: position{line: 96, col: 7, offset: 3100},
 									expr: &litMatcher{
-										pos:        position{line: 96, col: 8, offset: 3127},
+										pos:        position{line: 96, col: 8, offset: 3101},
 										val:        "p",
 										ignoreCase: false,
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 96, col: 14, offset: 3133},
+									pos: position{line: 96, col: 14, offset: 3107},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 96, col: 14, offset: 3133},
+											pos:  position{line: 96, col: 14, offset: 3107},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 96, col: 27, offset: 3146},
+											pos:  position{line: 96, col: 27, offset: 3120},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 96, col: 33, offset: 3152},
+											pos:  position{line: 96, col: 33, offset: 3126},
 											name: "EOF",
 										},
 									},
@@ -1195,49 +1191,49 @@ var g = &grammar{
 		},
 		{
 			name: "UnicodeClassEscape",
-			pos:  position{line: 100, col: 1, offset: 3218},
+			pos:  position{line: 100, col: 1, offset: 3192},
 			expr: &seqExpr{
-				pos: position{line: 100, col: 22, offset: 3239},
+				pos: position{line: 100, col: 22, offset: 3213},
 				exprs: []interface{}{
 					&litMatcher{
-						pos:        position{line: 100, col: 22, offset: 3239},
+						pos:        position{line: 100, col: 22, offset: 3213},
 						val:        "p",
 						ignoreCase: false,
 					},
 					&choiceExpr{
-						pos: position{line: 101, col: 7, offset: 3252},
+						pos: position{line: 101, col: 7, offset: 3226},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 101, col: 7, offset: 3252},
+								pos:  position{line: 101, col: 7, offset: 3226},
 								name: "SingleCharUnicodeClass",
 							},
 							&actionExpr{
-								pos: position{line: 102, col: 7, offset: 3281},
+								pos: position{line: 102, col: 7, offset: 3255},
 								run: (*parser).callonUnicodeClassEscape5,
 								expr: &seqExpr{
-									pos: position{line: 102, col: 7, offset: 3281},
+									pos: position{line: 102, col: 7, offset: 3255},
 									exprs: []interface{}{
 										&notExpr{
-											pos: position{line: 102, col: 7, offset: 3281},
+											pos: position{line: 102, col: 7, offset: 3255},
 											expr: &litMatcher{
-												pos:        position{line: 102, col: 8, offset: 3282},
+												pos:        position{line: 102, col: 8, offset: 3256},
 												val:        "{",
 												ignoreCase: false,
 											},
 										},
 										&choiceExpr{
-											pos: position{line: 102, col: 14, offset: 3288},
+											pos: position{line: 102, col: 14, offset: 3262},
 											alternatives: []interface{}{
 												&ruleRefExpr{
-													pos:  position{line: 102, col: 14, offset: 3288},
+													pos:  position{line: 102, col: 14, offset: 3262},
 													name: "SourceChar",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 102, col: 27, offset: 3301},
+													pos:  position{line: 102, col: 27, offset: 3275},
 													name: "EOL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 102, col: 33, offset: 3307},
+													pos:  position{line: 102, col: 33, offset: 3281},
 													name: "EOF",
 												},
 											},
@@ -1246,26 +1242,26 @@ var g = &grammar{
 								},
 							},
 							&actionExpr{
-								pos: position{line: 103, col: 7, offset: 3378},
+								pos: position{line: 103, col: 7, offset: 3352},
 								run: (*parser).callonUnicodeClassEscape13,
 								expr: &seqExpr{
-									pos: position{line: 103, col: 7, offset: 3378},
+									pos: position{line: 103, col: 7, offset: 3352},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 103, col: 7, offset: 3378},
+											pos:        position{line: 103, col: 7, offset: 3352},
 											val:        "{",
 											ignoreCase: false,
 										},
 										&labeledExpr{
-											pos:   position{line: 103, col: 11, offset: 3382},
+											pos:   position{line: 103, col: 11, offset: 3356},
 											label: "ident",
 											expr: &ruleRefExpr{
-												pos:  position{line: 103, col: 17, offset: 3388},
+												pos:  position{line: 103, col: 17, offset: 3362},
 												name: "IdentifierName",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 103, col: 32, offset: 3403},
+											pos:        position{line: 103, col: 32, offset: 3377},
 											val:        "}",
 											ignoreCase: false,
 										},
@@ -1273,34 +1269,34 @@ var g = &grammar{
 								},
 							},
 							&actionExpr{
-								pos: position{line: 109, col: 7, offset: 3567},
+								pos: position{line: 109, col: 7, offset: 3541},
 								run: (*parser).callonUnicodeClassEscape19,
 								expr: &seqExpr{
-									pos: position{line: 109, col: 7, offset: 3567},
+									pos: position{line: 109, col: 7, offset: 3541},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 109, col: 7, offset: 3567},
+											pos:        position{line: 109, col: 7, offset: 3541},
 											val:        "{",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 109, col: 11, offset: 3571},
+											pos:  position{line: 109, col: 11, offset: 3545},
 											name: "IdentifierName",
 										},
 										&choiceExpr{
-											pos: position{line: 109, col: 28, offset: 3588},
+											pos: position{line: 109, col: 28, offset: 3562},
 											alternatives: []interface{}{
 												&litMatcher{
-													pos:        position{line: 109, col: 28, offset: 3588},
+													pos:        position{line: 109, col: 28, offset: 3562},
 													val:        "]",
 													ignoreCase: false,
 												},
 												&ruleRefExpr{
-													pos:  position{line: 109, col: 34, offset: 3594},
+													pos:  position{line: 109, col: 34, offset: 3568},
 													name: "EOL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 109, col: 40, offset: 3600},
+													pos:  position{line: 109, col: 40, offset: 3574},
 													name: "EOF",
 												},
 											},
@@ -1315,9 +1311,9 @@ var g = &grammar{
 		},
 		{
 			name: "SingleCharUnicodeClass",
-			pos:  position{line: 114, col: 1, offset: 3680},
+			pos:  position{line: 114, col: 1, offset: 3654},
 			expr: &charClassMatcher{
-				pos:        position{line: 114, col: 26, offset: 3705},
+				pos:        position{line: 114, col: 26, offset: 3679},
 				val:        "[LMNCPZS]",
 				chars:      []rune{'L', 'M', 'N', 'C', 'P', 'Z', 'S'},
 				ignoreCase: false,
@@ -1326,39 +1322,39 @@ var g = &grammar{
 		},
 		{
 			name: "Number",
-			pos:  position{line: 117, col: 1, offset: 3717},
+			pos:  position{line: 117, col: 1, offset: 3691},
 			expr: &actionExpr{
-				pos: position{line: 117, col: 10, offset: 3726},
+				pos: position{line: 117, col: 10, offset: 3700},
 				run: (*parser).callonNumber1,
 				expr: &seqExpr{
-					pos: position{line: 117, col: 10, offset: 3726},
+					pos: position{line: 117, col: 10, offset: 3700},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 117, col: 10, offset: 3726},
+							pos: position{line: 117, col: 10, offset: 3700},
 							expr: &litMatcher{
-								pos:        position{line: 117, col: 10, offset: 3726},
+								pos:        position{line: 117, col: 10, offset: 3700},
 								val:        "-",
 								ignoreCase: false,
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 117, col: 15, offset: 3731},
+							pos:  position{line: 117, col: 15, offset: 3705},
 							name: "Integer",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 117, col: 23, offset: 3739},
+							pos: position{line: 117, col: 23, offset: 3713},
 							expr: &seqExpr{
-								pos: position{line: 117, col: 25, offset: 3741},
+								pos: position{line: 117, col: 25, offset: 3715},
 								exprs: []interface{}{
 									&litMatcher{
-										pos:        position{line: 117, col: 25, offset: 3741},
+										pos:        position{line: 117, col: 25, offset: 3715},
 										val:        ".",
 										ignoreCase: false,
 									},
 									&oneOrMoreExpr{
-										pos: position{line: 117, col: 29, offset: 3745},
+										pos: position{line: 117, col: 29, offset: 3719},
 										expr: &ruleRefExpr{
-											pos:  position{line: 117, col: 29, offset: 3745},
+											pos:  position{line: 117, col: 29, offset: 3719},
 											name: "Digit",
 										},
 									},
@@ -1371,29 +1367,29 @@ var g = &grammar{
 		},
 		{
 			name: "Integer",
-			pos:  position{line: 121, col: 1, offset: 3797},
+			pos:  position{line: 121, col: 1, offset: 3771},
 			expr: &choiceExpr{
-				pos: position{line: 121, col: 11, offset: 3807},
+				pos: position{line: 121, col: 11, offset: 3781},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 121, col: 11, offset: 3807},
+						pos:        position{line: 121, col: 11, offset: 3781},
 						val:        "0",
 						ignoreCase: false,
 					},
 					&actionExpr{
-						pos: position{line: 121, col: 17, offset: 3813},
+						pos: position{line: 121, col: 17, offset: 3787},
 						run: (*parser).callonInteger3,
 						expr: &seqExpr{
-							pos: position{line: 121, col: 17, offset: 3813},
+							pos: position{line: 121, col: 17, offset: 3787},
 							exprs: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 121, col: 17, offset: 3813},
+									pos:  position{line: 121, col: 17, offset: 3787},
 									name: "NonZeroDigit",
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 121, col: 30, offset: 3826},
+									pos: position{line: 121, col: 30, offset: 3800},
 									expr: &ruleRefExpr{
-										pos:  position{line: 121, col: 30, offset: 3826},
+										pos:  position{line: 121, col: 30, offset: 3800},
 										name: "Digit",
 									},
 								},
@@ -1405,9 +1401,9 @@ var g = &grammar{
 		},
 		{
 			name: "NonZeroDigit",
-			pos:  position{line: 125, col: 1, offset: 3890},
+			pos:  position{line: 125, col: 1, offset: 3864},
 			expr: &charClassMatcher{
-				pos:        position{line: 125, col: 16, offset: 3905},
+				pos:        position{line: 125, col: 16, offset: 3879},
 				val:        "[1-9]",
 				ranges:     []rune{'1', '9'},
 				ignoreCase: false,
@@ -1416,9 +1412,9 @@ var g = &grammar{
 		},
 		{
 			name: "Digit",
-			pos:  position{line: 126, col: 1, offset: 3911},
+			pos:  position{line: 126, col: 1, offset: 3885},
 			expr: &charClassMatcher{
-				pos:        position{line: 126, col: 9, offset: 3919},
+				pos:        position{line: 126, col: 9, offset: 3893},
 				val:        "[0-9]",
 				ranges:     []rune{'0', '9'},
 				ignoreCase: false,
@@ -1427,31 +1423,31 @@ var g = &grammar{
 		},
 		{
 			name: "LabelBlock",
-			pos:  position{line: 128, col: 1, offset: 3926},
+			pos:  position{line: 128, col: 1, offset: 3900},
 			expr: &choiceExpr{
-				pos: position{line: 128, col: 14, offset: 3939},
+				pos: position{line: 128, col: 14, offset: 3913},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 128, col: 14, offset: 3939},
+						pos: position{line: 128, col: 14, offset: 3913},
 						run: (*parser).callonLabelBlock2,
 						expr: &seqExpr{
-							pos: position{line: 128, col: 14, offset: 3939},
+							pos: position{line: 128, col: 14, offset: 3913},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 128, col: 14, offset: 3939},
+									pos:        position{line: 128, col: 14, offset: 3913},
 									val:        "{",
 									ignoreCase: false,
 								},
 								&labeledExpr{
-									pos:   position{line: 128, col: 18, offset: 3943},
+									pos:   position{line: 128, col: 18, offset: 3917},
 									label: "block",
 									expr: &ruleRefExpr{
-										pos:  position{line: 128, col: 24, offset: 3949},
+										pos:  position{line: 128, col: 24, offset: 3923},
 										name: "LabelMatches",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 128, col: 37, offset: 3962},
+									pos:        position{line: 128, col: 37, offset: 3936},
 									val:        "}",
 									ignoreCase: false,
 								},
@@ -1459,22 +1455,22 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 130, col: 5, offset: 3994},
+						pos: position{line: 130, col: 5, offset: 3968},
 						run: (*parser).callonLabelBlock8,
 						expr: &seqExpr{
-							pos: position{line: 130, col: 5, offset: 3994},
+							pos: position{line: 130, col: 5, offset: 3968},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 130, col: 5, offset: 3994},
+									pos:        position{line: 130, col: 5, offset: 3968},
 									val:        "{",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 130, col: 9, offset: 3998},
+									pos:  position{line: 130, col: 9, offset: 3972},
 									name: "LabelMatches",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 130, col: 22, offset: 4011},
+									pos:  position{line: 130, col: 22, offset: 3985},
 									name: "EOF",
 								},
 							},
@@ -1485,12 +1481,12 @@ var g = &grammar{
 		},
 		{
 			name: "NanoSecondUnits",
-			pos:  position{line: 134, col: 1, offset: 4076},
+			pos:  position{line: 134, col: 1, offset: 4050},
 			expr: &actionExpr{
-				pos: position{line: 134, col: 19, offset: 4094},
+				pos: position{line: 134, col: 19, offset: 4068},
 				run: (*parser).callonNanoSecondUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 134, col: 19, offset: 4094},
+					pos:        position{line: 134, col: 19, offset: 4068},
 					val:        "ns",
 					ignoreCase: false,
 				},
@@ -1498,25 +1494,25 @@ var g = &grammar{
 		},
 		{
 			name: "MicroSecondUnits",
-			pos:  position{line: 139, col: 1, offset: 4199},
+			pos:  position{line: 139, col: 1, offset: 4173},
 			expr: &actionExpr{
-				pos: position{line: 139, col: 20, offset: 4218},
+				pos: position{line: 139, col: 20, offset: 4192},
 				run: (*parser).callonMicroSecondUnits1,
 				expr: &choiceExpr{
-					pos: position{line: 139, col: 21, offset: 4219},
+					pos: position{line: 139, col: 21, offset: 4193},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 139, col: 21, offset: 4219},
+							pos:        position{line: 139, col: 21, offset: 4193},
 							val:        "us",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 139, col: 28, offset: 4226},
+							pos:        position{line: 139, col: 28, offset: 4200},
 							val:        "µs",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 139, col: 35, offset: 4234},
+							pos:        position{line: 139, col: 35, offset: 4208},
 							val:        "μs",
 							ignoreCase: false,
 						},
@@ -1526,12 +1522,12 @@ var g = &grammar{
 		},
 		{
 			name: "MilliSecondUnits",
-			pos:  position{line: 144, col: 1, offset: 4343},
+			pos:  position{line: 144, col: 1, offset: 4317},
 			expr: &actionExpr{
-				pos: position{line: 144, col: 20, offset: 4362},
+				pos: position{line: 144, col: 20, offset: 4336},
 				run: (*parser).callonMilliSecondUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 144, col: 20, offset: 4362},
+					pos:        position{line: 144, col: 20, offset: 4336},
 					val:        "ms",
 					ignoreCase: false,
 				},
@@ -1539,12 +1535,12 @@ var g = &grammar{
 		},
 		{
 			name: "SecondUnits",
-			pos:  position{line: 149, col: 1, offset: 4469},
+			pos:  position{line: 149, col: 1, offset: 4443},
 			expr: &actionExpr{
-				pos: position{line: 149, col: 15, offset: 4483},
+				pos: position{line: 149, col: 15, offset: 4457},
 				run: (*parser).callonSecondUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 149, col: 15, offset: 4483},
+					pos:        position{line: 149, col: 15, offset: 4457},
 					val:        "s",
 					ignoreCase: false,
 				},
@@ -1552,12 +1548,12 @@ var g = &grammar{
 		},
 		{
 			name: "MinuteUnits",
-			pos:  position{line: 153, col: 1, offset: 4520},
+			pos:  position{line: 153, col: 1, offset: 4494},
 			expr: &actionExpr{
-				pos: position{line: 153, col: 15, offset: 4534},
+				pos: position{line: 153, col: 15, offset: 4508},
 				run: (*parser).callonMinuteUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 153, col: 15, offset: 4534},
+					pos:        position{line: 153, col: 15, offset: 4508},
 					val:        "m",
 					ignoreCase: false,
 				},
@@ -1565,12 +1561,12 @@ var g = &grammar{
 		},
 		{
 			name: "HourUnits",
-			pos:  position{line: 157, col: 1, offset: 4571},
+			pos:  position{line: 157, col: 1, offset: 4545},
 			expr: &actionExpr{
-				pos: position{line: 157, col: 13, offset: 4583},
+				pos: position{line: 157, col: 13, offset: 4557},
 				run: (*parser).callonHourUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 157, col: 13, offset: 4583},
+					pos:        position{line: 157, col: 13, offset: 4557},
 					val:        "h",
 					ignoreCase: false,
 				},
@@ -1578,12 +1574,12 @@ var g = &grammar{
 		},
 		{
 			name: "DayUnits",
-			pos:  position{line: 161, col: 1, offset: 4618},
+			pos:  position{line: 161, col: 1, offset: 4592},
 			expr: &actionExpr{
-				pos: position{line: 161, col: 12, offset: 4629},
+				pos: position{line: 161, col: 12, offset: 4603},
 				run: (*parser).callonDayUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 161, col: 12, offset: 4629},
+					pos:        position{line: 161, col: 12, offset: 4603},
 					val:        "d",
 					ignoreCase: false,
 				},
@@ -1591,12 +1587,12 @@ var g = &grammar{
 		},
 		{
 			name: "WeekUnits",
-			pos:  position{line: 167, col: 1, offset: 4837},
+			pos:  position{line: 167, col: 1, offset: 4811},
 			expr: &actionExpr{
-				pos: position{line: 167, col: 13, offset: 4849},
+				pos: position{line: 167, col: 13, offset: 4823},
 				run: (*parser).callonWeekUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 167, col: 13, offset: 4849},
+					pos:        position{line: 167, col: 13, offset: 4823},
 					val:        "w",
 					ignoreCase: false,
 				},
@@ -1604,12 +1600,12 @@ var g = &grammar{
 		},
 		{
 			name: "YearUnits",
-			pos:  position{line: 173, col: 1, offset: 5060},
+			pos:  position{line: 173, col: 1, offset: 5034},
 			expr: &actionExpr{
-				pos: position{line: 173, col: 13, offset: 5072},
+				pos: position{line: 173, col: 13, offset: 5046},
 				run: (*parser).callonYearUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 173, col: 13, offset: 5072},
+					pos:        position{line: 173, col: 13, offset: 5046},
 					val:        "y",
 					ignoreCase: false,
 				},
@@ -1617,44 +1613,44 @@ var g = &grammar{
 		},
 		{
 			name: "DurationUnits",
-			pos:  position{line: 179, col: 1, offset: 5269},
+			pos:  position{line: 179, col: 1, offset: 5243},
 			expr: &choiceExpr{
-				pos: position{line: 179, col: 18, offset: 5286},
+				pos: position{line: 179, col: 18, offset: 5260},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 18, offset: 5286},
+						pos:  position{line: 179, col: 18, offset: 5260},
 						name: "NanoSecondUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 36, offset: 5304},
+						pos:  position{line: 179, col: 36, offset: 5278},
 						name: "MicroSecondUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 55, offset: 5323},
+						pos:  position{line: 179, col: 55, offset: 5297},
 						name: "MilliSecondUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 74, offset: 5342},
+						pos:  position{line: 179, col: 74, offset: 5316},
 						name: "SecondUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 88, offset: 5356},
+						pos:  position{line: 179, col: 88, offset: 5330},
 						name: "MinuteUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 102, offset: 5370},
+						pos:  position{line: 179, col: 102, offset: 5344},
 						name: "HourUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 114, offset: 5382},
+						pos:  position{line: 179, col: 114, offset: 5356},
 						name: "DayUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 125, offset: 5393},
+						pos:  position{line: 179, col: 125, offset: 5367},
 						name: "WeekUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 137, offset: 5405},
+						pos:  position{line: 179, col: 137, offset: 5379},
 						name: "YearUnits",
 					},
 				},
@@ -1662,26 +1658,26 @@ var g = &grammar{
 		},
 		{
 			name: "Duration",
-			pos:  position{line: 181, col: 1, offset: 5417},
+			pos:  position{line: 181, col: 1, offset: 5391},
 			expr: &actionExpr{
-				pos: position{line: 181, col: 12, offset: 5428},
+				pos: position{line: 181, col: 12, offset: 5402},
 				run: (*parser).callonDuration1,
 				expr: &seqExpr{
-					pos: position{line: 181, col: 12, offset: 5428},
+					pos: position{line: 181, col: 12, offset: 5402},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 181, col: 12, offset: 5428},
+							pos:   position{line: 181, col: 12, offset: 5402},
 							label: "dur",
 							expr: &ruleRefExpr{
-								pos:  position{line: 181, col: 16, offset: 5432},
+								pos:  position{line: 181, col: 16, offset: 5406},
 								name: "Integer",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 181, col: 24, offset: 5440},
+							pos:   position{line: 181, col: 24, offset: 5414},
 							label: "units",
 							expr: &ruleRefExpr{
-								pos:  position{line: 181, col: 30, offset: 5446},
+								pos:  position{line: 181, col: 30, offset: 5420},
 								name: "DurationUnits",
 							},
 						},
@@ -1691,126 +1687,233 @@ var g = &grammar{
 		},
 		{
 			name: "Operators",
-			pos:  position{line: 187, col: 1, offset: 5595},
+			pos:  position{line: 187, col: 1, offset: 5569},
 			expr: &choiceExpr{
-				pos: position{line: 187, col: 13, offset: 5607},
+				pos: position{line: 187, col: 13, offset: 5581},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 187, col: 13, offset: 5607},
+						pos:        position{line: 187, col: 13, offset: 5581},
 						val:        "-",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 19, offset: 5613},
+						pos:        position{line: 187, col: 19, offset: 5587},
 						val:        "+",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 25, offset: 5619},
+						pos:        position{line: 187, col: 25, offset: 5593},
 						val:        "*",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 31, offset: 5625},
+						pos:        position{line: 187, col: 31, offset: 5599},
 						val:        "%",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 37, offset: 5631},
+						pos:        position{line: 187, col: 37, offset: 5605},
 						val:        "/",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 43, offset: 5637},
+						pos:        position{line: 187, col: 43, offset: 5611},
 						val:        "==",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 50, offset: 5644},
+						pos:        position{line: 187, col: 50, offset: 5618},
 						val:        "!=",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 57, offset: 5651},
+						pos:        position{line: 187, col: 57, offset: 5625},
 						val:        "<=",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 64, offset: 5658},
+						pos:        position{line: 187, col: 64, offset: 5632},
 						val:        "<",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 70, offset: 5664},
+						pos:        position{line: 187, col: 70, offset: 5638},
 						val:        ">=",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 77, offset: 5671},
+						pos:        position{line: 187, col: 77, offset: 5645},
 						val:        ">",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 83, offset: 5677},
+						pos:        position{line: 187, col: 83, offset: 5651},
 						val:        "=~",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 90, offset: 5684},
+						pos:        position{line: 187, col: 90, offset: 5658},
 						val:        "!~",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 97, offset: 5691},
+						pos:        position{line: 187, col: 97, offset: 5665},
 						val:        "^",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 103, offset: 5697},
+						pos:        position{line: 187, col: 103, offset: 5671},
 						val:        "=",
 						ignoreCase: false,
 					},
 				},
 			},
 		},
+		{
+			name: "ComparisonOperators",
+			pos:  position{line: 189, col: 1, offset: 5676},
+			expr: &actionExpr{
+				pos: position{line: 189, col: 23, offset: 5698},
+				run: (*parser).callonComparisonOperators1,
+				expr: &choiceExpr{
+					pos: position{line: 189, col: 25, offset: 5700},
+					alternatives: []interface{}{
+						&litMatcher{
+							pos:        position{line: 189, col: 25, offset: 5700},
+							val:        "==",
+							ignoreCase: false,
+						},
+						&litMatcher{
+							pos:        position{line: 189, col: 32, offset: 5707},
+							val:        "!=",
+							ignoreCase: false,
+						},
+						&litMatcher{
+							pos:        position{line: 189, col: 39, offset: 5714},
+							val:        "<=",
+							ignoreCase: false,
+						},
+						&litMatcher{
+							pos:        position{line: 189, col: 46, offset: 5721},
+							val:        "<",
+							ignoreCase: false,
+						},
+						&litMatcher{
+							pos:        position{line: 189, col: 52, offset: 5727},
+							val:        ">=",
+							ignoreCase: false,
+						},
+						&litMatcher{
+							pos:        position{line: 189, col: 59, offset: 5734},
+							val:        ">",
+							ignoreCase: false,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AdditiveOperators",
+			pos:  position{line: 193, col: 1, offset: 5776},
+			expr: &actionExpr{
+				pos: position{line: 193, col: 21, offset: 5796},
+				run: (*parser).callonAdditiveOperators1,
+				expr: &choiceExpr{
+					pos: position{line: 193, col: 23, offset: 5798},
+					alternatives: []interface{}{
+						&litMatcher{
+							pos:        position{line: 193, col: 23, offset: 5798},
+							val:        "+",
+							ignoreCase: false,
+						},
+						&litMatcher{
+							pos:        position{line: 193, col: 29, offset: 5804},
+							val:        "-",
+							ignoreCase: false,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "MultiplicativeOperators",
+			pos:  position{line: 197, col: 1, offset: 5846},
+			expr: &actionExpr{
+				pos: position{line: 197, col: 27, offset: 5872},
+				run: (*parser).callonMultiplicativeOperators1,
+				expr: &choiceExpr{
+					pos: position{line: 197, col: 29, offset: 5874},
+					alternatives: []interface{}{
+						&litMatcher{
+							pos:        position{line: 197, col: 29, offset: 5874},
+							val:        "*",
+							ignoreCase: false,
+						},
+						&litMatcher{
+							pos:        position{line: 197, col: 35, offset: 5880},
+							val:        "/",
+							ignoreCase: false,
+						},
+						&litMatcher{
+							pos:        position{line: 197, col: 41, offset: 5886},
+							val:        "%",
+							ignoreCase: false,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PowerOperator",
+			pos:  position{line: 201, col: 1, offset: 5928},
+			expr: &actionExpr{
+				pos: position{line: 201, col: 17, offset: 5944},
+				run: (*parser).callonPowerOperator1,
+				expr: &litMatcher{
+					pos:        position{line: 201, col: 17, offset: 5944},
+					val:        "^",
+					ignoreCase: false,
+				},
+			},
+		},
 		{
 			name: "LabelOperators",
-			pos:  position{line: 189, col: 1, offset: 5702},
+			pos:  position{line: 205, col: 1, offset: 5984},
 			expr: &choiceExpr{
-				pos: position{line: 189, col: 19, offset: 5720},
+				pos: position{line: 205, col: 19, offset: 6002},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 189, col: 19, offset: 5720},
+						pos: position{line: 205, col: 19, offset: 6002},
 						run: (*parser).callonLabelOperators2,
 						expr: &litMatcher{
-							pos:        position{line: 189, col: 19, offset: 5720},
+							pos:        position{line: 205, col: 19, offset: 6002},
 							val:        "!=",
 							ignoreCase: false,
 						},
 					},
 					&actionExpr{
-						pos: position{line: 191, col: 5, offset: 5756},
+						pos: position{line: 207, col: 5, offset: 6038},
 						run: (*parser).callonLabelOperators4,
 						expr: &litMatcher{
-							pos:        position{line: 191, col: 5, offset: 5756},
+							pos:        position{line: 207, col: 5, offset: 6038},
 							val:        "=~",
 							ignoreCase: false,
 						},
 					},
 					&actionExpr{
-						pos: position{line: 193, col: 5, offset: 5794},
+						pos: position{line: 209, col: 5, offset: 6076},
 						run: (*parser).callonLabelOperators6,
 						expr: &litMatcher{
-							pos:        position{line: 193, col: 5, offset: 5794},
+							pos:        position{line: 209, col: 5, offset: 6076},
 							val:        "!~",
 							ignoreCase: false,
 						},
 					},
 					&actionExpr{
-						pos: position{line: 195, col: 5, offset: 5834},
+						pos: position{line: 211, col: 5, offset: 6116},
 						run: (*parser).callonLabelOperators8,
 						expr: &litMatcher{
-							pos:        position{line: 195, col: 5, offset: 5834},
+							pos:        position{line: 211, col: 5, offset: 6116},
 							val:        "=",
 							ignoreCase: false,
 						},
@@ -1820,57 +1923,57 @@ var g = &grammar{
 		},
 		{
 			name: "Label",
-			pos:  position{line: 199, col: 1, offset: 5865},
+			pos:  position{line: 215, col: 1, offset: 6147},
 			expr: &ruleRefExpr{
-				pos:  position{line: 199, col: 9, offset: 5873},
+				pos:  position{line: 215, col: 9, offset: 6155},
 				name: "Identifier",
 			},
 		},
 		{
 			name: "LabelMatch",
-			pos:  position{line: 200, col: 1, offset: 5884},
+			pos:  position{line: 216, col: 1, offset: 6166},
 			expr: &actionExpr{
-				pos: position{line: 200, col: 14, offset: 5897},
+				pos: position{line: 216, col: 14, offset: 6179},
 				run: (*parser).callonLabelMatch1,
 				expr: &seqExpr{
-					pos: position{line: 200, col: 14, offset: 5897},
+					pos: position{line: 216, col: 14, offset: 6179},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 200, col: 14, offset: 5897},
+							pos:   position{line: 216, col: 14, offset: 6179},
 							label: "label",
 							expr: &ruleRefExpr{
-								pos:  position{line: 200, col: 20, offset: 5903},
+								pos:  position{line: 216, col: 20, offset: 6185},
 								name: "Label",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 200, col: 26, offset: 5909},
+							pos:  position{line: 216, col: 26, offset: 6191},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 200, col: 29, offset: 5912},
+							pos:   position{line: 216, col: 29, offset: 6194},
 							label: "op",
 							expr: &ruleRefExpr{
-								pos:  position{line: 200, col: 32, offset: 5915},
+								pos:  position{line: 216, col: 32, offset: 6197},
 								name: "LabelOperators",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 200, col: 47, offset: 5930},
+							pos:  position{line: 216, col: 47, offset: 6212},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 200, col: 50, offset: 5933},
+							pos:   position{line: 216, col: 50, offset: 6215},
 							label: "match",
 							expr: &choiceExpr{
-								pos: position{line: 200, col: 58, offset: 5941},
+								pos: position{line: 216, col: 58, offset: 6223},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 200, col: 58, offset: 5941},
+										pos:  position{line: 216, col: 58, offset: 6223},
 										name: "StringLiteral",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 200, col: 74, offset: 5957},
+										pos:  position{line: 216, col: 74, offset: 6239},
 										name: "Number",
 									},
 								},
@@ -1882,32 +1985,32 @@ var g = &grammar{
 		},
 		{
 			name: "LabelMatches",
-			pos:  position{line: 203, col: 1, offset: 6047},
+			pos:  position{line: 219, col: 1, offset: 6329},
 			expr: &actionExpr{
-				pos: position{line: 203, col: 16, offset: 6062},
+				pos: position{line: 219, col: 16, offset: 6344},
 				run: (*parser).callonLabelMatches1,
 				expr: &seqExpr{
-					pos: position{line: 203, col: 16, offset: 6062},
+					pos: position{line: 219, col: 16, offset: 6344},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 203, col: 16, offset: 6062},
+							pos:   position{line: 219, col: 16, offset: 6344},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 203, col: 22, offset: 6068},
+								pos:  position{line: 219, col: 22, offset: 6350},
 								name: "LabelMatch",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 203, col: 33, offset: 6079},
+							pos:  position{line: 219, col: 33, offset: 6361},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 203, col: 36, offset: 6082},
+							pos:   position{line: 219, col: 36, offset: 6364},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 203, col: 41, offset: 6087},
+								pos: position{line: 219, col: 41, offset: 6369},
 								expr: &ruleRefExpr{
-									pos:  position{line: 203, col: 41, offset: 6087},
+									pos:  position{line: 219, col: 41, offset: 6369},
 									name: "LabelMatchesRest",
 								},
 							},
@@ -1918,27 +2021,27 @@ var g = &grammar{
 		},
 		{
 			name: "LabelMatchesRest",
-			pos:  position{line: 207, col: 1, offset: 6166},
+			pos:  position{line: 223, col: 1, offset: 6448},
 			expr: &actionExpr{
-				pos: position{line: 207, col: 21, offset: 6186},
+				pos: position{line: 223, col: 21, offset: 6468},
 				run: (*parser).callonLabelMatchesRest1,
 				expr: &seqExpr{
-					pos: position{line: 207, col: 21, offset: 6186},
+					pos: position{line: 223, col: 21, offset: 6468},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 207, col: 21, offset: 6186},
+							pos:        position{line: 223, col: 21, offset: 6468},
 							val:        ",",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 207, col: 25, offset: 6190},
+							pos:  position{line: 223, col: 25, offset: 6472},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 207, col: 28, offset: 6193},
+							pos:   position{line: 223, col: 28, offset: 6475},
 							label: "match",
 							expr: &ruleRefExpr{
-								pos:  position{line: 207, col: 34, offset: 6199},
+								pos:  position{line: 223, col: 34, offset: 6481},
 								name: "LabelMatch",
 							},
 						},
@@ -1948,27 +2051,27 @@ var g = &grammar{
 		},
 		{
 			name: "LabelList",
-			pos:  position{line: 211, col: 1, offset: 6237},
+			pos:  position{line: 227, col: 1, offset: 6519},
 			expr: &choiceExpr{
-				pos: position{line: 211, col: 13, offset: 6249},
+				pos: position{line: 227, col: 13, offset: 6531},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 211, col: 13, offset: 6249},
+						pos: position{line: 227, col: 13, offset: 6531},
 						run: (*parser).callonLabelList2,
 						expr: &seqExpr{
-							pos: position{line: 211, col: 14, offset: 6250},
+							pos: position{line: 227, col: 14, offset: 6532},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 211, col: 14, offset: 6250},
+									pos:        position{line: 227, col: 14, offset: 6532},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 211, col: 18, offset: 6254},
+									pos:  position{line: 227, col: 18, offset: 6536},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 211, col: 21, offset: 6257},
+									pos:        position{line: 227, col: 21, offset: 6539},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -1976,49 +2079,49 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 213, col: 6, offset: 6289},
+						pos: position{line: 229, col: 6, offset: 6571},
 						run: (*parser).callonLabelList7,
 						expr: &seqExpr{
-							pos: position{line: 213, col: 6, offset: 6289},
+							pos: position{line: 229, col: 6, offset: 6571},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 213, col: 6, offset: 6289},
+									pos:        position{line: 229, col: 6, offset: 6571},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 213, col: 10, offset: 6293},
+									pos:  position{line: 229, col: 10, offset: 6575},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 213, col: 13, offset: 6296},
+									pos:   position{line: 229, col: 13, offset: 6578},
 									label: "label",
 									expr: &ruleRefExpr{
-										pos:  position{line: 213, col: 19, offset: 6302},
+										pos:  position{line: 229, col: 19, offset: 6584},
 										name: "Label",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 213, col: 25, offset: 6308},
+									pos:  position{line: 229, col: 25, offset: 6590},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 213, col: 28, offset: 6311},
+									pos:   position{line: 229, col: 28, offset: 6593},
 									label: "rest",
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 213, col: 33, offset: 6316},
+										pos: position{line: 229, col: 33, offset: 6598},
 										expr: &ruleRefExpr{
-											pos:  position{line: 213, col: 33, offset: 6316},
+											pos:  position{line: 229, col: 33, offset: 6598},
 											name: "LabelListRest",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 213, col: 48, offset: 6331},
+									pos:  position{line: 229, col: 48, offset: 6613},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 213, col: 51, offset: 6334},
+									pos:        position{line: 229, col: 51, offset: 6616},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -2030,27 +2133,27 @@ var g = &grammar{
 		},
 		{
 			name: "LabelListRest",
-			pos:  position{line: 217, col: 1, offset: 6400},
+			pos:  position{line: 233, col: 1, offset: 6682},
 			expr: &actionExpr{
-				pos: position{line: 217, col: 18, offset: 6417},
+				pos: position{line: 233, col: 18, offset: 6699},
 				run: (*parser).callonLabelListRest1,
 				expr: &seqExpr{
-					pos: position{line: 217, col: 18, offset: 6417},
+					pos: position{line: 233, col: 18, offset: 6699},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 217, col: 18, offset: 6417},
+							pos:        position{line: 233, col: 18, offset: 6699},
 							val:        ",",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 217, col: 22, offset: 6421},
+							pos:  position{line: 233, col: 22, offset: 6703},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 217, col: 25, offset: 6424},
+							pos:   position{line: 233, col: 25, offset: 6706},
 							label: "label",
 							expr: &ruleRefExpr{
-								pos:  position{line: 217, col: 31, offset: 6430},
+								pos:  position{line: 233, col: 31, offset: 6712},
 								name: "Label",
 							},
 						},
@@ -2060,62 +2163,62 @@ var g = &grammar{
 		},
 		{
 			name: "VectorSelector",
-			pos:  position{line: 221, col: 1, offset: 6463},
+			pos:  position{line: 237, col: 1, offset: 6745},
 			expr: &actionExpr{
-				pos: position{line: 221, col: 18, offset: 6480},
+				pos: position{line: 237, col: 18, offset: 6762},
 				run: (*parser).callonVectorSelector1,
 				expr: &seqExpr{
-					pos: position{line: 221, col: 18, offset: 6480},
+					pos: position{line: 237, col: 18, offset: 6762},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 221, col: 18, offset: 6480},
+							pos:   position{line: 237, col: 18, offset: 6762},
 							label: "metric",
 							expr: &ruleRefExpr{
-								pos:  position{line: 221, col: 25, offset: 6487},
+								pos:  position{line: 237, col: 25, offset: 6769},
 								name: "Identifier",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 221, col: 36, offset: 6498},
+							pos:  position{line: 237, col: 36, offset: 6780},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 221, col: 40, offset: 6502},
+							pos:   position{line: 237, col: 40, offset: 6784},
 							label: "block",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 221, col: 46, offset: 6508},
+								pos: position{line: 237, col: 46, offset: 6790},
 								expr: &ruleRefExpr{
-									pos:  position{line: 221, col: 46, offset: 6508},
+									pos:  position{line: 237, col: 46, offset: 6790},
 									name: "LabelBlock",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 221, col: 58, offset: 6520},
+							pos:  position{line: 237, col: 58, offset: 6802},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 221, col: 61, offset: 6523},
+							pos:   position{line: 237, col: 61, offset: 6805},
 							label: "rng",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 221, col: 65, offset: 6527},
+								pos: position{line: 237, col: 65, offset: 6809},
 								expr: &ruleRefExpr{
-									pos:  position{line: 221, col: 65, offset: 6527},
+									pos:  position{line: 237, col: 65, offset: 6809},
 									name: "Range",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 221, col: 72, offset: 6534},
+							pos:  position{line: 237, col: 72, offset: 6816},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 221, col: 75, offset: 6537},
+							pos:   position{line: 237, col: 75, offset: 6819},
 							label: "offset",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 221, col: 82, offset: 6544},
+								pos: position{line: 237, col: 82, offset: 6826},
 								expr: &ruleRefExpr{
-									pos:  position{line: 221, col: 82, offset: 6544},
+									pos:  position{line: 237, col: 82, offset: 6826},
 									name: "Offset",
 								},
 							},
@@ -2126,36 +2229,36 @@ var g = &grammar{
 		},
 		{
 			name: "Range",
-			pos:  position{line: 225, col: 1, offset: 6622},
+			pos:  position{line: 241, col: 1, offset: 6904},
 			expr: &actionExpr{
-				pos: position{line: 225, col: 9, offset: 6630},
+				pos: position{line: 241, col: 9, offset: 6912},
 				run: (*parser).callonRange1,
 				expr: &seqExpr{
-					pos: position{line: 225, col: 9, offset: 6630},
+					pos: position{line: 241, col: 9, offset: 6912},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 225, col: 9, offset: 6630},
+							pos:        position{line: 241, col: 9, offset: 6912},
 							val:        "[",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 225, col: 13, offset: 6634},
+							pos:  position{line: 241, col: 13, offset: 6916},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 225, col: 16, offset: 6637},
+							pos:   position{line: 241, col: 16, offset: 6919},
 							label: "dur",
 							expr: &ruleRefExpr{
-								pos:  position{line: 225, col: 20, offset: 6641},
+								pos:  position{line: 241, col: 20, offset: 6923},
 								name: "Duration",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 225, col: 29, offset: 6650},
+							pos:  position{line: 241, col: 29, offset: 6932},
 							name: "__",
 						},
 						&litMatcher{
-							pos:        position{line: 225, col: 32, offset: 6653},
+							pos:        position{line: 241, col: 32, offset: 6935},
 							val:        "]",
 							ignoreCase: false,
 						},
@@ -2165,27 +2268,27 @@ var g = &grammar{
 		},
 		{
 			name: "Offset",
-			pos:  position{line: 229, col: 1, offset: 6682},
+			pos:  position{line: 245, col: 1, offset: 6964},
 			expr: &actionExpr{
-				pos: position{line: 229, col: 10, offset: 6691},
+				pos: position{line: 245, col: 10, offset: 6973},
 				run: (*parser).callonOffset1,
 				expr: &seqExpr{
-					pos: position{line: 229, col: 10, offset: 6691},
+					pos: position{line: 245, col: 10, offset: 6973},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 229, col: 10, offset: 6691},
+							pos:        position{line: 245, col: 10, offset: 6973},
 							val:        "offset",
 							ignoreCase: true,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 229, col: 20, offset: 6701},
+							pos:  position{line: 245, col: 20, offset: 6983},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 229, col: 23, offset: 6704},
+							pos:   position{line: 245, col: 23, offset: 6986},
 							label: "dur",
 							expr: &ruleRefExpr{
-								pos:  position{line: 229, col: 27, offset: 6708},
+								pos:  position{line: 245, col: 27, offset: 6990},
 								name: "Duration",
 							},
 						},
@@ -2194,298 +2297,708 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "CountValueOperator",
-			pos:  position{line: 233, col: 1, offset: 6742},
-			expr: &actionExpr{
-				pos: position{line: 233, col: 22, offset: 6763},
-				run: (*parser).callonCountValueOperator1,
-				expr: &litMatcher{
-					pos:        position{line: 233, col: 22, offset: 6763},
-					val:        "count_values",
-					ignoreCase: true,
-				},
-			},
-		},
-		{
-			name: "BinaryAggregateOperators",
-			pos:  position{line: 239, col: 1, offset: 6848},
-			expr: &actionExpr{
-				pos: position{line: 239, col: 29, offset: 6876},
-				run: (*parser).callonBinaryAggregateOperators1,
-				expr: &labeledExpr{
-					pos:   position{line: 239, col: 29, offset: 6876},
-					label: "op",
-					expr: &choiceExpr{
-						pos: position{line: 239, col: 33, offset: 6880},
-						alternatives: []interface{}{
-							&litMatcher{
-								pos:        position{line: 239, col: 33, offset: 6880},
-								val:        "topk",
-								ignoreCase: true,
-							},
-							&litMatcher{
-								pos:        position{line: 239, col: 43, offset: 6890},
-								val:        "bottomk",
-								ignoreCase: true,
-							},
-							&litMatcher{
-								pos:        position{line: 239, col: 56, offset: 6903},
-								val:        "quantile",
-								ignoreCase: true,
-							},
-						},
-					},
-				},
-			},
-		},
-		{
-			name: "UnaryAggregateOperators",
-			pos:  position{line: 245, col: 1, offset: 7005},
-			expr: &actionExpr{
-				pos: position{line: 245, col: 27, offset: 7031},
-				run: (*parser).callonUnaryAggregateOperators1,
-				expr: &labeledExpr{
-					pos:   position{line: 245, col: 27, offset: 7031},
-					label: "op",
-					expr: &choiceExpr{
-						pos: position{line: 245, col: 31, offset: 7035},
-						alternatives: []interface{}{
-							&litMatcher{
-								pos:        position{line: 245, col: 31, offset: 7035},
-								val:        "sum",
-								ignoreCase: true,
-							},
-							&litMatcher{
-								pos:        position{line: 245, col: 40, offset: 7044},
-								val:        "min",
-								ignoreCase: true,
-							},
-							&litMatcher{
-								pos:        position{line: 245, col: 49, offset: 7053},
-								val:        "max",
-								ignoreCase: true,
-							},
-							&litMatcher{
-								pos:        position{line: 245, col: 58, offset: 7062},
-								val:        "avg",
-								ignoreCase: true,
-							},
-							&litMatcher{
-								pos:        position{line: 245, col: 67, offset: 7071},
-								val:        "stddev",
-								ignoreCase: true,
-							},
-							&litMatcher{
-								pos:        position{line: 245, col: 79, offset: 7083},
-								val:        "stdvar",
-								ignoreCase: true,
-							},
-							&litMatcher{
-								pos:        position{line: 245, col: 91, offset: 7095},
-								val:        "count",
-								ignoreCase: true,
-							},
-						},
-					},
-				},
-			},
-		},
-		{
-			name: "AggregateOperators",
-			pos:  position{line: 251, col: 1, offset: 7194},
+			name: "RangeFunctionNames",
+			pos:  position{line: 249, col: 1, offset: 7024},
 			expr: &choiceExpr{
-				pos: position{line: 251, col: 22, offset: 7215},
+				pos: position{line: 249, col: 22, offset: 7045},
 				alternatives: []interface{}{
-					&ruleRefExpr{
-						pos:  position{line: 251, col: 22, offset: 7215},
-						name: "CountValueOperator",
+					&litMatcher{
+						pos:        position{line: 249, col: 22, offset: 7045},
+						val:        "rate",
+						ignoreCase: false,
 					},
-					&ruleRefExpr{
-						pos:  position{line: 251, col: 43, offset: 7236},
-						name: "BinaryAggregateOperators",
+					&litMatcher{
+						pos:        position{line: 249, col: 31, offset: 7054},
+						val:        "irate",
+						ignoreCase: false,
 					},
-					&ruleRefExpr{
-						pos:  position{line: 251, col: 70, offset: 7263},
-						name: "UnaryAggregateOperators",
+					&litMatcher{
+						pos:        position{line: 249, col: 41, offset: 7064},
+						val:        "increase",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 249, col: 54, offset: 7077},
+						val:        "delta",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 249, col: 64, offset: 7087},
+						val:        "deriv",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 249, col: 74, offset: 7097},
+						val:        "avg_over_time",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 249, col: 92, offset: 7115},
+						val:        "min_over_time",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 249, col: 110, offset: 7133},
+						val:        "max_over_time",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 249, col: 128, offset: 7151},
+						val:        "sum_over_time",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 249, col: 146, offset: 7169},
+						val:        "count_over_time",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 249, col: 166, offset: 7189},
+						val:        "last_over_time",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 249, col: 185, offset: 7208},
+						val:        "stddev_over_time",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 249, col: 206, offset: 7229},
+						val:        "stdvar_over_time",
+						ignoreCase: false,
 					},
 				},
 			},
 		},
 		{
-			name: "AggregateBy",
-			pos:  position{line: 253, col: 1, offset: 7288},
+			name: "RangeFunctionExpression",
+			pos:  position{line: 251, col: 1, offset: 7249},
 			expr: &actionExpr{
-				pos: position{line: 253, col: 15, offset: 7302},
-				run: (*parser).callonAggregateBy1,
+				pos: position{line: 251, col: 27, offset: 7275},
+				run: (*parser).callonRangeFunctionExpression1,
 				expr: &seqExpr{
-					pos: position{line: 253, col: 15, offset: 7302},
+					pos: position{line: 251, col: 27, offset: 7275},
 					exprs: []interface{}{
-						&litMatcher{
-							pos:        position{line: 253, col: 15, offset: 7302},
-							val:        "by",
-							ignoreCase: true,
-						},
-						&ruleRefExpr{
-							pos:  position{line: 253, col: 21, offset: 7308},
-							name: "__",
-						},
 						&labeledExpr{
-							pos:   position{line: 253, col: 24, offset: 7311},
-							label: "labels",
+							pos:   position{line: 251, col: 27, offset: 7275},
+							label: "fn",
 							expr: &ruleRefExpr{
-								pos:  position{line: 253, col: 31, offset: 7318},
-								name: "LabelList",
+								pos:  position{line: 251, col: 30, offset: 7278},
+								name: "RangeFunctionNames",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 253, col: 41, offset: 7328},
+							pos:  position{line: 251, col: 49, offset: 7297},
 							name: "__",
 						},
-						&labeledExpr{
-							pos:   position{line: 253, col: 44, offset: 7331},
-							label: "keep",
-							expr: &zeroOrOneExpr{
-								pos: position{line: 253, col: 49, offset: 7336},
-								expr: &litMatcher{
-									pos:        position{line: 253, col: 49, offset: 7336},
-									val:        "keep_common",
-									ignoreCase: true,
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-		{
-			name: "AggregateWithout",
-			pos:  position{line: 260, col: 1, offset: 7449},
-			expr: &actionExpr{
-				pos: position{line: 260, col: 20, offset: 7468},
-				run: (*parser).callonAggregateWithout1,
-				expr: &seqExpr{
-					pos: position{line: 260, col: 20, offset: 7468},
-					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 260, col: 20, offset: 7468},
-							val:        "without",
-							ignoreCase: true,
+							pos:        position{line: 251, col: 52, offset: 7300},
+							val:        "(",
+							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 260, col: 31, offset: 7479},
+							pos:  position{line: 251, col: 56, offset: 7304},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 260, col: 34, offset: 7482},
-							label: "labels",
+							pos:   position{line: 251, col: 59, offset: 7307},
+							label: "vector",
 							expr: &ruleRefExpr{
-								pos:  position{line: 260, col: 41, offset: 7489},
-								name: "LabelList",
+								pos:  position{line: 251, col: 66, offset: 7314},
+								name: "VectorSelector",
 							},
 						},
+						&ruleRefExpr{
+							pos:  position{line: 251, col: 81, offset: 7329},
+							name: "__",
+						},
+						&litMatcher{
+							pos:        position{line: 251, col: 84, offset: 7332},
+							val:        ")",
+							ignoreCase: false,
+						},
 					},
 				},
 			},
 		},
 		{
-			name: "AggregateGroup",
-			pos:  position{line: 267, col: 1, offset: 7601},
+			name: "InstantFunctionNames",
+			pos:  position{line: 255, col: 1, offset: 7410},
 			expr: &choiceExpr{
-				pos: position{line: 267, col: 18, offset: 7618},
+				pos: position{line: 255, col: 24, offset: 7433},
 				alternatives: []interface{}{
-					&ruleRefExpr{
-						pos:  position{line: 267, col: 18, offset: 7618},
-						name: "AggregateBy",
+					&litMatcher{
+						pos:        position{line: 255, col: 24, offset: 7433},
+						val:        "abs",
+						ignoreCase: false,
 					},
-					&ruleRefExpr{
-						pos:  position{line: 267, col: 32, offset: 7632},
-						name: "AggregateWithout",
+					&litMatcher{
+						pos:        position{line: 255, col: 32, offset: 7441},
+						val:        "ceil",
+						ignoreCase: false,
 					},
-				},
-			},
-		},
-		{
-			name: "AggregateExpression",
-			pos:  position{line: 269, col: 1, offset: 7650},
-			expr: &choiceExpr{
-				pos: position{line: 270, col: 1, offset: 7672},
-				alternatives: []interface{}{
-					&actionExpr{
-						pos: position{line: 270, col: 1, offset: 7672},
-						run: (*parser).callonAggregateExpression2,
-						expr: &seqExpr{
-							pos: position{line: 270, col: 1, offset: 7672},
-							exprs: []interface{}{
-								&labeledExpr{
-									pos:   position{line: 270, col: 1, offset: 7672},
-									label: "op",
-									expr: &ruleRefExpr{
-										pos:  position{line: 270, col: 4, offset: 7675},
-										name: "CountValueOperator",
-									},
-								},
-								&ruleRefExpr{
-									pos:  position{line: 270, col: 24, offset: 7695},
-									name: "__",
-								},
+					&litMatcher{
+						pos:        position{line: 255, col: 41, offset: 7450},
+						val:        "floor",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 255, col: 51, offset: 7460},
+						val:        "exp",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 255, col: 59, offset: 7468},
+						val:        "ln",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 255, col: 66, offset: 7475},
+						val:        "log2",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 255, col: 75, offset: 7484},
+						val:        "log10",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 255, col: 85, offset: 7494},
+						val:        "sqrt",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 255, col: 94, offset: 7503},
+						val:        "round",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 255, col: 104, offset: 7513},
+						val:        "clamp_max",
+						ignoreCase: false,
+					},
+					&litMatcher{
+						pos:        position{line: 255, col: 118, offset: 7527},
+						val:        "clamp_min",
+						ignoreCase: false,
+					},
+				},
+			},
+		},
+		{
+			name: "InstantFunctionExpression",
+			pos:  position{line: 257, col: 1, offset: 7540},
+			expr: &actionExpr{
+				pos: position{line: 257, col: 29, offset: 7568},
+				run: (*parser).callonInstantFunctionExpression1,
+				expr: &seqExpr{
+					pos: position{line: 257, col: 29, offset: 7568},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 257, col: 29, offset: 7568},
+							label: "fn",
+							expr: &ruleRefExpr{
+								pos:  position{line: 257, col: 32, offset: 7571},
+								name: "InstantFunctionNames",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 257, col: 53, offset: 7592},
+							name: "__",
+						},
+						&litMatcher{
+							pos:        position{line: 257, col: 56, offset: 7595},
+							val:        "(",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 257, col: 60, offset: 7599},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 257, col: 63, offset: 7602},
+							label: "vector",
+							expr: &ruleRefExpr{
+								pos:  position{line: 257, col: 70, offset: 7609},
+								name: "Expression",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 257, col: 81, offset: 7620},
+							label: "args",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 257, col: 86, offset: 7625},
+								expr: &seqExpr{
+									pos: position{line: 257, col: 88, offset: 7627},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 257, col: 88, offset: 7627},
+											name: "__",
+										},
+										&litMatcher{
+											pos:        position{line: 257, col: 91, offset: 7630},
+											val:        ",",
+											ignoreCase: false,
+										},
+										&ruleRefExpr{
+											pos:  position{line: 257, col: 95, offset: 7634},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 257, col: 98, offset: 7637},
+											name: "Number",
+										},
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 257, col: 108, offset: 7647},
+							name: "__",
+						},
+						&litMatcher{
+							pos:        position{line: 257, col: 111, offset: 7650},
+							val:        ")",
+							ignoreCase: false,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ScalarFunctionExpression",
+			pos:  position{line: 261, col: 1, offset: 7724},
+			expr: &choiceExpr{
+				pos: position{line: 261, col: 28, offset: 7751},
+				alternatives: []interface{}{
+					&actionExpr{
+						pos: position{line: 261, col: 28, offset: 7751},
+						run: (*parser).callonScalarFunctionExpression2,
+						expr: &seqExpr{
+							pos: position{line: 261, col: 28, offset: 7751},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 261, col: 28, offset: 7751},
+									val:        "time",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 261, col: 35, offset: 7758},
+									name: "__",
+								},
+								&litMatcher{
+									pos:        position{line: 261, col: 38, offset: 7761},
+									val:        "(",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 261, col: 42, offset: 7765},
+									name: "__",
+								},
+								&litMatcher{
+									pos:        position{line: 261, col: 45, offset: 7768},
+									val:        ")",
+									ignoreCase: false,
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 263, col: 5, offset: 7820},
+						run: (*parser).callonScalarFunctionExpression9,
+						expr: &seqExpr{
+							pos: position{line: 263, col: 5, offset: 7820},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 263, col: 5, offset: 7820},
+									val:        "scalar",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 263, col: 14, offset: 7829},
+									name: "__",
+								},
+								&litMatcher{
+									pos:        position{line: 263, col: 17, offset: 7832},
+									val:        "(",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 263, col: 21, offset: 7836},
+									name: "__",
+								},
+								&labeledExpr{
+									pos:   position{line: 263, col: 24, offset: 7839},
+									label: "vector",
+									expr: &ruleRefExpr{
+										pos:  position{line: 263, col: 31, offset: 7846},
+										name: "Expression",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 263, col: 42, offset: 7857},
+									name: "__",
+								},
+								&litMatcher{
+									pos:        position{line: 263, col: 45, offset: 7860},
+									val:        ")",
+									ignoreCase: false,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "VectorFunctionExpression",
+			pos:  position{line: 267, col: 1, offset: 7916},
+			expr: &actionExpr{
+				pos: position{line: 267, col: 28, offset: 7943},
+				run: (*parser).callonVectorFunctionExpression1,
+				expr: &seqExpr{
+					pos: position{line: 267, col: 28, offset: 7943},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 267, col: 28, offset: 7943},
+							val:        "vector",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 267, col: 37, offset: 7952},
+							name: "__",
+						},
+						&litMatcher{
+							pos:        position{line: 267, col: 40, offset: 7955},
+							val:        "(",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 267, col: 44, offset: 7959},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 267, col: 47, offset: 7962},
+							label: "scalar",
+							expr: &ruleRefExpr{
+								pos:  position{line: 267, col: 54, offset: 7969},
+								name: "Expression",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 267, col: 65, offset: 7980},
+							name: "__",
+						},
+						&litMatcher{
+							pos:        position{line: 267, col: 68, offset: 7983},
+							val:        ")",
+							ignoreCase: false,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AggregateVector",
+			pos:  position{line: 271, col: 1, offset: 8029},
+			expr: &ruleRefExpr{
+				pos:  position{line: 271, col: 19, offset: 8047},
+				name: "Expression",
+			},
+		},
+		{
+			name: "CountValueOperator",
+			pos:  position{line: 273, col: 1, offset: 8059},
+			expr: &actionExpr{
+				pos: position{line: 273, col: 22, offset: 8080},
+				run: (*parser).callonCountValueOperator1,
+				expr: &litMatcher{
+					pos:        position{line: 273, col: 22, offset: 8080},
+					val:        "count_values",
+					ignoreCase: true,
+				},
+			},
+		},
+		{
+			name: "BinaryAggregateOperators",
+			pos:  position{line: 279, col: 1, offset: 8165},
+			expr: &actionExpr{
+				pos: position{line: 279, col: 29, offset: 8193},
+				run: (*parser).callonBinaryAggregateOperators1,
+				expr: &labeledExpr{
+					pos:   position{line: 279, col: 29, offset: 8193},
+					label: "op",
+					expr: &choiceExpr{
+						pos: position{line: 279, col: 33, offset: 8197},
+						alternatives: []interface{}{
+							&litMatcher{
+								pos:        position{line: 279, col: 33, offset: 8197},
+								val:        "topk",
+								ignoreCase: true,
+							},
+							&litMatcher{
+								pos:        position{line: 279, col: 43, offset: 8207},
+								val:        "bottomk",
+								ignoreCase: true,
+							},
+							&litMatcher{
+								pos:        position{line: 279, col: 56, offset: 8220},
+								val:        "quantile",
+								ignoreCase: true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "UnaryAggregateOperators",
+			pos:  position{line: 285, col: 1, offset: 8322},
+			expr: &actionExpr{
+				pos: position{line: 285, col: 27, offset: 8348},
+				run: (*parser).callonUnaryAggregateOperators1,
+				expr: &labeledExpr{
+					pos:   position{line: 285, col: 27, offset: 8348},
+					label: "op",
+					expr: &choiceExpr{
+						pos: position{line: 285, col: 31, offset: 8352},
+						alternatives: []interface{}{
+							&litMatcher{
+								pos:        position{line: 285, col: 31, offset: 8352},
+								val:        "sum",
+								ignoreCase: true,
+							},
+							&litMatcher{
+								pos:        position{line: 285, col: 40, offset: 8361},
+								val:        "min",
+								ignoreCase: true,
+							},
+							&litMatcher{
+								pos:        position{line: 285, col: 49, offset: 8370},
+								val:        "max",
+								ignoreCase: true,
+							},
+							&litMatcher{
+								pos:        position{line: 285, col: 58, offset: 8379},
+								val:        "avg",
+								ignoreCase: true,
+							},
+							&litMatcher{
+								pos:        position{line: 285, col: 67, offset: 8388},
+								val:        "stddev",
+								ignoreCase: true,
+							},
+							&litMatcher{
+								pos:        position{line: 285, col: 79, offset: 8400},
+								val:        "stdvar",
+								ignoreCase: true,
+							},
+							&litMatcher{
+								pos:        position{line: 285, col: 91, offset: 8412},
+								val:        "count",
+								ignoreCase: true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AggregateOperators",
+			pos:  position{line: 291, col: 1, offset: 8511},
+			expr: &choiceExpr{
+				pos: position{line: 291, col: 22, offset: 8532},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 291, col: 22, offset: 8532},
+						name: "CountValueOperator",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 291, col: 43, offset: 8553},
+						name: "BinaryAggregateOperators",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 291, col: 70, offset: 8580},
+						name: "UnaryAggregateOperators",
+					},
+				},
+			},
+		},
+		{
+			name: "AggregateBy",
+			pos:  position{line: 293, col: 1, offset: 8605},
+			expr: &actionExpr{
+				pos: position{line: 293, col: 15, offset: 8619},
+				run: (*parser).callonAggregateBy1,
+				expr: &seqExpr{
+					pos: position{line: 293, col: 15, offset: 8619},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 293, col: 15, offset: 8619},
+							val:        "by",
+							ignoreCase: true,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 293, col: 21, offset: 8625},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 293, col: 24, offset: 8628},
+							label: "labels",
+							expr: &ruleRefExpr{
+								pos:  position{line: 293, col: 31, offset: 8635},
+								name: "LabelList",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 293, col: 41, offset: 8645},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 293, col: 44, offset: 8648},
+							label: "keep",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 293, col: 49, offset: 8653},
+								expr: &litMatcher{
+									pos:        position{line: 293, col: 49, offset: 8653},
+									val:        "keep_common",
+									ignoreCase: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AggregateWithout",
+			pos:  position{line: 300, col: 1, offset: 8766},
+			expr: &actionExpr{
+				pos: position{line: 300, col: 20, offset: 8785},
+				run: (*parser).callonAggregateWithout1,
+				expr: &seqExpr{
+					pos: position{line: 300, col: 20, offset: 8785},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 300, col: 20, offset: 8785},
+							val:        "without",
+							ignoreCase: true,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 300, col: 31, offset: 8796},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 300, col: 34, offset: 8799},
+							label: "labels",
+							expr: &ruleRefExpr{
+								pos:  position{line: 300, col: 41, offset: 8806},
+								name: "LabelList",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AggregateGroup",
+			pos:  position{line: 307, col: 1, offset: 8918},
+			expr: &choiceExpr{
+				pos: position{line: 307, col: 18, offset: 8935},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 307, col: 18, offset: 8935},
+						name: "AggregateBy",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 307, col: 32, offset: 8949},
+						name: "AggregateWithout",
+					},
+				},
+			},
+		},
+		{
+			name: "AggregateExpression",
+			pos:  position{line: 309, col: 1, offset: 8967},
+			expr: &choiceExpr{
+				pos: position{line: 310, col: 1, offset: 8989},
+				alternatives: []interface{}{
+					&actionExpr{
+						pos: position{line: 310, col: 1, offset: 8989},
+						run: (*parser).callonAggregateExpression2,
+						expr: &seqExpr{
+							pos: position{line: 310, col: 1, offset: 8989},
+							exprs: []interface{}{
+								&labeledExpr{
+									pos:   position{line: 310, col: 1, offset: 8989},
+									label: "op",
+									expr: &ruleRefExpr{
+										pos:  position{line: 310, col: 4, offset: 8992},
+										name: "CountValueOperator",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 310, col: 24, offset: 9012},
+									name: "__",
+								},
 								&litMatcher{
-									pos:        position{line: 270, col: 27, offset: 7698},
+									pos:        position{line: 310, col: 27, offset: 9015},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 270, col: 31, offset: 7702},
+									pos:  position{line: 310, col: 31, offset: 9019},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 270, col: 34, offset: 7705},
+									pos:   position{line: 310, col: 34, offset: 9022},
 									label: "param",
 									expr: &ruleRefExpr{
-										pos:  position{line: 270, col: 40, offset: 7711},
+										pos:  position{line: 310, col: 40, offset: 9028},
 										name: "StringLiteral",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 270, col: 54, offset: 7725},
+									pos:  position{line: 310, col: 54, offset: 9042},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 270, col: 57, offset: 7728},
+									pos:        position{line: 310, col: 57, offset: 9045},
 									val:        ",",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 270, col: 61, offset: 7732},
+									pos:  position{line: 310, col: 61, offset: 9049},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 270, col: 64, offset: 7735},
+									pos:   position{line: 310, col: 64, offset: 9052},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 270, col: 71, offset: 7742},
-										name: "VectorSelector",
+										pos:  position{line: 310, col: 71, offset: 9059},
+										name: "AggregateVector",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 270, col: 86, offset: 7757},
+									pos:  position{line: 310, col: 87, offset: 9075},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 270, col: 89, offset: 7760},
+									pos:        position{line: 310, col: 90, offset: 9078},
 									val:        ")",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 270, col: 93, offset: 7764},
+									pos:  position{line: 310, col: 94, offset: 9082},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 270, col: 96, offset: 7767},
+									pos:   position{line: 310, col: 97, offset: 9085},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 270, col: 102, offset: 7773},
+										pos: position{line: 310, col: 103, offset: 9091},
 										expr: &ruleRefExpr{
-											pos:  position{line: 270, col: 102, offset: 7773},
+											pos:  position{line: 310, col: 103, offset: 9091},
 											name: "AggregateGroup",
 										},
 									},
@@ -2494,82 +3007,82 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 276, col: 1, offset: 7921},
+						pos: position{line: 316, col: 1, offset: 9227},
 						run: (*parser).callonAggregateExpression22,
 						expr: &seqExpr{
-							pos: position{line: 276, col: 1, offset: 7921},
+							pos: position{line: 316, col: 1, offset: 9227},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 276, col: 1, offset: 7921},
+									pos:   position{line: 316, col: 1, offset: 9227},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 276, col: 4, offset: 7924},
+										pos:  position{line: 316, col: 4, offset: 9230},
 										name: "CountValueOperator",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 276, col: 24, offset: 7944},
+									pos:  position{line: 316, col: 24, offset: 9250},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 276, col: 27, offset: 7947},
+									pos:   position{line: 316, col: 27, offset: 9253},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 276, col: 33, offset: 7953},
+										pos: position{line: 316, col: 33, offset: 9259},
 										expr: &ruleRefExpr{
-											pos:  position{line: 276, col: 33, offset: 7953},
+											pos:  position{line: 316, col: 33, offset: 9259},
 											name: "AggregateGroup",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 276, col: 49, offset: 7969},
+									pos:  position{line: 316, col: 49, offset: 9275},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 276, col: 52, offset: 7972},
+									pos:        position{line: 316, col: 52, offset: 9278},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 276, col: 56, offset: 7976},
+									pos:  position{line: 316, col: 56, offset: 9282},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 276, col: 59, offset: 7979},
+									pos:   position{line: 316, col: 59, offset: 9285},
 									label: "param",
 									expr: &ruleRefExpr{
-										pos:  position{line: 276, col: 65, offset: 7985},
+										pos:  position{line: 316, col: 65, offset: 9291},
 										name: "StringLiteral",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 276, col: 79, offset: 7999},
+									pos:  position{line: 316, col: 79, offset: 9305},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 276, col: 82, offset: 8002},
+									pos:        position{line: 316, col: 82, offset: 9308},
 									val:        ",",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 276, col: 86, offset: 8006},
+									pos:  position{line: 316, col: 86, offset: 9312},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 276, col: 89, offset: 8009},
+									pos:   position{line: 316, col: 89, offset: 9315},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 276, col: 96, offset: 8016},
-										name: "VectorSelector",
+										pos:  position{line: 316, col: 96, offset: 9322},
+										name: "AggregateVector",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 276, col: 111, offset: 8031},
+									pos:  position{line: 316, col: 112, offset: 9338},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 276, col: 114, offset: 8034},
+									pos:        position{line: 316, col: 115, offset: 9341},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -2577,81 +3090,81 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 282, col: 1, offset: 8170},
+						pos: position{line: 322, col: 1, offset: 9465},
 						run: (*parser).callonAggregateExpression42,
 						expr: &seqExpr{
-							pos: position{line: 282, col: 1, offset: 8170},
+							pos: position{line: 322, col: 1, offset: 9465},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 282, col: 1, offset: 8170},
+									pos:   position{line: 322, col: 1, offset: 9465},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 282, col: 4, offset: 8173},
+										pos:  position{line: 322, col: 4, offset: 9468},
 										name: "BinaryAggregateOperators",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 30, offset: 8199},
+									pos:  position{line: 322, col: 30, offset: 9494},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 282, col: 33, offset: 8202},
+									pos:        position{line: 322, col: 33, offset: 9497},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 37, offset: 8206},
+									pos:  position{line: 322, col: 37, offset: 9501},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 282, col: 41, offset: 8210},
+									pos:   position{line: 322, col: 41, offset: 9505},
 									label: "param",
 									expr: &ruleRefExpr{
-										pos:  position{line: 282, col: 47, offset: 8216},
+										pos:  position{line: 322, col: 47, offset: 9511},
 										name: "Number",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 54, offset: 8223},
+									pos:  position{line: 322, col: 54, offset: 9518},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 282, col: 57, offset: 8226},
+									pos:        position{line: 322, col: 57, offset: 9521},
 									val:        ",",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 61, offset: 8230},
+									pos:  position{line: 322, col: 61, offset: 9525},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 282, col: 64, offset: 8233},
+									pos:   position{line: 322, col: 64, offset: 9528},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 282, col: 71, offset: 8240},
-										name: "VectorSelector",
+										pos:  position{line: 322, col: 71, offset: 9535},
+										name: "AggregateVector",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 86, offset: 8255},
+									pos:  position{line: 322, col: 87, offset: 9551},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 282, col: 89, offset: 8258},
+									pos:        position{line: 322, col: 90, offset: 9554},
 									val:        ")",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 93, offset: 8262},
+									pos:  position{line: 322, col: 94, offset: 9558},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 282, col: 96, offset: 8265},
+									pos:   position{line: 322, col: 97, offset: 9561},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 282, col: 102, offset: 8271},
+										pos: position{line: 322, col: 103, offset: 9567},
 										expr: &ruleRefExpr{
-											pos:  position{line: 282, col: 102, offset: 8271},
+											pos:  position{line: 322, col: 103, offset: 9567},
 											name: "AggregateGroup",
 										},
 									},
@@ -2660,82 +3173,82 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 288, col: 1, offset: 8412},
+						pos: position{line: 328, col: 1, offset: 9696},
 						run: (*parser).callonAggregateExpression62,
 						expr: &seqExpr{
-							pos: position{line: 288, col: 1, offset: 8412},
+							pos: position{line: 328, col: 1, offset: 9696},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 288, col: 1, offset: 8412},
+									pos:   position{line: 328, col: 1, offset: 9696},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 288, col: 4, offset: 8415},
+										pos:  position{line: 328, col: 4, offset: 9699},
 										name: "BinaryAggregateOperators",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 288, col: 30, offset: 8441},
+									pos:  position{line: 328, col: 30, offset: 9725},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 288, col: 33, offset: 8444},
+									pos:   position{line: 328, col: 33, offset: 9728},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 288, col: 39, offset: 8450},
+										pos: position{line: 328, col: 39, offset: 9734},
 										expr: &ruleRefExpr{
-											pos:  position{line: 288, col: 39, offset: 8450},
+											pos:  position{line: 328, col: 39, offset: 9734},
 											name: "AggregateGroup",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 288, col: 55, offset: 8466},
+									pos:  position{line: 328, col: 55, offset: 9750},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 288, col: 58, offset: 8469},
+									pos:        position{line: 328, col: 58, offset: 9753},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 288, col: 62, offset: 8473},
+									pos:  position{line: 328, col: 62, offset: 9757},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 288, col: 66, offset: 8477},
+									pos:   position{line: 328, col: 66, offset: 9761},
 									label: "param",
 									expr: &ruleRefExpr{
-										pos:  position{line: 288, col: 72, offset: 8483},
+										pos:  position{line: 328, col: 72, offset: 9767},
 										name: "Number",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 288, col: 79, offset: 8490},
+									pos:  position{line: 328, col: 79, offset: 9774},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 288, col: 82, offset: 8493},
+									pos:        position{line: 328, col: 82, offset: 9777},
 									val:        ",",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 288, col: 86, offset: 8497},
+									pos:  position{line: 328, col: 86, offset: 9781},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 288, col: 89, offset: 8500},
+									pos:   position{line: 328, col: 89, offset: 9784},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 288, col: 96, offset: 8507},
-										name: "VectorSelector",
+										pos:  position{line: 328, col: 96, offset: 9791},
+										name: "AggregateVector",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 288, col: 111, offset: 8522},
+									pos:  position{line: 328, col: 112, offset: 9807},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 288, col: 114, offset: 8525},
+									pos:        position{line: 328, col: 115, offset: 9810},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -2743,60 +3256,60 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 294, col: 1, offset: 8654},
+						pos: position{line: 334, col: 1, offset: 9927},
 						run: (*parser).callonAggregateExpression82,
 						expr: &seqExpr{
-							pos: position{line: 294, col: 1, offset: 8654},
+							pos: position{line: 334, col: 1, offset: 9927},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 294, col: 1, offset: 8654},
+									pos:   position{line: 334, col: 1, offset: 9927},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 294, col: 4, offset: 8657},
+										pos:  position{line: 334, col: 4, offset: 9930},
 										name: "UnaryAggregateOperators",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 294, col: 29, offset: 8682},
+									pos:  position{line: 334, col: 29, offset: 9955},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 294, col: 32, offset: 8685},
+									pos:        position{line: 334, col: 32, offset: 9958},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 294, col: 36, offset: 8689},
+									pos:  position{line: 334, col: 36, offset: 9962},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 294, col: 39, offset: 8692},
+									pos:   position{line: 334, col: 39, offset: 9965},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 294, col: 46, offset: 8699},
-										name: "VectorSelector",
+										pos:  position{line: 334, col: 46, offset: 9972},
+										name: "AggregateVector",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 294, col: 61, offset: 8714},
+									pos:  position{line: 334, col: 62, offset: 9988},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 294, col: 64, offset: 8717},
+									pos:        position{line: 334, col: 65, offset: 9991},
 									val:        ")",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 294, col: 68, offset: 8721},
+									pos:  position{line: 334, col: 69, offset: 9995},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 294, col: 71, offset: 8724},
+									pos:   position{line: 334, col: 72, offset: 9998},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 294, col: 77, offset: 8730},
+										pos: position{line: 334, col: 78, offset: 10004},
 										expr: &ruleRefExpr{
-											pos:  position{line: 294, col: 77, offset: 8730},
+											pos:  position{line: 334, col: 78, offset: 10004},
 											name: "AggregateGroup",
 										},
 									},
@@ -2805,88 +3318,570 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 298, col: 1, offset: 8823},
+						pos: position{line: 338, col: 1, offset: 10085},
 						run: (*parser).callonAggregateExpression97,
 						expr: &seqExpr{
-							pos: position{line: 298, col: 1, offset: 8823},
+							pos: position{line: 338, col: 1, offset: 10085},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 298, col: 1, offset: 8823},
+									pos:   position{line: 338, col: 1, offset: 10085},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 298, col: 4, offset: 8826},
+										pos:  position{line: 338, col: 4, offset: 10088},
 										name: "UnaryAggregateOperators",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 298, col: 29, offset: 8851},
+									pos:  position{line: 338, col: 29, offset: 10113},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 298, col: 32, offset: 8854},
+									pos:   position{line: 338, col: 32, offset: 10116},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 298, col: 38, offset: 8860},
+										pos: position{line: 338, col: 38, offset: 10122},
 										expr: &ruleRefExpr{
-											pos:  position{line: 298, col: 38, offset: 8860},
+											pos:  position{line: 338, col: 38, offset: 10122},
 											name: "AggregateGroup",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 298, col: 54, offset: 8876},
+									pos:  position{line: 338, col: 54, offset: 10138},
+									name: "__",
+								},
+								&litMatcher{
+									pos:        position{line: 338, col: 57, offset: 10141},
+									val:        "(",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 338, col: 61, offset: 10145},
+									name: "__",
+								},
+								&labeledExpr{
+									pos:   position{line: 338, col: 64, offset: 10148},
+									label: "vector",
+									expr: &ruleRefExpr{
+										pos:  position{line: 338, col: 71, offset: 10155},
+										name: "AggregateVector",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 338, col: 87, offset: 10171},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 298, col: 57, offset: 8879},
+									pos:        position{line: 338, col: 90, offset: 10174},
+									val:        ")",
+									ignoreCase: false,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Expression",
+			pos:  position{line: 342, col: 1, offset: 10242},
+			expr: &ruleRefExpr{
+				pos:  position{line: 342, col: 14, offset: 10255},
+				name: "ComparisonExpression",
+			},
+		},
+		{
+			name: "ComparisonExpression",
+			pos:  position{line: 344, col: 1, offset: 10277},
+			expr: &actionExpr{
+				pos: position{line: 344, col: 24, offset: 10300},
+				run: (*parser).callonComparisonExpression1,
+				expr: &seqExpr{
+					pos: position{line: 344, col: 24, offset: 10300},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 344, col: 24, offset: 10300},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 344, col: 30, offset: 10306},
+								name: "AdditiveExpression",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 344, col: 49, offset: 10325},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 344, col: 54, offset: 10330},
+								expr: &seqExpr{
+									pos: position{line: 344, col: 56, offset: 10332},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 344, col: 56, offset: 10332},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 344, col: 59, offset: 10335},
+											name: "ComparisonOperators",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 344, col: 79, offset: 10355},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 344, col: 82, offset: 10358},
+											name: "BinaryModifiers",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 344, col: 98, offset: 10374},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 344, col: 101, offset: 10377},
+											name: "AdditiveExpression",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AdditiveExpression",
+			pos:  position{line: 348, col: 1, offset: 10443},
+			expr: &actionExpr{
+				pos: position{line: 348, col: 22, offset: 10464},
+				run: (*parser).callonAdditiveExpression1,
+				expr: &seqExpr{
+					pos: position{line: 348, col: 22, offset: 10464},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 348, col: 22, offset: 10464},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 348, col: 28, offset: 10470},
+								name: "MultiplicativeExpression",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 348, col: 53, offset: 10495},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 348, col: 58, offset: 10500},
+								expr: &seqExpr{
+									pos: position{line: 348, col: 60, offset: 10502},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 348, col: 60, offset: 10502},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 348, col: 63, offset: 10505},
+											name: "AdditiveOperators",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 348, col: 81, offset: 10523},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 348, col: 84, offset: 10526},
+											name: "BinaryModifiers",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 348, col: 100, offset: 10542},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 348, col: 103, offset: 10545},
+											name: "MultiplicativeExpression",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "MultiplicativeExpression",
+			pos:  position{line: 352, col: 1, offset: 10617},
+			expr: &actionExpr{
+				pos: position{line: 352, col: 28, offset: 10644},
+				run: (*parser).callonMultiplicativeExpression1,
+				expr: &seqExpr{
+					pos: position{line: 352, col: 28, offset: 10644},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 352, col: 28, offset: 10644},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 352, col: 34, offset: 10650},
+								name: "PowerExpression",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 352, col: 50, offset: 10666},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 352, col: 55, offset: 10671},
+								expr: &seqExpr{
+									pos: position{line: 352, col: 57, offset: 10673},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 352, col: 57, offset: 10673},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 352, col: 60, offset: 10676},
+											name: "MultiplicativeOperators",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 352, col: 84, offset: 10700},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 352, col: 87, offset: 10703},
+											name: "BinaryModifiers",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 352, col: 103, offset: 10719},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 352, col: 106, offset: 10722},
+											name: "PowerExpression",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PowerExpression",
+			pos:  position{line: 357, col: 1, offset: 10829},
+			expr: &actionExpr{
+				pos: position{line: 357, col: 19, offset: 10847},
+				run: (*parser).callonPowerExpression1,
+				expr: &seqExpr{
+					pos: position{line: 357, col: 19, offset: 10847},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 357, col: 19, offset: 10847},
+							label: "base",
+							expr: &ruleRefExpr{
+								pos:  position{line: 357, col: 24, offset: 10852},
+								name: "PrimaryExpression",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 357, col: 42, offset: 10870},
+							label: "rest",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 357, col: 47, offset: 10875},
+								expr: &seqExpr{
+									pos: position{line: 357, col: 49, offset: 10877},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 357, col: 49, offset: 10877},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 357, col: 52, offset: 10880},
+											name: "PowerOperator",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 357, col: 66, offset: 10894},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 357, col: 69, offset: 10897},
+											name: "BinaryModifiers",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 357, col: 85, offset: 10913},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 357, col: 88, offset: 10916},
+											name: "PowerExpression",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PrimaryExpression",
+			pos:  position{line: 364, col: 1, offset: 11045},
+			expr: &choiceExpr{
+				pos: position{line: 364, col: 21, offset: 11065},
+				alternatives: []interface{}{
+					&actionExpr{
+						pos: position{line: 364, col: 21, offset: 11065},
+						run: (*parser).callonPrimaryExpression2,
+						expr: &seqExpr{
+							pos: position{line: 364, col: 21, offset: 11065},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 364, col: 21, offset: 11065},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 298, col: 61, offset: 8883},
+									pos:  position{line: 364, col: 25, offset: 11069},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 298, col: 64, offset: 8886},
-									label: "vector",
+									pos:   position{line: 364, col: 28, offset: 11072},
+									label: "expr",
 									expr: &ruleRefExpr{
-										pos:  position{line: 298, col: 71, offset: 8893},
-										name: "VectorSelector",
+										pos:  position{line: 364, col: 33, offset: 11077},
+										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 298, col: 86, offset: 8908},
+									pos:  position{line: 364, col: 44, offset: 11088},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 298, col: 89, offset: 8911},
+									pos:        position{line: 364, col: 47, offset: 11091},
 									val:        ")",
 									ignoreCase: false,
 								},
 							},
 						},
 					},
+					&ruleRefExpr{
+						pos:  position{line: 366, col: 5, offset: 11122},
+						name: "Number",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 366, col: 14, offset: 11131},
+						name: "ScalarFunctionExpression",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 366, col: 41, offset: 11158},
+						name: "VectorFunctionExpression",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 366, col: 68, offset: 11185},
+						name: "InstantFunctionExpression",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 366, col: 96, offset: 11213},
+						name: "RangeFunctionExpression",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 366, col: 122, offset: 11239},
+						name: "AggregateExpression",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 366, col: 144, offset: 11261},
+						name: "VectorSelector",
+					},
+				},
+			},
+		},
+		{
+			name: "BinaryModifiers",
+			pos:  position{line: 368, col: 1, offset: 11277},
+			expr: &actionExpr{
+				pos: position{line: 368, col: 19, offset: 11295},
+				run: (*parser).callonBinaryModifiers1,
+				expr: &seqExpr{
+					pos: position{line: 368, col: 19, offset: 11295},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 368, col: 19, offset: 11295},
+							label: "ret",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 368, col: 23, offset: 11299},
+								expr: &seqExpr{
+									pos: position{line: 368, col: 25, offset: 11301},
+									exprs: []interface{}{
+										&litMatcher{
+											pos:        position{line: 368, col: 25, offset: 11301},
+											val:        "bool",
+											ignoreCase: true,
+										},
+										&notExpr{
+											pos: position{line: 368, col: 33, offset: 11309},
+											expr: &ruleRefExpr{
+												pos:  position{line: 368, col: 34, offset: 11310},
+												name: "IdentifierPart",
+											},
+										},
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 368, col: 52, offset: 11328},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 368, col: 55, offset: 11331},
+							label: "matching",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 368, col: 64, offset: 11340},
+								expr: &ruleRefExpr{
+									pos:  position{line: 368, col: 64, offset: 11340},
+									name: "VectorMatching",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "VectorMatching",
+			pos:  position{line: 378, col: 1, offset: 11532},
+			expr: &actionExpr{
+				pos: position{line: 378, col: 18, offset: 11549},
+				run: (*parser).callonVectorMatching1,
+				expr: &seqExpr{
+					pos: position{line: 378, col: 18, offset: 11549},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 378, col: 18, offset: 11549},
+							label: "on",
+							expr: &choiceExpr{
+								pos: position{line: 378, col: 23, offset: 11554},
+								alternatives: []interface{}{
+									&actionExpr{
+										pos: position{line: 378, col: 23, offset: 11554},
+										run: (*parser).callonVectorMatching5,
+										expr: &litMatcher{
+											pos:        position{line: 378, col: 23, offset: 11554},
+											val:        "on",
+											ignoreCase: true,
+										},
+									},
+									&actionExpr{
+										pos: position{line: 378, col: 52, offset: 11583},
+										run: (*parser).callonVectorMatching7,
+										expr: &litMatcher{
+											pos:        position{line: 378, col: 52, offset: 11583},
+											val:        "ignoring",
+											ignoreCase: true,
+										},
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 378, col: 88, offset: 11619},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 378, col: 91, offset: 11622},
+							label: "labels",
+							expr: &ruleRefExpr{
+								pos:  position{line: 378, col: 98, offset: 11629},
+								name: "LabelList",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 378, col: 108, offset: 11639},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 378, col: 111, offset: 11642},
+							label: "group",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 378, col: 117, offset: 11648},
+								expr: &ruleRefExpr{
+									pos:  position{line: 378, col: 117, offset: 11648},
+									name: "GroupModifier",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "GroupModifier",
+			pos:  position{line: 382, col: 1, offset: 11723},
+			expr: &actionExpr{
+				pos: position{line: 382, col: 17, offset: 11739},
+				run: (*parser).callonGroupModifier1,
+				expr: &seqExpr{
+					pos: position{line: 382, col: 17, offset: 11739},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 382, col: 17, offset: 11739},
+							label: "card",
+							expr: &choiceExpr{
+								pos: position{line: 382, col: 24, offset: 11746},
+								alternatives: []interface{}{
+									&actionExpr{
+										pos: position{line: 382, col: 24, offset: 11746},
+										run: (*parser).callonGroupModifier5,
+										expr: &litMatcher{
+											pos:        position{line: 382, col: 24, offset: 11746},
+											val:        "group_left",
+											ignoreCase: true,
+										},
+									},
+									&actionExpr{
+										pos: position{line: 382, col: 70, offset: 11792},
+										run: (*parser).callonGroupModifier7,
+										expr: &litMatcher{
+											pos:        position{line: 382, col: 70, offset: 11792},
+											val:        "group_right",
+											ignoreCase: true,
+										},
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 382, col: 117, offset: 11839},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 382, col: 120, offset: 11842},
+							label: "labels",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 382, col: 127, offset: 11849},
+								expr: &ruleRefExpr{
+									pos:  position{line: 382, col: 127, offset: 11849},
+									name: "LabelList",
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		{
 			name: "__",
-			pos:  position{line: 302, col: 1, offset: 8991},
+			pos:  position{line: 386, col: 1, offset: 11932},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 302, col: 6, offset: 8996},
+				pos: position{line: 386, col: 6, offset: 11937},
 				expr: &choiceExpr{
-					pos: position{line: 302, col: 8, offset: 8998},
+					pos: position{line: 386, col: 8, offset: 11939},
 					alternatives: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 302, col: 8, offset: 8998},
+							pos:  position{line: 386, col: 8, offset: 11939},
 							name: "Whitespace",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 302, col: 21, offset: 9011},
+							pos:  position{line: 386, col: 21, offset: 11952},
 							name: "EOL",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 302, col: 27, offset: 9017},
+							pos:  position{line: 386, col: 27, offset: 11958},
 							name: "Comment",
 						},
 					},
@@ -2895,20 +3890,20 @@ var g = &grammar{
 		},
 		{
 			name: "_",
-			pos:  position{line: 303, col: 1, offset: 9028},
+			pos:  position{line: 387, col: 1, offset: 11969},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 303, col: 5, offset: 9032},
+				pos: position{line: 387, col: 5, offset: 11973},
 				expr: &ruleRefExpr{
-					pos:  position{line: 303, col: 5, offset: 9032},
+					pos:  position{line: 387, col: 5, offset: 11973},
 					name: "Whitespace",
 				},
 			},
 		},
 		{
 			name: "Whitespace",
-			pos:  position{line: 305, col: 1, offset: 9045},
+			pos:  position{line: 389, col: 1, offset: 11986},
 			expr: &charClassMatcher{
-				pos:        position{line: 305, col: 14, offset: 9058},
+				pos:        position{line: 389, col: 14, offset: 11999},
 				val:        "[ \\t\\r]",
 				chars:      []rune{' ', '\t', '\r'},
 				ignoreCase: false,
@@ -2917,62 +3912,62 @@ var g = &grammar{
 		},
 		{
 			name: "EOL",
-			pos:  position{line: 306, col: 1, offset: 9066},
+			pos:  position{line: 390, col: 1, offset: 12007},
 			expr: &litMatcher{
-				pos:        position{line: 306, col: 7, offset: 9072},
+				pos:        position{line: 390, col: 7, offset: 12013},
 				val:        "\n",
 				ignoreCase: false,
 			},
 		},
 		{
 			name: "EOS",
-			pos:  position{line: 307, col: 1, offset: 9077},
+			pos:  position{line: 391, col: 1, offset: 12018},
 			expr: &choiceExpr{
-				pos: position{line: 307, col: 7, offset: 9083},
+				pos: position{line: 391, col: 7, offset: 12024},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 307, col: 7, offset: 9083},
+						pos: position{line: 391, col: 7, offset: 12024},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 307, col: 7, offset: 9083},
+								pos:  position{line: 391, col: 7, offset: 12024},
 								name: "__",
 							},
 							&litMatcher{
-								pos:        position{line: 307, col: 10, offset: 9086},
+								pos:        position{line: 391, col: 10, offset: 12027},
 								val:        ";",
 								ignoreCase: false,
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 307, col: 16, offset: 9092},
+						pos: position{line: 391, col: 16, offset: 12033},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 307, col: 16, offset: 9092},
+								pos:  position{line: 391, col: 16, offset: 12033},
 								name: "_",
 							},
 							&zeroOrOneExpr{
-								pos: position{line: 307, col: 18, offset: 9094},
+								pos: position{line: 391, col: 18, offset: 12035},
 								expr: &ruleRefExpr{
-									pos:  position{line: 307, col: 18, offset: 9094},
+									pos:  position{line: 391, col: 18, offset: 12035},
 									name: "SingleLineComment",
 								},
 							},
 							&ruleRefExpr{
-								pos:  position{line: 307, col: 37, offset: 9113},
+								pos:  position{line: 391, col: 37, offset: 12054},
 								name: "EOL",
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 307, col: 43, offset: 9119},
+						pos: position{line: 391, col: 43, offset: 12060},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 307, col: 43, offset: 9119},
+								pos:  position{line: 391, col: 43, offset: 12060},
 								name: "__",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 307, col: 46, offset: 9122},
+								pos:  position{line: 391, col: 46, offset: 12063},
 								name: "EOF",
 							},
 						},
@@ -2982,11 +3977,11 @@ var g = &grammar{
 		},
 		{
 			name: "EOF",
-			pos:  position{line: 309, col: 1, offset: 9127},
+			pos:  position{line: 393, col: 1, offset: 12068},
 			expr: &notExpr{
-				pos: position{line: 309, col: 7, offset: 9133},
+				pos: position{line: 393, col: 7, offset: 12074},
 				expr: &anyMatcher{
-					line: 309, col: 8, offset: 9134,
+					line: 393, col: 8, offset: 12075,
 				},
 			},
 		},
@@ -3359,6 +4354,46 @@ func (p *parser) callonDuration1() (interface{}, error) {
 	return p.cur.onDuration1(stack["dur"], stack["units"])
 }
 
+func (c *current) onComparisonOperators1() (interface{}, error) {
+	return string(c.text), nil
+}
+
+func (p *parser) callonComparisonOperators1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onComparisonOperators1()
+}
+
+func (c *current) onAdditiveOperators1() (interface{}, error) {
+	return string(c.text), nil
+}
+
+func (p *parser) callonAdditiveOperators1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAdditiveOperators1()
+}
+
+func (c *current) onMultiplicativeOperators1() (interface{}, error) {
+	return string(c.text), nil
+}
+
+func (p *parser) callonMultiplicativeOperators1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onMultiplicativeOperators1()
+}
+
+func (c *current) onPowerOperator1() (interface{}, error) {
+	return string(c.text), nil
+}
+
+func (p *parser) callonPowerOperator1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPowerOperator1()
+}
+
 func (c *current) onLabelOperators2() (interface{}, error) {
 	return NotEqual, nil
 }
@@ -3489,6 +4524,56 @@ func (p *parser) callonOffset1() (interface{}, error) {
 	return p.cur.onOffset1(stack["dur"])
 }
 
+func (c *current) onRangeFunctionExpression1(fn, vector interface{}) (interface{}, error) {
+	return NewRangeFunction(string(fn.([]byte)), vector.(*Selector))
+}
+
+func (p *parser) callonRangeFunctionExpression1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onRangeFunctionExpression1(stack["fn"], stack["vector"])
+}
+
+func (c *current) onInstantFunctionExpression1(fn, vector, args interface{}) (interface{}, error) {
+	return NewInstantFunction(string(fn.([]byte)), vector, args)
+}
+
+func (p *parser) callonInstantFunctionExpression1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onInstantFunctionExpression1(stack["fn"], stack["vector"], stack["args"])
+}
+
+func (c *current) onScalarFunctionExpression2() (interface{}, error) {
+	return NewScalarFunction("time", nil)
+}
+
+func (p *parser) callonScalarFunctionExpression2() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onScalarFunctionExpression2()
+}
+
+func (c *current) onScalarFunctionExpression9(vector interface{}) (interface{}, error) {
+	return NewScalarFunction("scalar", vector)
+}
+
+func (p *parser) callonScalarFunctionExpression9() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onScalarFunctionExpression9(stack["vector"])
+}
+
+func (c *current) onVectorFunctionExpression1(scalar interface{}) (interface{}, error) {
+	return NewVectorFunction(scalar)
+}
+
+func (p *parser) callonVectorFunctionExpression1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onVectorFunctionExpression1(stack["scalar"])
+}
+
 func (c *current) onCountValueOperator1() (interface{}, error) {
 	return &Operator{
 		Kind: CountValuesKind,
@@ -3554,7 +4639,7 @@ func (p *parser) callonAggregateWithout1() (interface{}, error) {
 func (c *current) onAggregateExpression2(op, param, vector, group interface{}) (interface{}, error) {
 	oper := op.(*Operator)
 	oper.Arg = param.(*StringLiteral)
-	return NewAggregateExpr(oper, vector.(*Selector), group)
+	return NewAggregateExpr(oper, vector, group)
 }
 
 func (p *parser) callonAggregateExpression2() (interface{}, error) {
@@ -3566,7 +4651,7 @@ func (p *parser) callonAggregateExpression2() (interface{}, error) {
 func (c *current) onAggregateExpression22(op, group, param, vector interface{}) (interface{}, error) {
 	oper := op.(*Operator)
 	oper.Arg = param.(*StringLiteral)
-	return NewAggregateExpr(oper, vector.(*Selector), group)
+	return NewAggregateExpr(oper, vector, group)
 }
 
 func (p *parser) callonAggregateExpression22() (interface{}, error) {
@@ -3578,7 +4663,7 @@ func (p *parser) callonAggregateExpression22() (interface{}, error) {
 func (c *current) onAggregateExpression42(op, param, vector, group interface{}) (interface{}, error) {
 	oper := op.(*Operator)
 	oper.Arg = param.(*Number)
-	return NewAggregateExpr(oper, vector.(*Selector), group)
+	return NewAggregateExpr(oper, vector, group)
 }
 
 func (p *parser) callonAggregateExpression42() (interface{}, error) {
@@ -3590,7 +4675,7 @@ func (p *parser) callonAggregateExpression42() (interface{}, error) {
 func (c *current) onAggregateExpression62(op, group, param, vector interface{}) (interface{}, error) {
 	oper := op.(*Operator)
 	oper.Arg = param.(*Number)
-	return NewAggregateExpr(oper, vector.(*Selector), group)
+	return NewAggregateExpr(oper, vector, group)
 }
 
 func (p *parser) callonAggregateExpression62() (interface{}, error) {
@@ -3600,7 +4685,7 @@ func (p *parser) callonAggregateExpression62() (interface{}, error) {
 }
 
 func (c *current) onAggregateExpression82(op, vector, group interface{}) (interface{}, error) {
-	return NewAggregateExpr(op.(*Operator), vector.(*Selector), group)
+	return NewAggregateExpr(op.(*Operator), vector, group)
 }
 
 func (p *parser) callonAggregateExpression82() (interface{}, error) {
@@ -3610,7 +4695,7 @@ func (p *parser) callonAggregateExpression82() (interface{}, error) {
 }
 
 func (c *current) onAggregateExpression97(op, group, vector interface{}) (interface{}, error) {
-	return NewAggregateExpr(op.(*Operator), vector.(*Selector), group)
+	return NewAggregateExpr(op.(*Operator), vector, group)
 }
 
 func (p *parser) callonAggregateExpression97() (interface{}, error) {
@@ -3619,6 +4704,135 @@ func (p *parser) callonAggregateExpression97() (interface{}, error) {
 	return p.cur.onAggregateExpression97(stack["op"], stack["group"], stack["vector"])
 }
 
+func (c *current) onComparisonExpression1(first, rest interface{}) (interface{}, error) {
+	return NewBinaryExprs(first, rest)
+}
+
+func (p *parser) callonComparisonExpression1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onComparisonExpression1(stack["first"], stack["rest"])
+}
+
+func (c *current) onAdditiveExpression1(first, rest interface{}) (interface{}, error) {
+	return NewBinaryExprs(first, rest)
+}
+
+func (p *parser) callonAdditiveExpression1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAdditiveExpression1(stack["first"], stack["rest"])
+}
+
+func (c *current) onMultiplicativeExpression1(first, rest interface{}) (interface{}, error) {
+	return NewBinaryExprs(first, rest)
+}
+
+func (p *parser) callonMultiplicativeExpression1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onMultiplicativeExpression1(stack["first"], stack["rest"])
+}
+
+func (c *current) onPowerExpression1(base, rest interface{}) (interface{}, error) {
+	if rest == nil {
+		return base, nil
+	}
+	return NewBinaryExprs(base, []interface{}{rest})
+}
+
+func (p *parser) callonPowerExpression1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPowerExpression1(stack["base"], stack["rest"])
+}
+
+func (c *current) onPrimaryExpression2(expr interface{}) (interface{}, error) {
+	return expr, nil
+}
+
+func (p *parser) callonPrimaryExpression2() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPrimaryExpression2(stack["expr"])
+}
+
+func (c *current) onBinaryModifiers1(ret, matching interface{}) (interface{}, error) {
+	mods := &BinaryModifiers{
+		ReturnBool: ret != nil,
+	}
+	if matching != nil {
+		mods.Matching = matching.(*VectorMatching)
+	}
+	return mods, nil
+}
+
+func (p *parser) callonBinaryModifiers1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onBinaryModifiers1(stack["ret"], stack["matching"])
+}
+
+func (c *current) onVectorMatching5() (interface{}, error) {
+	return true, nil
+}
+
+func (p *parser) callonVectorMatching5() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onVectorMatching5()
+}
+
+func (c *current) onVectorMatching7() (interface{}, error) {
+	return false, nil
+}
+
+func (p *parser) callonVectorMatching7() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onVectorMatching7()
+}
+
+func (c *current) onVectorMatching1(on, labels, group interface{}) (interface{}, error) {
+	return NewVectorMatching(on.(bool), labels, group)
+}
+
+func (p *parser) callonVectorMatching1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onVectorMatching1(stack["on"], stack["labels"], stack["group"])
+}
+
+func (c *current) onGroupModifier5() (interface{}, error) {
+	return CardManyToOne, nil
+}
+
+func (p *parser) callonGroupModifier5() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onGroupModifier5()
+}
+
+func (c *current) onGroupModifier7() (interface{}, error) {
+	return CardOneToMany, nil
+}
+
+func (p *parser) callonGroupModifier7() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onGroupModifier7()
+}
+
+func (c *current) onGroupModifier1(card, labels interface{}) (interface{}, error) {
+	return NewGroupModifier(card.(VectorMatchCardinality), labels)
+}
+
+func (p *parser) callonGroupModifier1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onGroupModifier1(stack["card"], stack["labels"])
+}
+
 var (
 	// errNoRule is returned when the grammar to parse has no rule.
 	errNoRule = errors.New("grammar has no rule")
@@ -3677,18 +4891,17 @@ func Entrypoint(ruleName string) Option {
 //
 // Example usage:
 //
-//     input := "input"
-//     stats := Stats{}
-//     _, err := Parse("input-file", []byte(input), Statistics(&stats, "no match"))
-//     if err != nil {
-//         log.Panicln(err)
-//     }
-//     b, err := json.MarshalIndent(stats.ChoiceAltCnt, "", "  ")
-//     if err != nil {
-//         log.Panicln(err)
-//     }
-//     fmt.Println(string(b))
-//
+//	input := "input"
+//	stats := Stats{}
+//	_, err := Parse("input-file", []byte(input), Statistics(&stats, "no match"))
+//	if err != nil {
+//	    log.Panicln(err)
+//	}
+//	b, err := json.MarshalIndent(stats.ChoiceAltCnt, "", "  ")
+//	if err != nil {
+//	    log.Panicln(err)
+//	}
+//	fmt.Println(string(b))
 func Statistics(stats *Stats, choiceNoMatch string) Option {
 	return func(p *parser) Option {
 		oldStats := p.Stats
@@ -3,10 +3,24 @@ package promql
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/platform"
 )
 
+// Config is where and when an expression is evaluated.
+type Config struct {
+	// BucketID is the bucket with the series. If it is not set, the series are
+	// read from the bucket named prometheus.
+	BucketID platform.ID
+	// Start and End are the times of the first and last evaluation. If End is
+	// not set, the expression is evaluated once at the time of the query.
+	Start, End time.Time
+	// Step is the time between evaluations.
+	Step time.Duration
+}
+
 func ParsePromQL(promql string, opts ...Option) (interface{}, error) {
 	f, err := Parse("", []byte(promql), opts...)
 	if err != nil {
@@ -20,9 +34,17 @@ func Build(promql string, opts ...Option) (*flux.Spec, error) {
 	if err != nil {
 		return nil, err
 	}
-	builder, ok := parsed.(QueryBuilder)
-	if !ok {
-		return nil, fmt.Errorf("Unable to build as %t is not a QueryBuilder", parsed)
+	return NewQuerySpec(parsed, Config{})
+}
+
+// NewQuerySpec creates a query specification for a parsed PromQL expression.
+func NewQuerySpec(expr interface{}, config Config) (*flux.Spec, error) {
+	switch expr := expr.(type) {
+	case expression:
+		return newBuilder(config).build(expr)
+	case QueryBuilder:
+		return expr.QuerySpec()
+	default:
+		return nil, fmt.Errorf("Unable to build as %T is not a QueryBuilder", expr)
 	}
-	return builder.QuerySpec()
 }
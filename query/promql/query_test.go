@@ -8,10 +8,12 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/functions/inputs"
 	"github.com/influxdata/flux/functions/transformations"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/semantic/semantictest"
+	"github.com/influxdata/platform"
 )
 
 func TestParsePromQL(t *testing.T) {
@@ -334,6 +336,288 @@ func TestParsePromQL(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "rate over a range",
+			promql: `rate(http_requests_total{job="api"}[5m])`,
+			want: &RangeFunction{
+				Name: "rate",
+				Selector: &Selector{
+					Name:  "http_requests_total",
+					Range: 5 * time.Minute,
+					LabelMatchers: []*LabelMatcher{
+						{
+							Name: "job",
+							Kind: Equal,
+							Value: &StringLiteral{
+								String: "api",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "count_over_time with offset",
+			promql: `count_over_time(up[1h] offset 1d)`,
+			want: &RangeFunction{
+				Name: "count_over_time",
+				Selector: &Selector{
+					Name:   "up",
+					Range:  time.Hour,
+					Offset: 24 * time.Hour,
+				},
+			},
+		},
+		{
+			name:    "rate of an instant vector",
+			promql:  `rate(http_requests_total)`,
+			wantErr: true,
+			want:    "",
+		},
+		{
+			name:   "sum of rate with group by",
+			promql: `sum(rate(http_requests_total[5m])) by (job)`,
+			want: &AggregateExpr{
+				Op: &Operator{
+					Kind: SumKind,
+				},
+				Function: &RangeFunction{
+					Name: "rate",
+					Selector: &Selector{
+						Name:  "http_requests_total",
+						Range: 5 * time.Minute,
+					},
+				},
+				Aggregate: &Aggregate{
+					By: true,
+					Labels: []*Identifier{
+						&Identifier{
+							Name: "job",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "topk with argument",
+			promql: `topk(3, http_requests_total)`,
+			want: &AggregateExpr{
+				Op: &Operator{
+					Kind: TopKind,
+					Arg: &Number{
+						Val: 3,
+					},
+				},
+				Selector: &Selector{
+					Name: "http_requests_total",
+				},
+			},
+		},
+		{
+			name:   "binary operators have precedence",
+			promql: `a + b * c ^ 2 ^ 3`,
+			want: &BinaryExpr{
+				Op:  "+",
+				LHS: &Selector{Name: "a"},
+				RHS: &BinaryExpr{
+					Op:  "*",
+					LHS: &Selector{Name: "b"},
+					RHS: &BinaryExpr{
+						Op:  "^",
+						LHS: &Selector{Name: "c"},
+						RHS: &Number{Val: 8},
+					},
+				},
+			},
+		},
+		{
+			name:   "binary operators are left associative",
+			promql: `(a - b) - c / 2`,
+			want: &BinaryExpr{
+				Op: "-",
+				LHS: &BinaryExpr{
+					Op:  "-",
+					LHS: &Selector{Name: "a"},
+					RHS: &Selector{Name: "b"},
+				},
+				RHS: &BinaryExpr{
+					Op:  "/",
+					LHS: &Selector{Name: "c"},
+					RHS: &Number{Val: 2},
+				},
+			},
+		},
+		{
+			name:   "comparison with bool",
+			promql: `up == bool 0`,
+			want: &BinaryExpr{
+				Op:         "==",
+				ReturnBool: true,
+				LHS:        &Selector{Name: "up"},
+				RHS:        &Number{Val: 0},
+			},
+		},
+		{
+			name:   "scalar comparison with bool",
+			promql: `1 < bool 2`,
+			want:   &Number{Val: 1},
+		},
+		{
+			name:    "scalar comparison without bool",
+			promql:  `1 < 2`,
+			wantErr: true,
+			want:    "",
+		},
+		{
+			name:    "bool with an arithmetic operator",
+			promql:  `up + bool 1`,
+			wantErr: true,
+			want:    "",
+		},
+		{
+			name:   "vector matching with group_left",
+			promql: `errors / on(job) group_left(version) requests`,
+			want: &BinaryExpr{
+				Op: "/",
+				Matching: &VectorMatching{
+					Card:           CardManyToOne,
+					On:             true,
+					MatchingLabels: []string{"job"},
+					Include:        []string{"version"},
+				},
+				LHS: &Selector{Name: "errors"},
+				RHS: &Selector{Name: "requests"},
+			},
+		},
+		{
+			name:   "vector matching with ignoring and group_right",
+			promql: `errors > IGNORING(code) GROUP_RIGHT requests`,
+			want: &BinaryExpr{
+				Op: ">",
+				Matching: &VectorMatching{
+					Card:           CardOneToMany,
+					MatchingLabels: []string{"code"},
+				},
+				LHS: &Selector{Name: "errors"},
+				RHS: &Selector{Name: "requests"},
+			},
+		},
+		{
+			name:    "vector matching with a scalar",
+			promql:  `errors / on(job) 2`,
+			wantErr: true,
+			want:    "",
+		},
+		{
+			name:    "label in on and group_left",
+			promql:  `errors / on(job) group_left(job) requests`,
+			wantErr: true,
+			want:    "",
+		},
+		{
+			name:    "binary operator with a range vector",
+			promql:  `errors[5m] / 2`,
+			wantErr: true,
+			want:    "",
+		},
+		{
+			name:   "instant function",
+			promql: `round(rate(http_requests_total[5m]), 0.1)`,
+			want: &InstantFunction{
+				Name: "round",
+				Args: []float64{0.1},
+				Vector: &RangeFunction{
+					Name: "rate",
+					Selector: &Selector{
+						Name:  "http_requests_total",
+						Range: 5 * time.Minute,
+					},
+				},
+			},
+		},
+		{
+			name:    "instant function with too many arguments",
+			promql:  `abs(up, 1)`,
+			wantErr: true,
+			want:    "",
+		},
+		{
+			name:    "instant function of a range vector",
+			promql:  `abs(up[5m])`,
+			wantErr: true,
+			want:    "",
+		},
+		{
+			name:   "time",
+			promql: `time()`,
+			want:   &ScalarFunction{Name: "time"},
+		},
+		{
+			name:   "scalar of an instant vector",
+			promql: `up / scalar(sum(up))`,
+			want: &BinaryExpr{
+				Op:  "/",
+				LHS: &Selector{Name: "up"},
+				RHS: &ScalarFunction{
+					Name: "scalar",
+					Vector: &AggregateExpr{
+						Op:       &Operator{Kind: SumKind},
+						Selector: &Selector{Name: "up"},
+					},
+				},
+			},
+		},
+		{
+			name:    "scalar of a scalar",
+			promql:  `scalar(1)`,
+			wantErr: true,
+			want:    "",
+		},
+		{
+			name:   "vector of a scalar expression",
+			promql: `vector(time() - 60)`,
+			want: &VectorFunction{
+				Scalar: &BinaryExpr{
+					Op:  "-",
+					LHS: &ScalarFunction{Name: "time"},
+					RHS: &Number{Val: 60},
+				},
+			},
+		},
+		{
+			name:    "vector of an instant vector",
+			promql:  `vector(up)`,
+			wantErr: true,
+			want:    "",
+		},
+		{
+			name:    "aggregation of a scalar",
+			promql:  `sum(time())`,
+			wantErr: true,
+			want:    "",
+		},
+		{
+			name:   "aggregation of a binary expression",
+			promql: `sum(errors / requests) by (job)`,
+			want: &AggregateExpr{
+				Op: &Operator{
+					Kind: SumKind,
+				},
+				Expr: &BinaryExpr{
+					Op:  "/",
+					LHS: &Selector{Name: "errors"},
+					RHS: &Selector{Name: "requests"},
+				},
+				Aggregate: &Aggregate{
+					By: true,
+					Labels: []*Identifier{
+						&Identifier{
+							Name: "job",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -366,6 +650,16 @@ func TestBuild(t *testing.T) {
 						ID:   flux.OperationID("from"),
 						Spec: &inputs.FromOpSpec{Bucket: "prometheus"},
 					},
+					{
+						ID: flux.OperationID("range"),
+						Spec: &transformations.RangeOpSpec{
+							Start:    flux.Time{IsRelative: true, Relative: -5 * time.Minute},
+							Stop:     flux.Time{IsRelative: true, Relative: time.Nanosecond},
+							TimeCol:  execute.DefaultTimeColLabel,
+							StartCol: execute.DefaultStartColLabel,
+							StopCol:  execute.DefaultStopColLabel,
+						},
+					},
 					{
 						ID: "where",
 						Spec: &transformations.FilterOpSpec{
@@ -381,7 +675,7 @@ func TestBuild(t *testing.T) {
 												Object: &semantic.IdentifierExpression{
 													Name: "r",
 												},
-												Property: "_metric",
+												Property: "_measurement",
 											},
 											Right: &semantic.StringLiteral{
 												Value: "node_cpu",
@@ -417,16 +711,48 @@ func TestBuild(t *testing.T) {
 						},
 					},
 					{
-						ID: flux.OperationID("count"), Spec: &transformations.CountOpSpec{},
+						ID: "instant",
+						Spec: &RangeFunctionOpSpec{
+							Function: "last_over_time",
+							Range:    flux.Duration(5 * time.Minute),
+							Start:    flux.Now,
+							Stop:     flux.Now,
+						},
+					},
+					{
+						ID: "merge",
+						Spec: &transformations.GroupOpSpec{
+							By: []string{"_time"},
+						},
+					},
+					{
+						ID: flux.OperationID("count"),
+						Spec: &transformations.CountOpSpec{
+							AggregateConfig: execute.AggregateConfig{
+								Columns: []string{execute.DefaultValueColLabel},
+							},
+						},
 					},
 				},
 				Edges: []flux.Edge{
 					{
 						Parent: flux.OperationID("from"),
+						Child:  flux.OperationID("range"),
+					},
+					{
+						Parent: flux.OperationID("range"),
 						Child:  flux.OperationID("where"),
 					},
 					{
 						Parent: flux.OperationID("where"),
+						Child:  flux.OperationID("instant"),
+					},
+					{
+						Parent: flux.OperationID("instant"),
+						Child:  flux.OperationID("merge"),
+					},
+					{
+						Parent: flux.OperationID("merge"),
 						Child:  flux.OperationID("count"),
 					},
 				},
@@ -444,7 +770,11 @@ func TestBuild(t *testing.T) {
 					{
 						ID: flux.OperationID("range"),
 						Spec: &transformations.RangeOpSpec{
-							Start: flux.Time{Relative: -time.Minute * 7},
+							Start:    flux.Time{IsRelative: true, Relative: -time.Minute * 7},
+							Stop:     flux.Time{IsRelative: true, Relative: -time.Minute*5 + time.Nanosecond},
+							TimeCol:  execute.DefaultTimeColLabel,
+							StartCol: execute.DefaultStartColLabel,
+							StopCol:  execute.DefaultStopColLabel,
 						},
 					},
 					{
@@ -460,7 +790,7 @@ func TestBuild(t *testing.T) {
 											Object: &semantic.IdentifierExpression{
 												Name: "r",
 											},
-											Property: "_metric",
+											Property: "_measurement",
 										},
 										Right: &semantic.StringLiteral{
 											Value: "node_cpu",
@@ -508,7 +838,11 @@ func TestBuild(t *testing.T) {
 					{
 						ID: flux.OperationID("range"),
 						Spec: &transformations.RangeOpSpec{
-							Start: flux.Time{Relative: -170 * time.Hour},
+							Start:    flux.Time{IsRelative: true, Relative: -170 * time.Hour},
+							Stop:     flux.Time{IsRelative: true, Relative: time.Nanosecond},
+							TimeCol:  execute.DefaultTimeColLabel,
+							StartCol: execute.DefaultStartColLabel,
+							StopCol:  execute.DefaultStopColLabel,
 						},
 					},
 					{
@@ -524,7 +858,7 @@ func TestBuild(t *testing.T) {
 											Object: &semantic.IdentifierExpression{
 												Name: "r",
 											},
-											Property: "_metric",
+											Property: "_measurement",
 										},
 										Right: &semantic.StringLiteral{
 											Value: "node_cpu",
@@ -547,7 +881,156 @@ func TestBuild(t *testing.T) {
 						},
 					},
 					{
-						ID: flux.OperationID("sum"), Spec: &transformations.SumOpSpec{},
+						ID: flux.OperationID("sum"),
+						Spec: &transformations.SumOpSpec{
+							AggregateConfig: execute.AggregateConfig{
+								Columns: []string{execute.DefaultValueColLabel},
+							},
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{
+						Parent: flux.OperationID("from"),
+						Child:  flux.OperationID("range"),
+					},
+					{
+						Parent: flux.OperationID("range"),
+						Child:  flux.OperationID("where"),
+					},
+					{
+						Parent: flux.OperationID("where"),
+						Child:  flux.OperationID("sum"),
+					},
+				},
+			},
+		},
+		{
+			name:   "rate over a range",
+			promql: `rate(http_requests_total[5m])`,
+			want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID:   flux.OperationID("from"),
+						Spec: &inputs.FromOpSpec{Bucket: "prometheus"},
+					},
+					{
+						ID: flux.OperationID("range"),
+						Spec: &transformations.RangeOpSpec{
+							Start:    flux.Time{IsRelative: true, Relative: -5 * time.Minute},
+							Stop:     flux.Time{IsRelative: true, Relative: time.Nanosecond},
+							TimeCol:  execute.DefaultTimeColLabel,
+							StartCol: execute.DefaultStartColLabel,
+							StopCol:  execute.DefaultStopColLabel,
+						},
+					},
+					{
+						ID: "where",
+						Spec: &transformations.FilterOpSpec{
+							Fn: &semantic.FunctionExpression{
+								Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "r"}}},
+								Body: &semantic.BinaryExpression{
+									Operator: ast.EqualOperator,
+									Left: &semantic.MemberExpression{
+										Object: &semantic.IdentifierExpression{
+											Name: "r",
+										},
+										Property: "_measurement",
+									},
+									Right: &semantic.StringLiteral{
+										Value: "http_requests_total",
+									},
+								},
+							},
+						},
+					},
+					{
+						ID: "rate",
+						Spec: &RangeFunctionOpSpec{
+							Function: "rate",
+							Range:    flux.Duration(5 * time.Minute),
+							Start:    flux.Now,
+							Stop:     flux.Now,
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{
+						Parent: flux.OperationID("from"),
+						Child:  flux.OperationID("range"),
+					},
+					{
+						Parent: flux.OperationID("range"),
+						Child:  flux.OperationID("where"),
+					},
+					{
+						Parent: flux.OperationID("where"),
+						Child:  flux.OperationID("rate"),
+					},
+				},
+			},
+		},
+		{
+			name:   "sum of rate with group by",
+			promql: `sum(rate(http_requests_total[5m])) by (job)`,
+			want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID:   flux.OperationID("from"),
+						Spec: &inputs.FromOpSpec{Bucket: "prometheus"},
+					},
+					{
+						ID: flux.OperationID("range"),
+						Spec: &transformations.RangeOpSpec{
+							Start:    flux.Time{IsRelative: true, Relative: -5 * time.Minute},
+							Stop:     flux.Time{IsRelative: true, Relative: time.Nanosecond},
+							TimeCol:  execute.DefaultTimeColLabel,
+							StartCol: execute.DefaultStartColLabel,
+							StopCol:  execute.DefaultStopColLabel,
+						},
+					},
+					{
+						ID: "where",
+						Spec: &transformations.FilterOpSpec{
+							Fn: &semantic.FunctionExpression{
+								Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "r"}}},
+								Body: &semantic.BinaryExpression{
+									Operator: ast.EqualOperator,
+									Left: &semantic.MemberExpression{
+										Object: &semantic.IdentifierExpression{
+											Name: "r",
+										},
+										Property: "_measurement",
+									},
+									Right: &semantic.StringLiteral{
+										Value: "http_requests_total",
+									},
+								},
+							},
+						},
+					},
+					{
+						ID: "rate",
+						Spec: &RangeFunctionOpSpec{
+							Function: "rate",
+							Range:    flux.Duration(5 * time.Minute),
+							Start:    flux.Now,
+							Stop:     flux.Now,
+						},
+					},
+					{
+						ID: "merge",
+						Spec: &transformations.GroupOpSpec{
+							By: []string{"job", "_time"},
+						},
+					},
+					{
+						ID: "sum",
+						Spec: &transformations.SumOpSpec{
+							AggregateConfig: execute.AggregateConfig{
+								Columns: []string{execute.DefaultValueColLabel},
+							},
+						},
 					},
 				},
 				Edges: []flux.Edge{
@@ -561,11 +1044,329 @@ func TestBuild(t *testing.T) {
 					},
 					{
 						Parent: flux.OperationID("where"),
+						Child:  flux.OperationID("rate"),
+					},
+					{
+						Parent: flux.OperationID("rate"),
+						Child:  flux.OperationID("merge"),
+					},
+					{
+						Parent: flux.OperationID("merge"),
 						Child:  flux.OperationID("sum"),
 					},
 				},
 			},
 		},
+		{
+			name:   "topk",
+			promql: `topk(3, http_requests_total)`,
+			want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID:   flux.OperationID("from"),
+						Spec: &inputs.FromOpSpec{Bucket: "prometheus"},
+					},
+					{
+						ID: flux.OperationID("range"),
+						Spec: &transformations.RangeOpSpec{
+							Start:    flux.Time{IsRelative: true, Relative: -5 * time.Minute},
+							Stop:     flux.Time{IsRelative: true, Relative: time.Nanosecond},
+							TimeCol:  execute.DefaultTimeColLabel,
+							StartCol: execute.DefaultStartColLabel,
+							StopCol:  execute.DefaultStopColLabel,
+						},
+					},
+					{
+						ID: "where",
+						Spec: &transformations.FilterOpSpec{
+							Fn: &semantic.FunctionExpression{
+								Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "r"}}},
+								Body: &semantic.BinaryExpression{
+									Operator: ast.EqualOperator,
+									Left: &semantic.MemberExpression{
+										Object: &semantic.IdentifierExpression{
+											Name: "r",
+										},
+										Property: "_measurement",
+									},
+									Right: &semantic.StringLiteral{
+										Value: "http_requests_total",
+									},
+								},
+							},
+						},
+					},
+					{
+						ID: "instant",
+						Spec: &RangeFunctionOpSpec{
+							Function: "last_over_time",
+							Range:    flux.Duration(5 * time.Minute),
+							Start:    flux.Now,
+							Stop:     flux.Now,
+						},
+					},
+					{
+						ID: "merge",
+						Spec: &transformations.GroupOpSpec{
+							By: []string{"_time"},
+						},
+					},
+					{
+						ID: "sort",
+						Spec: &transformations.SortOpSpec{
+							Cols: []string{execute.DefaultValueColLabel},
+							Desc: true,
+						},
+					},
+					{
+						ID: "limit",
+						Spec: &transformations.LimitOpSpec{
+							N: 3,
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{
+						Parent: flux.OperationID("from"),
+						Child:  flux.OperationID("range"),
+					},
+					{
+						Parent: flux.OperationID("range"),
+						Child:  flux.OperationID("where"),
+					},
+					{
+						Parent: flux.OperationID("where"),
+						Child:  flux.OperationID("instant"),
+					},
+					{
+						Parent: flux.OperationID("instant"),
+						Child:  flux.OperationID("merge"),
+					},
+					{
+						Parent: flux.OperationID("merge"),
+						Child:  flux.OperationID("sort"),
+					},
+					{
+						Parent: flux.OperationID("sort"),
+						Child:  flux.OperationID("limit"),
+					},
+				},
+			},
+		},
+		{
+			name:   "max",
+			promql: `max(node_load1)`,
+			want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID:   flux.OperationID("from"),
+						Spec: &inputs.FromOpSpec{Bucket: "prometheus"},
+					},
+					{
+						ID: flux.OperationID("range"),
+						Spec: &transformations.RangeOpSpec{
+							Start:    flux.Time{IsRelative: true, Relative: -5 * time.Minute},
+							Stop:     flux.Time{IsRelative: true, Relative: time.Nanosecond},
+							TimeCol:  execute.DefaultTimeColLabel,
+							StartCol: execute.DefaultStartColLabel,
+							StopCol:  execute.DefaultStopColLabel,
+						},
+					},
+					{
+						ID: "where",
+						Spec: &transformations.FilterOpSpec{
+							Fn: &semantic.FunctionExpression{
+								Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "r"}}},
+								Body: &semantic.BinaryExpression{
+									Operator: ast.EqualOperator,
+									Left: &semantic.MemberExpression{
+										Object: &semantic.IdentifierExpression{
+											Name: "r",
+										},
+										Property: "_measurement",
+									},
+									Right: &semantic.StringLiteral{
+										Value: "node_load1",
+									},
+								},
+							},
+						},
+					},
+					{
+						ID: "instant",
+						Spec: &RangeFunctionOpSpec{
+							Function: "last_over_time",
+							Range:    flux.Duration(5 * time.Minute),
+							Start:    flux.Now,
+							Stop:     flux.Now,
+						},
+					},
+					{
+						ID: "merge",
+						Spec: &transformations.GroupOpSpec{
+							By: []string{"_time"},
+						},
+					},
+					{
+						ID: "max",
+						Spec: &transformations.MaxOpSpec{
+							SelectorConfig: execute.SelectorConfig{
+								Column: execute.DefaultValueColLabel,
+							},
+						},
+					},
+					{
+						ID: "keep",
+						Spec: &transformations.KeepOpSpec{
+							Cols: []string{"_time", "_value"},
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{
+						Parent: flux.OperationID("from"),
+						Child:  flux.OperationID("range"),
+					},
+					{
+						Parent: flux.OperationID("range"),
+						Child:  flux.OperationID("where"),
+					},
+					{
+						Parent: flux.OperationID("where"),
+						Child:  flux.OperationID("instant"),
+					},
+					{
+						Parent: flux.OperationID("instant"),
+						Child:  flux.OperationID("merge"),
+					},
+					{
+						Parent: flux.OperationID("merge"),
+						Child:  flux.OperationID("max"),
+					},
+					{
+						Parent: flux.OperationID("max"),
+						Child:  flux.OperationID("keep"),
+					},
+				},
+			},
+		},
+		{
+			name:   "stddev uses the population standard deviation",
+			promql: `stddev(node_load1)`,
+			want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID:   flux.OperationID("from"),
+						Spec: &inputs.FromOpSpec{Bucket: "prometheus"},
+					},
+					{
+						ID: flux.OperationID("range"),
+						Spec: &transformations.RangeOpSpec{
+							Start:    flux.Time{IsRelative: true, Relative: -5 * time.Minute},
+							Stop:     flux.Time{IsRelative: true, Relative: time.Nanosecond},
+							TimeCol:  execute.DefaultTimeColLabel,
+							StartCol: execute.DefaultStartColLabel,
+							StopCol:  execute.DefaultStopColLabel,
+						},
+					},
+					{
+						ID: "where",
+						Spec: &transformations.FilterOpSpec{
+							Fn: &semantic.FunctionExpression{
+								Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "r"}}},
+								Body: &semantic.BinaryExpression{
+									Operator: ast.EqualOperator,
+									Left: &semantic.MemberExpression{
+										Object: &semantic.IdentifierExpression{
+											Name: "r",
+										},
+										Property: "_measurement",
+									},
+									Right: &semantic.StringLiteral{
+										Value: "node_load1",
+									},
+								},
+							},
+						},
+					},
+					{
+						ID: "instant",
+						Spec: &RangeFunctionOpSpec{
+							Function: "last_over_time",
+							Range:    flux.Duration(5 * time.Minute),
+							Start:    flux.Now,
+							Stop:     flux.Now,
+						},
+					},
+					{
+						ID: "merge",
+						Spec: &transformations.GroupOpSpec{
+							By: []string{"_time"},
+						},
+					},
+					{
+						ID: "stddev",
+						Spec: &RangeFunctionOpSpec{
+							Function: "stddev_over_time",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{
+						Parent: flux.OperationID("from"),
+						Child:  flux.OperationID("range"),
+					},
+					{
+						Parent: flux.OperationID("range"),
+						Child:  flux.OperationID("where"),
+					},
+					{
+						Parent: flux.OperationID("where"),
+						Child:  flux.OperationID("instant"),
+					},
+					{
+						Parent: flux.OperationID("instant"),
+						Child:  flux.OperationID("merge"),
+					},
+					{
+						Parent: flux.OperationID("merge"),
+						Child:  flux.OperationID("stddev"),
+					},
+				},
+			},
+		},
+		{
+			name:   "time",
+			promql: `time()`,
+			want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: flux.OperationID("time"),
+						Spec: &StepsOpSpec{
+							Start:     flux.Now,
+							Stop:      flux.Now,
+							Timestamp: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "vector",
+			promql: `vector(1)`,
+			want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: flux.OperationID("vector"),
+						Spec: &StepsOpSpec{
+							Start: flux.Now,
+							Stop:  flux.Now,
+							Value: 1,
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -582,3 +1383,83 @@ func TestBuild(t *testing.T) {
 		})
 	}
 }
+
+func TestNewQuerySpec(t *testing.T) {
+	expr, err := ParsePromQL(`sum by (job) (rate(errors[5m])) / on(job) abs(up)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	end := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	config := Config{
+		BucketID: platform.ID(1),
+		Start:    end.Add(-time.Hour),
+		End:      end,
+		Step:     time.Minute,
+	}
+	spec, err := NewQuerySpec(expr, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []flux.OperationID
+	for _, op := range spec.Operations {
+		ids = append(ids, op.ID)
+	}
+	wantIDs := []flux.OperationID{"from", "range", "where", "rate", "merge", "sum", "from1", "range1", "where1", "instant", "abs", "binary"}
+	if !cmp.Equal(wantIDs, ids) {
+		t.Fatalf("unexpected operations -want/+got\n%s", cmp.Diff(wantIDs, ids))
+	}
+
+	if want, got := (&inputs.FromOpSpec{BucketID: "0000000000000001"}), spec.Operations[0].Spec; !cmp.Equal(want, got) {
+		t.Errorf("unexpected from -want/+got\n%s", cmp.Diff(want, got))
+	}
+	wantRange := &transformations.RangeOpSpec{
+		Start:    flux.Time{Absolute: end.Add(-time.Hour - 5*time.Minute)},
+		Stop:     flux.Time{Absolute: end.Add(time.Nanosecond)},
+		TimeCol:  execute.DefaultTimeColLabel,
+		StartCol: execute.DefaultStartColLabel,
+		StopCol:  execute.DefaultStopColLabel,
+	}
+	if got := spec.Operations[1].Spec; !cmp.Equal(wantRange, got) {
+		t.Errorf("unexpected range -want/+got\n%s", cmp.Diff(wantRange, got))
+	}
+	wantRate := &RangeFunctionOpSpec{
+		Function: "rate",
+		Range:    flux.Duration(5 * time.Minute),
+		Start:    flux.Time{Absolute: end.Add(-time.Hour)},
+		Stop:     flux.Time{Absolute: end},
+		Step:     flux.Duration(time.Minute),
+	}
+	if got := spec.Operations[3].Spec; !cmp.Equal(wantRate, got) {
+		t.Errorf("unexpected rate -want/+got\n%s", cmp.Diff(wantRate, got))
+	}
+	wantBinary := &BinaryOpSpec{
+		Operator: "/",
+		LHS:      "sum",
+		RHS:      "abs",
+		Matching: &VectorMatching{
+			On:             true,
+			MatchingLabels: []string{"job"},
+		},
+	}
+	if got := spec.Operations[11].Spec; !cmp.Equal(wantBinary, got) {
+		t.Errorf("unexpected binary -want/+got\n%s", cmp.Diff(wantBinary, got))
+	}
+
+	wantEdges := []flux.Edge{
+		{Parent: "from", Child: "range"},
+		{Parent: "range", Child: "where"},
+		{Parent: "where", Child: "rate"},
+		{Parent: "rate", Child: "merge"},
+		{Parent: "merge", Child: "sum"},
+		{Parent: "from1", Child: "range1"},
+		{Parent: "range1", Child: "where1"},
+		{Parent: "where1", Child: "instant"},
+		{Parent: "instant", Child: "abs"},
+		{Parent: "sum", Child: "binary"},
+		{Parent: "abs", Child: "binary"},
+	}
+	if !cmp.Equal(wantEdges, spec.Edges) {
+		t.Errorf("unexpected edges -want/+got\n%s", cmp.Diff(wantEdges, spec.Edges))
+	}
+}
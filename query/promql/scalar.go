@@ -0,0 +1,290 @@
+package promql
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+)
+
+// StepsKind is the source that has a sample at each evaluation. It is the result of
+// time() and of vector() with a number. It is not registered as a Flux function.
+const StepsKind = "promqlSteps"
+
+// ScalarKind is the transformation that converts an instant vector to a scalar at
+// each evaluation. It is not registered as a Flux function.
+const ScalarKind = "promqlScalar"
+
+func init() {
+	flux.RegisterOpSpec(StepsKind, newStepsOp)
+	plan.RegisterProcedureSpec(StepsKind, newStepsProcedure, StepsKind)
+	execute.RegisterSource(StepsKind, createStepsSource)
+
+	flux.RegisterOpSpec(ScalarKind, newScalarOp)
+	plan.RegisterProcedureSpec(ScalarKind, newScalarProcedure, ScalarKind)
+	execute.RegisterTransformation(ScalarKind, createScalarTransformation)
+}
+
+// StepsOpSpec produces a table without labels with a sample at each step from the
+// start to the stop. If Timestamp is set, the value of each sample is its time in
+// seconds. Otherwise, it is the value.
+type StepsOpSpec struct {
+	Start     flux.Time     `json:"start"`
+	Stop      flux.Time     `json:"stop"`
+	Step      flux.Duration `json:"step"`
+	Value     float64       `json:"value"`
+	Timestamp bool          `json:"timestamp"`
+}
+
+func newStepsOp() flux.OperationSpec {
+	return new(StepsOpSpec)
+}
+
+func (s *StepsOpSpec) Kind() flux.OperationKind {
+	return StepsKind
+}
+
+type StepsProcedureSpec struct {
+	Start     flux.Time
+	Stop      flux.Time
+	Step      flux.Duration
+	Value     float64
+	Timestamp bool
+}
+
+func newStepsProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*StepsOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &StepsProcedureSpec{
+		Start:     spec.Start,
+		Stop:      spec.Stop,
+		Step:      spec.Step,
+		Value:     spec.Value,
+		Timestamp: spec.Timestamp,
+	}, nil
+}
+
+func (s *StepsProcedureSpec) Kind() plan.ProcedureKind {
+	return StepsKind
+}
+
+func (s *StepsProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createStepsSource(spec plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	s, ok := spec.(*StepsProcedureSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	return &stepsSource{
+		id:        id,
+		alloc:     a.Allocator(),
+		start:     a.ResolveTime(s.Start),
+		stop:      a.ResolveTime(s.Stop),
+		step:      execute.Duration(s.Step),
+		value:     s.Value,
+		timestamp: s.Timestamp,
+	}, nil
+}
+
+type stepsSource struct {
+	id    execute.DatasetID
+	ts    []execute.Transformation
+	alloc *execute.Allocator
+
+	start, stop execute.Time
+	step        execute.Duration
+	value       float64
+	timestamp   bool
+}
+
+func (s *stepsSource) AddTransformation(t execute.Transformation) {
+	s.ts = append(s.ts, t)
+}
+
+func (s *stepsSource) Run(ctx context.Context) {
+	tbl, err := s.table()
+	if err == nil {
+		for _, t := range s.ts {
+			if err = t.Process(s.id, tbl); err != nil {
+				break
+			}
+		}
+	}
+	for _, t := range s.ts {
+		t.Finish(s.id, err)
+	}
+}
+
+// table returns the table with a sample at each step.
+func (s *stepsSource) table() (flux.Table, error) {
+	builder := execute.NewColListTableBuilder(execute.NewGroupKey(nil, nil), s.alloc)
+	timeIdx := builder.AddCol(flux.ColMeta{Label: execute.DefaultTimeColLabel, Type: flux.TTime})
+	valueIdx := builder.AddCol(flux.ColMeta{Label: execute.DefaultValueColLabel, Type: flux.TFloat})
+	for ts := s.start; ts <= s.stop; ts += execute.Time(s.step) {
+		v := s.value
+		if s.timestamp {
+			v = seconds(ts)
+		}
+		builder.AppendTime(timeIdx, ts)
+		builder.AppendFloat(valueIdx, v)
+		if s.step <= 0 {
+			break
+		}
+	}
+	return builder.Table()
+}
+
+// ScalarOpSpec converts the samples at each step from the start to the stop to a
+// scalar. The scalar is the value of the sample if there is exactly one and NaN
+// otherwise. The result is a table without labels.
+type ScalarOpSpec struct {
+	Start flux.Time     `json:"start"`
+	Stop  flux.Time     `json:"stop"`
+	Step  flux.Duration `json:"step"`
+}
+
+func newScalarOp() flux.OperationSpec {
+	return new(ScalarOpSpec)
+}
+
+func (s *ScalarOpSpec) Kind() flux.OperationKind {
+	return ScalarKind
+}
+
+type ScalarProcedureSpec struct {
+	Start flux.Time
+	Stop  flux.Time
+	Step  flux.Duration
+}
+
+func newScalarProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ScalarOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &ScalarProcedureSpec{
+		Start: spec.Start,
+		Stop:  spec.Stop,
+		Step:  spec.Step,
+	}, nil
+}
+
+func (s *ScalarProcedureSpec) Kind() plan.ProcedureKind {
+	return ScalarKind
+}
+
+func (s *ScalarProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createScalarTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ScalarProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewScalarTransformation(d, cache, s, a.ResolveTime(s.Start), a.ResolveTime(s.Stop))
+	return t, d, nil
+}
+
+type scalarTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	start, stop execute.Time
+	step        execute.Duration
+
+	// samples are the values of the samples of all of the series by time.
+	samples map[execute.Time][]float64
+}
+
+// NewScalarTransformation creates a transformation that converts the samples at each
+// step from the start to the stop to a scalar.
+func NewScalarTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *ScalarProcedureSpec, start, stop execute.Time) execute.Transformation {
+	return &scalarTransformation{
+		d:       d,
+		cache:   cache,
+		start:   start,
+		stop:    stop,
+		step:    execute.Duration(spec.Step),
+		samples: make(map[execute.Time][]float64),
+	}
+}
+
+func (t *scalarTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return nil
+}
+
+// Process reads the samples of the table. The scalars are calculated once the
+// samples of all of the series are read.
+func (t *scalarTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	timeIdx := execute.ColIdx(execute.DefaultTimeColLabel, tbl.Cols())
+	if timeIdx < 0 {
+		return fmt.Errorf("no %s column found", execute.DefaultTimeColLabel)
+	}
+	valueIdx := execute.ColIdx(execute.DefaultValueColLabel, tbl.Cols())
+	if valueIdx < 0 {
+		return fmt.Errorf("no %s column found", execute.DefaultValueColLabel)
+	}
+
+	return tbl.Do(func(cr flux.ColReader) error {
+		times := cr.Times(timeIdx)
+		for i := 0; i < cr.Len(); i++ {
+			v, err := floatValue(cr, i, valueIdx)
+			if err != nil {
+				return fmt.Errorf("scalar: %v", err)
+			}
+			t.samples[times[i]] = append(t.samples[times[i]], v)
+		}
+		return nil
+	})
+}
+
+func (t *scalarTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return nil
+}
+
+func (t *scalarTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return nil
+}
+
+func (t *scalarTransformation) Finish(id execute.DatasetID, err error) {
+	if err == nil {
+		err = t.evaluate()
+	}
+	t.d.Finish(err)
+}
+
+// evaluate adds the table with the scalar at each step.
+func (t *scalarTransformation) evaluate() error {
+	key := execute.NewGroupKey(nil, nil)
+	builder, created := t.cache.TableBuilder(key)
+	if !created {
+		return fmt.Errorf("scalar found duplicate table with key: %v", key)
+	}
+	timeIdx := builder.AddCol(flux.ColMeta{Label: execute.DefaultTimeColLabel, Type: flux.TTime})
+	valueIdx := builder.AddCol(flux.ColMeta{Label: execute.DefaultValueColLabel, Type: flux.TFloat})
+
+	for ts := t.start; ts <= t.stop; ts += execute.Time(t.step) {
+		v := math.NaN()
+		if samples := t.samples[ts]; len(samples) == 1 {
+			v = samples[0]
+		}
+		builder.AppendTime(timeIdx, ts)
+		builder.AppendFloat(valueIdx, v)
+		if t.step <= 0 {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package promql
+
+import (
+	"math"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+)
+
+func TestScalar_Process(t *testing.T) {
+	d := executetest.NewDataset(executetest.RandomDatasetID())
+	c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+	c.SetTriggerSpec(execute.DefaultTriggerSpec)
+
+	// The scalar is NaN at the first step, which has no samples, and at the last
+	// step, which has a sample in each series.
+	tx := NewScalarTransformation(d, c, &ScalarProcedureSpec{Step: flux.Duration(1)}, 0, 3)
+	parentID := executetest.RandomDatasetID()
+	for _, tbl := range []*executetest.Table{
+		{
+			KeyCols: []string{"job"},
+			ColMeta: []flux.ColMeta{
+				{Label: "job", Type: flux.TString},
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{"api", execute.Time(1), 1.0},
+				{"api", execute.Time(3), 3.0},
+			},
+		},
+		{
+			KeyCols: []string{"job"},
+			ColMeta: []flux.ColMeta{
+				{Label: "job", Type: flux.TString},
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{"db", execute.Time(2), 2.0},
+				{"db", execute.Time(3), 3.0},
+			},
+		},
+	} {
+		if err := tx.Process(parentID, tbl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tx.Finish(parentID, nil)
+
+	if !d.Finished {
+		t.Fatal("expected the transformation to finish")
+	} else if d.FinishedErr != nil {
+		t.Fatal(d.FinishedErr)
+	}
+
+	got, err := executetest.TablesFromCache(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("unexpected number of tables: got %d, want 1", len(got))
+	} else if len(got[0].KeyCols) != 0 {
+		t.Errorf("unexpected labels: %v", got[0].KeyCols)
+	}
+
+	want := []float64{math.NaN(), 1, 2, math.NaN()}
+	if len(got[0].Data) != len(want) {
+		t.Fatalf("unexpected number of samples: got %d, want %d", len(got[0].Data), len(want))
+	}
+	for i, row := range got[0].Data {
+		if ts := row[0].(execute.Time); ts != execute.Time(i) {
+			t.Errorf("unexpected time of sample %d: %v", i, ts)
+		}
+		v := row[1].(float64)
+		if v != want[i] && !(math.IsNaN(v) && math.IsNaN(want[i])) {
+			t.Errorf("unexpected value at %d: got %v, want %v", i, v, want[i])
+		}
+	}
+}
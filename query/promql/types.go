@@ -2,13 +2,14 @@ package promql
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/ast"
-	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/functions/transformations"
 	"github.com/influxdata/flux/semantic"
 )
@@ -24,6 +25,89 @@ const (
 	SelectorKind
 )
 
+const (
+	// LookbackDelta is how long before an evaluation the last point of a series
+	// is its value in an instant vector.
+	LookbackDelta = 5 * time.Minute
+
+	// MetricNameColumn is the column with the name of the metric of each series.
+	MetricNameColumn = "_measurement"
+)
+
+// LabelName returns the name of the label of a series that is stored in the
+// column. It returns false if the column is not a label.
+func LabelName(c flux.ColMeta) (string, bool) {
+	if c.Type != flux.TString {
+		return "", false
+	}
+	switch c.Label {
+	case MetricNameColumn:
+		return "__name__", true
+	case "_field", execute.DefaultStartColLabel, execute.DefaultStopColLabel:
+		return "", false
+	}
+	return c.Label, true
+}
+
+// ValueType is the type of the result of an expression.
+type ValueType int
+
+const (
+	NoneType ValueType = iota
+	ScalarType
+	VectorType
+	MatrixType
+)
+
+func (t ValueType) String() string {
+	switch t {
+	case ScalarType:
+		return "scalar"
+	case VectorType:
+		return "vector"
+	case MatrixType:
+		return "matrix"
+	default:
+		return "none"
+	}
+}
+
+// documentedType returns the name of the type in the PromQL documentation.
+func documentedType(t ValueType) string {
+	switch t {
+	case VectorType:
+		return "instant vector"
+	case MatrixType:
+		return "range vector"
+	default:
+		return t.String()
+	}
+}
+
+// TypeOf returns the type of the result of a parsed expression.
+func TypeOf(expr interface{}) ValueType {
+	switch expr := expr.(type) {
+	case *Number:
+		return ScalarType
+	case *Selector:
+		if expr.Range > 0 {
+			return MatrixType
+		}
+		return VectorType
+	case *ScalarFunction:
+		return ScalarType
+	case *BinaryExpr:
+		if TypeOf(expr.LHS) == ScalarType && TypeOf(expr.RHS) == ScalarType {
+			return ScalarType
+		}
+		return VectorType
+	case *Comment:
+		return NoneType
+	default:
+		return VectorType
+	}
+}
+
 type QueryBuilder interface {
 	QuerySpec() (*flux.Spec, error)
 }
@@ -141,71 +225,69 @@ type Selector struct {
 	LabelMatchers []*LabelMatcher `json:"label_matchers,omitempty"`
 }
 
+// QuerySpec returns the query specification for the selector.
 func (s *Selector) QuerySpec() (*flux.Spec, error) {
-	parent := "from"
-	ops := []*flux.Operation{
-		{
-			ID: "from", // TODO: Change this to a UUID
-			Spec: &inputs.FromOpSpec{
-				Bucket: "prometheus",
-			},
-		},
-	}
-	edges := []flux.Edge{}
+	return newBuilder(Config{}).build(s)
+}
 
-	rng, err := NewRangeOp(s.Range, s.Offset)
-	if err != nil {
-		return nil, err
+func (s *Selector) build(b *builder) (flux.OperationID, error) {
+	if s.Range > 0 {
+		return s.source(b, s.Range)
 	}
 
-	if rng != nil {
-		ops = append(ops, rng)
-		edge := flux.Edge{
-			Parent: flux.OperationID(parent),
-			Child:  "range",
-		}
-		parent = "range"
-		edges = append(edges, edge)
+	// The value of a series at each evaluation is its last point within the
+	// lookback delta.
+	parent, err := s.source(b, LookbackDelta)
+	if err != nil {
+		return "", err
 	}
+	start, stop, step := b.evaluation()
+	return b.add(&flux.Operation{
+		ID: "instant",
+		Spec: &RangeFunctionOpSpec{
+			Function: "last_over_time",
+			Range:    flux.Duration(LookbackDelta),
+			Offset:   flux.Duration(s.Offset),
+			Start:    start,
+			Stop:     stop,
+			Step:     step,
+		},
+	}, parent), nil
+}
 
+// source adds the operations that read the points of the series within the range
+// before each evaluation.
+func (s *Selector) source(b *builder, rng time.Duration) (flux.OperationID, error) {
 	where, err := NewWhereOperation(s.Name, s.LabelMatchers)
 	if err != nil {
-		return nil, err
-	}
-
-	ops = append(ops, where)
-	edge := flux.Edge{
-		Parent: flux.OperationID(parent),
-		Child:  "where",
+		return "", err
 	}
-	parent = "where"
-	edges = append(edges, edge)
 
-	return &flux.Spec{
-		Operations: ops,
-		Edges:      edges,
-	}, nil
+	// The stop of the range is exclusive, but a point at the time of the last
+	// evaluation is within its range.
+	start, stop, _ := b.evaluation()
+	rangeOp := NewRangeOp(shift(start, -rng-s.Offset), shift(stop, -s.Offset+time.Nanosecond))
+	return b.chain(b.from(), rangeOp, where), nil
 }
 
-func NewRangeOp(rng, offset time.Duration) (*flux.Operation, error) {
-	if rng == 0 && offset == 0 {
-		return nil, nil
-	}
+func NewRangeOp(start, stop flux.Time) *flux.Operation {
 	return &flux.Operation{
 		ID: "range", // TODO: Change this to a UUID
 		Spec: &transformations.RangeOpSpec{
-			Start: flux.Time{
-				Relative: -rng - offset,
-			},
+			Start:    start,
+			Stop:     stop,
+			TimeCol:  execute.DefaultTimeColLabel,
+			StartCol: execute.DefaultStartColLabel,
+			StopCol:  execute.DefaultStopColLabel,
 		},
-	}, nil
+	}
 }
 
 var operatorLookup = map[MatchKind]ast.OperatorKind{
 	Equal:        ast.EqualOperator,
 	NotEqual:     ast.NotEqualOperator,
-	RegexMatch:   ast.EqualOperator,
-	RegexNoMatch: ast.NotEqualOperator,
+	RegexMatch:   ast.RegexpMatchOperator,
+	RegexNoMatch: ast.NotRegexpMatchOperator,
 }
 
 func NewWhereOperation(metricName string, labels []*LabelMatcher) (*flux.Operation, error) {
@@ -216,7 +298,7 @@ func NewWhereOperation(metricName string, labels []*LabelMatcher) (*flux.Operati
 			Object: &semantic.IdentifierExpression{
 				Name: "r",
 			},
-			Property: "_metric",
+			Property: MetricNameColumn,
 		},
 		Right: &semantic.StringLiteral{
 			Value: metricName,
@@ -234,7 +316,20 @@ func NewWhereOperation(metricName string, labels []*LabelMatcher) (*flux.Operati
 			Property: label.Name,
 		}
 		var value semantic.Expression
-		if label.Value.Type() == StringKind {
+		if label.Kind == RegexMatch || label.Kind == RegexNoMatch {
+			s, ok := label.Value.Value().(string)
+			if !ok {
+				return nil, fmt.Errorf("expected string for regular expression of label %s, got %T", label.Name, label.Value.Value())
+			}
+			// Like Prometheus, the regular expression must match the whole value.
+			re, err := regexp.Compile("^(?:" + s + ")$")
+			if err != nil {
+				return nil, err
+			}
+			value = &semantic.RegexpLiteral{
+				Value: re,
+			}
+		} else if label.Value.Type() == StringKind {
 			value = &semantic.StringLiteral{
 				Value: label.Value.Value().(string),
 			}
@@ -293,6 +388,49 @@ func NewSelector(metric *Identifier, block, rng, offset interface{}) (*Selector,
 	return sel, nil
 }
 
+// RangeFunction is a function like rate() that is evaluated over the points of
+// each series in a range vector.
+type RangeFunction struct {
+	Name     string    `json:"name,omitempty"`
+	Selector *Selector `json:"selector,omitempty"`
+}
+
+// QuerySpec returns the query specification for the function.
+func (f *RangeFunction) QuerySpec() (*flux.Spec, error) {
+	return newBuilder(Config{}).build(f)
+}
+
+func (f *RangeFunction) build(b *builder) (flux.OperationID, error) {
+	parent, err := f.Selector.source(b, f.Selector.Range)
+	if err != nil {
+		return "", err
+	}
+	start, stop, step := b.evaluation()
+	return b.add(&flux.Operation{
+		ID: flux.OperationID(f.Name),
+		Spec: &RangeFunctionOpSpec{
+			Function: f.Name,
+			Range:    flux.Duration(f.Selector.Range),
+			Offset:   flux.Duration(f.Selector.Offset),
+			Start:    start,
+			Stop:     stop,
+			Step:     step,
+		},
+	}, parent), nil
+}
+
+func NewRangeFunction(name string, selector *Selector) (*RangeFunction, error) {
+	if _, ok := rangeFunctions[name]; !ok {
+		return nil, fmt.Errorf("unknown function %s", name)
+	} else if selector.Range == 0 {
+		return nil, fmt.Errorf("expected type range vector in call to function %s, got instant vector", name)
+	}
+	return &RangeFunction{
+		Name:     name,
+		Selector: selector,
+	}, nil
+}
+
 type Aggregate struct {
 	Without bool          `json:"without,omitempty"`
 	By      bool          `json:"by,omitempty"`
@@ -367,103 +505,175 @@ type Operator struct {
 	Arg  Arg          `json:"arg,omitempty"`
 }
 
-func (o *Operator) QuerySpec() (*flux.Operation, error) {
+// QuerySpec returns the operations that apply the operator. The operations are
+// chained together in the order they are returned.
+func (o *Operator) QuerySpec() ([]*flux.Operation, error) {
+	aggregateConfig := execute.AggregateConfig{
+		Columns: []string{execute.DefaultValueColLabel},
+	}
+	selectorConfig := execute.SelectorConfig{
+		Column: execute.DefaultValueColLabel,
+	}
+
 	switch o.Kind {
-	case CountValuesKind, BottomKind, QuantileKind, StdVarKind:
+	case CountValuesKind, QuantileKind:
 		return nil, fmt.Errorf("Unable to run %d yet", o.Kind)
 	case CountKind:
-		return &flux.Operation{
+		return []*flux.Operation{{
 			ID:   "count",
-			Spec: &transformations.CountOpSpec{},
+			Spec: &transformations.CountOpSpec{AggregateConfig: aggregateConfig},
+		}}, nil
+	case TopKind, BottomKind:
+		k, ok := o.Arg.Value().(float64)
+		if !ok {
+			return nil, fmt.Errorf("expected number as parameter, got %T", o.Arg.Value())
+		} else if k < 1 {
+			return nil, fmt.Errorf("parameter k must be at least 1, got %v", k)
+		}
+		return []*flux.Operation{
+			{
+				ID: "sort",
+				Spec: &transformations.SortOpSpec{
+					Cols: []string{execute.DefaultValueColLabel},
+					Desc: o.Kind == TopKind,
+				},
+			},
+			{
+				ID:   "limit",
+				Spec: &transformations.LimitOpSpec{N: int64(k)},
+			},
 		}, nil
-	//case TopKind:
-	//	return &flux.Operation{
-	//		ID:   "top",
-	//		Spec: &transformations.TopOpSpec{}, // TODO: Top doesn't have arg yet
-	//	}, nil
 	case SumKind:
-		return &flux.Operation{
+		return []*flux.Operation{{
 			ID:   "sum",
-			Spec: &transformations.SumOpSpec{},
-		}, nil
-	//case MinKind:
-	//	return &flux.Operation{
-	//		ID:   "min",
-	//		Spec: &transformations.MinOpSpec{},
-	//	}, nil
-	//case MaxKind:
-	//	return &flux.Operation{
-	//		ID:   "max",
-	//		Spec: &transformations.MaxOpSpec{},
-	//	}, nil
-	//case AvgKind:
-	//	return &flux.Operation{
-	//		ID:   "mean",
-	//		Spec: &transformations.MeanOpSpec{},
-	//	}, nil
-	//case StdevKind:
-	//	return &flux.Operation{
-	//		ID:   "stddev",
-	//		Spec: &transformations.StddevOpSpec{},
-	//	}, nil
+			Spec: &transformations.SumOpSpec{AggregateConfig: aggregateConfig},
+		}}, nil
+	case MinKind:
+		return []*flux.Operation{{
+			ID:   "min",
+			Spec: &transformations.MinOpSpec{SelectorConfig: selectorConfig},
+		}}, nil
+	case MaxKind:
+		return []*flux.Operation{{
+			ID:   "max",
+			Spec: &transformations.MaxOpSpec{SelectorConfig: selectorConfig},
+		}}, nil
+	case AvgKind:
+		return []*flux.Operation{{
+			ID:   "mean",
+			Spec: &transformations.MeanOpSpec{AggregateConfig: aggregateConfig},
+		}}, nil
+	case StdevKind, StdVarKind:
+		// Prometheus uses the population standard deviation and variance, but
+		// the Flux stddev() uses the sample standard deviation.
+		fn := "stddev_over_time"
+		if o.Kind == StdVarKind {
+			fn = "stdvar_over_time"
+		}
+		return []*flux.Operation{{
+			ID:   flux.OperationID(strings.TrimSuffix(fn, "_over_time")),
+			Spec: &RangeFunctionOpSpec{Function: fn},
+		}}, nil
 	default:
 		return nil, fmt.Errorf("Unknown Op kind %d", o.Kind)
 	}
 }
 
 type AggregateExpr struct {
-	Op        *Operator  `json:"op,omitempty"`
-	Selector  *Selector  `json:"selector,omitempty"`
-	Aggregate *Aggregate `json:"aggregate,omitempty"`
+	Op        *Operator      `json:"op,omitempty"`
+	Selector  *Selector      `json:"selector,omitempty"`
+	Function  *RangeFunction `json:"function,omitempty"`
+	Aggregate *Aggregate     `json:"aggregate,omitempty"`
+	// Expr is the vector if it is not a selector or a range function.
+	Expr interface{} `json:"expr,omitempty"`
 }
 
+// QuerySpec returns the query specification for the aggregation.
 func (a *AggregateExpr) QuerySpec() (*flux.Spec, error) {
-	spec, err := a.Selector.QuerySpec()
+	return newBuilder(Config{}).build(a)
+}
+
+func (a *AggregateExpr) vector() interface{} {
+	switch {
+	case a.Function != nil:
+		return a.Function
+	case a.Expr != nil:
+		return a.Expr
+	default:
+		return a.Selector
+	}
+}
+
+func (a *AggregateExpr) build(b *builder) (flux.OperationID, error) {
+	vector, ok := a.vector().(expression)
+	if !ok {
+		return "", fmt.Errorf("unexpected vector type %T", a.vector())
+	}
+	parent, err := vector.build(b)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
+	var group *flux.Operation
 	if a.Aggregate != nil {
-		agg, err := a.Aggregate.QuerySpec()
-		if err != nil {
-			return nil, err
+		if group, err = a.Aggregate.QuerySpec(); err != nil {
+			return "", err
 		}
-
-		parent := flux.OperationID("from")
-		if len(spec.Edges) > 0 {
-			tail := spec.Edges[len(spec.Edges)-1]
-			parent = tail.Child
+	}
+	// The points of a range vector are aggregated together. The series of an
+	// instant vector are aggregated at each evaluation.
+	instant := TypeOf(vector) == VectorType
+	if instant {
+		if group == nil {
+			group = &flux.Operation{
+				ID:   "merge",
+				Spec: &transformations.GroupOpSpec{},
+			}
 		}
-
-		spec.Operations = append(spec.Operations, agg)
-		spec.Edges = append(spec.Edges, flux.Edge{
-			Parent: parent,
-			Child:  agg.ID,
-		})
+		spec := group.Spec.(*transformations.GroupOpSpec)
+		spec.By = append(spec.By, execute.DefaultTimeColLabel)
+	}
+	if group != nil {
+		parent = b.add(group, parent)
 	}
 
-	op, err := a.Op.QuerySpec()
+	ops, err := a.Op.QuerySpec()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	parent = b.chain(parent, ops...)
 
-	parent := flux.OperationID("from")
-	if len(spec.Edges) > 0 {
-		tail := spec.Edges[len(spec.Edges)-1]
-		parent = tail.Child
+	if instant && (a.Op.Kind == MinKind || a.Op.Kind == MaxKind) {
+		// The selectors keep the row with the value, but only the labels of the
+		// group are in the result.
+		by := group.Spec.(*transformations.GroupOpSpec).By
+		cols := make([]string, len(by), len(by)+1)
+		copy(cols, by)
+		parent = b.add(&flux.Operation{
+			ID: "keep",
+			Spec: &transformations.KeepOpSpec{
+				Cols: append(cols, execute.DefaultValueColLabel),
+			},
+		}, parent)
 	}
-	spec.Operations = append(spec.Operations, op)
-	spec.Edges = append(spec.Edges, flux.Edge{
-		Parent: parent,
-		Child:  op.ID,
-	})
-	return spec, nil
+	return parent, nil
 }
 
-func NewAggregateExpr(op *Operator, selector *Selector, group interface{}) (*AggregateExpr, error) {
+// NewAggregateExpr creates an aggregate expression over a vector.
+func NewAggregateExpr(op *Operator, vector interface{}, group interface{}) (*AggregateExpr, error) {
 	expr := &AggregateExpr{
-		Op:       op,
-		Selector: selector,
+		Op: op,
+	}
+	switch vector := vector.(type) {
+	case *Selector:
+		expr.Selector = vector
+	case *RangeFunction:
+		expr.Function = vector
+	default:
+		if typ := TypeOf(vector); typ != VectorType {
+			return nil, fmt.Errorf("expected type instant vector in aggregation expression, got %s", documentedType(typ))
+		}
+		expr.Expr = vector
 	}
 	if group != nil {
 		expr.Aggregate = group.(*Aggregate)
@@ -471,6 +681,334 @@ func NewAggregateExpr(op *Operator, selector *Selector, group interface{}) (*Agg
 	return expr, nil
 }
 
+// VectorMatchCardinality is how many series on each side of a binary operator
+// match each other.
+type VectorMatchCardinality int
+
+const (
+	CardOneToOne VectorMatchCardinality = iota
+	// CardManyToOne matches many series on the left with one on the right.
+	CardManyToOne
+	// CardOneToMany matches one series on the left with many on the right.
+	CardOneToMany
+)
+
+// VectorMatching is how the series of two instant vectors are matched by a
+// binary operator.
+type VectorMatching struct {
+	Card VectorMatchCardinality `json:"card,omitempty"`
+	// On is set if only the matching labels are matched. Otherwise, all of the
+	// labels except the matching labels are matched.
+	On             bool     `json:"on,omitempty"`
+	MatchingLabels []string `json:"matching_labels,omitempty"`
+	// Include are the labels of the one side that are added to the result.
+	Include []string `json:"include,omitempty"`
+}
+
+// labelNames returns the columns of the labels. The metric name is a label too.
+func labelNames(labels interface{}) []string {
+	if labels == nil {
+		return nil
+	}
+	ids := labels.([]*Identifier)
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = id.Name
+		if id.Name == "__name__" {
+			names[i] = MetricNameColumn
+		}
+	}
+	return names
+}
+
+func NewVectorMatching(on bool, labels interface{}, group interface{}) (*VectorMatching, error) {
+	m := &VectorMatching{
+		On:             on,
+		MatchingLabels: labelNames(labels),
+	}
+	if group != nil {
+		g := group.(*VectorMatching)
+		m.Card = g.Card
+		m.Include = g.Include
+	}
+	if m.On {
+		for _, name := range m.Include {
+			if contains(m.MatchingLabels, name) {
+				return nil, fmt.Errorf("label %q must not occur in ON and GROUP clause at once", name)
+			}
+		}
+	}
+	return m, nil
+}
+
+// NewGroupModifier returns the matching for a group_left or group_right modifier.
+func NewGroupModifier(card VectorMatchCardinality, labels interface{}) (*VectorMatching, error) {
+	return &VectorMatching{
+		Card:    card,
+		Include: labelNames(labels),
+	}, nil
+}
+
+// BinaryModifiers are the modifiers after a binary operator.
+type BinaryModifiers struct {
+	ReturnBool bool
+	Matching   *VectorMatching
+}
+
+// BinaryExpr applies a binary operator to the values of two instant vectors or
+// of an instant vector and a scalar.
+type BinaryExpr struct {
+	Op         string          `json:"op,omitempty"`
+	ReturnBool bool            `json:"return_bool,omitempty"`
+	Matching   *VectorMatching `json:"matching,omitempty"`
+	LHS        interface{}     `json:"lhs,omitempty"`
+	RHS        interface{}     `json:"rhs,omitempty"`
+}
+
+// QuerySpec returns the query specification for the binary expression.
+func (e *BinaryExpr) QuerySpec() (*flux.Spec, error) {
+	return newBuilder(Config{}).build(e)
+}
+
+func (e *BinaryExpr) build(b *builder) (flux.OperationID, error) {
+	spec := &BinaryOpSpec{
+		Operator:   e.Op,
+		ReturnBool: e.ReturnBool,
+		Matching:   e.Matching,
+	}
+	var parents []flux.OperationID
+	for _, operand := range []struct {
+		expr   interface{}
+		id     *flux.OperationID
+		scalar *bool
+	}{
+		{expr: e.LHS, id: &spec.LHS, scalar: &spec.ScalarLHS},
+		{expr: e.RHS, id: &spec.RHS, scalar: &spec.ScalarRHS},
+	} {
+		switch expr := operand.expr.(type) {
+		case *Number:
+			spec.Scalar = expr.Val
+		case expression:
+			id, err := expr.build(b)
+			if err != nil {
+				return "", err
+			}
+			*operand.id = id
+			*operand.scalar = TypeOf(expr) == ScalarType
+			parents = append(parents, id)
+		default:
+			return "", fmt.Errorf("unexpected operand type %T", expr)
+		}
+	}
+	return b.add(&flux.Operation{
+		ID:   "binary",
+		Spec: spec,
+	}, parents...), nil
+}
+
+// NewBinaryExpr creates a binary expression. The result of an operator between
+// two scalars is a number.
+func NewBinaryExpr(op string, lhs, rhs interface{}, mods *BinaryModifiers) (interface{}, error) {
+	lt, rt := TypeOf(lhs), TypeOf(rhs)
+	if (lt != ScalarType && lt != VectorType) || (rt != ScalarType && rt != VectorType) {
+		return nil, fmt.Errorf("binary expression must contain only scalar and instant vector types")
+	} else if mods.ReturnBool && !comparisonOperators[op] {
+		return nil, fmt.Errorf("bool modifier can only be used on comparison operators")
+	} else if mods.Matching != nil && (lt != VectorType || rt != VectorType) {
+		return nil, fmt.Errorf("vector matching only allowed between instant vectors")
+	}
+
+	if lt == ScalarType && rt == ScalarType {
+		if comparisonOperators[op] && !mods.ReturnBool {
+			return nil, fmt.Errorf("comparisons between scalars must use BOOL modifier")
+		}
+		l, lok := lhs.(*Number)
+		r, rok := rhs.(*Number)
+		if lok && rok {
+			v, keep := binaryOp(op, l.Val, r.Val)
+			if mods.ReturnBool {
+				v = boolValue(keep)
+			}
+			return &Number{Val: v}, nil
+		}
+	}
+	return &BinaryExpr{
+		Op:         op,
+		ReturnBool: mods.ReturnBool,
+		Matching:   mods.Matching,
+		LHS:        lhs,
+		RHS:        rhs,
+	}, nil
+}
+
+// NewBinaryExprs applies the operators from left to right. Each of the rest is the
+// operator, its modifiers and the right operand separated by whitespace.
+func NewBinaryExprs(first, rest interface{}) (interface{}, error) {
+	expr := first
+	for _, r := range toIfaceSlice(rest) {
+		parts := toIfaceSlice(r)
+		var err error
+		expr, err = NewBinaryExpr(parts[1].(string), expr, parts[5], parts[3].(*BinaryModifiers))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return expr, nil
+}
+
+// InstantFunction is a function like abs() that is applied to the value of each
+// series in an instant vector.
+type InstantFunction struct {
+	Name   string      `json:"name,omitempty"`
+	Args   []float64   `json:"args,omitempty"`
+	Vector interface{} `json:"vector,omitempty"`
+}
+
+// QuerySpec returns the query specification for the function.
+func (f *InstantFunction) QuerySpec() (*flux.Spec, error) {
+	return newBuilder(Config{}).build(f)
+}
+
+func (f *InstantFunction) build(b *builder) (flux.OperationID, error) {
+	vector, ok := f.Vector.(expression)
+	if !ok {
+		return "", fmt.Errorf("unexpected vector type %T", f.Vector)
+	}
+	parent, err := vector.build(b)
+	if err != nil {
+		return "", err
+	}
+	return b.add(&flux.Operation{
+		ID: flux.OperationID(f.Name),
+		Spec: &InstantFunctionOpSpec{
+			Function: f.Name,
+			Args:     f.Args,
+		},
+	}, parent), nil
+}
+
+// NewInstantFunction creates a call to the function. The rest are the numbers
+// after the vector, each preceded by a comma.
+func NewInstantFunction(name string, vector interface{}, rest interface{}) (*InstantFunction, error) {
+	var args []float64
+	for _, r := range toIfaceSlice(rest) {
+		parts := toIfaceSlice(r)
+		args = append(args, parts[len(parts)-1].(*Number).Val)
+	}
+	if err := checkInstantFunction(name, args); err != nil {
+		return nil, err
+	} else if typ := TypeOf(vector); typ != VectorType {
+		return nil, fmt.Errorf("expected type instant vector in call to function %q, got %s", name, documentedType(typ))
+	}
+	return &InstantFunction{
+		Name:   name,
+		Args:   args,
+		Vector: vector,
+	}, nil
+}
+
+// ScalarFunction is time() or scalar() and results in a scalar that changes with the
+// time of the evaluation. Its result is a table without any labels that has a sample
+// at each evaluation, so it can be used in the same way as a series.
+type ScalarFunction struct {
+	Name string `json:"name,omitempty"`
+	// Vector is the argument of scalar(). It is not set for time().
+	Vector interface{} `json:"vector,omitempty"`
+}
+
+// QuerySpec returns the query specification for the function.
+func (f *ScalarFunction) QuerySpec() (*flux.Spec, error) {
+	return newBuilder(Config{}).build(f)
+}
+
+func (f *ScalarFunction) build(b *builder) (flux.OperationID, error) {
+	start, stop, step := b.evaluation()
+	if f.Vector == nil {
+		// The value of time() is the time of each evaluation in seconds.
+		return b.add(&flux.Operation{
+			ID: "time",
+			Spec: &StepsOpSpec{
+				Start:     start,
+				Stop:      stop,
+				Step:      step,
+				Timestamp: true,
+			},
+		}), nil
+	}
+
+	vector, ok := f.Vector.(expression)
+	if !ok {
+		return "", fmt.Errorf("unexpected vector type %T", f.Vector)
+	}
+	parent, err := vector.build(b)
+	if err != nil {
+		return "", err
+	}
+	return b.add(&flux.Operation{
+		ID: "scalar",
+		Spec: &ScalarOpSpec{
+			Start: start,
+			Stop:  stop,
+			Step:  step,
+		},
+	}, parent), nil
+}
+
+// NewScalarFunction creates a call to time() or scalar(). The vector is only set
+// for scalar().
+func NewScalarFunction(name string, vector interface{}) (*ScalarFunction, error) {
+	if name == "time" {
+		return &ScalarFunction{Name: name}, nil
+	} else if typ := TypeOf(vector); typ != VectorType {
+		return nil, fmt.Errorf("expected type instant vector in call to function %q, got %s", name, documentedType(typ))
+	}
+	return &ScalarFunction{
+		Name:   name,
+		Vector: vector,
+	}, nil
+}
+
+// VectorFunction is vector() and results in an instant vector with a single series
+// without any labels that has the value of the scalar.
+type VectorFunction struct {
+	Scalar interface{} `json:"scalar,omitempty"`
+}
+
+// QuerySpec returns the query specification for the function.
+func (f *VectorFunction) QuerySpec() (*flux.Spec, error) {
+	return newBuilder(Config{}).build(f)
+}
+
+func (f *VectorFunction) build(b *builder) (flux.OperationID, error) {
+	switch scalar := f.Scalar.(type) {
+	case *Number:
+		start, stop, step := b.evaluation()
+		return b.add(&flux.Operation{
+			ID: "vector",
+			Spec: &StepsOpSpec{
+				Start: start,
+				Stop:  stop,
+				Step:  step,
+				Value: scalar.Val,
+			},
+		}), nil
+	case expression:
+		// A scalar that changes with the time of the evaluation already is a
+		// series without any labels.
+		return scalar.build(b)
+	default:
+		return "", fmt.Errorf("unexpected scalar type %T", f.Scalar)
+	}
+}
+
+// NewVectorFunction creates a call to vector().
+func NewVectorFunction(scalar interface{}) (*VectorFunction, error) {
+	if typ := TypeOf(scalar); typ != ScalarType {
+		return nil, fmt.Errorf("expected type scalar in call to function \"vector\", got %s", documentedType(typ))
+	}
+	return &VectorFunction{Scalar: scalar}, nil
+}
+
 type Comment struct {
 	Source string `json:"source,omitempty"`
 }
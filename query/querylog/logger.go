@@ -0,0 +1,178 @@
+// Package querylog persists query logs to the query log bucket of each organization.
+package querylog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/snowflake"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb"
+	"go.uber.org/zap"
+)
+
+const (
+	// BucketName is the name of the bucket of each organization its queries are logged to.
+	BucketName = "_queries"
+	// RetentionPeriod is the retention period of the query log buckets the Logger creates.
+	RetentionPeriod = 7 * 24 * time.Hour
+)
+
+// Measurement and keys of the points written to the query log bucket.
+const (
+	measurement = "query_log"
+
+	queryIDTag      = "query_id"
+	compilerTypeTag = "compiler_type"
+	slowTag         = "slow"
+
+	queryField           = "query"
+	errorField           = "error"
+	responseSizeField    = "response_size"
+	durationField        = "duration"
+	totalDurationField   = "total_duration"
+	compileDurationField = "compile_duration"
+	queueDurationField   = "queue_duration"
+	planDurationField    = "plan_duration"
+	requeueDurationField = "requeue_duration"
+	executeDurationField = "execute_duration"
+	concurrencyField     = "concurrency"
+	maxAllocatedField    = "max_allocated"
+)
+
+// findBucket returns the query log bucket of the organization, or nil if it does not exist.
+func findBucket(ctx context.Context, bs platform.BucketService, orgID platform.ID) (*platform.Bucket, error) {
+	name := BucketName
+	buckets, _, err := bs.FindBuckets(ctx, platform.BucketFilter{OrganizationID: &orgID, Name: &name})
+	if err != nil {
+		return nil, err
+	}
+	if len(buckets) == 0 {
+		return nil, nil
+	}
+	return buckets[0], nil
+}
+
+var _ query.Logger = (*Logger)(nil)
+
+// Logger implements query.Logger by writing every query to the query log bucket
+// of the organization that issued it.
+type Logger struct {
+	PointsWriter  storage.PointsWriter
+	BucketService platform.BucketService
+	IDGenerator   platform.IDGenerator
+
+	// SlowQueryThreshold is the duration above which a query is considered slow.
+	// Slow queries are tagged in the query log and reported at warn level.
+	// A zero threshold disables slow query detection.
+	SlowQueryThreshold time.Duration
+
+	logger *zap.Logger
+}
+
+// NewLogger returns a Logger writing to w the query log buckets found or created with bs.
+func NewLogger(w storage.PointsWriter, bs platform.BucketService, logger *zap.Logger) *Logger {
+	return &Logger{
+		PointsWriter:  w,
+		BucketService: bs,
+		IDGenerator:   snowflake.NewIDGenerator(),
+		logger:        logger,
+	}
+}
+
+// Log redacts and persists the query log.
+func (l *Logger) Log(q query.Log) error {
+	q.Redact()
+
+	if !q.OrganizationID.Valid() {
+		return errors.New("query log requires an organization")
+	}
+
+	slow := l.SlowQueryThreshold > 0 && q.Duration >= l.SlowQueryThreshold
+	if slow {
+		l.logger.Warn("Slow query",
+			zap.Stringer("org_id", q.OrganizationID),
+			zap.String("query", queryText(q.ProxyRequest)),
+			zap.Duration("duration", q.Duration),
+			zap.Duration("threshold", l.SlowQueryThreshold),
+			zap.Int64("response_size", q.ResponseSize),
+		)
+	}
+
+	pt, err := newPoint(l.IDGenerator.ID(), q, slow)
+	if err != nil {
+		return err
+	}
+
+	b, err := platform.FindOrCreateOrgBucket(context.Background(), l.BucketService, q.OrganizationID, BucketName, RetentionPeriod)
+	if err != nil {
+		return err
+	}
+	points, err := tsdb.ExplodePoints(q.OrganizationID, b.ID, []models.Point{pt})
+	if err != nil {
+		return err
+	}
+	return l.PointsWriter.WritePoints(points)
+}
+
+func newPoint(id platform.ID, q query.Log, slow bool) (models.Point, error) {
+	var compilerType flux.CompilerType
+	if q.ProxyRequest != nil && q.ProxyRequest.Request.Compiler != nil {
+		compilerType = q.ProxyRequest.Request.Compiler.CompilerType()
+	}
+	tags := models.NewTags(map[string]string{
+		queryIDTag:      id.String(),
+		compilerTypeTag: string(compilerType),
+		slowTag:         strconv.FormatBool(slow),
+	})
+
+	fields := models.Fields{
+		queryField:           queryText(q.ProxyRequest),
+		responseSizeField:    q.ResponseSize,
+		durationField:        int64(q.Duration),
+		totalDurationField:   int64(q.Statistics.TotalDuration),
+		compileDurationField: int64(q.Statistics.CompileDuration),
+		queueDurationField:   int64(q.Statistics.QueueDuration),
+		planDurationField:    int64(q.Statistics.PlanDuration),
+		requeueDurationField: int64(q.Statistics.RequeueDuration),
+		executeDurationField: int64(q.Statistics.ExecuteDuration),
+		concurrencyField:     int64(q.Statistics.Concurrency),
+		maxAllocatedField:    q.Statistics.MaxAllocated,
+	}
+	if q.Error != nil {
+		fields[errorField] = q.Error.Error()
+	}
+
+	t := q.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return models.NewPoint(measurement, tags, fields, t)
+}
+
+// queryText returns the text of the query, or the JSON encoding of its compiler
+// when the compiler is not a flux compiler.
+func queryText(req *query.ProxyRequest) string {
+	if req == nil || req.Request.Compiler == nil {
+		return ""
+	}
+	switch c := req.Request.Compiler.(type) {
+	case lang.FluxCompiler:
+		return c.Query
+	case *lang.FluxCompiler:
+		return c.Query
+	}
+	octets, err := json.Marshal(req.Request.Compiler)
+	if err != nil {
+		return ""
+	}
+	return string(octets)
+}
@@ -0,0 +1,380 @@
+package querylog_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/platform"
+	platformmock "github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/mock"
+	"github.com/influxdata/platform/query/querylog"
+	"github.com/influxdata/platform/tsdb"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type pointsWriter struct {
+	points []models.Point
+}
+
+func (w *pointsWriter) WritePoints(points []models.Point) error {
+	w.points = append(w.points, points...)
+	return nil
+}
+
+// bucketService returns a BucketService finding the query log bucket of the
+// organization once it exists with bucketID.
+func bucketService(t *testing.T, orgID, bucketID platform.ID, exists bool) *platformmock.BucketService {
+	var created *platform.Bucket
+	if exists {
+		created = &platform.Bucket{ID: bucketID, OrganizationID: orgID, Name: querylog.BucketName}
+	}
+	bs := platformmock.NewBucketService()
+	bs.FindBucketsFn = func(ctx context.Context, filter platform.BucketFilter, opts ...platform.FindOptions) ([]*platform.Bucket, int, error) {
+		if *filter.OrganizationID != orgID || *filter.Name != querylog.BucketName || created == nil {
+			return nil, 0, nil
+		}
+		return []*platform.Bucket{created}, 1, nil
+	}
+	bs.CreateBucketFn = func(ctx context.Context, b *platform.Bucket) error {
+		if created != nil {
+			t.Fatalf("query log bucket created twice")
+		}
+		if b.RetentionPeriod != querylog.RetentionPeriod {
+			t.Errorf("unexpected retention period of the query log bucket: got %v want %v", b.RetentionPeriod, querylog.RetentionPeriod)
+		}
+		b.ID = bucketID
+		created = b
+		return nil
+	}
+	return bs
+}
+
+func TestLogger_Log(t *testing.T) {
+	orgID := platform.ID(1)
+	bucketID := platform.ID(2)
+	now := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		log       query.Log
+		wantSlow  string
+		wantWarn  int
+		wantError string
+	}{
+		{
+			name:      "fast query",
+			threshold: time.Second,
+			log: query.Log{
+				Time:           now,
+				OrganizationID: orgID,
+				Duration:       time.Millisecond,
+				ResponseSize:   42,
+				ProxyRequest: &query.ProxyRequest{
+					Request: query.Request{
+						Authorization:  &platform.Authorization{Token: "secret"},
+						OrganizationID: orgID,
+						Compiler:       lang.FluxCompiler{Query: `from(bucket: "telegraf")`},
+					},
+				},
+			},
+			wantSlow: "false",
+		},
+		{
+			name:      "slow failing query",
+			threshold: time.Second,
+			log: query.Log{
+				Time:           now,
+				OrganizationID: orgID,
+				Duration:       2 * time.Second,
+				Error:          errors.New("oops"),
+				ProxyRequest: &query.ProxyRequest{
+					Request: query.Request{
+						Authorization:  &platform.Authorization{Token: "secret"},
+						OrganizationID: orgID,
+						Compiler:       lang.FluxCompiler{Query: `from(bucket: "telegraf")`},
+					},
+				},
+			},
+			wantSlow:  "true",
+			wantWarn:  1,
+			wantError: "oops",
+		},
+		{
+			name: "disabled threshold",
+			log: query.Log{
+				Time:           now,
+				OrganizationID: orgID,
+				Duration:       time.Hour,
+				ProxyRequest: &query.ProxyRequest{
+					Request: query.Request{
+						OrganizationID: orgID,
+						Compiler:       lang.FluxCompiler{Query: `from(bucket: "telegraf")`},
+					},
+				},
+			},
+			wantSlow: "false",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.WarnLevel)
+			w := &pointsWriter{}
+			l := querylog.NewLogger(w, bucketService(t, orgID, bucketID, false), zap.New(core))
+			l.SlowQueryThreshold = tt.threshold
+
+			if err := l.Log(tt.log); err != nil {
+				t.Fatal(err)
+			}
+			// The second query is logged to the bucket created by the first.
+			if err := l.Log(tt.log); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := logs.Len(); got != 2*tt.wantWarn {
+				t.Errorf("unexpected number of warnings: got %d want %d", got, 2*tt.wantWarn)
+			}
+
+			fields := make(map[string]string)
+			ids := make(map[string]bool)
+			for _, pt := range w.points {
+				name := [16]byte{}
+				copy(name[:], pt.Name())
+				if org, bucket := tsdb.DecodeName(name); org != orgID || bucket != bucketID {
+					t.Fatalf("point written to unexpected bucket %s/%s", org, bucket)
+				}
+				ids[string(pt.Tags().Get([]byte("query_id")))] = true
+				if got := string(pt.Tags().Get([]byte("slow"))); got != tt.wantSlow {
+					t.Errorf("unexpected slow tag: got %q want %q", got, tt.wantSlow)
+				}
+				if !pt.Time().Equal(now) {
+					t.Errorf("unexpected time: got %v want %v", pt.Time(), now)
+				}
+				field := string(pt.Tags().Get(tsdb.FieldKeyTagKeyBytes))
+				fields[field] = pt.String()
+			}
+
+			if len(ids) != 2 {
+				t.Errorf("expected a distinct query ID for each query, got %v", ids)
+			}
+			for _, f := range []string{"query", "response_size", "duration", "total_duration"} {
+				if _, ok := fields[f]; !ok {
+					t.Errorf("missing field %q", f)
+				}
+			}
+			if tt.wantError != "" && !strings.Contains(fields["error"], tt.wantError) {
+				t.Errorf("missing error field: got %q", fields["error"])
+			}
+			for _, pt := range w.points {
+				if strings.Contains(string(pt.Key()), "secret") {
+					t.Errorf("authorization token stored in series key: %s", pt.Key())
+				}
+				fs, err := pt.Fields()
+				if err != nil {
+					t.Fatal(err)
+				}
+				for k, v := range fs {
+					if strings.Contains(k, "secret") || strings.Contains(fmt.Sprint(v), "secret") {
+						t.Errorf("authorization token stored in field %s: %v", k, v)
+					}
+				}
+			}
+			for _, e := range logs.All() {
+				if strings.Contains(fmt.Sprint(e.Message, e.ContextMap()), "secret") {
+					t.Errorf("authorization token logged: %v", e.ContextMap())
+				}
+			}
+			if tt.log.ProxyRequest.Request.Authorization != nil && tt.log.ProxyRequest.Request.Authorization.Token != "secret" {
+				t.Errorf("logging must not modify the authorization of the request")
+			}
+		})
+	}
+}
+
+func TestLogger_Log_RecreatesBucket(t *testing.T) {
+	orgID := platform.ID(1)
+	var (
+		buckets []*platform.Bucket
+		nextID  = platform.ID(2)
+	)
+	bs := platformmock.NewBucketService()
+	bs.FindBucketsFn = func(ctx context.Context, filter platform.BucketFilter, opts ...platform.FindOptions) ([]*platform.Bucket, int, error) {
+		return buckets, len(buckets), nil
+	}
+	bs.CreateBucketFn = func(ctx context.Context, b *platform.Bucket) error {
+		b.ID = nextID
+		nextID++
+		buckets = []*platform.Bucket{b}
+		return nil
+	}
+
+	w := &pointsWriter{}
+	l := querylog.NewLogger(w, bs, zap.NewNop())
+	log := query.Log{OrganizationID: orgID, Time: time.Unix(0, 0)}
+	if err := l.Log(log); err != nil {
+		t.Fatal(err)
+	}
+
+	// Once the bucket is deleted, the next query is logged to a new bucket.
+	deleted := buckets[0].ID
+	buckets = nil
+	if err := l.Log(log); err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != 1 || buckets[0].ID == deleted {
+		t.Fatalf("expected the query log bucket to be created again, got %v", buckets)
+	}
+	name := [16]byte{}
+	copy(name[:], w.points[len(w.points)-1].Name())
+	if _, bucket := tsdb.DecodeName(name); bucket != buckets[0].ID {
+		t.Fatalf("expected the query to be logged to bucket %s, got %s", buckets[0].ID, bucket)
+	}
+}
+
+func TestLogger_Log_RequiresOrganization(t *testing.T) {
+	l := querylog.NewLogger(&pointsWriter{}, platformmock.NewBucketService(), zap.NewNop())
+	if err := l.Log(query.Log{}); err == nil {
+		t.Fatal("expected error logging a query without an organization")
+	}
+}
+
+func TestService_FindLogEntries(t *testing.T) {
+	orgID := platform.ID(1)
+	bucketID := platform.ID(0xb)
+	t1 := execute.Time(time.Date(2018, 10, 1, 0, 0, 1, 0, time.UTC).UnixNano())
+	t2 := execute.Time(time.Date(2018, 10, 1, 0, 0, 2, 0, time.UTC).UnixNano())
+
+	cols := func(typ flux.DataType) []flux.ColMeta {
+		return []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: typ},
+			{Label: "_field", Type: flux.TString},
+			{Label: "query_id", Type: flux.TString},
+			{Label: "compiler_type", Type: flux.TString},
+			{Label: "slow", Type: flux.TString},
+		}
+	}
+	keyCols := []string{"_field", "query_id", "compiler_type", "slow"}
+	// The queries 01 and 02 completed at the same time.
+	tables := []*executetest.Table{
+		{
+			KeyCols: keyCols,
+			ColMeta: cols(flux.TString),
+			Data: [][]interface{}{
+				{t1, "from(bucket: \"a\")", "query", "0000000000000001", "flux", "false"},
+			},
+		},
+		{
+			KeyCols: keyCols,
+			ColMeta: cols(flux.TInt),
+			Data: [][]interface{}{
+				{t1, int64(10), "response_size", "0000000000000001", "flux", "false"},
+			},
+		},
+		{
+			KeyCols: keyCols,
+			ColMeta: cols(flux.TString),
+			Data: [][]interface{}{
+				{t1, "from(bucket: \"c\")", "query", "0000000000000002", "flux", "false"},
+			},
+		},
+		{
+			KeyCols: keyCols,
+			ColMeta: cols(flux.TString),
+			Data: [][]interface{}{
+				{t2, "from(bucket: \"b\")", "query", "0000000000000003", "flux", "true"},
+			},
+		},
+		{
+			KeyCols: keyCols,
+			ColMeta: cols(flux.TInt),
+			Data: [][]interface{}{
+				{t2, int64(time.Minute), "duration", "0000000000000003", "flux", "true"},
+			},
+		},
+	}
+
+	var gotQuery string
+	s := querylog.NewService(&mock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			if req.OrganizationID != orgID {
+				t.Errorf("unexpected organization %s", req.OrganizationID)
+			}
+			gotQuery = req.Compiler.(lang.FluxCompiler).Query
+			return flux.NewSliceResultIterator([]flux.Result{executetest.NewResult(tables)}), nil
+		},
+	}, bucketService(t, orgID, bucketID, true))
+
+	entries, err := s.FindLogEntries(context.Background(), query.LogFilter{
+		OrganizationID: orgID,
+		Since:          time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC),
+		SlowOnly:       true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantQuery := `from(bucketID: "000000000000000b") |> range(start: 2018-10-01T00:00:00Z) |> filter(fn: (r) => r._measurement == "query_log" and r.slow == "true")`
+	if gotQuery != wantQuery {
+		t.Errorf("unexpected flux query:\ngot  %s\nwant %s", gotQuery, wantQuery)
+	}
+
+	want := []*query.LogEntry{
+		{
+			ID:             3,
+			Time:           time.Unix(0, int64(t2)).UTC(),
+			OrganizationID: orgID,
+			CompilerType:   "flux",
+			Query:          `from(bucket: "b")`,
+			Duration:       time.Minute,
+			Slow:           true,
+		},
+		{
+			ID:             2,
+			Time:           time.Unix(0, int64(t1)).UTC(),
+			OrganizationID: orgID,
+			CompilerType:   "flux",
+			Query:          `from(bucket: "c")`,
+		},
+		{
+			ID:             1,
+			Time:           time.Unix(0, int64(t1)).UTC(),
+			OrganizationID: orgID,
+			CompilerType:   "flux",
+			Query:          `from(bucket: "a")`,
+			ResponseSize:   10,
+		},
+	}
+	if !cmp.Equal(entries, want) {
+		t.Errorf("unexpected entries -got/+want\n%s", cmp.Diff(entries, want))
+	}
+}
+
+func TestService_FindLogEntries_NoBucket(t *testing.T) {
+	s := querylog.NewService(&mock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			t.Fatal("unexpected query of an organization without a query log bucket")
+			return nil, nil
+		},
+	}, bucketService(t, 1, 2, false))
+
+	entries, err := s.FindLogEntries(context.Background(), query.LogFilter{OrganizationID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("unexpected entries %v", entries)
+	}
+}
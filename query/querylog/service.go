@@ -0,0 +1,195 @@
+package querylog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/query"
+)
+
+// DefaultLookback is how far back entries are searched when no start time is given.
+const DefaultLookback = time.Hour
+
+var _ query.LogService = (*Service)(nil)
+
+// Service implements query.LogService by querying the query log bucket with Flux.
+type Service struct {
+	QueryService  query.QueryService
+	BucketService platform.BucketService
+
+	Now func() time.Time
+}
+
+// NewService returns a Service executing its queries with qs against the query log buckets found with bs.
+func NewService(qs query.QueryService, bs platform.BucketService) *Service {
+	return &Service{
+		QueryService:  qs,
+		BucketService: bs,
+		Now:           time.Now,
+	}
+}
+
+// FindLogEntries returns the logged queries of an organization, most recent first.
+func (s *Service) FindLogEntries(ctx context.Context, filter query.LogFilter) ([]*query.LogEntry, error) {
+	if !filter.OrganizationID.Valid() {
+		return nil, errors.New("organization is required to find query logs")
+	}
+
+	b, err := findBucket(ctx, s.BucketService, filter.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		// The organization has not run any queries yet.
+		return []*query.LogEntry{}, nil
+	}
+
+	since := filter.Since
+	if since.IsZero() {
+		since = s.Now().Add(-DefaultLookback)
+	}
+
+	req := &query.Request{
+		OrganizationID: filter.OrganizationID,
+		Compiler: lang.FluxCompiler{
+			Query: logQuery(b.ID, since, filter.SlowOnly),
+		},
+	}
+	results, err := s.QueryService.Query(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer results.Cancel()
+
+	entries := make(map[platform.ID]*query.LogEntry)
+	for results.More() {
+		err := results.Next().Tables().Do(func(tbl flux.Table) error {
+			return readTable(tbl, entries)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := results.Err(); err != nil {
+		return nil, err
+	}
+
+	es := make([]*query.LogEntry, 0, len(entries))
+	for _, e := range entries {
+		e.OrganizationID = filter.OrganizationID
+		es = append(es, e)
+	}
+	sort.Slice(es, func(i, j int) bool {
+		if es[i].Time.Equal(es[j].Time) {
+			return es[i].ID > es[j].ID
+		}
+		return es[i].Time.After(es[j].Time)
+	})
+	if filter.Limit > 0 && len(es) > filter.Limit {
+		es = es[:filter.Limit]
+	}
+	return es, nil
+}
+
+func logQuery(bucketID platform.ID, since time.Time, slowOnly bool) string {
+	predicate := fmt.Sprintf(`r._measurement == %q`, measurement)
+	if slowOnly {
+		predicate += fmt.Sprintf(` and r.%s == "true"`, slowTag)
+	}
+	return fmt.Sprintf(`from(bucketID: %q) |> range(start: %s) |> filter(fn: (r) => %s)`,
+		bucketID.String(), since.UTC().Format(time.RFC3339Nano), predicate)
+}
+
+// readTable merges the fields stored in tbl into the entries, which are keyed by query ID.
+func readTable(tbl flux.Table, entries map[platform.ID]*query.LogEntry) error {
+	var id platform.ID
+	var compilerType flux.CompilerType
+	var slow bool
+	var field string
+	key := tbl.Key()
+	for j, c := range key.Cols() {
+		switch c.Label {
+		case queryIDTag:
+			if err := id.DecodeFromString(key.ValueString(j)); err != nil {
+				return fmt.Errorf("invalid query ID in query log: %v", err)
+			}
+		case compilerTypeTag:
+			compilerType = flux.CompilerType(key.ValueString(j))
+		case slowTag:
+			slow = key.ValueString(j) == "true"
+		case "_field":
+			field = key.ValueString(j)
+		}
+	}
+
+	timeIdx := execute.ColIdx(execute.DefaultTimeColLabel, tbl.Cols())
+	valueIdx := execute.ColIdx(execute.DefaultValueColLabel, tbl.Cols())
+	if timeIdx < 0 || valueIdx < 0 {
+		return fmt.Errorf("query log table is missing columns: %v", tbl.Cols())
+	}
+
+	return tbl.Do(func(cr flux.ColReader) error {
+		times := cr.Times(timeIdx)
+		for i := 0; i < cr.Len(); i++ {
+			e, ok := entries[id]
+			if !ok {
+				e = &query.LogEntry{
+					ID:           id,
+					Time:         times[i].Time(),
+					CompilerType: compilerType,
+					Slow:         slow,
+				}
+				entries[id] = e
+			}
+
+			switch cr.Cols()[valueIdx].Type {
+			case flux.TString:
+				setStringField(e, field, cr.Strings(valueIdx)[i])
+			case flux.TInt:
+				setIntField(e, field, cr.Ints(valueIdx)[i])
+			}
+		}
+		return nil
+	})
+}
+
+func setStringField(e *query.LogEntry, field, v string) {
+	switch field {
+	case queryField:
+		e.Query = v
+	case errorField:
+		e.Error = v
+	}
+}
+
+func setIntField(e *query.LogEntry, field string, v int64) {
+	switch field {
+	case responseSizeField:
+		e.ResponseSize = v
+	case durationField:
+		e.Duration = time.Duration(v)
+	case totalDurationField:
+		e.Statistics.TotalDuration = time.Duration(v)
+	case compileDurationField:
+		e.Statistics.CompileDuration = time.Duration(v)
+	case queueDurationField:
+		e.Statistics.QueueDuration = time.Duration(v)
+	case planDurationField:
+		e.Statistics.PlanDuration = time.Duration(v)
+	case requeueDurationField:
+		e.Statistics.RequeueDuration = time.Duration(v)
+	case executeDurationField:
+		e.Statistics.ExecuteDuration = time.Duration(v)
+	case concurrencyField:
+		e.Statistics.Concurrency = int(v)
+	case maxAllocatedField:
+		e.Statistics.MaxAllocated = v
+	}
+}
@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb"
+	"github.com/influxdata/platform/tsdb/tsm1"
+)
+
+var _ platform.BucketStatsService = (*Engine)(nil)
+
+// FindBucketStats returns the stats of the data stored in the engine for a bucket.
+// A bucket without data has empty stats.
+//
+// The engine is only locked while the index is read. The TSM files are walked
+// after the lock is released, so that writes and compactions are not blocked
+// for the duration of the walk.
+func (e *Engine) FindBucketStats(ctx context.Context, id platform.ID) (*platform.BucketStats, error) {
+	stats := &platform.BucketStats{
+		BucketID:     id,
+		Measurements: []platform.MeasurementStats{},
+	}
+
+	name, measurements, engine, err := e.bucketSeriesN(stats)
+	if err != nil {
+		return nil, err
+	} else if name == nil {
+		return stats, nil
+	}
+
+	diskStats, err := engine.MeasurementStats()
+	if err != nil {
+		return nil, err
+	}
+	stats.DiskBytes = int64(diskStats[string(name)])
+
+	// The measurement of a series is held in a tag of its key.
+	keyStats := make(map[string]*tsm1.KeyStats, len(measurements))
+	prefix := append(models.EscapeMeasurement(name), ',')
+	err = engine.WalkKeyStats(prefix, func(key []byte, s tsm1.KeyStats) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		seriesKey, _ := tsm1.SeriesAndFieldFromCompositeKey(key)
+		_, tags := models.ParseKeyBytes(seriesKey)
+		m := string(tags.Get(tsdb.MeasurementTagKeyBytes))
+
+		ms, ok := keyStats[m]
+		if !ok {
+			ms = &tsm1.KeyStats{MinTime: s.MinTime, MaxTime: s.MaxTime}
+			keyStats[m] = ms
+		}
+		if s.MinTime < ms.MinTime {
+			ms.MinTime = s.MinTime
+		}
+		if s.MaxTime > ms.MaxTime {
+			ms.MaxTime = s.MaxTime
+		}
+		ms.DiskBytes += s.DiskBytes
+		ms.CacheBytes += s.CacheBytes
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for m := range keyStats {
+		if _, ok := measurements[m]; !ok {
+			measurements[m] = 0 // Data remains for a measurement without series.
+		}
+	}
+
+	for m, seriesN := range measurements {
+		ms := platform.MeasurementStats{Name: m, SeriesN: seriesN}
+		if s, ok := keyStats[m]; ok {
+			ms.DiskBytes, ms.CacheBytes = s.DiskBytes, s.CacheBytes
+			ms.Earliest, ms.Latest = unixTime(s.MinTime), unixTime(s.MaxTime)
+
+			stats.CacheBytes += s.CacheBytes
+			if stats.Earliest == nil || ms.Earliest.Before(*stats.Earliest) {
+				stats.Earliest = ms.Earliest
+			}
+			if stats.Latest == nil || ms.Latest.After(*stats.Latest) {
+				stats.Latest = ms.Latest
+			}
+		}
+		stats.Measurements = append(stats.Measurements, ms)
+	}
+	sort.Slice(stats.Measurements, func(i, j int) bool {
+		return stats.Measurements[i].Name < stats.Measurements[j].Name
+	})
+	return stats, nil
+}
+
+// bucketSeriesN sets the number of series of the bucket of stats and returns the name of
+// the series of the bucket, the number of series of each of its measurements and the
+// TSM engine holding its data. The name is nil if the bucket has no series.
+func (e *Engine) bucketSeriesN(stats *platform.BucketStats) ([]byte, map[string]int, *tsm1.Engine, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return nil, nil, nil, ErrEngineClosed
+	}
+
+	// The series of a bucket are named after the organization and the bucket,
+	// so find the name of the bucket amongst the names held by the index.
+	var name []byte
+	for n, seriesN := range e.index.MeasurementCardinalityStats() {
+		if bucketIDFromName([]byte(n)) == stats.BucketID {
+			name = []byte(n)
+			stats.SeriesN = seriesN
+			break
+		}
+	}
+	if name == nil {
+		return nil, nil, nil, nil
+	}
+
+	measurements, err := e.measurementSeriesN(name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return name, measurements, e.engine, nil
+}
+
+// measurementSeriesN returns the number of series of each measurement stored under name.
+func (e *Engine) measurementSeriesN(name []byte) (map[string]int, error) {
+	itr, err := e.index.TagValueIterator(name, tsdb.MeasurementTagKeyBytes)
+	if err != nil {
+		return nil, err
+	} else if itr == nil {
+		return map[string]int{}, nil
+	}
+	defer itr.Close()
+
+	seriesN := make(map[string]int)
+	for {
+		m, err := itr.Next()
+		if err != nil {
+			return nil, err
+		} else if m == nil {
+			return seriesN, nil
+		}
+
+		n, err := e.countSeries(name, m)
+		if err != nil {
+			return nil, err
+		}
+		seriesN[string(m)] = n
+	}
+}
+
+func (e *Engine) countSeries(name, measurement []byte) (int, error) {
+	itr, err := e.index.TagValueSeriesIDIterator(name, tsdb.MeasurementTagKeyBytes, measurement)
+	if err != nil {
+		return 0, err
+	} else if itr == nil {
+		return 0, nil
+	}
+	defer itr.Close()
+
+	var n int
+	for {
+		elem, err := itr.Next()
+		if err != nil {
+			return 0, err
+		} else if elem.SeriesID.IsZero() {
+			return n, nil
+		}
+		n++
+	}
+}
+
+func unixTime(ns int64) *time.Time {
+	t := time.Unix(0, ns).UTC()
+	return &t
+}
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/influxdata/influxql"
+	"github.com/influxdata/platform/kit/check"
 	"github.com/influxdata/platform/models"
 	"github.com/influxdata/platform/tsdb"
 	"github.com/influxdata/platform/tsdb/tsi1"
@@ -33,10 +35,12 @@ type Engine struct {
 
 	mu                sync.RWMutex
 	closing           chan struct{} //closing returns the zero value when the engine is shutting down.
+	state             int32         // state is the engineState of the engine, read without holding mu.
 	index             *tsi1.Index
 	sfile             *tsdb.SeriesFile
 	engine            *tsm1.Engine
 	retentionEnforcer *retentionEnforcer
+	seriesLimiter     *seriesLimiter
 
 	// Tracks all goroutines started by the Engine.
 	wg sync.WaitGroup
@@ -44,6 +48,15 @@ type Engine struct {
 	logger *zap.Logger
 }
 
+// engineState is the state of an Engine as reported by its checks.
+type engineState = int32
+
+const (
+	engineClosed  engineState = iota // The engine is closed.
+	engineOpening                    // The engine is loading its files.
+	engineOpen                       // The engine is open.
+)
+
 // Option provides a set
 type Option func(*Engine)
 
@@ -91,6 +104,14 @@ var WithRetentionEnforcer = func(finder BucketFinder) Option {
 	}
 }
 
+// WithSeriesLimits rejects writes that would create more series in a bucket
+// than the MaxSeries of the bucket.
+var WithSeriesLimits = func(finder BucketFinder) Option {
+	return func(e *Engine) {
+		e.seriesLimiter = newSeriesLimiter(finder)
+	}
+}
+
 // NewEngine initialises a new storage engine, including a series file, index and
 // TSM engine.
 func NewEngine(path string, c Config, options ...Option) *Engine {
@@ -138,6 +159,7 @@ func (e *Engine) WithLogger(log *zap.Logger) {
 	e.index.WithLogger(e.logger)
 	e.engine.WithLogger(e.logger)
 	e.retentionEnforcer.WithLogger(e.logger)
+	e.seriesLimiter.WithLogger(e.logger)
 }
 
 // PrometheusCollectors returns all the prometheus collectors associated with
@@ -148,6 +170,10 @@ func (e *Engine) PrometheusCollectors() []prometheus.Collector {
 	// TODO(edd): Get prom metrics for index.
 	// TODO(edd): Get prom metrics for series file.
 	metrics = append(metrics, e.retentionEnforcer.PrometheusCollectors()...)
+	metrics = append(metrics, newBucketSeriesCollector(e))
+	if e.seriesLimiter != nil {
+		metrics = append(metrics, e.seriesLimiter.metrics.PrometheusCollectors()...)
+	}
 	return metrics
 }
 
@@ -161,6 +187,24 @@ func (e *Engine) Open() error {
 		return nil // Already open
 	}
 
+	atomic.StoreInt32(&e.state, engineOpening)
+	if err := e.open(); err != nil {
+		atomic.StoreInt32(&e.state, engineClosed)
+		return err
+	}
+
+	e.closing = make(chan struct{})
+	atomic.StoreInt32(&e.state, engineOpen)
+	// TODO(edd) background tasks will be run in priority order via a scheduler.
+	// For now we will just run on an interval as we only have the retention
+	// policy enforcer.
+	e.runRetentionEnforcer()
+
+	return nil
+}
+
+// open opens the series file, index and TSM engine, replaying the WAL.
+func (e *Engine) open() error {
 	if err := e.sfile.Open(); err != nil {
 		return err
 	}
@@ -173,14 +217,33 @@ func (e *Engine) Open() error {
 		return err
 	}
 	e.engine.SetCompactionsEnabled(true) // TODO(edd):is this needed?
+	return nil
+}
 
-	e.closing = make(chan struct{})
-	// TODO(edd) background tasks will be run in priority order via a scheduler.
-	// For now we will just run on an interval as we only have the retention
-	// policy enforcer.
-	e.runRetentionEnforcer()
+// Check reports whether the engine is open or still loading its files.
+// A closed engine is unhealthy.
+func (e *Engine) Check(ctx context.Context) check.Response {
+	switch atomic.LoadInt32(&e.state) {
+	case engineOpen:
+		return check.Pass()
+	case engineOpening:
+		return check.Info("loading TSM files and replaying WAL")
+	default:
+		return check.Error(ErrEngineClosed)
+	}
+}
 
-	return nil
+// CheckReady reports whether the engine has finished loading its files and can
+// serve reads and writes.
+func (e *Engine) CheckReady(ctx context.Context) check.Response {
+	switch atomic.LoadInt32(&e.state) {
+	case engineOpen:
+		return check.Pass()
+	case engineOpening:
+		return check.Error(errors.New("engine is loading TSM files and replaying WAL"))
+	default:
+		return check.Error(ErrEngineClosed)
+	}
 }
 
 // runRetentionEnforcer runs the retention enforcer in a separate goroutine.
@@ -221,11 +284,23 @@ func (e *Engine) runRetentionEnforcer() {
 	}()
 }
 
+// WriteSnapshot writes the data held in the cache to TSM files, so that the WAL
+// does not have to be replayed when the engine is next opened.
+func (e *Engine) WriteSnapshot() error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return ErrEngineClosed
+	}
+	return e.engine.WriteSnapshot()
+}
+
 // Close closes the store and all underlying resources. It returns an error if
 // any of the underlying systems fail to close.
 func (e *Engine) Close() error {
 	e.mu.RLock()
 	if e.closing == nil {
+		e.mu.RUnlock()
 		return nil // Already closed
 	}
 
@@ -238,6 +313,7 @@ func (e *Engine) Close() error {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 	e.closing = nil
+	atomic.StoreInt32(&e.state, engineClosed)
 
 	if err := e.sfile.Close(); err != nil {
 		return err
@@ -322,8 +398,16 @@ func (e *Engine) WritePoints(points []models.Point) error {
 		return ErrEngineClosed
 	}
 
+	// Drop the points that would exceed the series limit of their bucket.
+	release := func(error) {}
+	if e.seriesLimiter != nil {
+		release = e.seriesLimiter.enforce(collection, e.sfile, e.index)
+	}
+
 	// Add new series to the index and series file. Check for partial writes.
-	if err := e.index.CreateSeriesListIfNotExists(collection); err != nil {
+	err := e.index.CreateSeriesListIfNotExists(collection)
+	release(err)
+	if err != nil {
 		// ignore PartialWriteErrors. The collection captures it.
 		// TODO(edd/jeff): should we just remove PartialWriteError from the index then?
 		if _, ok := err.(tsdb.PartialWriteError); !ok {
@@ -346,6 +430,10 @@ func (e *Engine) DeleteSeriesRangeWithPredicate(itr tsdb.SeriesIterator, fn func
 	if e.closing == nil {
 		return ErrEngineClosed
 	}
+	if e.seriesLimiter != nil {
+		// The series limits count the remaining series of the buckets again.
+		defer e.seriesLimiter.forget()
+	}
 	return e.engine.DeleteSeriesRangeWithPredicate(itr, fn)
 }
 
@@ -377,6 +465,11 @@ func (e *Engine) ApplyFnToSeriesIDSet(fn func(*tsdb.SeriesIDSet)) {
 
 // MeasurementCardinalityStats returns cardinality stats for all measurements.
 func (e *Engine) MeasurementCardinalityStats() tsi1.MeasurementCardinalityStats {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return nil
+	}
 	return e.index.MeasurementCardinalityStats()
 }
 
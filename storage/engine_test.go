@@ -1,12 +1,16 @@
 package storage_test
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/check"
+	"github.com/influxdata/platform/mock"
 	"github.com/influxdata/platform/models"
 	"github.com/influxdata/platform/storage"
 	"github.com/influxdata/platform/tsdb"
@@ -186,14 +190,159 @@ type Engine struct {
 	*storage.Engine
 }
 
+func TestEngine_SeriesLimits(t *testing.T) {
+	bucketSvc := mock.NewBucketService()
+	bucketSvc.FindBucketsFn = func(context.Context, platform.BucketFilter, ...platform.FindOptions) ([]*platform.Bucket, int, error) {
+		bucket, _ := platform.IDFromString("3232323232323232")
+		return []*platform.Bucket{{ID: *bucket, MaxSeries: 2}}, 1, nil
+	}
+
+	engine := NewEngine(storage.NewConfig(), storage.WithSeriesLimits(bucketSvc))
+	defer engine.Close()
+	engine.MustOpen()
+
+	point := func(host string) models.Point {
+		return models.MustNewPoint(
+			"cpu",
+			models.Tags{{Key: []byte("host"), Value: []byte(host)}},
+			map[string]interface{}{"value": 1.0},
+			time.Unix(1, 2),
+		)
+	}
+
+	if err := engine.Write1xPoints([]models.Point{point("a"), point("b")}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Writing to the existing series is allowed while new series are dropped.
+	err := engine.Write1xPoints([]models.Point{point("a"), point("c"), point("d")})
+	if err == nil || !strings.Contains(err.Error(), "max series per bucket exceeded") {
+		t.Fatalf("expected series limit error, got %v", err)
+	}
+	if pwe, ok := err.(tsdb.PartialWriteError); !ok || pwe.Dropped != 2 {
+		t.Fatalf("expected partial write of 2 dropped points, got %v", err)
+	}
+
+	if got, exp := engine.SeriesCardinality(), int64(2); got != exp {
+		t.Fatalf("got %v series, exp %v series in index", got, exp)
+	}
+}
+
+func TestEngine_FindBucketStats(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	point := func(name, host string, ts time.Time) models.Point {
+		return models.MustNewPoint(
+			name,
+			models.Tags{{Key: []byte("host"), Value: []byte(host)}},
+			map[string]interface{}{"value": 1.0},
+			ts,
+		)
+	}
+
+	if err := engine.Write1xPoints([]models.Point{
+		point("cpu", "a", time.Unix(1, 0)),
+		point("cpu", "b", time.Unix(3, 0)),
+		point("mem", "a", time.Unix(2, 0)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket, _ := platform.IDFromString("3232323232323232")
+	stats, err := engine.FindBucketStats(context.Background(), *bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, exp := stats.SeriesN, 3; got != exp {
+		t.Fatalf("got %v series, exp %v series", got, exp)
+	}
+	if stats.CacheBytes == 0 {
+		t.Fatal("expected cached data")
+	}
+	if stats.Earliest == nil || !stats.Earliest.Equal(time.Unix(1, 0)) || stats.Latest == nil || !stats.Latest.Equal(time.Unix(3, 0)) {
+		t.Fatalf("unexpected time range %v - %v", stats.Earliest, stats.Latest)
+	}
+	if got, exp := len(stats.Measurements), 2; got != exp {
+		t.Fatalf("got %v measurements, exp %v measurements", got, exp)
+	}
+	if m := stats.Measurements[0]; m.Name != "cpu" || m.SeriesN != 2 || !m.Latest.Equal(time.Unix(3, 0)) {
+		t.Fatalf("unexpected cpu stats %+v", m)
+	}
+	if m := stats.Measurements[1]; m.Name != "mem" || m.SeriesN != 1 || !m.Earliest.Equal(time.Unix(2, 0)) {
+		t.Fatalf("unexpected mem stats %+v", m)
+	}
+
+	// Another bucket holds no data.
+	other, _ := platform.IDFromString("3333333333333333")
+	if stats, err := engine.FindBucketStats(context.Background(), *other); err != nil {
+		t.Fatal(err)
+	} else if stats.SeriesN != 0 || len(stats.Measurements) != 0 || stats.Earliest != nil {
+		t.Fatalf("unexpected stats of empty bucket %+v", stats)
+	}
+}
+
+func TestEngine_Check(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+
+	ctx := context.Background()
+	if res := engine.CheckReady(ctx); res.Status != check.StatusFail {
+		t.Fatalf("expected closed engine not to be ready, got %+v", res)
+	}
+
+	engine.MustOpen()
+	if res := engine.Check(ctx); res.Status != check.StatusPass {
+		t.Fatalf("expected open engine to be healthy, got %+v", res)
+	}
+	if res := engine.CheckReady(ctx); res.Status != check.StatusPass {
+		t.Fatalf("expected open engine to be ready, got %+v", res)
+	}
+}
+
+func TestEngine_WriteSnapshot(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	pt := models.MustNewPoint(
+		"cpu",
+		models.NewTags(map[string]string{"host": "server"}),
+		map[string]interface{}{"value": 1.0},
+		time.Unix(1, 2),
+	)
+	if err := engine.Write1xPoints([]models.Point{pt}); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket, _ := platform.IDFromString("3232323232323232")
+	if err := engine.WriteSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	stats, err := engine.FindBucketStats(context.Background(), *bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.CacheBytes != 0 || stats.DiskBytes == 0 {
+		t.Fatalf("expected the cache to be written to disk, got %d cache bytes and %d disk bytes", stats.CacheBytes, stats.DiskBytes)
+	}
+
+	engine.Engine.Close() // Don't destroy temporary data.
+	if err := engine.WriteSnapshot(); err != storage.ErrEngineClosed {
+		t.Fatalf("WriteSnapshot() = %v, want %v", err, storage.ErrEngineClosed)
+	}
+}
+
 // NewEngine create a new wrapper around a storage engine.
-func NewEngine(c storage.Config) *Engine {
+func NewEngine(c storage.Config, options ...storage.Option) *Engine {
 	path, _ := ioutil.TempDir("", "storage_engine_test")
 
 	// TODO(edd) clean this up...
 	c.EngineOptions.Config = c.Config
 
-	engine := storage.NewEngine(path, c)
+	engine := storage.NewEngine(path, c, options...)
 	return &Engine{
 		path:   path,
 		Engine: engine,
@@ -1,6 +1,12 @@
 package storage
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	"sync/atomic"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/tsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
 
 // namespace is the leading part of all published metrics for the Storage service.
 const namespace = "storage"
@@ -63,3 +69,84 @@ func (rm *retentionMetrics) PrometheusCollectors() []prometheus.Collector {
 		rm.Series,
 	}
 }
+
+const seriesLimitSubsystem = "bucket" // sub-system associated with metrics for bucket series limits.
+
+// seriesLimitMetrics is a set of metrics concerned with the series limits of buckets.
+type seriesLimitMetrics struct {
+	limit   *prometheus.GaugeVec
+	dropped *prometheus.CounterVec
+}
+
+func newSeriesLimitMetrics() *seriesLimitMetrics {
+	return &seriesLimitMetrics{
+		limit: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: seriesLimitSubsystem,
+			Name:      "max_series",
+			Help:      "Number of series the bucket may hold.",
+		}, []string{"bucket"}),
+
+		dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: seriesLimitSubsystem,
+			Name:      "series_limit_dropped_points_total",
+			Help:      "Number of points dropped because they would exceed the series limit of the bucket.",
+		}, []string{"bucket"}),
+	}
+}
+
+// setLimits sets the limit gauge of each limited bucket, forgetting the buckets
+// that are no longer limited.
+func (m *seriesLimitMetrics) setLimits(limits map[platform.ID]int) {
+	m.limit.Reset()
+	for id, limit := range limits {
+		m.limit.WithLabelValues(id.String()).Set(float64(limit))
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (m *seriesLimitMetrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.limit,
+		m.dropped,
+	}
+}
+
+// bucketSeriesCollector reports the number of series of each bucket held by an engine.
+type bucketSeriesCollector struct {
+	engine *Engine
+	desc   *prometheus.Desc
+}
+
+func newBucketSeriesCollector(e *Engine) *bucketSeriesCollector {
+	return &bucketSeriesCollector{
+		engine: e,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, seriesLimitSubsystem, "series"),
+			"Number of series in the bucket.",
+			[]string{"org", "bucket"}, nil,
+		),
+	}
+}
+
+// Describe satisfies the prometheus.Collector interface.
+func (c *bucketSeriesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect satisfies the prometheus.Collector interface.
+func (c *bucketSeriesCollector) Collect(ch chan<- prometheus.Metric) {
+	if atomic.LoadInt32(&c.engine.state) != engineOpen {
+		return // Do not wait for the engine to load its files.
+	}
+	for name, n := range c.engine.MeasurementCardinalityStats() {
+		if len(name) != platform.IDLength {
+			continue
+		}
+		var nameBytes [16]byte
+		copy(nameBytes[:], name)
+		orgID, bucketID := tsdb.DecodeName(nameBytes)
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(n), orgID.String(), bucketID.String())
+	}
+}
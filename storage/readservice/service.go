@@ -1,36 +1,61 @@
 package readservice
 
 import (
-	"context"
-
-	"github.com/influxdata/flux"
-	"github.com/influxdata/flux/control"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/query"
+	pcontrol "github.com/influxdata/platform/query/control"
 	"github.com/influxdata/platform/query/functions/inputs"
 	fstorage "github.com/influxdata/platform/query/functions/inputs/storage"
 	"github.com/influxdata/platform/storage"
 	"github.com/influxdata/platform/storage/reads"
-	"go.uber.org/zap"
 )
 
-func NewProxyQueryService(engine *storage.Engine, bucketSvc platform.BucketService, orgSvc platform.OrganizationService, logger *zap.Logger) (query.ProxyQueryService, error) {
-	var ( // flux
-		concurrencyQuota = 10
-		memoryBytesQuota = 1e6
-	)
-
-	cc := control.Config{
-		ExecutorDependencies: make(execute.Dependencies),
-		ConcurrencyQuota:     concurrencyQuota,
-		MemoryBytesQuota:     int64(memoryBytesQuota),
-		Logger:               logger,
-		Verbose:              false,
+// NewProxyQueryService returns a ProxyQueryService executing queries against the storage engine.
+func NewProxyQueryService(config pcontrol.Config, engine *storage.Engine, bucketSvc platform.BucketService, orgSvc platform.OrganizationService) (query.ProxyQueryService, error) {
+	service, err := NewQueryService(config, engine, bucketSvc, orgSvc)
+	if err != nil {
+		return nil, err
+	}
+	return query.ProxyQueryServiceBridge{
+		QueryService: service,
+	}, nil
+}
+
+// NewQueryService returns a QueryService executing queries against the storage engine.
+func NewQueryService(config pcontrol.Config, engine *storage.Engine, bucketSvc platform.BucketService, orgSvc platform.OrganizationService) (query.QueryService, error) {
+	ctrl, err := NewController(config, engine, bucketSvc, orgSvc)
+	if err != nil {
+		return nil, err
+	}
+	return query.QueryServiceBridge{
+		AsyncQueryService: ctrl,
+	}, nil
+}
+
+// Defaults of the controller executing queries against the storage engine.
+const (
+	DefaultConcurrencyQuota = 10
+	DefaultMemoryBytesQuota = 1e6
+)
+
+// NewController returns a query controller executing queries against the storage engine.
+// The controller also reports and cancels the queries in flight.
+// The executor dependencies of config are populated with the storage engine,
+// and its quotas default to DefaultConcurrencyQuota and DefaultMemoryBytesQuota.
+func NewController(config pcontrol.Config, engine *storage.Engine, bucketSvc platform.BucketService, orgSvc platform.OrganizationService) (*pcontrol.Controller, error) {
+	if config.ExecutorDependencies == nil {
+		config.ExecutorDependencies = make(execute.Dependencies)
+	}
+	if config.ConcurrencyQuota == 0 {
+		config.ConcurrencyQuota = DefaultConcurrencyQuota
+	}
+	if config.MemoryBytesQuota == 0 {
+		config.MemoryBytesQuota = DefaultMemoryBytesQuota
 	}
 
 	lookupSvc := query.FromBucketService(bucketSvc)
-	err := inputs.InjectFromDependencies(cc.ExecutorDependencies, fstorage.Dependencies{
+	err := inputs.InjectFromDependencies(config.ExecutorDependencies, fstorage.Dependencies{
 		Reader:             reads.NewReader(newStore(engine)),
 		BucketLookup:       lookupSvc,
 		OrganizationLookup: query.FromOrganizationService(orgSvc),
@@ -39,23 +64,9 @@ func NewProxyQueryService(engine *storage.Engine, bucketSvc platform.BucketServi
 		return nil, err
 	}
 
-	err = inputs.InjectBucketDependencies(cc.ExecutorDependencies, lookupSvc)
-
-	return query.ProxyQueryServiceBridge{
-		QueryService: query.QueryServiceBridge{
-			AsyncQueryService: &queryAdapter{
-				Controller: control.New(cc),
-			},
-		},
-	}, nil
-}
-
-type queryAdapter struct {
-	Controller *control.Controller
-}
+	if err := inputs.InjectBucketDependencies(config.ExecutorDependencies, lookupSvc); err != nil {
+		return nil, err
+	}
 
-func (q *queryAdapter) Query(ctx context.Context, req *query.Request) (flux.Query, error) {
-	ctx = query.ContextWithRequest(ctx, req)
-	ctx = context.WithValue(ctx, "org", req.OrganizationID.String())
-	return q.Controller.Query(ctx, req.Compiler)
+	return pcontrol.New(config), nil
 }
@@ -0,0 +1,234 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/tsdb"
+	"github.com/influxdata/platform/tsdb/tsi1"
+	"go.uber.org/zap"
+)
+
+// seriesLimitsRefreshInterval is how long the series limits of the buckets are
+// cached before they are looked up again.
+const seriesLimitsRefreshInterval = 10 * time.Second
+
+// The seriesLimiter drops the points that would create more series in a bucket
+// than the bucket allows.
+type seriesLimiter struct {
+	// BucketService provides an API for retrieving the limits of the buckets.
+	BucketService BucketFinder
+
+	logger *zap.Logger
+
+	// mu serialises checking and creating new series in limited buckets so
+	// that concurrent writes cannot exceed a limit together.
+	mu     sync.Mutex
+	counts map[platform.ID]int // Series by limited bucket, counted once and then kept up to date.
+
+	// seriesN counts the series of a bucket in the index.
+	seriesN func(index *tsi1.Index, name []byte) (int, error)
+
+	limitsMu  sync.RWMutex
+	limits    map[platform.ID]int // Series limit by bucket ID.
+	refreshed time.Time
+
+	metrics *seriesLimitMetrics
+}
+
+func newSeriesLimiter(bucketService BucketFinder) *seriesLimiter {
+	return &seriesLimiter{
+		BucketService: bucketService,
+		logger:        zap.NewNop(),
+		seriesN:       seriesN,
+		metrics:       newSeriesLimitMetrics(),
+	}
+}
+
+// WithLogger sets the logger l on the limiter.
+func (l *seriesLimiter) WithLogger(log *zap.Logger) {
+	if l == nil {
+		return // Not initialised
+	}
+	l.logger = log.With(zap.String("component", "series_limiter"))
+}
+
+// seriesLimits returns the series limit of each limited bucket, looking them up
+// again once they are older than seriesLimitsRefreshInterval. The previous
+// limits are kept if they cannot be looked up.
+func (l *seriesLimiter) seriesLimits() map[platform.ID]int {
+	l.limitsMu.RLock()
+	limits, refreshed := l.limits, l.refreshed
+	l.limitsMu.RUnlock()
+	if time.Since(refreshed) < seriesLimitsRefreshInterval {
+		return limits
+	}
+
+	l.limitsMu.Lock()
+	defer l.limitsMu.Unlock()
+	if time.Since(l.refreshed) < seriesLimitsRefreshInterval {
+		return l.limits // Refreshed concurrently.
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), bucketAPITimeout)
+	defer cancel()
+	buckets, _, err := l.BucketService.FindBuckets(ctx, platform.BucketFilter{})
+	if err != nil {
+		l.logger.Error("Unable to look up bucket series limits", zap.Error(err))
+		// Retry on the next interval rather than on every write.
+		l.refreshed = time.Now()
+		return l.limits
+	}
+
+	limits = make(map[platform.ID]int)
+	for _, b := range buckets {
+		if b.MaxSeries > 0 {
+			limits[b.ID] = b.MaxSeries
+		}
+	}
+	l.limits, l.refreshed = limits, time.Now()
+	l.metrics.setLimits(limits)
+	return limits
+}
+
+// enforce drops the points of collection that would create a series in a bucket
+// that already holds as many series as it allows, or whose series cannot be
+// counted. When points are written to a
+// limited bucket, enforce holds the limiter until release is called, which must
+// be once the new series have been created, with the error creating them.
+func (l *seriesLimiter) enforce(collection *tsdb.SeriesCollection, sfile *tsdb.SeriesFile, index *tsi1.Index) (release func(error)) {
+	limits := l.seriesLimits()
+	if len(limits) == 0 {
+		return func(error) {}
+	}
+
+	limited := false
+	for iter := collection.Iterator(); iter.Next(); {
+		if _, ok := limits[bucketIDFromName(iter.Name())]; ok {
+			limited = true
+			break
+		}
+	}
+	if !limited {
+		return func(error) {}
+	}
+
+	l.mu.Lock()
+	if l.counts == nil {
+		l.counts = make(map[platform.ID]int)
+	}
+
+	var (
+		newSeries = make(map[string]struct{})   // New series keys of the collection.
+		newN      = make(map[platform.ID]int)   // Number of new series by bucket.
+		countErrs = make(map[platform.ID]error) // Errors counting the series of buckets.
+		buf       []byte
+	)
+
+	j := 0
+	for iter := collection.Iterator(); iter.Next(); {
+		bucketID := bucketIDFromName(iter.Name())
+		limit, ok := limits[bucketID]
+		if !ok {
+			collection.Copy(j, iter.Index())
+			j++
+			continue
+		}
+
+		if _, ok := newSeries[string(iter.Key())]; ok || sfile.HasSeries(iter.Name(), iter.Tags(), buf) {
+			collection.Copy(j, iter.Index())
+			j++
+			continue
+		}
+
+		n, ok := l.counts[bucketID]
+		err := countErrs[bucketID]
+		if !ok && err == nil {
+			if n, err = l.seriesN(index, iter.Name()); err != nil {
+				l.logger.Error("Unable to count the series of a bucket", zap.String("bucket_id", bucketID.String()), zap.Error(err))
+				countErrs[bucketID] = err
+			} else {
+				l.counts[bucketID] = n
+			}
+		}
+		// New series are dropped unless the bucket is known to be below its limit.
+		if err != nil || n+newN[bucketID] >= limit {
+			if collection.Reason == "" {
+				if err != nil {
+					collection.Reason = fmt.Sprintf("unable to count the series of bucket %s: %v", bucketID, err)
+				} else {
+					collection.Reason = fmt.Sprintf("max series per bucket exceeded: bucket %s has reached its limit of %d series", bucketID, limit)
+				}
+			}
+			collection.Dropped++
+			collection.DroppedKeys = append(collection.DroppedKeys, iter.Key())
+			l.metrics.dropped.WithLabelValues(bucketID.String()).Inc()
+			continue
+		}
+
+		newSeries[string(iter.Key())] = struct{}{}
+		newN[bucketID]++
+		collection.Copy(j, iter.Index())
+		j++
+	}
+	collection.Truncate(j)
+
+	return func(err error) {
+		defer l.mu.Unlock()
+		for bucketID, n := range newN {
+			if _, ok := l.counts[bucketID]; !ok {
+				continue
+			}
+			if err != nil {
+				// Some of the new series may not have been created, so they are counted again.
+				delete(l.counts, bucketID)
+				continue
+			}
+			l.counts[bucketID] += n
+		}
+	}
+}
+
+// forget drops the series counts of the buckets, which are counted again when
+// series are next created in them. Series must be forgotten once they are deleted.
+func (l *seriesLimiter) forget() {
+	l.mu.Lock()
+	l.counts = nil
+	l.mu.Unlock()
+}
+
+// seriesN returns the number of series of a measurement of the index.
+func seriesN(index *tsi1.Index, name []byte) (int, error) {
+	itr, err := index.MeasurementSeriesIDIterator(name)
+	if err != nil {
+		return 0, err
+	} else if itr == nil {
+		return 0, nil
+	}
+	defer itr.Close()
+
+	var n int
+	for {
+		e, err := itr.Next()
+		if err != nil {
+			return 0, err
+		} else if e.SeriesID.IsZero() {
+			return n, nil
+		}
+		n++
+	}
+}
+
+// bucketIDFromName returns the ID of the bucket encoded in a series name.
+func bucketIDFromName(name []byte) platform.ID {
+	if len(name) != platform.IDLength {
+		return 0
+	}
+	var n [16]byte
+	copy(n[:], name)
+	_, bucketID := tsdb.DecodeName(n)
+	return bucketID
+}
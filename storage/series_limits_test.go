@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb"
+	"github.com/influxdata/platform/tsdb/tsi1"
+)
+
+func TestSeriesLimiter_CountError(t *testing.T) {
+	orgID, bucketID := platform.ID(1), platform.ID(2)
+	bucketSvc := mock.NewBucketService()
+	bucketSvc.FindBucketsFn = func(context.Context, platform.BucketFilter, ...platform.FindOptions) ([]*platform.Bucket, int, error) {
+		return []*platform.Bucket{{ID: bucketID, MaxSeries: 2}}, 1, nil
+	}
+
+	dir, err := ioutil.TempDir("", "series-limits-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	sfile := tsdb.NewSeriesFile(dir)
+	if err := sfile.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer sfile.Close()
+
+	countErr := errors.New("index unavailable")
+	limiter := newSeriesLimiter(bucketSvc)
+	limiter.seriesN = func(*tsi1.Index, []byte) (int, error) { return 0, countErr }
+
+	name := tsdb.EncodeName(orgID, bucketID)
+	point := func(host string) models.Point {
+		return models.MustNewPoint(
+			string(name[:]),
+			models.Tags{{Key: []byte("host"), Value: []byte(host)}},
+			map[string]interface{}{"value": 1.0},
+			time.Unix(1, 2),
+		)
+	}
+
+	// The new series of a bucket whose series cannot be counted are dropped
+	// rather than written past its limit.
+	collection := tsdb.NewSeriesCollection([]models.Point{point("a"), point("b")})
+	release := limiter.enforce(collection, sfile, nil)
+	release(nil)
+	if got := collection.Length(); got != 0 {
+		t.Fatalf("expected all points to be dropped, got %d points", got)
+	}
+	if collection.Dropped != 2 || !strings.Contains(collection.Reason, countErr.Error()) {
+		t.Fatalf("expected 2 points dropped because of the count error, got %d dropped: %q", collection.Dropped, collection.Reason)
+	}
+
+	// The failed count is not cached, so the series are counted again.
+	if _, ok := limiter.counts[bucketID]; ok {
+		t.Fatal("expected the failed count not to be cached")
+	}
+	limiter.seriesN = func(*tsi1.Index, []byte) (int, error) { return 0, nil }
+	collection = tsdb.NewSeriesCollection([]models.Point{point("a")})
+	limiter.enforce(collection, sfile, nil)(nil)
+	if got := collection.Length(); got != 1 {
+		t.Fatalf("expected the point to be kept, got %d points", got)
+	}
+}
@@ -1,6 +1,9 @@
 package platform
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Task is a task. 🎊
 type Task struct {
@@ -12,6 +15,14 @@ type Task struct {
 	Flux         string `json:"flux"`
 	Every        string `json:"every,omitempty"`
 	Cron         string `json:"cron,omitempty"`
+
+	// ScheduleAfter is the RFC3339 time after which the task is first scheduled.
+	// It is only read when creating the task; if empty, the task is scheduled after its creation.
+	ScheduleAfter string `json:"scheduleAfter,omitempty"`
+
+	// CatchUp is the policy for the schedules of the task that are due at once,
+	// such as after it was inactive: "all", "latest" or "skip".
+	CatchUp string `json:"catchUp,omitempty"`
 }
 
 // Run is a record created when a run of a task is scheduled.
@@ -57,13 +68,18 @@ type TaskService interface {
 	FindRunByID(ctx context.Context, orgID, runID ID) (*Run, error)
 
 	// Creates and returns a new run (which is a retry of another run)
-	RetryRun(ctx context.Context, id ID) (*Run, error)
+	RetryRun(ctx context.Context, taskID, runID ID) (*Run, error)
+
+	// Requests runs of a task for its schedules from start through end, to
+	// backfill a past range. FindRuns returns the queued runs as scheduled.
+	BackfillTask(ctx context.Context, taskID ID, start, end time.Time) error
 }
 
 // TaskUpdate represents updates to a task
 type TaskUpdate struct {
-	Flux   *string `json:"flux,omitempty"`
-	Status *string `json:"status,omitempty"`
+	Flux    *string `json:"flux,omitempty"`
+	Status  *string `json:"status,omitempty"`
+	CatchUp *string `json:"catchUp,omitempty"`
 }
 
 // TaskFilter represents a set of filters that restrict the returned results
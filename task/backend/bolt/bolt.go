@@ -9,6 +9,7 @@
 //    bucket(/tasks/v1/user_by_task_id) key(:task_id) -> The user ID (stored as encoded string) associated with given task.
 //    buket(/tasks/v1/name_by_task_id) key(:task_id) -> The user-supplied name of the script.
 //    bucket(/tasks/v1/run_ids) -> Counter for run IDs
+//    bucket(/tasks/v1/leases) key(:task_id) -> Big-endian Unix timestamp of when the lease expires, followed by the lease owner.
 //    bucket(/tasks/v1/orgs).bucket(:org_id) key(:task_id) -> Empty content; presence of :task_id allows for lookup from org to tasks.
 //    bucket(/tasks/v1/users).bucket(:user_id) key(:task_id) -> Empty content; presence of :task_id allows for lookup from user to tasks.
 // Note that task IDs are stored big-endian uint64s for sorting purposes,
@@ -18,6 +19,7 @@ package bolt
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"time"
@@ -59,6 +61,7 @@ var (
 	userByTaskID = []byte(basePath + "user_by_task_id")
 	nameByTaskID = []byte(basePath + "name_by_task_id")
 	runIDs       = []byte(basePath + "run_ids")
+	leasesPath   = []byte(basePath + "leases")
 )
 
 // New gives us a new Store based on "github.com/coreos/bbolt"
@@ -78,7 +81,7 @@ func New(db *bolt.DB, rootBucket string) (*Store, error) {
 		for _, b := range [][]byte{
 			tasksPath, orgsPath, usersPath, taskMetaPath,
 			orgByTaskID, userByTaskID,
-			nameByTaskID, runIDs,
+			nameByTaskID, runIDs, leasesPath,
 		} {
 			_, err := root.CreateBucketIfNotExists(b)
 			if err != nil {
@@ -176,10 +179,14 @@ func (s *Store) CreateTask(ctx context.Context, req backend.CreateTaskRequest) (
 			LatestCompleted: req.ScheduleAfter,
 			EffectiveCron:   o.EffectiveCronString(),
 			Delay:           int32(o.Delay / time.Second),
+			CatchUp:         string(req.CatchUp),
 		}
 		if stm.Status == "" {
 			stm.Status = string(backend.DefaultTaskStatus)
 		}
+		if stm.CatchUp == "" {
+			stm.CatchUp = string(backend.DefaultCatchUpPolicy)
+		}
 
 		stmBytes, err := stm.Marshal()
 		if err != nil {
@@ -253,8 +260,13 @@ func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (
 			return err
 		}
 		res.OldStatus = backend.TaskStatus(stm.Status)
-		if req.Status != "" {
-			stm.Status = string(req.Status)
+		if req.Status != "" || req.CatchUp != "" {
+			if req.Status != "" {
+				stm.Status = string(req.Status)
+			}
+			if req.CatchUp != "" {
+				stm.CatchUp = string(req.CatchUp)
+			}
 			stmBytes, err = stm.Marshal()
 			if err != nil {
 				return err
@@ -507,6 +519,35 @@ func (s *Store) FindTaskMetaByID(ctx context.Context, id platform.ID) (*backend.
 	return &stm, nil
 }
 
+func (s *Store) FindTaskMetasByIDs(ctx context.Context, ids []platform.ID) ([]*backend.StoreTaskMeta, error) {
+	metas := make([]*backend.StoreTaskMeta, len(ids))
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket).Bucket(taskMetaPath)
+		for i, id := range ids {
+			encodedID, err := id.Encode()
+			if err != nil {
+				return err
+			}
+			stmBytes := b.Get(encodedID)
+			if stmBytes == nil {
+				continue
+			}
+
+			stm := backend.StoreTaskMeta{}
+			if err := stm.Unmarshal(stmBytes); err != nil {
+				return err
+			}
+			metas[i] = &stm
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return metas, nil
+}
+
 func (s *Store) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*backend.StoreTask, *backend.StoreTaskMeta, error) {
 	var stmBytes []byte
 	var userID, orgID platform.ID
@@ -584,6 +625,9 @@ func (s *Store) DeleteTask(ctx context.Context, id platform.ID) (deleted bool, e
 		if err := b.Bucket(nameByTaskID).Delete(encodedID); err != nil {
 			return err
 		}
+		if err := b.Bucket(leasesPath).Delete(encodedID); err != nil {
+			return err
+		}
 
 		org := b.Bucket(orgByTaskID).Get(encodedID)
 		if len(org) > 0 {
@@ -602,8 +646,10 @@ func (s *Store) DeleteTask(ctx context.Context, id platform.ID) (deleted bool, e
 	return true, nil
 }
 
-func (s *Store) CreateNextRun(ctx context.Context, taskID platform.ID, now int64) (backend.RunCreation, error) {
+// CreateNextRun creates the next run of the task, unless another owner than owner holds its lease.
+func (s *Store) CreateNextRun(ctx context.Context, taskID platform.ID, now int64, owner string) (backend.RunCreation, error) {
 	var rc backend.RunCreation
+	var createErr error
 
 	encodedID, err := taskID.Encode()
 	if err != nil {
@@ -617,6 +663,18 @@ func (s *Store) CreateNextRun(ctx context.Context, taskID platform.ID, now int64
 			return backend.ErrTaskNotFound
 		}
 
+		if owner != "" {
+			if v := b.Bucket(leasesPath).Get(encodedID); v != nil {
+				l, err := decodeLease(v)
+				if err != nil {
+					return err
+				}
+				if err := l.Check(owner, now); err != nil {
+					return err
+				}
+			}
+		}
+
 		var stm backend.StoreTaskMeta
 		if err := stm.Unmarshal(stmBytes); err != nil {
 			return err
@@ -631,14 +689,16 @@ func (s *Store) CreateNextRun(ctx context.Context, taskID platform.ID, now int64
 			return platform.ID(idi), nil
 		}
 
-		var err error
-		rc, err = stm.CreateNextRun(now, makeID)
-		if err != nil {
-			return err
+		rc, createErr = stm.CreateNextRun(now, makeID)
+		if createErr != nil {
+			if _, ok := createErr.(backend.RunNotYetDueError); !ok {
+				return createErr
+			}
+			// The task may have skipped schedules according to its catch-up policy, so store the meta anyway.
 		}
 		rc.Created.TaskID = taskID
 
-		stmBytes, err = stm.Marshal()
+		stmBytes, err := stm.Marshal()
 		if err != nil {
 			return err
 		}
@@ -646,6 +706,9 @@ func (s *Store) CreateNextRun(ctx context.Context, taskID platform.ID, now int64
 	}); err != nil {
 		return backend.RunCreation{}, err
 	}
+	if createErr != nil {
+		return backend.RunCreation{}, createErr
+	}
 
 	return rc, nil
 }
@@ -703,6 +766,75 @@ func (s *Store) ManuallyRunTimeRange(_ context.Context, taskID platform.ID, star
 	})
 }
 
+// ClaimTaskLease grants owner the lease of the task until expiresAt, unless another owner holds a lease that has not expired by now.
+func (s *Store) ClaimTaskLease(_ context.Context, taskID platform.ID, owner string, now, expiresAt int64) error {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if check := b.Bucket(tasksPath).Get(encodedID); check == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		var l backend.TaskLease
+		if v := b.Bucket(leasesPath).Get(encodedID); v != nil {
+			if l, err = decodeLease(v); err != nil {
+				return err
+			}
+		}
+		if err := l.Claim(owner, now, expiresAt); err != nil {
+			return err
+		}
+
+		return b.Bucket(leasesPath).Put(encodedID, encodeLease(l))
+	})
+}
+
+// ReleaseTaskLease releases the lease of the task, if owner holds it.
+func (s *Store) ReleaseTaskLease(_ context.Context, taskID platform.ID, owner string) error {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket).Bucket(leasesPath)
+		v := b.Get(encodedID)
+		if v == nil {
+			return nil
+		}
+
+		l, err := decodeLease(v)
+		if err != nil {
+			return err
+		}
+		if l.Owner != owner {
+			return nil
+		}
+		return b.Delete(encodedID)
+	})
+}
+
+func encodeLease(l backend.TaskLease) []byte {
+	v := make([]byte, 8+len(l.Owner))
+	binary.BigEndian.PutUint64(v, uint64(l.ExpiresAt))
+	copy(v[8:], l.Owner)
+	return v
+}
+
+func decodeLease(v []byte) (backend.TaskLease, error) {
+	if len(v) < 8 {
+		return backend.TaskLease{}, errors.New("invalid task lease")
+	}
+	return backend.TaskLease{
+		ExpiresAt: int64(binary.BigEndian.Uint64(v)),
+		Owner:     string(v[8:]),
+	}, nil
+}
+
 // Close closes the store
 func (s *Store) Close() error {
 	return s.db.Close()
@@ -748,6 +880,9 @@ func (s *Store) DeleteUser(ctx context.Context, id platform.ID) error {
 			if err := b.Bucket(nameByTaskID).Delete(k); err != nil {
 				return err
 			}
+			if err := b.Bucket(leasesPath).Delete(k); err != nil {
+				return err
+			}
 
 			org := b.Bucket(orgByTaskID).Get(k)
 			if len(org) > 0 {
@@ -812,6 +947,9 @@ func (s *Store) DeleteOrg(ctx context.Context, id platform.ID) error {
 			if err := b.Bucket(nameByTaskID).Delete(k); err != nil {
 				return err
 			}
+			if err := b.Bucket(leasesPath).Delete(k); err != nil {
+				return err
+			}
 			user := b.Bucket(userByTaskID).Get(k)
 			if len(user) > 0 {
 				ub := b.Bucket(usersPath).Bucket(user)
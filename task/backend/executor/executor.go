@@ -15,11 +15,42 @@ import (
 	"go.uber.org/zap"
 )
 
+// ResultHandler handles the results of the query of a run, before the run completes.
+type ResultHandler interface {
+	// HandleResults consumes the results of the query of run.
+	// If it returns an error, the run fails with that error.
+	HandleResults(ctx context.Context, run backend.QueuedRun, results flux.ResultIterator) error
+}
+
+// Option configures an executor.
+type Option func(*executorOptions)
+
+type executorOptions struct {
+	resultHandler ResultHandler
+}
+
+// WithResultHandler sets the handler of the results of the queries of runs.
+// Without a handler, the results are discarded.
+func WithResultHandler(h ResultHandler) Option {
+	return func(o *executorOptions) {
+		o.resultHandler = h
+	}
+}
+
+func newExecutorOptions(opts []Option) executorOptions {
+	var o executorOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
 // queryServiceExecutor is an implementation of backend.Executor that depends on a QueryService.
 type queryServiceExecutor struct {
 	svc    query.QueryService
 	st     backend.Store
 	logger *zap.Logger
+	opts   executorOptions
 }
 
 var _ backend.Executor = (*queryServiceExecutor)(nil)
@@ -27,8 +58,8 @@ var _ backend.Executor = (*queryServiceExecutor)(nil)
 // NewQueryServiceExecutor returns a new executor based on the given QueryService.
 // In general, you should prefer NewAsyncQueryServiceExecutor, as that code is smaller and simpler,
 // because asynchronous queries are more in line with the Executor interface.
-func NewQueryServiceExecutor(logger *zap.Logger, svc query.QueryService, st backend.Store) backend.Executor {
-	return &queryServiceExecutor{logger: logger, svc: svc, st: st}
+func NewQueryServiceExecutor(logger *zap.Logger, svc query.QueryService, st backend.Store, opts ...Option) backend.Executor {
+	return &queryServiceExecutor{logger: logger, svc: svc, st: st, opts: newExecutorOptions(opts)}
 }
 
 func (e *queryServiceExecutor) Execute(ctx context.Context, run backend.QueuedRun) (backend.RunPromise, error) {
@@ -42,13 +73,14 @@ func (e *queryServiceExecutor) Execute(ctx context.Context, run backend.QueuedRu
 
 // syncRunPromise implements backend.RunPromise for a synchronous QueryService.
 type syncRunPromise struct {
-	qr     backend.QueuedRun
-	svc    query.QueryService
-	t      *backend.StoreTask
-	ctx    context.Context
-	cancel context.CancelFunc
-	logger *zap.Logger
-	logEnd func()
+	qr      backend.QueuedRun
+	svc     query.QueryService
+	t       *backend.StoreTask
+	handler ResultHandler
+	ctx     context.Context
+	cancel  context.CancelFunc
+	logger  *zap.Logger
+	logEnd  func()
 
 	finishOnce sync.Once     // Ensure we set the values only once.
 	ready      chan struct{} // Closed inside finish. Indicates Wait will no longer block.
@@ -62,14 +94,15 @@ func newSyncRunPromise(ctx context.Context, qr backend.QueuedRun, e *queryServic
 	ctx, cancel := context.WithCancel(ctx)
 	log, logEnd := logger.NewOperation(e.logger, "Executing task", "execute")
 	rp := &syncRunPromise{
-		qr:     qr,
-		svc:    e.svc,
-		t:      t,
-		logger: log,
-		logEnd: logEnd,
-		ctx:    ctx,
-		cancel: cancel,
-		ready:  make(chan struct{}),
+		qr:      qr,
+		svc:     e.svc,
+		t:       t,
+		handler: e.opts.resultHandler,
+		logger:  log,
+		logEnd:  logEnd,
+		ctx:     ctx,
+		cancel:  cancel,
+		ready:   make(chan struct{}),
 	}
 
 	go rp.doQuery()
@@ -138,6 +171,18 @@ func (p *syncRunPromise) doQuery() {
 		return
 	}
 
+	if p.handler != nil {
+		if err := p.handler.HandleResults(p.ctx, p.qr, it); err != nil {
+			it.Cancel()
+			// The query is only released once the canceled iterator has no more results.
+			for it.More() {
+				_ = it.Next()
+			}
+			p.finish(&runResult{err: err}, nil)
+			return
+		}
+	}
+
 	// Drain the result iterator.
 	for it.More() {
 		// Is it okay to assume it.Err will be set if the query context is canceled?
@@ -164,13 +209,14 @@ type asyncQueryServiceExecutor struct {
 	svc    query.AsyncQueryService
 	st     backend.Store
 	logger *zap.Logger
+	opts   executorOptions
 }
 
 var _ backend.Executor = (*asyncQueryServiceExecutor)(nil)
 
 // NewQueryServiceExecutor returns a new executor based on the given AsyncQueryService.
-func NewAsyncQueryServiceExecutor(logger *zap.Logger, svc query.AsyncQueryService, st backend.Store) backend.Executor {
-	return &asyncQueryServiceExecutor{logger: logger, svc: svc, st: st}
+func NewAsyncQueryServiceExecutor(logger *zap.Logger, svc query.AsyncQueryService, st backend.Store, opts ...Option) backend.Executor {
+	return &asyncQueryServiceExecutor{logger: logger, svc: svc, st: st, opts: newExecutorOptions(opts)}
 }
 
 func (e *asyncQueryServiceExecutor) Execute(ctx context.Context, run backend.QueuedRun) (backend.RunPromise, error) {
@@ -195,13 +241,15 @@ func (e *asyncQueryServiceExecutor) Execute(ctx context.Context, run backend.Que
 		return nil, err
 	}
 
-	return newAsyncRunPromise(run, q, e), nil
+	return newAsyncRunPromise(ctx, run, q, e), nil
 }
 
 // asyncRunPromise implements backend.RunPromise for an AsyncQueryService.
 type asyncRunPromise struct {
-	qr backend.QueuedRun
-	q  flux.Query
+	ctx     context.Context
+	qr      backend.QueuedRun
+	q       flux.Query
+	handler ResultHandler
 
 	logger *zap.Logger
 	logEnd func()
@@ -214,13 +262,15 @@ type asyncRunPromise struct {
 
 var _ backend.RunPromise = (*asyncRunPromise)(nil)
 
-func newAsyncRunPromise(qr backend.QueuedRun, q flux.Query, e *asyncQueryServiceExecutor) *asyncRunPromise {
+func newAsyncRunPromise(ctx context.Context, qr backend.QueuedRun, q flux.Query, e *asyncQueryServiceExecutor) *asyncRunPromise {
 	log, logEnd := logger.NewOperation(e.logger, "Executing task", "execute")
 
 	p := &asyncRunPromise{
-		qr:    qr,
-		q:     q,
-		ready: make(chan struct{}),
+		ctx:     ctx,
+		qr:      qr,
+		q:       q,
+		handler: e.opts.resultHandler,
+		ready:   make(chan struct{}),
 
 		logger: log,
 		logEnd: logEnd,
@@ -260,7 +310,7 @@ func (p *asyncRunPromise) followQuery() {
 		// The promise was finished somewhere else, so we don't need to call p.finish.
 		// But we do need to cancel the flux. This could be a no-op.
 		p.q.Cancel()
-	case _, ok := <-p.q.Ready():
+	case results, ok := <-p.q.Ready():
 		if !ok {
 			// Something went wrong with the flux. Set the error in the run result.
 			rr := &runResult{err: p.q.Err()}
@@ -268,6 +318,13 @@ func (p *asyncRunPromise) followQuery() {
 			return
 		}
 
+		if p.handler != nil {
+			if err := p.handler.HandleResults(p.ctx, p.qr, flux.NewMapResultIterator(results)); err != nil {
+				p.finish(&runResult{err: err}, nil)
+				return
+			}
+		}
+
 		// Otherwise, query was successful.
 		// TODO(mr): collect query statistics, once RunResult interface supports them?
 		p.finish(new(runResult), nil)
@@ -205,20 +205,20 @@ type system struct {
 	ex   backend.Executor
 }
 
-type createSysFn func() *system
+type createSysFn func(opts ...executor.Option) *system
 
-func createAsyncSystem() *system {
+func createAsyncSystem(opts ...executor.Option) *system {
 	svc := newFakeQueryService()
 	st := backend.NewInMemStore()
 	return &system{
 		name: "AsyncExecutor",
 		svc:  svc,
 		st:   st,
-		ex:   executor.NewAsyncQueryServiceExecutor(zap.NewNop(), svc, st),
+		ex:   executor.NewAsyncQueryServiceExecutor(zap.NewNop(), svc, st, opts...),
 	}
 }
 
-func createSyncSystem() *system {
+func createSyncSystem(opts ...executor.Option) *system {
 	svc := newFakeQueryService()
 	st := backend.NewInMemStore()
 	return &system{
@@ -231,6 +231,7 @@ func createSyncSystem() *system {
 				AsyncQueryService: svc,
 			},
 			st,
+			opts...,
 		),
 	}
 }
@@ -241,6 +242,7 @@ func TestExecutor(t *testing.T) {
 		testExecutorQueryFailure(t, fn)
 		testExecutorPromiseCancel(t, fn)
 		testExecutorServiceError(t, fn)
+		testExecutorResultHandler(t, fn)
 	}
 }
 
@@ -391,3 +393,85 @@ func testExecutorServiceError(t *testing.T, fn createSysFn) {
 		}
 	})
 }
+
+// resultHandlerFunc is a function implementing executor.ResultHandler.
+type resultHandlerFunc func(ctx context.Context, run backend.QueuedRun, results flux.ResultIterator) error
+
+func (f resultHandlerFunc) HandleResults(ctx context.Context, run backend.QueuedRun, results flux.ResultIterator) error {
+	return f(ctx, run, results)
+}
+
+func testExecutorResultHandler(t *testing.T, fn createSysFn) {
+	var orgID = platformtesting.MustIDBase16("aaaaaaaaaaaaaaaa")
+	var userID = platformtesting.MustIDBase16("baaaaaaaaaaaaaab")
+
+	var (
+		mu       sync.Mutex
+		runs     []backend.QueuedRun
+		names    []string
+		expErr   = errors.New("forced handler error")
+		failNext bool
+	)
+	handler := resultHandlerFunc(func(ctx context.Context, run backend.QueuedRun, results flux.ResultIterator) error {
+		mu.Lock()
+		defer mu.Unlock()
+		runs = append(runs, run)
+		for results.More() {
+			names = append(names, results.Next().Name())
+		}
+		if failNext {
+			failNext = false
+			return expErr
+		}
+		return nil
+	})
+
+	sys := fn(executor.WithResultHandler(handler))
+	t.Run(sys.name+"/ResultHandler", func(t *testing.T) {
+		tid, err := sys.st.CreateTask(context.Background(), backend.CreateTaskRequest{Org: orgID, User: userID, Script: testScript})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		qr := backend.QueuedRun{TaskID: tid, RunID: platform.ID(1), Now: 123}
+		rp, err := sys.ex.Execute(context.Background(), qr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sys.svc.WaitForQueryLive(t, testScript)
+		sys.svc.SucceedQuery(testScript)
+		res, err := rp.Wait()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := res.Err(); got != nil {
+			t.Fatal(got)
+		}
+
+		mu.Lock()
+		if len(runs) != 1 || runs[0] != qr {
+			t.Fatalf("expected handler to be called for run %v, got %v", qr, runs)
+		}
+		if !reflect.DeepEqual(names, []string{"res"}) {
+			t.Fatalf("expected handler to see result res, got %v", names)
+		}
+		failNext = true
+		mu.Unlock()
+
+		// An error of the handler fails the run.
+		qr.RunID = platform.ID(2)
+		rp, err = sys.ex.Execute(context.Background(), qr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sys.svc.WaitForQueryLive(t, testScript)
+		sys.svc.SucceedQuery(testScript)
+		res, err = rp.Wait()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := res.Err(); got != expErr {
+			t.Fatalf("expected error %v; got %v", expErr, got)
+		}
+	})
+}
@@ -25,6 +25,8 @@ type inmem struct {
 	tasks []StoreTask
 
 	runners map[string]StoreTaskMeta
+
+	leases map[string]TaskLease
 }
 
 // NewInMemStore returns a new in-memory store.
@@ -33,6 +35,7 @@ func NewInMemStore() Store {
 	return &inmem{
 		idgen:   snowflake.NewIDGenerator(),
 		runners: map[string]StoreTaskMeta{},
+		leases:  map[string]TaskLease{},
 	}
 }
 
@@ -66,10 +69,14 @@ func (s *inmem) CreateTask(_ context.Context, req CreateTaskRequest) (platform.I
 		LatestCompleted: req.ScheduleAfter,
 		EffectiveCron:   o.EffectiveCronString(),
 		Delay:           int32(o.Delay / time.Second),
+		CatchUp:         string(req.CatchUp),
 	}
 	if stm.Status == "" {
 		stm.Status = string(DefaultTaskStatus)
 	}
+	if stm.CatchUp == "" {
+		stm.CatchUp = string(DefaultCatchUpPolicy)
+	}
 	s.runners[id.String()] = stm
 
 	return id, nil
@@ -124,6 +131,11 @@ func (s *inmem) UpdateTask(_ context.Context, req UpdateTaskRequest) (UpdateTask
 		stm.Status = string(req.Status)
 		s.runners[idStr] = stm
 	}
+	if req.CatchUp != "" {
+		// Changing the catch-up policy.
+		stm.CatchUp = string(req.CatchUp)
+		s.runners[idStr] = stm
+	}
 	res.NewMeta = stm
 
 	return res, nil
@@ -239,6 +251,20 @@ func (s *inmem) FindTaskMetaByID(ctx context.Context, id platform.ID) (*StoreTas
 	return &meta, nil
 }
 
+func (s *inmem) FindTaskMetasByIDs(ctx context.Context, ids []platform.ID) ([]*StoreTaskMeta, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	metas := make([]*StoreTaskMeta, len(ids))
+	for i, id := range ids {
+		if meta, ok := s.runners[id.String()]; ok {
+			metas[i] = &meta
+		}
+	}
+
+	return metas, nil
+}
+
 func (s *inmem) DeleteTask(_ context.Context, id platform.ID) (deleted bool, err error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -257,6 +283,7 @@ func (s *inmem) DeleteTask(_ context.Context, id platform.ID) (deleted bool, err
 
 	// Delete entry from slice.
 	s.tasks = append(s.tasks[:idx], s.tasks[idx+1:]...)
+	delete(s.leases, id.String())
 	return true, nil
 }
 
@@ -264,7 +291,7 @@ func (s *inmem) Close() error {
 	return nil
 }
 
-func (s *inmem) CreateNextRun(ctx context.Context, taskID platform.ID, now int64) (RunCreation, error) {
+func (s *inmem) CreateNextRun(ctx context.Context, taskID platform.ID, now int64, owner string) (RunCreation, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -273,11 +300,21 @@ func (s *inmem) CreateNextRun(ctx context.Context, taskID platform.ID, now int64
 		return RunCreation{}, errors.New("task not found")
 	}
 
+	if owner != "" {
+		if err := s.leases[taskID.String()].Check(owner, now); err != nil {
+			return RunCreation{}, err
+		}
+	}
+
 	makeID := func() (platform.ID, error) {
 		return s.idgen.ID(), nil
 	}
 	rc, err := stm.CreateNextRun(now, makeID)
 	if err != nil {
+		if _, ok := err.(RunNotYetDueError); ok {
+			// The task may have skipped schedules according to its catch-up policy.
+			s.runners[taskID.String()] = stm
+		}
 		return RunCreation{}, err
 	}
 	rc.Created.TaskID = taskID
@@ -326,6 +363,42 @@ func (s *inmem) ManuallyRunTimeRange(_ context.Context, taskID platform.ID, star
 	return nil
 }
 
+func (s *inmem) ClaimTaskLease(_ context.Context, taskID platform.ID, owner string, now, expiresAt int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found := false
+	for _, t := range s.tasks {
+		if t.ID == taskID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrTaskNotFound
+	}
+
+	tid := taskID.String()
+	l := s.leases[tid]
+	if err := l.Claim(owner, now, expiresAt); err != nil {
+		return err
+	}
+
+	s.leases[tid] = l
+	return nil
+}
+
+func (s *inmem) ReleaseTaskLease(_ context.Context, taskID platform.ID, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tid := taskID.String()
+	if l, ok := s.leases[tid]; ok && l.Owner == owner {
+		delete(s.leases, tid)
+	}
+	return nil
+}
+
 func (s *inmem) delete(ctx context.Context, id platform.ID, f func(StoreTask) platform.ID) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -354,6 +427,7 @@ func (s *inmem) delete(ctx context.Context, id platform.ID, f func(StoreTask) pl
 	}
 	for i := range deletingTasks {
 		delete(s.runners, s.tasks[i].ID.String())
+		delete(s.leases, deletingTasks[i].String())
 	}
 	s.tasks = newTasks
 	return nil
@@ -0,0 +1,361 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"go.uber.org/zap"
+)
+
+// ErrTaskLeased is returned when claiming the lease of a task that another owner holds.
+var ErrTaskLeased = errors.New("task leased by another owner")
+
+// TaskLease is a time-limited claim of a task by one of the schedulers sharing a Store.
+type TaskLease struct {
+	// Owner identifies the scheduler holding the lease.
+	Owner string
+
+	// Unix timestamp of when the lease expires, unless it is renewed.
+	ExpiresAt int64
+}
+
+// Check returns ErrTaskLeased if an owner other than owner holds the lease
+// and it has not expired by the Unix timestamp now.
+func (l TaskLease) Check(owner string, now int64) error {
+	if l.Owner != "" && l.Owner != owner && l.ExpiresAt > now {
+		return ErrTaskLeased
+	}
+	return nil
+}
+
+// Claim grants the lease to owner until the Unix timestamp expiresAt.
+// If another owner holds the lease and it has not expired by the Unix timestamp now,
+// Claim returns ErrTaskLeased and the lease is unchanged.
+func (l *TaskLease) Claim(owner string, now, expiresAt int64) error {
+	if err := l.Check(owner, now); err != nil {
+		return err
+	}
+
+	l.Owner = owner
+	l.ExpiresAt = expiresAt
+	return nil
+}
+
+// leasedDesiredState creates the runs of the tasks of a Store on behalf of a lease owner.
+type leasedDesiredState struct {
+	st    Store
+	owner string
+}
+
+// LeasedDesiredState returns the DesiredState of the tasks of st for the scheduler of a LeaseScheduler held by owner.
+// It refuses to create the runs of the tasks whose leases other owners hold.
+func LeasedDesiredState(st Store, owner string) DesiredState {
+	return leasedDesiredState{st: st, owner: owner}
+}
+
+func (s leasedDesiredState) CreateNextRun(ctx context.Context, taskID platform.ID, now int64) (RunCreation, error) {
+	return s.st.CreateNextRun(ctx, taskID, now, s.owner)
+}
+
+func (s leasedDesiredState) FinishRun(ctx context.Context, taskID, runID platform.ID) error {
+	return s.st.FinishRun(ctx, taskID, runID)
+}
+
+// LeaseSchedulerOption is a option you can use to modify the behavior of a LeaseScheduler.
+type LeaseSchedulerOption func(*LeaseScheduler)
+
+// WithLeaseLogger sets the logger for the lease scheduler.
+// If not set, the lease scheduler will use a no-op logger.
+func WithLeaseLogger(logger *zap.Logger) LeaseSchedulerOption {
+	return func(s *LeaseScheduler) {
+		s.logger = logger.With(zap.String("svc", "taskd/leases"))
+	}
+}
+
+// WithLeaseClock sets the clock the lease scheduler uses to grant and renew leases.
+// If not set, the lease scheduler uses time.Now.
+func WithLeaseClock(now func() time.Time) LeaseSchedulerOption {
+	return func(s *LeaseScheduler) {
+		s.now = now
+	}
+}
+
+// LeaseScheduler is a Scheduler that only claims the tasks whose lease it holds in the Store,
+// so that each task is executed by a single one of the schedulers sharing the Store.
+//
+// While started, it periodically renews the leases of its tasks,
+// and claims the active tasks whose leases are free or expired, such as those of a scheduler that went away.
+// A claimed task resumes the runs in its CurrentlyRunning metadata, that the previous owner did not finish.
+// Stop releases all of its leases, so that other schedulers can claim the tasks right away.
+//
+// A scheduler only learns that it lost a lease on the next sync, so its DesiredState must be the LeasedDesiredState
+// of the Store and owner, which refuses to create runs once another owner holds the lease.
+type LeaseScheduler struct {
+	sch   Scheduler
+	store Store
+
+	owner string
+	ttl   time.Duration
+	now   func() time.Time
+
+	logger *zap.Logger
+
+	mu      sync.Mutex            // Protects claimed and the claims and releases of tasks in sch.
+	claimed map[string]*StoreTask // Stringified task ID -> task claimed in sch.
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var _ Scheduler = (*LeaseScheduler)(nil)
+
+// NewLeaseScheduler returns a LeaseScheduler that claims tasks in sch under leases of st held by owner.
+// Owner must be unique among the schedulers sharing st.
+// Leases are granted for ttl and renewed every third of ttl.
+func NewLeaseScheduler(sch Scheduler, st Store, owner string, ttl time.Duration, opts ...LeaseSchedulerOption) *LeaseScheduler {
+	s := &LeaseScheduler{
+		sch:     sch,
+		store:   st,
+		owner:   owner,
+		ttl:     ttl,
+		now:     time.Now,
+		logger:  zap.NewNop(),
+		claimed: make(map[string]*StoreTask),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Start starts the underlying scheduler, and keeps the leases in sync until ctx is done or Stop is called.
+func (s *LeaseScheduler) Start(ctx context.Context) {
+	s.sch.Start(ctx)
+
+	ctx, cancel := context.WithCancel(ctx)
+	s.mu.Lock()
+	s.cancel = cancel
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.ttl / 3)
+		defer ticker.Stop()
+		for {
+			if err := s.Sync(ctx); err != nil {
+				s.logger.Info("Failed to sync task leases", zap.Error(err))
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the underlying scheduler and releases the leases of its tasks.
+func (s *LeaseScheduler) Stop() {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+	s.wg.Wait()
+
+	s.sch.Stop()
+
+	s.mu.Lock()
+	claimed := s.claimed
+	s.claimed = make(map[string]*StoreTask)
+	s.mu.Unlock()
+
+	for tid, task := range claimed {
+		if err := s.store.ReleaseTaskLease(context.Background(), task.ID, s.owner); err != nil {
+			s.logger.Info("Failed to release task lease", zap.String("task_id", tid), zap.Error(err))
+		}
+	}
+}
+
+// ClaimTask claims the lease of the task and then the task in the underlying scheduler.
+// If another scheduler holds the lease, the task is left to that scheduler and ClaimTask returns nil.
+func (s *LeaseScheduler) ClaimTask(task *StoreTask, meta *StoreTaskMeta) error {
+	return s.claim(context.Background(), task, meta)
+}
+
+// UpdateTask updates the task in the underlying scheduler, if it claimed the task.
+func (s *LeaseScheduler) UpdateTask(task *StoreTask, meta *StoreTaskMeta) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.sch.UpdateTask(task, meta); err != nil {
+		return err
+	}
+	s.claimed[task.ID.String()] = task
+	return nil
+}
+
+// ReleaseTask releases the task in the underlying scheduler, and then its lease.
+func (s *LeaseScheduler) ReleaseTask(taskID platform.ID) error {
+	return s.release(context.Background(), taskID)
+}
+
+// Sync renews the leases of the claimed tasks and claims the active tasks whose leases are free or expired.
+// Claimed tasks that were updated, deactivated or deleted, or whose lease was lost, are updated or released accordingly.
+// The store is read a page of tasks at a time, and without holding s.mu, so that Sync does not block the claims and releases of tasks.
+// Sync is called periodically once the LeaseScheduler is started.
+func (s *LeaseScheduler) Sync(ctx context.Context) error {
+	s.mu.Lock()
+	claimed := make([]*StoreTask, 0, len(s.claimed))
+	for _, task := range s.claimed {
+		claimed = append(claimed, task)
+	}
+	s.mu.Unlock()
+
+	for _, task := range claimed {
+		if err := s.renew(ctx, task); err != nil {
+			return err
+		}
+	}
+
+	var after platform.ID
+	for {
+		tasks, err := s.store.ListTasks(ctx, TaskSearchParams{After: after, PageSize: syncPageSize})
+		if err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return nil
+		}
+		after = tasks[len(tasks)-1].ID
+
+		unclaimed := s.unclaimed(tasks)
+		ids := make([]platform.ID, len(unclaimed))
+		for i, task := range unclaimed {
+			ids[i] = task.ID
+		}
+		metas, err := s.store.FindTaskMetasByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for i, task := range unclaimed {
+			meta := metas[i]
+			if meta == nil || meta.Status != string(TaskActive) {
+				continue
+			}
+
+			if err := s.claim(ctx, task, meta); err != nil {
+				s.logger.Info("Failed to claim task", zap.String("task_id", task.ID.String()), zap.Error(err))
+			}
+		}
+	}
+}
+
+// syncPageSize is the number of tasks Sync reads from the store at a time.
+const syncPageSize = 500
+
+// unclaimed returns the tasks that are not claimed.
+func (s *LeaseScheduler) unclaimed(tasks []StoreTask) []*StoreTask {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var unclaimed []*StoreTask
+	for i := range tasks {
+		if _, ok := s.claimed[tasks[i].ID.String()]; !ok {
+			unclaimed = append(unclaimed, &tasks[i])
+		}
+	}
+	return unclaimed
+}
+
+// claim claims the lease of task, and then task in the underlying scheduler.
+func (s *LeaseScheduler) claim(ctx context.Context, task *StoreTask, meta *StoreTaskMeta) error {
+	if err := s.claimLease(ctx, task.ID); err != nil {
+		if err == ErrTaskLeased {
+			return nil
+		}
+		return err
+	}
+
+	s.mu.Lock()
+	err := s.sch.ClaimTask(task, meta)
+	if err == nil || err == ErrTaskAlreadyClaimed {
+		s.claimed[task.ID.String()] = task
+	}
+	s.mu.Unlock()
+
+	if err != nil && err != ErrTaskAlreadyClaimed {
+		if relErr := s.store.ReleaseTaskLease(ctx, task.ID, s.owner); relErr != nil {
+			s.logger.Info("Failed to release task lease", zap.String("task_id", task.ID.String()), zap.Error(relErr))
+		}
+		return err
+	}
+	return nil
+}
+
+// renew renews the lease of a claimed task, and updates or releases the task if it changed in the store.
+func (s *LeaseScheduler) renew(ctx context.Context, task *StoreTask) error {
+	if err := s.claimLease(ctx, task.ID); err != nil {
+		if err == ErrTaskLeased || err == ErrTaskNotFound {
+			// Another scheduler took over the task, or it was deleted.
+			s.logger.Info("Releasing task", zap.String("task_id", task.ID.String()), zap.Error(err))
+			return s.release(ctx, task.ID)
+		}
+		return err
+	}
+
+	newTask, meta, err := s.store.FindTaskByIDWithMeta(ctx, task.ID)
+	if err != nil {
+		if err == ErrTaskNotFound {
+			return s.release(ctx, task.ID)
+		}
+		return err
+	}
+	if newTask == nil || meta.Status != string(TaskActive) {
+		return s.release(ctx, task.ID)
+	}
+
+	if newTask.Script == task.Script {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.claimed[task.ID.String()]; !ok {
+		// The task was released while its lease was renewed.
+		return nil
+	}
+	if err := s.sch.UpdateTask(newTask, meta); err != nil {
+		return err
+	}
+	s.claimed[task.ID.String()] = newTask
+	return nil
+}
+
+// release releases the task in the underlying scheduler, and then its lease.
+func (s *LeaseScheduler) release(ctx context.Context, taskID platform.ID) error {
+	s.mu.Lock()
+	delete(s.claimed, taskID.String())
+	err := s.sch.ReleaseTask(taskID)
+	s.mu.Unlock()
+
+	if err != nil && err != ErrTaskNotClaimed {
+		return err
+	}
+	return s.store.ReleaseTaskLease(ctx, taskID, s.owner)
+}
+
+func (s *LeaseScheduler) claimLease(ctx context.Context, taskID platform.ID) error {
+	now := s.now()
+	return s.store.ClaimTaskLease(ctx, taskID, s.owner, now.Unix(), now.Add(s.ttl).Unix())
+}
@@ -0,0 +1,243 @@
+package backend_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/mock"
+)
+
+func TestTaskLease_Claim(t *testing.T) {
+	var l backend.TaskLease
+	if err := l.Claim("a", 100, 160); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Claim("b", 159, 219); err != backend.ErrTaskLeased {
+		t.Fatalf("expected %v, got %v", backend.ErrTaskLeased, err)
+	}
+	if l.Owner != "a" || l.ExpiresAt != 160 {
+		t.Fatalf("expected failed claim to leave the lease unchanged, got %+v", l)
+	}
+	if err := l.Claim("b", 160, 220); err != nil {
+		t.Fatal(err)
+	}
+	if l.Owner != "b" || l.ExpiresAt != 220 {
+		t.Fatalf("expected lease of b until 220, got %+v", l)
+	}
+}
+
+// runRecorder records the schedulers that executed each run.
+type runRecorder struct {
+	mu   sync.Mutex
+	runs map[string][]string // Task ID and run time -> names of the executors of the run.
+}
+
+func (r *runRecorder) record(name string, qr backend.QueuedRun) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := fmt.Sprintf("%s@%d", qr.TaskID, qr.Now)
+	r.runs[key] = append(r.runs[key], name)
+}
+
+// check fails t if any run was executed more than once, and returns the number of executed runs.
+func (r *runRecorder) check(t *testing.T) int {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, names := range r.runs {
+		if len(names) != 1 {
+			t.Errorf("run %s executed %d times, by %v", key, len(names), names)
+		}
+	}
+	return len(r.runs)
+}
+
+// recordingExecutor immediately succeeds every run.
+type recordingExecutor struct {
+	name string
+	rec  *runRecorder
+}
+
+func (e *recordingExecutor) Execute(_ context.Context, qr backend.QueuedRun) (backend.RunPromise, error) {
+	e.rec.record(e.name, qr)
+	rp := mock.NewRunPromise(qr)
+	rp.Finish(mock.NewRunResult(nil, false), nil)
+	return rp, nil
+}
+
+func TestLeaseScheduler_NoDuplicateRuns(t *testing.T) {
+	const script = `option task = {
+		name: "a task",
+		every: 1m,
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+
+	ctx := context.Background()
+	st := backend.NewInMemStore()
+	rec := &runRecorder{runs: make(map[string][]string)}
+
+	var clockNow int64
+	clock := func() time.Time {
+		return time.Unix(atomic.LoadInt64(&clockNow), 0)
+	}
+
+	type node struct {
+		sch *backend.TickScheduler
+		ls  *backend.LeaseScheduler
+	}
+	nodes := make([]node, 3)
+	for i := range nodes {
+		name := fmt.Sprintf("node-%d", i)
+		sch := backend.NewScheduler(backend.LeasedDesiredState(st, name), &recordingExecutor{name: name, rec: rec}, backend.NopLogWriter{}, 0)
+		ls := backend.NewLeaseScheduler(sch, st, name, time.Minute, backend.WithLeaseClock(clock))
+		ls.Start(ctx)
+		defer ls.Stop()
+		nodes[i] = node{sch: sch, ls: ls}
+	}
+
+	// Create tasks while the nodes sync, so that each node leases some of them.
+	var taskIDs []platform.ID
+	for _, n := range nodes {
+		for i := 0; i < 2; i++ {
+			id, err := st.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+			if err != nil {
+				t.Fatal(err)
+			}
+			taskIDs = append(taskIDs, id)
+		}
+		if err := n.ls.Sync(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tick := func(now int64, nodes ...node) {
+		t.Helper()
+		for _, n := range nodes {
+			n.sch.Tick(now)
+		}
+		for _, id := range taskIDs {
+			var meta *backend.StoreTaskMeta
+			for i := 0; i < 50; i++ {
+				var err error
+				if meta, err = st.FindTaskMetaByID(ctx, id); err != nil {
+					t.Fatal(err)
+				}
+				if meta.LatestCompleted >= now && len(meta.CurrentlyRunning) == 0 {
+					break
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			if meta.LatestCompleted != now {
+				t.Fatalf("expected task %s to complete runs through %d, got %d", id, now, meta.LatestCompleted)
+			}
+		}
+	}
+
+	// Every node ticks, but each run is executed by the node leasing its task.
+	tick(300, nodes...)
+	if n, want := rec.check(t), len(taskIDs)*5; n != want {
+		t.Fatalf("expected %d runs, got %d", want, n)
+	}
+
+	// A stopped node releases its leases to the other nodes.
+	nodes[0].ls.Stop()
+	for _, n := range nodes[1:] {
+		if err := n.ls.Sync(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tick(360, nodes[1:]...)
+	if n, want := rec.check(t), len(taskIDs)*6; n != want {
+		t.Fatalf("expected %d runs, got %d", want, n)
+	}
+
+	// The leases of a node that stops renewing them expire, and the remaining node takes over.
+	atomic.StoreInt64(&clockNow, 120)
+	if err := nodes[2].ls.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	tick(420, nodes[2])
+	if n, want := rec.check(t), len(taskIDs)*7; n != want {
+		t.Fatalf("expected %d runs, got %d", want, n)
+	}
+
+	// Once the node comes back, it finds its leases lost and releases its tasks.
+	if err := nodes[1].ls.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	tick(480, nodes[1:]...)
+	if n, want := rec.check(t), len(taskIDs)*8; n != want {
+		t.Fatalf("expected %d runs, got %d", want, n)
+	}
+}
+
+// blockingListStore blocks ListTasks until unblock is closed.
+type blockingListStore struct {
+	backend.Store
+	listing chan struct{}
+	unblock chan struct{}
+}
+
+func (s *blockingListStore) ListTasks(ctx context.Context, params backend.TaskSearchParams) ([]backend.StoreTask, error) {
+	select {
+	case s.listing <- struct{}{}:
+	default:
+	}
+	<-s.unblock
+	return s.Store.ListTasks(ctx, params)
+}
+
+func TestLeaseScheduler_SyncDoesNotBlockClaims(t *testing.T) {
+	const script = `option task = {
+		name: "a task",
+		every: 1m,
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+
+	ctx := context.Background()
+	st := &blockingListStore{
+		Store:   backend.NewInMemStore(),
+		listing: make(chan struct{}),
+		unblock: make(chan struct{}),
+	}
+	sch := backend.NewScheduler(backend.LeasedDesiredState(st, "node"), &recordingExecutor{name: "node", rec: &runRecorder{runs: make(map[string][]string)}}, backend.NopLogWriter{}, 0)
+	sch.Start(ctx)
+	defer sch.Stop()
+	ls := backend.NewLeaseScheduler(sch, st, "node", time.Minute)
+
+	id, err := st.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, meta, err := st.FindTaskByIDWithMeta(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	syncErr := make(chan error, 1)
+	go func() {
+		syncErr <- ls.Sync(ctx)
+	}()
+	<-st.listing
+
+	// The task is claimed while the sync is reading the tasks from the store.
+	if err := ls.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+	close(st.unblock)
+	if err := <-syncErr; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ls.ReleaseTask(id); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -53,6 +53,11 @@ func (stm *StoreTaskMeta) FinishRun(runID platform.ID) bool {
 // that is later than any in-progress run and stm's LatestCompleted timestamp.
 // If the run's now would be later than the passed-in now, CreateNextRun returns a RunNotYetDueError.
 //
+// If more than one schedule is due, stm.CatchUp determines which of them are run.
+// With CatchUpLatest, the run's now is the latest due schedule instead.
+// With CatchUpSkip, LatestCompleted is advanced to the latest due schedule without creating a run,
+// and CreateNextRun returns a RunNotYetDueError for the following schedule, unless there is a queue of manual runs.
+//
 // makeID is a function provided by the caller to create an ID, in case we can create a run.
 // Because a StoreTaskMeta doesn't know the ID of the task it belongs to, it never sets RunCreation.Created.TaskID.
 func (stm *StoreTaskMeta) CreateNextRun(now int64, makeID func() (platform.ID, error)) (RunCreation, error) {
@@ -84,6 +89,28 @@ func (stm *StoreTaskMeta) CreateNextRun(now int64, makeID func() (platform.ID, e
 		return RunCreation{}, RunNotYetDueError{DueAt: dueAt}
 	}
 
+	if policy := CatchUpPolicy(stm.CatchUp); policy == CatchUpLatest || policy == CatchUpSkip {
+		// Find the latest due schedule; any earlier ones were missed.
+		lastDue := nextScheduled
+		for next := sch.Next(lastDue); next.Unix()+int64(stm.Delay) <= now; next = sch.Next(next) {
+			lastDue = next
+		}
+
+		if !lastDue.Equal(nextScheduled) {
+			if policy == CatchUpSkip {
+				stm.LatestCompleted = lastDue.Unix()
+				dueAt := sch.Next(lastDue).Unix() + int64(stm.Delay)
+				if len(stm.ManualRuns) > 0 {
+					return stm.createNextRunFromQueue(now, dueAt, sch, makeID)
+				}
+				return RunCreation{}, RunNotYetDueError{DueAt: dueAt}
+			}
+
+			nextScheduled = lastDue
+			nextScheduledUnix = lastDue.Unix()
+		}
+	}
+
 	id, err := makeID()
 	if err != nil {
 		return RunCreation{}, err
@@ -177,6 +204,39 @@ func (stm *StoreTaskMeta) NextDueRun() (int64, error) {
 	return sch.Next(time.Unix(latest, 0)).Unix() + int64(stm.Delay), nil
 }
 
+// QueuedManualRuns returns up to limit runs the manual run queues have yet to create,
+// in the order the queues create them. The returned runs have no IDs.
+func (stm *StoreTaskMeta) QueuedManualRuns(limit int) ([]QueuedRun, error) {
+	if len(stm.ManualRuns) == 0 {
+		return nil, nil
+	}
+
+	sch, err := cron.Parse(stm.EffectiveCron)
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []QueuedRun
+	for _, q := range stm.ManualRuns {
+		latest := q.LatestCompleted
+		for _, r := range stm.CurrentlyRunning {
+			if r.RangeStart == q.Start && r.RangeEnd == q.End && r.RequestedAt == q.RequestedAt && r.Now > latest {
+				latest = r.Now
+			}
+		}
+
+		// A queue is dropped once it creates the run at or after its end.
+		for latest < q.End {
+			if len(runs) >= limit {
+				return runs, nil
+			}
+			latest = sch.Next(time.Unix(latest, 0)).Unix()
+			runs = append(runs, QueuedRun{Now: latest, RequestedAt: q.RequestedAt})
+		}
+	}
+	return runs, nil
+}
+
 // ManuallyRunTimeRange requests a manual run covering the approximate range specified by the Unix timestamps start and end.
 // More specifically, it requests runs scheduled no earlier than start, but possibly later than start,
 // if start does not land on the task's schedule; and as late as, but not necessarily equal to, end.
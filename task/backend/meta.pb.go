@@ -35,7 +35,11 @@ type StoreTaskMeta struct {
 	// effective_cron is the effective cron string as reported by the task's options.
 	EffectiveCron string `protobuf:"bytes,5,opt,name=effective_cron,json=effectiveCron,proto3" json:"effective_cron,omitempty"`
 	// Task's configured delay, in seconds.
-	Delay                int32                     `protobuf:"varint,6,opt,name=delay,proto3" json:"delay,omitempty"`
+	Delay int32 `protobuf:"varint,6,opt,name=delay,proto3" json:"delay,omitempty"`
+	// catch_up is the policy for when more than one schedule of the task is due at once, such as after it was inactive:
+	// "all" runs every due schedule, "latest" runs only the latest one,
+	// and "skip" runs none of them and waits for the next schedule. Empty is treated as "all".
+	CatchUp              string                    `protobuf:"bytes,7,opt,name=catch_up,json=catchUp,proto3" json:"catch_up,omitempty"`
 	ManualRuns           []*StoreTaskMetaManualRun `protobuf:"bytes,16,rep,name=manual_runs,json=manualRuns" json:"manual_runs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_sizecache        int32                     `json:"-"`
@@ -45,7 +49,7 @@ func (m *StoreTaskMeta) Reset()         { *m = StoreTaskMeta{} }
 func (m *StoreTaskMeta) String() string { return proto.CompactTextString(m) }
 func (*StoreTaskMeta) ProtoMessage()    {}
 func (*StoreTaskMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meta_9d9298f22bde0681, []int{0}
+	return fileDescriptor_meta_98982d89ee1c958d, []int{0}
 }
 func (m *StoreTaskMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -116,6 +120,13 @@ func (m *StoreTaskMeta) GetDelay() int32 {
 	return 0
 }
 
+func (m *StoreTaskMeta) GetCatchUp() string {
+	if m != nil {
+		return m.CatchUp
+	}
+	return ""
+}
+
 func (m *StoreTaskMeta) GetManualRuns() []*StoreTaskMetaManualRun {
 	if m != nil {
 		return m.ManualRuns
@@ -143,7 +154,7 @@ func (m *StoreTaskMetaRun) Reset()         { *m = StoreTaskMetaRun{} }
 func (m *StoreTaskMetaRun) String() string { return proto.CompactTextString(m) }
 func (*StoreTaskMetaRun) ProtoMessage()    {}
 func (*StoreTaskMetaRun) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meta_9d9298f22bde0681, []int{1}
+	return fileDescriptor_meta_98982d89ee1c958d, []int{1}
 }
 func (m *StoreTaskMetaRun) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -233,7 +244,7 @@ func (m *StoreTaskMetaManualRun) Reset()         { *m = StoreTaskMetaManualRun{}
 func (m *StoreTaskMetaManualRun) String() string { return proto.CompactTextString(m) }
 func (*StoreTaskMetaManualRun) ProtoMessage()    {}
 func (*StoreTaskMetaManualRun) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meta_9d9298f22bde0681, []int{2}
+	return fileDescriptor_meta_98982d89ee1c958d, []int{2}
 }
 func (m *StoreTaskMetaManualRun) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -349,6 +360,12 @@ func (m *StoreTaskMeta) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintMeta(dAtA, i, uint64(m.Delay))
 	}
+	if len(m.CatchUp) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintMeta(dAtA, i, uint64(len(m.CatchUp)))
+		i += copy(dAtA[i:], m.CatchUp)
+	}
 	if len(m.ManualRuns) > 0 {
 		for _, msg := range m.ManualRuns {
 			dAtA[i] = 0x82
@@ -487,6 +504,10 @@ func (m *StoreTaskMeta) Size() (n int) {
 	if m.Delay != 0 {
 		n += 1 + sovMeta(uint64(m.Delay))
 	}
+	l = len(m.CatchUp)
+	if l > 0 {
+		n += 1 + l + sovMeta(uint64(l))
+	}
 	if len(m.ManualRuns) > 0 {
 		for _, e := range m.ManualRuns {
 			l = e.Size()
@@ -726,6 +747,35 @@ func (m *StoreTaskMeta) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CatchUp", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMeta
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CatchUp = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 16:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ManualRuns", wireType)
@@ -1173,38 +1223,39 @@ var (
 	ErrIntOverflowMeta   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("meta.proto", fileDescriptor_meta_9d9298f22bde0681) }
+func init() { proto.RegisterFile("meta.proto", fileDescriptor_meta_98982d89ee1c958d) }
 
-var fileDescriptor_meta_9d9298f22bde0681 = []byte{
-	// 468 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x92, 0x41, 0x6e, 0xd3, 0x40,
-	0x14, 0x86, 0x71, 0x1d, 0x07, 0xf2, 0x42, 0x5a, 0x33, 0xaa, 0x2a, 0x03, 0x52, 0x6a, 0x22, 0x10,
-	0x61, 0x63, 0x24, 0x90, 0x58, 0xb1, 0xa1, 0x81, 0x45, 0x17, 0xdd, 0x4c, 0x59, 0x21, 0x21, 0x6b,
-	0x6a, 0x4f, 0xa2, 0x28, 0xf6, 0x9b, 0x32, 0xf3, 0x06, 0x92, 0x4b, 0x20, 0xae, 0xc3, 0x0d, 0x58,
-	0x72, 0x02, 0x84, 0xc2, 0x35, 0x58, 0xa0, 0x99, 0x69, 0x83, 0x28, 0x59, 0x20, 0x76, 0x6f, 0x3e,
-	0x79, 0xfe, 0xf9, 0xff, 0xdf, 0x0f, 0xa0, 0x95, 0x24, 0x8a, 0x73, 0xad, 0x48, 0xb1, 0xfb, 0x95,
-	0x6a, 0x8b, 0x39, 0x4e, 0x1b, 0xbb, 0xac, 0x85, 0xa3, 0x8d, 0xa0, 0xa9, 0xd2, 0x6d, 0x41, 0xc2,
-	0x2c, 0x8a, 0x33, 0x51, 0x2d, 0x24, 0xd6, 0x77, 0xf6, 0x67, 0x6a, 0xa6, 0xfc, 0x85, 0xc7, 0x6e,
-	0x0a, 0x77, 0x47, 0x3f, 0x77, 0x60, 0x70, 0x4a, 0x4a, 0xcb, 0xd7, 0xc2, 0x2c, 0x4e, 0x24, 0x09,
-	0xf6, 0x10, 0xf6, 0x5a, 0xb1, 0x2c, 0x2b, 0x85, 0x95, 0xd5, 0x5a, 0x62, 0xb5, 0xca, 0xa2, 0x3c,
-	0x1a, 0x27, 0x7c, 0xb7, 0x15, 0xcb, 0xc9, 0x6f, 0xca, 0x1e, 0x41, 0xda, 0x08, 0x92, 0x86, 0xca,
-	0x4a, 0xb5, 0xe7, 0x8d, 0x24, 0x59, 0x67, 0x3b, 0x79, 0x34, 0x8e, 0xf9, 0x5e, 0xe0, 0x93, 0x4b,
-	0xcc, 0x0e, 0xa0, 0x6b, 0x48, 0x90, 0x35, 0x59, 0x9c, 0x47, 0xe3, 0x1e, 0xbf, 0x38, 0xb1, 0x0a,
-	0x6e, 0x05, 0x39, 0x6a, 0x56, 0xa5, 0xb6, 0x88, 0x73, 0x9c, 0x65, 0x9d, 0x3c, 0x1e, 0xf7, 0x9f,
-	0x3c, 0x2b, 0xfe, 0x25, 0x55, 0xf1, 0x87, 0x77, 0x6e, 0x91, 0xa7, 0x1b, 0x41, 0x1e, 0xf4, 0xd8,
-	0x03, 0xd8, 0x95, 0xd3, 0xa9, 0xac, 0x68, 0xfe, 0x5e, 0x96, 0x95, 0x56, 0x98, 0x25, 0xde, 0xc4,
-	0x60, 0x43, 0x27, 0x5a, 0x21, 0xdb, 0x87, 0xa4, 0x96, 0x8d, 0x58, 0x65, 0x5d, 0x9f, 0x36, 0x1c,
-	0xd8, 0x5b, 0xe8, 0xb7, 0x02, 0xad, 0x68, 0x9c, 0x3d, 0x93, 0xa5, 0xde, 0xdb, 0xf3, 0xff, 0xf0,
-	0x76, 0xe2, 0x55, 0x9c, 0x43, 0x68, 0x2f, 0x47, 0x33, 0xfa, 0x1c, 0x41, 0x7a, 0x35, 0x02, 0x4b,
-	0x21, 0x46, 0xf5, 0xc1, 0xb7, 0x1e, 0x73, 0x37, 0x3a, 0x42, 0x7a, 0xe5, 0xdb, 0x1d, 0x70, 0x37,
-	0xb2, 0x1c, 0xba, 0xda, 0x62, 0x39, 0xaf, 0x7d, 0xa3, 0x9d, 0xa3, 0xde, 0xfa, 0xdb, 0x61, 0xc2,
-	0x2d, 0x1e, 0xbf, 0xe4, 0x89, 0xb6, 0x78, 0x5c, 0xb3, 0x43, 0xe8, 0x6b, 0x81, 0x33, 0x59, 0x1a,
-	0x12, 0x9a, 0xb2, 0x8e, 0x57, 0x03, 0x8f, 0x4e, 0x1d, 0x61, 0x77, 0xa1, 0x17, 0x3e, 0x90, 0x58,
-	0xfb, 0x4a, 0x62, 0x7e, 0xc3, 0x83, 0x57, 0x58, 0xb3, 0x7b, 0x70, 0x53, 0xcb, 0x77, 0x56, 0x1a,
-	0x92, 0x75, 0x29, 0xc8, 0x97, 0x12, 0xf3, 0xfe, 0x86, 0xbd, 0xa0, 0xd1, 0xc7, 0x08, 0x0e, 0xb6,
-	0x47, 0x74, 0x5d, 0x86, 0x57, 0x43, 0x86, 0x70, 0x70, 0x29, 0xdc, 0x53, 0x61, 0x47, 0xdc, 0xb8,
-	0x75, 0x85, 0xe2, 0xed, 0x2b, 0x74, 0xd5, 0x50, 0xe7, 0x2f, 0x43, 0x47, 0xb7, 0xbf, 0xac, 0x87,
-	0xd1, 0xd7, 0xf5, 0x30, 0xfa, 0xbe, 0x1e, 0x46, 0x9f, 0x7e, 0x0c, 0xaf, 0xbd, 0xb9, 0x7e, 0xf1,
-	0x2b, 0xce, 0xba, 0x7e, 0xdb, 0x9f, 0xfe, 0x0a, 0x00, 0x00, 0xff, 0xff, 0x88, 0x78, 0xfd, 0xdc,
-	0x37, 0x03, 0x00, 0x00,
+var fileDescriptor_meta_98982d89ee1c958d = []byte{
+	// 483 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0xc1, 0x6e, 0x13, 0x31,
+	0x10, 0x86, 0x59, 0x36, 0x9b, 0x34, 0x13, 0xd2, 0x2e, 0x56, 0x55, 0x6d, 0x41, 0x4a, 0x97, 0x08,
+	0x44, 0xb8, 0x2c, 0x12, 0x48, 0x9c, 0xb8, 0xd0, 0xc0, 0xa1, 0x87, 0x5e, 0x5c, 0xb8, 0x20, 0xa1,
+	0x95, 0xeb, 0x75, 0x42, 0x94, 0x5d, 0x3b, 0xd8, 0x63, 0x48, 0x5e, 0x02, 0x71, 0xe3, 0x59, 0x78,
+	0x03, 0x8e, 0x3c, 0x01, 0x42, 0xe1, 0x45, 0x90, 0xed, 0x36, 0x88, 0x92, 0x03, 0xe2, 0x36, 0xf3,
+	0x69, 0x3d, 0xfe, 0xff, 0xdf, 0xb3, 0x00, 0x8d, 0x40, 0x56, 0x2c, 0xb4, 0x42, 0x45, 0xee, 0x72,
+	0xd5, 0x14, 0x33, 0x39, 0xa9, 0xed, 0xb2, 0x62, 0x8e, 0xd6, 0x0c, 0x27, 0x4a, 0x37, 0x05, 0x32,
+	0x33, 0x2f, 0xce, 0x19, 0x9f, 0x0b, 0x59, 0xdd, 0xda, 0x9f, 0xaa, 0xa9, 0xf2, 0x07, 0x1e, 0xba,
+	0x2a, 0x9c, 0x1d, 0x7e, 0x8e, 0xa1, 0x7f, 0x86, 0x4a, 0x8b, 0x97, 0xcc, 0xcc, 0x4f, 0x05, 0x32,
+	0x72, 0x1f, 0xf6, 0x1a, 0xb6, 0x2c, 0xb9, 0x92, 0xdc, 0x6a, 0x2d, 0x24, 0x5f, 0x65, 0x51, 0x1e,
+	0x8d, 0x12, 0xba, 0xdb, 0xb0, 0xe5, 0xf8, 0x37, 0x25, 0x0f, 0x20, 0xad, 0x19, 0x0a, 0x83, 0x25,
+	0x57, 0xcd, 0xa2, 0x16, 0x28, 0xaa, 0xec, 0x7a, 0x1e, 0x8d, 0x62, 0xba, 0x17, 0xf8, 0xf8, 0x12,
+	0x93, 0x03, 0x68, 0x1b, 0x64, 0x68, 0x4d, 0x16, 0xe7, 0xd1, 0xa8, 0x4b, 0x2f, 0x3a, 0xc2, 0xe1,
+	0x66, 0x18, 0x87, 0xf5, 0xaa, 0xd4, 0x56, 0xca, 0x99, 0x9c, 0x66, 0xad, 0x3c, 0x1e, 0xf5, 0x1e,
+	0x3d, 0x29, 0xfe, 0xc5, 0x55, 0xf1, 0x87, 0x76, 0x6a, 0x25, 0x4d, 0x37, 0x03, 0x69, 0x98, 0x47,
+	0xee, 0xc1, 0xae, 0x98, 0x4c, 0x04, 0xc7, 0xd9, 0x7b, 0x51, 0x72, 0xad, 0x64, 0x96, 0x78, 0x11,
+	0xfd, 0x0d, 0x1d, 0x6b, 0x25, 0xc9, 0x3e, 0x24, 0x95, 0xa8, 0xd9, 0x2a, 0x6b, 0x7b, 0xb7, 0xa1,
+	0x21, 0x87, 0xb0, 0xc3, 0x19, 0xf2, 0xb7, 0xa5, 0x5d, 0x64, 0x1d, 0x7f, 0xac, 0xe3, 0xfb, 0x57,
+	0x0b, 0xf2, 0x06, 0x7a, 0x0d, 0x93, 0x96, 0xd5, 0x4e, 0xb9, 0xc9, 0x52, 0x2f, 0xfb, 0xe9, 0x7f,
+	0xc8, 0x3e, 0xf5, 0x53, 0x9c, 0x78, 0x68, 0x2e, 0x4b, 0x33, 0xfc, 0x12, 0x41, 0x7a, 0xd5, 0x1d,
+	0x49, 0x21, 0x96, 0xea, 0x83, 0x7f, 0x90, 0x98, 0xba, 0xd2, 0x11, 0xd4, 0x2b, 0x1f, 0x7c, 0x9f,
+	0xba, 0x92, 0xe4, 0xd0, 0xd6, 0x56, 0x96, 0xb3, 0xca, 0x87, 0xdd, 0x3a, 0xee, 0xae, 0xbf, 0x1f,
+	0x25, 0xd4, 0xca, 0x93, 0xe7, 0x34, 0xd1, 0x56, 0x9e, 0x54, 0xe4, 0x08, 0x7a, 0x9a, 0xc9, 0xa9,
+	0x28, 0x0d, 0x32, 0x8d, 0x59, 0xcb, 0x4f, 0x03, 0x8f, 0xce, 0x1c, 0x21, 0xb7, 0xa1, 0x1b, 0x3e,
+	0x10, 0xb2, 0xf2, 0x69, 0xc5, 0x74, 0xc7, 0x83, 0x17, 0xb2, 0x22, 0x77, 0xe0, 0x86, 0x16, 0xef,
+	0xac, 0x30, 0x28, 0xaa, 0x92, 0xa1, 0xcf, 0x2b, 0xa6, 0xbd, 0x0d, 0x7b, 0x86, 0xc3, 0x8f, 0x11,
+	0x1c, 0x6c, 0xb7, 0xe8, 0x62, 0x0e, 0xb7, 0x06, 0x0f, 0xa1, 0x71, 0x2e, 0xdc, 0x55, 0x61, 0x7d,
+	0x5c, 0xb9, 0x75, 0xbb, 0xe2, 0xed, 0xdb, 0x75, 0x55, 0x50, 0xeb, 0x2f, 0x41, 0xc7, 0x87, 0x5f,
+	0xd7, 0x83, 0xe8, 0xdb, 0x7a, 0x10, 0xfd, 0x58, 0x0f, 0xa2, 0x4f, 0x3f, 0x07, 0xd7, 0x5e, 0x77,
+	0x2e, 0x9e, 0xe2, 0xbc, 0xed, 0x7f, 0x84, 0xc7, 0xbf, 0x06, 0x00, 0x82, 0x61, 0x91, 0x23, 0x52,
+	0x03, 0x00, 0x00,
 }
@@ -2,6 +2,7 @@ package backend_test
 
 import (
 	"errors"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -213,6 +214,135 @@ func TestMeta_CreateNextRun_Delay(t *testing.T) {
 	}
 }
 
+func TestMeta_CreateNextRun_CatchUp(t *testing.T) {
+	t.Run("latest", func(t *testing.T) {
+		stm := backend.StoreTaskMeta{
+			MaxConcurrency:  2,
+			Status:          "enabled",
+			EffectiveCron:   "* * * * *", // Every minute.
+			Delay:           5,
+			LatestCompleted: 60,
+			CatchUp:         string(backend.CatchUpLatest),
+		}
+
+		// Schedules 120 through 240 are due; only the latest is run.
+		rc, err := stm.CreateNextRun(250, makeID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rc.Created.Now != 240 {
+			t.Fatalf("expected created run to have time 240, got %d", rc.Created.Now)
+		}
+		if rc.NextDue != 305 {
+			t.Fatalf("unexpected next run time: %d", rc.NextDue)
+		}
+
+		// A single due schedule is run as usual.
+		rc, err = stm.CreateNextRun(305, makeID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rc.Created.Now != 300 {
+			t.Fatalf("expected created run to have time 300, got %d", rc.Created.Now)
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		stm := backend.StoreTaskMeta{
+			MaxConcurrency:  2,
+			Status:          "enabled",
+			EffectiveCron:   "* * * * *", // Every minute.
+			LatestCompleted: 60,
+			CatchUp:         string(backend.CatchUpSkip),
+		}
+
+		// Schedules 120 through 240 are due; none of them are run.
+		_, err := stm.CreateNextRun(250, makeID)
+		if e, ok := err.(backend.RunNotYetDueError); !ok {
+			t.Fatalf("expected RunNotYetDueError, got %v (%T)", err, err)
+		} else if e.DueAt != 300 {
+			t.Fatalf("expected run due at 300, got %d", e.DueAt)
+		}
+		if stm.LatestCompleted != 240 {
+			t.Fatalf("expected skipped schedules to advance latest completed to 240, got %d", stm.LatestCompleted)
+		}
+
+		// A single due schedule is run as usual.
+		rc, err := stm.CreateNextRun(300, makeID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rc.Created.Now != 300 {
+			t.Fatalf("expected created run to have time 300, got %d", rc.Created.Now)
+		}
+
+		// Manual runs are not skipped.
+		stm = backend.StoreTaskMeta{
+			MaxConcurrency:  2,
+			Status:          "enabled",
+			EffectiveCron:   "* * * * *",
+			LatestCompleted: 60,
+			CatchUp:         string(backend.CatchUpSkip),
+		}
+		if err := stm.ManuallyRunTimeRange(0, 0, 250); err != nil {
+			t.Fatal(err)
+		}
+		rc, err = stm.CreateNextRun(250, makeID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rc.Created.Now != 0 || rc.Created.RequestedAt != 250 {
+			t.Fatalf("expected manual run at 0 requested at 250, got %+v", rc.Created)
+		}
+		if rc.NextDue != 300 {
+			t.Fatalf("unexpected next run time: %d", rc.NextDue)
+		}
+	})
+}
+
+func TestMeta_QueuedManualRuns(t *testing.T) {
+	stm := backend.StoreTaskMeta{
+		MaxConcurrency:  2,
+		Status:          "enabled",
+		EffectiveCron:   "* * * * *", // Every minute.
+		LatestCompleted: 3000,
+	}
+	if err := stm.ManuallyRunTimeRange(60, 240, 3001); err != nil {
+		t.Fatal(err)
+	}
+	if err := stm.ManuallyRunTimeRange(600, 660, 3002); err != nil {
+		t.Fatal(err)
+	}
+
+	// The run for 60 is running, so 120 is the first queued run.
+	if _, err := stm.CreateNextRun(3010, makeID); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := stm.QueuedManualRuns(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []backend.QueuedRun{
+		{Now: 120, RequestedAt: 3001},
+		{Now: 180, RequestedAt: 3001},
+		{Now: 240, RequestedAt: 3001},
+		{Now: 600, RequestedAt: 3002},
+		{Now: 660, RequestedAt: 3002},
+	}
+	if !reflect.DeepEqual(runs, want) {
+		t.Errorf("QueuedManualRuns() = %v, want %v", runs, want)
+	}
+
+	runs, err = stm.QueuedManualRuns(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(runs, want[:2]) {
+		t.Errorf("QueuedManualRuns() = %v, want %v", runs, want[:2])
+	}
+}
+
 func TestMeta_ManuallyRunTimeRange(t *testing.T) {
 	now := time.Now().Unix()
 	stm := backend.StoreTaskMeta{
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/check"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
@@ -236,6 +237,22 @@ func (s *TickScheduler) Stop() {
 	}
 }
 
+// Check reports whether the scheduler has been started and not stopped.
+func (s *TickScheduler) Check(ctx context.Context) check.Response {
+	s.schedulerMu.Lock()
+	defer s.schedulerMu.Unlock()
+
+	if s.ctx == nil {
+		return check.Error(errors.New("task scheduler has not been started"))
+	}
+	if err := s.ctx.Err(); err != nil {
+		return check.Error(errors.New("task scheduler has stopped"))
+	}
+
+	now := atomic.LoadInt64(&s.now)
+	return check.Info("%d tasks claimed, last ticked at %s", len(s.taskSchedulers), time.Unix(now, 0).UTC().Format(time.RFC3339))
+}
+
 func (s *TickScheduler) ClaimTask(task *StoreTask, meta *StoreTaskMeta) (err error) {
 	s.schedulerMu.Lock()
 	defer s.schedulerMu.Unlock()
@@ -532,6 +549,12 @@ func (r *runner) RestartRun(qr QueuedRun) bool {
 // startFromWorking attempts to create a run if one is due, and then begins execution on a separate goroutine.
 // r.state must be runnerWorking when this is called.
 func (r *runner) startFromWorking(now int64) {
+	if r.ctx.Err() != nil {
+		// The task was released. Leave its runs to the scheduler that claims it next.
+		atomic.StoreUint32(r.state, runnerIdle)
+		return
+	}
+
 	if nextDue, hasQueue := r.ts.NextDue(); now < nextDue && !hasQueue {
 		// Not ready for a new run. Go idle again.
 		atomic.StoreUint32(r.state, runnerIdle)
@@ -540,6 +563,12 @@ func (r *runner) startFromWorking(now int64) {
 
 	rc, err := r.desiredState.CreateNextRun(r.ctx, r.task.ID, now)
 	if err != nil {
+		if e, ok := err.(RunNotYetDueError); ok {
+			// No run is due, such as when the task skipped its due schedules per its catch-up policy.
+			r.ts.SetNextDue(e.DueAt, false, now)
+			atomic.StoreUint32(r.state, runnerIdle)
+			return
+		}
 		r.logger.Info("Failed to create run", zap.Error(err))
 		atomic.StoreUint32(r.state, runnerIdle)
 		return
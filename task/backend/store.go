@@ -51,6 +51,39 @@ func (s TaskStatus) validate(allowEmpty bool) error {
 	return fmt.Errorf("invalid task status: %q", s)
 }
 
+// CatchUpPolicy determines which schedules of a task are run when more than one of them is due at once,
+// such as after the task was inactive or its scheduler was down.
+type CatchUpPolicy string
+
+const (
+	// CatchUpAll runs every due schedule, in order.
+	CatchUpAll CatchUpPolicy = "all"
+	// CatchUpLatest runs only the latest due schedule.
+	CatchUpLatest CatchUpPolicy = "latest"
+	// CatchUpSkip runs none of the due schedules, and waits for the next schedule.
+	CatchUpSkip CatchUpPolicy = "skip"
+
+	DefaultCatchUpPolicy CatchUpPolicy = CatchUpAll
+)
+
+// validate returns an error if p is not a known catch-up policy.
+func (p CatchUpPolicy) validate(allowEmpty bool) error {
+	if allowEmpty && p == "" {
+		return nil
+	}
+
+	if p == CatchUpAll || p == CatchUpLatest || p == CatchUpSkip {
+		return nil
+	}
+
+	return fmt.Errorf("invalid task catch-up policy: %q", p)
+}
+
+// Valid returns an error if p is neither empty, which means DefaultCatchUpPolicy, nor a known catch-up policy.
+func (p CatchUpPolicy) Valid() error {
+	return p.validate(true)
+}
+
 type RunStatus int
 
 const (
@@ -112,6 +145,10 @@ type CreateTaskRequest struct {
 	// The initial task status.
 	// If empty, will be treated as DefaultTaskStatus.
 	Status TaskStatus
+
+	// The policy for the schedules the task misses.
+	// If empty, will be treated as DefaultCatchUpPolicy.
+	CatchUp CatchUpPolicy
 }
 
 // UpdateTaskRequest encapsulates requested changes to a task.
@@ -126,6 +163,10 @@ type UpdateTaskRequest struct {
 	// The new desired task status.
 	// If empty, do not modify the existing status.
 	Status TaskStatus
+
+	// The new catch-up policy of the task.
+	// If empty, do not modify the existing policy.
+	CatchUp CatchUpPolicy
 }
 
 // UpdateTaskResult describes the result of modifying a single task.
@@ -160,6 +201,10 @@ type Store interface {
 	// FindTaskMetaByID returns the metadata about a task.
 	FindTaskMetaByID(ctx context.Context, id platform.ID) (*StoreTaskMeta, error)
 
+	// FindTaskMetasByIDs returns the metadata about the tasks with the given IDs, read at once.
+	// The returned metadata are in the order of ids, and are nil for IDs that match no task.
+	FindTaskMetasByIDs(ctx context.Context, ids []platform.ID) ([]*StoreTaskMeta, error)
+
 	// FindTaskByIDWithMeta combines finding the task and the meta into a single call.
 	FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*StoreTask, *StoreTaskMeta, error)
 
@@ -171,7 +216,9 @@ type Store interface {
 
 	// CreateNextRun creates the earliest needed run scheduled no later than the given Unix timestamp now.
 	// Internally, the Store should rely on the underlying task's StoreTaskMeta to create the next run.
-	CreateNextRun(ctx context.Context, taskID platform.ID, now int64) (RunCreation, error)
+	// If owner is not empty and another owner holds a lease of the task that has not expired by now,
+	// ErrTaskLeased is returned; the Store must check the underlying TaskLease's Check method.
+	CreateNextRun(ctx context.Context, taskID platform.ID, now int64, owner string) (RunCreation, error)
 
 	// FinishRun removes runID from the list of running tasks and if its `now` is later then last completed update it.
 	FinishRun(ctx context.Context, taskID, runID platform.ID) error
@@ -181,6 +228,15 @@ type Store interface {
 	// ManuallyRunTimeRange must delegate to an underlying StoreTaskMeta's ManuallyRunTimeRange method.
 	ManuallyRunTimeRange(ctx context.Context, taskID platform.ID, start, end, requestedAt int64) error
 
+	// ClaimTaskLease grants owner the lease of the task with the given ID until the Unix timestamp expiresAt,
+	// or renews the lease if owner already holds it.
+	// If another owner holds a lease that has not expired by the Unix timestamp now, ErrTaskLeased is returned.
+	// ClaimTaskLease must delegate to an underlying TaskLease's Claim method.
+	ClaimTaskLease(ctx context.Context, taskID platform.ID, owner string, now, expiresAt int64) error
+
+	// ReleaseTaskLease releases the lease of the task with the given ID, if owner holds it.
+	ReleaseTaskLease(ctx context.Context, taskID platform.ID, owner string) error
+
 	// DeleteOrg deletes the org.
 	DeleteOrg(ctx context.Context, orgID platform.ID) error
 
@@ -323,19 +379,22 @@ func (StoreValidation) CreateArgs(req CreateTaskRequest) (options.Options, error
 	if err := req.Status.validate(true); err != nil {
 		return o, err
 	}
+	if err := req.CatchUp.validate(true); err != nil {
+		return o, err
+	}
 
 	return o, nil
 }
 
 // UpdateArgs validates the UpdateTaskRequest.
-// If the update only includes a new status (i.e. req.Script is empty), the returned options are zero.
-// If the update contains neither a new script nor a new status, or if the script is invalid, an error is returned.
+// If the update does not include a new script (i.e. req.Script is empty), the returned options are zero.
+// If the update contains neither a new script, status nor catch-up policy, or if the script is invalid, an error is returned.
 func (StoreValidation) UpdateArgs(req UpdateTaskRequest) (options.Options, error) {
 	var missing []string
 	var o options.Options
 
-	if req.Script == "" && req.Status == "" {
-		missing = append(missing, "script or status")
+	if req.Script == "" && req.Status == "" && req.CatchUp == "" {
+		missing = append(missing, "script, status or catch-up policy")
 	} else {
 		if req.Script != "" {
 			var err error
@@ -347,6 +406,9 @@ func (StoreValidation) UpdateArgs(req UpdateTaskRequest) (options.Options, error
 		if err := req.Status.validate(true); err != nil {
 			return o, err
 		}
+		if err := req.CatchUp.validate(true); err != nil {
+			return o, err
+		}
 	}
 
 	if !req.ID.Valid() {
@@ -33,6 +33,8 @@ func NewStoreTest(name string, cf CreateStoreFunc, df DestroyStoreFunc, funcName
 			"CreateNextRun",
 			"FinishRun",
 			"ManuallyRunTimeRange",
+			"TaskLease",
+			"LeasedRun",
 		}
 	}
 	availableFuncs := map[string]TestFunc{
@@ -46,6 +48,8 @@ func NewStoreTest(name string, cf CreateStoreFunc, df DestroyStoreFunc, funcName
 		"CreateNextRun":        testStoreCreateNextRun,
 		"FinishRun":            testStoreFinishRun,
 		"ManuallyRunTimeRange": testStoreManuallyRunTimeRange,
+		"TaskLease":            testStoreTaskLease,
+		"LeasedRun":            testStoreLeasedRun,
 		"DeleteOrg":            testStoreDeleteOrg,
 		"DeleteUser":           testStoreDeleteUser,
 	}
@@ -223,6 +227,31 @@ from(bucket:"y") |> range(start:-1h)`
 		if meta.Status != string(backend.TaskInactive) {
 			t.Fatalf("expected task status to be inactive, got %q", meta.Status)
 		}
+
+		// Modify just the catch-up policy.
+		res, err = s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, CatchUp: backend.CatchUpSkip})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		task = res.NewTask
+		meta = res.NewMeta
+		if task.Script != script2 {
+			t.Fatalf("Task script unexpectedly updated: %s", task.Script)
+		}
+		if meta.Status != string(backend.TaskInactive) {
+			t.Fatalf("Task status unexpectedly updated: %q", meta.Status)
+		}
+		if meta.CatchUp != string(backend.CatchUpSkip) {
+			t.Fatalf("expected task catch-up policy to be skip, got %q", meta.CatchUp)
+		}
+		stored, err := s.FindTaskMetaByID(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.CatchUp != string(backend.CatchUpSkip) {
+			t.Fatalf("expected stored catch-up policy to be skip, got %q", stored.CatchUp)
+		}
 	})
 
 	for _, args := range []struct {
@@ -232,6 +261,7 @@ from(bucket:"y") |> range(start:-1h)`
 		{caseName: "missing id", req: backend.UpdateTaskRequest{Script: script}},
 		{caseName: "not found", req: backend.UpdateTaskRequest{ID: platform.ID(7123), Script: script}},
 		{caseName: "missing script and status", req: backend.UpdateTaskRequest{ID: platform.ID(1)}},
+		{caseName: "invalid catch-up policy", req: backend.UpdateTaskRequest{ID: platform.ID(1), CatchUp: "bogus"}},
 		{caseName: "missing name", req: backend.UpdateTaskRequest{ID: platform.ID(1), Script: scriptNoName}},
 	} {
 		t.Run(args.caseName, func(t *testing.T) {
@@ -528,6 +558,10 @@ from(bucket:"test") |> range(start:-1h)`
 			t.Fatalf("unexpected status: got %v, exp %v", meta.Status, backend.DefaultTaskStatus)
 		}
 
+		if meta.CatchUp != string(backend.DefaultCatchUpPolicy) {
+			t.Fatalf("unexpected catch-up policy: got %v, exp %v", meta.CatchUp, backend.DefaultCatchUpPolicy)
+		}
+
 		badID := platform.ID(0)
 		meta, err = s.FindTaskMetaByID(context.Background(), badID)
 		if err == nil {
@@ -537,12 +571,12 @@ from(bucket:"test") |> range(start:-1h)`
 			t.Fatalf("expected nil meta when finding nonexistent ID, got %#v", meta)
 		}
 
-		rc, err := s.CreateNextRun(context.Background(), id, 6065)
+		rc, err := s.CreateNextRun(context.Background(), id, 6065, "")
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		_, err = s.CreateNextRun(context.Background(), id, 6125)
+		_, err = s.CreateNextRun(context.Background(), id, 6125, "")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -586,6 +620,64 @@ from(bucket:"test") |> range(start:-1h)`
 			}
 		}
 	})
+
+	t.Run("explicit catch-up policy", func(t *testing.T) {
+		s := create(t)
+		defer destroy(t, s)
+
+		for _, cu := range []backend.CatchUpPolicy{backend.CatchUpAll, backend.CatchUpLatest, backend.CatchUpSkip} {
+			id, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script, CatchUp: cu})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			meta, err := s.FindTaskMetaByID(context.Background(), id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if meta.CatchUp != string(cu) {
+				t.Fatalf("got catch-up policy %v, exp %v", meta.CatchUp, cu)
+			}
+		}
+
+		if _, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script, CatchUp: "bogus"}); err == nil {
+			t.Fatal("expected error creating task with invalid catch-up policy")
+		}
+	})
+
+	t.Run("multiple tasks", func(t *testing.T) {
+		s := create(t)
+		defer destroy(t, s)
+
+		var ids []platform.ID
+		for _, st := range []backend.TaskStatus{backend.TaskActive, backend.TaskInactive} {
+			id, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script, Status: st})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, id)
+		}
+		ids = append(ids, platform.ID(0xdeadbeef))
+
+		metas, err := s.FindTaskMetasByIDs(context.Background(), ids)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(metas) != len(ids) {
+			t.Fatalf("expected %d metas, got %d", len(ids), len(metas))
+		}
+
+		if metas[0] == nil || metas[0].Status != string(backend.TaskActive) {
+			t.Fatalf("expected meta of active task, got %#v", metas[0])
+		}
+		if metas[1] == nil || metas[1].Status != string(backend.TaskInactive) {
+			t.Fatalf("expected meta of inactive task, got %#v", metas[1])
+		}
+		if metas[2] != nil {
+			t.Fatalf("expected nil meta when finding nonexistent ID, got %#v", metas[2])
+		}
+	})
 }
 
 func testStoreFindByIDWithMeta(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
@@ -733,18 +825,18 @@ from(bucket:"test") |> range(start:-1h)`
 
 		badID := uint64(taskID)
 		badID++
-		if _, err := s.CreateNextRun(context.Background(), platform.ID(badID), 999); err == nil {
+		if _, err := s.CreateNextRun(context.Background(), platform.ID(badID), 999, ""); err == nil {
 			t.Fatal("expected error for CreateNextRun with bad ID, got none")
 		}
 
-		_, err = s.CreateNextRun(context.Background(), taskID, 64)
+		_, err = s.CreateNextRun(context.Background(), taskID, 64, "")
 		if e, ok := err.(backend.RunNotYetDueError); !ok {
 			t.Fatalf("expected RunNotYetDueError, got %v (%T)", err, err)
 		} else if e.DueAt != 65 {
 			t.Fatalf("expected run due at 65, got %d", e.DueAt)
 		}
 
-		rc, err := s.CreateNextRun(context.Background(), taskID, 65)
+		rc, err := s.CreateNextRun(context.Background(), taskID, 65, "")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -759,7 +851,7 @@ from(bucket:"test") |> range(start:-1h)`
 			t.Fatalf("unexpected next due time: %d", rc.NextDue)
 		}
 
-		rc, err = s.CreateNextRun(context.Background(), taskID, 125)
+		rc, err = s.CreateNextRun(context.Background(), taskID, 125, "")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -799,7 +891,7 @@ from(bucket:"test") |> range(start:-1h)`
 			t.Fatal(err)
 		}
 
-		rc, err := s.CreateNextRun(context.Background(), taskID, 3005)
+		rc, err := s.CreateNextRun(context.Background(), taskID, 3005, "")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -815,7 +907,7 @@ from(bucket:"test") |> range(start:-1h)`
 		}
 
 		// Queue: 0
-		rc, err = s.CreateNextRun(context.Background(), taskID, 3005)
+		rc, err = s.CreateNextRun(context.Background(), taskID, 3005, "")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -831,7 +923,7 @@ from(bucket:"test") |> range(start:-1h)`
 		}
 
 		// Queue: 60
-		rc, err = s.CreateNextRun(context.Background(), taskID, 3005)
+		rc, err = s.CreateNextRun(context.Background(), taskID, 3005, "")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -847,7 +939,7 @@ from(bucket:"test") |> range(start:-1h)`
 		}
 
 		// Queue: 180
-		rc, err = s.CreateNextRun(context.Background(), taskID, 3005)
+		rc, err = s.CreateNextRun(context.Background(), taskID, 3005, "")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -862,6 +954,37 @@ from(bucket:"test") |> range(start:-1h)`
 			t.Fatal("expected run to have empty queue but it didn't")
 		}
 	})
+
+	t.Run("skipping schedules", func(t *testing.T) {
+		taskID, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script, ScheduleAfter: 60, CatchUp: backend.CatchUpSkip})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Schedules 120 through 240 are due and skipped.
+		_, err = s.CreateNextRun(context.Background(), taskID, 250, "")
+		if e, ok := err.(backend.RunNotYetDueError); !ok {
+			t.Fatalf("expected RunNotYetDueError, got %v (%T)", err, err)
+		} else if e.DueAt != 305 {
+			t.Fatalf("expected run due at 305, got %d", e.DueAt)
+		}
+
+		meta, err := s.FindTaskMetaByID(context.Background(), taskID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.LatestCompleted != 240 {
+			t.Fatalf("expected skipped schedules to be stored as completed through 240, got %d", meta.LatestCompleted)
+		}
+
+		rc, err := s.CreateNextRun(context.Background(), taskID, 305, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rc.Created.Now != 300 {
+			t.Fatalf("unexpected time for created run: %d", rc.Created.Now)
+		}
+	})
 }
 
 func testStoreFinishRun(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
@@ -879,7 +1002,7 @@ from(bucket:"test") |> range(start:-1h)`
 		t.Fatal(err)
 	}
 
-	rc, err := s.CreateNextRun(context.Background(), task, 60)
+	rc, err := s.CreateNextRun(context.Background(), task, 60, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -920,7 +1043,7 @@ from(bucket:"test") |> range(start:-1h)`
 		t.Fatalf("expected 1 manual run to be created, got %d", len(meta.ManualRuns))
 	}
 
-	rc, err := s.CreateNextRun(context.Background(), taskID, 9999)
+	rc, err := s.CreateNextRun(context.Background(), taskID, 9999, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -930,6 +1053,117 @@ from(bucket:"test") |> range(start:-1h)`
 	}
 }
 
+func testStoreTaskLease(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	ctx := context.Background()
+	taskID, err := s.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.ClaimTaskLease(ctx, taskID, "a", 100, 160); err != nil {
+		t.Fatal(err)
+	}
+	// The owner can renew the lease.
+	if err := s.ClaimTaskLease(ctx, taskID, "a", 120, 180); err != nil {
+		t.Fatal(err)
+	}
+	// Others can't claim it until it expires.
+	if err := s.ClaimTaskLease(ctx, taskID, "b", 170, 230); err != backend.ErrTaskLeased {
+		t.Fatalf("expected %v, got %v", backend.ErrTaskLeased, err)
+	}
+	if err := s.ClaimTaskLease(ctx, taskID, "b", 180, 240); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ClaimTaskLease(ctx, taskID, "a", 190, 250); err != backend.ErrTaskLeased {
+		t.Fatalf("expected %v, got %v", backend.ErrTaskLeased, err)
+	}
+
+	// Releasing the lease of another owner has no effect.
+	if err := s.ReleaseTaskLease(ctx, taskID, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ClaimTaskLease(ctx, taskID, "a", 190, 250); err != backend.ErrTaskLeased {
+		t.Fatalf("expected %v, got %v", backend.ErrTaskLeased, err)
+	}
+
+	// Once released, anyone can claim it.
+	if err := s.ReleaseTaskLease(ctx, taskID, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ClaimTaskLease(ctx, taskID, "a", 190, 250); err != nil {
+		t.Fatal(err)
+	}
+
+	// Deleted tasks can't be leased.
+	if _, err := s.DeleteTask(ctx, taskID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ClaimTaskLease(ctx, taskID, "b", 300, 360); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected %v, got %v", backend.ErrTaskNotFound, err)
+	}
+}
+
+func testStoreLeasedRun(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+		concurrency: 4,
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	ctx := context.Background()
+	taskID, err := s.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script, ScheduleAfter: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a lease, any owner can create runs.
+	if _, err := s.CreateNextRun(ctx, taskID, 60, "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.ClaimTaskLease(ctx, taskID, "b", 100, 160); err != nil {
+		t.Fatal(err)
+	}
+	// Only the owner of an unexpired lease can create runs.
+	if _, err := s.CreateNextRun(ctx, taskID, 120, "a"); err != backend.ErrTaskLeased {
+		t.Fatalf("expected %v, got %v", backend.ErrTaskLeased, err)
+	}
+	rc, err := s.CreateNextRun(ctx, taskID, 120, "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rc.Created.Now != 120 {
+		t.Fatalf("expected run at 120, got %d", rc.Created.Now)
+	}
+
+	// Runs created without an owner ignore the lease.
+	if _, err := s.CreateNextRun(ctx, taskID, 180, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Once the lease expires, another owner can create runs again.
+	rc, err = s.CreateNextRun(ctx, taskID, 240, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rc.Created.Now != 240 {
+		t.Fatalf("expected run at 240, got %d", rc.Created.Now)
+	}
+}
+
 func testStoreDeleteUser(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
 	s := create(t)
 	defer destroy(t, s)
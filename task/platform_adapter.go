@@ -3,6 +3,7 @@ package task
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/influxdata/platform"
@@ -72,9 +73,25 @@ func (p pAdapter) CreateTask(ctx context.Context, t *platform.Task) error {
 		return err
 	}
 
-	// TODO(mr): decide whether we allow user to configure scheduleAfter. https://github.com/influxdata/platform/issues/595
 	scheduleAfter := time.Now().Unix()
-	req := backend.CreateTaskRequest{Org: t.Organization, User: t.Owner.ID, Script: t.Flux, ScheduleAfter: scheduleAfter}
+	if t.ScheduleAfter != "" {
+		sa, err := time.Parse(time.RFC3339, t.ScheduleAfter)
+		if err != nil {
+			return &platform.Error{Code: platform.EInvalid, Err: fmt.Errorf("invalid scheduleAfter: %v", err)}
+		}
+		scheduleAfter = sa.Unix()
+	}
+	if err := backend.CatchUpPolicy(t.CatchUp).Valid(); err != nil {
+		return &platform.Error{Code: platform.EInvalid, Err: err}
+	}
+
+	req := backend.CreateTaskRequest{
+		Org:           t.Organization,
+		User:          t.Owner.ID,
+		Script:        t.Flux,
+		ScheduleAfter: scheduleAfter,
+		CatchUp:       backend.CatchUpPolicy(t.CatchUp),
+	}
 	id, err := p.s.CreateTask(ctx, req)
 	if err != nil {
 		return err
@@ -82,12 +99,15 @@ func (p pAdapter) CreateTask(ctx context.Context, t *platform.Task) error {
 	t.ID = id
 	t.Every = opts.Every.String()
 	t.Cron = opts.Cron
+	if t.CatchUp == "" {
+		t.CatchUp = string(backend.DefaultCatchUpPolicy)
+	}
 
 	return nil
 }
 
 func (p pAdapter) UpdateTask(ctx context.Context, id platform.ID, upd platform.TaskUpdate) (*platform.Task, error) {
-	if upd.Flux == nil && upd.Status == nil {
+	if upd.Flux == nil && upd.Status == nil && upd.CatchUp == nil {
 		return nil, errors.New("cannot update task without content")
 	}
 
@@ -98,6 +118,12 @@ func (p pAdapter) UpdateTask(ctx context.Context, id platform.ID, upd platform.T
 	if upd.Status != nil {
 		req.Status = backend.TaskStatus(*upd.Status)
 	}
+	if upd.CatchUp != nil {
+		req.CatchUp = backend.CatchUpPolicy(*upd.CatchUp)
+		if err := req.CatchUp.Valid(); err != nil {
+			return nil, &platform.Error{Code: platform.EInvalid, Err: err}
+		}
+	}
 	res, err := p.s.UpdateTask(ctx, req)
 	if err != nil {
 		return nil, err
@@ -109,13 +135,14 @@ func (p pAdapter) UpdateTask(ctx context.Context, id platform.ID, upd platform.T
 	}
 
 	task := &platform.Task{
-		ID:     id,
-		Name:   opts.Name,
-		Status: res.NewMeta.Status,
-		Owner:  platform.User{},
-		Flux:   res.NewTask.Script,
-		Every:  opts.Every.String(),
-		Cron:   opts.Cron,
+		ID:      id,
+		Name:    opts.Name,
+		Status:  res.NewMeta.Status,
+		Owner:   platform.User{},
+		Flux:    res.NewTask.Script,
+		Every:   opts.Every.String(),
+		Cron:    opts.Cron,
+		CatchUp: res.NewMeta.CatchUp,
 	}
 
 	return task, nil
@@ -136,17 +163,111 @@ func (p pAdapter) FindLogs(ctx context.Context, filter platform.LogFilter) ([]*p
 	return logPointers, len(logs), err
 }
 
+// defaultRunLimit is the number of runs FindRuns returns when the filter has no limit.
+const defaultRunLimit = 100
+
+// FindRuns returns the runs of the task, followed by the runs its backfills
+// have queued with a status of scheduled.
 func (p pAdapter) FindRuns(ctx context.Context, filter platform.RunFilter) ([]*platform.Run, int, error) {
 	runs, err := p.r.ListRuns(ctx, filter)
-	return runs, len(runs), err
+	if err != nil && err != backend.ErrRunNotFound {
+		return nil, 0, err
+	}
+	if filter.Task == nil {
+		return runs, len(runs), err
+	}
+
+	meta, merr := p.s.FindTaskMetaByID(ctx, *filter.Task)
+	if merr != nil {
+		return nil, 0, merr
+	}
+
+	limit := filter.Limit
+	if limit == 0 {
+		limit = defaultRunLimit
+	}
+	queued, qerr := meta.QueuedManualRuns(limit - len(runs))
+	if qerr != nil {
+		return nil, 0, qerr
+	}
+	for _, qr := range queued {
+		scheduledFor := time.Unix(qr.Now, 0).UTC().Format(time.RFC3339)
+		if (filter.AfterTime != "" && scheduledFor <= filter.AfterTime) || (filter.BeforeTime != "" && scheduledFor > filter.BeforeTime) {
+			continue
+		}
+		runs = append(runs, &platform.Run{
+			TaskID:       *filter.Task,
+			Status:       "scheduled",
+			ScheduledFor: scheduledFor,
+			RequestedAt:  time.Unix(qr.RequestedAt, 0).UTC().Format(time.RFC3339),
+		})
+	}
+	if len(runs) == 0 {
+		return nil, 0, err
+	}
+	return runs, len(runs), nil
 }
 
 func (p pAdapter) FindRunByID(ctx context.Context, orgID, id platform.ID) (*platform.Run, error) {
 	return p.r.FindRunByID(ctx, orgID, id)
 }
 
-func (p pAdapter) RetryRun(ctx context.Context, id platform.ID) (*platform.Run, error) {
-	return nil, errors.New("not yet implemented")
+// RetryRun queues a run of the task at the time the finished run was scheduled for.
+// The returned run has no ID, until the scheduler creates it.
+func (p pAdapter) RetryRun(ctx context.Context, taskID, runID platform.ID) (*platform.Run, error) {
+	t, err := p.s.FindTaskByID(ctx, taskID)
+	if err != nil && err != backend.ErrTaskNotFound {
+		return nil, err
+	}
+	if t == nil {
+		return nil, &platform.Error{Code: platform.ENotFound, Err: backend.ErrTaskNotFound}
+	}
+
+	run, err := p.r.FindRunByID(ctx, t.Org, runID)
+	if err != nil && err != backend.ErrRunNotFound {
+		return nil, err
+	}
+	if run == nil || run.TaskID != taskID {
+		return nil, &platform.Error{Code: platform.ENotFound, Err: fmt.Errorf("run %s not found for task %s", runID, taskID)}
+	}
+	if run.FinishedAt == "" {
+		return nil, &platform.Error{Code: platform.EInvalid, Err: errors.New("cannot retry a run that has not finished")}
+	}
+
+	scheduledFor, err := time.Parse(time.RFC3339, run.ScheduledFor)
+	if err != nil {
+		return nil, err
+	}
+	requestedAt := time.Now().UTC()
+	if err := p.s.ManuallyRunTimeRange(ctx, taskID, scheduledFor.Unix(), scheduledFor.Unix(), requestedAt.Unix()); err != nil {
+		return nil, err
+	}
+
+	return &platform.Run{
+		TaskID:       taskID,
+		Status:       "scheduled",
+		ScheduledFor: run.ScheduledFor,
+		RequestedAt:  requestedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (p pAdapter) BackfillTask(ctx context.Context, taskID platform.ID, start, end time.Time) error {
+	if end.Before(start) {
+		return &platform.Error{Code: platform.EInvalid, Err: errors.New("backfill must not end before it starts")}
+	}
+	now := time.Now()
+	if end.After(now) {
+		return &platform.Error{Code: platform.EInvalid, Err: errors.New("backfill must not end in the future")}
+	}
+
+	t, err := p.s.FindTaskByID(ctx, taskID)
+	if err != nil && err != backend.ErrTaskNotFound {
+		return err
+	}
+	if t == nil {
+		return &platform.Error{Code: platform.ENotFound, Err: backend.ErrTaskNotFound}
+	}
+	return p.s.ManuallyRunTimeRange(ctx, taskID, start.Unix(), end.Unix(), now.Unix())
 }
 
 func toPlatformTask(t backend.StoreTask, m *backend.StoreTaskMeta) (*platform.Task, error) {
@@ -171,6 +292,7 @@ func toPlatformTask(t backend.StoreTask, m *backend.StoreTaskMeta) (*platform.Ta
 	}
 	if m != nil {
 		pt.Status = string(m.Status)
+		pt.CatchUp = m.CatchUp
 	}
 	return pt, nil
 }
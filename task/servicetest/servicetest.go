@@ -58,11 +58,21 @@ func TestTaskService(t *testing.T, fn BackendComponentFactory) {
 			testTaskCRUD(t, sys)
 		})
 
+		t.Run("Task Schedule After", func(t *testing.T) {
+			t.Parallel()
+			testTaskScheduleAfter(t, sys)
+		})
+
 		t.Run("Task Runs", func(t *testing.T) {
 			t.Parallel()
 			testTaskRuns(t, sys)
 		})
 
+		t.Run("Task Backfill", func(t *testing.T) {
+			t.Parallel()
+			testTaskBackfill(t, sys)
+		})
+
 		t.Run("Task Concurrency", func(t *testing.T) {
 			t.Parallel()
 			testTaskConcurrency(t, sys)
@@ -152,6 +162,9 @@ func testTaskCRUD(t *testing.T, sys *System) {
 			if f.Status != string(backend.DefaultTaskStatus) {
 				t.Fatalf(`%s: wrong default task status; want %q, got %q`, fn, backend.DefaultTaskStatus, f.Status)
 			}
+			if f.CatchUp != string(backend.DefaultCatchUpPolicy) {
+				t.Fatalf(`%s: wrong default catch-up policy; want %q, got %q`, fn, backend.DefaultCatchUpPolicy, f.CatchUp)
+			}
 		}
 	}
 
@@ -200,6 +213,24 @@ func testTaskCRUD(t *testing.T, sys *System) {
 		t.Fatalf("expected task status to be inactive, got %q", f.Status)
 	}
 
+	// Update task: catch-up policy only.
+	newCatchUp := string(backend.CatchUpLatest)
+	f, err = sys.ts.UpdateTask(sys.Ctx, origID, platform.TaskUpdate{CatchUp: &newCatchUp})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Flux != newFlux {
+		t.Fatalf("flux unexpected updated: %s", f.Flux)
+	}
+	if f.CatchUp != newCatchUp {
+		t.Fatalf("expected task catch-up policy to be %q, got %q", newCatchUp, f.CatchUp)
+	}
+	if f, err = sys.ts.FindTaskByID(sys.Ctx, origID); err != nil {
+		t.Fatal(err)
+	} else if f.CatchUp != newCatchUp {
+		t.Fatalf("expected found task catch-up policy to be %q, got %q", newCatchUp, f.CatchUp)
+	}
+
 	// Delete task.
 	if err := sys.ts.DeleteTask(sys.Ctx, origID); err != nil {
 		t.Fatal(err)
@@ -211,6 +242,40 @@ func testTaskCRUD(t *testing.T, sys *System) {
 	}
 }
 
+func testTaskScheduleAfter(t *testing.T, sys *System) {
+	task := &platform.Task{
+		Organization:  idGen.ID(),
+		Owner:         platform.User{ID: idGen.ID()},
+		Flux:          fmt.Sprintf(scriptFmt, 0),
+		ScheduleAfter: "2018-10-01T00:00:00Z",
+		CatchUp:       string(backend.CatchUpSkip),
+	}
+	if err := sys.ts.CreateTask(sys.Ctx, task); err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := sys.S.FindTaskMetaByID(sys.Ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC).Unix(); meta.LatestCompleted != exp {
+		t.Fatalf("expected task to be scheduled after %d, got %d", exp, meta.LatestCompleted)
+	}
+	if meta.CatchUp != string(backend.CatchUpSkip) {
+		t.Fatalf("expected catch-up policy %q, got %q", backend.CatchUpSkip, meta.CatchUp)
+	}
+
+	bad := &platform.Task{
+		Organization:  idGen.ID(),
+		Owner:         platform.User{ID: idGen.ID()},
+		Flux:          fmt.Sprintf(scriptFmt, 0),
+		ScheduleAfter: "yesterday",
+	}
+	if err := sys.ts.CreateTask(sys.Ctx, bad); err == nil {
+		t.Fatal("expected error creating task with invalid scheduleAfter")
+	}
+}
+
 func testTaskRuns(t *testing.T, sys *System) {
 	orgID := idGen.ID()
 	userID := idGen.ID()
@@ -226,7 +291,7 @@ func testTaskRuns(t *testing.T, sys *System) {
 	}
 
 	// Create a run.
-	rc, err := sys.S.CreateNextRun(sys.Ctx, task.ID, requestedAtUnix+1)
+	rc, err := sys.S.CreateNextRun(sys.Ctx, task.ID, requestedAtUnix+1, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -250,7 +315,7 @@ func testTaskRuns(t *testing.T, sys *System) {
 		t.Fatal(err)
 	}
 
-	// Find runs, to see the started run.
+	// Find runs, to see the started run followed by the runs still queued.
 	runs, n, err := sys.ts.FindRuns(sys.Ctx, platform.RunFilter{Org: &orgID, Task: &task.ID})
 	if err != nil {
 		t.Fatal(err)
@@ -258,8 +323,13 @@ func testTaskRuns(t *testing.T, sys *System) {
 	if n != len(runs) {
 		t.Fatalf("expected n=%d, got %d", len(runs), n)
 	}
-	if len(runs) != 1 {
-		t.Fatalf("expected 1 run returned, got %d", len(runs))
+	if len(runs) != 5 {
+		t.Fatalf("expected 5 runs returned, got %d", len(runs))
+	}
+	for _, r := range runs[1:] {
+		if r.Status != "scheduled" || r.ID.Valid() {
+			t.Errorf("expected queued run to be scheduled without an ID, got %s with ID %s", r.Status, r.ID)
+		}
 	}
 
 	r := runs[0]
@@ -282,6 +352,102 @@ func testTaskRuns(t *testing.T, sys *System) {
 	if r.FinishedAt != "" {
 		t.Errorf("expected run not be finished, got %q", r.FinishedAt)
 	}
+
+	// Retrying a run that has not finished is an error.
+	if _, err := sys.ts.RetryRun(sys.Ctx, task.ID, runID); err == nil {
+		t.Fatal("expected error retrying unfinished run")
+	}
+
+	// Finish the run and retry it.
+	if err := sys.S.FinishRun(sys.Ctx, task.ID, runID); err != nil {
+		t.Fatal(err)
+	}
+	if err := sys.LW.UpdateRunState(sys.Ctx, rlb, time.Now(), backend.RunFail); err != nil {
+		t.Fatal(err)
+	}
+	retry, err := sys.ts.RetryRun(sys.Ctx, task.ID, runID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retry.TaskID != task.ID {
+		t.Errorf("expected retry to have task ID %s, got %s", task.ID.String(), retry.TaskID.String())
+	}
+	if retry.ScheduledFor != r.ScheduledFor {
+		t.Errorf("expected retry to be scheduled for %q, got %q", r.ScheduledFor, retry.ScheduledFor)
+	}
+
+	// The retry is queued as a manual run of the schedule of the original run.
+	_, meta, err := sys.S.FindTaskByIDWithMeta(sys.Ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(meta.ManualRuns); n == 0 {
+		t.Fatal("expected the retry to be queued")
+	}
+	q := meta.ManualRuns[len(meta.ManualRuns)-1]
+	if q.Start != rc.Created.Now || q.End != rc.Created.Now {
+		t.Errorf("expected retry of %d queued, got range %d-%d", rc.Created.Now, q.Start, q.End)
+	}
+
+	// Retrying a run of another task is an error.
+	if _, err := sys.ts.RetryRun(sys.Ctx, idGen.ID(), runID); err == nil {
+		t.Error("expected error retrying run of unknown task")
+	}
+}
+
+func testTaskBackfill(t *testing.T, sys *System) {
+	orgID := idGen.ID()
+	userID := idGen.ID()
+
+	task := &platform.Task{Organization: orgID, Owner: platform.User{ID: userID}, Flux: fmt.Sprintf(scriptFmt, 0)}
+	if err := sys.ts.CreateTask(sys.Ctx, task); err != nil {
+		t.Fatal(err)
+	}
+
+	start, end := time.Unix(60, 0), time.Unix(300, 0)
+	if err := sys.ts.BackfillTask(sys.Ctx, task.ID, end, start); err == nil {
+		t.Error("expected error backfilling a range that ends before it starts")
+	}
+	if err := sys.ts.BackfillTask(sys.Ctx, task.ID, start, time.Now().Add(time.Hour)); err == nil {
+		t.Error("expected error backfilling a range that ends in the future")
+	}
+	if err := sys.ts.BackfillTask(sys.Ctx, task.ID, start, end); err != nil {
+		t.Fatal(err)
+	}
+
+	_, meta, err := sys.S.FindTaskByIDWithMeta(sys.Ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(meta.ManualRuns) != 1 {
+		t.Fatalf("expected 1 queued range, got %d", len(meta.ManualRuns))
+	}
+	if q := meta.ManualRuns[0]; q.Start != 60 || q.End != 300 {
+		t.Errorf("expected range 60-300 queued, got %d-%d", q.Start, q.End)
+	}
+
+	// The queued runs are scheduled until the scheduler creates them.
+	runs, _, err := sys.ts.FindRuns(sys.Ctx, platform.RunFilter{Org: &orgID, Task: &task.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 5 {
+		t.Fatalf("expected 5 scheduled runs, got %d", len(runs))
+	}
+	for i, r := range runs {
+		if want := time.Unix(int64(60*(i+1)), 0).UTC().Format(time.RFC3339); r.Status != "scheduled" || r.ScheduledFor != want {
+			t.Errorf("expected run %d to be scheduled for %s, got %s for %s", i, want, r.Status, r.ScheduledFor)
+		}
+	}
+
+	// The runs of the range are created in order of their schedules.
+	rc, err := sys.S.CreateNextRun(sys.Ctx, task.ID, time.Now().Unix(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rc.Created.Now != 60 {
+		t.Errorf("expected first backfilled run to be scheduled for 60, got %d", rc.Created.Now)
+	}
 }
 
 func testTaskConcurrency(t *testing.T, sys *System) {
@@ -394,7 +560,7 @@ func testTaskConcurrency(t *testing.T, sys *System) {
 			// Create a run for the last task we found.
 			// The script should run every minute, so use max now.
 			tid := tasks[len(tasks)-1].ID
-			if _, err := sys.S.CreateNextRun(sys.Ctx, tid, math.MaxInt64); err != nil {
+			if _, err := sys.S.CreateNextRun(sys.Ctx, tid, math.MaxInt64, ""); err != nil {
 				// This may have errored due to the task being deleted. Check if the task still exists.
 				if _, err2 := sys.S.FindTaskByID(sys.Ctx, tid); err2 == backend.ErrTaskNotFound {
 					// It was deleted. Just continue.
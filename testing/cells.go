@@ -33,8 +33,9 @@ var viewCmpOptions = cmp.Options{
 
 // ViewFields will include the IDGenerator, and views
 type ViewFields struct {
-	IDGenerator platform.IDGenerator
-	Views       []*platform.View
+	IDGenerator          platform.IDGenerator
+	Views                []*platform.View
+	UserResourceMappings []*platform.UserResourceMapping
 }
 
 // CreateView testing
@@ -226,8 +227,10 @@ func FindViews(
 	t *testing.T,
 ) {
 	type args struct {
-		ID   platform.ID
-		name string
+		ID             platform.ID
+		organizationID *platform.ID
+		namePrefix     *string
+		owner          *platform.ID
 	}
 
 	type wants struct {
@@ -327,6 +330,99 @@ func FindViews(
 				},
 			},
 		},
+		{
+			name: "find views of an organization by name prefix",
+			fields: ViewFields{
+				Views: []*platform.View{
+					{
+						ViewContents: platform.ViewContents{
+							ID:             MustIDBase16(viewOneID),
+							OrganizationID: MustIDBase16(orgOneID),
+							Name:           "cpu",
+						},
+						Properties: platform.EmptyViewProperties{},
+					},
+					{
+						ViewContents: platform.ViewContents{
+							ID:             MustIDBase16(viewTwoID),
+							OrganizationID: MustIDBase16(orgOneID),
+							Name:           "mem",
+						},
+						Properties: platform.EmptyViewProperties{},
+					},
+					{
+						ViewContents: platform.ViewContents{
+							ID:             MustIDBase16(viewThreeID),
+							OrganizationID: MustIDBase16(orgTwoID),
+							Name:           "cpu",
+						},
+						Properties: platform.EmptyViewProperties{},
+					},
+				},
+			},
+			args: args{
+				organizationID: idPtr(MustIDBase16(orgOneID)),
+				namePrefix:     strPtr("cp"),
+			},
+			wants: wants{
+				views: []*platform.View{
+					{
+						ViewContents: platform.ViewContents{
+							ID:             MustIDBase16(viewOneID),
+							OrganizationID: MustIDBase16(orgOneID),
+							Name:           "cpu",
+						},
+						Properties: platform.EmptyViewProperties{},
+					},
+				},
+			},
+		},
+		{
+			name: "find views by owner",
+			fields: ViewFields{
+				Views: []*platform.View{
+					{
+						ViewContents: platform.ViewContents{
+							ID:             MustIDBase16(viewOneID),
+							OrganizationID: MustIDBase16(orgOneID),
+							Name:           "cpu",
+						},
+						Properties: platform.EmptyViewProperties{},
+					},
+					{
+						ViewContents: platform.ViewContents{
+							ID:             MustIDBase16(viewTwoID),
+							OrganizationID: MustIDBase16(orgOneID),
+							Name:           "mem",
+						},
+						Properties: platform.EmptyViewProperties{},
+					},
+				},
+				UserResourceMappings: []*platform.UserResourceMapping{
+					{
+						ResourceID:   MustIDBase16(viewTwoID),
+						ResourceType: platform.ViewResourceType,
+						UserID:       MustIDBase16(userOneID),
+						UserType:     platform.Owner,
+					},
+				},
+			},
+			args: args{
+				owner: idPtr(MustIDBase16(userOneID)),
+			},
+			wants: wants{
+				views: []*platform.View{
+					{
+						ViewContents: platform.ViewContents{
+							ID:             MustIDBase16(viewTwoID),
+							OrganizationID: MustIDBase16(orgOneID),
+							Name:           "mem",
+						},
+						Properties: platform.EmptyViewProperties{},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -335,7 +431,11 @@ func FindViews(
 			defer done()
 			ctx := context.TODO()
 
-			filter := platform.ViewFilter{}
+			filter := platform.ViewFilter{
+				OrganizationID: tt.args.organizationID,
+				NamePrefix:     tt.args.namePrefix,
+				Owner:          tt.args.owner,
+			}
 			if tt.args.ID.Valid() {
 				filter.ID = &tt.args.ID
 			}
@@ -0,0 +1,480 @@
+package testing
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+)
+
+// CheckFields includes prepopulated data for mapping tests.
+type CheckFields struct {
+	IDGenerator platform.IDGenerator
+	Checks      []*platform.Check
+}
+
+var checkCmpOptions = cmp.Options{
+	cmp.Transformer("Sort", func(in []*platform.Check) []*platform.Check {
+		out := append([]*platform.Check(nil), in...)
+		sort.Slice(out, func(i, j int) bool {
+			return out[i].ID > out[j].ID
+		})
+		return out
+	}),
+}
+
+func newTestCheck(id, orgID, taskID string, name string) *platform.Check {
+	return &platform.Check{
+		ID:             MustIDBase16(id),
+		OrganizationID: MustIDBase16(orgID),
+		Name:           name,
+		Type:           platform.ThresholdCheckType,
+		Status:         platform.CheckActive,
+		Every:          "1m",
+		Query: platform.CheckQuery{
+			Bucket:      "telegraf",
+			Measurement: "cpu",
+			Field:       "usage_user",
+		},
+		Thresholds: []platform.Threshold{
+			{Level: platform.CheckLevelCrit, Value: 90},
+		},
+		TaskID: MustIDBase16(taskID),
+	}
+}
+
+// CheckService tests all the service functions.
+func CheckService(
+	init func(CheckFields, *testing.T) (platform.CheckService, func()), t *testing.T,
+) {
+	tests := []struct {
+		name string
+		fn   func(init func(CheckFields, *testing.T) (platform.CheckService, func()),
+			t *testing.T)
+	}{
+		{
+			name: "CreateCheck",
+			fn:   CreateCheck,
+		},
+		{
+			name: "FindCheckByID",
+			fn:   FindCheckByID,
+		},
+		{
+			name: "FindChecks",
+			fn:   FindChecks,
+		},
+		{
+			name: "UpdateCheck",
+			fn:   UpdateCheck,
+		},
+		{
+			name: "DeleteCheck",
+			fn:   DeleteCheck,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(init, t)
+		})
+	}
+}
+
+// CreateCheck testing.
+func CreateCheck(
+	init func(CheckFields, *testing.T) (platform.CheckService, func()),
+	t *testing.T,
+) {
+	s, done := init(CheckFields{
+		IDGenerator: mock.NewIDGenerator(twoID, t),
+		Checks: []*platform.Check{
+			newTestCheck(oneID, fourID, threeID, "check1"),
+		},
+	}, t)
+	defer done()
+	ctx := context.Background()
+
+	c := newTestCheck(oneID, fourID, threeID, "check2")
+	c.ID = 0
+	if err := s.CreateCheck(ctx, c); err != nil {
+		t.Fatalf("expected error to be nil got '%v'", err)
+	}
+	if c.ID != MustIDBase16(twoID) {
+		t.Fatalf("expected check ID %s got %s", twoID, c.ID)
+	}
+
+	checks, err := s.FindChecks(ctx, platform.CheckFilter{})
+	if err != nil {
+		t.Fatalf("failed to retrieve checks: %v", err)
+	}
+	want := []*platform.Check{
+		newTestCheck(oneID, fourID, threeID, "check1"),
+		newTestCheck(twoID, fourID, threeID, "check2"),
+	}
+	if diff := cmp.Diff(checks, want, checkCmpOptions...); diff != "" {
+		t.Errorf("checks are different -got/+want\ndiff %s", diff)
+	}
+}
+
+// FindCheckByID testing.
+func FindCheckByID(
+	init func(CheckFields, *testing.T) (platform.CheckService, func()),
+	t *testing.T,
+) {
+	type wants struct {
+		err   error
+		check *platform.Check
+	}
+
+	tests := []struct {
+		name  string
+		id    platform.ID
+		wants wants
+	}{
+		{
+			name: "find check by id",
+			id:   MustIDBase16(twoID),
+			wants: wants{
+				check: newTestCheck(twoID, fourID, threeID, "check2"),
+			},
+		},
+		{
+			name: "check not found",
+			id:   MustIDBase16(threeID),
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.ENotFound,
+				},
+			},
+		},
+		{
+			name: "invalid id",
+			id:   platform.ID(0),
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.EEmptyValue,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(CheckFields{
+				Checks: []*platform.Check{
+					newTestCheck(oneID, fourID, threeID, "check1"),
+					newTestCheck(twoID, fourID, threeID, "check2"),
+				},
+			}, t)
+			defer done()
+
+			check, err := s.FindCheckByID(context.Background(), tt.id)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if diff := cmp.Diff(check, tt.wants.check); diff != "" {
+				t.Errorf("check is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindChecks testing.
+func FindChecks(
+	init func(CheckFields, *testing.T) (platform.CheckService, func()),
+	t *testing.T,
+) {
+	orgID := MustIDBase16(fourID)
+	otherOrgID := MustIDBase16(threeID)
+	taskID := MustIDBase16(threeID)
+	id := MustIDBase16(twoID)
+
+	tests := []struct {
+		name   string
+		filter platform.CheckFilter
+		checks []*platform.Check
+	}{
+		{
+			name:   "find all checks",
+			filter: platform.CheckFilter{},
+			checks: []*platform.Check{
+				newTestCheck(oneID, fourID, threeID, "check1"),
+				newTestCheck(twoID, fourID, oneID, "check2"),
+				newTestCheck(threeID, threeID, oneID, "check3"),
+			},
+		},
+		{
+			name:   "find checks by organization",
+			filter: platform.CheckFilter{OrganizationID: &orgID},
+			checks: []*platform.Check{
+				newTestCheck(oneID, fourID, threeID, "check1"),
+				newTestCheck(twoID, fourID, oneID, "check2"),
+			},
+		},
+		{
+			name:   "find checks by task",
+			filter: platform.CheckFilter{TaskID: &taskID},
+			checks: []*platform.Check{
+				newTestCheck(oneID, fourID, threeID, "check1"),
+			},
+		},
+		{
+			name:   "find checks by id",
+			filter: platform.CheckFilter{ID: &id},
+			checks: []*platform.Check{
+				newTestCheck(twoID, fourID, oneID, "check2"),
+			},
+		},
+		{
+			name:   "find no checks",
+			filter: platform.CheckFilter{OrganizationID: &otherOrgID, TaskID: &taskID},
+			checks: []*platform.Check{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(CheckFields{
+				Checks: []*platform.Check{
+					newTestCheck(oneID, fourID, threeID, "check1"),
+					newTestCheck(twoID, fourID, oneID, "check2"),
+					newTestCheck(threeID, threeID, oneID, "check3"),
+				},
+			}, t)
+			defer done()
+
+			checks, err := s.FindChecks(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("expected error to be nil got '%v'", err)
+			}
+			if diff := cmp.Diff(checks, tt.checks, checkCmpOptions...); diff != "" {
+				t.Errorf("checks are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// UpdateCheck testing.
+func UpdateCheck(
+	init func(CheckFields, *testing.T) (platform.CheckService, func()),
+	t *testing.T,
+) {
+	name := "check1 renamed"
+	status := platform.CheckInactive
+
+	type wants struct {
+		err   error
+		check *platform.Check
+	}
+
+	tests := []struct {
+		name  string
+		id    platform.ID
+		upd   platform.CheckUpdate
+		wants wants
+	}{
+		{
+			name: "update name and status",
+			id:   MustIDBase16(oneID),
+			upd:  platform.CheckUpdate{Name: &name, Status: &status},
+			wants: wants{
+				check: func() *platform.Check {
+					c := newTestCheck(oneID, fourID, threeID, name)
+					c.Status = status
+					return c
+				}(),
+			},
+		},
+		{
+			name: "update thresholds",
+			id:   MustIDBase16(oneID),
+			upd: platform.CheckUpdate{
+				Thresholds: []platform.Threshold{
+					{Level: platform.CheckLevelWarn, Value: 10, Below: true},
+				},
+			},
+			wants: wants{
+				check: func() *platform.Check {
+					c := newTestCheck(oneID, fourID, threeID, "check1")
+					c.Thresholds = []platform.Threshold{
+						{Level: platform.CheckLevelWarn, Value: 10, Below: true},
+					}
+					return c
+				}(),
+			},
+		},
+		{
+			name: "check not found",
+			id:   MustIDBase16(twoID),
+			upd:  platform.CheckUpdate{Name: &name},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.ENotFound,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(CheckFields{
+				Checks: []*platform.Check{
+					newTestCheck(oneID, fourID, threeID, "check1"),
+				},
+			}, t)
+			defer done()
+			ctx := context.Background()
+
+			check, err := s.UpdateCheck(ctx, tt.id, tt.upd)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil {
+				if platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+				}
+				return
+			}
+			if diff := cmp.Diff(check, tt.wants.check); diff != "" {
+				t.Errorf("check is different -got/+want\ndiff %s", diff)
+			}
+
+			found, err := s.FindCheckByID(ctx, tt.id)
+			if err != nil {
+				t.Fatalf("failed to retrieve check: %v", err)
+			}
+			if diff := cmp.Diff(found, tt.wants.check); diff != "" {
+				t.Errorf("stored check is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// DeleteCheck testing.
+func DeleteCheck(
+	init func(CheckFields, *testing.T) (platform.CheckService, func()),
+	t *testing.T,
+) {
+	type wants struct {
+		err    error
+		checks []*platform.Check
+	}
+
+	tests := []struct {
+		name  string
+		id    platform.ID
+		wants wants
+	}{
+		{
+			name: "delete check",
+			id:   MustIDBase16(oneID),
+			wants: wants{
+				checks: []*platform.Check{
+					newTestCheck(twoID, fourID, threeID, "check2"),
+				},
+			},
+		},
+		{
+			name: "check not found",
+			id:   MustIDBase16(threeID),
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.ENotFound,
+				},
+				checks: []*platform.Check{
+					newTestCheck(oneID, fourID, threeID, "check1"),
+					newTestCheck(twoID, fourID, threeID, "check2"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(CheckFields{
+				Checks: []*platform.Check{
+					newTestCheck(oneID, fourID, threeID, "check1"),
+					newTestCheck(twoID, fourID, threeID, "check2"),
+				},
+			}, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.DeleteCheck(ctx, tt.id)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+
+			checks, err := s.FindChecks(ctx, platform.CheckFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve checks: %v", err)
+			}
+			if diff := cmp.Diff(checks, tt.wants.checks, checkCmpOptions...); diff != "" {
+				t.Errorf("checks are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// CheckNStatusService includes check service
+// and check status service.
+type CheckNStatusService interface {
+	platform.CheckService
+	platform.CheckStatusService
+}
+
+// CheckStatuses testing.
+func CheckStatuses(
+	init func(CheckFields, *testing.T) (CheckNStatusService, func()),
+	t *testing.T,
+) {
+	s, done := init(CheckFields{
+		Checks: []*platform.Check{
+			newTestCheck(oneID, fourID, threeID, "check1"),
+			newTestCheck(twoID, fourID, threeID, "check2"),
+		},
+	}, t)
+	defer done()
+	ctx := context.Background()
+	id := MustIDBase16(oneID)
+
+	find := func(id platform.ID, want []*platform.CheckStatus) {
+		t.Helper()
+		statuses, err := s.FindCheckStatuses(ctx, id)
+		if err != nil {
+			t.Fatalf("failed to retrieve check statuses: %v", err)
+		}
+		if diff := cmp.Diff(statuses, want); diff != "" {
+			t.Errorf("check statuses are different -got/+want\ndiff %s", diff)
+		}
+	}
+
+	find(id, []*platform.CheckStatus{})
+
+	statuses := []*platform.CheckStatus{
+		{Tags: map[string]string{"host": "a"}, Level: platform.CheckLevelCrit},
+		{Tags: map[string]string{"host": "b"}, Level: platform.CheckLevelOK},
+	}
+	if err := s.PutCheckStatuses(ctx, id, statuses); err != nil {
+		t.Fatalf("failed to put check statuses: %v", err)
+	}
+	find(id, statuses)
+	find(MustIDBase16(twoID), []*platform.CheckStatus{})
+
+	statuses = statuses[1:]
+	if err := s.PutCheckStatuses(ctx, id, statuses); err != nil {
+		t.Fatalf("failed to put check statuses: %v", err)
+	}
+	find(id, statuses)
+
+	// Deleting a check deletes its statuses.
+	if err := s.DeleteCheck(ctx, id); err != nil {
+		t.Fatalf("failed to delete check: %v", err)
+	}
+	find(id, []*platform.CheckStatus{})
+}
@@ -37,9 +37,10 @@ var dashboardCmpOptions = cmp.Options{
 
 // DashboardFields will include the IDGenerator, and dashboards
 type DashboardFields struct {
-	IDGenerator platform.IDGenerator
-	Dashboards  []*platform.Dashboard
-	Views       []*platform.View
+	IDGenerator          platform.IDGenerator
+	Dashboards           []*platform.Dashboard
+	Views                []*platform.View
+	UserResourceMappings []*platform.UserResourceMapping
 }
 
 // DashboardService tests all the service functions.
@@ -120,26 +121,30 @@ func CreateDashboard(
 				},
 				Dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 					},
 				},
 			},
 			args: args{
 				dashboard: &platform.Dashboard{
-					ID:   MustIDBase16(dashTwoID),
-					Name: "dashboard2",
+					ID:             MustIDBase16(dashTwoID),
+					OrganizationID: MustIDBase16(orgOneID),
+					Name:           "dashboard2",
 				},
 			},
 			wants: wants{
 				dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 					},
 					{
-						ID:   MustIDBase16(dashTwoID),
-						Name: "dashboard2",
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard2",
 					},
 				},
 			},
@@ -154,25 +159,29 @@ func CreateDashboard(
 				},
 				Dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 					},
 				},
 			},
 			args: args{
 				dashboard: &platform.Dashboard{
-					Name: "dashboard2",
+					OrganizationID: MustIDBase16(orgOneID),
+					Name:           "dashboard2",
 				},
 			},
 			wants: wants{
 				dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 					},
 					{
-						ID:   MustIDBase16(dashTwoID),
-						Name: "dashboard2",
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard2",
 					},
 				},
 			},
@@ -196,7 +205,7 @@ func CreateDashboard(
 			}
 			defer s.DeleteDashboard(ctx, tt.args.dashboard.ID)
 
-			dashboards, _, err := s.FindDashboards(ctx, platform.DashboardFilter{})
+			dashboards, _, err := s.FindDashboards(ctx, platform.DashboardFilter{OrganizationID: idPtr(MustIDBase16(orgOneID))})
 			if err != nil {
 				t.Fatalf("failed to retrieve dashboards: %v", err)
 			}
@@ -215,6 +224,7 @@ func AddDashboardCell(
 	type args struct {
 		dashboardID platform.ID
 		cell        *platform.Cell
+		opts        platform.AddDashboardCellOptions
 	}
 	type wants struct {
 		err        error
@@ -237,14 +247,16 @@ func AddDashboardCell(
 				},
 				Dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 					},
 				},
 				Views: []*platform.View{
 					{
 						ViewContents: platform.ViewContents{
-							ID: MustIDBase16(dashTwoID),
+							ID:             MustIDBase16(dashTwoID),
+							OrganizationID: MustIDBase16(orgOneID),
 						},
 					},
 				},
@@ -259,8 +271,9 @@ func AddDashboardCell(
 			wants: wants{
 				dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 						Cells: []*platform.Cell{
 							{
 								ID:     MustIDBase16(dashTwoID),
@@ -281,14 +294,16 @@ func AddDashboardCell(
 				},
 				Dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 					},
 				},
 				Views: []*platform.View{
 					{
 						ViewContents: platform.ViewContents{
-							ID: MustIDBase16(dashTwoID),
+							ID:             MustIDBase16(dashTwoID),
+							OrganizationID: MustIDBase16(orgOneID),
 						},
 					},
 				},
@@ -302,8 +317,9 @@ func AddDashboardCell(
 			wants: wants{
 				dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 						Cells: []*platform.Cell{
 							{
 								ID:     MustIDBase16(dashTwoID),
@@ -314,6 +330,48 @@ func AddDashboardCell(
 				},
 			},
 		},
+		{
+			name: "add cell using a view of another organization",
+			fields: DashboardFields{
+				IDGenerator: &mock.IDGenerator{
+					IDFn: func() platform.ID {
+						return MustIDBase16(dashTwoID)
+					},
+				},
+				Dashboards: []*platform.Dashboard{
+					{
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
+					},
+				},
+				Views: []*platform.View{
+					{
+						ViewContents: platform.ViewContents{
+							ID:             MustIDBase16(dashThreeID),
+							OrganizationID: MustIDBase16(orgTwoID),
+						},
+					},
+				},
+			},
+			args: args{
+				dashboardID: MustIDBase16(dashOneID),
+				cell:        &platform.Cell{},
+				opts: platform.AddDashboardCellOptions{
+					UsingView: MustIDBase16(dashThreeID),
+				},
+			},
+			wants: wants{
+				err: &platform.Error{Code: platform.EInvalid},
+				dashboards: []*platform.Dashboard{
+					{
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -321,19 +379,24 @@ func AddDashboardCell(
 			s, done := init(tt.fields, t)
 			defer done()
 			ctx := context.Background()
-			err := s.AddDashboardCell(ctx, tt.args.dashboardID, tt.args.cell, platform.AddDashboardCellOptions{})
+			err := s.AddDashboardCell(ctx, tt.args.dashboardID, tt.args.cell, tt.args.opts)
 			if (err != nil) != (tt.wants.err != nil) {
 				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
+				if want, ok := tt.wants.err.(*platform.Error); ok {
+					// Clients of the HTTP API receive the code as the status of the response.
+					if got, ok := err.(*platform.Error); ok && platform.ErrorCode(got) != want.Code {
+						t.Fatalf("expected error code '%v' got '%v'", want.Code, platform.ErrorCode(got))
+					}
+				} else if err.Error() != tt.wants.err.Error() {
 					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
 				}
 			}
 			defer s.DeleteDashboard(ctx, tt.args.dashboardID)
 
-			dashboards, _, err := s.FindDashboards(ctx, platform.DashboardFilter{})
+			dashboards, _, err := s.FindDashboards(ctx, platform.DashboardFilter{OrganizationID: idPtr(MustIDBase16(orgOneID))})
 			if err != nil {
 				t.Fatalf("failed to retrieve dashboards: %v", err)
 			}
@@ -368,12 +431,14 @@ func FindDashboardByID(
 			fields: DashboardFields{
 				Dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 					},
 					{
-						ID:   MustIDBase16(dashTwoID),
-						Name: "dashboard2",
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard2",
 					},
 				},
 			},
@@ -382,8 +447,9 @@ func FindDashboardByID(
 			},
 			wants: wants{
 				dashboard: &platform.Dashboard{
-					ID:   MustIDBase16(dashTwoID),
-					Name: "dashboard2",
+					ID:             MustIDBase16(dashTwoID),
+					OrganizationID: MustIDBase16(orgOneID),
+					Name:           "dashboard2",
 				},
 			},
 		},
@@ -419,8 +485,10 @@ func FindDashboards(
 	t *testing.T,
 ) {
 	type args struct {
-		IDs  []*platform.ID
-		name string
+		IDs            []*platform.ID
+		organizationID *platform.ID
+		namePrefix     *string
+		owner          *platform.ID
 	}
 
 	type wants struct {
@@ -438,12 +506,14 @@ func FindDashboards(
 			fields: DashboardFields{
 				Dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "abc",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "abc",
 					},
 					{
-						ID:   MustIDBase16(dashTwoID),
-						Name: "xyz",
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "xyz",
 					},
 				},
 			},
@@ -451,12 +521,14 @@ func FindDashboards(
 			wants: wants{
 				dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "abc",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "abc",
 					},
 					{
-						ID:   MustIDBase16(dashTwoID),
-						Name: "xyz",
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "xyz",
 					},
 				},
 			},
@@ -466,12 +538,14 @@ func FindDashboards(
 			fields: DashboardFields{
 				Dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "abc",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "abc",
 					},
 					{
-						ID:   MustIDBase16(dashTwoID),
-						Name: "xyz",
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "xyz",
 					},
 				},
 			},
@@ -483,8 +557,9 @@ func FindDashboards(
 			wants: wants{
 				dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashTwoID),
-						Name: "xyz",
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "xyz",
 					},
 				},
 			},
@@ -494,12 +569,14 @@ func FindDashboards(
 			fields: DashboardFields{
 				Dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "abc",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "abc",
 					},
 					{
-						ID:   MustIDBase16(dashTwoID),
-						Name: "xyz",
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "xyz",
 					},
 				},
 			},
@@ -512,12 +589,114 @@ func FindDashboards(
 			wants: wants{
 				dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "abc",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "abc",
 					},
 					{
-						ID:   MustIDBase16(dashTwoID),
-						Name: "xyz",
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "xyz",
+					},
+				},
+			},
+		},
+		{
+			name: "find dashboards of another organization",
+			fields: DashboardFields{
+				Dashboards: []*platform.Dashboard{
+					{
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "abc",
+					},
+					{
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgTwoID),
+						Name:           "xyz",
+					},
+				},
+			},
+			args: args{
+				organizationID: idPtr(MustIDBase16(orgTwoID)),
+			},
+			wants: wants{
+				dashboards: []*platform.Dashboard{
+					{
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgTwoID),
+						Name:           "xyz",
+					},
+				},
+			},
+		},
+		{
+			name: "find dashboards by name prefix",
+			fields: DashboardFields{
+				Dashboards: []*platform.Dashboard{
+					{
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "abc",
+					},
+					{
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "xyz",
+					},
+					{
+						ID:             MustIDBase16(dashThreeID),
+						OrganizationID: MustIDBase16(orgTwoID),
+						Name:           "abd",
+					},
+				},
+			},
+			args: args{
+				namePrefix: strPtr("ab"),
+			},
+			wants: wants{
+				dashboards: []*platform.Dashboard{
+					{
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "abc",
+					},
+				},
+			},
+		},
+		{
+			name: "find dashboards by owner",
+			fields: DashboardFields{
+				Dashboards: []*platform.Dashboard{
+					{
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "abc",
+					},
+					{
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "xyz",
+					},
+				},
+				UserResourceMappings: []*platform.UserResourceMapping{
+					{
+						ResourceID:   MustIDBase16(dashTwoID),
+						ResourceType: platform.DashboardResourceType,
+						UserID:       MustIDBase16(userOneID),
+						UserType:     platform.Owner,
+					},
+				},
+			},
+			args: args{
+				owner: idPtr(MustIDBase16(userOneID)),
+			},
+			wants: wants{
+				dashboards: []*platform.Dashboard{
+					{
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "xyz",
 					},
 				},
 			},
@@ -530,9 +709,14 @@ func FindDashboards(
 			defer done()
 			ctx := context.Background()
 
-			filter := platform.DashboardFilter{}
-			if tt.args.IDs != nil {
-				filter.IDs = tt.args.IDs
+			filter := platform.DashboardFilter{
+				IDs:            tt.args.IDs,
+				OrganizationID: idPtr(MustIDBase16(orgOneID)),
+				NamePrefix:     tt.args.namePrefix,
+				Owner:          tt.args.owner,
+			}
+			if tt.args.organizationID != nil {
+				filter.OrganizationID = tt.args.organizationID
 			}
 
 			dashboards, _, err := s.FindDashboards(ctx, filter)
@@ -577,12 +761,14 @@ func DeleteDashboard(
 			fields: DashboardFields{
 				Dashboards: []*platform.Dashboard{
 					{
-						Name: "A",
-						ID:   MustIDBase16(dashOneID),
+						Name:           "A",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
 					},
 					{
-						Name: "B",
-						ID:   MustIDBase16(dashTwoID),
+						Name:           "B",
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
 					},
 				},
 			},
@@ -592,8 +778,9 @@ func DeleteDashboard(
 			wants: wants{
 				dashboards: []*platform.Dashboard{
 					{
-						Name: "B",
-						ID:   MustIDBase16(dashTwoID),
+						Name:           "B",
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
 					},
 				},
 			},
@@ -603,12 +790,14 @@ func DeleteDashboard(
 			fields: DashboardFields{
 				Dashboards: []*platform.Dashboard{
 					{
-						Name: "A",
-						ID:   MustIDBase16(dashOneID),
+						Name:           "A",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
 					},
 					{
-						Name: "B",
-						ID:   MustIDBase16(dashTwoID),
+						Name:           "B",
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
 					},
 				},
 			},
@@ -619,12 +808,14 @@ func DeleteDashboard(
 				err: fmt.Errorf("dashboard not found"),
 				dashboards: []*platform.Dashboard{
 					{
-						Name: "A",
-						ID:   MustIDBase16(dashOneID),
+						Name:           "A",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
 					},
 					{
-						Name: "B",
-						ID:   MustIDBase16(dashTwoID),
+						Name:           "B",
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
 					},
 				},
 			},
@@ -647,7 +838,7 @@ func DeleteDashboard(
 				}
 			}
 
-			filter := platform.DashboardFilter{}
+			filter := platform.DashboardFilter{OrganizationID: idPtr(MustIDBase16(orgOneID))}
 			dashboards, _, err := s.FindDashboards(ctx, filter)
 			if err != nil {
 				t.Fatalf("failed to retrieve dashboards: %v", err)
@@ -685,12 +876,14 @@ func UpdateDashboard(
 			fields: DashboardFields{
 				Dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 					},
 					{
-						ID:   MustIDBase16(dashTwoID),
-						Name: "dashboard2",
+						ID:             MustIDBase16(dashTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard2",
 					},
 				},
 			},
@@ -700,8 +893,9 @@ func UpdateDashboard(
 			},
 			wants: wants{
 				dashboard: &platform.Dashboard{
-					ID:   MustIDBase16(dashOneID),
-					Name: "changed",
+					ID:             MustIDBase16(dashOneID),
+					OrganizationID: MustIDBase16(orgOneID),
+					Name:           "changed",
 				},
 			},
 		},
@@ -766,8 +960,9 @@ func RemoveDashboardCell(
 				},
 				Dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 						Cells: []*platform.Cell{
 							{
 								ID:     MustIDBase16(dashTwoID),
@@ -795,8 +990,9 @@ func RemoveDashboardCell(
 			wants: wants{
 				dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 						Cells: []*platform.Cell{
 							{
 								ID:     MustIDBase16(dashOneID),
@@ -826,7 +1022,7 @@ func RemoveDashboardCell(
 			}
 			defer s.DeleteDashboard(ctx, tt.args.dashboardID)
 
-			dashboards, _, err := s.FindDashboards(ctx, platform.DashboardFilter{})
+			dashboards, _, err := s.FindDashboards(ctx, platform.DashboardFilter{OrganizationID: idPtr(MustIDBase16(orgOneID))})
 			if err != nil {
 				t.Fatalf("failed to retrieve dashboards: %v", err)
 			}
@@ -868,8 +1064,9 @@ func UpdateDashboardCell(
 				},
 				Dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 						Cells: []*platform.Cell{
 							{
 								ID:     MustIDBase16(dashTwoID),
@@ -894,8 +1091,9 @@ func UpdateDashboardCell(
 			wants: wants{
 				dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 						Cells: []*platform.Cell{
 							{
 								ID:     MustIDBase16(dashTwoID),
@@ -930,7 +1128,7 @@ func UpdateDashboardCell(
 			}
 			defer s.DeleteDashboard(ctx, tt.args.dashboardID)
 
-			dashboards, _, err := s.FindDashboards(ctx, platform.DashboardFilter{})
+			dashboards, _, err := s.FindDashboards(ctx, platform.DashboardFilter{OrganizationID: idPtr(MustIDBase16(orgOneID))})
 			if err != nil {
 				t.Fatalf("failed to retrieve dashboards: %v", err)
 			}
@@ -983,8 +1181,9 @@ func ReplaceDashboardCells(
 				},
 				Dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 						Cells: []*platform.Cell{
 							{
 								ID:     MustIDBase16(dashTwoID),
@@ -1016,8 +1215,9 @@ func ReplaceDashboardCells(
 			wants: wants{
 				dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 						Cells: []*platform.Cell{
 							{
 								ID:     MustIDBase16(dashTwoID),
@@ -1051,8 +1251,9 @@ func ReplaceDashboardCells(
 				},
 				Dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 						Cells: []*platform.Cell{
 							{
 								ID:     MustIDBase16(dashTwoID),
@@ -1081,8 +1282,9 @@ func ReplaceDashboardCells(
 				err: fmt.Errorf("cannot replace cells that were not already present"),
 				dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 						Cells: []*platform.Cell{
 							{
 								ID:     MustIDBase16(dashTwoID),
@@ -1115,8 +1317,9 @@ func ReplaceDashboardCells(
 				},
 				Dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 						Cells: []*platform.Cell{
 							{
 								ID:     MustIDBase16(dashTwoID),
@@ -1140,8 +1343,9 @@ func ReplaceDashboardCells(
 				err: fmt.Errorf("cannot update view id in replace"),
 				dashboards: []*platform.Dashboard{
 					{
-						ID:   MustIDBase16(dashOneID),
-						Name: "dashboard1",
+						ID:             MustIDBase16(dashOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "dashboard1",
 						Cells: []*platform.Cell{
 							{
 								ID:     MustIDBase16(dashTwoID),
@@ -1171,7 +1375,7 @@ func ReplaceDashboardCells(
 			}
 			defer s.DeleteDashboard(ctx, tt.args.dashboardID)
 
-			dashboards, _, err := s.FindDashboards(ctx, platform.DashboardFilter{})
+			dashboards, _, err := s.FindDashboards(ctx, platform.DashboardFilter{OrganizationID: idPtr(MustIDBase16(orgOneID))})
 			if err != nil {
 				t.Fatalf("failed to retrieve dashboards: %v", err)
 			}
@@ -34,8 +34,9 @@ var macroCmpOptions = cmp.Options{
 
 // MacroFields defines fields for a macro test
 type MacroFields struct {
-	Macros      []*platform.Macro
-	IDGenerator platform.IDGenerator
+	Macros               []*platform.Macro
+	UserResourceMappings []*platform.UserResourceMapping
+	IDGenerator          platform.IDGenerator
 }
 
 // MacroService tests all the service functions.
@@ -55,6 +56,10 @@ func MacroService(
 			name: "FindMacroByID",
 			fn:   FindMacroByID,
 		},
+		{
+			name: "FindMacros",
+			fn:   FindMacros,
+		},
 		{
 			name: "UpdateMacro",
 			fn:   UpdateMacro,
@@ -97,9 +102,10 @@ func CreateMacro(init func(MacroFields, *testing.T) (platform.MacroService, func
 				},
 				Macros: []*platform.Macro{
 					{
-						ID:       MustIDBase16(idB),
-						Name:     "existing-macro",
-						Selected: []string{"b"},
+						ID:             MustIDBase16(idB),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "existing_macro",
+						Selected:       []string{"b"},
 						Arguments: &platform.MacroArguments{
 							Type:   "constant",
 							Values: platform.MacroConstantValues{"b"},
@@ -109,9 +115,10 @@ func CreateMacro(init func(MacroFields, *testing.T) (platform.MacroService, func
 			},
 			args: args{
 				macro: &platform.Macro{
-					ID:       MustIDBase16(idA),
-					Name:     "my-macro",
-					Selected: []string{"a"},
+					ID:             MustIDBase16(idA),
+					OrganizationID: MustIDBase16(orgOneID),
+					Name:           "my_macro",
+					Selected:       []string{"a"},
 					Arguments: &platform.MacroArguments{
 						Type:   "constant",
 						Values: platform.MacroConstantValues{"a"},
@@ -122,18 +129,20 @@ func CreateMacro(init func(MacroFields, *testing.T) (platform.MacroService, func
 				err: nil,
 				macros: []*platform.Macro{
 					{
-						ID:       MustIDBase16(idB),
-						Name:     "existing-macro",
-						Selected: []string{"b"},
+						ID:             MustIDBase16(idB),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "existing_macro",
+						Selected:       []string{"b"},
 						Arguments: &platform.MacroArguments{
 							Type:   "constant",
 							Values: platform.MacroConstantValues{"b"},
 						},
 					},
 					{
-						ID:       MustIDBase16(idA),
-						Name:     "my-macro",
-						Selected: []string{"a"},
+						ID:             MustIDBase16(idA),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "my_macro",
+						Selected:       []string{"a"},
 						Arguments: &platform.MacroArguments{
 							Type:   "constant",
 							Values: platform.MacroConstantValues{"a"},
@@ -152,7 +161,7 @@ func CreateMacro(init func(MacroFields, *testing.T) (platform.MacroService, func
 		err := s.CreateMacro(ctx, tt.args.macro)
 		diffErrors(err, tt.wants.err, t)
 
-		macros, err := s.FindMacros(ctx)
+		macros, err := s.FindMacros(ctx, platform.MacroFilter{OrganizationID: idPtr(MustIDBase16(orgOneID))})
 		if err != nil {
 			t.Fatalf("failed to retrieve macros: %v", err)
 		}
@@ -183,16 +192,18 @@ func FindMacroByID(init func(MacroFields, *testing.T) (platform.MacroService, fu
 			fields: MacroFields{
 				Macros: []*platform.Macro{
 					{
-						ID:   MustIDBase16(idA),
-						Name: "existing-macro-a",
+						ID:             MustIDBase16(idA),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "existing_macro_a",
 						Arguments: &platform.MacroArguments{
 							Type:   "constant",
 							Values: platform.MacroConstantValues{},
 						},
 					},
 					{
-						ID:   MustIDBase16(idB),
-						Name: "existing-macro-b",
+						ID:             MustIDBase16(idB),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "existing_macro_b",
 						Arguments: &platform.MacroArguments{
 							Type:   "constant",
 							Values: platform.MacroConstantValues{},
@@ -206,8 +217,9 @@ func FindMacroByID(init func(MacroFields, *testing.T) (platform.MacroService, fu
 			wants: wants{
 				err: nil,
 				macro: &platform.Macro{
-					ID:   MustIDBase16(idB),
-					Name: "existing-macro-b",
+					ID:             MustIDBase16(idB),
+					OrganizationID: MustIDBase16(orgOneID),
+					Name:           "existing_macro_b",
 					Arguments: &platform.MacroArguments{
 						Type:   "constant",
 						Values: platform.MacroConstantValues{},
@@ -244,6 +256,120 @@ func FindMacroByID(init func(MacroFields, *testing.T) (platform.MacroService, fu
 	}
 }
 
+// FindMacros tests platform.MacroService FindMacros interface method
+func FindMacros(init func(MacroFields, *testing.T) (platform.MacroService, func()), t *testing.T) {
+	macroA := &platform.Macro{
+		ID:             MustIDBase16(idA),
+		OrganizationID: MustIDBase16(orgOneID),
+		Name:           "host",
+		Arguments: &platform.MacroArguments{
+			Type:   "constant",
+			Values: platform.MacroConstantValues{},
+		},
+	}
+	macroB := &platform.Macro{
+		ID:             MustIDBase16(idB),
+		OrganizationID: MustIDBase16(orgTwoID),
+		Name:           "host",
+		Arguments: &platform.MacroArguments{
+			Type:   "constant",
+			Values: platform.MacroConstantValues{},
+		},
+	}
+	macroC := &platform.Macro{
+		ID:             MustIDBase16(idC),
+		OrganizationID: MustIDBase16(orgOneID),
+		Name:           "region",
+		Arguments: &platform.MacroArguments{
+			Type:   "constant",
+			Values: platform.MacroConstantValues{},
+		},
+	}
+
+	type args struct {
+		filter platform.MacroFilter
+	}
+	type wants struct {
+		err    error
+		macros []*platform.Macro
+	}
+
+	tests := []struct {
+		name   string
+		fields MacroFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "finding the macros of an organization",
+			fields: MacroFields{
+				Macros: []*platform.Macro{macroA, macroB, macroC},
+			},
+			args: args{
+				filter: platform.MacroFilter{
+					OrganizationID: idPtr(MustIDBase16(orgOneID)),
+				},
+			},
+			wants: wants{
+				macros: []*platform.Macro{macroA, macroC},
+			},
+		},
+		{
+			name: "finding macros by name prefix",
+			fields: MacroFields{
+				Macros: []*platform.Macro{macroA, macroB, macroC},
+			},
+			args: args{
+				filter: platform.MacroFilter{
+					OrganizationID: idPtr(MustIDBase16(orgOneID)),
+					NamePrefix:     strPtr("ho"),
+				},
+			},
+			wants: wants{
+				macros: []*platform.Macro{macroA},
+			},
+		},
+		{
+			name: "finding macros by owner",
+			fields: MacroFields{
+				Macros: []*platform.Macro{macroA, macroB, macroC},
+				UserResourceMappings: []*platform.UserResourceMapping{
+					{
+						ResourceID:   MustIDBase16(idC),
+						ResourceType: platform.MacroResourceType,
+						UserID:       MustIDBase16(userOneID),
+						UserType:     platform.Owner,
+					},
+				},
+			},
+			args: args{
+				filter: platform.MacroFilter{
+					OrganizationID: idPtr(MustIDBase16(orgOneID)),
+					Owner:          idPtr(MustIDBase16(userOneID)),
+				},
+			},
+			wants: wants{
+				macros: []*platform.Macro{macroC},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.TODO()
+
+			macros, err := s.FindMacros(ctx, tt.args.filter)
+			diffErrors(err, tt.wants.err, t)
+
+			if diff := cmp.Diff(macros, tt.wants.macros, macroCmpOptions...); diff != "" {
+				t.Fatalf("found unexpected macros -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
 // UpdateMacro tests platform.MacroService UpdateMacro interface method
 func UpdateMacro(init func(MacroFields, *testing.T) (platform.MacroService, func()), t *testing.T) {
 	type args struct {
@@ -266,16 +392,18 @@ func UpdateMacro(init func(MacroFields, *testing.T) (platform.MacroService, func
 			fields: MacroFields{
 				Macros: []*platform.Macro{
 					{
-						ID:   MustIDBase16(idA),
-						Name: "existing-macro-a",
+						ID:             MustIDBase16(idA),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "existing_macro_a",
 						Arguments: &platform.MacroArguments{
 							Type:   "constant",
 							Values: platform.MacroConstantValues{},
 						},
 					},
 					{
-						ID:   MustIDBase16(idB),
-						Name: "existing-macro-b",
+						ID:             MustIDBase16(idB),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "existing_macro_b",
 						Arguments: &platform.MacroArguments{
 							Type:   "constant",
 							Values: platform.MacroConstantValues{},
@@ -286,23 +414,25 @@ func UpdateMacro(init func(MacroFields, *testing.T) (platform.MacroService, func
 			args: args{
 				id: MustIDBase16(idB),
 				update: &platform.MacroUpdate{
-					Name: "new-macro-b-name",
+					Name: "new_macro_b_name",
 				},
 			},
 			wants: wants{
 				err: nil,
 				macros: []*platform.Macro{
 					{
-						ID:   MustIDBase16(idA),
-						Name: "existing-macro-a",
+						ID:             MustIDBase16(idA),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "existing_macro_a",
 						Arguments: &platform.MacroArguments{
 							Type:   "constant",
 							Values: platform.MacroConstantValues{},
 						},
 					},
 					{
-						ID:   MustIDBase16(idB),
-						Name: "new-macro-b-name",
+						ID:             MustIDBase16(idB),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "new_macro_b_name",
 						Arguments: &platform.MacroArguments{
 							Type:   "constant",
 							Values: platform.MacroConstantValues{},
@@ -344,7 +474,7 @@ func UpdateMacro(init func(MacroFields, *testing.T) (platform.MacroService, func
 				}
 			}
 
-			macros, err := s.FindMacros(ctx)
+			macros, err := s.FindMacros(ctx, platform.MacroFilter{OrganizationID: idPtr(MustIDBase16(orgOneID))})
 			if err != nil {
 				t.Fatalf("failed to retrieve macros: %v", err)
 			}
@@ -376,8 +506,9 @@ func DeleteMacro(init func(MacroFields, *testing.T) (platform.MacroService, func
 			fields: MacroFields{
 				Macros: []*platform.Macro{
 					{
-						ID:   MustIDBase16(idA),
-						Name: "existing-macro",
+						ID:             MustIDBase16(idA),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "existing_macro",
 						Arguments: &platform.MacroArguments{
 							Type:   "constant",
 							Values: platform.MacroConstantValues{},
@@ -398,8 +529,9 @@ func DeleteMacro(init func(MacroFields, *testing.T) (platform.MacroService, func
 			fields: MacroFields{
 				Macros: []*platform.Macro{
 					{
-						ID:   MustIDBase16(idA),
-						Name: "existing-macro",
+						ID:             MustIDBase16(idA),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "existing_macro",
 						Arguments: &platform.MacroArguments{
 							Type:   "constant",
 							Values: platform.MacroConstantValues{},
@@ -414,8 +546,9 @@ func DeleteMacro(init func(MacroFields, *testing.T) (platform.MacroService, func
 				err: kerrors.Errorf(kerrors.NotFound, "macro with ID %s not found", idB),
 				macros: []*platform.Macro{
 					{
-						ID:   MustIDBase16(idA),
-						Name: "existing-macro",
+						ID:             MustIDBase16(idA),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "existing_macro",
 						Arguments: &platform.MacroArguments{
 							Type:   "constant",
 							Values: platform.MacroConstantValues{},
@@ -437,7 +570,7 @@ func DeleteMacro(init func(MacroFields, *testing.T) (platform.MacroService, func
 		})
 		diffErrors(err, tt.wants.err, t)
 
-		macros, err := s.FindMacros(ctx)
+		macros, err := s.FindMacros(ctx, platform.MacroFilter{OrganizationID: idPtr(MustIDBase16(orgOneID))})
 		if err != nil {
 			t.Fatalf("failed to retrieve macros: %v", err)
 		}
@@ -0,0 +1,382 @@
+package testing
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+)
+
+// NotificationEndpointFields includes prepopulated data for mapping tests.
+type NotificationEndpointFields struct {
+	IDGenerator           platform.IDGenerator
+	NotificationEndpoints []*platform.NotificationEndpoint
+}
+
+var notificationEndpointCmpOptions = cmp.Options{
+	cmp.Transformer("Sort", func(in []*platform.NotificationEndpoint) []*platform.NotificationEndpoint {
+		out := append([]*platform.NotificationEndpoint(nil), in...)
+		sort.Slice(out, func(i, j int) bool {
+			return out[i].ID > out[j].ID
+		})
+		return out
+	}),
+}
+
+func newTestNotificationEndpoint(id, orgID string, name string) *platform.NotificationEndpoint {
+	return &platform.NotificationEndpoint{
+		ID:             MustIDBase16(id),
+		OrganizationID: MustIDBase16(orgID),
+		Name:           name,
+		Type:           platform.HTTPEndpointType,
+		URL:            "http://localhost:8080/" + name,
+		Headers:        map[string]string{"Authorization": "Bearer token"},
+	}
+}
+
+// NotificationEndpointService tests all the service functions.
+func NotificationEndpointService(
+	init func(NotificationEndpointFields, *testing.T) (platform.NotificationEndpointService, func()), t *testing.T,
+) {
+	tests := []struct {
+		name string
+		fn   func(init func(NotificationEndpointFields, *testing.T) (platform.NotificationEndpointService, func()),
+			t *testing.T)
+	}{
+		{
+			name: "CreateNotificationEndpoint",
+			fn:   CreateNotificationEndpoint,
+		},
+		{
+			name: "FindNotificationEndpointByID",
+			fn:   FindNotificationEndpointByID,
+		},
+		{
+			name: "FindNotificationEndpoints",
+			fn:   FindNotificationEndpoints,
+		},
+		{
+			name: "UpdateNotificationEndpoint",
+			fn:   UpdateNotificationEndpoint,
+		},
+		{
+			name: "DeleteNotificationEndpoint",
+			fn:   DeleteNotificationEndpoint,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(init, t)
+		})
+	}
+}
+
+// CreateNotificationEndpoint testing.
+func CreateNotificationEndpoint(
+	init func(NotificationEndpointFields, *testing.T) (platform.NotificationEndpointService, func()),
+	t *testing.T,
+) {
+	s, done := init(NotificationEndpointFields{
+		IDGenerator: mock.NewIDGenerator(twoID, t),
+		NotificationEndpoints: []*platform.NotificationEndpoint{
+			newTestNotificationEndpoint(oneID, fourID, "endpoint1"),
+		},
+	}, t)
+	defer done()
+	ctx := context.Background()
+
+	e := newTestNotificationEndpoint(oneID, fourID, "endpoint2")
+	e.ID = 0
+	if err := s.CreateNotificationEndpoint(ctx, e); err != nil {
+		t.Fatalf("expected error to be nil got '%v'", err)
+	}
+	if e.ID != MustIDBase16(twoID) {
+		t.Fatalf("expected notification endpoint ID %s got %s", twoID, e.ID)
+	}
+
+	endpoints, err := s.FindNotificationEndpoints(ctx, platform.NotificationEndpointFilter{})
+	if err != nil {
+		t.Fatalf("failed to retrieve notification endpoints: %v", err)
+	}
+	want := []*platform.NotificationEndpoint{
+		newTestNotificationEndpoint(oneID, fourID, "endpoint1"),
+		newTestNotificationEndpoint(twoID, fourID, "endpoint2"),
+	}
+	if diff := cmp.Diff(endpoints, want, notificationEndpointCmpOptions...); diff != "" {
+		t.Errorf("notification endpoints are different -got/+want\ndiff %s", diff)
+	}
+}
+
+// FindNotificationEndpointByID testing.
+func FindNotificationEndpointByID(
+	init func(NotificationEndpointFields, *testing.T) (platform.NotificationEndpointService, func()),
+	t *testing.T,
+) {
+	type wants struct {
+		err      error
+		endpoint *platform.NotificationEndpoint
+	}
+
+	tests := []struct {
+		name  string
+		id    platform.ID
+		wants wants
+	}{
+		{
+			name: "find notification endpoint by id",
+			id:   MustIDBase16(twoID),
+			wants: wants{
+				endpoint: newTestNotificationEndpoint(twoID, fourID, "endpoint2"),
+			},
+		},
+		{
+			name: "notification endpoint not found",
+			id:   MustIDBase16(threeID),
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.ENotFound,
+				},
+			},
+		},
+		{
+			name: "invalid id",
+			id:   platform.ID(0),
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.EEmptyValue,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(NotificationEndpointFields{
+				NotificationEndpoints: []*platform.NotificationEndpoint{
+					newTestNotificationEndpoint(oneID, fourID, "endpoint1"),
+					newTestNotificationEndpoint(twoID, fourID, "endpoint2"),
+				},
+			}, t)
+			defer done()
+
+			endpoint, err := s.FindNotificationEndpointByID(context.Background(), tt.id)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if diff := cmp.Diff(endpoint, tt.wants.endpoint); diff != "" {
+				t.Errorf("notification endpoint is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindNotificationEndpoints testing.
+func FindNotificationEndpoints(
+	init func(NotificationEndpointFields, *testing.T) (platform.NotificationEndpointService, func()),
+	t *testing.T,
+) {
+	orgID := MustIDBase16(fourID)
+	id := MustIDBase16(twoID)
+
+	tests := []struct {
+		name      string
+		filter    platform.NotificationEndpointFilter
+		endpoints []*platform.NotificationEndpoint
+	}{
+		{
+			name:   "find all notification endpoints",
+			filter: platform.NotificationEndpointFilter{},
+			endpoints: []*platform.NotificationEndpoint{
+				newTestNotificationEndpoint(oneID, fourID, "endpoint1"),
+				newTestNotificationEndpoint(twoID, fourID, "endpoint2"),
+				newTestNotificationEndpoint(threeID, threeID, "endpoint3"),
+			},
+		},
+		{
+			name:   "find notification endpoints by organization",
+			filter: platform.NotificationEndpointFilter{OrganizationID: &orgID},
+			endpoints: []*platform.NotificationEndpoint{
+				newTestNotificationEndpoint(oneID, fourID, "endpoint1"),
+				newTestNotificationEndpoint(twoID, fourID, "endpoint2"),
+			},
+		},
+		{
+			name:   "find notification endpoints by id",
+			filter: platform.NotificationEndpointFilter{ID: &id},
+			endpoints: []*platform.NotificationEndpoint{
+				newTestNotificationEndpoint(twoID, fourID, "endpoint2"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(NotificationEndpointFields{
+				NotificationEndpoints: []*platform.NotificationEndpoint{
+					newTestNotificationEndpoint(oneID, fourID, "endpoint1"),
+					newTestNotificationEndpoint(twoID, fourID, "endpoint2"),
+					newTestNotificationEndpoint(threeID, threeID, "endpoint3"),
+				},
+			}, t)
+			defer done()
+
+			endpoints, err := s.FindNotificationEndpoints(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("expected error to be nil got '%v'", err)
+			}
+			if diff := cmp.Diff(endpoints, tt.endpoints, notificationEndpointCmpOptions...); diff != "" {
+				t.Errorf("notification endpoints are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// UpdateNotificationEndpoint testing.
+func UpdateNotificationEndpoint(
+	init func(NotificationEndpointFields, *testing.T) (platform.NotificationEndpointService, func()),
+	t *testing.T,
+) {
+	url := "https://hooks.example.com/services/1"
+	channel := "#alerts"
+
+	type wants struct {
+		err      error
+		endpoint *platform.NotificationEndpoint
+	}
+
+	tests := []struct {
+		name  string
+		id    platform.ID
+		upd   platform.NotificationEndpointUpdate
+		wants wants
+	}{
+		{
+			name: "update url and channel",
+			id:   MustIDBase16(oneID),
+			upd:  platform.NotificationEndpointUpdate{URL: &url, Channel: &channel},
+			wants: wants{
+				endpoint: func() *platform.NotificationEndpoint {
+					e := newTestNotificationEndpoint(oneID, fourID, "endpoint1")
+					e.URL = url
+					e.Channel = channel
+					return e
+				}(),
+			},
+		},
+		{
+			name: "notification endpoint not found",
+			id:   MustIDBase16(twoID),
+			upd:  platform.NotificationEndpointUpdate{URL: &url},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.ENotFound,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(NotificationEndpointFields{
+				NotificationEndpoints: []*platform.NotificationEndpoint{
+					newTestNotificationEndpoint(oneID, fourID, "endpoint1"),
+				},
+			}, t)
+			defer done()
+			ctx := context.Background()
+
+			endpoint, err := s.UpdateNotificationEndpoint(ctx, tt.id, tt.upd)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil {
+				if platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+				}
+				return
+			}
+			if diff := cmp.Diff(endpoint, tt.wants.endpoint); diff != "" {
+				t.Errorf("notification endpoint is different -got/+want\ndiff %s", diff)
+			}
+
+			found, err := s.FindNotificationEndpointByID(ctx, tt.id)
+			if err != nil {
+				t.Fatalf("failed to retrieve notification endpoint: %v", err)
+			}
+			if diff := cmp.Diff(found, tt.wants.endpoint); diff != "" {
+				t.Errorf("stored notification endpoint is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// DeleteNotificationEndpoint testing.
+func DeleteNotificationEndpoint(
+	init func(NotificationEndpointFields, *testing.T) (platform.NotificationEndpointService, func()),
+	t *testing.T,
+) {
+	type wants struct {
+		err       error
+		endpoints []*platform.NotificationEndpoint
+	}
+
+	tests := []struct {
+		name  string
+		id    platform.ID
+		wants wants
+	}{
+		{
+			name: "delete notification endpoint",
+			id:   MustIDBase16(oneID),
+			wants: wants{
+				endpoints: []*platform.NotificationEndpoint{
+					newTestNotificationEndpoint(twoID, fourID, "endpoint2"),
+				},
+			},
+		},
+		{
+			name: "notification endpoint not found",
+			id:   MustIDBase16(threeID),
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.ENotFound,
+				},
+				endpoints: []*platform.NotificationEndpoint{
+					newTestNotificationEndpoint(oneID, fourID, "endpoint1"),
+					newTestNotificationEndpoint(twoID, fourID, "endpoint2"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(NotificationEndpointFields{
+				NotificationEndpoints: []*platform.NotificationEndpoint{
+					newTestNotificationEndpoint(oneID, fourID, "endpoint1"),
+					newTestNotificationEndpoint(twoID, fourID, "endpoint2"),
+				},
+			}, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.DeleteNotificationEndpoint(ctx, tt.id)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+
+			endpoints, err := s.FindNotificationEndpoints(ctx, platform.NotificationEndpointFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve notification endpoints: %v", err)
+			}
+			if diff := cmp.Diff(endpoints, tt.wants.endpoints, notificationEndpointCmpOptions...); diff != "" {
+				t.Errorf("notification endpoints are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
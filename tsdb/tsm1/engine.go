@@ -586,6 +586,47 @@ func (e *Engine) MeasurementStats() (MeasurementStats, error) {
 	return e.FileStore.MeasurementStats()
 }
 
+// KeyStats describes the values stored under a key in either the TSM files or the cache.
+type KeyStats struct {
+	MinTime, MaxTime int64 // The min and max time of the values.
+	DiskBytes        int64 // The size of the blocks of the key in the TSM files.
+	CacheBytes       int64 // The size of the values of the key in the cache.
+}
+
+// WalkKeyStats calls fn with the stats of every key starting with prefix. A key
+// is passed once for each TSM file holding it and once more if it is in the
+// cache. Deleted values are accounted for until the TSM files are compacted.
+func (e *Engine) WalkKeyStats(prefix []byte, fn func(key []byte, stats KeyStats) error) error {
+	if err := e.FileStore.WalkKeyStats(prefix, fn); err != nil {
+		return err
+	}
+
+	return e.Cache.ApplyEntryFn(func(key []byte, entry *entry) error {
+		if !bytes.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		entry.mu.RLock()
+		values := entry.values
+		var min, max int64 = math.MaxInt64, math.MinInt64
+		for _, v := range values {
+			if t := v.UnixNano(); t < min {
+				min = t
+			}
+			if t := v.UnixNano(); t > max {
+				max = t
+			}
+		}
+		size := values.Size()
+		entry.mu.RUnlock()
+
+		if len(values) == 0 {
+			return nil
+		}
+		return fn(key, KeyStats{MinTime: min, MaxTime: max, CacheBytes: int64(size)})
+	})
+}
+
 // EngineStatistics maintains statistics for the engine.
 type EngineStatistics struct {
 	CacheCompactions        int64 // Counter of cache compactions that have ever run.
@@ -1086,6 +1086,49 @@ func (f *FileStore) MeasurementStats() (MeasurementStats, error) {
 	return stats, nil
 }
 
+// WalkKeyStats calls fn with the time range and size of every key starting with
+// prefix, once for each file holding the key.
+func (f *FileStore) WalkKeyStats(prefix []byte, fn func(key []byte, stats KeyStats) error) error {
+	f.mu.RLock()
+	files := make([]TSMFile, len(f.files))
+	copy(files, f.files)
+	// Ensure files are not unmapped while we're iterating over them.
+	for _, r := range files {
+		r.Ref()
+		defer r.Unref()
+	}
+	f.mu.RUnlock()
+
+	var entries []IndexEntry
+	for _, r := range files {
+		for i := r.Seek(prefix); i < r.KeyCount(); i++ {
+			key, _ := r.KeyAt(i)
+			if !bytes.HasPrefix(key, prefix) {
+				break
+			}
+
+			entries = r.ReadEntries(key, &entries)
+			if len(entries) == 0 {
+				continue
+			}
+			stats := KeyStats{MinTime: math.MaxInt64, MaxTime: math.MinInt64}
+			for _, e := range entries {
+				if e.MinTime < stats.MinTime {
+					stats.MinTime = e.MinTime
+				}
+				if e.MaxTime > stats.MaxTime {
+					stats.MaxTime = e.MaxTime
+				}
+				stats.DiskBytes += int64(e.Size)
+			}
+			if err := fn(key, stats); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // FormatFileNameFunc is executed when generating a new TSM filename.
 // Source filenames are provided via src.
 type FormatFileNameFunc func(generation, sequence int) string
@@ -0,0 +1,21 @@
+package platform
+
+import "context"
+
+// UserIdentity links a user to the account it signs in with at an external identity provider.
+type UserIdentity struct {
+	// Provider is the name of the identity provider, such as github.
+	Provider string `json:"provider"`
+	// Subject identifies the account of the user at the provider.
+	Subject string `json:"subject"`
+	UserID  ID     `json:"userID"`
+}
+
+// UserIdentityService represents a service for managing the external identities of users.
+type UserIdentityService interface {
+	// FindUserIdentity returns the identity of the subject at the provider.
+	FindUserIdentity(ctx context.Context, provider, subject string) (*UserIdentity, error)
+
+	// PutUserIdentity links the identity to its user, replacing any previous link.
+	PutUserIdentity(ctx context.Context, i *UserIdentity) error
+}
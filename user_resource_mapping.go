@@ -19,6 +19,7 @@ const (
 	OrgResourceType       ResourceType = "org"
 	ViewResourceType      ResourceType = "view"
 	TelegrafResourceType  ResourceType = "telegraf"
+	MacroResourceType     ResourceType = "macro"
 )
 
 // UserResourceMappingService maps the relationships between users and resources
@@ -53,7 +54,7 @@ func (m UserResourceMapping) Validate() error {
 		return errors.New("a valid user type is required")
 	}
 	switch m.ResourceType {
-	case DashboardResourceType, BucketResourceType, TaskResourceType, OrgResourceType, ViewResourceType, TelegrafResourceType:
+	case DashboardResourceType, BucketResourceType, TaskResourceType, OrgResourceType, ViewResourceType, TelegrafResourceType, MacroResourceType:
 	default:
 		return fmt.Errorf("a valid resource type is required")
 	}
@@ -52,7 +52,12 @@ type ViewContentsUpdate struct {
 
 // ViewFilter represents a set of filter that restrict the returned results.
 type ViewFilter struct {
-	ID *ID
+	ID             *ID
+	OrganizationID *ID
+	// NamePrefix matches views whose name starts with the prefix.
+	NamePrefix *string
+	// Owner matches views owned by the user.
+	Owner *ID
 }
 
 // View holds positional and visual information for a View.
@@ -63,8 +68,9 @@ type View struct {
 
 // ViewContents is the id and name of a specific view.
 type ViewContents struct {
-	ID   ID     `json:"id,omitempty"`
-	Name string `json:"name"`
+	ID             ID     `json:"id,omitempty"`
+	OrganizationID ID     `json:"organizationID,omitempty"`
+	Name           string `json:"name"`
 }
 
 // ViewProperties is used to mark other structures as conforming to a View.
@@ -406,6 +412,27 @@ func (GaugeViewProperties) viewProperties()          {}
 func (TableViewProperties) viewProperties()          {}
 func (LogViewProperties) viewProperties()            {}
 
+// Queries returns the queries of the view, if its properties have any.
+func (c View) Queries() []DashboardQuery {
+	switch p := c.Properties.(type) {
+	case LineViewProperties:
+		return p.Queries
+	case LinePlusSingleStatProperties:
+		return p.Queries
+	case StepPlotViewProperties:
+		return p.Queries
+	case SingleStatViewProperties:
+		return p.Queries
+	case StackedViewProperties:
+		return p.Queries
+	case GaugeViewProperties:
+		return p.Queries
+	case TableViewProperties:
+		return p.Queries
+	}
+	return nil
+}
+
 /////////////////////////////
 // Old Chronograf Types
 /////////////////////////////